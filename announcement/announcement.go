@@ -0,0 +1,55 @@
+package announcement
+
+import (
+	"time"
+)
+
+// Type is the type of announcement, which determines how it is rendered
+type Type string
+
+var (
+	// TypeInformation is the type of announcement for general information
+	TypeInformation Type = "information"
+
+	// TypeMaintenance is the type of announcement for scheduled or ongoing maintenance
+	TypeMaintenance Type = "maintenance"
+
+	// TypeWarning is the type of announcement for degraded performance
+	TypeWarning Type = "warning"
+
+	// TypeOutage is the type of announcement for outages
+	TypeOutage Type = "outage"
+)
+
+// IsValid returns whether the type is one of the supported types
+func (t Type) IsValid() bool {
+	switch t {
+	case TypeInformation, TypeMaintenance, TypeWarning, TypeOutage:
+		return true
+	}
+	return false
+}
+
+// Announcement is a message displayed as a banner on the dashboard, such as a scheduled maintenance notice or a
+// warning about degraded performance
+type Announcement struct {
+	// ID is the identifier of the announcement, which is set by the store when the announcement is inserted
+	ID int64 `json:"id"`
+
+	// Type is the type of announcement
+	Type Type `json:"type"`
+
+	// Message is what the announcement says
+	Message string `json:"message"`
+
+	// Timestamp is when the announcement was created
+	Timestamp time.Time `json:"timestamp"`
+
+	// ExpiresAt is when the announcement stops being displayed. If nil, it is displayed until it is expired through the API.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// IsActive returns whether the announcement is still displayed at the time passed as parameter
+func (a *Announcement) IsActive(now time.Time) bool {
+	return a.ExpiresAt == nil || now.Before(*a.ExpiresAt)
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+// EndpointAggregates handles requests to retrieve the hourly or daily aggregates that the old results of an endpoint
+// were downsampled into
+//
+// Valid values for :duration -> 365d, 90d, 30d, 7d
+// Valid values for the resolution query parameter -> daily (default), hourly
+func EndpointAggregates(c *fiber.Ctx) error {
+	var from time.Time
+	switch c.Params("duration") {
+	case "365d":
+		from = time.Now().Add(-365 * 24 * time.Hour)
+	case "90d":
+		from = time.Now().Add(-90 * 24 * time.Hour)
+	case "30d":
+		from = time.Now().Add(-30 * 24 * time.Hour)
+	case "7d":
+		from = time.Now().Add(-7 * 24 * time.Hour)
+	default:
+		return c.Status(400).SendString("Durations supported: 365d, 90d, 30d, 7d")
+	}
+	var resolution time.Duration
+	switch c.Query("resolution", "daily") {
+	case "daily":
+		resolution = 24 * time.Hour
+	case "hourly":
+		resolution = time.Hour
+	default:
+		return c.Status(400).SendString("Resolutions supported: daily, hourly")
+	}
+	downsampler, ok := store.Get().(store.Downsampler)
+	if !ok {
+		return c.Status(404).SendString("downsampling is not supported by the configured storage type")
+	}
+	aggregates, err := downsampler.GetAggregatesByKey(c.Params("key"), resolution, from, time.Now())
+	if err != nil {
+		if errors.Is(err, common.ErrEndpointNotFound) {
+			return c.Status(404).SendString(err.Error())
+		} else if errors.Is(err, common.ErrInvalidTimeRange) || errors.Is(err, common.ErrInvalidResolution) {
+			return c.Status(400).SendString(err.Error())
+		}
+		log.Printf("[api.EndpointAggregates] Failed to retrieve aggregates: %s", err.Error())
+		return c.Status(500).SendString(err.Error())
+	}
+	output, err := json.Marshal(aggregates)
+	if err != nil {
+		log.Printf("[api.EndpointAggregates] Unable to marshal object to JSON: %s", err.Error())
+		return c.Status(500).SendString("unable to marshal object to JSON")
+	}
+	c.Set("Content-Type", "application/json")
+	return c.Status(200).Send(output)
+}
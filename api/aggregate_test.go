@@ -0,0 +1,75 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/storage"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/watchdog"
+)
+
+func TestEndpointAggregates(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	cfg := &config.Config{Metrics: true}
+	api := New(cfg)
+	router := api.Router()
+	scenarios := []struct {
+		Name         string
+		Storage      *storage.Config
+		Path         string
+		ExpectedCode int
+	}{
+		{
+			Name:         "unsupported-storage",
+			Storage:      &storage.Config{Type: storage.TypeMemory},
+			Path:         "/api/v1/endpoints/group_name/aggregates/30d",
+			ExpectedCode: http.StatusNotFound,
+		},
+		{
+			Name:         "invalid-duration",
+			Storage:      &storage.Config{Type: storage.TypeMemory},
+			Path:         "/api/v1/endpoints/group_name/aggregates/3d",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "invalid-resolution",
+			Storage:      &storage.Config{Type: storage.TypeMemory},
+			Path:         "/api/v1/endpoints/group_name/aggregates/30d?resolution=minutely",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "endpoint-not-found",
+			Storage:      &storage.Config{Type: storage.TypeSQLite, Path: t.TempDir() + "/TestEndpointAggregates.db"},
+			Path:         "/api/v1/endpoints/nope/aggregates/30d",
+			ExpectedCode: http.StatusNotFound,
+		},
+		{
+			Name:         "hourly",
+			Storage:      &storage.Config{Type: storage.TypeSQLite, Path: t.TempDir() + "/TestEndpointAggregates.db"},
+			Path:         "/api/v1/endpoints/group_name/aggregates/7d?resolution=hourly",
+			ExpectedCode: http.StatusOK,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			if err := store.Initialize(scenario.Storage); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			defer store.Initialize(nil)
+			watchdog.UpdateEndpointStatuses(&testEndpoint, &testSuccessfulResult)
+			request := httptest.NewRequest("GET", scenario.Path, http.NoBody)
+			response, err := router.Test(request)
+			if err != nil {
+				return
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+		})
+	}
+}
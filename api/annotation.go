@@ -0,0 +1,92 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/TwiN/gatus/v5/audit"
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+// CreateAnnotation handles requests to annotate a change, such as a deployment, that affected some or all endpoints
+func CreateAnnotation(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		annotationStore, ok := store.Get().(store.AnnotationStore)
+		if !ok {
+			return c.Status(404).SendString("annotations are not supported by the configured storage type")
+		}
+		annotation := &endpoint.Annotation{}
+		if err := json.Unmarshal(c.Body(), annotation); err != nil {
+			return c.Status(400).SendString("invalid annotation: " + err.Error())
+		}
+		if annotation.Title = strings.TrimSpace(annotation.Title); len(annotation.Title) == 0 {
+			return c.Status(400).SendString("annotation title must not be empty")
+		}
+		if annotation.Timestamp.IsZero() {
+			annotation.Timestamp = time.Now()
+		}
+		hiddenGroups := getHiddenGroups(c, cfg.Security, cfg.Groups)
+		for _, key := range annotation.EndpointKeys {
+			if group, exists := getGroupOfEndpoint(cfg, key); !exists || hiddenGroups[group] {
+				return c.Status(400).SendString("endpoint with key=" + key + " not found")
+			}
+		}
+		if err := annotationStore.InsertAnnotation(annotation); err != nil {
+			log.Printf("[api.CreateAnnotation] Failed to insert annotation in storage: %s", err.Error())
+			return c.Status(500).SendString(err.Error())
+		}
+		store.Audit(audit.NewEntry(audit.ActionAnnotationCreation, getActor(c), strings.Join(annotation.EndpointKeys, ","), true, annotation.Title))
+		output, err := json.Marshal(annotation)
+		if err != nil {
+			log.Printf("[api.CreateAnnotation] Unable to marshal object to JSON: %s", err.Error())
+			return c.Status(500).SendString("unable to marshal object to JSON")
+		}
+		c.Set("Content-Type", "application/json")
+		return c.Status(201).Send(output)
+	}
+}
+
+// EndpointAnnotations handles requests to retrieve the annotations affecting an endpoint, from oldest to newest
+//
+// Valid values for :duration -> 30d, 7d, 24h
+func EndpointAnnotations(c *fiber.Ctx) error {
+	var from time.Time
+	switch c.Params("duration") {
+	case "30d":
+		from = time.Now().Add(-30 * 24 * time.Hour)
+	case "7d":
+		from = time.Now().Add(-7 * 24 * time.Hour)
+	case "24h":
+		from = time.Now().Add(-24 * time.Hour)
+	default:
+		return c.Status(400).SendString("Durations supported: 30d, 7d, 24h")
+	}
+	annotationStore, ok := store.Get().(store.AnnotationStore)
+	if !ok {
+		return c.Status(404).SendString("annotations are not supported by the configured storage type")
+	}
+	annotations, err := annotationStore.GetAnnotationsByKey(c.Params("key"), from, time.Now())
+	if err != nil {
+		if errors.Is(err, common.ErrEndpointNotFound) {
+			return c.Status(404).SendString(err.Error())
+		} else if errors.Is(err, common.ErrInvalidTimeRange) {
+			return c.Status(400).SendString(err.Error())
+		}
+		log.Printf("[api.EndpointAnnotations] Failed to retrieve annotations: %s", err.Error())
+		return c.Status(500).SendString(err.Error())
+	}
+	output, err := json.Marshal(annotations)
+	if err != nil {
+		log.Printf("[api.EndpointAnnotations] Unable to marshal object to JSON: %s", err.Error())
+		return c.Status(500).SendString("unable to marshal object to JSON")
+	}
+	c.Set("Content-Type", "application/json")
+	return c.Status(200).Send(output)
+}
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/watchdog"
+)
+
+func TestCreateAnnotation(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	cfg := &config.Config{
+		Metrics: true,
+		Endpoints: []*endpoint.Endpoint{
+			{Name: "frontend", Group: "core"},
+			{Name: "backend", Group: "core"},
+		},
+	}
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: true, Duration: time.Millisecond, Timestamp: time.Now()})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[1], &endpoint.Result{Success: true, Duration: time.Millisecond, Timestamp: time.Now()})
+	api := New(cfg)
+	router := api.Router()
+	scenarios := []struct {
+		Name         string
+		Method       string
+		Path         string
+		Body         string
+		ExpectedCode int
+	}{
+		{
+			Name:         "create-invalid-json",
+			Method:       "POST",
+			Path:         "/api/v1/annotations",
+			Body:         "{",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "create-without-title",
+			Method:       "POST",
+			Path:         "/api/v1/annotations",
+			Body:         `{"title":" "}`,
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "create-for-unknown-endpoint",
+			Method:       "POST",
+			Path:         "/api/v1/annotations",
+			Body:         `{"title":"Deployed v1.2.3","endpoints":["core_nope"]}`,
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "create-for-every-endpoint",
+			Method:       "POST",
+			Path:         "/api/v1/annotations",
+			Body:         `{"title":"Migrated the database","timestamp":"` + time.Now().Add(-time.Hour).Format(time.RFC3339) + `"}`,
+			ExpectedCode: http.StatusCreated,
+		},
+		{
+			Name:         "create-for-backend",
+			Method:       "POST",
+			Path:         "/api/v1/annotations",
+			Body:         `{"title":"Deployed v1.2.3","description":"Rolled out to 100%","endpoints":["core_backend"]}`,
+			ExpectedCode: http.StatusCreated,
+		},
+		{
+			Name:         "list-with-invalid-duration",
+			Method:       "GET",
+			Path:         "/api/v1/endpoints/core_backend/annotations/3d",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "list-for-unknown-endpoint",
+			Method:       "GET",
+			Path:         "/api/v1/endpoints/core_nope/annotations/24h",
+			ExpectedCode: http.StatusNotFound,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest(scenario.Method, scenario.Path, strings.NewReader(scenario.Body))
+			request.Header.Set("Content-Type", "application/json")
+			response, err := router.Test(request)
+			if err != nil {
+				return
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+		})
+	}
+	t.Run("verify-annotations", func(t *testing.T) {
+		for key, expectedTitles := range map[string][]string{
+			"core_backend":  {"Migrated the database", "Deployed v1.2.3"},
+			"core_frontend": {"Migrated the database"},
+		} {
+			response, err := router.Test(httptest.NewRequest("GET", "/api/v1/endpoints/"+key+"/annotations/24h", http.NoBody))
+			if err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			var annotations []*endpoint.Annotation
+			if err = json.NewDecoder(response.Body).Decode(&annotations); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			response.Body.Close()
+			if len(annotations) != len(expectedTitles) {
+				t.Fatalf("expected %d annotations for %s, got %d", len(expectedTitles), key, len(annotations))
+			}
+			for i, annotation := range annotations {
+				if annotation.Title != expectedTitles[i] {
+					t.Errorf("expected annotation #%d of %s to be %s, got %s", i, key, expectedTitles[i], annotation.Title)
+				}
+			}
+		}
+	})
+	t.Run("verify-chart", func(t *testing.T) {
+		response, err := router.Test(httptest.NewRequest("GET", "/api/v1/endpoints/core_backend/response-times/24h/chart.svg", http.NoBody))
+		if err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+		defer response.Body.Close()
+		body, _ := io.ReadAll(response.Body)
+		if !strings.Contains(string(body), "Deployed v1.2.3") {
+			t.Error("expected annotations to be drawn on the response time chart")
+		}
+	})
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TwiN/gatus/v5/announcement"
+	"github.com/TwiN/gatus/v5/audit"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/gatus/v5/subscription/notifier"
+	"github.com/gofiber/fiber/v2"
+)
+
+// CreateAnnouncement handles requests to create an announcement displayed on the dashboard until it expires
+func CreateAnnouncement(c *fiber.Ctx) error {
+	announcementStore, ok := store.Get().(store.AnnouncementStore)
+	if !ok {
+		return c.Status(404).SendString("announcements are not supported by the configured storage type")
+	}
+	a := &announcement.Announcement{}
+	if err := json.Unmarshal(c.Body(), a); err != nil {
+		return c.Status(400).SendString("invalid announcement: " + err.Error())
+	}
+	if a.Message = strings.TrimSpace(a.Message); len(a.Message) == 0 {
+		return c.Status(400).SendString("announcement message must not be empty")
+	}
+	if len(a.Type) == 0 {
+		a.Type = announcement.TypeInformation
+	} else if !a.Type.IsValid() {
+		return c.Status(400).SendString("invalid announcement type " + string(a.Type) + ", must be one of information, maintenance, warning or outage")
+	}
+	// The ID is set by the store, and the announcement is created now regardless of what was passed
+	a.ID, a.Timestamp = 0, time.Now()
+	if a.ExpiresAt != nil && !a.ExpiresAt.After(a.Timestamp) {
+		return c.Status(400).SendString("announcement expiration must be in the future")
+	}
+	if err := announcementStore.InsertAnnouncement(a); err != nil {
+		log.Printf("[api.CreateAnnouncement] Failed to insert announcement in storage: %s", err.Error())
+		return c.Status(500).SendString(err.Error())
+	}
+	store.Audit(audit.NewEntry(audit.ActionAnnouncementCreation, getActor(c), strconv.FormatInt(a.ID, 10), true, a.Message))
+	notifier.Announce(a)
+	output, err := json.Marshal(a)
+	if err != nil {
+		log.Printf("[api.CreateAnnouncement] Unable to marshal object to JSON: %s", err.Error())
+		return c.Status(500).SendString("unable to marshal object to JSON")
+	}
+	c.Set("Content-Type", "application/json")
+	return c.Status(201).Send(output)
+}
+
+// ExpireAnnouncement handles requests to stop displaying an announcement on the dashboard right away
+func ExpireAnnouncement(c *fiber.Ctx) error {
+	announcementStore, ok := store.Get().(store.AnnouncementStore)
+	if !ok {
+		return c.Status(404).SendString("announcements are not supported by the configured storage type")
+	}
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(400).SendString("invalid announcement id")
+	}
+	if err = announcementStore.ExpireAnnouncement(id, time.Now()); err != nil {
+		if errors.Is(err, common.ErrAnnouncementNotFound) {
+			return c.Status(404).SendString(err.Error())
+		}
+		log.Printf("[api.ExpireAnnouncement] Failed to expire announcement with id=%d: %s", id, err.Error())
+		return c.Status(500).SendString(err.Error())
+	}
+	store.Audit(audit.NewEntry(audit.ActionAnnouncementExpiration, getActor(c), strconv.FormatInt(id, 10), true, ""))
+	return c.Status(200).SendString("")
+}
+
+// ActiveAnnouncements handles requests to retrieve the announcements that haven't expired, from newest to oldest
+func ActiveAnnouncements(c *fiber.Ctx) error {
+	announcementStore, ok := store.Get().(store.AnnouncementStore)
+	if !ok {
+		return c.Status(404).SendString("announcements are not supported by the configured storage type")
+	}
+	announcements, err := announcementStore.GetActiveAnnouncements(time.Now())
+	if err != nil {
+		log.Printf("[api.ActiveAnnouncements] Failed to retrieve announcements: %s", err.Error())
+		return c.Status(500).SendString(err.Error())
+	}
+	output, err := json.Marshal(announcements)
+	if err != nil {
+		log.Printf("[api.ActiveAnnouncements] Unable to marshal object to JSON: %s", err.Error())
+		return c.Status(500).SendString("unable to marshal object to JSON")
+	}
+	c.Set("Content-Type", "application/json")
+	return c.Status(200).Send(output)
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/announcement"
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+func TestCreateAnnouncement(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	router := New(&config.Config{}).Router()
+	scenarios := []struct {
+		Name         string
+		Method       string
+		Path         string
+		Body         string
+		ExpectedCode int
+	}{
+		{
+			Name:         "create-invalid-json",
+			Method:       "POST",
+			Path:         "/api/v1/announcements",
+			Body:         "{",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "create-without-message",
+			Method:       "POST",
+			Path:         "/api/v1/announcements",
+			Body:         `{"message":" "}`,
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "create-with-invalid-type",
+			Method:       "POST",
+			Path:         "/api/v1/announcements",
+			Body:         `{"type":"nope","message":"Hello"}`,
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "create-already-expired",
+			Method:       "POST",
+			Path:         "/api/v1/announcements",
+			Body:         `{"message":"Hello","expiresAt":"` + time.Now().Add(-time.Hour).Format(time.RFC3339) + `"}`,
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "create-maintenance",
+			Method:       "POST",
+			Path:         "/api/v1/announcements",
+			Body:         `{"type":"maintenance","message":"Scheduled maintenance tonight from 22:00 to 23:00 UTC","expiresAt":"` + time.Now().Add(time.Hour).Format(time.RFC3339) + `"}`,
+			ExpectedCode: http.StatusCreated,
+		},
+		{
+			Name:         "create-without-type",
+			Method:       "POST",
+			Path:         "/api/v1/announcements",
+			Body:         `{"message":"Degraded performance of the search"}`,
+			ExpectedCode: http.StatusCreated,
+		},
+		{
+			Name:         "expire-with-invalid-id",
+			Method:       "POST",
+			Path:         "/api/v1/announcements/nope/expire",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "expire-unknown",
+			Method:       "POST",
+			Path:         "/api/v1/announcements/42/expire",
+			ExpectedCode: http.StatusNotFound,
+		},
+		{
+			Name:         "expire-maintenance",
+			Method:       "POST",
+			Path:         "/api/v1/announcements/1/expire",
+			ExpectedCode: http.StatusOK,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest(scenario.Method, scenario.Path, strings.NewReader(scenario.Body))
+			request.Header.Set("Content-Type", "application/json")
+			response, err := router.Test(request)
+			if err != nil {
+				return
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+		})
+	}
+	t.Run("verify-announcements", func(t *testing.T) {
+		response, err := router.Test(httptest.NewRequest("GET", "/api/v1/announcements", http.NoBody))
+		if err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+		defer response.Body.Close()
+		var announcements []*announcement.Announcement
+		if err = json.NewDecoder(response.Body).Decode(&announcements); err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+		if len(announcements) != 1 {
+			t.Fatalf("expected only the announcement that wasn't expired to be active, got %d", len(announcements))
+		}
+		if a := announcements[0]; a.ID != 2 || a.Type != announcement.TypeInformation || a.Message != "Degraded performance of the search" || a.Timestamp.IsZero() {
+			t.Errorf("expected the announcement created without a type to be an information, got %+v", a)
+		}
+	})
+}
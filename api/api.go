@@ -5,16 +5,18 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strings"
 
 	"github.com/TwiN/gatus/v5/config"
 	"github.com/TwiN/gatus/v5/config/web"
+	"github.com/TwiN/gatus/v5/security"
 	static "github.com/TwiN/gatus/v5/web"
-	"github.com/TwiN/health"
 	fiber "github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/gofiber/fiber/v2/middleware/compress"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	fiberfs "github.com/gofiber/fiber/v2/middleware/filesystem"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
 	"github.com/gofiber/fiber/v2/middleware/recover"
 	"github.com/gofiber/fiber/v2/middleware/redirect"
 	"github.com/prometheus/client_golang/prometheus"
@@ -40,15 +42,37 @@ func (a *API) Router() *fiber.App {
 }
 
 func (a *API) createRouter(cfg *config.Config) *fiber.App {
-	app := fiber.New(fiber.Config{
+	fiberConfig := fiber.Config{
 		ErrorHandler: func(c *fiber.Ctx, err error) error {
 			log.Printf("[api.ErrorHandler] %s", err.Error())
 			return fiber.DefaultErrorHandler(c, err)
 		},
 		ReadBufferSize: cfg.Web.ReadBufferSize,
 		Network:        fiber.NetworkTCP,
-	})
-	if os.Getenv("ENVIRONMENT") == "dev" {
+	}
+	if len(cfg.Web.TrustedProxies) > 0 {
+		// The IP of the client, which the rate limit, the IP filter and the audit log rely on, is read from the proxy
+		// header only if the request comes from one of the trusted proxies
+		fiberConfig.ProxyHeader = cfg.Web.ProxyHeader
+		fiberConfig.EnableTrustedProxyCheck = true
+		fiberConfig.TrustedProxies = cfg.Web.TrustedProxies
+		fiberConfig.EnableIPValidation = true
+	}
+	app := fiber.New(fiberConfig)
+	if len(cfg.Web.TrustedProxies) > 0 {
+		app.Use(withClientIP(cfg.Web))
+	}
+	if cfg.Web.IPFilter != nil {
+		app.Use(withIPFilter(cfg.Web.IPFilter, cfg.Security))
+	}
+	if cfg.Web.CORS != nil {
+		app.Use(cors.New(cors.Config{
+			AllowOrigins:     strings.Join(cfg.Web.CORS.AllowedOrigins, ","),
+			AllowMethods:     strings.Join(cfg.Web.CORS.AllowedMethods, ","),
+			AllowHeaders:     strings.Join(cfg.Web.CORS.AllowedHeaders, ","),
+			AllowCredentials: cfg.Web.CORS.AllowCredentials,
+		}))
+	} else if os.Getenv("ENVIRONMENT") == "dev" {
 		app.Use(cors.New(cors.Config{
 			AllowOrigins:     "http://localhost:8081",
 			AllowCredentials: true,
@@ -56,37 +80,97 @@ func (a *API) createRouter(cfg *config.Config) *fiber.App {
 	}
 	// Middlewares
 	app.Use(recover.New())
-	app.Use(compress.New())
+	app.Use(compress.New(compress.Config{
+		// Compressing the live status updates would delay the events until enough of them are buffered
+		Next: func(c *fiber.Ctx) bool {
+			return c.Path() == "/api/v1/endpoints/statuses/stream"
+		},
+	}))
 	// Define metrics handler, if necessary
 	if cfg.Metrics {
 		metricsHandler := promhttp.InstrumentMetricHandler(prometheus.DefaultRegisterer, promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{
 			DisableCompression: true,
 		}))
-		app.Get("/metrics", adaptor.HTTPHandler(metricsHandler))
+		if cfg.MetricsSecurity != nil {
+			app.Get("/metrics", cfg.MetricsSecurity.Middleware(), adaptor.HTTPHandler(metricsHandler))
+		} else {
+			app.Get("/metrics", adaptor.HTTPHandler(metricsHandler))
+		}
+	}
+	// The TLS handshake also accepts the certificates signed by the certificate authority of the metrics, which must
+	// not grant access to anything else
+	if cfg.Web.HasClientAuth() && cfg.MetricsSecurity != nil && len(cfg.MetricsSecurity.ClientCertificateAuthorityFile) > 0 {
+		app.Use(withClientCertificates(cfg.Web.TLS.ClientAuth, cfg.Security))
 	}
 	// Define main router
 	apiRouter := app.Group("/api")
+	if cfg.Web.RateLimit != nil {
+		apiRouter.Use(limiter.New(limiter.Config{
+			Max:        cfg.Web.RateLimit.MaximumRequests,
+			Expiration: cfg.Web.RateLimit.Window,
+			// The IP is copied, because it points to a buffer of fasthttp that is reused by the next requests when it's
+			// read from the header of a trusted proxy, whereas it's kept as the key of the requests of the client
+			KeyGenerator: func(c *fiber.Ctx) string {
+				return strings.Clone(c.IP())
+			},
+			Next: func(c *fiber.Ctx) bool {
+				return cfg.Security != nil && cfg.Security.IsAuthenticated(c)
+			},
+			LimitReached: func(c *fiber.Ctx) error {
+				return c.Status(429).SendString("too many requests, try again later")
+			},
+		}))
+	}
+	cacheControl := cfg.Web.CacheControl
+	if cacheControl == nil {
+		cacheControl = web.GetDefaultConfig().CacheControl
+	}
 	////////////////////////
 	// UNPROTECTED ROUTES //
 	////////////////////////
 	unprotectedAPIRouter := apiRouter.Group("/")
-	unprotectedAPIRouter.Get("/v1/config", ConfigHandler{securityConfig: cfg.Security}.GetConfig)
-	unprotectedAPIRouter.Get("/v1/endpoints/:key/health/badge.svg", HealthBadge)
-	unprotectedAPIRouter.Get("/v1/endpoints/:key/health/badge.shields", HealthBadgeShields)
-	unprotectedAPIRouter.Get("/v1/endpoints/:key/uptimes/:duration/badge.svg", UptimeBadge)
-	unprotectedAPIRouter.Get("/v1/endpoints/:key/response-times/:duration/badge.svg", ResponseTimeBadge(cfg))
-	unprotectedAPIRouter.Get("/v1/endpoints/:key/response-times/:duration/chart.svg", ResponseTimeChart)
+	// The requests about an endpoint or a group that the client isn't allowed to see are rejected as if it didn't exist
+	visibility := withGroupVisibility(cfg)
+	unprotectedAPIRouter.Get("/v1/config", ConfigHandler{securityConfig: cfg.Security, groups: cfg.Groups, groupPages: cfg.GroupPages}.GetConfig)
+	unprotectedAPIRouter.Get("/v1/openapi.json", OpenAPISpecificationJSON)
+	unprotectedAPIRouter.Get("/v1/openapi.yaml", OpenAPISpecificationYAML)
+	if cfg.Web.APIDocs {
+		unprotectedAPIRouter.Get("/docs", APIDocs)
+	}
+	// Badges are embedded in READMEs and status pages, so conditional requests spare sending them again and again
+	badgeCaching := withConditionalRequests(cacheControl.Badges)
+	unprotectedAPIRouter.Get("/v1/endpoints/:key/health/badge.svg", visibility, badgeCaching, HealthBadge(cfg))
+	unprotectedAPIRouter.Get("/v1/endpoints/:key/health/badge.shields", visibility, badgeCaching, HealthBadgeShields(cfg))
+	unprotectedAPIRouter.Get("/v1/endpoints/:key/uptimes/badge.svg", visibility, badgeCaching, UptimeBadge(cfg))
+	unprotectedAPIRouter.Get("/v1/endpoints/:key/uptimes/:duration/badge.svg", visibility, badgeCaching, UptimeBadge(cfg))
+	unprotectedAPIRouter.Get("/v1/endpoints/:key/response-times/:duration/badge.svg", visibility, badgeCaching, ResponseTimeBadge(cfg))
+	unprotectedAPIRouter.Get("/v1/endpoints/:key/response-times/:duration/:percentile/badge.svg", visibility, badgeCaching, ResponseTimePercentileBadge(cfg))
+	unprotectedAPIRouter.Get("/v1/endpoints/:key/slas/:duration/badge.svg", visibility, badgeCaching, SLABadge(cfg))
+	unprotectedAPIRouter.Get("/v1/endpoints/:key/certificate-expiration/badge.svg", visibility, badgeCaching, CertificateExpirationBadge(cfg))
+	unprotectedAPIRouter.Get("/v1/endpoints/:key/response-times/:duration/chart.svg", visibility, ResponseTimeChart)
 	// This endpoint requires authz with bearer token, so technically it is protected
 	unprotectedAPIRouter.Post("/v1/endpoints/:key/external", CreateExternalEndpointResult(cfg))
+	unprotectedAPIRouter.Post("/v1/endpoints/external", CreateExternalEndpointResults(cfg))
+	// These endpoints are opened from the messages sent to the subscribers, who are authenticated by the token instead
+	unprotectedAPIRouter.Get("/v1/subscriptions/confirm", ConfirmSubscription)
+	unprotectedAPIRouter.Get("/v1/subscriptions/unsubscribe", Unsubscribe)
+	unprotectedAPIRouter.Post("/v1/subscriptions/unsubscribe", Unsubscribe)
+	// The users of a tenant are authenticated through the security configuration of the tenant, so TenantAccess
+	// handles the authn of the API of the tenants instead
+	unprotectedAPIRouter.Get("/v1/tenants/:tenant/endpoints/statuses", TenantAccess(cfg), visibility, withConditionalRequests(cacheControl.Statuses), TenantEndpointStatuses(cfg))
+	unprotectedAPIRouter.Get("/v1/tenants/:tenant/endpoints/:key/statuses", TenantAccess(cfg), visibility, withConditionalRequests(cacheControl.Statuses), TenantEndpointStatus(cfg))
+	// The API of the public group pages must be accessible without authn, so GroupPageAccess handles it instead
+	unprotectedAPIRouter.Get("/v1/groups/:group/endpoints/statuses", visibility, GroupPageAccess(cfg), withConditionalRequests(cacheControl.Statuses), GroupEndpointStatuses(cfg))
 	// SPA
 	app.Get("/", SinglePageApplication(cfg.UI))
 	app.Get("/endpoints/:name", SinglePageApplication(cfg.UI))
+	app.Get("/groups/:group", GroupPageApplication(cfg))
+	app.Get("/tenants/:tenant", TenantApplication(cfg))
+	app.Get("/tenants/:tenant/endpoints/:name", TenantApplication(cfg))
+	// Widgets are embedded in other sites through an iframe or a fetch, so they have their own caching and CORS rules
+	app.Get("/widget/:key", withWidgetRules(cfg.Web.Widgets), visibility, withConditionalRequests(cacheControl.Widgets), Widget(cfg))
 	// Health endpoint
-	healthHandler := health.Handler().WithJSON(true)
-	app.Get("/health", func(c *fiber.Ctx) error {
-		statusCode, body := healthHandler.GetResponseStatusCodeAndBody()
-		return c.Status(statusCode).Send(body)
-	})
+	app.Get("/health", Health(cfg))
 	// Everything else falls back on static content
 	app.Use(redirect.New(redirect.Config{
 		Rules: map[string]string{
@@ -108,15 +192,59 @@ func (a *API) createRouter(cfg *config.Config) *fiber.App {
 	//////////////////////
 	// ORDER IS IMPORTANT: all routes applied AFTER the security middleware will require authn
 	protectedAPIRouter := apiRouter.Group("/")
+	var securityMiddleware fiber.Handler
 	if cfg.Security != nil {
 		if err := cfg.Security.RegisterHandlers(app); err != nil {
 			panic(err)
 		}
-		if err := cfg.Security.ApplySecurityMiddleware(protectedAPIRouter); err != nil {
+		var err error
+		if securityMiddleware, err = cfg.Security.Middleware(); err != nil {
+			panic(err)
+		}
+	}
+	// API tokens authenticate the requests bearing them in place of the security middleware, and each protected route
+	// requires the role of the clients allowed to access it
+	protectedAPIRouter.Use(withTokens(cfg.Security, securityMiddleware))
+	viewer := requireRole(cfg.Security, security.RoleViewer)
+	operator := requireRole(cfg.Security, security.RoleOperator)
+	admin := requireRole(cfg.Security, security.RoleAdmin)
+	statusesCaching := withConditionalRequests(cacheControl.Statuses)
+	protectedAPIRouter.Get("/v1/endpoints/statuses", viewer, statusesCaching, EndpointStatuses(cfg))
+	protectedAPIRouter.Get("/v1/endpoints/statuses/stream", viewer, EndpointStatusesStream(cfg))
+	protectedAPIRouter.Get("/v1/endpoints/:key/statuses", viewer, visibility, statusesCaching, EndpointStatus)
+	protectedAPIRouter.Get("/v1/endpoints/:key/aggregates/:duration", viewer, visibility, EndpointAggregates)
+	protectedAPIRouter.Get("/v1/endpoints/:key/uptimes", viewer, visibility, EndpointUptime)
+	protectedAPIRouter.Get("/v1/endpoints/:key/response-times", viewer, visibility, EndpointResponseTimes)
+	protectedAPIRouter.Get("/v1/endpoints/:key/failures", viewer, visibility, EndpointFailureCaptures)
+	protectedAPIRouter.Post("/v1/endpoints/:key/check", operator, visibility, TriggerEndpointCheck(cfg))
+	protectedAPIRouter.Post("/v1/endpoints/:key/pause", operator, visibility, PauseEndpoint(cfg))
+	protectedAPIRouter.Post("/v1/endpoints/:key/resume", operator, visibility, ResumeEndpoint(cfg))
+	protectedAPIRouter.Post("/v1/groups/:group/pause", operator, visibility, PauseGroup(cfg))
+	protectedAPIRouter.Post("/v1/groups/:group/resume", operator, visibility, ResumeGroup(cfg))
+	protectedAPIRouter.Get("/v1/endpoints/:key/annotations/:duration", viewer, visibility, EndpointAnnotations)
+	protectedAPIRouter.Post("/v1/annotations", operator, CreateAnnotation(cfg))
+	protectedAPIRouter.Get("/v1/announcements", viewer, ActiveAnnouncements)
+	protectedAPIRouter.Post("/v1/announcements", operator, CreateAnnouncement)
+	protectedAPIRouter.Post("/v1/announcements/:id/expire", operator, ExpireAnnouncement)
+	protectedAPIRouter.Post("/v1/subscriptions", viewer, Subscribe(cfg))
+	protectedAPIRouter.Get("/v1/reports/sla", viewer, SLAReport(cfg))
+	protectedAPIRouter.Get("/v1/audit", admin, AuditEntries)
+	protectedAPIRouter.Post("/v1/admin/reload", admin, ReloadConfiguration(cfg))
+	protectedAPIRouter.Get("/v1/tokens", admin, Tokens)
+	protectedAPIRouter.Post("/v1/tokens", admin, CreateToken)
+	protectedAPIRouter.Post("/v1/tokens/:id/revoke", admin, RevokeToken)
+	protectedAPIRouter.Get("/v2/status.json", viewer, StatuspageStatus(cfg))
+	protectedAPIRouter.Get("/v2/components.json", viewer, StatuspageComponents(cfg))
+	protectedAPIRouter.Get("/v2/summary.json", viewer, StatuspageSummary(cfg))
+	// The feed is served outside the API so that it can be found at the usual path by feed readers
+	feedRouter := app.Group("/feed.xml")
+	if cfg.Security != nil {
+		if err := cfg.Security.ApplySecurityMiddleware(feedRouter); err != nil {
 			panic(err)
 		}
 	}
-	protectedAPIRouter.Get("/v1/endpoints/statuses", EndpointStatuses(cfg))
-	protectedAPIRouter.Get("/v1/endpoints/:key/statuses", EndpointStatus)
+	feedRouter.Get("/", Feed(cfg))
+	protectedAPIRouter.Get("/graphql", viewer, GraphQL(cfg))
+	protectedAPIRouter.Post("/graphql", viewer, GraphQL(cfg))
 	return app
 }
@@ -4,9 +4,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/TwiN/gatus/v5/config"
 	"github.com/TwiN/gatus/v5/config/ui"
+	"github.com/TwiN/gatus/v5/config/web"
 	"github.com/TwiN/gatus/v5/security"
 	"github.com/gofiber/fiber/v2"
 )
@@ -119,3 +121,144 @@ func TestNew(t *testing.T) {
 		})
 	}
 }
+
+func TestNew_CORS(t *testing.T) {
+	cfg := &config.Config{
+		UI: &ui.Config{},
+		Web: &web.Config{
+			CORS: &web.CORSConfig{
+				AllowedOrigins:   []string{"https://widget.example.org"},
+				AllowedMethods:   []string{"GET"},
+				AllowCredentials: true,
+			},
+		},
+		Security: &security.Config{
+			Basic: &security.BasicConfig{
+				Username:                        "john.doe",
+				PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT",
+			},
+		},
+	}
+	router := New(cfg).Router()
+	scenarios := []struct {
+		Name                  string
+		Method                string
+		Origin                string
+		ExpectedCode          int
+		ExpectedAllowedOrigin string
+	}{
+		{
+			Name:                  "preflight-from-allowed-origin",
+			Method:                "OPTIONS",
+			Origin:                "https://widget.example.org",
+			ExpectedCode:          fiber.StatusNoContent,
+			ExpectedAllowedOrigin: "https://widget.example.org",
+		},
+		{
+			Name:                  "preflight-from-other-origin",
+			Method:                "OPTIONS",
+			Origin:                "https://evil.example.org",
+			ExpectedCode:          fiber.StatusNoContent,
+			ExpectedAllowedOrigin: "",
+		},
+		{
+			Name:                  "request-from-allowed-origin",
+			Method:                "GET",
+			Origin:                "https://widget.example.org",
+			ExpectedCode:          fiber.StatusUnauthorized,
+			ExpectedAllowedOrigin: "https://widget.example.org",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest(scenario.Method, "/api/v1/endpoints/statuses", http.NoBody)
+			request.Header.Set("Origin", scenario.Origin)
+			if scenario.Method == "OPTIONS" {
+				request.Header.Set("Access-Control-Request-Method", "GET")
+			}
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+			if allowedOrigin := response.Header.Get("Access-Control-Allow-Origin"); allowedOrigin != scenario.ExpectedAllowedOrigin {
+				t.Errorf("expected Access-Control-Allow-Origin to be %q, got %q", scenario.ExpectedAllowedOrigin, allowedOrigin)
+			}
+			if len(scenario.ExpectedAllowedOrigin) > 0 && response.Header.Get("Access-Control-Allow-Credentials") != "true" {
+				t.Error("expected Access-Control-Allow-Credentials to be true")
+			}
+		})
+	}
+}
+
+func TestNew_RateLimit(t *testing.T) {
+	cfg := &config.Config{
+		UI:  &ui.Config{},
+		Web: &web.Config{RateLimit: &web.RateLimitConfig{MaximumRequests: 2, Window: time.Minute}},
+		Security: &security.Config{
+			Basic: &security.BasicConfig{
+				Username:                        "john.doe",
+				PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT",
+			},
+		},
+	}
+	router := New(cfg).Router()
+	scenarios := []struct {
+		Name          string
+		Path          string
+		Authenticated bool
+		ExpectedCode  int
+	}{
+		{
+			Name:         "first-request",
+			Path:         "/api/v1/config",
+			ExpectedCode: fiber.StatusOK,
+		},
+		{
+			Name:         "second-request",
+			Path:         "/api/v1/config",
+			ExpectedCode: fiber.StatusOK,
+		},
+		{
+			Name:         "third-request",
+			Path:         "/api/v1/config",
+			ExpectedCode: fiber.StatusTooManyRequests,
+		},
+		{
+			Name:         "other-route",
+			Path:         "/api/v1/endpoints/core_frontend/health/badge.svg",
+			ExpectedCode: fiber.StatusTooManyRequests,
+		},
+		{
+			Name:          "authenticated",
+			Path:          "/api/v1/config",
+			Authenticated: true,
+			ExpectedCode:  fiber.StatusOK,
+		},
+		{
+			Name:         "outside-of-the-api",
+			Path:         "/health",
+			ExpectedCode: fiber.StatusOK,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", scenario.Path, http.NoBody)
+			if scenario.Authenticated {
+				request.SetBasicAuth("john.doe", "hunter2")
+			}
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+			if scenario.ExpectedCode == fiber.StatusTooManyRequests && len(response.Header.Get("Retry-After")) == 0 {
+				t.Error("expected the Retry-After header to be set")
+			}
+		})
+	}
+}
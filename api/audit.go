@@ -0,0 +1,30 @@
+package api
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/gofiber/fiber/v2"
+)
+
+// AuditEntries handles requests to retrieve the audit log of the administrative actions, from newest to oldest
+func AuditEntries(c *fiber.Ctx) error {
+	auditStore, ok := store.Get().(store.AuditStore)
+	if !ok {
+		return c.Status(404).SendString("the audit log is not supported by the configured storage type")
+	}
+	page, pageSize := extractPageAndPageSizeFromRequest(c)
+	entries, err := auditStore.GetAuditEntries(page, pageSize)
+	if err != nil {
+		log.Printf("[api.AuditEntries] Failed to retrieve audit entries: %s", err.Error())
+		return c.Status(500).SendString(err.Error())
+	}
+	output, err := json.Marshal(entries)
+	if err != nil {
+		log.Printf("[api.AuditEntries] Unable to marshal object to JSON: %s", err.Error())
+		return c.Status(500).SendString("unable to marshal object to JSON")
+	}
+	c.Set("Content-Type", "application/json")
+	return c.Status(200).Send(output)
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/security"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/token"
+)
+
+func TestSecurityAudit(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	cfg := &config.Config{
+		Security: &security.Config{
+			Basic: &security.BasicConfig{
+				Username:                        "john.doe",
+				PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT",
+			},
+			DefaultRole: security.RoleViewer,
+			Audit:       &security.AuditConfig{Store: true},
+		},
+		Endpoints: []*endpoint.Endpoint{{Name: "frontend", Group: "core", URL: "https://example.org"}},
+	}
+	router := New(cfg).Router()
+	secret, hash, err := token.Generate()
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if err = store.Get().(store.TokenStore).InsertToken(&token.Token{Name: "dashboard", Scopes: []token.Scope{token.ScopeReadStatuses}, Hash: hash, Timestamp: time.Now()}); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	scenarios := []struct {
+		Name         string
+		Method       string
+		Path         string
+		Username     string
+		Password     string
+		BearerToken  string
+		ExpectedCode int
+	}{
+		{
+			Name:         "invalid-credentials",
+			Method:       "GET",
+			Path:         "/api/v1/endpoints/statuses",
+			Username:     "john.doe",
+			Password:     "hunter3",
+			ExpectedCode: http.StatusUnauthorized,
+		},
+		{
+			Name:         "viewer-checking-endpoint",
+			Method:       "POST",
+			Path:         "/api/v1/endpoints/core_frontend/check",
+			Username:     "john.doe",
+			Password:     "hunter2",
+			ExpectedCode: http.StatusForbidden,
+		},
+		{
+			Name:         "token-reading-statuses",
+			Method:       "GET",
+			Path:         "/api/v1/endpoints/statuses",
+			BearerToken:  secret,
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "invalid-token",
+			Method:       "GET",
+			Path:         "/api/v1/endpoints/statuses",
+			BearerToken:  token.Prefix + "invalid",
+			ExpectedCode: http.StatusUnauthorized,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest(scenario.Method, scenario.Path, http.NoBody)
+			if len(scenario.Username) > 0 {
+				request.SetBasicAuth(scenario.Username, scenario.Password)
+			}
+			if len(scenario.BearerToken) > 0 {
+				request.Header.Set("Authorization", "Bearer "+scenario.BearerToken)
+			}
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+		})
+	}
+	entries, err := store.Get().(store.AuditStore).GetAuditEntries(1, 100)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	numberOfEntriesByAction := make(map[string]int)
+	for _, entry := range entries {
+		numberOfEntriesByAction[string(entry.Action)]++
+		if entry.Action == "ACCESS_DENIAL" && entry.Target != "POST /api/v1/endpoints/core_frontend/check" {
+			t.Errorf("expected the denial to target the request denied, got %+v", entry)
+		}
+		if entry.Action == "AUTHENTICATION_FAILURE" && entry.Target != "john.doe" {
+			t.Errorf("expected the failure to target the user, got %+v", entry)
+		}
+	}
+	if numberOfEntriesByAction["AUTHENTICATION_FAILURE"] != 1 {
+		t.Errorf("expected 1 failed authentication, got %d", numberOfEntriesByAction["AUTHENTICATION_FAILURE"])
+	}
+	if numberOfEntriesByAction["ACCESS_DENIAL"] != 1 {
+		t.Errorf("expected 1 access denial, got %d", numberOfEntriesByAction["ACCESS_DENIAL"])
+	}
+	if numberOfEntriesByAction["TOKEN_USAGE"] != 2 {
+		t.Errorf("expected 2 token usages, got %d", numberOfEntriesByAction["TOKEN_USAGE"])
+	}
+}
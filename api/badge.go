@@ -4,6 +4,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"html"
+	"math"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -35,46 +38,82 @@ var (
 	badgeColors = []string{badgeColorHexAwesome, badgeColorHexGreat, badgeColorHexGood, badgeColorHexPassable, badgeColorHexBad}
 )
 
+// Levels of the badges, each of which is the index of a color in badgeOptions.colors
+const (
+	badgeLevelAwesome = iota
+	badgeLevelGreat
+	badgeLevelGood
+	badgeLevelPassable
+	badgeLevelBad
+	badgeLevelVeryBad
+)
+
+// badgeOptions are the options used to generate a badge, which come from the badge configuration of the endpoint and
+// can be overridden by the query parameters of the request
+type badgeOptions struct {
+	label                  string    // Replaces the label of the badge if not empty
+	style                  string    // One of the ui.BadgeStyle* constants. Empty means ui.BadgeStyleFlat.
+	colors                 []string  // Color of each badge level, from badgeLevelAwesome to badgeLevelVeryBad
+	uptimeThresholds       []float64 // Uptimes from 0 to 1, in descending order, at which the badge changes level
+	responseTimeThresholds []int     // Response times in milliseconds, in ascending order, at which the badge changes level
+	slaTarget              float64   // Uptime from 0 to 1 that must be reached for the SLA to be met
+}
+
 // UptimeBadge handles the automatic generation of badge based on the group name and endpoint name passed.
 //
-// Valid values for :duration -> 7d, 24h, 1h
-func UptimeBadge(c *fiber.Ctx) error {
-	duration := c.Params("duration")
-	var from time.Time
-	switch duration {
-	case "7d":
-		from = time.Now().Add(-7 * 24 * time.Hour)
-	case "24h":
-		from = time.Now().Add(-24 * time.Hour)
-	case "1h":
-		from = time.Now().Add(-2 * time.Hour) // Because uptime metrics are stored by hour, we have to cheat a little
-	default:
-		return c.Status(400).SendString("Durations supported: 7d, 24h, 1h")
-	}
-	key := c.Params("key")
-	uptime, err := store.Get().GetUptimeByKey(key, from, time.Now())
-	if err != nil {
-		if errors.Is(err, common.ErrEndpointNotFound) {
-			return c.Status(404).SendString(err.Error())
-		} else if errors.Is(err, common.ErrInvalidTimeRange) {
+// The :duration is a number of hours, days or weeks of at most 365d, e.g. 1h, 24h, 14d or 2w. If the route has no
+// :duration, the time range is described by either the duration query parameter, or the from and to query parameters,
+// which are RFC3339 timestamps.
+func UptimeBadge(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var from, to time.Time
+		var err error
+		duration := c.Params("duration")
+		if len(duration) > 0 {
+			to = time.Now()
+			from, err = getUptimeStartTimeFromDuration(duration, to)
+		} else {
+			from, to, duration, err = getUptimeTimeRange(c, time.Now())
+		}
+		if err != nil {
 			return c.Status(400).SendString(err.Error())
 		}
-		return c.Status(500).SendString(err.Error())
+		key := c.Params("key")
+		options, err := getBadgeOptions(c, key, cfg)
+		if err == nil {
+			err = options.setUptimeThresholds(c.Query("thresholds"))
+		}
+		if err != nil {
+			return c.Status(400).SendString(err.Error())
+		}
+		uptime, err := store.Get().GetUptimeByKey(key, from, to)
+		if err != nil {
+			if errors.Is(err, common.ErrEndpointNotFound) {
+				return c.Status(404).SendString(err.Error())
+			} else if errors.Is(err, common.ErrInvalidTimeRange) {
+				return c.Status(400).SendString(err.Error())
+			}
+			return c.Status(500).SendString(err.Error())
+		}
+		c.Set("Content-Type", "image/svg+xml")
+		return c.Status(200).Send(generateUptimeBadgeSVG(duration, uptime, options))
 	}
-	c.Set("Content-Type", "image/svg+xml")
-	c.Set("Cache-Control", "no-cache, no-store, must-revalidate")
-	c.Set("Expires", "0")
-	return c.Status(200).Send(generateUptimeBadgeSVG(duration, uptime))
 }
 
 // ResponseTimeBadge handles the automatic generation of badge based on the group name and endpoint name passed.
 //
-// Valid values for :duration -> 7d, 24h, 1h
+// Valid values for :duration -> 365d, 90d, 30d, 7d, 24h, 1h
 func ResponseTimeBadge(cfg *config.Config) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		duration := c.Params("duration")
 		var from time.Time
 		switch duration {
+		case "365d":
+			from = time.Now().Add(-365 * 24 * time.Hour)
+		case "90d":
+			from = time.Now().Add(-90 * 24 * time.Hour)
+		case "30d":
+			from = time.Now().Add(-30 * 24 * time.Hour)
 		case "7d":
 			from = time.Now().Add(-7 * 24 * time.Hour)
 		case "24h":
@@ -82,9 +121,16 @@ func ResponseTimeBadge(cfg *config.Config) fiber.Handler {
 		case "1h":
 			from = time.Now().Add(-2 * time.Hour) // Because response time metrics are stored by hour, we have to cheat a little
 		default:
-			return c.Status(400).SendString("Durations supported: 7d, 24h, 1h")
+			return c.Status(400).SendString("Durations supported: 365d, 90d, 30d, 7d, 24h, 1h")
 		}
 		key := c.Params("key")
+		options, err := getBadgeOptions(c, key, cfg)
+		if err == nil {
+			err = options.setResponseTimeThresholds(c.Query("thresholds"))
+		}
+		if err != nil {
+			return c.Status(400).SendString(err.Error())
+		}
 		averageResponseTime, err := store.Get().GetAverageResponseTimeByKey(key, from, time.Now())
 		if err != nil {
 			if errors.Is(err, common.ErrEndpointNotFound) {
@@ -95,138 +141,386 @@ func ResponseTimeBadge(cfg *config.Config) fiber.Handler {
 			return c.Status(500).SendString(err.Error())
 		}
 		c.Set("Content-Type", "image/svg+xml")
-		c.Set("Cache-Control", "no-cache, no-store, must-revalidate")
-		c.Set("Expires", "0")
-		return c.Status(200).Send(generateResponseTimeBadgeSVG(duration, averageResponseTime, key, cfg))
+		return c.Status(200).Send(generateResponseTimeBadgeSVG(duration, averageResponseTime, options))
 	}
 }
 
 // HealthBadge handles the automatic generation of badge based on the group name and endpoint name passed.
-func HealthBadge(c *fiber.Ctx) error {
-	key := c.Params("key")
-	pagingConfig := paging.NewEndpointStatusParams()
-	status, err := store.Get().GetEndpointStatusByKey(key, pagingConfig.WithResults(1, 1))
-	if err != nil {
-		if errors.Is(err, common.ErrEndpointNotFound) {
-			return c.Status(404).SendString(err.Error())
-		} else if errors.Is(err, common.ErrInvalidTimeRange) {
+func HealthBadge(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Params("key")
+		options, err := getBadgeOptions(c, key, cfg)
+		if err != nil {
 			return c.Status(400).SendString(err.Error())
 		}
-		return c.Status(500).SendString(err.Error())
+		pagingConfig := paging.NewEndpointStatusParams()
+		status, err := store.Get().GetEndpointStatusByKey(key, pagingConfig.WithResults(1, 1))
+		if err != nil {
+			if errors.Is(err, common.ErrEndpointNotFound) {
+				return c.Status(404).SendString(err.Error())
+			} else if errors.Is(err, common.ErrInvalidTimeRange) {
+				return c.Status(400).SendString(err.Error())
+			}
+			return c.Status(500).SendString(err.Error())
+		}
+		healthStatus := HealthStatusUnknown
+		if len(status.Results) > 0 {
+			if status.Results[0].Success {
+				healthStatus = HealthStatusUp
+			} else {
+				healthStatus = HealthStatusDown
+			}
+		}
+		c.Set("Content-Type", "image/svg+xml")
+		return c.Status(200).Send(generateHealthBadgeSVG(healthStatus, options))
 	}
-	healthStatus := HealthStatusUnknown
-	if len(status.Results) > 0 {
-		if status.Results[0].Success {
-			healthStatus = HealthStatusUp
-		} else {
-			healthStatus = HealthStatusDown
+}
+
+func HealthBadgeShields(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Params("key")
+		options, err := getBadgeOptions(c, key, cfg)
+		if err != nil {
+			return c.Status(400).SendString(err.Error())
+		}
+		pagingConfig := paging.NewEndpointStatusParams()
+		status, err := store.Get().GetEndpointStatusByKey(key, pagingConfig.WithResults(1, 1))
+		if err != nil {
+			if errors.Is(err, common.ErrEndpointNotFound) {
+				return c.Status(404).SendString(err.Error())
+			} else if errors.Is(err, common.ErrInvalidTimeRange) {
+				return c.Status(400).SendString(err.Error())
+			}
+			return c.Status(500).SendString(err.Error())
 		}
+		healthStatus := HealthStatusUnknown
+		if len(status.Results) > 0 {
+			if status.Results[0].Success {
+				healthStatus = HealthStatusUp
+			} else {
+				healthStatus = HealthStatusDown
+			}
+		}
+		c.Set("Content-Type", "application/json")
+		jsonData, err := generateHealthBadgeShields(healthStatus, options)
+		if err != nil {
+			return c.Status(500).SendString(err.Error())
+		}
+		return c.Status(200).Send(jsonData)
 	}
-	c.Set("Content-Type", "image/svg+xml")
-	c.Set("Cache-Control", "no-cache, no-store, must-revalidate")
-	c.Set("Expires", "0")
-	return c.Status(200).Send(generateHealthBadgeSVG(healthStatus))
 }
 
-func HealthBadgeShields(c *fiber.Ctx) error {
-	key := c.Params("key")
-	pagingConfig := paging.NewEndpointStatusParams()
-	status, err := store.Get().GetEndpointStatusByKey(key, pagingConfig.WithResults(1, 1))
-	if err != nil {
-		if errors.Is(err, common.ErrEndpointNotFound) {
-			return c.Status(404).SendString(err.Error())
-		} else if errors.Is(err, common.ErrInvalidTimeRange) {
+// SLABadge handles the automatic generation of badge showing whether the uptime of the endpoint whose key is passed
+// met the target of its SLA.
+//
+// Valid values for :duration -> 365d, 90d, 30d, 7d, 24h, 1h
+func SLABadge(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		duration := c.Params("duration")
+		from, ok := getBadgeStartTimeFromDuration(duration)
+		if !ok {
+			return c.Status(400).SendString("Durations supported: 365d, 90d, 30d, 7d, 24h, 1h")
+		}
+		key := c.Params("key")
+		options, err := getBadgeOptions(c, key, cfg)
+		if err == nil {
+			err = options.setSLATarget(c.Query("target"))
+		}
+		if err != nil {
 			return c.Status(400).SendString(err.Error())
 		}
-		return c.Status(500).SendString(err.Error())
+		uptime, err := store.Get().GetUptimeByKey(key, from, time.Now())
+		if err != nil {
+			if errors.Is(err, common.ErrEndpointNotFound) {
+				return c.Status(404).SendString(err.Error())
+			} else if errors.Is(err, common.ErrInvalidTimeRange) {
+				return c.Status(400).SendString(err.Error())
+			}
+			return c.Status(500).SendString(err.Error())
+		}
+		c.Set("Content-Type", "image/svg+xml")
+		return c.Status(200).Send(generateSLABadgeSVG(duration, uptime, options))
 	}
-	healthStatus := HealthStatusUnknown
-	if len(status.Results) > 0 {
-		if status.Results[0].Success {
-			healthStatus = HealthStatusUp
-		} else {
-			healthStatus = HealthStatusDown
+}
+
+// ResponseTimePercentileBadge handles the automatic generation of badge showing a percentile of the response time of
+// the endpoint whose key is passed. The percentile is computed from the results of the endpoint that are still stored.
+//
+// Valid values for :duration -> 365d, 90d, 30d, 7d, 24h, 1h
+// Valid values for :percentile -> p95, p99
+func ResponseTimePercentileBadge(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		duration := c.Params("duration")
+		from, ok := getBadgeStartTimeFromDuration(duration)
+		if !ok {
+			return c.Status(400).SendString("Durations supported: 365d, 90d, 30d, 7d, 24h, 1h")
+		}
+		if duration == "1h" {
+			// Unlike uptime metrics, results are not stored by hour, so there's no need to cheat
+			from = time.Now().Add(-time.Hour)
+		}
+		percentile := c.Params("percentile")
+		var p float64
+		switch percentile {
+		case "p95":
+			p = 0.95
+		case "p99":
+			p = 0.99
+		default:
+			return c.Status(400).SendString("Percentiles supported: p95, p99")
+		}
+		key := c.Params("key")
+		options, err := getBadgeOptions(c, key, cfg)
+		if err == nil {
+			err = options.setResponseTimeThresholds(c.Query("thresholds"))
+		}
+		if err != nil {
+			return c.Status(400).SendString(err.Error())
+		}
+		status, err := store.Get().GetEndpointStatusByKey(key, paging.NewEndpointStatusParams().WithResults(1, common.MaximumNumberOfResults))
+		if err != nil {
+			if errors.Is(err, common.ErrEndpointNotFound) {
+				return c.Status(404).SendString(err.Error())
+			} else if errors.Is(err, common.ErrInvalidTimeRange) {
+				return c.Status(400).SendString(err.Error())
+			}
+			return c.Status(500).SendString(err.Error())
+		}
+		var responseTimes []int
+		for _, result := range status.Results {
+			if !result.Timestamp.Before(from) {
+				responseTimes = append(responseTimes, int(result.Duration.Milliseconds()))
+			}
 		}
+		label := percentile + " response time " + duration
+		c.Set("Content-Type", "image/svg+xml")
+		if len(responseTimes) == 0 {
+			return c.Status(200).Send(generateBadgeSVG(label, getBadgeTextWidth(label), HealthStatusUnknown, getBadgeTextWidth(HealthStatusUnknown), options.colors[badgeLevelPassable], options))
+		}
+		responseTime := getResponseTimePercentile(responseTimes, p)
+		value := strconv.Itoa(responseTime) + "ms"
+		return c.Status(200).Send(generateBadgeSVG(label, getBadgeTextWidth(label), value, getBadgeTextWidth(value), getBadgeColorFromResponseTime(responseTime, options), options))
 	}
-	c.Set("Content-Type", "application/json")
-	c.Set("Cache-Control", "no-cache, no-store, must-revalidate")
-	c.Set("Expires", "0")
-	jsonData, err := generateHealthBadgeShields(healthStatus)
-	if err != nil {
-		return c.Status(500).SendString(err.Error())
+}
+
+// CertificateExpirationBadge handles the automatic generation of badge showing the number of days left before the
+// certificate of the endpoint whose key is passed expires, as of its most recent result that has a certificate.
+func CertificateExpirationBadge(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Params("key")
+		options, err := getBadgeOptions(c, key, cfg)
+		if err != nil {
+			return c.Status(400).SendString(err.Error())
+		}
+		status, err := store.Get().GetEndpointStatusByKey(key, paging.NewEndpointStatusParams().WithResults(1, common.MaximumNumberOfResults))
+		if err != nil {
+			if errors.Is(err, common.ErrEndpointNotFound) {
+				return c.Status(404).SendString(err.Error())
+			} else if errors.Is(err, common.ErrInvalidTimeRange) {
+				return c.Status(400).SendString(err.Error())
+			}
+			return c.Status(500).SendString(err.Error())
+		}
+		value, color := HealthStatusUnknown, options.colors[badgeLevelPassable]
+		// Results are sorted from oldest to newest
+		for i := len(status.Results) - 1; i >= 0; i-- {
+			if status.Results[i].CertificateExpiration != 0 {
+				timeLeft := status.Results[i].CertificateExpiration - time.Since(status.Results[i].Timestamp)
+				value, color = formatCertificateExpiration(timeLeft), getBadgeColorFromCertificateExpiration(timeLeft, options)
+				break
+			}
+		}
+		c.Set("Content-Type", "image/svg+xml")
+		return c.Status(200).Send(generateBadgeSVG("certificate", getBadgeTextWidth("certificate"), value, getBadgeTextWidth(value), color, options))
 	}
-	return c.Status(200).Send(jsonData)
 }
 
-func generateUptimeBadgeSVG(duration string, uptime float64) []byte {
-	var labelWidth, valueWidth, valueWidthAdjustment int
+// getBadgeStartTimeFromDuration returns the start of the time range of a badge, and whether the duration is supported
+func getBadgeStartTimeFromDuration(duration string) (time.Time, bool) {
 	switch duration {
+	case "365d":
+		return time.Now().Add(-365 * 24 * time.Hour), true
+	case "90d":
+		return time.Now().Add(-90 * 24 * time.Hour), true
+	case "30d":
+		return time.Now().Add(-30 * 24 * time.Hour), true
 	case "7d":
-		labelWidth = 65
+		return time.Now().Add(-7 * 24 * time.Hour), true
 	case "24h":
-		labelWidth = 70
+		return time.Now().Add(-24 * time.Hour), true
 	case "1h":
-		labelWidth = 65
+		return time.Now().Add(-2 * time.Hour), true // Because uptime metrics are stored by hour, we have to cheat a little
 	default:
+		return time.Time{}, false
+	}
+}
+
+// getBadgeOptions returns the options of the badge of the endpoint whose key is passed, with the label, style and colors
+// overridden by the query parameters of the same name, if any.
+//
+// The colors query parameter is a comma-separated list of 6 hexadecimal colors, with or without the leading #.
+func getBadgeOptions(c *fiber.Ctx, key string, cfg *config.Config) (*badgeOptions, error) {
+	options := newBadgeOptions(key, cfg)
+	options.label = c.Query("label")
+	if style := c.Query("style"); len(style) > 0 {
+		if !ui.IsValidBadgeStyle(style) {
+			return nil, ui.ErrInvalidBadgeStyle
+		}
+		options.style = style
+	}
+	if colorsParameter := c.Query("colors"); len(colorsParameter) > 0 {
+		colors := strings.Split(colorsParameter, ",")
+		for i, color := range colors {
+			if color = strings.TrimSpace(color); !strings.HasPrefix(color, "#") {
+				color = "#" + color
+			}
+			colors[i] = color
+		}
+		if !ui.AreValidBadgeColors(colors) {
+			return nil, ui.ErrInvalidBadgeColorsConfig
+		}
+		options.colors = colors
+	}
+	return options, nil
+}
+
+// newBadgeOptions returns the options from the badge configuration of the endpoint whose key is passed, or the default
+// options if there's no such endpoint
+func newBadgeOptions(key string, cfg *config.Config) *badgeOptions {
+	badgeConfig := ui.GetDefaultConfig().Badge
+	options := &badgeOptions{
+		colors:                 append(append([]string{}, badgeColors...), badgeColorHexVeryBad),
+		responseTimeThresholds: badgeConfig.ResponseTime.Thresholds,
+		slaTarget:              badgeConfig.SLA.Target / 100,
 	}
-	color := getBadgeColorFromUptime(uptime)
+	for _, threshold := range badgeConfig.Uptime.Thresholds {
+		options.uptimeThresholds = append(options.uptimeThresholds, threshold/100)
+	}
+	endpoint := cfg.GetEndpointByKey(key)
+	if endpoint == nil || endpoint.UIConfig == nil || endpoint.UIConfig.Badge == nil {
+		return options
+	}
+	badgeConfig = endpoint.UIConfig.Badge
+	if badgeConfig.ResponseTime != nil {
+		options.responseTimeThresholds = badgeConfig.ResponseTime.Thresholds
+	}
+	if badgeConfig.Uptime != nil {
+		options.uptimeThresholds = nil
+		for _, threshold := range badgeConfig.Uptime.Thresholds {
+			options.uptimeThresholds = append(options.uptimeThresholds, threshold/100)
+		}
+	}
+	if badgeConfig.SLA != nil {
+		options.slaTarget = badgeConfig.SLA.Target / 100
+	}
+	options.style = badgeConfig.Style
+	if len(badgeConfig.Colors) > 0 {
+		options.colors = badgeConfig.Colors
+	}
+	return options
+}
+
+// setUptimeThresholds replaces the uptime thresholds by those of the comma-separated list of 5 descending percentages
+// passed, if it isn't empty
+func (options *badgeOptions) setUptimeThresholds(value string) error {
+	if len(value) == 0 {
+		return nil
+	}
+	thresholds, err := parseBadgeThresholds(value)
+	if err != nil {
+		return ui.ErrInvalidBadgeUptimeConfig
+	}
+	if err = (&ui.Uptime{Thresholds: thresholds}).Validate(); err != nil {
+		return err
+	}
+	options.uptimeThresholds = nil
+	for _, threshold := range thresholds {
+		options.uptimeThresholds = append(options.uptimeThresholds, threshold/100)
+	}
+	return nil
+}
+
+// setResponseTimeThresholds replaces the response time thresholds by those of the comma-separated list of 5 ascending
+// values in milliseconds passed, if it isn't empty
+func (options *badgeOptions) setResponseTimeThresholds(value string) error {
+	if len(value) == 0 {
+		return nil
+	}
+	parsedThresholds, err := parseBadgeThresholds(value)
+	if err != nil {
+		return ui.ErrInvalidBadgeResponseTimeConfig
+	}
+	responseTime := &ui.ResponseTime{}
+	for _, threshold := range parsedThresholds {
+		responseTime.Thresholds = append(responseTime.Thresholds, int(threshold))
+	}
+	if err = responseTime.Validate(); err != nil {
+		return err
+	}
+	options.responseTimeThresholds = responseTime.Thresholds
+	return nil
+}
+
+// setSLATarget replaces the SLA target by the percentage passed, if it isn't empty
+func (options *badgeOptions) setSLATarget(value string) error {
+	if len(value) == 0 {
+		return nil
+	}
+	target, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return ui.ErrInvalidBadgeSLAConfig
+	}
+	if err = (&ui.SLA{Target: target}).Validate(); err != nil {
+		return err
+	}
+	options.slaTarget = target / 100
+	return nil
+}
+
+func parseBadgeThresholds(value string) ([]float64, error) {
+	var thresholds []float64
+	for _, threshold := range strings.Split(value, ",") {
+		parsedThreshold, err := strconv.ParseFloat(strings.TrimSpace(threshold), 64)
+		if err != nil {
+			return nil, err
+		}
+		thresholds = append(thresholds, parsedThreshold)
+	}
+	return thresholds, nil
+}
+
+// generateUptimeBadgeSVG generates the SVG of an uptime badge. The duration is omitted from the label if it's empty,
+// which is the case when the time range was passed as timestamps.
+func generateUptimeBadgeSVG(duration string, uptime float64, options *badgeOptions) []byte {
+	var valueWidth, valueWidthAdjustment int
+	label := strings.TrimSpace("uptime " + duration)
+	labelWidth := len(label)*5 + 20
+	color := getBadgeColorFromUptime(uptime, options)
 	sanitizedValue := strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.2f", uptime*100), "0"), ".") + "%"
 	if strings.Contains(sanitizedValue, ".") {
 		valueWidthAdjustment = -10
 	}
 	valueWidth = (len(sanitizedValue) * 11) + valueWidthAdjustment
-	width := labelWidth + valueWidth
-	labelX := labelWidth / 2
-	valueX := labelWidth + (valueWidth / 2)
-	svg := []byte(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20">
-  <linearGradient id="b" x2="0" y2="100%%">
-    <stop offset="0" stop-color="#bbb" stop-opacity=".1"/>
-    <stop offset="1" stop-opacity=".1"/>
-  </linearGradient>
-  <mask id="a">
-    <rect width="%d" height="20" rx="3" fill="#fff"/>
-  </mask>
-  <g mask="url(#a)">
-    <path fill="#555" d="M0 0h%dv20H0z"/>
-    <path fill="%s" d="M%d 0h%dv20H%dz"/>
-    <path fill="url(#b)" d="M0 0h%dv20H0z"/>
-  </g>
-  <g fill="#fff" text-anchor="middle" font-family="DejaVu Sans,Verdana,Geneva,sans-serif" font-size="11">
-    <text x="%d" y="15" fill="#010101" fill-opacity=".3">
-      uptime %s
-    </text>
-    <text x="%d" y="14">
-      uptime %s
-    </text>
-    <text x="%d" y="15" fill="#010101" fill-opacity=".3">
-      %s
-    </text>
-    <text x="%d" y="14">
-      %s
-    </text>
-  </g>
-</svg>`, width, width, labelWidth, color, labelWidth, valueWidth, labelWidth, width, labelX, duration, labelX, duration, valueX, sanitizedValue, valueX, sanitizedValue))
-	return svg
+	return generateBadgeSVG(label, labelWidth, sanitizedValue, valueWidth, color, options)
 }
 
-func getBadgeColorFromUptime(uptime float64) string {
-	if uptime >= 0.975 {
-		return badgeColorHexAwesome
-	} else if uptime >= 0.95 {
-		return badgeColorHexGreat
-	} else if uptime >= 0.9 {
-		return badgeColorHexGood
-	} else if uptime >= 0.8 {
-		return badgeColorHexPassable
-	} else if uptime >= 0.65 {
-		return badgeColorHexBad
-	}
-	return badgeColorHexVeryBad
+func getBadgeColorFromUptime(uptime float64, options *badgeOptions) string {
+	// the threshold config requires 5 values, so we can be sure it's set here
+	for i := 0; i < 5; i++ {
+		if uptime >= options.uptimeThresholds[i] {
+			return options.colors[i]
+		}
+	}
+	return options.colors[badgeLevelVeryBad]
 }
 
-func generateResponseTimeBadgeSVG(duration string, averageResponseTime int, key string, cfg *config.Config) []byte {
+func generateResponseTimeBadgeSVG(duration string, averageResponseTime int, options *badgeOptions) []byte {
 	var labelWidth, valueWidth int
 	switch duration {
+	case "365d":
+		labelWidth = 115
+	case "90d", "30d":
+		labelWidth = 110
 	case "7d":
 		labelWidth = 105
 	case "24h":
@@ -235,75 +529,129 @@ func generateResponseTimeBadgeSVG(duration string, averageResponseTime int, key
 		labelWidth = 105
 	default:
 	}
-	color := getBadgeColorFromResponseTime(averageResponseTime, key, cfg)
+	color := getBadgeColorFromResponseTime(averageResponseTime, options)
 	sanitizedValue := strconv.Itoa(averageResponseTime) + "ms"
 	valueWidth = len(sanitizedValue) * 11
+	return generateBadgeSVG("response time "+duration, labelWidth, sanitizedValue, valueWidth, color, options)
+}
+
+func getBadgeColorFromResponseTime(responseTime int, options *badgeOptions) string {
+	// the threshold config requires 5 values, so we can be sure it's set here
+	for i := 0; i < 5; i++ {
+		if responseTime <= options.responseTimeThresholds[i] {
+			return options.colors[i]
+		}
+	}
+	return options.colors[badgeLevelVeryBad]
+}
+
+func generateSLABadgeSVG(duration string, uptime float64, options *badgeOptions) []byte {
+	label := "SLA " + duration
+	sanitizedValue := strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.2f", uptime*100), "0"), ".") + "%"
+	return generateBadgeSVG(label, getBadgeTextWidth(label), sanitizedValue, getBadgeTextWidth(sanitizedValue), getBadgeColorFromSLA(uptime, options), options)
+}
+
+// getBadgeColorFromSLA returns the color of the SLA badge, which is only green when the uptime met the target
+func getBadgeColorFromSLA(uptime float64, options *badgeOptions) string {
+	if uptime >= options.slaTarget {
+		return options.colors[badgeLevelAwesome]
+	}
+	return options.colors[badgeLevelVeryBad]
+}
+
+// getResponseTimePercentile returns the response time below which the fraction p of the response times passed fall,
+// using the nearest-rank method
+func getResponseTimePercentile(responseTimes []int, p float64) int {
+	sorted := make([]int, len(responseTimes))
+	copy(sorted, responseTimes)
+	sort.Ints(sorted)
+	rank := int(math.Ceil(p * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func formatCertificateExpiration(timeLeft time.Duration) string {
+	if timeLeft <= 0 {
+		return "expired"
+	}
+	days := int(timeLeft.Hours() / 24)
+	if days == 1 {
+		return "1 day"
+	}
+	return strconv.Itoa(days) + " days"
+}
+
+func getBadgeColorFromCertificateExpiration(timeLeft time.Duration, options *badgeOptions) string {
+	if timeLeft >= 30*24*time.Hour {
+		return options.colors[badgeLevelAwesome]
+	} else if timeLeft >= 21*24*time.Hour {
+		return options.colors[badgeLevelGreat]
+	} else if timeLeft >= 14*24*time.Hour {
+		return options.colors[badgeLevelGood]
+	} else if timeLeft >= 7*24*time.Hour {
+		return options.colors[badgeLevelPassable]
+	} else if timeLeft > 0 {
+		return options.colors[badgeLevelBad]
+	}
+	return options.colors[badgeLevelVeryBad]
+}
+
+// getBadgeTextWidth returns the approximate width of the label or the value of a badge, padding included
+func getBadgeTextWidth(text string) int {
+	return len(text)*7 + 10
+}
+
+// generateBadgeSVG generates an SVG badge in the style of the options passed. If the options have a label, it replaces
+// the label passed, and the width of the label is computed from its length.
+func generateBadgeSVG(label string, labelWidth int, value string, valueWidth int, color string, options *badgeOptions) []byte {
+	if len(options.label) > 0 {
+		label = options.label
+		labelWidth = getBadgeTextWidth(label)
+	}
+	if options.style == ui.BadgeStyleForTheBadge {
+		// Text is in uppercase and spaced out, so the widths depend on the length of the text only
+		label, value = strings.ToUpper(label), strings.ToUpper(value)
+		labelWidth, valueWidth = len(label)*8+20, len(value)*8+20
+	}
 	width := labelWidth + valueWidth
 	labelX := labelWidth / 2
 	valueX := labelWidth + (valueWidth / 2)
-	svg := []byte(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20">
-  <linearGradient id="b" x2="0" y2="100%%">
-    <stop offset="0" stop-color="#bbb" stop-opacity=".1"/>
-    <stop offset="1" stop-opacity=".1"/>
-  </linearGradient>
-  <mask id="a">
-    <rect width="%d" height="20" rx="3" fill="#fff"/>
-  </mask>
-  <g mask="url(#a)">
+	label, value = html.EscapeString(label), html.EscapeString(value)
+	switch options.style {
+	case ui.BadgeStyleFlatSquare:
+		return []byte(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20">
+  <g shape-rendering="crispEdges">
     <path fill="#555" d="M0 0h%dv20H0z"/>
     <path fill="%s" d="M%d 0h%dv20H%dz"/>
-    <path fill="url(#b)" d="M0 0h%dv20H0z"/>
   </g>
   <g fill="#fff" text-anchor="middle" font-family="DejaVu Sans,Verdana,Geneva,sans-serif" font-size="11">
-    <text x="%d" y="15" fill="#010101" fill-opacity=".3">
-      response time %s
+    <text x="%d" y="14">
+      %s
     </text>
     <text x="%d" y="14">
-      response time %s
+      %s
     </text>
-    <text x="%d" y="15" fill="#010101" fill-opacity=".3">
+  </g>
+</svg>`, width, labelWidth, color, labelWidth, valueWidth, labelWidth, labelX, label, valueX, value))
+	case ui.BadgeStyleForTheBadge:
+		return []byte(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="28">
+  <g shape-rendering="crispEdges">
+    <path fill="#555" d="M0 0h%dv28H0z"/>
+    <path fill="%s" d="M%d 0h%dv28H%dz"/>
+  </g>
+  <g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="10" font-weight="bold" letter-spacing="1">
+    <text x="%d" y="18">
       %s
     </text>
-    <text x="%d" y="14">
+    <text x="%d" y="18">
       %s
     </text>
   </g>
-</svg>`, width, width, labelWidth, color, labelWidth, valueWidth, labelWidth, width, labelX, duration, labelX, duration, valueX, sanitizedValue, valueX, sanitizedValue))
-	return svg
-}
-
-func getBadgeColorFromResponseTime(responseTime int, key string, cfg *config.Config) string {
-	thresholds := ui.GetDefaultConfig().Badge.ResponseTime.Thresholds
-	if endpoint := cfg.GetEndpointByKey(key); endpoint != nil {
-		thresholds = endpoint.UIConfig.Badge.ResponseTime.Thresholds
-	}
-	// the threshold config requires 5 values, so we can be sure it's set here
-	for i := 0; i < 5; i++ {
-		if responseTime <= thresholds[i] {
-			return badgeColors[i]
-		}
-	}
-	return badgeColorHexVeryBad
-}
-
-func generateHealthBadgeSVG(healthStatus string) []byte {
-	var labelWidth, valueWidth int
-	switch healthStatus {
-	case HealthStatusUp:
-		valueWidth = 28
-	case HealthStatusDown:
-		valueWidth = 44
-	case HealthStatusUnknown:
-		valueWidth = 10
+</svg>`, width, labelWidth, color, labelWidth, valueWidth, labelWidth, labelX, label, valueX, value))
 	default:
-	}
-	color := getBadgeColorFromHealth(healthStatus)
-	labelWidth = 48
-
-	width := labelWidth + valueWidth
-	labelX := labelWidth / 2
-	valueX := labelWidth + (valueWidth / 2)
-	svg := []byte(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20">
+		return []byte(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20">
   <linearGradient id="b" x2="0" y2="100%%">
     <stop offset="0" stop-color="#bbb" stop-opacity=".1"/>
     <stop offset="1" stop-opacity=".1"/>
@@ -318,10 +666,10 @@ func generateHealthBadgeSVG(healthStatus string) []byte {
   </g>
   <g fill="#fff" text-anchor="middle" font-family="DejaVu Sans,Verdana,Geneva,sans-serif" font-size="11">
     <text x="%d" y="15" fill="#010101" fill-opacity=".3">
-      health
+      %s
     </text>
     <text x="%d" y="14">
-      health
+      %s
     </text>
     <text x="%d" y="15" fill="#010101" fill-opacity=".3">
       %s
@@ -330,12 +678,27 @@ func generateHealthBadgeSVG(healthStatus string) []byte {
       %s
     </text>
   </g>
-</svg>`, width, width, labelWidth, color, labelWidth, valueWidth, labelWidth, width, labelX, labelX, valueX, healthStatus, valueX, healthStatus))
+</svg>`, width, width, labelWidth, color, labelWidth, valueWidth, labelWidth, width, labelX, label, labelX, label, valueX, value, valueX, value))
+	}
+}
 
-	return svg
+func generateHealthBadgeSVG(healthStatus string, options *badgeOptions) []byte {
+	var labelWidth, valueWidth int
+	switch healthStatus {
+	case HealthStatusUp:
+		valueWidth = 28
+	case HealthStatusDown:
+		valueWidth = 44
+	case HealthStatusUnknown:
+		valueWidth = 10
+	default:
+	}
+	color := getBadgeColorFromHealth(healthStatus, options)
+	labelWidth = 48
+	return generateBadgeSVG("health", labelWidth, healthStatus, valueWidth, color, options)
 }
 
-func generateHealthBadgeShields(healthStatus string) ([]byte, error) {
+func generateHealthBadgeShields(healthStatus string, options *badgeOptions) ([]byte, error) {
 	color := getBadgeShieldsColorFromHealth(healthStatus)
 	data := map[string]interface{}{
 		"schemaVersion": 1,
@@ -343,16 +706,22 @@ func generateHealthBadgeShields(healthStatus string) ([]byte, error) {
 		"message":       healthStatus,
 		"color":         color,
 	}
+	if len(options.label) > 0 {
+		data["label"] = options.label
+	}
+	if len(options.style) > 0 {
+		data["style"] = options.style
+	}
 	return json.Marshal(data)
 }
 
-func getBadgeColorFromHealth(healthStatus string) string {
+func getBadgeColorFromHealth(healthStatus string, options *badgeOptions) string {
 	if healthStatus == HealthStatusUp {
-		return badgeColorHexAwesome
+		return options.colors[badgeLevelAwesome]
 	} else if healthStatus == HealthStatusDown {
-		return badgeColorHexVeryBad
+		return options.colors[badgeLevelVeryBad]
 	}
-	return badgeColorHexPassable
+	return options.colors[badgeLevelPassable]
 }
 
 func getBadgeShieldsColorFromHealth(healthStatus string) string {
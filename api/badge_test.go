@@ -1,9 +1,12 @@
 package api
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -34,7 +37,7 @@ func TestBadge(t *testing.T) {
 	cfg.Endpoints[0].UIConfig = ui.GetDefaultConfig()
 	cfg.Endpoints[1].UIConfig = ui.GetDefaultConfig()
 
-	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: true, Connected: true, Duration: time.Millisecond, Timestamp: time.Now()})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: true, Connected: true, Duration: time.Millisecond, CertificateExpiration: 60 * 24 * time.Hour, Timestamp: time.Now()})
 	watchdog.UpdateEndpointStatuses(cfg.Endpoints[1], &endpoint.Result{Success: false, Connected: false, Duration: time.Second, Timestamp: time.Now()})
 	api := New(cfg)
 	router := api.Router()
@@ -60,9 +63,44 @@ func TestBadge(t *testing.T) {
 			Path:         "/api/v1/endpoints/core_frontend/uptimes/7d/badge.svg",
 			ExpectedCode: http.StatusOK,
 		},
+		{
+			Name:         "badge-uptime-30d",
+			Path:         "/api/v1/endpoints/core_frontend/uptimes/30d/badge.svg",
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "badge-uptime-90d",
+			Path:         "/api/v1/endpoints/core_backend/uptimes/90d/badge.svg",
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "badge-uptime-365d",
+			Path:         "/api/v1/endpoints/core_frontend/uptimes/365d/badge.svg",
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "badge-uptime-14d",
+			Path:         "/api/v1/endpoints/core_frontend/uptimes/14d/badge.svg",
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "badge-uptime-with-duration-query-parameter",
+			Path:         "/api/v1/endpoints/core_frontend/uptimes/badge.svg?duration=2w",
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "badge-uptime-with-from-and-to",
+			Path:         "/api/v1/endpoints/core_frontend/uptimes/badge.svg?from=" + time.Now().Add(-48*time.Hour).UTC().Format(time.RFC3339) + "&to=" + time.Now().UTC().Format(time.RFC3339),
+			ExpectedCode: http.StatusOK,
+		},
 		{
 			Name:         "badge-uptime-with-invalid-duration",
-			Path:         "/api/v1/endpoints/core_backend/uptimes/3d/badge.svg",
+			Path:         "/api/v1/endpoints/core_backend/uptimes/3y/badge.svg",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "badge-uptime-with-duration-longer-than-a-year",
+			Path:         "/api/v1/endpoints/core_backend/uptimes/366d/badge.svg",
 			ExpectedCode: http.StatusBadRequest,
 		},
 		{
@@ -85,6 +123,21 @@ func TestBadge(t *testing.T) {
 			Path:         "/api/v1/endpoints/core_frontend/response-times/7d/badge.svg",
 			ExpectedCode: http.StatusOK,
 		},
+		{
+			Name:         "badge-response-time-30d",
+			Path:         "/api/v1/endpoints/core_frontend/response-times/30d/badge.svg",
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "badge-response-time-90d",
+			Path:         "/api/v1/endpoints/core_backend/response-times/90d/badge.svg",
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "badge-response-time-365d",
+			Path:         "/api/v1/endpoints/core_frontend/response-times/365d/badge.svg",
+			ExpectedCode: http.StatusOK,
+		},
 		{
 			Name:         "badge-response-time-with-invalid-duration",
 			Path:         "/api/v1/endpoints/core_backend/response-times/3d/badge.svg",
@@ -95,6 +148,101 @@ func TestBadge(t *testing.T) {
 			Path:         "/api/v1/endpoints/invalid_key/response-times/7d/badge.svg",
 			ExpectedCode: http.StatusNotFound,
 		},
+		{
+			Name:         "badge-response-time-p95-1h",
+			Path:         "/api/v1/endpoints/core_frontend/response-times/1h/p95/badge.svg",
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "badge-response-time-p99-7d",
+			Path:         "/api/v1/endpoints/core_backend/response-times/7d/p99/badge.svg",
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "badge-response-time-with-invalid-percentile",
+			Path:         "/api/v1/endpoints/core_backend/response-times/7d/p50/badge.svg",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "badge-response-time-percentile-with-invalid-duration",
+			Path:         "/api/v1/endpoints/core_backend/response-times/3d/p95/badge.svg",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "badge-response-time-percentile-for-invalid-key",
+			Path:         "/api/v1/endpoints/invalid_key/response-times/7d/p95/badge.svg",
+			ExpectedCode: http.StatusNotFound,
+		},
+		{
+			Name:         "badge-sla-30d",
+			Path:         "/api/v1/endpoints/core_frontend/slas/30d/badge.svg",
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "badge-sla-with-invalid-duration",
+			Path:         "/api/v1/endpoints/core_frontend/slas/3d/badge.svg",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "badge-sla-for-invalid-key",
+			Path:         "/api/v1/endpoints/invalid_key/slas/30d/badge.svg",
+			ExpectedCode: http.StatusNotFound,
+		},
+		{
+			Name:         "badge-certificate-expiration",
+			Path:         "/api/v1/endpoints/core_frontend/certificate-expiration/badge.svg",
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "badge-certificate-expiration-without-certificate",
+			Path:         "/api/v1/endpoints/core_backend/certificate-expiration/badge.svg",
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "badge-certificate-expiration-for-invalid-key",
+			Path:         "/api/v1/endpoints/invalid_key/certificate-expiration/badge.svg",
+			ExpectedCode: http.StatusNotFound,
+		},
+		{
+			Name:         "badge-uptime-with-custom-label-style-colors-and-thresholds",
+			Path:         "/api/v1/endpoints/core_frontend/uptimes/7d/badge.svg?label=availability&style=for-the-badge&colors=000,111,222,333,444,555&thresholds=99,98,97,96,95",
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "badge-uptime-with-invalid-thresholds",
+			Path:         "/api/v1/endpoints/core_frontend/uptimes/7d/badge.svg?thresholds=95,96,97,98,99",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "badge-response-time-with-custom-thresholds",
+			Path:         "/api/v1/endpoints/core_frontend/response-times/7d/badge.svg?thresholds=1,2,3,4,5",
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "badge-response-time-with-invalid-thresholds",
+			Path:         "/api/v1/endpoints/core_frontend/response-times/7d/badge.svg?thresholds=1,2,3",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "badge-sla-with-custom-target",
+			Path:         "/api/v1/endpoints/core_frontend/slas/7d/badge.svg?target=95",
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "badge-sla-with-invalid-target",
+			Path:         "/api/v1/endpoints/core_frontend/slas/7d/badge.svg?target=0",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "badge-health-with-invalid-style",
+			Path:         "/api/v1/endpoints/core_frontend/health/badge.svg?style=plastic",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "badge-health-with-invalid-colors",
+			Path:         "/api/v1/endpoints/core_frontend/health/badge.svg?colors=red,green",
+			ExpectedCode: http.StatusBadRequest,
+		},
 		{
 			Name:         "badge-health-up",
 			Path:         "/api/v1/endpoints/core_frontend/health/badge.svg",
@@ -206,8 +354,8 @@ func TestGetBadgeColorFromUptime(t *testing.T) {
 	}
 	for _, scenario := range scenarios {
 		t.Run("uptime-"+strconv.Itoa(int(scenario.Uptime*100)), func(t *testing.T) {
-			if getBadgeColorFromUptime(scenario.Uptime) != scenario.ExpectedColor {
-				t.Errorf("expected %s from %f, got %v", scenario.ExpectedColor, scenario.Uptime, getBadgeColorFromUptime(scenario.Uptime))
+			if getBadgeColorFromUptime(scenario.Uptime, newBadgeOptions("", &config.Config{})) != scenario.ExpectedColor {
+				t.Errorf("expected %s from %f, got %v", scenario.ExpectedColor, scenario.Uptime, getBadgeColorFromUptime(scenario.Uptime, newBadgeOptions("", &config.Config{})))
 			}
 		})
 	}
@@ -360,8 +508,8 @@ func TestGetBadgeColorFromResponseTime(t *testing.T) {
 	}
 	for _, scenario := range scenarios {
 		t.Run(scenario.Key+"-response-time-"+strconv.Itoa(scenario.ResponseTime), func(t *testing.T) {
-			if getBadgeColorFromResponseTime(scenario.ResponseTime, scenario.Key, cfg) != scenario.ExpectedColor {
-				t.Errorf("expected %s from %d, got %v", scenario.ExpectedColor, scenario.ResponseTime, getBadgeColorFromResponseTime(scenario.ResponseTime, scenario.Key, cfg))
+			if getBadgeColorFromResponseTime(scenario.ResponseTime, newBadgeOptions(scenario.Key, cfg)) != scenario.ExpectedColor {
+				t.Errorf("expected %s from %d, got %v", scenario.ExpectedColor, scenario.ResponseTime, getBadgeColorFromResponseTime(scenario.ResponseTime, newBadgeOptions(scenario.Key, cfg)))
 			}
 		})
 	}
@@ -387,8 +535,158 @@ func TestGetBadgeColorFromHealth(t *testing.T) {
 	}
 	for _, scenario := range scenarios {
 		t.Run("health-"+scenario.HealthStatus, func(t *testing.T) {
-			if getBadgeColorFromHealth(scenario.HealthStatus) != scenario.ExpectedColor {
-				t.Errorf("expected %s from %s, got %v", scenario.ExpectedColor, scenario.HealthStatus, getBadgeColorFromHealth(scenario.HealthStatus))
+			if getBadgeColorFromHealth(scenario.HealthStatus, newBadgeOptions("", &config.Config{})) != scenario.ExpectedColor {
+				t.Errorf("expected %s from %s, got %v", scenario.ExpectedColor, scenario.HealthStatus, getBadgeColorFromHealth(scenario.HealthStatus, newBadgeOptions("", &config.Config{})))
+			}
+		})
+	}
+}
+
+func TestGetBadgeColorFromSLA(t *testing.T) {
+	scenarios := []struct {
+		Uptime        float64
+		Target        float64
+		ExpectedColor string
+	}{
+		{Uptime: 1, Target: 0.999, ExpectedColor: badgeColorHexAwesome},
+		{Uptime: 0.999, Target: 0.999, ExpectedColor: badgeColorHexAwesome},
+		{Uptime: 0.998, Target: 0.999, ExpectedColor: badgeColorHexVeryBad},
+		{Uptime: 0, Target: 0.5, ExpectedColor: badgeColorHexVeryBad},
+	}
+	for _, scenario := range scenarios {
+		t.Run(fmt.Sprintf("uptime-%v-target-%v", scenario.Uptime, scenario.Target), func(t *testing.T) {
+			if color := getBadgeColorFromSLA(scenario.Uptime, &badgeOptions{colors: newBadgeOptions("", &config.Config{}).colors, slaTarget: scenario.Target}); color != scenario.ExpectedColor {
+				t.Errorf("expected %s from %f with target %f, got %v", scenario.ExpectedColor, scenario.Uptime, scenario.Target, color)
+			}
+		})
+	}
+}
+
+func TestNewBadgeOptions(t *testing.T) {
+	cfg := &config.Config{
+		Endpoints: []*endpoint.Endpoint{
+			{
+				Name: "a",
+				UIConfig: &ui.Config{
+					Badge: &ui.Badge{
+						Uptime: &ui.Uptime{Thresholds: []float64{99, 98, 97, 96, 95}},
+						SLA:    &ui.SLA{Target: 95},
+						Style:  ui.BadgeStyleForTheBadge,
+						Colors: []string{"#000", "#111", "#222", "#333", "#444", "#555"},
+					},
+				},
+			},
+			{Name: "b", UIConfig: &ui.Config{Badge: &ui.Badge{}}},
+		},
+	}
+	defaultOptions := newBadgeOptions("_c", cfg)
+	if defaultOptions.slaTarget != ui.GetDefaultConfig().Badge.SLA.Target/100 {
+		t.Errorf("expected the default target of %f, got %f", ui.GetDefaultConfig().Badge.SLA.Target/100, defaultOptions.slaTarget)
+	}
+	if len(defaultOptions.style) != 0 {
+		t.Errorf("expected no style, got %s", defaultOptions.style)
+	}
+	if len(defaultOptions.colors) != 6 || defaultOptions.colors[badgeLevelAwesome] != badgeColorHexAwesome || defaultOptions.colors[badgeLevelVeryBad] != badgeColorHexVeryBad {
+		t.Errorf("expected the default colors, got %v", defaultOptions.colors)
+	}
+	if defaultOptions.uptimeThresholds[0] != 0.975 {
+		t.Errorf("expected the default uptime thresholds, got %v", defaultOptions.uptimeThresholds)
+	}
+	options := newBadgeOptions("_a", cfg)
+	if options.slaTarget != 0.95 {
+		t.Errorf("expected 0.95, got %f", options.slaTarget)
+	}
+	if options.uptimeThresholds[0] != 0.99 || options.uptimeThresholds[4] != 0.95 {
+		t.Errorf("expected the uptime thresholds of the endpoint, got %v", options.uptimeThresholds)
+	}
+	if options.style != ui.BadgeStyleForTheBadge {
+		t.Errorf("expected %s, got %s", ui.BadgeStyleForTheBadge, options.style)
+	}
+	if options.colors[badgeLevelVeryBad] != "#555" {
+		t.Errorf("expected the colors of the endpoint, got %v", options.colors)
+	}
+	if options = newBadgeOptions("_b", cfg); options.slaTarget != defaultOptions.slaTarget || options.responseTimeThresholds[0] != defaultOptions.responseTimeThresholds[0] {
+		t.Error("expected the default options for an endpoint whose badge configuration is empty")
+	}
+}
+
+func TestBadgeOptions_setThresholds(t *testing.T) {
+	options := newBadgeOptions("", &config.Config{})
+	if err := options.setUptimeThresholds(""); err != nil || options.uptimeThresholds[0] != 0.975 {
+		t.Errorf("expected the uptime thresholds to be left untouched, got %v with error %v", options.uptimeThresholds, err)
+	}
+	if err := options.setUptimeThresholds("50,40,30,20,10"); err != nil || options.uptimeThresholds[0] != 0.5 {
+		t.Errorf("expected the uptime thresholds to be replaced, got %v with error %v", options.uptimeThresholds, err)
+	}
+	if err := options.setUptimeThresholds("90,95,98,99,99.9"); !errors.Is(err, ui.ErrInvalidBadgeUptimeConfig) {
+		t.Errorf("expected %v, got %v", ui.ErrInvalidBadgeUptimeConfig, err)
+	}
+	if err := options.setResponseTimeThresholds("100,200,300,400,500"); err != nil || options.responseTimeThresholds[4] != 500 {
+		t.Errorf("expected the response time thresholds to be replaced, got %v with error %v", options.responseTimeThresholds, err)
+	}
+	if err := options.setResponseTimeThresholds("100,200,fast,400,500"); !errors.Is(err, ui.ErrInvalidBadgeResponseTimeConfig) {
+		t.Errorf("expected %v, got %v", ui.ErrInvalidBadgeResponseTimeConfig, err)
+	}
+	if err := options.setSLATarget("75"); err != nil || options.slaTarget != 0.75 {
+		t.Errorf("expected the SLA target to be replaced, got %f with error %v", options.slaTarget, err)
+	}
+	if err := options.setSLATarget("101"); !errors.Is(err, ui.ErrInvalidBadgeSLAConfig) {
+		t.Errorf("expected %v, got %v", ui.ErrInvalidBadgeSLAConfig, err)
+	}
+}
+
+func TestGenerateBadgeSVG(t *testing.T) {
+	options := newBadgeOptions("", &config.Config{})
+	if svg := string(generateBadgeSVG("uptime 7d", 65, "100%", 44, badgeColorHexAwesome, options)); !strings.Contains(svg, `width="109"`) || !strings.Contains(svg, "uptime 7d") || !strings.Contains(svg, `rx="3"`) {
+		t.Errorf("expected a flat badge, got %s", svg)
+	}
+	options.label, options.style = "<script>", ui.BadgeStyleFlatSquare
+	if svg := string(generateBadgeSVG("uptime 7d", 65, "100%", 44, badgeColorHexAwesome, options)); strings.Contains(svg, "<script>") || !strings.Contains(svg, "&lt;script&gt;") || strings.Contains(svg, `rx="3"`) {
+		t.Errorf("expected a flat-square badge with an escaped label, got %s", svg)
+	}
+	options.label, options.style = "", ui.BadgeStyleForTheBadge
+	if svg := string(generateBadgeSVG("uptime 7d", 65, "100%", 44, badgeColorHexAwesome, options)); !strings.Contains(svg, "UPTIME 7D") || !strings.Contains(svg, `height="28"`) {
+		t.Errorf("expected a for-the-badge badge, got %s", svg)
+	}
+}
+
+func TestGetResponseTimePercentile(t *testing.T) {
+	responseTimes := []int{100, 20, 30, 90, 80, 70, 60, 50, 40, 10}
+	if percentile := getResponseTimePercentile(responseTimes, 0.95); percentile != 100 {
+		t.Errorf("expected 100, got %d", percentile)
+	}
+	if percentile := getResponseTimePercentile(responseTimes, 0.5); percentile != 50 {
+		t.Errorf("expected 50, got %d", percentile)
+	}
+	if percentile := getResponseTimePercentile([]int{42}, 0.99); percentile != 42 {
+		t.Errorf("expected 42, got %d", percentile)
+	}
+	if responseTimes[0] != 100 {
+		t.Error("the response times passed should not have been sorted")
+	}
+}
+
+func TestGetBadgeColorFromCertificateExpiration(t *testing.T) {
+	scenarios := []struct {
+		TimeLeft      time.Duration
+		ExpectedValue string
+		ExpectedColor string
+	}{
+		{TimeLeft: 90 * 24 * time.Hour, ExpectedValue: "90 days", ExpectedColor: badgeColorHexAwesome},
+		{TimeLeft: 25 * 24 * time.Hour, ExpectedValue: "25 days", ExpectedColor: badgeColorHexGreat},
+		{TimeLeft: 14 * 24 * time.Hour, ExpectedValue: "14 days", ExpectedColor: badgeColorHexGood},
+		{TimeLeft: 10 * 24 * time.Hour, ExpectedValue: "10 days", ExpectedColor: badgeColorHexPassable},
+		{TimeLeft: 36 * time.Hour, ExpectedValue: "1 day", ExpectedColor: badgeColorHexBad},
+		{TimeLeft: time.Hour, ExpectedValue: "0 days", ExpectedColor: badgeColorHexBad},
+		{TimeLeft: -time.Hour, ExpectedValue: "expired", ExpectedColor: badgeColorHexVeryBad},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.TimeLeft.String(), func(t *testing.T) {
+			if value := formatCertificateExpiration(scenario.TimeLeft); value != scenario.ExpectedValue {
+				t.Errorf("expected %s from %s, got %s", scenario.ExpectedValue, scenario.TimeLeft, value)
+			}
+			if color := getBadgeColorFromCertificateExpiration(scenario.TimeLeft, newBadgeOptions("", &config.Config{})); color != scenario.ExpectedColor {
+				t.Errorf("expected %s from %s, got %s", scenario.ExpectedColor, scenario.TimeLeft, color)
 			}
 		})
 	}
@@ -28,6 +28,17 @@ var (
 	transparentStyle = chart.Style{
 		FillColor: drawing.Color{R: 255, G: 255, B: 255, A: 0},
 	}
+	annotationLineStyle = chart.Style{
+		StrokeColor:     drawing.Color{R: 234, G: 88, B: 12, A: 200},
+		StrokeWidth:     1.0,
+		StrokeDashArray: []float64{4.0, 4.0},
+	}
+	annotationLabelStyle = chart.Style{
+		FontColor:   drawing.Color{R: 119, G: 119, B: 119, A: 255},
+		FillColor:   drawing.Color{R: 255, G: 255, B: 255, A: 200},
+		StrokeColor: drawing.Color{R: 234, G: 88, B: 12, A: 200},
+		FontSize:    8.0,
+	}
 )
 
 func ResponseTimeChart(c *fiber.Ctx) error {
@@ -105,7 +116,7 @@ func ResponseTimeChart(c *fiber.Ctx) error {
 				Max: math.Ceil(maxAverageResponseTime * 1.25),
 			},
 		},
-		Series: []chart.Series{series},
+		Series: append([]chart.Series{series}, annotationSeries(c.Params("key"), from, math.Ceil(maxAverageResponseTime*1.25))...),
 	}
 	c.Set("Content-Type", "image/svg+xml")
 	c.Set("Cache-Control", "no-cache, no-store")
@@ -117,3 +128,31 @@ func ResponseTimeChart(c *fiber.Ctx) error {
 	}
 	return nil
 }
+
+// annotationSeries returns the series marking the annotations affecting an endpoint since the time passed as parameter,
+// which are drawn as a vertical line labelled with the title of the annotation, or nil if there are none
+func annotationSeries(key string, from time.Time, height float64) []chart.Series {
+	annotationStore, ok := store.Get().(store.AnnotationStore)
+	if !ok {
+		return nil
+	}
+	annotations, err := annotationStore.GetAnnotationsByKey(key, from, time.Now())
+	if err != nil || len(annotations) == 0 {
+		return nil
+	}
+	labels := chart.AnnotationSeries{Style: annotationLabelStyle}
+	seriesOfAnnotations := make([]chart.Series, 0, len(annotations)+1)
+	for _, annotation := range annotations {
+		seriesOfAnnotations = append(seriesOfAnnotations, chart.TimeSeries{
+			Style:   annotationLineStyle,
+			XValues: []time.Time{annotation.Timestamp, annotation.Timestamp},
+			YValues: []float64{0, height},
+		})
+		labels.Annotations = append(labels.Annotations, chart.Value2{
+			Label:  annotation.Title,
+			XValue: chart.TimeToFloat64(annotation.Timestamp),
+			YValue: height,
+		})
+	}
+	return append(seriesOfAnnotations, labels)
+}
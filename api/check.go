@@ -0,0 +1,76 @@
+package api
+
+import (
+	"encoding/json"
+	"log"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/TwiN/gatus/v5/audit"
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/watchdog"
+	"github.com/gofiber/fiber/v2"
+)
+
+// MinimumIntervalBetweenOnDemandChecks is the minimum duration between two checks of the same endpoint triggered
+// through the API
+const MinimumIntervalBetweenOnDemandChecks = 10 * time.Second
+
+var (
+	lastOnDemandCheckByKey      = make(map[string]time.Time)
+	lastOnDemandCheckByKeyMutex sync.Mutex
+)
+
+// TriggerEndpointCheck handles requests to check an endpoint right away instead of waiting for its next interval.
+// The check is handled like any other: its result is stored, and alerts are triggered or resolved accordingly.
+func TriggerEndpointCheck(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Params("key")
+		ep := cfg.GetEndpointByKey(key)
+		if ep == nil {
+			if cfg.GetExternalEndpointByKey(key) != nil {
+				return c.Status(400).SendString("external endpoints cannot be checked by Gatus")
+			}
+			return c.Status(404).SendString("endpoint with key=" + key + " not found")
+		}
+		if !ep.IsEnabled() {
+			return c.Status(400).SendString("endpoint with key=" + key + " is disabled")
+		}
+		if retryAfter := reserveOnDemandCheck(ep.Key(), time.Now()); retryAfter > 0 {
+			c.Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			return c.Status(429).SendString("endpoint with key=" + key + " was already checked on demand less than " + MinimumIntervalBetweenOnDemandChecks.String() + " ago")
+		}
+		log.Printf("[api.TriggerEndpointCheck] Checking endpoint with key=%s on demand", key)
+		result := watchdog.Execute(ep, cfg)
+		if result == nil {
+			store.Audit(audit.NewEntry(audit.ActionOnDemandCheck, getActor(c), ep.Key(), false, "no connectivity"))
+			return c.Status(503).SendString("the endpoint was not checked, because Gatus has no connectivity")
+		}
+		store.Audit(audit.NewEntry(audit.ActionOnDemandCheck, getActor(c), ep.Key(), true, ""))
+		output, err := json.Marshal(result)
+		if err != nil {
+			log.Printf("[api.TriggerEndpointCheck] Unable to marshal object to JSON: %s", err.Error())
+			return c.Status(500).SendString("unable to marshal object to JSON")
+		}
+		c.Set("Content-Type", "application/json")
+		return c.Status(200).Send(output)
+	}
+}
+
+// reserveOnDemandCheck records that the endpoint whose key is passed is checked on demand at the time passed, unless
+// it was already checked on demand less than MinimumIntervalBetweenOnDemandChecks before, in which case nothing is
+// recorded and the duration left before it can be checked on demand again is returned
+func reserveOnDemandCheck(key string, now time.Time) time.Duration {
+	lastOnDemandCheckByKeyMutex.Lock()
+	defer lastOnDemandCheckByKeyMutex.Unlock()
+	if lastCheck, exists := lastOnDemandCheckByKey[key]; exists {
+		if elapsed := now.Sub(lastCheck); elapsed < MinimumIntervalBetweenOnDemandChecks {
+			return MinimumIntervalBetweenOnDemandChecks - elapsed
+		}
+	}
+	lastOnDemandCheckByKey[key] = now
+	return 0
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/maintenance"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+)
+
+func TestTriggerEndpointCheck(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	disabled := false
+	cfg := &config.Config{
+		Endpoints: []*endpoint.Endpoint{
+			{Name: "frontend", Group: "core", URL: server.URL, Conditions: []endpoint.Condition{"[STATUS] == 200"}},
+			{Name: "backend", Group: "core", URL: server.URL, Conditions: []endpoint.Condition{"[STATUS] == 200"}, Enabled: &disabled},
+		},
+		ExternalEndpoints: []*endpoint.ExternalEndpoint{
+			{Name: "job", Group: "core", Token: "token"},
+		},
+		Maintenance: &maintenance.Config{},
+	}
+	for _, ep := range cfg.Endpoints {
+		if err := ep.ValidateAndSetDefaults(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	router := New(cfg).Router()
+	scenarios := []struct {
+		Name         string
+		Path         string
+		ExpectedCode int
+	}{
+		{
+			Name:         "check",
+			Path:         "/api/v1/endpoints/core_frontend/check",
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "check-again-too-soon",
+			Path:         "/api/v1/endpoints/core_frontend/check",
+			ExpectedCode: http.StatusTooManyRequests,
+		},
+		{
+			Name:         "check-unknown-endpoint-with-a-key-of-the-same-length",
+			Path:         "/api/v1/endpoints/core_nopenope/check",
+			ExpectedCode: http.StatusNotFound,
+		},
+		{
+			Name:         "check-again-too-soon-after-another-request",
+			Path:         "/api/v1/endpoints/core_frontend/check",
+			ExpectedCode: http.StatusTooManyRequests,
+		},
+		{
+			Name:         "check-disabled-endpoint",
+			Path:         "/api/v1/endpoints/core_backend/check",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "check-external-endpoint",
+			Path:         "/api/v1/endpoints/core_job/check",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "check-unknown-endpoint",
+			Path:         "/api/v1/endpoints/core_nope/check",
+			ExpectedCode: http.StatusNotFound,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest("POST", scenario.Path, http.NoBody)
+			response, err := router.Test(request, 5000)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+			if scenario.ExpectedCode == http.StatusTooManyRequests && len(response.Header.Get("Retry-After")) == 0 {
+				t.Error("expected the Retry-After header to be set")
+			}
+			if scenario.ExpectedCode == http.StatusOK {
+				body, _ := io.ReadAll(response.Body)
+				var result endpoint.Result
+				if err := json.Unmarshal(body, &result); err != nil {
+					t.Fatal(err)
+				}
+				if !result.Success || result.HTTPStatus != 200 {
+					t.Errorf("expected a successful result with status 200, got %s", body)
+				}
+			}
+		})
+	}
+	status, err := store.Get().GetEndpointStatusByKey("core_frontend", paging.NewEndpointStatusParams().WithResults(1, 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(status.Results) != 1 {
+		t.Errorf("expected the result of the on-demand check to be stored, got %d results", len(status.Results))
+	}
+}
+
+func TestReserveOnDemandCheck(t *testing.T) {
+	now := time.Now()
+	if retryAfter := reserveOnDemandCheck("test_reserve", now); retryAfter != 0 {
+		t.Errorf("expected the first check to be allowed, got %s", retryAfter)
+	}
+	if retryAfter := reserveOnDemandCheck("test_reserve", now.Add(4*time.Second)); retryAfter != MinimumIntervalBetweenOnDemandChecks-4*time.Second {
+		t.Errorf("expected %s, got %s", MinimumIntervalBetweenOnDemandChecks-4*time.Second, retryAfter)
+	}
+	if retryAfter := reserveOnDemandCheck("test_other", now.Add(4*time.Second)); retryAfter != 0 {
+		t.Errorf("expected the check of another endpoint to be allowed, got %s", retryAfter)
+	}
+	if retryAfter := reserveOnDemandCheck("test_reserve", now.Add(MinimumIntervalBetweenOnDemandChecks)); retryAfter != 0 {
+		t.Errorf("expected the check to be allowed once the interval elapsed, got %s", retryAfter)
+	}
+}
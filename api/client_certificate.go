@@ -0,0 +1,28 @@
+package api
+
+import (
+	"github.com/TwiN/gatus/v5/config/web"
+	"github.com/TwiN/gatus/v5/security"
+	"github.com/gofiber/fiber/v2"
+)
+
+// withClientCertificates returns a handler rejecting the requests whose client didn't present a certificate signed by
+// one of the certificate authorities of the client authentication configuration passed as parameter, or, if presenting
+// one is optional, presented a certificate that isn't
+func withClientCertificates(clientAuth *web.ClientAuthConfig, securityConfig *security.Config) fiber.Handler {
+	certificateAuthorities, err := clientAuth.CertificateAuthorities()
+	if err != nil {
+		panic(err)
+	}
+	return func(c *fiber.Ctx) error {
+		state := c.Context().TLSConnectionState()
+		if clientAuth.Mode == web.ClientAuthModeVerifyIfGiven && (state == nil || len(state.PeerCertificates) == 0) {
+			return c.Next()
+		}
+		if !security.IsClientCertificateVerifiedBy(state, certificateAuthorities) {
+			recordAccessDenial(c, securityConfig, "client certificate is not signed by a trusted certificate authority")
+			return c.Status(403).SendString("client certificate is not signed by a trusted certificate authority")
+		}
+		return c.Next()
+	}
+}
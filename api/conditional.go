@@ -0,0 +1,17 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/etag"
+)
+
+// withConditionalRequests returns a handler setting the Cache-Control header passed on the responses of the handlers
+// that come after it, and tagging these responses with an ETag so that clients sending it back through the
+// If-None-Match header get a 304 Not Modified without a body if the response hasn't changed since
+func withConditionalRequests(cacheControl string) fiber.Handler {
+	eTag := etag.New()
+	return func(c *fiber.Ctx) error {
+		c.Set("Cache-Control", cacheControl)
+		return eTag(c)
+	}
+}
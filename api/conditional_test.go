@@ -0,0 +1,84 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/web"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/watchdog"
+)
+
+func TestWithConditionalRequests(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	cfg := &config.Config{
+		Endpoints: []*endpoint.Endpoint{{Name: "frontend", Group: "core"}},
+		Web:       &web.Config{CacheControl: &web.CacheControlConfig{Statuses: "no-cache", Badges: "public, max-age=300"}},
+	}
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: true, Connected: true})
+	router := New(cfg).Router()
+	scenarios := []struct {
+		Name                 string
+		Path                 string
+		ExpectedCacheControl string
+	}{
+		{
+			Name:                 "statuses",
+			Path:                 "/api/v1/endpoints/statuses",
+			ExpectedCacheControl: "no-cache",
+		},
+		{
+			Name:                 "endpoint-statuses",
+			Path:                 "/api/v1/endpoints/core_frontend/statuses",
+			ExpectedCacheControl: "no-cache",
+		},
+		{
+			Name:                 "badge",
+			Path:                 "/api/v1/endpoints/core_frontend/health/badge.svg",
+			ExpectedCacheControl: "public, max-age=300",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			response, err := router.Test(httptest.NewRequest("GET", scenario.Path, http.NoBody))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != http.StatusOK {
+				t.Fatalf("expected %d, got %d", http.StatusOK, response.StatusCode)
+			}
+			if cacheControl := response.Header.Get("Cache-Control"); cacheControl != scenario.ExpectedCacheControl {
+				t.Errorf("expected Cache-Control to be %q, got %q", scenario.ExpectedCacheControl, cacheControl)
+			}
+			eTag := response.Header.Get("ETag")
+			if len(eTag) == 0 {
+				t.Fatal("expected the response to have an ETag")
+			}
+			request := httptest.NewRequest("GET", scenario.Path, http.NoBody)
+			request.Header.Set("If-None-Match", eTag)
+			conditionalResponse, err := router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conditionalResponse.Body.Close()
+			if conditionalResponse.StatusCode != http.StatusNotModified {
+				t.Errorf("expected %d, got %d", http.StatusNotModified, conditionalResponse.StatusCode)
+			}
+			request = httptest.NewRequest("GET", scenario.Path, http.NoBody)
+			request.Header.Set("If-None-Match", `"0-0"`)
+			staleResponse, err := router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer staleResponse.Body.Close()
+			if staleResponse.StatusCode != http.StatusOK {
+				t.Errorf("expected %d for an outdated ETag, got %d", http.StatusOK, staleResponse.StatusCode)
+			}
+		})
+	}
+}
@@ -1,25 +1,79 @@
 package api
 
 import (
-	"fmt"
+	"encoding/json"
 
+	"github.com/TwiN/gatus/v5/config/group"
+	"github.com/TwiN/gatus/v5/config/grouppage"
 	"github.com/TwiN/gatus/v5/security"
 	"github.com/gofiber/fiber/v2"
 )
 
 type ConfigHandler struct {
 	securityConfig *security.Config
+	groups         []*group.Group
+	groupPages     []*grouppage.GroupPage
+}
+
+type configResponse struct {
+	OIDC          bool             `json:"oidc"`
+	SAML          bool             `json:"saml"`
+	RememberMe    bool             `json:"rememberMe,omitempty"` // Whether users can ask to be remembered when logging in
+	Authenticated bool             `json:"authenticated"`
+	Role          security.Role    `json:"role,omitempty"`         // Role of the authenticated user, which governs what they're allowed to do
+	PublicGroups  []string         `json:"publicGroups,omitempty"` // Groups whose page can be accessed without being authenticated
+	Groups        []*groupResponse `json:"groups,omitempty"`       // Metadata of the groups, in the order they must be displayed in
+}
+
+type groupResponse struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Logo        string          `json:"logo,omitempty"`
+	Links       []*linkResponse `json:"links,omitempty"`
+	Order       int             `json:"order"`
+}
+
+type linkResponse struct {
+	Name string `json:"name"`
+	Link string `json:"link"`
 }
 
 func (handler ConfigHandler) GetConfig(c *fiber.Ctx) error {
-	hasOIDC := false
-	isAuthenticated := true // Default to true if no security config is set
+	response := configResponse{Authenticated: true} // Default to true if no security config is set
 	if handler.securityConfig != nil {
-		hasOIDC = handler.securityConfig.OIDC != nil
-		isAuthenticated = handler.securityConfig.IsAuthenticated(c)
+		response.OIDC = handler.securityConfig.OIDC != nil
+		response.SAML = handler.securityConfig.SAML != nil
+		response.RememberMe = (response.OIDC || response.SAML) && handler.securityConfig.Session != nil && handler.securityConfig.Session.IsRememberMeEnabled()
+		response.Authenticated = handler.securityConfig.IsAuthenticated(c)
+	}
+	if response.Authenticated {
+		response.Role = getRole(c, handler.securityConfig)
+	}
+	// The groups the client isn't allowed to see are left out, even if their page is public
+	hiddenGroups := getHiddenGroups(c, handler.securityConfig, handler.groups)
+	publicGroups := make(map[string]bool)
+	for _, page := range handler.groupPages {
+		if page.Public && !hiddenGroups[page.Group] {
+			response.PublicGroups = append(response.PublicGroups, page.Group)
+			publicGroups[page.Group] = true
+		}
+	}
+	// The metadata of the groups is only returned to those who can see their endpoints
+	for _, g := range handler.groups {
+		if (!response.Authenticated && !publicGroups[g.Name]) || hiddenGroups[g.Name] {
+			continue
+		}
+		groupResponse := &groupResponse{Name: g.Name, Description: g.Description, Logo: g.Logo, Order: g.Order}
+		for _, link := range g.Links {
+			groupResponse.Links = append(groupResponse.Links, &linkResponse{Name: link.Name, Link: link.Link})
+		}
+		response.Groups = append(response.Groups, groupResponse)
+	}
+	output, err := json.Marshal(response)
+	if err != nil {
+		return c.Status(500).SendString("unable to marshal object to JSON")
 	}
 	// Return the config
 	c.Set("Content-Type", "application/json")
-	return c.Status(200).
-		SendString(fmt.Sprintf(`{"oidc":%v,"authenticated":%v}`, hasOIDC, isAuthenticated))
+	return c.Status(200).Send(output)
 }
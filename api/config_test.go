@@ -3,8 +3,12 @@ package api
 import (
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/TwiN/gatus/v5/config/group"
+	"github.com/TwiN/gatus/v5/config/grouppage"
+	"github.com/TwiN/gatus/v5/config/ui"
 	"github.com/TwiN/gatus/v5/security"
 	"github.com/gofiber/fiber/v2"
 )
@@ -40,7 +44,63 @@ func TestConfigHandler_ServeHTTP(t *testing.T) {
 	if err != nil {
 		t.Error("expected err to be nil, but was", err)
 	}
-	if string(body) != `{"oidc":true,"authenticated":false}` {
-		t.Error("expected body to be `{\"oidc\":true,\"authenticated\":false}`, but was", string(body))
+	if string(body) != `{"oidc":true,"saml":false,"authenticated":false}` {
+		t.Error("expected body to be `{\"oidc\":true,\"saml\":false,\"authenticated\":false}`, but was", string(body))
+	}
+}
+
+func TestConfigHandler_GetConfigWithGroups(t *testing.T) {
+	groups := []*group.Group{
+		{Name: "customers", Description: "Customer-facing services", Logo: "https://example.org/customers.png", Links: []ui.Button{{Name: "Runbook", Link: "https://example.org/runbook"}}, Order: 1},
+		{Name: "internal", Order: 2},
+	}
+	groupPages := []*grouppage.GroupPage{{Group: "customers", Public: true}}
+	scenarios := []struct {
+		name          string
+		handler       ConfigHandler
+		withBasicAuth bool
+		expectedBody  string
+	}{
+		{
+			name:         "without-security",
+			handler:      ConfigHandler{groups: groups, groupPages: groupPages},
+			expectedBody: `{"oidc":false,"saml":false,"authenticated":true,"role":"admin","publicGroups":["customers"],"groups":[{"name":"customers","description":"Customer-facing services","logo":"https://example.org/customers.png","links":[{"name":"Runbook","link":"https://example.org/runbook"}],"order":1},{"name":"internal","order":2}]}`,
+		},
+		{
+			name: "not-authenticated",
+			handler: ConfigHandler{
+				securityConfig: &security.Config{Basic: &security.BasicConfig{Username: "john.doe", PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT"}},
+				groups:         groups,
+				groupPages:     groupPages,
+			},
+			expectedBody: `{"oidc":false,"saml":false,"authenticated":false,"publicGroups":["customers"],"groups":[{"name":"customers","description":"Customer-facing services","logo":"https://example.org/customers.png","links":[{"name":"Runbook","link":"https://example.org/runbook"}],"order":1}]}`,
+		},
+		{
+			name: "authenticated-with-default-role",
+			handler: ConfigHandler{
+				securityConfig: &security.Config{Basic: &security.BasicConfig{Username: "john.doe", PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT"}, DefaultRole: security.RoleViewer},
+			},
+			withBasicAuth: true,
+			expectedBody:  `{"oidc":false,"saml":false,"authenticated":true,"role":"viewer"}`,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/api/v1/config", scenario.handler.GetConfig)
+			request := httptest.NewRequest("GET", "/api/v1/config", http.NoBody)
+			if scenario.withBasicAuth {
+				request.SetBasicAuth("john.doe", "hunter2")
+			}
+			response, err := app.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			body, _ := io.ReadAll(response.Body)
+			if string(body) != scenario.expectedBody {
+				t.Errorf("expected body to be %s, but was %s", scenario.expectedBody, body)
+			}
+		})
 	}
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/TwiN/gatus/v5/storage/store"
 	"github.com/TwiN/gatus/v5/storage/store/common"
 	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+	"github.com/TwiN/gatus/v5/watchdog"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -22,7 +23,9 @@ import (
 func EndpointStatuses(cfg *config.Config) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		page, pageSize := extractPageAndPageSizeFromRequest(c)
-		value, exists := cache.Get(fmt.Sprintf("endpoint-status-%d-%d", page, pageSize))
+		hiddenGroups := getHiddenGroups(c, cfg.Security, cfg.Groups)
+		cacheKey := fmt.Sprintf("endpoint-status-%d-%d", page, pageSize) + hiddenGroupsCacheKey(hiddenGroups)
+		value, exists := cache.Get(cacheKey)
 		var data []byte
 		if !exists {
 			endpointStatuses, err := store.Get().GetAllEndpointStatuses(paging.NewEndpointStatusParams().WithResults(page, pageSize))
@@ -30,6 +33,10 @@ func EndpointStatuses(cfg *config.Config) fiber.Handler {
 				log.Printf("[api.EndpointStatuses] Failed to retrieve endpoint statuses: %s", err.Error())
 				return c.Status(500).SendString(err.Error())
 			}
+			endpointStatuses = withoutHiddenGroups(endpointStatuses, hiddenGroups)
+			for _, endpointStatus := range endpointStatuses {
+				endpointStatus.Paused = watchdog.IsPaused(endpointStatus.Key)
+			}
 			// ALPHA: Retrieve endpoint statuses from remote instances
 			if endpointStatusesFromRemote, err := getEndpointStatusesFromRemoteInstances(cfg.Remote); err != nil {
 				log.Printf("[handler.EndpointStatuses] Silently failed to retrieve endpoint statuses from remote: %s", err.Error())
@@ -42,7 +49,7 @@ func EndpointStatuses(cfg *config.Config) fiber.Handler {
 				log.Printf("[api.EndpointStatuses] Unable to marshal object to JSON: %s", err.Error())
 				return c.Status(500).SendString("unable to marshal object to JSON")
 			}
-			cache.SetWithTTL(fmt.Sprintf("endpoint-status-%d-%d", page, pageSize), data, cacheTTL)
+			cache.SetWithTTL(cacheKey, data, cacheTTL)
 		} else {
 			data = value.([]byte)
 		}
@@ -51,6 +58,39 @@ func EndpointStatuses(cfg *config.Config) fiber.Handler {
 	}
 }
 
+// sendFilteredEndpointStatuses sends the statuses of the endpoints for which keep returns true, which, like in
+// EndpointStatuses, are cached under a key made of the scope passed as parameter and the page of results requested
+func sendFilteredEndpointStatuses(c *fiber.Ctx, scope string, keep func(endpointStatus *endpoint.Status) bool) error {
+	page, pageSize := extractPageAndPageSizeFromRequest(c)
+	cacheKey := fmt.Sprintf("%s-endpoint-status-%d-%d", scope, page, pageSize)
+	value, exists := cache.Get(cacheKey)
+	var data []byte
+	if !exists {
+		endpointStatuses, err := store.Get().GetAllEndpointStatuses(paging.NewEndpointStatusParams().WithResults(page, pageSize))
+		if err != nil {
+			log.Printf("[api.sendFilteredEndpointStatuses] Failed to retrieve endpoint statuses: %s", err.Error())
+			return c.Status(500).SendString(err.Error())
+		}
+		filteredEndpointStatuses := make([]*endpoint.Status, 0)
+		for _, endpointStatus := range endpointStatuses {
+			if keep(endpointStatus) {
+				endpointStatus.Paused = watchdog.IsPaused(endpointStatus.Key)
+				filteredEndpointStatuses = append(filteredEndpointStatuses, endpointStatus)
+			}
+		}
+		data, err = json.Marshal(filteredEndpointStatuses)
+		if err != nil {
+			log.Printf("[api.sendFilteredEndpointStatuses] Unable to marshal object to JSON: %s", err.Error())
+			return c.Status(500).SendString("unable to marshal object to JSON")
+		}
+		cache.SetWithTTL(cacheKey, data, cacheTTL)
+	} else {
+		data = value.([]byte)
+	}
+	c.Set("Content-Type", "application/json")
+	return c.Status(200).Send(data)
+}
+
 func getEndpointStatusesFromRemoteInstances(remoteConfig *remote.Config) ([]*endpoint.Status, error) {
 	if remoteConfig == nil || len(remoteConfig.Instances) == 0 {
 		return nil, nil
@@ -98,6 +138,7 @@ func EndpointStatus(c *fiber.Ctx) error {
 		log.Printf("[api.EndpointStatus] Endpoint with key=%s not found", c.Params("key"))
 		return c.Status(404).SendString("not found")
 	}
+	endpointStatus.Paused = watchdog.IsPaused(endpointStatus.Key)
 	output, err := json.Marshal(endpointStatus)
 	if err != nil {
 		log.Printf("[api.EndpointStatus] Unable to marshal object to JSON: %s", err.Error())
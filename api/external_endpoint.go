@@ -1,34 +1,129 @@
 package api
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"log"
 	"strings"
 	"time"
 
+	"github.com/TwiN/gatus/v5/audit"
 	"github.com/TwiN/gatus/v5/config"
 	"github.com/TwiN/gatus/v5/config/endpoint"
 	"github.com/TwiN/gatus/v5/storage/store"
 	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/gatus/v5/token"
 	"github.com/TwiN/gatus/v5/watchdog"
 	"github.com/gofiber/fiber/v2"
 )
 
+// MaximumNumberOfResultsPerBatch is the maximum number of results that can be pushed to external endpoints in a
+// single request
+const MaximumNumberOfResultsPerBatch = 100
+
+var (
+	errMissingExternalEndpointResultSuccess = errors.New("success must be set")
+	errMissingExternalEndpointResultKey     = errors.New("key must be set")
+	errEmptyExternalEndpointResultCondition = errors.New("the condition of each condition result must not be empty")
+	errMissingPushScope                     = errors.New("token does not have the push-external scope")
+)
+
+// externalEndpointResult is a result pushed to an external endpoint through the body of the request
+type externalEndpointResult struct {
+	// Key of the external endpoint, which is only used by batches, since it is otherwise part of the path
+	Key string `json:"key,omitempty"`
+
+	// Success is whether the health check was successful. Required.
+	Success *bool `json:"success"`
+
+	// Duration is how long the health check took, e.g. 150ms
+	Duration string `json:"duration,omitempty"`
+
+	// Errors encountered during the health check
+	Errors []string `json:"errors,omitempty"`
+
+	// ConditionResults are the results of the conditions evaluated by the health check
+	ConditionResults []*endpoint.ConditionResult `json:"conditionResults,omitempty"`
+
+	// Metadata is arbitrary information attached to the result
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// toResult validates the pushed result and converts it to the endpoint.Result it describes
+func (r *externalEndpointResult) toResult(timestamp time.Time) (*endpoint.Result, error) {
+	if r.Success == nil {
+		return nil, errMissingExternalEndpointResultSuccess
+	}
+	result := &endpoint.Result{
+		Timestamp:        timestamp,
+		Success:          *r.Success,
+		Errors:           []string{},
+		ConditionResults: r.ConditionResults,
+	}
+	if len(r.Duration) > 0 {
+		duration, err := time.ParseDuration(r.Duration)
+		if err != nil || duration < 0 {
+			return nil, fmt.Errorf("invalid duration %q", r.Duration)
+		}
+		result.Duration = duration
+	}
+	for _, resultError := range r.Errors {
+		result.AddError(resultError)
+	}
+	for _, conditionResult := range r.ConditionResults {
+		if conditionResult == nil || len(conditionResult.Condition) == 0 {
+			return nil, errEmptyExternalEndpointResultCondition
+		}
+	}
+	if len(r.Metadata) > 0 {
+		result.Metadata = r.Metadata
+	}
+	return result, nil
+}
+
+// CreateExternalEndpointResult handles requests to push a result to an external endpoint.
+//
+// The result is either passed as a JSON body, or, for the sake of simplicity, through the success, duration and
+// error query parameters.
 func CreateExternalEndpointResult(cfg *config.Config) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		// Check if the success query parameter is present
-		success, exists := c.Queries()["success"]
-		if !exists || (success != "true" && success != "false") {
-			return c.Status(400).SendString("missing or invalid success query parameter")
+		var pushedResult *externalEndpointResult
+		if body := c.Body(); len(body) > 0 {
+			pushedResult = &externalEndpointResult{}
+			if err := json.Unmarshal(body, pushedResult); err != nil {
+				return c.Status(400).SendString("invalid body: " + err.Error())
+			}
+		} else {
+			// Check if the success query parameter is present
+			success, exists := c.Queries()["success"]
+			if !exists || (success != "true" && success != "false") {
+				return c.Status(400).SendString("missing or invalid success query parameter")
+			}
+			successful := success == "true"
+			// The query parameters are copied, because they point to a buffer of fasthttp that is reused by the next
+			// requests, whereas the result is kept by the storage
+			pushedResult = &externalEndpointResult{Success: &successful, Duration: strings.Clone(c.Query("duration"))}
+			if resultError := c.Query("error"); len(resultError) > 0 {
+				pushedResult.Errors = []string{strings.Clone(resultError)}
+			}
+		}
+		result, err := pushedResult.toResult(time.Now())
+		if err != nil {
+			return c.Status(400).SendString(err.Error())
 		}
 		// Check if the authorization bearer token header is correct
-		authorizationHeader := string(c.Request().Header.Peek("Authorization"))
-		if !strings.HasPrefix(authorizationHeader, "Bearer ") {
-			return c.Status(401).SendString("invalid Authorization header")
+		bearerToken, err := getBearerToken(c)
+		if err != nil {
+			return c.Status(401).SendString(err.Error())
 		}
-		token := strings.TrimSpace(strings.TrimPrefix(authorizationHeader, "Bearer "))
-		if len(token) == 0 {
-			return c.Status(401).SendString("bearer token must not be empty")
+		hasPushScope, err := authenticatePushToken(c, bearerToken)
+		if err != nil {
+			if errors.Is(err, errMissingPushScope) {
+				return c.Status(403).SendString(err.Error())
+			}
+			log.Printf("[api.CreateExternalEndpointResult] Failed to retrieve token: %s", err.Error())
+			return c.Status(500).SendString(err.Error())
 		}
 		key := c.Params("key")
 		externalEndpoint := cfg.GetExternalEndpointByKey(key)
@@ -36,32 +131,118 @@ func CreateExternalEndpointResult(cfg *config.Config) fiber.Handler {
 			log.Printf("[api.CreateExternalEndpointResult] External endpoint with key=%s not found", key)
 			return c.Status(404).SendString("not found")
 		}
-		if externalEndpoint.Token != token {
+		if !hasPushScope && externalEndpoint.Token != bearerToken {
 			log.Printf("[api.CreateExternalEndpointResult] Invalid token for external endpoint with key=%s", key)
+			store.Audit(audit.NewEntry(audit.ActionExternalEndpointTokenUsage, getActor(c), externalEndpoint.Key(), false, "invalid token"))
 			return c.Status(401).SendString("invalid token")
 		}
-		// Persist the result in the storage
-		result := &endpoint.Result{
-			Timestamp: time.Now(),
-			Success:   c.QueryBool("success"),
-			Errors:    []string{},
-		}
-		convertedEndpoint := externalEndpoint.ToEndpoint()
-		if err := store.Get().Insert(convertedEndpoint, result); err != nil {
+		store.Audit(audit.NewEntry(audit.ActionExternalEndpointTokenUsage, getActor(c), externalEndpoint.Key(), true, ""))
+		// Persist the result in the storage and check if an alert should be triggered or resolved
+		if err := watchdog.HandleExternalEndpointResult(externalEndpoint, result, cfg); err != nil {
 			if errors.Is(err, common.ErrEndpointNotFound) {
 				return c.Status(404).SendString(err.Error())
 			}
 			log.Printf("[api.CreateExternalEndpointResult] Failed to insert result in storage: %s", err.Error())
 			return c.Status(500).SendString(err.Error())
 		}
-		log.Printf("[api.CreateExternalEndpointResult] Successfully inserted result for external endpoint with key=%s and success=%s", c.Params("key"), success)
-		// Check if an alert should be triggered or resolved
-		if !cfg.Maintenance.IsUnderMaintenance() {
-			watchdog.HandleAlerting(convertedEndpoint, result, cfg.Alerting, cfg.Debug)
-			externalEndpoint.NumberOfSuccessesInARow = convertedEndpoint.NumberOfSuccessesInARow
-			externalEndpoint.NumberOfFailuresInARow = convertedEndpoint.NumberOfFailuresInARow
-		}
+		log.Printf("[api.CreateExternalEndpointResult] Successfully inserted result for external endpoint with key=%s and success=%v", key, result.Success)
 		// Return the result
 		return c.Status(200).SendString("")
 	}
 }
+
+// CreateExternalEndpointResults handles requests to push results to several external endpoints at once, which is
+// meant for agents reporting the results of many health checks.
+//
+// Every result must be valid and the token must be the one of every external endpoint the results are pushed to, or an
+// API token with the push-external scope, otherwise none of the results are pushed.
+func CreateExternalEndpointResults(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var pushedResults []*externalEndpointResult
+		if err := json.Unmarshal(c.Body(), &pushedResults); err != nil {
+			return c.Status(400).SendString("invalid body: " + err.Error())
+		}
+		if len(pushedResults) == 0 {
+			return c.Status(400).SendString("at least one result must be pushed")
+		}
+		if len(pushedResults) > MaximumNumberOfResultsPerBatch {
+			return c.Status(400).SendString(fmt.Sprintf("at most %d results can be pushed at once", MaximumNumberOfResultsPerBatch))
+		}
+		bearerToken, err := getBearerToken(c)
+		if err != nil {
+			return c.Status(401).SendString(err.Error())
+		}
+		hasPushScope, err := authenticatePushToken(c, bearerToken)
+		if err != nil {
+			if errors.Is(err, errMissingPushScope) {
+				return c.Status(403).SendString(err.Error())
+			}
+			log.Printf("[api.CreateExternalEndpointResults] Failed to retrieve token: %s", err.Error())
+			return c.Status(500).SendString(err.Error())
+		}
+		externalEndpoints := make([]*endpoint.ExternalEndpoint, len(pushedResults))
+		results := make([]*endpoint.Result, len(pushedResults))
+		for i, pushedResult := range pushedResults {
+			if pushedResult == nil || len(pushedResult.Key) == 0 {
+				return c.Status(400).SendString(fmt.Sprintf("result #%d is invalid: %s", i+1, errMissingExternalEndpointResultKey.Error()))
+			}
+			if results[i], err = pushedResult.toResult(time.Now()); err != nil {
+				return c.Status(400).SendString(fmt.Sprintf("result #%d is invalid: %s", i+1, err.Error()))
+			}
+			if externalEndpoints[i] = cfg.GetExternalEndpointByKey(pushedResult.Key); externalEndpoints[i] == nil {
+				log.Printf("[api.CreateExternalEndpointResults] External endpoint with key=%s not found", pushedResult.Key)
+				return c.Status(404).SendString("external endpoint with key=" + pushedResult.Key + " not found")
+			}
+			if !hasPushScope && externalEndpoints[i].Token != bearerToken {
+				log.Printf("[api.CreateExternalEndpointResults] Invalid token for external endpoint with key=%s", pushedResult.Key)
+				store.Audit(audit.NewEntry(audit.ActionExternalEndpointTokenUsage, getActor(c), externalEndpoints[i].Key(), false, "invalid token"))
+				return c.Status(401).SendString("invalid token for external endpoint with key=" + pushedResult.Key)
+			}
+		}
+		for i, externalEndpoint := range externalEndpoints {
+			store.Audit(audit.NewEntry(audit.ActionExternalEndpointTokenUsage, getActor(c), externalEndpoint.Key(), true, ""))
+			if err := watchdog.HandleExternalEndpointResult(externalEndpoint, results[i], cfg); err != nil {
+				log.Printf("[api.CreateExternalEndpointResults] Failed to insert result in storage: %s", err.Error())
+				return c.Status(500).SendString(err.Error())
+			}
+		}
+		log.Printf("[api.CreateExternalEndpointResults] Successfully inserted %d results", len(results))
+		return c.Status(200).SendString("")
+	}
+}
+
+// authenticatePushToken authenticates the bearer token passed if it's an API token, and returns whether it grants access
+// to pushing the results of any external endpoint, or errMissingPushScope if it doesn't.
+//
+// A bearer token that looks like an API token but isn't one is left to be compared with the token of the external
+// endpoint, so that the token of an external endpoint may be anything.
+func authenticatePushToken(c *fiber.Ctx, bearerToken string) (bool, error) {
+	if !token.IsToken(bearerToken) {
+		return false, nil
+	}
+	t, err := getActiveToken(bearerToken)
+	if err != nil {
+		if errors.Is(err, errInvalidToken) {
+			return false, nil
+		}
+		return false, err
+	}
+	c.Locals(tokenLocalsKey, t)
+	if !t.HasScope(token.ScopePushExternal) {
+		return false, errMissingPushScope
+	}
+	return true, nil
+}
+
+// getBearerToken returns the bearer token passed through the Authorization header of the request
+func getBearerToken(c *fiber.Ctx) (string, error) {
+	authorizationHeader := string(c.Request().Header.Peek("Authorization"))
+	if !strings.HasPrefix(authorizationHeader, "Bearer ") {
+		return "", errors.New("invalid Authorization header")
+	}
+	token := strings.TrimSpace(strings.TrimPrefix(authorizationHeader, "Bearer "))
+	if len(token) == 0 {
+		return "", errors.New("bearer token must not be empty")
+	}
+	return token, nil
+}
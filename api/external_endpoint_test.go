@@ -1,13 +1,17 @@
 package api
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/TwiN/gatus/v5/alerting"
 	"github.com/TwiN/gatus/v5/alerting/alert"
 	"github.com/TwiN/gatus/v5/alerting/provider/discord"
+	"github.com/TwiN/gatus/v5/audit"
 	"github.com/TwiN/gatus/v5/config"
 	"github.com/TwiN/gatus/v5/config/endpoint"
 	"github.com/TwiN/gatus/v5/config/maintenance"
@@ -78,13 +82,13 @@ func TestCreateExternalEndpointResult(t *testing.T) {
 		},
 		{
 			Name:                           "good-token-success-false",
-			Path:                           "/api/v1/endpoints/g_n/external?success=false",
+			Path:                           "/api/v1/endpoints/g_n/external?success=false&error=timeout",
 			AuthorizationHeaderBearerToken: "Bearer token",
 			ExpectedCode:                   200,
 		},
 		{
 			Name:                           "good-token-success-false-again",
-			Path:                           "/api/v1/endpoints/g_n/external?success=false",
+			Path:                           "/api/v1/endpoints/g_n/external?success=false&error=XXXXXXX",
 			AuthorizationHeaderBearerToken: "Bearer token",
 			ExpectedCode:                   200,
 		},
@@ -126,6 +130,10 @@ func TestCreateExternalEndpointResult(t *testing.T) {
 		if endpointStatus.Results[2].Success {
 			t.Errorf("expected third result to be unsuccessful")
 		}
+		// The error of a result must not be overwritten by the error of the next result pushed
+		if len(endpointStatus.Results[1].Errors) != 1 || endpointStatus.Results[1].Errors[0] != "timeout" {
+			t.Errorf("expected second result to have the error timeout, got %v", endpointStatus.Results[1].Errors)
+		}
 		externalEndpointFromConfig := cfg.GetExternalEndpointByKey("g_n")
 		if externalEndpointFromConfig.NumberOfFailuresInARow != 2 {
 			t.Errorf("expected 2 failures in a row but got %d", externalEndpointFromConfig.NumberOfFailuresInARow)
@@ -134,4 +142,229 @@ func TestCreateExternalEndpointResult(t *testing.T) {
 			t.Errorf("expected 0 successes in a row but got %d", externalEndpointFromConfig.NumberOfSuccessesInARow)
 		}
 	})
+	t.Run("verify-audit-log", func(t *testing.T) {
+		response, err := router.Test(httptest.NewRequest("GET", "/api/v1/audit", http.NoBody))
+		if err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+		defer response.Body.Close()
+		var entries []*audit.Entry
+		if err := json.NewDecoder(response.Body).Decode(&entries); err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+		if len(entries) != 4 {
+			t.Fatalf("expected 4 audit entries but got %d", len(entries))
+		}
+		if entries[0].Action != audit.ActionExternalEndpointTokenUsage || entries[0].Target != "g_n" || !entries[0].Success {
+			t.Errorf("expected last entry to be a successful usage of the token of g_n, got %+v", entries[0])
+		}
+		if entries[3].Success || entries[3].Details != "invalid token" {
+			t.Errorf("expected first entry to be a usage of an invalid token, got %+v", entries[3])
+		}
+	})
+}
+
+func TestCreateExternalEndpointResult_WithBody(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	cfg := &config.Config{
+		ExternalEndpoints: []*endpoint.ExternalEndpoint{
+			{Name: "n", Group: "g", Token: "token"},
+		},
+		Maintenance: &maintenance.Config{},
+	}
+	router := New(cfg).Router()
+	scenarios := []struct {
+		Name         string
+		Path         string
+		Body         string
+		ExpectedCode int
+	}{
+		{
+			Name:         "invalid-body",
+			Path:         "/api/v1/endpoints/g_n/external",
+			Body:         `{"success":`,
+			ExpectedCode: 400,
+		},
+		{
+			Name:         "missing-success",
+			Path:         "/api/v1/endpoints/g_n/external",
+			Body:         `{"duration":"150ms"}`,
+			ExpectedCode: 400,
+		},
+		{
+			Name:         "invalid-duration",
+			Path:         "/api/v1/endpoints/g_n/external",
+			Body:         `{"success":true,"duration":"forever"}`,
+			ExpectedCode: 400,
+		},
+		{
+			Name:         "empty-condition",
+			Path:         "/api/v1/endpoints/g_n/external",
+			Body:         `{"success":true,"conditionResults":[{"condition":"","success":true}]}`,
+			ExpectedCode: 400,
+		},
+		{
+			Name:         "query-parameters",
+			Path:         "/api/v1/endpoints/g_n/external?success=false&duration=2s&error=timeout",
+			ExpectedCode: 200,
+		},
+		{
+			Name:         "body",
+			Path:         "/api/v1/endpoints/g_n/external",
+			Body:         `{"success":true,"duration":"150ms","conditionResults":[{"condition":"[BACKUP_SIZE] > 0","success":true}],"metadata":{"version":"1.2.3"}}`,
+			ExpectedCode: 200,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest("POST", scenario.Path, strings.NewReader(scenario.Body))
+			request.Header.Set("Authorization", "Bearer token")
+			if len(scenario.Body) > 0 {
+				request.Header.Set("Content-Type", "application/json")
+			}
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+		})
+	}
+	t.Run("verify-end-results", func(t *testing.T) {
+		endpointStatus, err := store.Get().GetEndpointStatusByKey("g_n", paging.NewEndpointStatusParams().WithResults(1, 10))
+		if err != nil {
+			t.Fatal("failed to get endpoint status:", err.Error())
+		}
+		if len(endpointStatus.Results) != 2 {
+			t.Fatalf("expected 2 results but got %d", len(endpointStatus.Results))
+		}
+		fromQuery, fromBody := endpointStatus.Results[0], endpointStatus.Results[1]
+		if fromQuery.Success || fromQuery.Duration != 2*time.Second || len(fromQuery.Errors) != 1 || fromQuery.Errors[0] != "timeout" {
+			t.Errorf("expected the result pushed through the query parameters to be a failure of 2s with the error timeout, got %+v", fromQuery)
+		}
+		if !fromBody.Success || fromBody.Duration != 150*time.Millisecond {
+			t.Errorf("expected the result pushed through the body to be a success of 150ms, got %+v", fromBody)
+		}
+		if len(fromBody.ConditionResults) != 1 || fromBody.ConditionResults[0].Condition != "[BACKUP_SIZE] > 0" || !fromBody.ConditionResults[0].Success {
+			t.Errorf("expected the condition results of the body to be persisted, got %+v", fromBody.ConditionResults)
+		}
+		if fromBody.Metadata["version"] != "1.2.3" {
+			t.Errorf("expected the metadata of the body to be persisted, got %+v", fromBody.Metadata)
+		}
+	})
+}
+
+func TestCreateExternalEndpointResults(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	cfg := &config.Config{
+		ExternalEndpoints: []*endpoint.ExternalEndpoint{
+			{Name: "a", Group: "g", Token: "token"},
+			{Name: "b", Group: "g", Token: "token"},
+			{Name: "c", Group: "g", Token: "other-token"},
+		},
+		Maintenance: &maintenance.Config{},
+	}
+	router := New(cfg).Router()
+	tooManyResults := make([]string, MaximumNumberOfResultsPerBatch+1)
+	for i := range tooManyResults {
+		tooManyResults[i] = `{"key":"g_a","success":true}`
+	}
+	scenarios := []struct {
+		Name                           string
+		Body                           string
+		AuthorizationHeaderBearerToken string
+		ExpectedCode                   int
+	}{
+		{
+			Name:                           "no-token",
+			Body:                           `[{"key":"g_a","success":true}]`,
+			AuthorizationHeaderBearerToken: "",
+			ExpectedCode:                   401,
+		},
+		{
+			Name:                           "invalid-body",
+			Body:                           `{"key":"g_a","success":true}`,
+			AuthorizationHeaderBearerToken: "Bearer token",
+			ExpectedCode:                   400,
+		},
+		{
+			Name:                           "empty-batch",
+			Body:                           `[]`,
+			AuthorizationHeaderBearerToken: "Bearer token",
+			ExpectedCode:                   400,
+		},
+		{
+			Name:                           "too-many-results",
+			Body:                           "[" + strings.Join(tooManyResults, ",") + "]",
+			AuthorizationHeaderBearerToken: "Bearer token",
+			ExpectedCode:                   400,
+		},
+		{
+			Name:                           "missing-key",
+			Body:                           `[{"key":"g_a","success":true},{"success":true}]`,
+			AuthorizationHeaderBearerToken: "Bearer token",
+			ExpectedCode:                   400,
+		},
+		{
+			Name:                           "bad-key",
+			Body:                           `[{"key":"g_a","success":true},{"key":"bad_key","success":true}]`,
+			AuthorizationHeaderBearerToken: "Bearer token",
+			ExpectedCode:                   404,
+		},
+		{
+			Name:                           "token-of-another-external-endpoint",
+			Body:                           `[{"key":"g_a","success":true},{"key":"g_c","success":true}]`,
+			AuthorizationHeaderBearerToken: "Bearer token",
+			ExpectedCode:                   401,
+		},
+		{
+			Name:                           "good-token",
+			Body:                           `[{"key":"g_a","success":true,"metadata":{"agent":"agent-1"}},{"key":"g_b","success":false,"errors":["disk full"]},{"key":"g_a","success":false}]`,
+			AuthorizationHeaderBearerToken: "Bearer token",
+			ExpectedCode:                   200,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest("POST", "/api/v1/endpoints/external", strings.NewReader(scenario.Body))
+			request.Header.Set("Content-Type", "application/json")
+			if len(scenario.AuthorizationHeaderBearerToken) > 0 {
+				request.Header.Set("Authorization", scenario.AuthorizationHeaderBearerToken)
+			}
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+		})
+	}
+	t.Run("verify-end-results", func(t *testing.T) {
+		statusOfA, err := store.Get().GetEndpointStatusByKey("g_a", paging.NewEndpointStatusParams().WithResults(1, 10))
+		if err != nil {
+			t.Fatal("failed to get endpoint status:", err.Error())
+		}
+		if len(statusOfA.Results) != 2 {
+			t.Fatalf("expected only the 2 results of the valid batch to be persisted for g_a, got %d", len(statusOfA.Results))
+		}
+		if !statusOfA.Results[0].Success || statusOfA.Results[0].Metadata["agent"] != "agent-1" || statusOfA.Results[1].Success {
+			t.Errorf("expected a success with its metadata followed by a failure, got %+v and %+v", statusOfA.Results[0], statusOfA.Results[1])
+		}
+		statusOfB, err := store.Get().GetEndpointStatusByKey("g_b", paging.NewEndpointStatusParams().WithResults(1, 10))
+		if err != nil {
+			t.Fatal("failed to get endpoint status:", err.Error())
+		}
+		if len(statusOfB.Results) != 1 || statusOfB.Results[0].Success || statusOfB.Results[0].Errors[0] != "disk full" {
+			t.Errorf("expected a single failure with the error disk full for g_b, got %+v", statusOfB.Results)
+		}
+		if _, err := store.Get().GetEndpointStatusByKey("g_c", paging.NewEndpointStatusParams()); err == nil {
+			t.Error("expected no result to be persisted for g_c")
+		}
+	})
 }
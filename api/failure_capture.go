@@ -0,0 +1,35 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+// EndpointFailureCaptures handles requests to retrieve the captures of the responses of the checks of an endpoint that
+// failed, from newest to oldest
+func EndpointFailureCaptures(c *fiber.Ctx) error {
+	failureCaptureStore, ok := store.Get().(store.FailureCaptureStore)
+	if !ok {
+		return c.Status(404).SendString("failure captures are not supported by the configured storage type")
+	}
+	captures, err := failureCaptureStore.GetFailureCapturesByKey(c.Params("key"))
+	if err != nil {
+		if errors.Is(err, common.ErrEndpointNotFound) {
+			return c.Status(404).SendString(err.Error())
+		}
+		log.Printf("[api.EndpointFailureCaptures] Failed to retrieve failure captures: %s", err.Error())
+		return c.Status(500).SendString(err.Error())
+	}
+	output, err := json.Marshal(captures)
+	if err != nil {
+		log.Printf("[api.EndpointFailureCaptures] Unable to marshal object to JSON: %s", err.Error())
+		return c.Status(500).SendString("unable to marshal object to JSON")
+	}
+	c.Set("Content-Type", "application/json")
+	return c.Status(200).Send(output)
+}
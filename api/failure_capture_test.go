@@ -0,0 +1,89 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/endpoint/capture"
+	"github.com/TwiN/gatus/v5/storage"
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+func TestEndpointFailureCaptures(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	cfg := &config.Config{Metrics: true}
+	api := New(cfg)
+	router := api.Router()
+	ep := testEndpoint
+	ep.FailureCaptureConfig = &capture.Config{MaximumBodySize: 16, MaximumCaptures: 5}
+	result := testUnsuccessfulResult
+	result.FailureCapture = &endpoint.FailureCapture{Timestamp: timestamp, HTTPStatus: 500, Body: "oops", Errors: []string{"error-1"}}
+	scenarios := []struct {
+		Name             string
+		Storage          *storage.Config
+		Path             string
+		ExpectedCode     int
+		ExpectedCaptures int
+	}{
+		{
+			Name:         "endpoint-not-found",
+			Storage:      &storage.Config{Type: storage.TypeMemory},
+			Path:         "/api/v1/endpoints/nope/failures",
+			ExpectedCode: http.StatusNotFound,
+		},
+		{
+			Name:             "memory",
+			Storage:          &storage.Config{Type: storage.TypeMemory},
+			Path:             "/api/v1/endpoints/group_name/failures",
+			ExpectedCode:     http.StatusOK,
+			ExpectedCaptures: 1,
+		},
+		{
+			Name:             "sqlite",
+			Storage:          &storage.Config{Type: storage.TypeSQLite, Path: t.TempDir() + "/TestEndpointFailureCaptures.db"},
+			Path:             "/api/v1/endpoints/group_name/failures",
+			ExpectedCode:     http.StatusOK,
+			ExpectedCaptures: 1,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			if err := store.Initialize(scenario.Storage); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			defer store.Initialize(nil)
+			if err := store.Get().Insert(&ep, &result); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			request := httptest.NewRequest("GET", scenario.Path, http.NoBody)
+			response, err := router.Test(request)
+			if err != nil {
+				return
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+			if scenario.ExpectedCode != http.StatusOK {
+				return
+			}
+			body, _ := io.ReadAll(response.Body)
+			var captures []*endpoint.FailureCapture
+			if err := json.Unmarshal(body, &captures); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			if len(captures) != scenario.ExpectedCaptures {
+				t.Fatalf("expected %d captures, got %d", scenario.ExpectedCaptures, len(captures))
+			}
+			if captures[0].Body != "oops" || captures[0].HTTPStatus != 500 {
+				t.Errorf("expected capture with body oops and status 500, got %s and %d", captures[0].Body, captures[0].HTTPStatus)
+			}
+		})
+	}
+}
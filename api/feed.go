@@ -0,0 +1,144 @@
+package api
+
+import (
+	"encoding/xml"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/ui"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+	"github.com/gofiber/fiber/v2"
+)
+
+// MaximumNumberOfFeedEntries is the maximum number of entries in the feed
+const MaximumNumberOfFeedEntries = 100
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Author  atomAuthor  `xml:"author"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	ID       string       `xml:"id"`
+	Title    string       `xml:"title"`
+	Updated  string       `xml:"updated"`
+	Link     atomLink     `xml:"link"`
+	Category atomCategory `xml:"category"`
+	Summary  string       `xml:"summary"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+// Feed handles requests to the Atom feed of the changes of the state of the endpoints, from newest to oldest
+func Feed(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		hiddenGroups := getHiddenGroups(c, cfg.Security, cfg.Groups)
+		cacheKey := "feed-" + c.BaseURL() + hiddenGroupsCacheKey(hiddenGroups)
+		value, exists := cache.Get(cacheKey)
+		var data []byte
+		if !exists {
+			endpointStatuses, err := store.Get().GetAllEndpointStatuses(paging.NewEndpointStatusParams().WithEvents(1, common.MaximumNumberOfEvents))
+			if err != nil {
+				log.Printf("[api.Feed] Failed to retrieve endpoint statuses: %s", err.Error())
+				return c.Status(500).SendString(err.Error())
+			}
+			output, err := xml.MarshalIndent(newAtomFeed(cfg.UI, c.BaseURL(), withoutHiddenGroups(endpointStatuses, hiddenGroups)), "", "  ")
+			if err != nil {
+				log.Printf("[api.Feed] Unable to marshal object to XML: %s", err.Error())
+				return c.Status(500).SendString("unable to marshal object to XML")
+			}
+			data = append([]byte(xml.Header), output...)
+			cache.SetWithTTL(cacheKey, data, cacheTTL)
+		} else {
+			data = value.([]byte)
+		}
+		c.Set("Content-Type", "application/atom+xml; charset=utf-8")
+		return c.Status(200).Send(data)
+	}
+}
+
+// newAtomFeed creates an Atom feed whose entries are the HEALTHY and UNHEALTHY events of the endpoint statuses passed
+// as parameter
+func newAtomFeed(uiConfig *ui.Config, baseURL string, endpointStatuses []*endpoint.Status) *atomFeed {
+	if uiConfig == nil {
+		uiConfig = ui.GetDefaultConfig()
+	}
+	feed := &atomFeed{
+		ID:    baseURL + "/feed.xml",
+		Title: uiConfig.Title,
+		Links: []atomLink{
+			{Rel: "self", Type: "application/atom+xml", Href: baseURL + "/feed.xml"},
+			{Rel: "alternate", Type: "text/html", Href: baseURL + "/"},
+		},
+		Author:  atomAuthor{Name: "Gatus"},
+		Entries: []atomEntry{},
+	}
+	type stateChange struct {
+		endpointStatus *endpoint.Status
+		event          *endpoint.Event
+	}
+	var stateChanges []stateChange
+	for _, endpointStatus := range endpointStatuses {
+		for _, event := range endpointStatus.Events {
+			if event.Type == endpoint.EventHealthy || event.Type == endpoint.EventUnhealthy {
+				stateChanges = append(stateChanges, stateChange{endpointStatus: endpointStatus, event: event})
+			}
+		}
+	}
+	sort.SliceStable(stateChanges, func(i, j int) bool {
+		return stateChanges[i].event.Timestamp.After(stateChanges[j].event.Timestamp)
+	})
+	if len(stateChanges) > MaximumNumberOfFeedEntries {
+		stateChanges = stateChanges[:MaximumNumberOfFeedEntries]
+	}
+	for _, change := range stateChanges {
+		name := change.endpointStatus.Name
+		if len(change.endpointStatus.Group) > 0 {
+			name = change.endpointStatus.Group + "/" + name
+		}
+		timestamp := change.event.Timestamp.UTC().Format(time.RFC3339)
+		entry := atomEntry{
+			ID:       fmt.Sprintf("%s/endpoints/%s#%d", baseURL, change.endpointStatus.Key, change.event.Timestamp.UnixMilli()),
+			Updated:  timestamp,
+			Link:     atomLink{Rel: "alternate", Type: "text/html", Href: baseURL + "/endpoints/" + change.endpointStatus.Key},
+			Category: atomCategory{Term: string(change.event.Type)},
+		}
+		if change.event.Type == endpoint.EventHealthy {
+			entry.Title = name + " is healthy"
+			entry.Summary = fmt.Sprintf("%s started passing all of its conditions at %s.", name, timestamp)
+		} else {
+			entry.Title = name + " is unhealthy"
+			entry.Summary = fmt.Sprintf("%s started failing one or more of its conditions at %s.", name, timestamp)
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+	if len(stateChanges) > 0 {
+		feed.Updated = feed.Entries[0].Updated
+	} else {
+		feed.Updated = time.Now().UTC().Format(time.RFC3339)
+	}
+	return feed
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/ui"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/watchdog"
+)
+
+func TestFeed(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	cfg := &config.Config{
+		UI: &ui.Config{Title: "Example Status"},
+		Endpoints: []*endpoint.Endpoint{
+			{Name: "frontend", Group: "core"},
+			{Name: "website"},
+		},
+	}
+	timestamp := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: true, Timestamp: timestamp})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[1], &endpoint.Result{Success: true, Timestamp: timestamp.Add(time.Minute)})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: false, Timestamp: timestamp.Add(2 * time.Minute)})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: false, Timestamp: timestamp.Add(3 * time.Minute)})
+	response, err := New(cfg).Router().Test(httptest.NewRequest("GET", "/feed.xml", http.NoBody))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected status code %d, got %d", http.StatusOK, response.StatusCode)
+	}
+	if contentType := response.Header.Get("Content-Type"); contentType != "application/atom+xml; charset=utf-8" {
+		t.Errorf("expected Atom content type, got %s", contentType)
+	}
+	body, _ := io.ReadAll(response.Body)
+	var feed atomFeed
+	if err = xml.Unmarshal(body, &feed); err != nil {
+		t.Fatalf("expected valid Atom feed, got %s: %s", body, err.Error())
+	}
+	if feed.Title != "Example Status" || feed.ID != "http://example.com/feed.xml" {
+		t.Errorf("expected feed Example Status with id http://example.com/feed.xml, got %s with id %s", feed.Title, feed.ID)
+	}
+	expectedEntries := []struct {
+		title, updated, link string
+	}{
+		{title: "core/frontend is unhealthy", updated: "2024-01-01T00:02:00Z", link: "http://example.com/endpoints/core_frontend"},
+		{title: "website is healthy", updated: "2024-01-01T00:01:00Z", link: "http://example.com/endpoints/_website"},
+		{title: "core/frontend is healthy", updated: "2024-01-01T00:00:00Z", link: "http://example.com/endpoints/core_frontend"},
+	}
+	if len(feed.Entries) != len(expectedEntries) {
+		t.Fatalf("expected %d entries, got %d in %s", len(expectedEntries), len(feed.Entries), body)
+	}
+	for i, expected := range expectedEntries {
+		entry := feed.Entries[i]
+		if entry.Title != expected.title || entry.Updated != expected.updated || entry.Link.Href != expected.link {
+			t.Errorf("expected entry #%d to be %+v, got %+v", i, expected, entry)
+		}
+	}
+	if feed.Updated != expectedEntries[0].updated {
+		t.Errorf("expected feed to have been updated at %s, got %s", expectedEntries[0].updated, feed.Updated)
+	}
+}
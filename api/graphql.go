@@ -0,0 +1,359 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/TwiN/gatus/v5/api/graphql"
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+	"github.com/TwiN/gatus/v5/watchdog"
+	"github.com/gofiber/fiber/v2"
+)
+
+// graphQLSchema is the schema of the GraphQL API, which exposes the endpoints, their groups, results, events and uptime
+var graphQLSchema = newGraphQLSchema()
+
+// endpointGroup is the value of the Group type of the GraphQL API
+type endpointGroup struct {
+	name             string
+	endpointStatuses []*endpoint.Status
+}
+
+// graphQLRoot is the root value of the queries, which holds what the resolvers need to know about the client
+type graphQLRoot struct {
+	hiddenGroups map[string]bool
+}
+
+// GraphQL handles GraphQL queries, which are read from the JSON body of POST requests, or from the query, variables
+// and operationName query parameters of GET requests. The endpoints that are part of a group the client isn't allowed
+// to see are left out.
+func GraphQL(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		request := &graphql.Request{RootValue: &graphQLRoot{hiddenGroups: getHiddenGroups(c, cfg.Security, cfg.Groups)}}
+		if c.Method() == fiber.MethodPost {
+			decoder := json.NewDecoder(bytes.NewReader(c.Body()))
+			decoder.UseNumber()
+			if err := decoder.Decode(request); err != nil {
+				return c.Status(400).SendString("invalid request body: " + err.Error())
+			}
+		} else {
+			request.Query, request.OperationName = c.Query("query"), c.Query("operationName")
+			if variables := c.Query("variables"); len(variables) > 0 {
+				decoder := json.NewDecoder(bytes.NewReader([]byte(variables)))
+				decoder.UseNumber()
+				if err := decoder.Decode(&request.Variables); err != nil {
+					return c.Status(400).SendString("invalid variables: " + err.Error())
+				}
+			}
+		}
+		if len(request.Query) == 0 {
+			return c.Status(400).SendString("query must not be empty")
+		}
+		output, err := json.Marshal(graphQLSchema.Execute(request))
+		if err != nil {
+			log.Printf("[api.GraphQL] Unable to marshal object to JSON: %s", err.Error())
+			return c.Status(500).SendString("unable to marshal object to JSON")
+		}
+		c.Set("Content-Type", "application/json")
+		return c.Status(200).Send(output)
+	}
+}
+
+func newGraphQLSchema() *graphql.Schema {
+	conditionResultType := &graphql.Object{
+		Name: "ConditionResult",
+		Fields: map[string]*graphql.Field{
+			"condition": {Type: &graphql.NonNull{OfType: graphql.String}, Resolve: func(source any, _ map[string]any) (any, error) {
+				return source.(*endpoint.ConditionResult).Condition, nil
+			}},
+			"success": {Type: &graphql.NonNull{OfType: graphql.Boolean}, Resolve: func(source any, _ map[string]any) (any, error) {
+				return source.(*endpoint.ConditionResult).Success, nil
+			}},
+		},
+	}
+	resultType := &graphql.Object{
+		Name: "Result",
+		Fields: map[string]*graphql.Field{
+			"status": {Type: graphql.Int, Resolve: func(source any, _ map[string]any) (any, error) {
+				if status := source.(*endpoint.Result).HTTPStatus; status != 0 {
+					return status, nil
+				}
+				return nil, nil
+			}},
+			"hostname": {Type: graphql.String, Resolve: func(source any, _ map[string]any) (any, error) {
+				if hostname := source.(*endpoint.Result).Hostname; len(hostname) > 0 {
+					return hostname, nil
+				}
+				return nil, nil
+			}},
+			"responseTime": {Type: &graphql.NonNull{OfType: graphql.Int}, Resolve: func(source any, _ map[string]any) (any, error) {
+				return int(source.(*endpoint.Result).Duration.Milliseconds()), nil
+			}},
+			"errors": {Type: &graphql.NonNull{OfType: &graphql.List{OfType: &graphql.NonNull{OfType: graphql.String}}}, Resolve: func(source any, _ map[string]any) (any, error) {
+				if errs := source.(*endpoint.Result).Errors; errs != nil {
+					return errs, nil
+				}
+				return []string{}, nil
+			}},
+			"conditionResults": {Type: &graphql.NonNull{OfType: &graphql.List{OfType: &graphql.NonNull{OfType: conditionResultType}}}, Resolve: func(source any, _ map[string]any) (any, error) {
+				if conditionResults := source.(*endpoint.Result).ConditionResults; conditionResults != nil {
+					return conditionResults, nil
+				}
+				return []*endpoint.ConditionResult{}, nil
+			}},
+			"success": {Type: &graphql.NonNull{OfType: graphql.Boolean}, Resolve: func(source any, _ map[string]any) (any, error) {
+				return source.(*endpoint.Result).Success, nil
+			}},
+			"timestamp": {Type: &graphql.NonNull{OfType: graphql.String}, Resolve: func(source any, _ map[string]any) (any, error) {
+				return source.(*endpoint.Result).Timestamp.Format(time.RFC3339), nil
+			}},
+		},
+	}
+	eventType := &graphql.Object{
+		Name: "Event",
+		Fields: map[string]*graphql.Field{
+			"type": {Type: &graphql.NonNull{OfType: graphql.String}, Resolve: func(source any, _ map[string]any) (any, error) {
+				return string(source.(*endpoint.Event).Type), nil
+			}},
+			"timestamp": {Type: &graphql.NonNull{OfType: graphql.String}, Resolve: func(source any, _ map[string]any) (any, error) {
+				return source.(*endpoint.Event).Timestamp.Format(time.RFC3339), nil
+			}},
+		},
+	}
+	endpointType := &graphql.Object{
+		Name: "Endpoint",
+		Fields: map[string]*graphql.Field{
+			"key": {Type: &graphql.NonNull{OfType: graphql.String}, Resolve: func(source any, _ map[string]any) (any, error) {
+				return source.(*endpoint.Status).Key, nil
+			}},
+			"name": {Type: &graphql.NonNull{OfType: graphql.String}, Resolve: func(source any, _ map[string]any) (any, error) {
+				return source.(*endpoint.Status).Name, nil
+			}},
+			"group": {Type: graphql.String, Resolve: func(source any, _ map[string]any) (any, error) {
+				if group := source.(*endpoint.Status).Group; len(group) > 0 {
+					return group, nil
+				}
+				return nil, nil
+			}},
+			"healthy": {Type: graphql.Boolean, Resolve: func(source any, _ map[string]any) (any, error) {
+				return isEndpointHealthy(source.(*endpoint.Status)), nil
+			}},
+			"paused": {Type: &graphql.NonNull{OfType: graphql.Boolean}, Resolve: func(source any, _ map[string]any) (any, error) {
+				return watchdog.IsPaused(source.(*endpoint.Status).Key), nil
+			}},
+			"results": {
+				Type: &graphql.NonNull{OfType: &graphql.List{OfType: &graphql.NonNull{OfType: resultType}}},
+				Args: map[string]*graphql.Argument{
+					"page":     {Type: graphql.Int, DefaultValue: DefaultPage},
+					"pageSize": {Type: graphql.Int, DefaultValue: DefaultPageSize},
+				},
+				Resolve: func(source any, args map[string]any) (any, error) {
+					page, pageSize, err := getPageAndPageSizeFromGraphQLArguments(args, MaximumPageSize)
+					if err != nil {
+						return nil, err
+					}
+					endpointStatus, err := store.Get().GetEndpointStatusByKey(source.(*endpoint.Status).Key, paging.NewEndpointStatusParams().WithResults(page, pageSize))
+					if err != nil {
+						return nil, err
+					}
+					return endpointStatus.Results, nil
+				},
+			},
+			"events": {
+				Type: &graphql.NonNull{OfType: &graphql.List{OfType: &graphql.NonNull{OfType: eventType}}},
+				Args: map[string]*graphql.Argument{
+					"page":     {Type: graphql.Int, DefaultValue: DefaultPage},
+					"pageSize": {Type: graphql.Int, DefaultValue: common.MaximumNumberOfEvents},
+				},
+				Resolve: func(source any, args map[string]any) (any, error) {
+					page, pageSize, err := getPageAndPageSizeFromGraphQLArguments(args, common.MaximumNumberOfEvents)
+					if err != nil {
+						return nil, err
+					}
+					endpointStatus, err := store.Get().GetEndpointStatusByKey(source.(*endpoint.Status).Key, paging.NewEndpointStatusParams().WithEvents(page, pageSize))
+					if err != nil {
+						return nil, err
+					}
+					return endpointStatus.Events, nil
+				},
+			},
+			"uptime": {
+				Type: graphql.Float,
+				Args: map[string]*graphql.Argument{"duration": {Type: graphql.String, DefaultValue: "24h"}},
+				Resolve: func(source any, args map[string]any) (any, error) {
+					from, err := getFromFromGraphQLDuration(args["duration"])
+					if err != nil {
+						return nil, err
+					}
+					return store.Get().GetUptimeByKey(source.(*endpoint.Status).Key, from, time.Now())
+				},
+			},
+			"averageResponseTime": {
+				Type: graphql.Int,
+				Args: map[string]*graphql.Argument{"duration": {Type: graphql.String, DefaultValue: "24h"}},
+				Resolve: func(source any, args map[string]any) (any, error) {
+					from, err := getFromFromGraphQLDuration(args["duration"])
+					if err != nil {
+						return nil, err
+					}
+					return store.Get().GetAverageResponseTimeByKey(source.(*endpoint.Status).Key, from, time.Now())
+				},
+			},
+		},
+	}
+	groupType := &graphql.Object{
+		Name: "Group",
+		Fields: map[string]*graphql.Field{
+			"name": {Type: graphql.String, Resolve: func(source any, _ map[string]any) (any, error) {
+				if name := source.(*endpointGroup).name; len(name) > 0 {
+					return name, nil
+				}
+				return nil, nil
+			}},
+			"endpoints": {
+				Type: &graphql.NonNull{OfType: &graphql.List{OfType: &graphql.NonNull{OfType: endpointType}}},
+				Args: map[string]*graphql.Argument{"healthy": {Type: graphql.Boolean}},
+				Resolve: func(source any, args map[string]any) (any, error) {
+					return filterEndpointStatuses(source.(*endpointGroup).endpointStatuses, args), nil
+				},
+			},
+		},
+	}
+	return graphql.NewSchema(&graphql.Object{
+		Name: "Query",
+		Fields: map[string]*graphql.Field{
+			"endpoints": {
+				Type: &graphql.NonNull{OfType: &graphql.List{OfType: &graphql.NonNull{OfType: endpointType}}},
+				Args: map[string]*graphql.Argument{
+					"group":   {Type: graphql.String},
+					"name":    {Type: graphql.String},
+					"keys":    {Type: &graphql.List{OfType: &graphql.NonNull{OfType: graphql.String}}},
+					"healthy": {Type: graphql.Boolean},
+				},
+				Resolve: func(source any, args map[string]any) (any, error) {
+					endpointStatuses, err := store.Get().GetAllEndpointStatuses(paging.NewEndpointStatusParams().WithResults(1, 1))
+					if err != nil {
+						return nil, err
+					}
+					return filterEndpointStatuses(withoutHiddenGroups(endpointStatuses, source.(*graphQLRoot).hiddenGroups), args), nil
+				},
+			},
+			"endpoint": {
+				Type: endpointType,
+				Args: map[string]*graphql.Argument{"key": {Type: &graphql.NonNull{OfType: graphql.String}}},
+				Resolve: func(source any, args map[string]any) (any, error) {
+					endpointStatus, err := store.Get().GetEndpointStatusByKey(args["key"].(string), paging.NewEndpointStatusParams().WithResults(1, 1))
+					if errors.Is(err, common.ErrEndpointNotFound) || (err == nil && source.(*graphQLRoot).hiddenGroups[endpointStatus.Group]) {
+						return nil, nil
+					}
+					return endpointStatus, err
+				},
+			},
+			"groups": {
+				Type: &graphql.NonNull{OfType: &graphql.List{OfType: &graphql.NonNull{OfType: groupType}}},
+				Resolve: func(source any, _ map[string]any) (any, error) {
+					endpointStatuses, err := store.Get().GetAllEndpointStatuses(paging.NewEndpointStatusParams().WithResults(1, 1))
+					if err != nil {
+						return nil, err
+					}
+					endpointStatuses = withoutHiddenGroups(endpointStatuses, source.(*graphQLRoot).hiddenGroups)
+					var groups []*endpointGroup
+					groupByName := make(map[string]*endpointGroup)
+					for _, endpointStatus := range endpointStatuses {
+						group, exists := groupByName[endpointStatus.Group]
+						if !exists {
+							group = &endpointGroup{name: endpointStatus.Group}
+							groupByName[endpointStatus.Group] = group
+							groups = append(groups, group)
+						}
+						group.endpointStatuses = append(group.endpointStatuses, endpointStatus)
+					}
+					return groups, nil
+				},
+			},
+		},
+	})
+}
+
+// filterEndpointStatuses returns the endpoint statuses that match the group, name, keys and healthy arguments that
+// were provided
+func filterEndpointStatuses(endpointStatuses []*endpoint.Status, args map[string]any) []*endpoint.Status {
+	filtered := make([]*endpoint.Status, 0, len(endpointStatuses))
+	for _, endpointStatus := range endpointStatuses {
+		if group, ok := args["group"].(string); ok && endpointStatus.Group != group {
+			continue
+		}
+		if name, ok := args["name"].(string); ok && endpointStatus.Name != name {
+			continue
+		}
+		if keys, ok := args["keys"].([]any); ok && !containsKey(keys, endpointStatus.Key) {
+			continue
+		}
+		if healthy, ok := args["healthy"].(bool); ok {
+			if isHealthy, _ := isEndpointHealthy(endpointStatus).(bool); isHealthy != healthy {
+				continue
+			}
+		}
+		filtered = append(filtered, endpointStatus)
+	}
+	return filtered
+}
+
+func containsKey(keys []any, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// isEndpointHealthy returns whether the most recent result of the endpoint status is successful, or nil if the
+// endpoint has no results yet
+func isEndpointHealthy(endpointStatus *endpoint.Status) any {
+	if len(endpointStatus.Results) == 0 {
+		return nil
+	}
+	return endpointStatus.Results[len(endpointStatus.Results)-1].Success
+}
+
+func getPageAndPageSizeFromGraphQLArguments(args map[string]any, maximumPageSize int) (page, pageSize int, err error) {
+	page, _ = args["page"].(int)
+	pageSize, _ = args["pageSize"].(int)
+	if page < 1 {
+		return 0, 0, errors.New("page must be greater than 0")
+	}
+	if pageSize < 1 || pageSize > maximumPageSize {
+		return 0, 0, fmt.Errorf("pageSize must be between 1 and %d", maximumPageSize)
+	}
+	return page, pageSize, nil
+}
+
+// getFromFromGraphQLDuration returns the start of the time range described by the duration passed as parameter
+//
+// Valid values for duration -> 365d, 90d, 30d, 7d, 24h, 1h
+func getFromFromGraphQLDuration(duration any) (time.Time, error) {
+	switch duration {
+	case "365d":
+		return time.Now().Add(-365 * 24 * time.Hour), nil
+	case "90d":
+		return time.Now().Add(-90 * 24 * time.Hour), nil
+	case "30d":
+		return time.Now().Add(-30 * 24 * time.Hour), nil
+	case "7d":
+		return time.Now().Add(-7 * 24 * time.Hour), nil
+	case "24h":
+		return time.Now().Add(-24 * time.Hour), nil
+	case "1h":
+		return time.Now().Add(-2 * time.Hour), nil // Because uptime metrics are stored by hour, we have to cheat a little
+	}
+	return time.Time{}, errors.New("durations supported: 365d, 90d, 30d, 7d, 24h, 1h")
+}
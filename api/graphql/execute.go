@@ -0,0 +1,347 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Request is a request to execute a query
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+
+	// RootValue is the source passed to the resolvers of the fields of the query type
+	RootValue any `json:"-"`
+}
+
+// Response is the result of the execution of a query.
+//
+// If the query is invalid, Data is nil and Errors describes why. Otherwise, Data holds the fields that were resolved,
+// and Errors describes why the other fields are null.
+type Response struct {
+	Data   *OrderedMap `json:"data,omitempty"`
+	Errors []*Error    `json:"errors,omitempty"`
+}
+
+// Error is an error that occurred while validating or executing a query
+type Error struct {
+	Message   string     `json:"message"`
+	Locations []Location `json:"locations,omitempty"`
+	Path      []any      `json:"path,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Location is the location of an error in the query
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+func newError(location Location, format string, a ...any) *Error {
+	return &Error{Message: fmt.Sprintf(format, a...), Locations: []Location{location}}
+}
+
+// OrderedMap is a JSON object whose keys are marshaled in the order in which they were selected by the query
+type OrderedMap struct {
+	keys   []string
+	values map[string]any
+}
+
+func (m *OrderedMap) set(key string, value any) {
+	if m.values == nil {
+		m.values = make(map[string]any)
+	}
+	if _, exists := m.values[key]; !exists {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+// Get returns the value of the key passed as parameter
+func (m *OrderedMap) Get(key string) any {
+	return m.values[key]
+}
+
+// MarshalJSON marshals the map to a JSON object whose keys are in the order in which they were selected by the query
+func (m *OrderedMap) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		encodedKey, _ := json.Marshal(key)
+		buffer.Write(encodedKey)
+		buffer.WriteByte(':')
+		encodedValue, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(encodedValue)
+	}
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
+
+// Execute validates and executes the query of the request passed as parameter
+func (s *Schema) Execute(request *Request) *Response {
+	doc, err := parse(request.Query)
+	if err != nil {
+		return &Response{Errors: []*Error{err.(*Error)}}
+	}
+	op, err := selectOperation(doc, request.OperationName)
+	if err != nil {
+		return &Response{Errors: []*Error{err.(*Error)}}
+	}
+	if errs := s.validate(doc, op); len(errs) > 0 {
+		return &Response{Errors: errs}
+	}
+	variables, errs := s.coerceVariables(op, request.Variables)
+	if len(errs) > 0 {
+		return &Response{Errors: errs}
+	}
+	e := &executor{schema: s, document: doc, variables: variables}
+	data, _ := e.executeSelectionSet(s.query, request.RootValue, op.selectionSet, nil)
+	return &Response{Data: data, Errors: e.errors}
+}
+
+func selectOperation(doc *document, operationName string) (*operation, error) {
+	if len(operationName) == 0 {
+		if len(doc.operations) > 1 {
+			return nil, &Error{Message: "Must provide operation name if query contains multiple operations."}
+		}
+		return doc.operations[0], nil
+	}
+	for _, op := range doc.operations {
+		if op.name == operationName {
+			return op, nil
+		}
+	}
+	return nil, &Error{Message: fmt.Sprintf("Unknown operation named \"%s\".", operationName)}
+}
+
+func (s *Schema) coerceVariables(op *operation, values map[string]any) (map[string]any, []*Error) {
+	variables := make(map[string]any)
+	var errs []*Error
+	for _, definition := range op.variables {
+		t, _ := s.inputType(definition.typeName)
+		value, provided := values[definition.name]
+		if !provided {
+			if definition.hasDefault {
+				variables[definition.name], _ = coerceInput(t, definition.defaultValue)
+			} else if definition.nonNull {
+				errs = append(errs, &Error{Message: fmt.Sprintf("Variable \"$%s\" of required type \"%s\" was not provided.", definition.name, definition.typeName)})
+			}
+			continue
+		}
+		coerced, err := coerceInput(t, value)
+		if err != nil {
+			errs = append(errs, &Error{Message: fmt.Sprintf("Variable \"$%s\" got invalid value: %s.", definition.name, err.Error())})
+			continue
+		}
+		variables[definition.name] = coerced
+	}
+	return variables, errs
+}
+
+type executor struct {
+	schema    *Schema
+	document  *document
+	variables map[string]any
+	errors    []*Error
+}
+
+// executeSelectionSet resolves the fields selected on the object passed as parameter. If a non-null field is null,
+// the object is null, and false is returned.
+func (e *executor) executeSelectionSet(object *Object, source any, selections []selection, path []any) (*OrderedMap, bool) {
+	result := &OrderedMap{}
+	keys, fields := e.collectFields(object, selections, make(map[string]bool), nil, nil)
+	for _, key := range keys {
+		fieldNodes := fields[key]
+		fieldPath := append(append([]any{}, path...), key)
+		if fieldNodes[0].name == "__typename" {
+			result.set(key, object.Name)
+			continue
+		}
+		definition := object.Fields[fieldNodes[0].name]
+		value, ok := e.executeField(definition, source, fieldNodes, fieldPath)
+		if !ok {
+			return nil, false
+		}
+		result.set(key, value)
+	}
+	return result, true
+}
+
+func (e *executor) executeField(definition *Field, source any, fieldNodes []*field, path []any) (any, bool) {
+	_, isNonNull := definition.Type.(*NonNull)
+	args, err := e.coerceArguments(definition.Args, fieldNodes[0].arguments)
+	if err == nil {
+		var value any
+		if value, err = definition.Resolve(source, args); err == nil {
+			return e.completeValue(definition.Type, fieldNodes, value, path)
+		}
+	}
+	e.errors = append(e.errors, &Error{Message: err.Error(), Locations: []Location{fieldNodes[0].location}, Path: path})
+	return nil, !isNonNull
+}
+
+func (e *executor) coerceArguments(definitions map[string]*Argument, values map[string]any) (map[string]any, error) {
+	args := make(map[string]any)
+	for name, definition := range definitions {
+		value, provided := values[name]
+		if v, isVariable := value.(variable); isVariable {
+			value, provided = e.variables[string(v)]
+		}
+		if !provided {
+			if definition.DefaultValue != nil {
+				args[name] = definition.DefaultValue
+			} else if _, isNonNull := definition.Type.(*NonNull); isNonNull {
+				return nil, fmt.Errorf("argument \"%s\" of type \"%s\" was not provided", name, definition.Type)
+			}
+			continue
+		}
+		coerced, err := coerceInput(definition.Type, replaceVariables(value, e.variables))
+		if err != nil {
+			return nil, fmt.Errorf("argument \"%s\" has invalid value: %s", name, err.Error())
+		}
+		args[name] = coerced
+	}
+	return args, nil
+}
+
+// completeValue converts the resolved value to the type passed as parameter. If the value is null and the type is
+// non-null, false is returned.
+func (e *executor) completeValue(t Type, fieldNodes []*field, value any, path []any) (any, bool) {
+	if nonNull, isNonNull := t.(*NonNull); isNonNull {
+		completed, ok := e.completeValue(nonNull.OfType, fieldNodes, value, path)
+		if ok && completed == nil {
+			e.errors = append(e.errors, &Error{Message: fmt.Sprintf("Cannot return null for non-nullable field %s.", fieldNodes[0].name), Locations: []Location{fieldNodes[0].location}, Path: path})
+		}
+		return completed, ok && completed != nil
+	}
+	if isNil(value) {
+		return nil, true
+	}
+	switch t := t.(type) {
+	case *List:
+		items := reflect.ValueOf(value)
+		if items.Kind() != reflect.Slice && items.Kind() != reflect.Array {
+			e.errors = append(e.errors, &Error{Message: fmt.Sprintf("Expected a list for field %s.", fieldNodes[0].name), Locations: []Location{fieldNodes[0].location}, Path: path})
+			return nil, true
+		}
+		completed := make([]any, 0, items.Len())
+		for i := 0; i < items.Len(); i++ {
+			item, ok := e.completeValue(t.OfType, fieldNodes, items.Index(i).Interface(), append(append([]any{}, path...), i))
+			if !ok {
+				return nil, true
+			}
+			completed = append(completed, item)
+		}
+		return completed, true
+	case *Scalar:
+		serialized, ok := t.serialize(value)
+		if !ok {
+			e.errors = append(e.errors, &Error{Message: fmt.Sprintf("%s cannot represent %s.", t.Name, describe(value)), Locations: []Location{fieldNodes[0].location}, Path: path})
+			return nil, true
+		}
+		return serialized, true
+	case *Object:
+		var selections []selection
+		for _, fieldNode := range fieldNodes {
+			selections = append(selections, fieldNode.selectionSet...)
+		}
+		object, ok := e.executeSelectionSet(t, value, selections, path)
+		if !ok {
+			return nil, true
+		}
+		return object, true
+	}
+	return nil, true
+}
+
+// collectFields returns the fields selected on the object passed as parameter by their response key, in the order in
+// which they were selected, along with said order
+func (e *executor) collectFields(object *Object, selections []selection, visitedFragments map[string]bool, keys []string, fields map[string][]*field) ([]string, map[string][]*field) {
+	if fields == nil {
+		fields = make(map[string][]*field)
+	}
+	for _, s := range selections {
+		switch s := s.(type) {
+		case *field:
+			if !e.shouldInclude(s.directives) {
+				continue
+			}
+			key := s.responseKey()
+			if _, exists := fields[key]; !exists {
+				keys = append(keys, key)
+			}
+			fields[key] = append(fields[key], s)
+		case *inlineFragment:
+			if !e.shouldInclude(s.directives) || (len(s.typeCondition) > 0 && s.typeCondition != object.Name) {
+				continue
+			}
+			keys, fields = e.collectFields(object, s.selectionSet, visitedFragments, keys, fields)
+		case *fragmentSpread:
+			if visitedFragments[s.name] || !e.shouldInclude(s.directives) {
+				continue
+			}
+			visitedFragments[s.name] = true
+			f := e.document.fragments[s.name]
+			if f.typeCondition != object.Name {
+				continue
+			}
+			keys, fields = e.collectFields(object, f.selectionSet, visitedFragments, keys, fields)
+		}
+	}
+	return keys, fields
+}
+
+// shouldInclude returns whether the @skip and @include directives passed as parameter allow the selection
+func (e *executor) shouldInclude(directives []*directive) bool {
+	for _, d := range directives {
+		condition, _ := coerceInput(Boolean, replaceVariables(d.arguments["if"], e.variables))
+		if (d.name == "skip" && condition == true) || (d.name == "include" && condition != true) {
+			return false
+		}
+	}
+	return true
+}
+
+// replaceVariables returns the value passed as parameter with the variables it references replaced by their values
+func replaceVariables(value any, variables map[string]any) any {
+	switch value := value.(type) {
+	case variable:
+		return variables[string(value)]
+	case []any:
+		replaced := make([]any, 0, len(value))
+		for _, item := range value {
+			replaced = append(replaced, replaceVariables(item, variables))
+		}
+		return replaced
+	case map[string]any:
+		replaced := make(map[string]any, len(value))
+		for key, item := range value {
+			replaced[key] = replaceVariables(item, variables)
+		}
+		return replaced
+	}
+	return value
+}
+
+func isNil(value any) bool {
+	if value == nil {
+		return true
+	}
+	switch v := reflect.ValueOf(value); v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface, reflect.Func:
+		return v.IsNil()
+	}
+	return false
+}
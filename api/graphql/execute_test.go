@@ -0,0 +1,264 @@
+package graphql
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type testBook struct {
+	Title  string
+	Pages  int
+	Author *testAuthor
+}
+
+type testAuthor struct {
+	Name string
+}
+
+func newTestSchema() *Schema {
+	authorType := &Object{
+		Name: "Author",
+		Fields: map[string]*Field{
+			"name": {Type: &NonNull{OfType: String}, Resolve: func(source any, _ map[string]any) (any, error) {
+				return source.(*testAuthor).Name, nil
+			}},
+			"failing": {Type: String, Resolve: func(_ any, _ map[string]any) (any, error) {
+				return nil, errors.New("failed")
+			}},
+			"failingNonNull": {Type: &NonNull{OfType: String}, Resolve: func(_ any, _ map[string]any) (any, error) {
+				return nil, errors.New("failed")
+			}},
+		},
+	}
+	bookType := &Object{
+		Name: "Book",
+		Fields: map[string]*Field{
+			"title": {Type: &NonNull{OfType: String}, Resolve: func(source any, _ map[string]any) (any, error) {
+				return source.(*testBook).Title, nil
+			}},
+			"pages": {Type: Int, Resolve: func(source any, _ map[string]any) (any, error) {
+				return source.(*testBook).Pages, nil
+			}},
+			"author": {Type: authorType, Resolve: func(source any, _ map[string]any) (any, error) {
+				return source.(*testBook).Author, nil
+			}},
+		},
+	}
+	books := []*testBook{
+		{Title: "Dune", Pages: 412, Author: &testAuthor{Name: "Frank Herbert"}},
+		{Title: "Anonymous", Pages: 100},
+	}
+	return NewSchema(&Object{
+		Name: "Query",
+		Fields: map[string]*Field{
+			"books": {
+				Type: &NonNull{OfType: &List{OfType: &NonNull{OfType: bookType}}},
+				Args: map[string]*Argument{
+					"titles":   {Type: &List{OfType: &NonNull{OfType: String}}},
+					"minPages": {Type: Int, DefaultValue: 0},
+				},
+				Resolve: func(_ any, args map[string]any) (any, error) {
+					var filtered []*testBook
+					for _, book := range books {
+						if titles, ok := args["titles"].([]any); ok {
+							found := false
+							for _, title := range titles {
+								found = found || title == book.Title
+							}
+							if !found {
+								continue
+							}
+						}
+						if book.Pages >= args["minPages"].(int) {
+							filtered = append(filtered, book)
+						}
+					}
+					return filtered, nil
+				},
+			},
+			"book": {
+				Type: bookType,
+				Args: map[string]*Argument{"title": {Type: &NonNull{OfType: String}}},
+				Resolve: func(_ any, args map[string]any) (any, error) {
+					for _, book := range books {
+						if book.Title == args["title"] {
+							return book, nil
+						}
+					}
+					return nil, nil
+				},
+			},
+			"ratio": {Type: Float, Args: map[string]*Argument{"value": {Type: Float}}, Resolve: func(_ any, args map[string]any) (any, error) {
+				return args["value"], nil
+			}},
+		},
+	})
+}
+
+func TestSchema_Execute(t *testing.T) {
+	schema := newTestSchema()
+	scenarios := []struct {
+		name             string
+		request          *Request
+		expectedResponse string
+	}{
+		{
+			name:             "field-selection",
+			request:          &Request{Query: `{ books { title } }`},
+			expectedResponse: `{"data":{"books":[{"title":"Dune"},{"title":"Anonymous"}]}}`,
+		},
+		{
+			name:             "aliases-and-order",
+			request:          &Request{Query: `query { b: book(title: "Dune") { pages, name: title, __typename } }`},
+			expectedResponse: `{"data":{"b":{"pages":412,"name":"Dune","__typename":"Book"}}}`,
+		},
+		{
+			name:             "null-object",
+			request:          &Request{Query: `{ book(title: "Anonymous") { author { name } } missing: book(title: "Missing") { title } }`},
+			expectedResponse: `{"data":{"book":{"author":null},"missing":null}}`,
+		},
+		{
+			name:             "argument-default-value",
+			request:          &Request{Query: `{ all: books { title } long: books(minPages: 200) { title } }`},
+			expectedResponse: `{"data":{"all":[{"title":"Dune"},{"title":"Anonymous"}],"long":[{"title":"Dune"}]}}`,
+		},
+		{
+			name:             "list-argument-from-single-value",
+			request:          &Request{Query: `{ books(titles: "Anonymous") { title } }`},
+			expectedResponse: `{"data":{"books":[{"title":"Anonymous"}]}}`,
+		},
+		{
+			name:             "variables",
+			request:          &Request{Query: `query Books($titles: [String!], $minPages: Int = 1) { books(titles: $titles, minPages: $minPages) { title } }`, Variables: map[string]any{"titles": []any{"Dune"}}},
+			expectedResponse: `{"data":{"books":[{"title":"Dune"}]}}`,
+		},
+		{
+			name:             "variables-from-json-numbers",
+			request:          &Request{Query: `query ($minPages: Int!, $value: Float) { books(minPages: $minPages) { title } ratio(value: $value) }`, Variables: map[string]any{"minPages": json.Number("200"), "value": json.Number("0.5")}},
+			expectedResponse: `{"data":{"books":[{"title":"Dune"}],"ratio":0.5}}`,
+		},
+		{
+			name:             "fragments-and-directives",
+			request:          &Request{Query: `query ($withPages: Boolean!) { book(title: "Dune") { ...BookFields ... on Book { author { name } } pages @include(if: $withPages) title @skip(if: true) } } fragment BookFields on Book { title }`, Variables: map[string]any{"withPages": false}},
+			expectedResponse: `{"data":{"book":{"title":"Dune","author":{"name":"Frank Herbert"}}}}`,
+		},
+		{
+			name:             "operation-name",
+			request:          &Request{Query: `query A { books { title } } query B { book(title: "Dune") { pages } }`, OperationName: "B"},
+			expectedResponse: `{"data":{"book":{"pages":412}}}`,
+		},
+		{
+			name:             "resolver-error",
+			request:          &Request{Query: `{ book(title: "Dune") { title author { failing } } }`},
+			expectedResponse: `{"data":{"book":{"title":"Dune","author":{"failing":null}}},"errors":[{"message":"failed","locations":[{"line":1,"column":40}],"path":["book","author","failing"]}]}`,
+		},
+		{
+			name:             "resolver-error-of-non-null-field",
+			request:          &Request{Query: `{ book(title: "Dune") { title author { failingNonNull } } }`},
+			expectedResponse: `{"data":{"book":{"title":"Dune","author":null}},"errors":[{"message":"failed","locations":[{"line":1,"column":40}],"path":["book","author","failingNonNull"]}]}`,
+		},
+		{
+			name:             "syntax-error",
+			request:          &Request{Query: `{ books { title }`},
+			expectedResponse: `{"errors":[{"message":"Syntax Error: Unexpected \u003cEOF\u003e.","locations":[{"line":1,"column":18}]}]}`,
+		},
+		{
+			name:             "mutation",
+			request:          &Request{Query: `mutation { books { title } }`},
+			expectedResponse: `{"errors":[{"message":"Only query operations are supported.","locations":[{"line":1,"column":1}]}]}`,
+		},
+		{
+			name:             "unknown-field",
+			request:          &Request{Query: `{ books { isbn } }`},
+			expectedResponse: `{"errors":[{"message":"Cannot query field \"isbn\" on type \"Book\".","locations":[{"line":1,"column":11}]}]}`,
+		},
+		{
+			name:             "unknown-argument",
+			request:          &Request{Query: `{ books(author: "Frank Herbert") { title } }`},
+			expectedResponse: `{"errors":[{"message":"Unknown argument \"author\" on field \"Query.books\".","locations":[{"line":1,"column":3}]}]}`,
+		},
+		{
+			name:             "missing-required-argument",
+			request:          &Request{Query: `{ book { title } }`},
+			expectedResponse: `{"errors":[{"message":"Field \"book\" argument \"title\" of type \"String!\" is required, but it was not provided.","locations":[{"line":1,"column":3}]}]}`,
+		},
+		{
+			name:             "invalid-argument",
+			request:          &Request{Query: `{ books(minPages: "many") { title } }`},
+			expectedResponse: `{"errors":[{"message":"Argument \"minPages\" has invalid value: Int cannot represent value \"many\".","locations":[{"line":1,"column":3}]}]}`,
+		},
+		{
+			name:             "missing-selection",
+			request:          &Request{Query: `{ books }`},
+			expectedResponse: `{"errors":[{"message":"Field \"books\" of type \"[Book!]!\" must have a selection of subfields.","locations":[{"line":1,"column":3}]}]}`,
+		},
+		{
+			name:             "selection-on-scalar",
+			request:          &Request{Query: `{ books { title { length } } }`},
+			expectedResponse: `{"errors":[{"message":"Field \"title\" must not have a selection since type \"String!\" has no subfields.","locations":[{"line":1,"column":11}]}]}`,
+		},
+		{
+			name:             "undefined-variable",
+			request:          &Request{Query: `{ books(minPages: $minPages) { title } }`},
+			expectedResponse: `{"errors":[{"message":"Variable \"$minPages\" is not defined.","locations":[{"line":1,"column":3}]}]}`,
+		},
+		{
+			name:             "missing-variable",
+			request:          &Request{Query: `query ($minPages: Int!) { books(minPages: $minPages) { title } }`},
+			expectedResponse: `{"errors":[{"message":"Variable \"$minPages\" of required type \"Int!\" was not provided."}]}`,
+		},
+		{
+			name:             "invalid-variable",
+			request:          &Request{Query: `query ($minPages: Int) { books(minPages: $minPages) { title } }`, Variables: map[string]any{"minPages": 1.5}},
+			expectedResponse: `{"errors":[{"message":"Variable \"$minPages\" got invalid value: Int cannot represent value 1.5."}]}`,
+		},
+		{
+			name:             "unknown-directive",
+			request:          &Request{Query: `{ books { title @deprecated } }`},
+			expectedResponse: `{"errors":[{"message":"Unknown directive \"@deprecated\".","locations":[{"line":1,"column":17}]}]}`,
+		},
+		{
+			name:             "fragment-cycle",
+			request:          &Request{Query: `{ books { ...A } } fragment A on Book { ...B } fragment B on Book { ...A }`},
+			expectedResponse: `{"errors":[{"message":"Cannot spread fragment \"A\" within itself.","locations":[{"line":1,"column":69}]}]}`,
+		},
+		{
+			name:             "fragment-on-wrong-type",
+			request:          &Request{Query: `{ books { ...AuthorFields } } fragment AuthorFields on Author { name }`},
+			expectedResponse: `{"errors":[{"message":"Fragment \"AuthorFields\" cannot be spread here as objects of type \"Book\" can never be of type \"Author\".","locations":[{"line":1,"column":11}]}]}`,
+		},
+		{
+			name:             "query-longer-than-maximum-length",
+			request:          &Request{Query: `{ books(titles: ` + strings.Repeat("[", 100000) + strings.Repeat("]", 100000) + `) { title } }`},
+			expectedResponse: `{"errors":[{"message":"Query must not be longer than 65536 bytes.","locations":[{"line":1,"column":1}]}]}`,
+		},
+		{
+			name:             "nested-value-deeper-than-maximum-depth",
+			request:          &Request{Query: `{ books(titles: ` + strings.Repeat("[", 100) + strings.Repeat("]", 100) + `) { title } }`},
+			expectedResponse: `{"errors":[{"message":"Query must not be nested more than 64 levels deep.","locations":[{"line":1,"column":80}]}]}`,
+		},
+		{
+			name:             "selection-set-deeper-than-maximum-depth",
+			request:          &Request{Query: `{ books ` + strings.Repeat("{ ... ", 100) + strings.Repeat("}", 101) + ` }`},
+			expectedResponse: `{"errors":[{"message":"Query must not be nested more than 64 levels deep.","locations":[{"line":1,"column":387}]}]}`,
+		},
+		{
+			name:             "multiple-operations-without-name",
+			request:          &Request{Query: `query A { books { title } } query B { books { pages } }`},
+			expectedResponse: `{"errors":[{"message":"Must provide operation name if query contains multiple operations."}]}`,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			output, err := json.Marshal(schema.Execute(scenario.request))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(output) != scenario.expectedResponse {
+				t.Errorf("expected:\n%s\ngot:\n%s", scenario.expectedResponse, output)
+			}
+		})
+	}
+}
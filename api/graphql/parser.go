@@ -0,0 +1,588 @@
+package graphql
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// document is a parsed query document
+type document struct {
+	operations []*operation
+	fragments  map[string]*fragment
+}
+
+type operation struct {
+	name         string
+	variables    []*variableDefinition
+	directives   []*directive
+	selectionSet []selection
+	location     Location
+}
+
+type variableDefinition struct {
+	name         string
+	typeName     string // The type of the variable as written, e.g. [String!]!
+	nonNull      bool
+	defaultValue any
+	hasDefault   bool
+}
+
+type selection interface{}
+
+type field struct {
+	alias        string
+	name         string
+	arguments    map[string]any
+	directives   []*directive
+	selectionSet []selection
+	location     Location
+}
+
+// responseKey returns the key of the field in the response, which is its alias if it has one
+func (f *field) responseKey() string {
+	if len(f.alias) > 0 {
+		return f.alias
+	}
+	return f.name
+}
+
+type fragmentSpread struct {
+	name       string
+	directives []*directive
+	location   Location
+}
+
+type inlineFragment struct {
+	typeCondition string
+	directives    []*directive
+	selectionSet  []selection
+	location      Location
+}
+
+type fragment struct {
+	name          string
+	typeCondition string
+	directives    []*directive
+	selectionSet  []selection
+	location      Location
+}
+
+type directive struct {
+	name      string
+	arguments map[string]any
+	location  Location
+}
+
+// variable is a reference to a variable in a value, which is replaced by the value of the variable before the value
+// is used
+type variable string
+
+// enumValue is an enum value in a value, which none of the built-in scalars can represent
+type enumValue string
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunctuator
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind     tokenKind
+	value    string
+	location Location
+}
+
+const (
+	// MaximumQueryLength is the maximum length of a query, in bytes, above which the query is rejected without being
+	// parsed
+	MaximumQueryLength = 64 * 1024
+
+	// MaximumDepth is the maximum number of nested selection sets, lists, objects and list types of a query. Since the
+	// parser is recursive, it would otherwise be possible to exhaust the stack of the goroutine with a deeply nested
+	// query, which crashes the whole process rather than causing a panic that could be recovered from.
+	MaximumDepth = 64
+)
+
+// parser is a recursive descent parser of the executable definitions of GraphQL documents
+type parser struct {
+	source   string
+	position int
+	line     int
+	column   int
+	token    token
+	depth    int
+}
+
+// parse parses the query passed as parameter into a document
+func parse(query string) (doc *document, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			parseErr, ok := r.(*Error)
+			if !ok {
+				panic(r)
+			}
+			doc, err = nil, parseErr
+		}
+	}()
+	if len(query) > MaximumQueryLength {
+		return nil, newError(Location{Line: 1, Column: 1}, "Query must not be longer than %d bytes.", MaximumQueryLength)
+	}
+	p := &parser{source: query, line: 1, column: 1}
+	p.next()
+	doc = &document{fragments: make(map[string]*fragment)}
+	for p.token.kind != tokenEOF {
+		switch {
+		case p.peek(tokenPunctuator, "{"):
+			op := &operation{location: p.token.location}
+			op.selectionSet = p.parseSelectionSet()
+			doc.operations = append(doc.operations, op)
+		case p.peek(tokenName, "query"):
+			doc.operations = append(doc.operations, p.parseOperation())
+		case p.peek(tokenName, "fragment"):
+			f := p.parseFragment()
+			if _, exists := doc.fragments[f.name]; exists {
+				return nil, newError(f.location, "There can be only one fragment named \"%s\".", f.name)
+			}
+			doc.fragments[f.name] = f
+		case p.peek(tokenName, "mutation"), p.peek(tokenName, "subscription"):
+			return nil, newError(p.token.location, "Only query operations are supported.")
+		default:
+			p.unexpected()
+		}
+	}
+	if len(doc.operations) == 0 {
+		return nil, newError(Location{Line: 1, Column: 1}, "Document must contain at least one operation.")
+	}
+	return doc, nil
+}
+
+func (p *parser) parseOperation() *operation {
+	op := &operation{location: p.token.location}
+	p.expect(tokenName, "query")
+	if p.token.kind == tokenName {
+		op.name = p.token.value
+		p.next()
+	}
+	if p.skip(tokenPunctuator, "(") {
+		for !p.skip(tokenPunctuator, ")") {
+			op.variables = append(op.variables, p.parseVariableDefinition())
+		}
+	}
+	op.directives = p.parseDirectives()
+	op.selectionSet = p.parseSelectionSet()
+	return op
+}
+
+func (p *parser) parseVariableDefinition() *variableDefinition {
+	p.expect(tokenPunctuator, "$")
+	definition := &variableDefinition{name: p.expect(tokenName, "").value}
+	p.expect(tokenPunctuator, ":")
+	definition.typeName, definition.nonNull = p.parseType()
+	if p.skip(tokenPunctuator, "=") {
+		definition.defaultValue, definition.hasDefault = p.parseValue(true), true
+	}
+	return definition
+}
+
+func (p *parser) parseType() (typeName string, nonNull bool) {
+	p.enter()
+	defer p.leave()
+	if p.skip(tokenPunctuator, "[") {
+		ofType, _ := p.parseType()
+		p.expect(tokenPunctuator, "]")
+		typeName = "[" + ofType + "]"
+	} else {
+		typeName = p.expect(tokenName, "").value
+	}
+	if p.skip(tokenPunctuator, "!") {
+		return typeName + "!", true
+	}
+	return typeName, false
+}
+
+func (p *parser) parseFragment() *fragment {
+	f := &fragment{location: p.token.location}
+	p.expect(tokenName, "fragment")
+	f.name = p.expect(tokenName, "").value
+	if f.name == "on" {
+		panic(newError(f.location, "Unexpected Name \"on\"."))
+	}
+	p.expect(tokenName, "on")
+	f.typeCondition = p.expect(tokenName, "").value
+	f.directives = p.parseDirectives()
+	f.selectionSet = p.parseSelectionSet()
+	return f
+}
+
+func (p *parser) parseSelectionSet() []selection {
+	p.enter()
+	defer p.leave()
+	p.expect(tokenPunctuator, "{")
+	var selections []selection
+	for !p.skip(tokenPunctuator, "}") {
+		if p.peek(tokenPunctuator, "...") {
+			selections = append(selections, p.parseFragmentSelection())
+		} else {
+			selections = append(selections, p.parseField())
+		}
+	}
+	if len(selections) == 0 {
+		p.unexpected()
+	}
+	return selections
+}
+
+func (p *parser) parseFragmentSelection() selection {
+	location := p.token.location
+	p.expect(tokenPunctuator, "...")
+	if p.token.kind == tokenName && p.token.value != "on" {
+		spread := &fragmentSpread{name: p.token.value, location: location}
+		p.next()
+		spread.directives = p.parseDirectives()
+		return spread
+	}
+	inline := &inlineFragment{location: location}
+	if p.skip(tokenName, "on") {
+		inline.typeCondition = p.expect(tokenName, "").value
+	}
+	inline.directives = p.parseDirectives()
+	inline.selectionSet = p.parseSelectionSet()
+	return inline
+}
+
+func (p *parser) parseField() *field {
+	f := &field{location: p.token.location}
+	f.name = p.expect(tokenName, "").value
+	if p.skip(tokenPunctuator, ":") {
+		f.alias, f.name = f.name, p.expect(tokenName, "").value
+	}
+	f.arguments = p.parseArguments()
+	f.directives = p.parseDirectives()
+	if p.peek(tokenPunctuator, "{") {
+		f.selectionSet = p.parseSelectionSet()
+	}
+	return f
+}
+
+func (p *parser) parseArguments() map[string]any {
+	if !p.skip(tokenPunctuator, "(") {
+		return nil
+	}
+	arguments := make(map[string]any)
+	for !p.skip(tokenPunctuator, ")") {
+		name := p.expect(tokenName, "")
+		if _, exists := arguments[name.value]; exists {
+			panic(newError(name.location, "There can be only one argument named \"%s\".", name.value))
+		}
+		p.expect(tokenPunctuator, ":")
+		arguments[name.value] = p.parseValue(false)
+	}
+	return arguments
+}
+
+func (p *parser) parseDirectives() []*directive {
+	var directives []*directive
+	for p.peek(tokenPunctuator, "@") {
+		d := &directive{location: p.token.location}
+		p.next()
+		d.name = p.expect(tokenName, "").value
+		d.arguments = p.parseArguments()
+		directives = append(directives, d)
+	}
+	return directives
+}
+
+// parseValue parses a value. If constant is true, the value may not reference variables.
+func (p *parser) parseValue(constant bool) any {
+	p.enter()
+	defer p.leave()
+	t := p.token
+	switch t.kind {
+	case tokenInt:
+		p.next()
+		value, err := strconv.Atoi(t.value)
+		if err != nil {
+			panic(newError(t.location, "Int cannot represent non 32-bit signed integer value: %s", t.value))
+		}
+		return value
+	case tokenFloat:
+		p.next()
+		value, _ := strconv.ParseFloat(t.value, 64)
+		return value
+	case tokenString:
+		p.next()
+		return t.value
+	case tokenName:
+		p.next()
+		switch t.value {
+		case "true":
+			return true
+		case "false":
+			return false
+		case "null":
+			return nil
+		}
+		return enumValue(t.value)
+	case tokenPunctuator:
+		switch t.value {
+		case "$":
+			if constant {
+				p.unexpected()
+			}
+			p.next()
+			return variable(p.expect(tokenName, "").value)
+		case "[":
+			p.next()
+			list := []any{}
+			for !p.skip(tokenPunctuator, "]") {
+				list = append(list, p.parseValue(constant))
+			}
+			return list
+		case "{":
+			p.next()
+			object := make(map[string]any)
+			for !p.skip(tokenPunctuator, "}") {
+				name := p.expect(tokenName, "").value
+				p.expect(tokenPunctuator, ":")
+				object[name] = p.parseValue(constant)
+			}
+			return object
+		}
+	}
+	p.unexpected()
+	return nil
+}
+
+// enter increases the depth of the parser before parsing a construct that may be nested, and fails if the depth
+// exceeds MaximumDepth. Each call must be followed by a call to leave once the construct has been parsed.
+func (p *parser) enter() {
+	p.depth++
+	if p.depth > MaximumDepth {
+		panic(newError(p.token.location, "Query must not be nested more than %d levels deep.", MaximumDepth))
+	}
+}
+
+// leave decreases the depth of the parser after parsing a construct that may be nested
+func (p *parser) leave() {
+	p.depth--
+}
+
+// peek returns whether the current token is of the kind and, if value isn't empty, of the value passed as parameter
+func (p *parser) peek(kind tokenKind, value string) bool {
+	return p.token.kind == kind && (len(value) == 0 || p.token.value == value)
+}
+
+// skip moves to the next token if the current token matches the kind and the value passed as parameter, and returns
+// whether it did
+func (p *parser) skip(kind tokenKind, value string) bool {
+	if p.peek(kind, value) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+// expect returns the current token and moves to the next one if it matches the kind and the value passed as
+// parameter, and fails otherwise
+func (p *parser) expect(kind tokenKind, value string) token {
+	if !p.peek(kind, value) {
+		p.unexpected()
+	}
+	t := p.token
+	p.next()
+	return t
+}
+
+func (p *parser) unexpected() {
+	switch p.token.kind {
+	case tokenEOF:
+		panic(newError(p.token.location, "Syntax Error: Unexpected <EOF>."))
+	case tokenName:
+		panic(newError(p.token.location, "Syntax Error: Unexpected Name \"%s\".", p.token.value))
+	case tokenString:
+		panic(newError(p.token.location, "Syntax Error: Unexpected String."))
+	}
+	panic(newError(p.token.location, "Syntax Error: Unexpected \"%s\".", p.token.value))
+}
+
+// next lexes the next token of the source
+func (p *parser) next() {
+	p.skipIgnored()
+	location := Location{Line: p.line, Column: p.column}
+	if p.position >= len(p.source) {
+		p.token = token{kind: tokenEOF, location: location}
+		return
+	}
+	c := p.source[p.position]
+	switch {
+	case strings.HasPrefix(p.source[p.position:], "..."):
+		p.advance(3)
+		p.token = token{kind: tokenPunctuator, value: "...", location: location}
+	case strings.IndexByte("!$&():=@[]{|}", c) >= 0:
+		p.advance(1)
+		p.token = token{kind: tokenPunctuator, value: string(c), location: location}
+	case c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+		start := p.position
+		for p.position < len(p.source) && isNameContinue(p.source[p.position]) {
+			p.advance(1)
+		}
+		p.token = token{kind: tokenName, value: p.source[start:p.position], location: location}
+	case c == '-' || (c >= '0' && c <= '9'):
+		p.token = p.lexNumber(location)
+	case c == '"':
+		p.token = token{kind: tokenString, value: p.lexString(location), location: location}
+	default:
+		r, _ := utf8.DecodeRuneInString(p.source[p.position:])
+		panic(newError(location, "Syntax Error: Unexpected character %q.", r))
+	}
+}
+
+func (p *parser) lexNumber(location Location) token {
+	start := p.position
+	kind := tokenInt
+	if p.source[p.position] == '-' {
+		p.advance(1)
+	}
+	p.readDigits(location)
+	if p.position < len(p.source) && p.source[p.position] == '.' {
+		kind = tokenFloat
+		p.advance(1)
+		p.readDigits(location)
+	}
+	if p.position < len(p.source) && (p.source[p.position] == 'e' || p.source[p.position] == 'E') {
+		kind = tokenFloat
+		p.advance(1)
+		if p.position < len(p.source) && (p.source[p.position] == '+' || p.source[p.position] == '-') {
+			p.advance(1)
+		}
+		p.readDigits(location)
+	}
+	if p.position < len(p.source) && (p.source[p.position] == '.' || isNameContinue(p.source[p.position])) {
+		panic(newError(location, "Syntax Error: Invalid number %q.", p.source[start:p.position+1]))
+	}
+	return token{kind: kind, value: p.source[start:p.position], location: location}
+}
+
+func (p *parser) readDigits(location Location) {
+	start := p.position
+	for p.position < len(p.source) && p.source[p.position] >= '0' && p.source[p.position] <= '9' {
+		p.advance(1)
+	}
+	if start == p.position {
+		panic(newError(location, "Syntax Error: Invalid number, expected digit."))
+	}
+}
+
+func (p *parser) lexString(location Location) string {
+	if strings.HasPrefix(p.source[p.position:], `"""`) {
+		p.advance(3)
+		end := strings.Index(p.source[p.position:], `"""`)
+		for end > 0 && p.source[p.position+end-1] == '\\' {
+			next := strings.Index(p.source[p.position+end+3:], `"""`)
+			if next < 0 {
+				end = -1
+				break
+			}
+			end += 3 + next
+		}
+		if end < 0 {
+			panic(newError(location, "Syntax Error: Unterminated string."))
+		}
+		value := strings.ReplaceAll(p.source[p.position:p.position+end], `\"""`, `"""`)
+		p.advance(end + 3)
+		return strings.TrimSpace(value)
+	}
+	p.advance(1)
+	var value strings.Builder
+	for {
+		if p.position >= len(p.source) || p.source[p.position] == '\n' || p.source[p.position] == '\r' {
+			panic(newError(location, "Syntax Error: Unterminated string."))
+		}
+		c := p.source[p.position]
+		if c == '"' {
+			p.advance(1)
+			return value.String()
+		}
+		if c != '\\' {
+			r, size := utf8.DecodeRuneInString(p.source[p.position:])
+			value.WriteRune(r)
+			p.advance(size)
+			continue
+		}
+		if p.position+1 >= len(p.source) {
+			panic(newError(location, "Syntax Error: Unterminated string."))
+		}
+		switch escaped := p.source[p.position+1]; escaped {
+		case '"', '\\', '/':
+			value.WriteByte(escaped)
+		case 'b':
+			value.WriteByte('\b')
+		case 'f':
+			value.WriteByte('\f')
+		case 'n':
+			value.WriteByte('\n')
+		case 'r':
+			value.WriteByte('\r')
+		case 't':
+			value.WriteByte('\t')
+		case 'u':
+			if p.position+6 > len(p.source) {
+				panic(newError(location, "Syntax Error: Invalid Unicode escape sequence."))
+			}
+			code, err := strconv.ParseUint(p.source[p.position+2:p.position+6], 16, 32)
+			if err != nil {
+				panic(newError(location, "Syntax Error: Invalid Unicode escape sequence."))
+			}
+			value.WriteRune(rune(code))
+			p.advance(4)
+		default:
+			panic(newError(location, "Syntax Error: Invalid character escape sequence \\%c.", escaped))
+		}
+		p.advance(2)
+	}
+}
+
+// skipIgnored skips the whitespaces, line terminators, commas, comments and unicode BOMs
+func (p *parser) skipIgnored() {
+	for p.position < len(p.source) {
+		switch c := p.source[p.position]; {
+		case c == ' ' || c == '\t' || c == ',' || c == '\r':
+			p.advance(1)
+		case c == '\n':
+			p.position++
+			p.line++
+			p.column = 1
+		case c == '#':
+			for p.position < len(p.source) && p.source[p.position] != '\n' {
+				p.advance(1)
+			}
+		case strings.HasPrefix(p.source[p.position:], "\uFEFF"):
+			p.advance(len("\uFEFF"))
+		default:
+			return
+		}
+	}
+}
+
+// advance moves forward by n bytes on the current line
+func (p *parser) advance(n int) {
+	for i := 0; i < n && p.position < len(p.source); i++ {
+		if p.source[p.position] == '\n' {
+			p.line++
+			p.column = 1
+		} else if p.source[p.position]&0xc0 != 0x80 {
+			p.column++
+		}
+		p.position++
+	}
+}
+
+func isNameContinue(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,278 @@
+// Package graphql is a minimal implementation of the execution of GraphQL queries against a schema whose types are
+// objects, lists and the built-in scalars, which is enough to serve the GraphQL API of Gatus.
+//
+// Queries may use variables, aliases, fragments, inline fragments and the @skip and @include directives.
+// Mutations, subscriptions, interfaces, unions, enums, input objects and introspection are not supported.
+//
+// It's implemented here rather than through an established GraphQL library because the API only needs this subset,
+// which keeps Gatus free of a large dependency. Queries are limited to MaximumQueryLength bytes and MaximumDepth levels
+// of nesting, so that a malicious query cannot exhaust the resources of the process.
+package graphql
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Type is the type of a field or of an argument, which is either a *Scalar, an *Object, a *List or a *NonNull
+type Type interface {
+	String() string
+}
+
+// Scalar is a built-in scalar type
+type Scalar struct {
+	Name string
+
+	serialize func(value any) (any, bool)
+	coerce    func(value any) (any, bool)
+}
+
+func (s *Scalar) String() string {
+	return s.Name
+}
+
+var (
+	// Int is the built-in scalar type of signed 32-bit integers
+	Int = &Scalar{Name: "Int", serialize: serializeInt, coerce: coerceInt}
+
+	// Float is the built-in scalar type of double-precision floating-point numbers
+	Float = &Scalar{Name: "Float", serialize: serializeFloat, coerce: coerceFloat}
+
+	// String is the built-in scalar type of UTF-8 character sequences
+	String = &Scalar{Name: "String", serialize: serializeString, coerce: serializeString}
+
+	// Boolean is the built-in scalar type of true and false
+	Boolean = &Scalar{Name: "Boolean", serialize: serializeBoolean, coerce: serializeBoolean}
+)
+
+// Object is an object type, whose fields are resolved by their ResolveFunc
+type Object struct {
+	Name   string
+	Fields map[string]*Field
+}
+
+func (o *Object) String() string {
+	return o.Name
+}
+
+// Field is a field of an object type
+type Field struct {
+	Type    Type
+	Args    map[string]*Argument
+	Resolve ResolveFunc
+}
+
+// ResolveFunc resolves the value of a field from the value of the object it belongs to (source) and the coerced
+// values of its arguments (args), in which every argument that has a default value, or that was provided, is set
+type ResolveFunc func(source any, args map[string]any) (any, error)
+
+// Argument is an argument of a field
+type Argument struct {
+	Type Type
+
+	// DefaultValue is the value of the argument if it's not provided. nil means no default value.
+	DefaultValue any
+}
+
+// List is a list whose items are of the type OfType
+type List struct {
+	OfType Type
+}
+
+func (l *List) String() string {
+	return "[" + l.OfType.String() + "]"
+}
+
+// NonNull is a type whose values are never null
+type NonNull struct {
+	OfType Type
+}
+
+func (n *NonNull) String() string {
+	return n.OfType.String() + "!"
+}
+
+// Schema is the schema that queries are executed against
+type Schema struct {
+	query *Object
+	types map[string]Type
+}
+
+// NewSchema creates a schema whose root operation type is the type passed as parameter
+func NewSchema(query *Object) *Schema {
+	schema := &Schema{query: query, types: map[string]Type{"Int": Int, "Float": Float, "String": String, "Boolean": Boolean}}
+	schema.addType(query)
+	return schema
+}
+
+func (s *Schema) addType(t Type) {
+	switch t := t.(type) {
+	case *List:
+		s.addType(t.OfType)
+	case *NonNull:
+		s.addType(t.OfType)
+	case *Object:
+		if _, exists := s.types[t.Name]; exists {
+			return
+		}
+		s.types[t.Name] = t
+		for _, f := range t.Fields {
+			s.addType(f.Type)
+			for _, argument := range f.Args {
+				s.addType(argument.Type)
+			}
+		}
+	}
+}
+
+// inputType returns the input type whose name is written as passed as parameter, e.g. [String!]!
+func (s *Schema) inputType(name string) (Type, bool) {
+	if ofTypeName, isNonNull := strings.CutSuffix(name, "!"); isNonNull {
+		ofType, ok := s.inputType(ofTypeName)
+		return &NonNull{OfType: ofType}, ok
+	}
+	if strings.HasPrefix(name, "[") && strings.HasSuffix(name, "]") {
+		ofType, ok := s.inputType(name[1 : len(name)-1])
+		return &List{OfType: ofType}, ok
+	}
+	scalar, ok := s.types[name].(*Scalar)
+	return scalar, ok
+}
+
+// namedType returns the type without its list and non-null wrappers
+func namedType(t Type) Type {
+	for {
+		switch wrapper := t.(type) {
+		case *List:
+			t = wrapper.OfType
+		case *NonNull:
+			t = wrapper.OfType
+		default:
+			return t
+		}
+	}
+}
+
+// coerceInput coerces the value of an argument or of a variable, from which the variables must have been replaced, to
+// the input type passed as parameter
+func coerceInput(t Type, value any) (any, error) {
+	switch t := t.(type) {
+	case *NonNull:
+		if value == nil {
+			return nil, fmt.Errorf("expected value of type \"%s\", found null", t)
+		}
+		return coerceInput(t.OfType, value)
+	case *List:
+		if value == nil {
+			return nil, nil
+		}
+		items, isList := value.([]any)
+		if !isList {
+			// A single value is coerced to a list of one item
+			items = []any{value}
+		}
+		coerced := make([]any, 0, len(items))
+		for _, item := range items {
+			coercedItem, err := coerceInput(t.OfType, item)
+			if err != nil {
+				return nil, err
+			}
+			coerced = append(coerced, coercedItem)
+		}
+		return coerced, nil
+	case *Scalar:
+		if value == nil {
+			return nil, nil
+		}
+		if coerced, ok := t.coerce(value); ok {
+			return coerced, nil
+		}
+		return nil, fmt.Errorf("%s cannot represent %s", t.Name, describe(value))
+	}
+	return nil, fmt.Errorf("\"%s\" is not an input type", t)
+}
+
+func describe(value any) string {
+	switch value := value.(type) {
+	case string:
+		return fmt.Sprintf("value %q", value)
+	case enumValue:
+		return fmt.Sprintf("enum value %s", value)
+	case []any:
+		return "a list"
+	case map[string]any:
+		return "an object"
+	}
+	return fmt.Sprintf("value %v", value)
+}
+
+func serializeInt(value any) (any, bool) {
+	var i int64
+	switch value := value.(type) {
+	case int:
+		i = int64(value)
+	case int32:
+		i = int64(value)
+	case int64:
+		i = value
+	default:
+		return nil, false
+	}
+	if i < math.MinInt32 || i > math.MaxInt32 {
+		return nil, false
+	}
+	return int(i), true
+}
+
+func coerceInt(value any) (any, bool) {
+	switch value := value.(type) {
+	case float64:
+		if value != math.Trunc(value) {
+			return nil, false
+		}
+		return serializeInt(int64(value))
+	case interface{ Int64() (int64, error) }:
+		i, err := value.Int64() // json.Number
+		if err != nil {
+			return nil, false
+		}
+		return serializeInt(i)
+	}
+	return serializeInt(value)
+}
+
+func serializeFloat(value any) (any, bool) {
+	switch value := value.(type) {
+	case float64:
+		return value, !math.IsInf(value, 0) && !math.IsNaN(value)
+	case float32:
+		return serializeFloat(float64(value))
+	case int:
+		return float64(value), true
+	case int64:
+		return float64(value), true
+	}
+	return nil, false
+}
+
+func coerceFloat(value any) (any, bool) {
+	if number, ok := value.(interface{ Float64() (float64, error) }); ok {
+		f, err := number.Float64() // json.Number
+		if err != nil {
+			return nil, false
+		}
+		return serializeFloat(f)
+	}
+	return serializeFloat(value)
+}
+
+func serializeString(value any) (any, bool) {
+	s, ok := value.(string)
+	return s, ok
+}
+
+func serializeBoolean(value any) (any, bool) {
+	b, ok := value.(bool)
+	return b, ok
+}
@@ -0,0 +1,187 @@
+package graphql
+
+import "fmt"
+
+type validator struct {
+	schema    *Schema
+	document  *document
+	variables map[string]*variableDefinition
+	errors    []*Error
+
+	validatedFragments map[string]bool
+	spreadFragments    map[string]bool
+}
+
+// validate returns the errors that prevent the operation passed as parameter from being executed against the schema
+func (s *Schema) validate(doc *document, op *operation) []*Error {
+	v := &validator{
+		schema:             s,
+		document:           doc,
+		variables:          make(map[string]*variableDefinition),
+		validatedFragments: make(map[string]bool),
+		spreadFragments:    make(map[string]bool),
+	}
+	for _, definition := range op.variables {
+		if _, exists := v.variables[definition.name]; exists {
+			v.errors = append(v.errors, newError(op.location, "There can be only one variable named \"$%s\".", definition.name))
+		}
+		if _, isInputType := s.inputType(definition.typeName); !isInputType {
+			v.errors = append(v.errors, newError(op.location, "Variable \"$%s\" cannot be non-input type \"%s\".", definition.name, definition.typeName))
+		}
+		v.variables[definition.name] = definition
+	}
+	v.validateDirectives(op.directives)
+	v.validateSelectionSet(s.query, op.selectionSet)
+	return v.errors
+}
+
+func (v *validator) validateSelectionSet(object *Object, selections []selection) {
+	for _, s := range selections {
+		switch s := s.(type) {
+		case *field:
+			v.validateDirectives(s.directives)
+			v.validateField(object, s)
+		case *inlineFragment:
+			v.validateDirectives(s.directives)
+			if len(s.typeCondition) == 0 || v.validateTypeCondition(object, s.typeCondition, s.location, "Fragment") {
+				v.validateSelectionSet(object, s.selectionSet)
+			}
+		case *fragmentSpread:
+			v.validateDirectives(s.directives)
+			v.validateFragmentSpread(object, s)
+		}
+	}
+}
+
+func (v *validator) validateField(object *Object, f *field) {
+	if f.name == "__typename" {
+		if len(f.selectionSet) > 0 {
+			v.errors = append(v.errors, newError(f.location, "Field \"%s\" must not have a selection since type \"String!\" has no subfields.", f.name))
+		}
+		return
+	}
+	definition, exists := object.Fields[f.name]
+	if !exists {
+		v.errors = append(v.errors, newError(f.location, "Cannot query field \"%s\" on type \"%s\".", f.name, object.Name))
+		return
+	}
+	for name, value := range f.arguments {
+		argument, exists := definition.Args[name]
+		if !exists {
+			v.errors = append(v.errors, newError(f.location, "Unknown argument \"%s\" on field \"%s.%s\".", name, object.Name, f.name))
+			continue
+		}
+		v.validateValue(argument.Type, value, f.location, fmt.Sprintf("Argument \"%s\"", name))
+	}
+	for name, argument := range definition.Args {
+		if _, isNonNull := argument.Type.(*NonNull); isNonNull && argument.DefaultValue == nil {
+			if _, provided := f.arguments[name]; !provided {
+				v.errors = append(v.errors, newError(f.location, "Field \"%s\" argument \"%s\" of type \"%s\" is required, but it was not provided.", f.name, name, argument.Type))
+			}
+		}
+	}
+	switch t := namedType(definition.Type).(type) {
+	case *Object:
+		if len(f.selectionSet) == 0 {
+			v.errors = append(v.errors, newError(f.location, "Field \"%s\" of type \"%s\" must have a selection of subfields.", f.name, definition.Type))
+			return
+		}
+		v.validateSelectionSet(t, f.selectionSet)
+	default:
+		if len(f.selectionSet) > 0 {
+			v.errors = append(v.errors, newError(f.location, "Field \"%s\" must not have a selection since type \"%s\" has no subfields.", f.name, definition.Type))
+		}
+	}
+}
+
+func (v *validator) validateFragmentSpread(object *Object, spread *fragmentSpread) {
+	f, exists := v.document.fragments[spread.name]
+	if !exists {
+		v.errors = append(v.errors, newError(spread.location, "Unknown fragment \"%s\".", spread.name))
+		return
+	}
+	if v.spreadFragments[spread.name] {
+		v.errors = append(v.errors, newError(spread.location, "Cannot spread fragment \"%s\" within itself.", spread.name))
+		return
+	}
+	if !v.validateTypeCondition(object, f.typeCondition, spread.location, fmt.Sprintf("Fragment \"%s\"", spread.name)) {
+		return
+	}
+	if v.validatedFragments[spread.name] {
+		return
+	}
+	v.spreadFragments[spread.name] = true
+	v.validateDirectives(f.directives)
+	v.validateSelectionSet(object, f.selectionSet)
+	v.spreadFragments[spread.name] = false
+	v.validatedFragments[spread.name] = true
+}
+
+// validateTypeCondition returns whether the fragment whose type condition is passed as parameter can be spread on the
+// object passed as parameter
+func (v *validator) validateTypeCondition(object *Object, typeCondition string, location Location, description string) bool {
+	if _, isObject := v.schema.types[typeCondition].(*Object); !isObject {
+		v.errors = append(v.errors, newError(location, "Unknown type \"%s\".", typeCondition))
+		return false
+	}
+	if typeCondition != object.Name {
+		v.errors = append(v.errors, newError(location, "%s cannot be spread here as objects of type \"%s\" can never be of type \"%s\".", description, object.Name, typeCondition))
+		return false
+	}
+	return true
+}
+
+func (v *validator) validateDirectives(directives []*directive) {
+	for _, d := range directives {
+		if d.name != "skip" && d.name != "include" {
+			v.errors = append(v.errors, newError(d.location, "Unknown directive \"@%s\".", d.name))
+			continue
+		}
+		for name := range d.arguments {
+			if name != "if" {
+				v.errors = append(v.errors, newError(d.location, "Unknown argument \"%s\" on directive \"@%s\".", name, d.name))
+			}
+		}
+		if _, provided := d.arguments["if"]; !provided {
+			v.errors = append(v.errors, newError(d.location, "Directive \"@%s\" argument \"if\" of type \"Boolean!\" is required, but it was not provided.", d.name))
+			continue
+		}
+		v.validateValue(&NonNull{OfType: Boolean}, d.arguments["if"], d.location, "Argument \"if\"")
+	}
+}
+
+// validateValue validates that the variables referenced by the value passed as parameter are defined, and, if it
+// doesn't reference any variable, that it can be coerced to the type passed as parameter
+func (v *validator) validateValue(t Type, value any, location Location, description string) {
+	if !v.validateVariables(value, location) {
+		return
+	}
+	if _, err := coerceInput(t, value); err != nil {
+		v.errors = append(v.errors, newError(location, "%s has invalid value: %s.", description, err.Error()))
+	}
+}
+
+// validateVariables returns whether the value passed as parameter is free of references to variables, and adds an
+// error for each reference to a variable that isn't defined
+func (v *validator) validateVariables(value any, location Location) bool {
+	switch value := value.(type) {
+	case variable:
+		if _, defined := v.variables[string(value)]; !defined {
+			v.errors = append(v.errors, newError(location, "Variable \"$%s\" is not defined.", value))
+		}
+		return false
+	case []any:
+		constant := true
+		for _, item := range value {
+			constant = v.validateVariables(item, location) && constant
+		}
+		return constant
+	case map[string]any:
+		constant := true
+		for _, item := range value {
+			constant = v.validateVariables(item, location) && constant
+		}
+		return constant
+	}
+	return true
+}
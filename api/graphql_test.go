@@ -0,0 +1,139 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/watchdog"
+)
+
+func TestGraphQL(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	cfg := &config.Config{
+		Metrics: true,
+		Endpoints: []*endpoint.Endpoint{
+			{
+				Name:  "frontend",
+				Group: "core",
+			},
+			{
+				Name:  "backend",
+				Group: "core",
+			},
+			{
+				Name: "website",
+			},
+		},
+	}
+	timestamp := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: true, HTTPStatus: 200, Duration: 5 * time.Millisecond, Timestamp: timestamp})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[1], &endpoint.Result{Success: false, Duration: time.Second, Errors: []string{"error-1"}, Timestamp: timestamp})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[2], &endpoint.Result{Success: true, Duration: time.Millisecond, Timestamp: timestamp})
+	router := New(cfg).Router()
+	type Scenario struct {
+		Name         string
+		Method       string
+		Path         string
+		Body         string
+		ExpectedCode int
+		ExpectedBody string
+	}
+	scenarios := []Scenario{
+		{
+			Name:         "endpoints",
+			Method:       "POST",
+			Path:         "/api/graphql",
+			Body:         `{"query":"{ endpoints { key healthy } }"}`,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: `{"data":{"endpoints":[{"key":"_website","healthy":true},{"key":"core_backend","healthy":false},{"key":"core_frontend","healthy":true}]}}`,
+		},
+		{
+			Name:         "endpoints-filtered-with-variables",
+			Method:       "POST",
+			Path:         "/api/graphql",
+			Body:         `{"query":"query ($group: String, $healthy: Boolean) { endpoints(group: $group, healthy: $healthy) { name } }","variables":{"group":"core","healthy":true}}`,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: `{"data":{"endpoints":[{"name":"frontend"}]}}`,
+		},
+		{
+			Name:         "endpoint-with-results-and-events",
+			Method:       "POST",
+			Path:         "/api/graphql",
+			Body:         `{"query":"{ endpoint(key: \"core_backend\") { group results(pageSize: 10) { status responseTime errors success timestamp } events { type } } }"}`,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: `{"data":{"endpoint":{"group":"core","results":[{"status":null,"responseTime":1000,"errors":["error-1"],"success":false,"timestamp":"2024-01-01T00:00:00Z"}],"events":[{"type":"START"},{"type":"UNHEALTHY"}]}}}`,
+		},
+		{
+			Name:         "endpoint-not-found",
+			Method:       "POST",
+			Path:         "/api/graphql",
+			Body:         `{"query":"{ endpoint(key: \"core_database\") { key } }"}`,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: `{"data":{"endpoint":null}}`,
+		},
+		{
+			Name:         "groups",
+			Method:       "GET",
+			Path:         "/api/graphql?query=" + url.QueryEscape(`{ groups { name endpoints(healthy: true) { name } } }`),
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: `{"data":{"groups":[{"name":null,"endpoints":[{"name":"website"}]},{"name":"core","endpoints":[{"name":"frontend"}]}]}}`,
+		},
+		{
+			Name:         "invalid-duration",
+			Method:       "GET",
+			Path:         "/api/graphql?query=" + url.QueryEscape(`query ($key: String!) { endpoint(key: $key) { uptime(duration: "2h") } }`) + "&variables=" + url.QueryEscape(`{"key":"core_frontend"}`),
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: `{"data":{"endpoint":{"uptime":null}},"errors":[{"message":"durations supported: 365d, 90d, 30d, 7d, 24h, 1h","locations":[{"line":1,"column":47}],"path":["endpoint","uptime"]}]}`,
+		},
+		{
+			Name:         "invalid-query",
+			Method:       "POST",
+			Path:         "/api/graphql",
+			Body:         `{"query":"{ endpoints { url } }"}`,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: `{"errors":[{"message":"Cannot query field \"url\" on type \"Endpoint\".","locations":[{"line":1,"column":15}]}]}`,
+		},
+		{
+			Name:         "invalid-body",
+			Method:       "POST",
+			Path:         "/api/graphql",
+			Body:         `{"query":`,
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "missing-query",
+			Method:       "GET",
+			Path:         "/api/graphql",
+			ExpectedCode: http.StatusBadRequest,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest(scenario.Method, scenario.Path, strings.NewReader(scenario.Body))
+			request.Header.Set("Content-Type", "application/json")
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+			if len(scenario.ExpectedBody) > 0 {
+				body, _ := io.ReadAll(response.Body)
+				if string(body) != scenario.ExpectedBody {
+					t.Errorf("expected:\n%s\n\ngot:\n%s", scenario.ExpectedBody, body)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/grouppage"
+	"github.com/TwiN/gatus/v5/config/ui"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GroupPageApplication handles requests to the dashboard of a group, which is the single page application rendered
+// with the title and the header of the page of the group
+func GroupPageApplication(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		page, err := getGroupPage(c, cfg)
+		if err != nil {
+			return c.Status(404).SendString(err.Error())
+		}
+		uiConfig := cfg.UI
+		if uiConfig == nil {
+			uiConfig = ui.GetDefaultConfig()
+		}
+		return SinglePageApplication(page.UI(uiConfig))(c)
+	}
+}
+
+// GroupPageAccess handles the authentication of the requests to the API of the page of a group. Requests to the API
+// of a public page are let through, whereas those to the API of any other page must be authenticated whenever
+// security is configured.
+//
+// This is needed because these routes are registered before the security middleware, so that the API of the public
+// pages can be accessed without being authenticated.
+func GroupPageAccess(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		page, err := getGroupPage(c, cfg)
+		if err != nil {
+			return c.Status(404).SendString(err.Error())
+		}
+		if !page.Public && cfg.Security != nil && !cfg.Security.IsAuthenticated(c) {
+			if cfg.Security.UsesBasicAuthentication() {
+				c.Set("WWW-Authenticate", "Basic")
+			}
+			return c.Status(401).SendString("Unauthorized")
+		}
+		return c.Next()
+	}
+}
+
+// GroupEndpointStatuses handles requests to retrieve the statuses of the endpoints of the group of a page.
+// Like EndpointStatuses, this function leverages a cache.
+func GroupEndpointStatuses(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		page, err := getGroupPage(c, cfg)
+		if err != nil {
+			return c.Status(404).SendString(err.Error())
+		}
+		return sendFilteredEndpointStatuses(c, "group-"+page.Group, func(endpointStatus *endpoint.Status) bool {
+			return endpointStatus.Group == page.Group
+		})
+	}
+}
+
+// getGroupPage returns the page of the group passed through the group parameter
+func getGroupPage(c *fiber.Ctx, cfg *config.Config) (*grouppage.GroupPage, error) {
+	group, err := url.PathUnescape(c.Params("group"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid group: %w", err)
+	}
+	page := cfg.GetGroupPageByGroup(group)
+	if page == nil {
+		return nil, fmt.Errorf("group %s has no page", group)
+	}
+	return page, nil
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/grouppage"
+	"github.com/TwiN/gatus/v5/config/ui"
+	"github.com/TwiN/gatus/v5/security"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/watchdog"
+)
+
+func TestGroupPages(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	cfg := &config.Config{
+		Endpoints: []*endpoint.Endpoint{
+			{Name: "website", Group: "customers"},
+			{Name: "database", Group: "internal"},
+		},
+		GroupPages: []*grouppage.GroupPage{
+			{Group: "customers", Header: "Acme Status", Public: true},
+			{Group: "internal"},
+		},
+		UI: ui.GetDefaultConfig(),
+		Security: &security.Config{
+			Basic: &security.BasicConfig{
+				Username:                        "john.doe",
+				PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT",
+			},
+		},
+	}
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: true, Duration: time.Millisecond, Timestamp: time.Now()})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[1], &endpoint.Result{Success: false, Duration: time.Second, Timestamp: time.Now()})
+	router := New(cfg).Router()
+	scenarios := []struct {
+		Name                  string
+		Path                  string
+		Authenticated         bool
+		ExpectedCode          int
+		ExpectedKeys          []string
+		ExpectedBodyToContain string
+	}{
+		{
+			Name:         "public-page-statuses",
+			Path:         "/api/v1/groups/customers/endpoints/statuses",
+			ExpectedCode: http.StatusOK,
+			ExpectedKeys: []string{"customers_website"},
+		},
+		{
+			Name:         "private-page-statuses-without-authentication",
+			Path:         "/api/v1/groups/internal/endpoints/statuses",
+			ExpectedCode: http.StatusUnauthorized,
+		},
+		{
+			Name:          "private-page-statuses-with-authentication",
+			Path:          "/api/v1/groups/internal/endpoints/statuses",
+			Authenticated: true,
+			ExpectedCode:  http.StatusOK,
+			ExpectedKeys:  []string{"internal_database"},
+		},
+		{
+			Name:          "group-without-page",
+			Path:          "/api/v1/groups/nope/endpoints/statuses",
+			Authenticated: true,
+			ExpectedCode:  http.StatusNotFound,
+		},
+		{
+			Name:         "all-statuses-still-require-authentication",
+			Path:         "/api/v1/endpoints/statuses",
+			ExpectedCode: http.StatusUnauthorized,
+		},
+		{
+			Name:                  "public-page",
+			Path:                  "/groups/customers",
+			ExpectedCode:          http.StatusOK,
+			ExpectedBodyToContain: "Acme Status",
+		},
+		{
+			Name:         "page-of-group-without-page",
+			Path:         "/groups/nope",
+			ExpectedCode: http.StatusNotFound,
+		},
+		{
+			Name:                  "config-lists-public-groups",
+			Path:                  "/api/v1/config",
+			ExpectedCode:          http.StatusOK,
+			ExpectedBodyToContain: `"publicGroups":["customers"]`,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", scenario.Path, http.NoBody)
+			if scenario.Authenticated {
+				request.SetBasicAuth("john.doe", "hunter2")
+			}
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+			body, _ := io.ReadAll(response.Body)
+			if len(scenario.ExpectedBodyToContain) > 0 && !strings.Contains(string(body), scenario.ExpectedBodyToContain) {
+				t.Errorf("expected body to contain %s, got %s", scenario.ExpectedBodyToContain, body)
+			}
+			if scenario.ExpectedKeys != nil {
+				var endpointStatuses []*endpoint.Status
+				if err := json.Unmarshal(body, &endpointStatuses); err != nil {
+					t.Fatal(err)
+				}
+				if len(endpointStatuses) != len(scenario.ExpectedKeys) {
+					t.Fatalf("expected %d endpoint statuses, got %d", len(scenario.ExpectedKeys), len(endpointStatuses))
+				}
+				for i, endpointStatus := range endpointStatuses {
+					if endpointStatus.Key != scenario.ExpectedKeys[i] {
+						t.Errorf("expected key %s, got %s", scenario.ExpectedKeys[i], endpointStatus.Key)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/watchdog"
+	"github.com/TwiN/health"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	healthStatusUp   = "UP"
+	healthStatusDown = "DOWN"
+
+	// storePingTimeout is how long the server keeping the data of the store has to respond when the detailed health
+	// is requested
+	storePingTimeout = 5 * time.Second
+
+	// MaximumWatchdogLag is how late the monitoring of the endpoints can be before the watchdog is considered down,
+	// which leaves room for the endpoints waiting for each other because of the monitoring lock
+	MaximumWatchdogLag = 5 * time.Minute
+)
+
+// detailedHealth is the health of Gatus along with the health of each of its components.
+// Gatus is only up if every one of its components is up.
+type detailedHealth struct {
+	Status     string            `json:"status"`
+	Components *healthComponents `json:"components"`
+}
+
+type healthComponents struct {
+	Store    *storeHealth    `json:"store"`
+	Alerting *alertingHealth `json:"alerting"`
+	Watchdog *watchdogHealth `json:"watchdog"`
+	Config   *configHealth   `json:"config"`
+}
+
+// storeHealth is whether the store can be reached and how long it took to reach it
+type storeHealth struct {
+	Status  string `json:"status"`
+	Type    string `json:"type,omitempty"`
+	Latency string `json:"latency,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// alertingHealth is which alerting providers are configured, and which were ignored because they aren't valid
+type alertingHealth struct {
+	Status           string   `json:"status"`
+	Providers        []string `json:"providers"`
+	IgnoredProviders []string `json:"ignoredProviders"`
+}
+
+// watchdogHealth is how late the monitoring of the most behind of the endpoints is
+type watchdogHealth struct {
+	Status string `json:"status"`
+	Lag    string `json:"lag"`
+}
+
+// configHealth is when the configuration was loaded and how long ago that was
+type configHealth struct {
+	Status   string     `json:"status"`
+	LoadedAt *time.Time `json:"loadedAt,omitempty"`
+	Age      string     `json:"age,omitempty"`
+}
+
+// Health handles requests to the health of Gatus.
+//
+// If the detailed query parameter is set to true, the health of the store, of the alerting providers, of the watchdog
+// and of the configuration is returned as well, and Gatus is only considered up if each of them is, which makes the
+// detailed health suitable as a readiness probe.
+func Health(cfg *config.Config) fiber.Handler {
+	healthHandler := health.Handler().WithJSON(true)
+	return func(c *fiber.Ctx) error {
+		if !c.QueryBool("detailed") {
+			statusCode, body := healthHandler.GetResponseStatusCodeAndBody()
+			return c.Status(statusCode).Send(body)
+		}
+		detailed := getDetailedHealth(cfg, time.Now())
+		output, err := json.Marshal(detailed)
+		if err != nil {
+			log.Printf("[api.Health] Unable to marshal object to JSON: %s", err.Error())
+			return c.Status(500).SendString("unable to marshal object to JSON")
+		}
+		c.Set("Content-Type", "application/json")
+		c.Set("Cache-Control", "no-store")
+		if detailed.Status != healthStatusUp {
+			return c.Status(503).Send(output)
+		}
+		return c.Status(200).Send(output)
+	}
+}
+
+// getDetailedHealth returns the health of Gatus and of each of its components at the time passed as parameter
+func getDetailedHealth(cfg *config.Config, now time.Time) *detailedHealth {
+	components := &healthComponents{
+		Store:    getStoreHealth(cfg),
+		Alerting: &alertingHealth{Status: healthStatusUp, Providers: []string{}, IgnoredProviders: []string{}},
+		Watchdog: &watchdogHealth{Status: healthStatusUp},
+		Config:   &configHealth{Status: healthStatusUp},
+	}
+	for _, alertType := range cfg.AlertingProviders() {
+		components.Alerting.Providers = append(components.Alerting.Providers, string(alertType))
+	}
+	for _, alertType := range cfg.IgnoredAlertingProviders() {
+		components.Alerting.IgnoredProviders = append(components.Alerting.IgnoredProviders, string(alertType))
+		components.Alerting.Status = healthStatusDown
+	}
+	lag := watchdog.GetLag(cfg.Endpoints, now)
+	components.Watchdog.Lag = lag.Round(time.Millisecond).String()
+	if lag > MaximumWatchdogLag {
+		components.Watchdog.Status = healthStatusDown
+	}
+	if loadedAt := cfg.LoadedAt(); !loadedAt.IsZero() {
+		components.Config.LoadedAt = &loadedAt
+		components.Config.Age = now.Sub(loadedAt).Round(time.Second).String()
+	}
+	detailed := &detailedHealth{Status: healthStatusUp, Components: components}
+	for _, status := range []string{components.Store.Status, components.Alerting.Status, components.Watchdog.Status, components.Config.Status} {
+		if status != healthStatusUp {
+			detailed.Status = healthStatusDown
+		}
+	}
+	return detailed
+}
+
+// getStoreHealth returns whether the store can be reached. The stores that don't keep their data on a server, such as
+// the memory store, can always be reached.
+func getStoreHealth(cfg *config.Config) *storeHealth {
+	storeHealth := &storeHealth{Status: healthStatusUp}
+	if cfg.Storage != nil {
+		storeHealth.Type = string(cfg.Storage.Type)
+	}
+	pinger, ok := store.Get().(store.Pinger)
+	if !ok {
+		return storeHealth
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), storePingTimeout)
+	defer cancel()
+	start := time.Now()
+	err := pinger.Ping(ctx)
+	storeHealth.Latency = time.Since(start).Round(time.Microsecond).String()
+	if err != nil {
+		storeHealth.Status = healthStatusDown
+		storeHealth.Error = err.Error()
+	}
+	return storeHealth
+}
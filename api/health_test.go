@@ -0,0 +1,55 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage"
+)
+
+func TestHealth(t *testing.T) {
+	cfg := &config.Config{
+		Endpoints: []*endpoint.Endpoint{{Name: "frontend", Group: "core"}},
+		Storage:   &storage.Config{Type: storage.TypeMemory},
+	}
+	router := New(cfg).Router()
+	t.Run("simple", func(t *testing.T) {
+		response, err := router.Test(httptest.NewRequest("GET", "/health", http.NoBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer response.Body.Close()
+		body, _ := io.ReadAll(response.Body)
+		if response.StatusCode != http.StatusOK || string(body) != `{"status":"UP"}` {
+			t.Errorf("expected 200 and {\"status\":\"UP\"}, got %d and %s", response.StatusCode, body)
+		}
+	})
+	t.Run("detailed", func(t *testing.T) {
+		response, err := router.Test(httptest.NewRequest("GET", "/health?detailed=true", http.NoBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer response.Body.Close()
+		if response.StatusCode != http.StatusOK {
+			t.Errorf("expected 200, got %d", response.StatusCode)
+		}
+		var detailed detailedHealth
+		if err := json.NewDecoder(response.Body).Decode(&detailed); err != nil {
+			t.Fatal(err)
+		}
+		if detailed.Status != healthStatusUp || detailed.Components == nil {
+			t.Fatalf("expected Gatus to be up, got %+v", detailed)
+		}
+		if detailed.Components.Store.Status != healthStatusUp || detailed.Components.Store.Type != "memory" {
+			t.Errorf("expected the memory store to be up, got %+v", detailed.Components.Store)
+		}
+		if detailed.Components.Watchdog.Status != healthStatusUp || detailed.Components.Alerting.Status != healthStatusUp || detailed.Components.Config.Status != healthStatusUp {
+			t.Errorf("expected every component to be up, got %+v", detailed.Components)
+		}
+	})
+}
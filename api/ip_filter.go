@@ -0,0 +1,33 @@
+package api
+
+import (
+	"strings"
+
+	"github.com/TwiN/gatus/v5/config/web"
+	"github.com/TwiN/gatus/v5/security"
+	"github.com/gofiber/fiber/v2"
+)
+
+// withIPFilter returns a handler rejecting the requests whose client isn't allowed to send a request to the path
+// requested by the IP filter passed as parameter
+func withIPFilter(ipFilter *web.IPFilterConfig, securityConfig *security.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !ipFilter.IsAllowed(c.IP(), c.Path()) {
+			recordAccessDenial(c, securityConfig, "ip is not allowed by the ip filter")
+			return c.Status(403).SendString("forbidden")
+		}
+		return c.Next()
+	}
+}
+
+// withClientIP returns a handler replacing the proxy header of the requests that come from one of the trusted proxies
+// by the IP of the client it holds, so that every IP on its left, which may have been sent by the client itself, is
+// ignored by fiber.Ctx.IP
+func withClientIP(webConfig *web.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if header := c.Get(webConfig.ProxyHeader); len(header) > 0 && c.IsProxyTrusted() {
+			c.Request().Header.Set(webConfig.ProxyHeader, strings.Clone(webConfig.ClientIP(header)))
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/web"
+)
+
+func TestWithIPFilter(t *testing.T) {
+	cfg := &config.Config{Web: &web.Config{
+		// The requests sent through fiber.App.Test come from 0.0.0.0
+		TrustedProxies: []string{"0.0.0.0", "10.0.0.1"},
+		IPFilter: &web.IPFilterConfig{
+			Deny:  []string{"203.0.113.0/24"},
+			Rules: []*web.IPFilterRule{{Paths: []string{"/api/v1/endpoints/*/external", "/api/v1/endpoints/external"}, Allow: []string{"10.0.0.0/8"}}},
+		},
+	}}
+	if err := cfg.Web.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	router := New(cfg).Router()
+	scenarios := []struct {
+		Name         string
+		Method       string
+		Path         string
+		ForwardedFor string
+		ExpectedCode int
+	}{
+		{Name: "allowed", Method: "GET", Path: "/health", ForwardedFor: "198.51.100.1", ExpectedCode: http.StatusOK},
+		{Name: "denied", Method: "GET", Path: "/health", ForwardedFor: "203.0.113.7", ExpectedCode: http.StatusForbidden},
+		{Name: "denied-behind-several-proxies", Method: "GET", Path: "/health", ForwardedFor: "203.0.113.7, 10.0.0.1", ExpectedCode: http.StatusForbidden},
+		{Name: "denied-spoofing-allowed-ip", Method: "GET", Path: "/health", ForwardedFor: "198.51.100.1, 203.0.113.7", ExpectedCode: http.StatusForbidden},
+		{Name: "denied-spoofing-trusted-proxy", Method: "GET", Path: "/health", ForwardedFor: "10.0.0.1, 203.0.113.7", ExpectedCode: http.StatusForbidden},
+		{Name: "invalid-ip-behind-trusted-proxy", Method: "GET", Path: "/health", ForwardedFor: "203.0.113.7, invalid, 10.0.0.1", ExpectedCode: http.StatusOK},
+		{Name: "push-from-outside", Method: "POST", Path: "/api/v1/endpoints/core_ext/external?success=true", ForwardedFor: "198.51.100.1", ExpectedCode: http.StatusForbidden},
+		{Name: "push-from-outside-spoofing-internal-network", Method: "POST", Path: "/api/v1/endpoints/core_ext/external?success=true", ForwardedFor: "10.1.2.3, 198.51.100.1", ExpectedCode: http.StatusForbidden},
+		{Name: "push-from-internal-network", Method: "POST", Path: "/api/v1/endpoints/core_ext/external?success=true", ForwardedFor: "10.1.2.3", ExpectedCode: http.StatusUnauthorized},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest(scenario.Method, scenario.Path, http.NoBody)
+			request.Header.Set("X-Forwarded-For", scenario.ForwardedFor)
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+		})
+	}
+}
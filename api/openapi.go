@@ -0,0 +1,67 @@
+package api
+
+import (
+	_ "embed"
+	"encoding/json"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// swaggerUIVersion is the version of Swagger UI whose assets are loaded by the interactive documentation of the API
+const swaggerUIVersion = "5.17.14"
+
+var (
+	//go:embed openapi.yaml
+	openAPISpecificationYAML []byte
+
+	openAPISpecificationJSON        []byte
+	openAPISpecificationJSONErr     error
+	convertOpenAPISpecificationOnce sync.Once
+)
+
+// OpenAPISpecificationYAML handles requests to retrieve the OpenAPI specification of the API in YAML
+func OpenAPISpecificationYAML(c *fiber.Ctx) error {
+	c.Set("Content-Type", "application/yaml")
+	return c.Status(200).Send(openAPISpecificationYAML)
+}
+
+// OpenAPISpecificationJSON handles requests to retrieve the OpenAPI specification of the API in JSON
+func OpenAPISpecificationJSON(c *fiber.Ctx) error {
+	convertOpenAPISpecificationOnce.Do(func() {
+		var specification map[string]any
+		if openAPISpecificationJSONErr = yaml.Unmarshal(openAPISpecificationYAML, &specification); openAPISpecificationJSONErr == nil {
+			openAPISpecificationJSON, openAPISpecificationJSONErr = json.Marshal(specification)
+		}
+	})
+	if openAPISpecificationJSONErr != nil {
+		return c.Status(500).SendString(openAPISpecificationJSONErr.Error())
+	}
+	c.Set("Content-Type", "application/json")
+	return c.Status(200).Send(openAPISpecificationJSON)
+}
+
+// APIDocs handles requests to the interactive documentation of the API, which is rendered by Swagger UI from the
+// OpenAPI specification of the API
+func APIDocs(c *fiber.Ctx) error {
+	c.Set("Content-Type", "text/html")
+	return c.Status(200).SendString(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>Gatus API</title>
+  <link rel="stylesheet" href="https://cdn.jsdelivr.net/npm/swagger-ui-dist@` + swaggerUIVersion + `/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://cdn.jsdelivr.net/npm/swagger-ui-dist@` + swaggerUIVersion + `/swagger-ui-bundle.js"></script>
+  <script>
+    window.onload = function() {
+      SwaggerUIBundle({url: "/api/v1/openapi.json", dom_id: "#swagger-ui", withCredentials: true});
+    };
+  </script>
+</body>
+</html>`)
+}
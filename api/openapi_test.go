@@ -0,0 +1,105 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/ui"
+	"github.com/TwiN/gatus/v5/config/web"
+	"github.com/gofiber/fiber/v2"
+)
+
+type openAPISpecification struct {
+	OpenAPI string                                `json:"openapi"`
+	Paths   map[string]map[string]json.RawMessage `json:"paths"`
+}
+
+func TestOpenAPISpecification(t *testing.T) {
+	router := New(&config.Config{UI: &ui.Config{}}).Router()
+	response, err := router.Test(httptest.NewRequest("GET", "/api/v1/openapi.json", http.NoBody))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != fiber.StatusOK {
+		t.Fatalf("expected status code %d, got %d", fiber.StatusOK, response.StatusCode)
+	}
+	if contentType := response.Header.Get("Content-Type"); contentType != "application/json" {
+		t.Errorf("expected Content-Type application/json, got %s", contentType)
+	}
+	var specification openAPISpecification
+	if err = json.NewDecoder(response.Body).Decode(&specification); err != nil {
+		t.Fatal("expected specification to be valid JSON, got", err.Error())
+	}
+	if !strings.HasPrefix(specification.OpenAPI, "3.") {
+		t.Errorf("expected OpenAPI 3 specification, got %s", specification.OpenAPI)
+	}
+	response, err = router.Test(httptest.NewRequest("GET", "/api/v1/openapi.yaml", http.NoBody))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	body, _ := io.ReadAll(response.Body)
+	if response.StatusCode != fiber.StatusOK || !strings.HasPrefix(string(body), "openapi: 3.") {
+		t.Errorf("expected YAML specification, got status code %d and body %.20q", response.StatusCode, body)
+	}
+}
+
+func TestOpenAPISpecification_DocumentsEveryRoute(t *testing.T) {
+	router := New(&config.Config{UI: &ui.Config{}}).Router()
+	response, err := router.Test(httptest.NewRequest("GET", "/api/v1/openapi.json", http.NoBody))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	var specification openAPISpecification
+	if err = json.NewDecoder(response.Body).Decode(&specification); err != nil {
+		t.Fatal(err)
+	}
+	routeParameter := regexp.MustCompile(`:(\w+)`)
+	documented := make(map[string]bool)
+	for _, route := range router.GetRoutes(true) {
+		if !strings.HasPrefix(route.Path, "/api/") || route.Method == fiber.MethodHead {
+			continue
+		}
+		path := routeParameter.ReplaceAllString(strings.TrimPrefix(route.Path, "/api"), "{$1}")
+		if _, exists := specification.Paths[path][strings.ToLower(route.Method)]; !exists {
+			t.Errorf("expected route %s %s to be documented as %s in openapi.yaml", route.Method, route.Path, path)
+		}
+		documented[strings.ToLower(route.Method)+" "+path] = true
+	}
+	for path, operations := range specification.Paths {
+		for method := range operations {
+			if !documented[method+" "+path] {
+				t.Errorf("expected %s %s documented in openapi.yaml to be a route of the API", strings.ToUpper(method), path)
+			}
+		}
+	}
+}
+
+func TestAPIDocs(t *testing.T) {
+	cfg := &config.Config{UI: &ui.Config{}, Web: web.GetDefaultConfig()}
+	response, err := New(cfg).Router().Test(httptest.NewRequest("GET", "/api/docs", http.NoBody))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.StatusCode != fiber.StatusNotFound {
+		t.Errorf("expected status code %d when the documentation is disabled, got %d", fiber.StatusNotFound, response.StatusCode)
+	}
+	cfg.Web.APIDocs = true
+	response, err = New(cfg).Router().Test(httptest.NewRequest("GET", "/api/docs", http.NoBody))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	body, _ := io.ReadAll(response.Body)
+	if response.StatusCode != fiber.StatusOK || !strings.Contains(string(body), `url: "/api/v1/openapi.json"`) {
+		t.Errorf("expected documentation to be rendered from the specification, got status code %d and body %s", response.StatusCode, body)
+	}
+}
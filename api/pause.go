@@ -0,0 +1,99 @@
+package api
+
+import (
+	"encoding/json"
+	"log"
+	"net/url"
+	"strings"
+
+	"github.com/TwiN/gatus/v5/audit"
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/watchdog"
+	"github.com/gofiber/fiber/v2"
+)
+
+type pauseResponse struct {
+	Keys   []string `json:"keys"`   // Keys of the endpoints that were paused or resumed
+	Paused bool     `json:"paused"` // Whether the endpoints are now paused
+}
+
+// PauseEndpoint handles requests to pause the monitoring of an endpoint until it is resumed
+func PauseEndpoint(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return setEndpointsPaused(c, cfg, true, false)
+	}
+}
+
+// ResumeEndpoint handles requests to resume the monitoring of an endpoint that was paused
+func ResumeEndpoint(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return setEndpointsPaused(c, cfg, false, false)
+	}
+}
+
+// PauseGroup handles requests to pause the monitoring of every endpoint of a group until they are resumed
+func PauseGroup(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return setEndpointsPaused(c, cfg, true, true)
+	}
+}
+
+// ResumeGroup handles requests to resume the monitoring of every endpoint of a group
+func ResumeGroup(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return setEndpointsPaused(c, cfg, false, true)
+	}
+}
+
+// setEndpointsPaused pauses or resumes the endpoint whose key is the key parameter, or, if byGroup is true, every
+// endpoint whose group is the group parameter
+func setEndpointsPaused(c *fiber.Ctx, cfg *config.Config, paused, byGroup bool) error {
+	var keys []string
+	if byGroup {
+		group, err := url.PathUnescape(c.Params("group"))
+		if err != nil {
+			return c.Status(400).SendString("invalid group: " + err.Error())
+		}
+		for _, ep := range cfg.Endpoints {
+			if ep.Group == group {
+				keys = append(keys, ep.Key())
+			}
+		}
+		if len(keys) == 0 {
+			return c.Status(404).SendString("group " + group + " has no endpoints")
+		}
+	} else {
+		// The key is kept after the request, so it must not reference the buffer of the request, which fiber reuses
+		key := strings.Clone(c.Params("key"))
+		if cfg.GetEndpointByKey(key) == nil {
+			if cfg.GetExternalEndpointByKey(key) != nil {
+				return c.Status(400).SendString("external endpoints are not monitored by Gatus, so they cannot be paused or resumed")
+			}
+			return c.Status(404).SendString("endpoint with key=" + key + " not found")
+		}
+		keys = append(keys, key)
+	}
+	action := audit.ActionEndpointResume
+	if paused {
+		action = audit.ActionEndpointPause
+	}
+	for _, key := range keys {
+		if paused {
+			watchdog.Pause(key)
+		} else {
+			watchdog.Resume(key)
+		}
+	}
+	log.Printf("[api.setEndpointsPaused] Set paused=%v for endpoints with keys=%s", paused, strings.Join(keys, ","))
+	store.Audit(audit.NewEntry(action, getActor(c), strings.Join(keys, ","), true, ""))
+	// The cached statuses would otherwise show the previous state of the endpoints
+	cache.Clear()
+	output, err := json.Marshal(&pauseResponse{Keys: keys, Paused: paused})
+	if err != nil {
+		log.Printf("[api.setEndpointsPaused] Unable to marshal object to JSON: %s", err.Error())
+		return c.Status(500).SendString("unable to marshal object to JSON")
+	}
+	c.Set("Content-Type", "application/json")
+	return c.Status(200).Send(output)
+}
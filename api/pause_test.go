@@ -0,0 +1,139 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/watchdog"
+)
+
+func TestPauseAndResume(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	cfg := &config.Config{
+		Endpoints: []*endpoint.Endpoint{
+			{Name: "frontend", Group: "core"},
+			{Name: "backend", Group: "core"},
+			{Name: "website", Group: "public apps"},
+		},
+		ExternalEndpoints: []*endpoint.ExternalEndpoint{
+			{Name: "job", Group: "core", Token: "token"},
+		},
+	}
+	for _, ep := range cfg.Endpoints {
+		defer watchdog.Resume(ep.Key())
+		watchdog.UpdateEndpointStatuses(ep, &endpoint.Result{Success: true, Duration: time.Millisecond, Timestamp: time.Now()})
+	}
+	router := New(cfg).Router()
+	scenarios := []struct {
+		Name           string
+		Path           string
+		ExpectedCode   int
+		ExpectedBody   string
+		ExpectedPaused map[string]bool
+	}{
+		{
+			Name:           "pause-endpoint",
+			Path:           "/api/v1/endpoints/core_frontend/pause",
+			ExpectedCode:   http.StatusOK,
+			ExpectedBody:   `{"keys":["core_frontend"],"paused":true}`,
+			ExpectedPaused: map[string]bool{"core_frontend": true, "core_backend": false, "public-apps_website": false},
+		},
+		{
+			Name:           "pause-group",
+			Path:           "/api/v1/groups/public%20apps/pause",
+			ExpectedCode:   http.StatusOK,
+			ExpectedBody:   `{"keys":["public-apps_website"],"paused":true}`,
+			ExpectedPaused: map[string]bool{"core_frontend": true, "core_backend": false, "public-apps_website": true},
+		},
+		{
+			Name:           "resume-endpoint",
+			Path:           "/api/v1/endpoints/public-apps_website/resume",
+			ExpectedCode:   http.StatusOK,
+			ExpectedBody:   `{"keys":["public-apps_website"],"paused":false}`,
+			ExpectedPaused: map[string]bool{"core_frontend": true, "core_backend": false, "public-apps_website": false},
+		},
+		{
+			Name:           "pause-group-again",
+			Path:           "/api/v1/groups/core/pause",
+			ExpectedCode:   http.StatusOK,
+			ExpectedBody:   `{"keys":["core_frontend","core_backend"],"paused":true}`,
+			ExpectedPaused: map[string]bool{"core_frontend": true, "core_backend": true, "public-apps_website": false},
+		},
+		{
+			Name:           "resume-group",
+			Path:           "/api/v1/groups/core/resume",
+			ExpectedCode:   http.StatusOK,
+			ExpectedBody:   `{"keys":["core_frontend","core_backend"],"paused":false}`,
+			ExpectedPaused: map[string]bool{"core_frontend": false, "core_backend": false, "public-apps_website": false},
+		},
+		{
+			Name:         "pause-external-endpoint",
+			Path:         "/api/v1/endpoints/core_job/pause",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "pause-unknown-endpoint",
+			Path:         "/api/v1/endpoints/core_nope/pause",
+			ExpectedCode: http.StatusNotFound,
+		},
+		{
+			Name:         "resume-unknown-group",
+			Path:         "/api/v1/groups/nope/resume",
+			ExpectedCode: http.StatusNotFound,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest("POST", scenario.Path, http.NoBody)
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+			if len(scenario.ExpectedBody) > 0 {
+				body, _ := io.ReadAll(response.Body)
+				if string(body) != scenario.ExpectedBody {
+					t.Errorf("expected:\n%s\n\ngot:\n%s", scenario.ExpectedBody, body)
+				}
+			}
+			if scenario.ExpectedPaused == nil {
+				return
+			}
+			// The statuses must reflect the new state right away, despite the cache
+			statusesResponse, err := router.Test(httptest.NewRequest("GET", "/api/v1/endpoints/statuses", http.NoBody))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer statusesResponse.Body.Close()
+			var endpointStatuses []*endpoint.Status
+			if err = json.NewDecoder(statusesResponse.Body).Decode(&endpointStatuses); err != nil {
+				t.Fatal(err)
+			}
+			for _, endpointStatus := range endpointStatuses {
+				if endpointStatus.Paused != scenario.ExpectedPaused[endpointStatus.Key] {
+					t.Errorf("expected paused of %s to be %v, got %v", endpointStatus.Key, scenario.ExpectedPaused[endpointStatus.Key], endpointStatus.Paused)
+				}
+			}
+		})
+	}
+	response, err := router.Test(httptest.NewRequest("GET", "/api/v1/endpoints/core_frontend/statuses", http.NoBody))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	if body, _ := io.ReadAll(response.Body); strings.Contains(string(body), `"paused"`) {
+		t.Errorf("an endpoint that isn't paused should not have the paused field, got %s", body)
+	}
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/TwiN/gatus/v5/audit"
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ReloadConfiguration handles requests to reload the configuration without waiting for the configuration file to be
+// detected as modified, and responds with the endpoints that were added, removed or changed.
+//
+// The configuration is loaded and validated before responding, but it is only applied once the response is sent,
+// since applying it restarts the server handling the request.
+func ReloadConfiguration(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		updatedConfig, err := cfg.Reload()
+		if err != nil {
+			log.Printf("[api.ReloadConfiguration] Failed to load configuration: %s", err.Error())
+			store.Audit(audit.NewEntry(audit.ActionConfigurationReload, getActor(c), "", false, err.Error()))
+			return c.Status(400).SendString("failed to load configuration: " + err.Error())
+		}
+		diff := config.DiffEndpoints(cfg, updatedConfig)
+		output, err := json.Marshal(diff)
+		if err != nil {
+			log.Printf("[api.ReloadConfiguration] Unable to marshal object to JSON: %s", err.Error())
+			return c.Status(500).SendString("unable to marshal object to JSON")
+		}
+		if !cfg.RequestReload(updatedConfig) {
+			return c.Status(409).SendString("the configuration is already being reloaded")
+		}
+		log.Printf("[api.ReloadConfiguration] Reloading configuration with %d endpoints added, %d removed and %d changed", len(diff.Added), len(diff.Removed), len(diff.Changed))
+		store.Audit(audit.NewEntry(audit.ActionConfigurationReload, getActor(c), "", true, ""))
+		c.Set("Content-Type", "application/json")
+		return c.Status(200).Send(output)
+	}
+}
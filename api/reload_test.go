@@ -0,0 +1,96 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/config"
+)
+
+func TestReloadConfiguration(t *testing.T) {
+	defer cache.Clear()
+	configFilePath := filepath.Join(t.TempDir(), "config.yaml")
+	_ = os.WriteFile(configFilePath, []byte(`endpoints:
+  - name: frontend
+    group: core
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+  - name: backend
+    group: core
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+`), 0644)
+	cfg, err := config.LoadConfiguration(configFilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	router := New(cfg).Router()
+	_ = os.WriteFile(configFilePath, []byte(`endpoints:
+  - name: frontend
+    group: core
+    url: https://example.com
+    conditions:
+      - "[STATUS] == 200"
+  - name: database
+    group: core
+    url: tcp://example.org:5432
+    conditions:
+      - "[CONNECTED] == true"
+`), 0644)
+	t.Run("reload", func(t *testing.T) {
+		response, err := router.Test(httptest.NewRequest("POST", "/api/v1/admin/reload", http.NoBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer response.Body.Close()
+		if response.StatusCode != http.StatusOK {
+			t.Fatalf("expected %d, got %d", http.StatusOK, response.StatusCode)
+		}
+		body, _ := io.ReadAll(response.Body)
+		var diff config.EndpointsDiff
+		if err := json.Unmarshal(body, &diff); err != nil {
+			t.Fatal(err)
+		}
+		expected := config.EndpointsDiff{Added: []string{"core_database"}, Removed: []string{"core_backend"}, Changed: []string{"core_frontend"}}
+		if !reflect.DeepEqual(diff, expected) {
+			t.Errorf("expected %+v, got %+v", expected, diff)
+		}
+	})
+	t.Run("reload-while-already-reloading", func(t *testing.T) {
+		response, err := router.Test(httptest.NewRequest("POST", "/api/v1/admin/reload", http.NoBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer response.Body.Close()
+		if response.StatusCode != http.StatusConflict {
+			t.Errorf("expected %d, got %d", http.StatusConflict, response.StatusCode)
+		}
+	})
+	if updatedConfig := <-cfg.ReloadRequests(); len(updatedConfig.Endpoints) != 2 || updatedConfig.Endpoints[1].Name != "database" {
+		t.Error("expected the reloaded configuration to be requested to replace the current one")
+	}
+	t.Run("reload-invalid-configuration", func(t *testing.T) {
+		_ = os.WriteFile(configFilePath, []byte(`endpoints: []`), 0644)
+		response, err := router.Test(httptest.NewRequest("POST", "/api/v1/admin/reload", http.NoBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer response.Body.Close()
+		if response.StatusCode != http.StatusBadRequest {
+			t.Errorf("expected %d, got %d", http.StatusBadRequest, response.StatusCode)
+		}
+		select {
+		case <-cfg.ReloadRequests():
+			t.Error("expected an invalid configuration not to be requested to replace the current one")
+		default:
+		}
+	})
+}
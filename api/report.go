@@ -0,0 +1,188 @@
+package api
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+var errInvalidReportPeriod = errors.New("period must be a month (e.g. 2024-06) or a quarter (e.g. 2024-Q2)")
+
+// slaReport is the uptime of the endpoints during a month or a quarter, compared to the target of their SLA
+type slaReport struct {
+	// Period covered by the report, e.g. 2024-06 or 2024-Q2
+	Period string `json:"period"`
+
+	// Group the endpoints of the report were filtered by, if any
+	Group string `json:"group,omitempty"`
+
+	// From is the start of the period, inclusive
+	From time.Time `json:"from"`
+
+	// To is the end of the period, exclusive
+	To time.Time `json:"to"`
+
+	Endpoints []*slaReportEntry `json:"endpoints"`
+}
+
+// slaReportEntry is the uptime of a single endpoint during the period of an slaReport
+type slaReportEntry struct {
+	Key   string `json:"key"`
+	Name  string `json:"name"`
+	Group string `json:"group,omitempty"`
+
+	// Uptime is the percentage of successful executions during the period
+	Uptime float64 `json:"uptime"`
+
+	// Target is the uptime percentage of the SLA of the endpoint
+	Target float64 `json:"target"`
+
+	// DowntimeMinutes is the number of minutes of the period that the endpoint is considered to have been down for,
+	// which is the share of unsuccessful executions applied to the part of the period that has already elapsed
+	DowntimeMinutes float64 `json:"downtimeMinutes"`
+
+	// Breached is whether the uptime is lower than the target
+	Breached bool `json:"breached"`
+}
+
+// SLAReport handles requests to generate a report of the uptime of every endpoint during a month or a quarter,
+// compared to the target of their SLA, which is the one configured through ui.badge.sla.
+//
+// The report is returned as JSON, unless the format query parameter is set to csv.
+func SLAReport(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		period := c.Query("period")
+		from, to, err := parseReportPeriod(period)
+		if err != nil {
+			return c.Status(400).SendString(err.Error())
+		}
+		now := time.Now()
+		if from.After(now) {
+			return c.Status(400).SendString("period " + period + " has not started yet")
+		}
+		format := c.Query("format", "json")
+		if format != "json" && format != "csv" {
+			return c.Status(400).SendString("format must be json or csv")
+		}
+		elapsed := to.Sub(from)
+		if to.After(now) {
+			elapsed = now.Sub(from)
+		}
+		report := &slaReport{Period: period, Group: c.Query("group"), From: from, To: to, Endpoints: []*slaReportEntry{}}
+		hiddenGroups := getHiddenGroups(c, cfg.Security, cfg.Groups)
+		for _, key := range getReportEndpointKeys(cfg, report.Group) {
+			if hiddenGroups[key.group] {
+				continue
+			}
+			// The uptimes are computed up to the end of the period, exclusive, so that the first hour of the next
+			// period isn't taken into account
+			uptime, err := store.Get().GetUptimeByKey(key.key, from, from.Add(elapsed-time.Nanosecond))
+			if err != nil {
+				if errors.Is(err, common.ErrEndpointNotFound) {
+					continue
+				}
+				log.Printf("[api.SLAReport] Failed to retrieve uptime of endpoint with key=%s: %s", key.key, err.Error())
+				return c.Status(500).SendString(err.Error())
+			}
+			target := newBadgeOptions(key.key, cfg).slaTarget
+			report.Endpoints = append(report.Endpoints, &slaReportEntry{
+				Key:             key.key,
+				Name:            key.name,
+				Group:           key.group,
+				Uptime:          roundReportValue(uptime * 100),
+				Target:          roundReportValue(target * 100),
+				DowntimeMinutes: roundReportValue((1 - uptime) * elapsed.Minutes()),
+				Breached:        uptime < target,
+			})
+		}
+		if format == "csv" {
+			output, err := report.csv()
+			if err != nil {
+				log.Printf("[api.SLAReport] Unable to write report as CSV: %s", err.Error())
+				return c.Status(500).SendString("unable to write report as CSV")
+			}
+			c.Set("Content-Type", "text/csv")
+			c.Set("Content-Disposition", `attachment; filename="sla-`+period+`.csv"`)
+			return c.Status(200).Send(output)
+		}
+		output, err := json.Marshal(report)
+		if err != nil {
+			log.Printf("[api.SLAReport] Unable to marshal object to JSON: %s", err.Error())
+			return c.Status(500).SendString("unable to marshal object to JSON")
+		}
+		c.Set("Content-Type", "application/json")
+		return c.Status(200).Send(output)
+	}
+}
+
+// csv returns the entries of the report as CSV, with a header
+func (report *slaReport) csv() ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	writer := csv.NewWriter(buffer)
+	_ = writer.Write([]string{"key", "name", "group", "uptime", "target", "downtime_minutes", "breached"})
+	for _, entry := range report.Endpoints {
+		_ = writer.Write([]string{
+			entry.Key,
+			entry.Name,
+			entry.Group,
+			strconv.FormatFloat(entry.Uptime, 'f', -1, 64),
+			strconv.FormatFloat(entry.Target, 'f', -1, 64),
+			strconv.FormatFloat(entry.DowntimeMinutes, 'f', -1, 64),
+			strconv.FormatBool(entry.Breached),
+		})
+	}
+	writer.Flush()
+	return buffer.Bytes(), writer.Error()
+}
+
+// reportEndpointKey is the key, the name and the group of an endpoint included in a report
+type reportEndpointKey struct {
+	key, name, group string
+}
+
+// getReportEndpointKeys returns the endpoints and the external endpoints of the group passed as parameter, or all of
+// them if the group is empty
+func getReportEndpointKeys(cfg *config.Config, group string) []reportEndpointKey {
+	var keys []reportEndpointKey
+	for _, ep := range cfg.Endpoints {
+		if len(group) == 0 || ep.Group == group {
+			keys = append(keys, reportEndpointKey{key: ep.Key(), name: ep.Name, group: ep.Group})
+		}
+	}
+	for _, ee := range cfg.ExternalEndpoints {
+		if len(group) == 0 || ee.Group == group {
+			keys = append(keys, reportEndpointKey{key: ee.Key(), name: ee.Name, group: ee.Group})
+		}
+	}
+	return keys
+}
+
+// parseReportPeriod returns the start, inclusive, and the end, exclusive, of the month (e.g. 2024-06) or of the
+// quarter (e.g. 2024-Q2) passed as parameter, in UTC
+func parseReportPeriod(period string) (time.Time, time.Time, error) {
+	if month, err := time.Parse("2006-01", period); err == nil {
+		return month, month.AddDate(0, 1, 0), nil
+	}
+	var year, quarter int
+	if n, err := fmt.Sscanf(period, "%4d-Q%1d", &year, &quarter); err != nil || n != 2 || quarter < 1 || quarter > 4 || len(period) != len("2006-Q1") {
+		return time.Time{}, time.Time{}, errInvalidReportPeriod
+	}
+	from := time.Date(year, time.Month(3*(quarter-1)+1), 1, 0, 0, 0, 0, time.UTC)
+	return from, from.AddDate(0, 3, 0), nil
+}
+
+// roundReportValue rounds a value of a report to 3 decimals, so that e.g. 99.95 isn't reported as 99.94999999999999
+func roundReportValue(value float64) float64 {
+	return math.Round(value*1000) / 1000
+}
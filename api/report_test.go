@@ -0,0 +1,147 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/endpoint/ui"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/watchdog"
+)
+
+func TestSLAReport(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	cfg := &config.Config{
+		Endpoints: []*endpoint.Endpoint{
+			{Name: "frontend", Group: "core", UIConfig: &ui.Config{Badge: &ui.Badge{SLA: &ui.SLA{Target: 50}}}},
+			{Name: "backend", Group: "core"},
+			{Name: "website", Group: "misc"},
+		},
+	}
+	now := time.Now().UTC()
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: true, Timestamp: now})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: false, Timestamp: now})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[1], &endpoint.Result{Success: true, Timestamp: now})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[1], &endpoint.Result{Success: false, Timestamp: now})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[2], &endpoint.Result{Success: true, Timestamp: now})
+	router := New(cfg).Router()
+	currentMonth := now.Format("2006-01")
+	scenarios := []struct {
+		Name                  string
+		Path                  string
+		ExpectedCode          int
+		ExpectedContentType   string
+		ExpectedBodyToContain string
+	}{
+		{
+			Name:         "missing-period",
+			Path:         "/api/v1/reports/sla",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "invalid-period",
+			Path:         "/api/v1/reports/sla?period=2024-Q5",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "future-period",
+			Path:         "/api/v1/reports/sla?period=" + now.AddDate(0, 2, 0).Format("2006-01"),
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "invalid-format",
+			Path:         "/api/v1/reports/sla?period=" + currentMonth + "&format=xml",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:                "json",
+			Path:                "/api/v1/reports/sla?period=" + currentMonth + "&group=core",
+			ExpectedCode:        http.StatusOK,
+			ExpectedContentType: "application/json",
+		},
+		{
+			Name:                  "csv",
+			Path:                  "/api/v1/reports/sla?period=" + currentMonth + "&group=misc&format=csv",
+			ExpectedCode:          http.StatusOK,
+			ExpectedContentType:   "text/csv",
+			ExpectedBodyToContain: "key,name,group,uptime,target,downtime_minutes,breached\nmisc_website,website,misc,100,99.9,0,false\n",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", scenario.Path, http.NoBody)
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+			if len(scenario.ExpectedContentType) > 0 && response.Header.Get("Content-Type") != scenario.ExpectedContentType {
+				t.Errorf("expected Content-Type %s, got %s", scenario.ExpectedContentType, response.Header.Get("Content-Type"))
+			}
+			body, _ := io.ReadAll(response.Body)
+			if len(scenario.ExpectedBodyToContain) > 0 && !strings.Contains(string(body), scenario.ExpectedBodyToContain) {
+				t.Errorf("expected body to contain %q, got %q", scenario.ExpectedBodyToContain, body)
+			}
+		})
+	}
+	t.Run("json-entries", func(t *testing.T) {
+		response, err := router.Test(httptest.NewRequest("GET", "/api/v1/reports/sla?period="+currentMonth+"&group=core", http.NoBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer response.Body.Close()
+		var report slaReport
+		if err := json.NewDecoder(response.Body).Decode(&report); err != nil {
+			t.Fatal(err)
+		}
+		if report.Period != currentMonth || report.Group != "core" || len(report.Endpoints) != 2 {
+			t.Fatalf("unexpected report: %+v", report)
+		}
+		if frontend := report.Endpoints[0]; frontend.Key != "core_frontend" || frontend.Uptime != 50 || frontend.Target != 50 || frontend.Breached {
+			t.Errorf("unexpected entry for frontend: %+v", frontend)
+		}
+		if backend := report.Endpoints[1]; backend.Key != "core_backend" || backend.Uptime != 50 || backend.Target != 99.9 || !backend.Breached || backend.DowntimeMinutes <= 0 {
+			t.Errorf("unexpected entry for backend: %+v", backend)
+		}
+	})
+}
+
+func TestParseReportPeriod(t *testing.T) {
+	scenarios := []struct {
+		Period       string
+		ExpectedFrom time.Time
+		ExpectedTo   time.Time
+		ExpectedErr  error
+	}{
+		{Period: "2024-06", ExpectedFrom: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), ExpectedTo: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)},
+		{Period: "2024-12", ExpectedFrom: time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC), ExpectedTo: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Period: "2024-Q1", ExpectedFrom: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), ExpectedTo: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{Period: "2024-Q4", ExpectedFrom: time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC), ExpectedTo: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Period: "", ExpectedErr: errInvalidReportPeriod},
+		{Period: "2024-13", ExpectedErr: errInvalidReportPeriod},
+		{Period: "2024-Q0", ExpectedErr: errInvalidReportPeriod},
+		{Period: "2024-Q2x", ExpectedErr: errInvalidReportPeriod},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Period, func(t *testing.T) {
+			from, to, err := parseReportPeriod(scenario.Period)
+			if err != scenario.ExpectedErr {
+				t.Fatalf("expected error %v, got %v", scenario.ExpectedErr, err)
+			}
+			if !from.Equal(scenario.ExpectedFrom) || !to.Equal(scenario.ExpectedTo) {
+				t.Errorf("expected %s to %s, got %s to %s", scenario.ExpectedFrom, scenario.ExpectedTo, from, to)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// MinimumResponseTimeStep is the smallest step supported by the response time series
+	MinimumResponseTimeStep = time.Minute
+
+	// MaximumNumberOfResponseTimePoints is the maximum number of points a response time series can have, which is
+	// the duration of its time range divided by its step
+	MaximumNumberOfResponseTimePoints = 1000
+)
+
+// responseTimePoint is the response time of an endpoint aggregated over a step of a response time series
+type responseTimePoint struct {
+	Timestamp time.Time `json:"timestamp"` // Start of the step
+	Value     int64     `json:"value"`     // Aggregated response time in milliseconds
+	Count     int       `json:"count"`     // Number of results aggregated
+}
+
+// EndpointResponseTimes handles requests to retrieve the response times of an endpoint as a series aggregated by step,
+// so that the response time can be charted over any time range without retrieving the results of the endpoint.
+//
+// Query parameters:
+//   - from: start of the time range, in RFC3339 (default: 24 hours before to)
+//   - to: end of the time range, in RFC3339 (default: now)
+//   - step: duration of each point of the series, e.g. 5m (default: 1h)
+//   - agg: aggregation of the response times of each step -> avg (default), min, max, p50, p90, p95, p99
+//
+// Steps without results are omitted from the series.
+func EndpointResponseTimes(c *fiber.Ctx) error {
+	to, from := time.Now(), time.Time{}
+	var err error
+	if value := c.Query("to"); len(value) > 0 {
+		if to, err = time.Parse(time.RFC3339, value); err != nil {
+			return c.Status(400).SendString("invalid to query parameter: must be in RFC3339")
+		}
+	}
+	if value := c.Query("from"); len(value) > 0 {
+		if from, err = time.Parse(time.RFC3339, value); err != nil {
+			return c.Status(400).SendString("invalid from query parameter: must be in RFC3339")
+		}
+	} else {
+		from = to.Add(-24 * time.Hour)
+	}
+	if !from.Before(to) {
+		return c.Status(400).SendString(common.ErrInvalidTimeRange.Error())
+	}
+	step, err := time.ParseDuration(c.Query("step", "1h"))
+	if err != nil || step < MinimumResponseTimeStep {
+		return c.Status(400).SendString("invalid step query parameter: must be a duration of at least " + MinimumResponseTimeStep.String())
+	}
+	if to.Sub(from)/step >= MaximumNumberOfResponseTimePoints {
+		return c.Status(400).SendString(fmt.Sprintf("the time range cannot be divided into more than %d steps", MaximumNumberOfResponseTimePoints))
+	}
+	aggregation := c.Query("agg", "avg")
+	aggregate, ok := getResponseTimeAggregationFunc(aggregation)
+	if !ok {
+		return c.Status(400).SendString("Aggregations supported: avg, min, max, p50, p90, p95, p99")
+	}
+	responseTimeStore, ok := store.Get().(store.ResponseTimeStore)
+	if !ok {
+		return c.Status(404).SendString("response time series are not supported by the configured storage type")
+	}
+	responseTimes, err := responseTimeStore.GetResponseTimesByKey(c.Params("key"), from, to)
+	if err != nil {
+		if errors.Is(err, common.ErrEndpointNotFound) {
+			return c.Status(404).SendString(err.Error())
+		} else if errors.Is(err, common.ErrInvalidTimeRange) {
+			return c.Status(400).SendString(err.Error())
+		}
+		log.Printf("[api.EndpointResponseTimes] Failed to retrieve response times: %s", err.Error())
+		return c.Status(500).SendString(err.Error())
+	}
+	output, err := json.Marshal(aggregateResponseTimes(responseTimes, from, step, aggregate))
+	if err != nil {
+		log.Printf("[api.EndpointResponseTimes] Unable to marshal object to JSON: %s", err.Error())
+		return c.Status(500).SendString("unable to marshal object to JSON")
+	}
+	c.Set("Content-Type", "application/json")
+	return c.Status(200).Send(output)
+}
+
+// getResponseTimeAggregationFunc returns the function aggregating response times in milliseconds for the aggregation
+// passed, and whether the aggregation is supported
+func getResponseTimeAggregationFunc(aggregation string) (func(responseTimes []int) int, bool) {
+	switch aggregation {
+	case "avg":
+		return func(responseTimes []int) int {
+			total := 0
+			for _, responseTime := range responseTimes {
+				total += responseTime
+			}
+			return total / len(responseTimes)
+		}, true
+	case "min":
+		return slices.Min[[]int], true
+	case "max":
+		return slices.Max[[]int], true
+	case "p50":
+		return func(responseTimes []int) int { return getResponseTimePercentile(responseTimes, 0.5) }, true
+	case "p90":
+		return func(responseTimes []int) int { return getResponseTimePercentile(responseTimes, 0.9) }, true
+	case "p95":
+		return func(responseTimes []int) int { return getResponseTimePercentile(responseTimes, 0.95) }, true
+	case "p99":
+		return func(responseTimes []int) int { return getResponseTimePercentile(responseTimes, 0.99) }, true
+	default:
+		return nil, false
+	}
+}
+
+// aggregateResponseTimes groups the response times, sorted from oldest to newest, in steps starting from the time
+// passed, and aggregates each step into a point
+func aggregateResponseTimes(responseTimes []*endpoint.ResponseTime, from time.Time, step time.Duration, aggregate func(responseTimes []int) int) []*responseTimePoint {
+	points := make([]*responseTimePoint, 0)
+	var stepResponseTimes []int
+	var stepStart time.Time
+	flush := func() {
+		if len(stepResponseTimes) > 0 {
+			points = append(points, &responseTimePoint{Timestamp: stepStart, Value: int64(aggregate(stepResponseTimes)), Count: len(stepResponseTimes)})
+		}
+		stepResponseTimes = nil
+	}
+	for _, responseTime := range responseTimes {
+		start := from.Add(responseTime.Timestamp.Sub(from) / step * step)
+		if !start.Equal(stepStart) {
+			flush()
+			stepStart = start
+		}
+		stepResponseTimes = append(stepResponseTimes, int(responseTime.Duration.Milliseconds()))
+	}
+	flush()
+	return points
+}
@@ -0,0 +1,134 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+func TestEndpointResponseTimes(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	to := time.Now().Truncate(time.Hour)
+	from := to.Add(-2 * time.Hour)
+	for _, result := range []struct {
+		Timestamp time.Time
+		Duration  time.Duration
+	}{
+		{Timestamp: from.Add(5 * time.Minute), Duration: 100 * time.Millisecond},
+		{Timestamp: from.Add(20 * time.Minute), Duration: 200 * time.Millisecond},
+		{Timestamp: from.Add(40 * time.Minute), Duration: 600 * time.Millisecond},
+		{Timestamp: from.Add(90 * time.Minute), Duration: 50 * time.Millisecond},
+	} {
+		storedResult := testSuccessfulResult
+		storedResult.Timestamp, storedResult.Duration = result.Timestamp, result.Duration
+		store.Get().Insert(&testEndpoint, &storedResult)
+	}
+	router := New(&config.Config{}).Router()
+	timeRange := "from=" + url.QueryEscape(from.Format(time.RFC3339)) + "&to=" + url.QueryEscape(to.Format(time.RFC3339))
+	scenarios := []struct {
+		Name           string
+		Path           string
+		ExpectedCode   int
+		ExpectedPoints []responseTimePoint
+	}{
+		{
+			Name:         "avg",
+			Path:         "/api/v1/endpoints/group_name/response-times?" + timeRange,
+			ExpectedCode: http.StatusOK,
+			ExpectedPoints: []responseTimePoint{
+				{Timestamp: from, Value: 300, Count: 3},
+				{Timestamp: from.Add(time.Hour), Value: 50, Count: 1},
+			},
+		},
+		{
+			Name:         "max-by-30m",
+			Path:         "/api/v1/endpoints/group_name/response-times?" + timeRange + "&step=30m&agg=max",
+			ExpectedCode: http.StatusOK,
+			ExpectedPoints: []responseTimePoint{
+				{Timestamp: from, Value: 200, Count: 2},
+				{Timestamp: from.Add(30 * time.Minute), Value: 600, Count: 1},
+				{Timestamp: from.Add(90 * time.Minute), Value: 50, Count: 1},
+			},
+		},
+		{
+			Name:         "p50-by-2h",
+			Path:         "/api/v1/endpoints/group_name/response-times?" + timeRange + "&step=2h&agg=p50",
+			ExpectedCode: http.StatusOK,
+			ExpectedPoints: []responseTimePoint{
+				{Timestamp: from, Value: 100, Count: 4},
+			},
+		},
+		{
+			Name:           "no-result-in-time-range",
+			Path:           "/api/v1/endpoints/group_name/response-times?to=" + url.QueryEscape(from.Format(time.RFC3339)),
+			ExpectedCode:   http.StatusOK,
+			ExpectedPoints: []responseTimePoint{},
+		},
+		{
+			Name:         "invalid-from",
+			Path:         "/api/v1/endpoints/group_name/response-times?from=yesterday",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "from-after-to",
+			Path:         "/api/v1/endpoints/group_name/response-times?from=" + url.QueryEscape(to.Format(time.RFC3339)) + "&to=" + url.QueryEscape(from.Format(time.RFC3339)),
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "step-too-small",
+			Path:         "/api/v1/endpoints/group_name/response-times?step=30s",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "too-many-steps",
+			Path:         "/api/v1/endpoints/group_name/response-times?step=1m",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "invalid-aggregation",
+			Path:         "/api/v1/endpoints/group_name/response-times?agg=median",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "endpoint-not-found",
+			Path:         "/api/v1/endpoints/nope/response-times",
+			ExpectedCode: http.StatusNotFound,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", scenario.Path, http.NoBody)
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Fatalf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+			if scenario.ExpectedCode != http.StatusOK {
+				return
+			}
+			var points []responseTimePoint
+			if err := json.NewDecoder(response.Body).Decode(&points); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			if len(points) != len(scenario.ExpectedPoints) {
+				t.Fatalf("expected %d points, got %d", len(scenario.ExpectedPoints), len(points))
+			}
+			for i, point := range points {
+				expected := scenario.ExpectedPoints[i]
+				if !point.Timestamp.Equal(expected.Timestamp) || point.Value != expected.Value || point.Count != expected.Count {
+					t.Errorf("expected point #%d to be %+v, got %+v", i+1, expected, point)
+				}
+			}
+		})
+	}
+}
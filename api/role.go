@@ -0,0 +1,67 @@
+package api
+
+import (
+	"strconv"
+
+	"github.com/TwiN/gatus/v5/audit"
+	"github.com/TwiN/gatus/v5/security"
+	"github.com/TwiN/gatus/v5/token"
+	"github.com/gofiber/fiber/v2"
+)
+
+// requireRole returns a handler rejecting the requests whose client doesn't have the role passed as parameter, which is
+// either the role granted by the scopes of the API token the request was authenticated with, or the role of the user
+func requireRole(securityConfig *security.Config, role security.Role) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !getRole(c, securityConfig).Includes(role) {
+			if _, ok := c.Locals(tokenLocalsKey).(*token.Token); ok {
+				recordAccessDenial(c, securityConfig, "token does not have a scope granting the "+string(role)+" role")
+				return c.Status(403).SendString("token does not have a scope granting the " + string(role) + " role")
+			}
+			if user := getUser(c, securityConfig); user != nil {
+				recordAccessDenial(c, securityConfig, "user "+user.Subject+" does not have the "+string(role)+" role")
+			}
+			return c.Status(403).SendString("the " + string(role) + " role is required")
+		}
+		return c.Next()
+	}
+}
+
+// getRole returns the role of the client of the request. Every client is an admin if there's no security
+// configuration.
+func getRole(c *fiber.Ctx, securityConfig *security.Config) security.Role {
+	if _, ok := c.Locals(tokenLocalsKey).(*token.Token); !ok && securityConfig == nil {
+		return security.RoleAdmin
+	}
+	if user := getUser(c, securityConfig); user != nil {
+		return user.Role
+	}
+	return ""
+}
+
+// getUser returns the user the request is authenticated as, or nil if it isn't authenticated. The requests
+// authenticated by an API token are considered being the token's, with the role granted by its scopes.
+func getUser(c *fiber.Ctx, securityConfig *security.Config) *security.User {
+	if t, ok := c.Locals(tokenLocalsKey).(*token.Token); ok {
+		user := &security.User{Subject: "token:" + strconv.FormatInt(t.ID, 10)}
+		switch {
+		case t.HasScope(token.ScopeAdmin):
+			user.Role = security.RoleAdmin
+		case t.HasScope(token.ScopeOperate):
+			user.Role = security.RoleOperator
+		case t.HasScope(token.ScopeReadStatuses):
+			user.Role = security.RoleViewer
+		}
+		return user
+	}
+	if securityConfig == nil {
+		return nil
+	}
+	return securityConfig.GetUser(c)
+}
+
+// recordAccessDenial records the denial of the request in the audit of the security configuration passed, if any,
+// with the reason passed as parameter
+func recordAccessDenial(c *fiber.Ctx, securityConfig *security.Config, reason string) {
+	securityConfig.RecordAuditEvent(audit.NewEntry(audit.ActionAccessDenial, getActor(c), c.Method()+" "+c.Path(), false, reason))
+}
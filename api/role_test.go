@@ -0,0 +1,119 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/security"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/token"
+	"github.com/TwiN/gatus/v5/watchdog"
+)
+
+func TestRequireRole(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	defer watchdog.Resume("core_frontend")
+	basic := &security.BasicConfig{
+		Username:                        "john.doe",
+		PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT",
+	}
+	endpoints := []*endpoint.Endpoint{{Name: "frontend", Group: "core", URL: "https://example.org"}}
+	secret, hash, err := token.Generate()
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if err = store.Get().(store.TokenStore).InsertToken(&token.Token{Name: "ops", Scopes: []token.Scope{token.ScopeOperate}, Hash: hash, Timestamp: time.Now()}); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	scenarios := []struct {
+		Name         string
+		Security     *security.Config
+		Method       string
+		Path         string
+		BearerToken  string
+		ExpectedCode int
+	}{
+		{
+			Name:         "viewer-reading-statuses",
+			Security:     &security.Config{Basic: basic, DefaultRole: security.RoleViewer},
+			Method:       "GET",
+			Path:         "/api/v1/endpoints/statuses",
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "viewer-pausing-endpoint",
+			Security:     &security.Config{Basic: basic, DefaultRole: security.RoleViewer},
+			Method:       "POST",
+			Path:         "/api/v1/endpoints/core_frontend/pause",
+			ExpectedCode: http.StatusForbidden,
+		},
+		{
+			Name:         "operator-pausing-endpoint",
+			Security:     &security.Config{Basic: basic, DefaultRole: security.RoleViewer, Roles: map[security.Role][]string{security.RoleOperator: {"john.doe"}}},
+			Method:       "POST",
+			Path:         "/api/v1/endpoints/core_frontend/pause",
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "operator-reading-audit-log",
+			Security:     &security.Config{Basic: basic, DefaultRole: security.RoleOperator},
+			Method:       "GET",
+			Path:         "/api/v1/audit",
+			ExpectedCode: http.StatusForbidden,
+		},
+		{
+			Name:         "admin-reading-audit-log",
+			Security:     &security.Config{Basic: basic, DefaultRole: security.RoleViewer, Roles: map[security.Role][]string{security.RoleAdmin: {"john.doe"}}},
+			Method:       "GET",
+			Path:         "/api/v1/audit",
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "without-roles-reading-audit-log",
+			Security:     &security.Config{Basic: basic},
+			Method:       "GET",
+			Path:         "/api/v1/audit",
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "operate-token-resuming-endpoint",
+			Security:     &security.Config{Basic: basic},
+			Method:       "POST",
+			Path:         "/api/v1/endpoints/core_frontend/resume",
+			BearerToken:  secret,
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "operate-token-reloading-configuration",
+			Security:     &security.Config{Basic: basic},
+			Method:       "POST",
+			Path:         "/api/v1/admin/reload",
+			BearerToken:  secret,
+			ExpectedCode: http.StatusForbidden,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			router := New(&config.Config{Security: scenario.Security, Endpoints: endpoints}).Router()
+			request := httptest.NewRequest(scenario.Method, scenario.Path, http.NoBody)
+			if len(scenario.BearerToken) > 0 {
+				request.Header.Set("Authorization", "Bearer "+scenario.BearerToken)
+			} else {
+				request.SetBasicAuth("john.doe", "hunter2")
+			}
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package api
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/ui"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+	"github.com/gofiber/fiber/v2"
+)
+
+// statuspagePageID is the identifier of the page in the responses of the Statuspage-compatible API
+const statuspagePageID = "gatus"
+
+// Statuses of the components of the Statuspage-compatible API
+const (
+	statuspageComponentStatusOperational      = "operational"
+	statuspageComponentStatusPartialOutage    = "partial_outage"
+	statuspageComponentStatusMajorOutage      = "major_outage"
+	statuspageComponentStatusUnderMaintenance = "under_maintenance"
+)
+
+type statuspagePage struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	URL       string    `json:"url"`
+	TimeZone  string    `json:"time_zone"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type statuspageStatus struct {
+	Indicator   string `json:"indicator"`
+	Description string `json:"description"`
+}
+
+type statuspageComponent struct {
+	ID                 string    `json:"id"`
+	Name               string    `json:"name"`
+	Status             string    `json:"status"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+	Position           int       `json:"position"`
+	Description        *string   `json:"description"`
+	Showcase           bool      `json:"showcase"`
+	StartDate          *string   `json:"start_date"`
+	GroupID            *string   `json:"group_id"`
+	PageID             string    `json:"page_id"`
+	Group              bool      `json:"group"`
+	OnlyShowIfDegraded bool      `json:"only_show_if_degraded"`
+	Components         []string  `json:"components,omitempty"`
+}
+
+type statuspageSummary struct {
+	Page                  *statuspagePage        `json:"page"`
+	Components            []*statuspageComponent `json:"components"`
+	Incidents             []any                  `json:"incidents"`
+	ScheduledMaintenances []any                  `json:"scheduled_maintenances"`
+	Status                *statuspageStatus      `json:"status"`
+}
+
+// StatuspageStatus handles requests to retrieve the overall status of the endpoints in the format of the
+// /api/v2/status.json endpoint of Atlassian Statuspage
+func StatuspageStatus(cfg *config.Config) fiber.Handler {
+	return statuspageHandler(cfg, "status", func(summary *statuspageSummary) any {
+		return struct {
+			Page   *statuspagePage   `json:"page"`
+			Status *statuspageStatus `json:"status"`
+		}{Page: summary.Page, Status: summary.Status}
+	})
+}
+
+// StatuspageComponents handles requests to retrieve the status of each endpoint and group in the format of the
+// /api/v2/components.json endpoint of Atlassian Statuspage
+func StatuspageComponents(cfg *config.Config) fiber.Handler {
+	return statuspageHandler(cfg, "components", func(summary *statuspageSummary) any {
+		return struct {
+			Page       *statuspagePage        `json:"page"`
+			Components []*statuspageComponent `json:"components"`
+		}{Page: summary.Page, Components: summary.Components}
+	})
+}
+
+// StatuspageSummary handles requests to retrieve the overall status and the status of each endpoint and group in the
+// format of the /api/v2/summary.json endpoint of Atlassian Statuspage. Gatus has no incidents.
+func StatuspageSummary(cfg *config.Config) fiber.Handler {
+	return statuspageHandler(cfg, "summary", func(summary *statuspageSummary) any {
+		return summary
+	})
+}
+
+func statuspageHandler(cfg *config.Config, name string, view func(summary *statuspageSummary) any) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		hiddenGroups := getHiddenGroups(c, cfg.Security, cfg.Groups)
+		cacheKey := "statuspage-" + name + "-" + c.BaseURL() + hiddenGroupsCacheKey(hiddenGroups)
+		value, exists := cache.Get(cacheKey)
+		var data []byte
+		if !exists {
+			endpointStatuses, err := store.Get().GetAllEndpointStatuses(paging.NewEndpointStatusParams().WithResults(1, 1).WithEvents(1, common.MaximumNumberOfEvents))
+			if err != nil {
+				log.Printf("[api.Statuspage] Failed to retrieve endpoint statuses: %s", err.Error())
+				return c.Status(500).SendString(err.Error())
+			}
+			underMaintenance := cfg.Maintenance != nil && cfg.Maintenance.IsUnderMaintenance()
+			data, err = json.Marshal(view(newStatuspageSummary(cfg.UI, c.BaseURL(), withoutHiddenGroups(endpointStatuses, hiddenGroups), underMaintenance)))
+			if err != nil {
+				log.Printf("[api.Statuspage] Unable to marshal object to JSON: %s", err.Error())
+				return c.Status(500).SendString("unable to marshal object to JSON")
+			}
+			cache.SetWithTTL(cacheKey, data, cacheTTL)
+		} else {
+			data = value.([]byte)
+		}
+		c.Set("Content-Type", "application/json")
+		return c.Status(200).Send(data)
+	}
+}
+
+// newStatuspageSummary converts the endpoint statuses, each of which must include its most recent result and its
+// events, to the summary of a page of Atlassian Statuspage. Each group of endpoints is a component group.
+func newStatuspageSummary(uiConfig *ui.Config, url string, endpointStatuses []*endpoint.Status, underMaintenance bool) *statuspageSummary {
+	if uiConfig == nil {
+		uiConfig = ui.GetDefaultConfig()
+	}
+	summary := &statuspageSummary{
+		Page:                  &statuspagePage{ID: statuspagePageID, Name: uiConfig.Header, URL: url, TimeZone: "Etc/UTC"},
+		Components:            []*statuspageComponent{},
+		Incidents:             []any{},
+		ScheduledMaintenances: []any{},
+	}
+	groups := make(map[string]*statuspageComponent)
+	numberOfEndpointsDown, numberOfTopLevelComponents := 0, 0
+	for _, endpointStatus := range endpointStatuses {
+		component := &statuspageComponent{
+			ID:     endpointStatus.Key,
+			Name:   endpointStatus.Name,
+			Status: statuspageComponentStatusOperational,
+			PageID: statuspagePageID,
+		}
+		if len(endpointStatus.Events) > 0 {
+			component.CreatedAt = endpointStatus.Events[0].Timestamp
+			component.UpdatedAt = endpointStatus.Events[len(endpointStatus.Events)-1].Timestamp
+		}
+		if len(endpointStatus.Results) > 0 {
+			if lastResult := endpointStatus.Results[len(endpointStatus.Results)-1]; !lastResult.Success {
+				component.Status = statuspageComponentStatusMajorOutage
+				numberOfEndpointsDown++
+			}
+		}
+		if underMaintenance {
+			component.Status = statuspageComponentStatusUnderMaintenance
+		}
+		if component.UpdatedAt.After(summary.Page.UpdatedAt) {
+			summary.Page.UpdatedAt = component.UpdatedAt
+		}
+		if len(endpointStatus.Group) == 0 {
+			numberOfTopLevelComponents++
+			component.Position = numberOfTopLevelComponents
+			summary.Components = append(summary.Components, component)
+			continue
+		}
+		group, exists := groups[endpointStatus.Group]
+		if !exists {
+			numberOfTopLevelComponents++
+			group = &statuspageComponent{
+				ID:        endpoint.ConvertGroupAndEndpointNameToKey(endpointStatus.Group, ""),
+				Name:      endpointStatus.Group,
+				CreatedAt: component.CreatedAt,
+				Position:  numberOfTopLevelComponents,
+				PageID:    statuspagePageID,
+				Group:     true,
+			}
+			groups[endpointStatus.Group] = group
+			summary.Components = append(summary.Components, group)
+		}
+		component.GroupID = &group.ID
+		component.Position = len(group.Components) + 1
+		group.Components = append(group.Components, component.ID)
+		if component.CreatedAt.Before(group.CreatedAt) {
+			group.CreatedAt = component.CreatedAt
+		}
+		if component.UpdatedAt.After(group.UpdatedAt) {
+			group.UpdatedAt = component.UpdatedAt
+		}
+		// The components of a group must be listed after the group
+		summary.Components = append(summary.Components, component)
+	}
+	for _, component := range summary.Components {
+		if component.Group {
+			component.Status = getStatuspageGroupStatus(component, summary.Components, underMaintenance)
+		}
+	}
+	summary.Status = getStatuspageStatus(len(endpointStatuses), numberOfEndpointsDown, underMaintenance)
+	if summary.Page.UpdatedAt.IsZero() {
+		summary.Page.UpdatedAt = time.Now()
+	}
+	return summary
+}
+
+// getStatuspageGroupStatus returns the status of a component group based on the status of its components
+func getStatuspageGroupStatus(group *statuspageComponent, components []*statuspageComponent, underMaintenance bool) string {
+	if underMaintenance {
+		return statuspageComponentStatusUnderMaintenance
+	}
+	numberOfComponentsDown := 0
+	for _, component := range components {
+		if component.GroupID != nil && *component.GroupID == group.ID && component.Status == statuspageComponentStatusMajorOutage {
+			numberOfComponentsDown++
+		}
+	}
+	switch {
+	case numberOfComponentsDown == 0:
+		return statuspageComponentStatusOperational
+	case numberOfComponentsDown == len(group.Components):
+		return statuspageComponentStatusMajorOutage
+	default:
+		return statuspageComponentStatusPartialOutage
+	}
+}
+
+// getStatuspageStatus returns the overall status based on the proportion of endpoints whose most recent result failed
+func getStatuspageStatus(numberOfEndpoints, numberOfEndpointsDown int, underMaintenance bool) *statuspageStatus {
+	switch {
+	case underMaintenance:
+		return &statuspageStatus{Indicator: "maintenance", Description: "Service Under Maintenance"}
+	case numberOfEndpointsDown == 0:
+		return &statuspageStatus{Indicator: "none", Description: "All Systems Operational"}
+	case numberOfEndpointsDown == numberOfEndpoints:
+		return &statuspageStatus{Indicator: "critical", Description: "Major System Outage"}
+	case numberOfEndpointsDown*2 >= numberOfEndpoints:
+		return &statuspageStatus{Indicator: "major", Description: "Partial System Outage"}
+	default:
+		return &statuspageStatus{Indicator: "minor", Description: "Minor Service Outage"}
+	}
+}
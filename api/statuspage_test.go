@@ -0,0 +1,115 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/ui"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/watchdog"
+)
+
+func TestStatuspage(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	cfg := &config.Config{
+		UI: &ui.Config{Header: "Example Status"},
+		Endpoints: []*endpoint.Endpoint{
+			{Name: "frontend", Group: "core"},
+			{Name: "backend", Group: "core"},
+			{Name: "website"},
+		},
+	}
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: true, Timestamp: time.Now()})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[1], &endpoint.Result{Success: false, Timestamp: time.Now()})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[2], &endpoint.Result{Success: true, Timestamp: time.Now()})
+	router := New(cfg).Router()
+	get := func(path string, v any) {
+		response, err := router.Test(httptest.NewRequest("GET", path, http.NoBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer response.Body.Close()
+		if response.StatusCode != http.StatusOK {
+			t.Fatalf("GET %s should have returned %d, but returned %d instead", path, http.StatusOK, response.StatusCode)
+		}
+		body, _ := io.ReadAll(response.Body)
+		if err = json.Unmarshal(body, v); err != nil {
+			t.Fatalf("GET %s returned invalid JSON %s: %s", path, body, err.Error())
+		}
+	}
+	var status struct {
+		Page   statuspagePage   `json:"page"`
+		Status statuspageStatus `json:"status"`
+	}
+	get("/api/v2/status.json", &status)
+	if status.Page.Name != "Example Status" || status.Page.URL != "http://example.com" {
+		t.Errorf("expected page named Example Status at http://example.com, got %s at %s", status.Page.Name, status.Page.URL)
+	}
+	if status.Status.Indicator != "minor" || status.Status.Description != "Minor Service Outage" {
+		t.Errorf("expected minor indicator, got %s (%s)", status.Status.Indicator, status.Status.Description)
+	}
+	var components struct {
+		Components []statuspageComponent `json:"components"`
+	}
+	get("/api/v2/components.json", &components)
+	type expectedComponent struct {
+		id, status string
+		position   int
+		group      bool
+		groupID    string
+	}
+	expectedComponents := []expectedComponent{
+		{id: "_website", status: "operational", position: 1},
+		{id: "core_", status: "partial_outage", position: 2, group: true},
+		{id: "core_backend", status: "major_outage", position: 1, groupID: "core_"},
+		{id: "core_frontend", status: "operational", position: 2, groupID: "core_"},
+	}
+	if len(components.Components) != len(expectedComponents) {
+		t.Fatalf("expected %d components, got %d", len(expectedComponents), len(components.Components))
+	}
+	for i, expected := range expectedComponents {
+		component := components.Components[i]
+		var groupID string
+		if component.GroupID != nil {
+			groupID = *component.GroupID
+		}
+		if component.ID != expected.id || component.Status != expected.status || component.Position != expected.position || component.Group != expected.group || groupID != expected.groupID {
+			t.Errorf("expected component #%d to be %+v, got %+v", i, expected, component)
+		}
+	}
+	if len(components.Components[1].Components) != 2 {
+		t.Errorf("expected group to have 2 components, got %v", components.Components[1].Components)
+	}
+	var summary statuspageSummary
+	get("/api/v2/summary.json", &summary)
+	if len(summary.Components) != 4 || summary.Status == nil || summary.Incidents == nil || summary.ScheduledMaintenances == nil {
+		t.Errorf("expected summary to have the components, the status, and no incidents, got %+v", summary)
+	}
+}
+
+func TestGetStatuspageStatus(t *testing.T) {
+	scenarios := []struct {
+		numberOfEndpoints, numberOfEndpointsDown int
+		underMaintenance                         bool
+		expectedIndicator                        string
+	}{
+		{numberOfEndpoints: 0, expectedIndicator: "none"},
+		{numberOfEndpoints: 4, expectedIndicator: "none"},
+		{numberOfEndpoints: 4, numberOfEndpointsDown: 1, expectedIndicator: "minor"},
+		{numberOfEndpoints: 4, numberOfEndpointsDown: 2, expectedIndicator: "major"},
+		{numberOfEndpoints: 4, numberOfEndpointsDown: 4, expectedIndicator: "critical"},
+		{numberOfEndpoints: 4, numberOfEndpointsDown: 4, underMaintenance: true, expectedIndicator: "maintenance"},
+	}
+	for _, scenario := range scenarios {
+		if status := getStatuspageStatus(scenario.numberOfEndpoints, scenario.numberOfEndpointsDown, scenario.underMaintenance); status.Indicator != scenario.expectedIndicator {
+			t.Errorf("expected indicator %s with %d/%d endpoints down, got %s", scenario.expectedIndicator, scenario.numberOfEndpointsDown, scenario.numberOfEndpoints, status.Indicator)
+		}
+	}
+}
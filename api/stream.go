@@ -0,0 +1,94 @@
+package api
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/stream"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// streamHeartbeatInterval is the interval at which a comment is sent to keep the connection of the live status
+	// updates alive when no event is sent
+	streamHeartbeatInterval = 15 * time.Second
+
+	// streamWriteTimeout is the maximum duration for writing an event, which replaces the write timeout of the server
+	// because the response of the live status updates never ends
+	streamWriteTimeout = 15 * time.Second
+)
+
+// EndpointStatusesStream handles requests to receive the results of the endpoints, and the changes of their state, as
+// they happen, using Server-Sent Events
+//
+// If the keys query parameter is set to a comma-separated list of endpoint keys, only the events of said endpoints are
+// sent. The events of the endpoints that are part of a group the client isn't allowed to see are never sent.
+func EndpointStatusesStream(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var keys map[string]bool
+		if keysParameter := c.Query("keys"); len(keysParameter) > 0 {
+			keys = make(map[string]bool)
+			for _, key := range strings.Split(keysParameter, ",") {
+				keys[strings.TrimSpace(key)] = true
+			}
+		}
+		hiddenGroups := getHiddenGroups(c, cfg.Security, cfg.Groups)
+		events, unsubscribe := stream.Subscribe()
+		connection := c.Context().Conn()
+		c.Set("Content-Type", "text/event-stream")
+		c.Set("Cache-Control", "no-cache")
+		c.Set("Connection", "keep-alive")
+		c.Set("X-Accel-Buffering", "no") // Prevents reverse proxies like nginx from buffering the events
+		c.Status(200).Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			defer unsubscribe()
+			heartbeat := time.NewTicker(streamHeartbeatInterval)
+			defer heartbeat.Stop()
+			// Send a comment right away so that clients know that they're subscribed
+			if err := writeStreamMessage(connection, w, ": connected\n\n"); err != nil {
+				return
+			}
+			for {
+				select {
+				case event, ok := <-events:
+					if !ok {
+						return
+					}
+					if (keys != nil && !keys[event.Key]) || hiddenGroups[event.Group] {
+						continue
+					}
+					data, err := json.Marshal(event)
+					if err != nil {
+						log.Printf("[api.EndpointStatusesStream] Unable to marshal object to JSON: %s", err.Error())
+						continue
+					}
+					if err = writeStreamMessage(connection, w, fmt.Sprintf("event: %s\ndata: %s\n\n", event.Type, data)); err != nil {
+						return
+					}
+				case <-heartbeat.C:
+					if err := writeStreamMessage(connection, w, ": heartbeat\n\n"); err != nil {
+						return
+					}
+				}
+			}
+		})
+		return nil
+	}
+}
+
+// writeStreamMessage writes and flushes a message of the live status updates. It returns an error once the client is
+// gone.
+func writeStreamMessage(connection net.Conn, w *bufio.Writer, message string) error {
+	if connection != nil {
+		_ = connection.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+	}
+	if _, err := w.WriteString(message); err != nil {
+		return err
+	}
+	return w.Flush()
+}
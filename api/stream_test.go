@@ -0,0 +1,91 @@
+package api
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/stream"
+	"github.com/TwiN/gatus/v5/watchdog"
+)
+
+func TestEndpointStatusesStream(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	cfg := &config.Config{
+		Endpoints: []*endpoint.Endpoint{
+			{
+				Name:  "frontend",
+				Group: "core",
+			},
+			{
+				Name:  "backend",
+				Group: "core",
+			},
+		},
+	}
+	router := New(cfg).Router()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go router.Listener(listener)
+	defer router.Shutdown()
+	request, _ := http.NewRequest("GET", "http://"+listener.Addr().String()+"/api/v1/endpoints/statuses/stream?keys=core_frontend", http.NoBody)
+	request.Header.Set("Accept-Encoding", "gzip")
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected status code %d, got %d", http.StatusOK, response.StatusCode)
+	}
+	if contentType := response.Header.Get("Content-Type"); contentType != "text/event-stream" {
+		t.Errorf("expected Content-Type text/event-stream, got %s", contentType)
+	}
+	if contentEncoding := response.Header.Get("Content-Encoding"); len(contentEncoding) > 0 {
+		t.Errorf("expected events not to be compressed, got Content-Encoding %s", contentEncoding)
+	}
+	reader := bufio.NewReader(response.Body)
+	readMessage := func() string {
+		var message strings.Builder
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return message.String() + line
+			}
+			if line == "\n" {
+				return message.String()
+			}
+			message.WriteString(line)
+		}
+	}
+	if message := readMessage(); message != ": connected\n" {
+		t.Fatalf("expected connected comment, got %q", message)
+	}
+	timestamp := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: true, Duration: time.Millisecond, Timestamp: timestamp})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[1], &endpoint.Result{Success: true, Duration: time.Millisecond, Timestamp: timestamp})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: false, Duration: time.Millisecond, Timestamp: timestamp})
+	expectedMessages := []string{
+		"event: result\ndata: {\"type\":\"result\",\"key\":\"core_frontend\",\"name\":\"frontend\",\"group\":\"core\",\"result\":{\"duration\":1000000,\"success\":true,\"timestamp\":\"2024-01-01T00:00:00Z\"}}\n",
+		"event: state-change\ndata: {\"type\":\"state-change\",\"key\":\"core_frontend\",\"name\":\"frontend\",\"group\":\"core\",\"event\":{\"type\":\"UNHEALTHY\",\"timestamp\":\"2024-01-01T00:00:00Z\"}}\n",
+		"event: result\ndata: {\"type\":\"result\",\"key\":\"core_frontend\",\"name\":\"frontend\",\"group\":\"core\",\"result\":{\"duration\":1000000,\"success\":false,\"timestamp\":\"2024-01-01T00:00:00Z\"}}\n",
+	}
+	for _, expectedMessage := range expectedMessages {
+		if message := readMessage(); message != expectedMessage {
+			t.Errorf("expected:\n%s\ngot:\n%s", expectedMessage, message)
+		}
+	}
+	stream.UnsubscribeAll()
+	if message := readMessage(); len(message) > 0 {
+		t.Errorf("expected stream to have ended, got %q", message)
+	}
+}
@@ -0,0 +1,151 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/gatus/v5/subscription"
+	"github.com/gofiber/fiber/v2"
+)
+
+// subscriptionRequest is the body of the requests of the visitors who subscribe
+type subscriptionRequest struct {
+	// Type is the type of subscription
+	Type subscription.Type `json:"type"`
+
+	// Target is where the subscriber wants to be notified, i.e. an email address or the URL of a webhook
+	Target string `json:"target"`
+
+	// Groups are the groups the subscriber wants to be notified of. If empty, the subscriber is notified of every group
+	// whose visibility isn't restricted.
+	Groups []string `json:"groups,omitempty"`
+}
+
+// Subscribe handles requests of visitors to be notified of the changes of state of the endpoints of some or all
+// groups, as well as of the announcements. The subscription must then be confirmed through the link of the message
+// sent to the target of the subscription.
+func Subscribe(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if cfg.Subscriptions == nil {
+			return c.Status(404).SendString("subscriptions are not enabled")
+		}
+		subscriptionStore, ok := store.Get().(store.SubscriptionStore)
+		if !ok {
+			return c.Status(404).SendString("subscriptions are not supported by the configured storage type")
+		}
+		request := &subscriptionRequest{}
+		if err := json.Unmarshal(c.Body(), request); err != nil {
+			return c.Status(400).SendString("invalid subscription: " + err.Error())
+		}
+		if request.Type != subscription.TypeEmail && request.Type != subscription.TypeWebhook {
+			return c.Status(400).SendString(subscription.ErrInvalidType.Error())
+		}
+		if !cfg.Subscriptions.IsTypeEnabled(request.Type) {
+			return c.Status(400).SendString("subscribing with type " + string(request.Type) + " is not enabled")
+		}
+		target := strings.TrimSpace(request.Target)
+		if request.Type == subscription.TypeEmail {
+			address, err := mail.ParseAddress(target)
+			if err != nil {
+				return c.Status(400).SendString("invalid email address")
+			}
+			target = address.Address
+		} else if webhookURL, err := url.Parse(target); err != nil || (webhookURL.Scheme != "http" && webhookURL.Scheme != "https") || len(webhookURL.Host) == 0 {
+			return c.Status(400).SendString("webhook must be an absolute URL with the http or https scheme")
+		}
+		hiddenGroups := getHiddenGroups(c, cfg.Security, cfg.Groups)
+		for _, group := range request.Groups {
+			if hiddenGroups[group] || !hasEndpointsInGroup(cfg, group) {
+				return c.Status(400).SendString("group " + group + " has no endpoints")
+			}
+		}
+		sub, err := subscription.New(request.Type, target, request.Groups)
+		if err != nil {
+			log.Printf("[api.Subscribe] Failed to create subscription: %s", err.Error())
+			return c.Status(500).SendString(err.Error())
+		}
+		if err = subscriptionStore.InsertSubscription(sub); err != nil {
+			log.Printf("[api.Subscribe] Failed to insert subscription in storage: %s", err.Error())
+			return c.Status(500).SendString(err.Error())
+		}
+		subscribedTo := "every endpoint"
+		if len(sub.Groups) > 0 {
+			subscribedTo = "the endpoints of " + strings.Join(sub.Groups, ", ")
+		}
+		err = cfg.Subscriptions.Send(sub, subscription.Message{
+			Type:      subscription.MessageTypeConfirmation,
+			Title:     "Confirm your subscription",
+			Text:      "Someone, hopefully you, subscribed " + sub.Target + " to be notified of the changes of state of " + subscribedTo + " of " + cfg.Subscriptions.URL + ". If it wasn't you, ignore this message.",
+			Timestamp: sub.Timestamp,
+		})
+		if err != nil {
+			log.Printf("[api.Subscribe] Failed to send confirmation of subscription with id=%d: %s", sub.ID, err.Error())
+			return c.Status(502).SendString("failed to send the confirmation message")
+		}
+		return c.Status(202).SendString("")
+	}
+}
+
+// ConfirmSubscription handles the requests sent by following the link of the confirmation message of a subscription
+func ConfirmSubscription(c *fiber.Ctx) error {
+	subscriptionStore, ok := store.Get().(store.SubscriptionStore)
+	if !ok {
+		return c.Status(404).SendString("subscriptions are not supported by the configured storage type")
+	}
+	token := c.Query("token")
+	if len(token) == 0 {
+		return c.Status(400).SendString("missing token query parameter")
+	}
+	if err := subscriptionStore.ConfirmSubscription(token, time.Now()); err != nil {
+		if errors.Is(err, common.ErrSubscriptionNotFound) {
+			return c.Status(404).SendString("subscription not found, or it was not confirmed in time")
+		}
+		log.Printf("[api.ConfirmSubscription] Failed to confirm subscription: %s", err.Error())
+		return c.Status(500).SendString(err.Error())
+	}
+	return c.Status(200).SendString("Your subscription is confirmed.")
+}
+
+// Unsubscribe handles the requests sent by following the unsubscribe link of the messages sent to a subscriber
+func Unsubscribe(c *fiber.Ctx) error {
+	subscriptionStore, ok := store.Get().(store.SubscriptionStore)
+	if !ok {
+		return c.Status(404).SendString("subscriptions are not supported by the configured storage type")
+	}
+	token := c.Query("token")
+	if len(token) == 0 {
+		return c.Status(400).SendString("missing token query parameter")
+	}
+	if err := subscriptionStore.DeleteSubscription(token); err != nil {
+		if errors.Is(err, common.ErrSubscriptionNotFound) {
+			return c.Status(404).SendString(err.Error())
+		}
+		log.Printf("[api.Unsubscribe] Failed to delete subscription: %s", err.Error())
+		return c.Status(500).SendString(err.Error())
+	}
+	return c.Status(200).SendString("You are unsubscribed.")
+}
+
+// hasEndpointsInGroup returns whether at least one endpoint or external endpoint is part of the group passed as
+// parameter
+func hasEndpointsInGroup(cfg *config.Config, group string) bool {
+	for _, ep := range cfg.Endpoints {
+		if ep.Group == group {
+			return true
+		}
+	}
+	for _, ee := range cfg.ExternalEndpoints {
+		if ee.Group == group {
+			return true
+		}
+	}
+	return false
+}
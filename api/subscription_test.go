@@ -0,0 +1,155 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/subscription"
+)
+
+func TestSubscribe(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	var confirmationMessages []*subscription.Message
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/broken" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		message := &subscription.Message{}
+		_ = json.NewDecoder(r.Body).Decode(message)
+		confirmationMessages = append(confirmationMessages, message)
+	}))
+	defer webhook.Close()
+	cfg := &config.Config{
+		Endpoints: []*endpoint.Endpoint{
+			{Name: "frontend", Group: "core"},
+		},
+		Subscriptions: &subscription.Config{URL: "https://status.example.org", Webhooks: true},
+	}
+	if err := cfg.Subscriptions.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	router := New(cfg).Router()
+	scenarios := []struct {
+		Name         string
+		Body         string
+		ExpectedCode int
+	}{
+		{
+			Name:         "invalid-json",
+			Body:         "{",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "invalid-type",
+			Body:         `{"type":"sms","target":"+15555555555"}`,
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "type-not-enabled",
+			Body:         `{"type":"email","target":"john.doe@example.org"}`,
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "invalid-webhook",
+			Body:         `{"type":"webhook","target":"ftp://example.org"}`,
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "unknown-group",
+			Body:         `{"type":"webhook","target":"` + webhook.URL + `","groups":["nope"]}`,
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "broken-webhook",
+			Body:         `{"type":"webhook","target":"` + webhook.URL + `/broken"}`,
+			ExpectedCode: http.StatusBadGateway,
+		},
+		{
+			Name:         "webhook",
+			Body:         `{"type":"webhook","target":"` + webhook.URL + `","groups":["core"]}`,
+			ExpectedCode: http.StatusAccepted,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest("POST", "/api/v1/subscriptions", strings.NewReader(scenario.Body))
+			request.Header.Set("Content-Type", "application/json")
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+		})
+	}
+	if len(confirmationMessages) != 1 || confirmationMessages[0].Type != subscription.MessageTypeConfirmation {
+		t.Fatalf("expected a confirmation message to be sent to the webhook, got %d messages", len(confirmationMessages))
+	}
+	subscriptionStore := store.Get().(store.SubscriptionStore)
+	if subscriptions, _ := subscriptionStore.GetConfirmedSubscriptions(); len(subscriptions) != 0 {
+		t.Errorf("expected the subscription to have yet to be confirmed, got %d confirmed subscriptions", len(subscriptions))
+	}
+	confirmationURL, _ := url.Parse(confirmationMessages[0].URL)
+	for _, scenario := range []struct {
+		Name         string
+		Method       string
+		Path         string
+		ExpectedCode int
+	}{
+		{Name: "confirm-without-token", Method: "GET", Path: "/api/v1/subscriptions/confirm", ExpectedCode: http.StatusBadRequest},
+		{Name: "confirm-with-invalid-token", Method: "GET", Path: "/api/v1/subscriptions/confirm?token=nope", ExpectedCode: http.StatusNotFound},
+		{Name: "confirm", Method: "GET", Path: confirmationURL.RequestURI(), ExpectedCode: http.StatusOK},
+		{Name: "unsubscribe-with-invalid-token", Method: "POST", Path: "/api/v1/subscriptions/unsubscribe?token=nope", ExpectedCode: http.StatusNotFound},
+	} {
+		t.Run(scenario.Name, func(t *testing.T) {
+			response, err := router.Test(httptest.NewRequest(scenario.Method, scenario.Path, http.NoBody))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", scenario.Method, scenario.Path, scenario.ExpectedCode, response.StatusCode)
+			}
+		})
+	}
+	subscriptions, _ := subscriptionStore.GetConfirmedSubscriptions()
+	if len(subscriptions) != 1 || subscriptions[0].Target != webhook.URL || len(subscriptions[0].Groups) != 1 || subscriptions[0].Groups[0] != "core" {
+		t.Fatalf("expected the subscription to be confirmed, got %+v", subscriptions)
+	}
+	response, err := router.Test(httptest.NewRequest("GET", "/api/v1/subscriptions/unsubscribe?token="+subscriptions[0].UnsubscribeToken, http.NoBody))
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Errorf("expected unsubscribing to return %d, got %d", http.StatusOK, response.StatusCode)
+	}
+	if subscriptions, _ = subscriptionStore.GetConfirmedSubscriptions(); len(subscriptions) != 0 {
+		t.Errorf("expected the subscription to be deleted, got %d subscriptions", len(subscriptions))
+	}
+}
+
+func TestSubscribe_WithoutSubscriptionsConfig(t *testing.T) {
+	router := New(&config.Config{}).Router()
+	request := httptest.NewRequest("POST", "/api/v1/subscriptions", strings.NewReader(`{"type":"email","target":"john.doe@example.org"}`))
+	request.Header.Set("Content-Type", "application/json")
+	response, err := router.Test(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusNotFound {
+		t.Errorf("expected %d, got %d", http.StatusNotFound, response.StatusCode)
+	}
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/tenant"
+	"github.com/TwiN/gatus/v5/config/ui"
+	"github.com/gofiber/fiber/v2"
+)
+
+// TenantApplication handles requests to the dashboard of a tenant, which is the single page application rendered
+// with the title and the header of the tenant
+func TenantApplication(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		t := cfg.GetTenantByName(c.Params("tenant"))
+		if t == nil {
+			return c.Status(404).SendString("tenant " + c.Params("tenant") + " not found")
+		}
+		uiConfig := cfg.UI
+		if uiConfig == nil {
+			uiConfig = ui.GetDefaultConfig()
+		}
+		return SinglePageApplication(t.UI(uiConfig))(c)
+	}
+}
+
+// TenantAccess handles the authentication of the requests to the API of a tenant, which can be accessed by the users
+// authenticated through the security configuration of the tenant, as well as by those authenticated through the
+// security configuration of Gatus.
+//
+// This is needed because these routes are registered before the security middleware, so that the users of a tenant
+// don't need to be authenticated through the security configuration of Gatus.
+func TenantAccess(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		t := cfg.GetTenantByName(c.Params("tenant"))
+		if t == nil {
+			return c.Status(404).SendString("tenant " + c.Params("tenant") + " not found")
+		}
+		if !isAuthorizedForTenant(c, cfg, t) {
+			if t.Security != nil || (cfg.Security != nil && cfg.Security.UsesBasicAuthentication()) {
+				c.Set("WWW-Authenticate", "Basic")
+			}
+			return c.Status(401).SendString("Unauthorized")
+		}
+		return c.Next()
+	}
+}
+
+// TenantEndpointStatuses handles requests to retrieve the statuses of the endpoints assigned to a tenant, except for
+// those of the groups hidden from the client. Like EndpointStatuses, this function leverages a cache.
+func TenantEndpointStatuses(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		keys := getTenantEndpointKeys(cfg, c.Params("tenant"))
+		hiddenGroups := getHiddenGroups(c, cfg.Security, cfg.Groups)
+		return sendFilteredEndpointStatuses(c, "tenant-"+c.Params("tenant")+hiddenGroupsCacheKey(hiddenGroups), func(endpointStatus *endpoint.Status) bool {
+			return keys[endpointStatus.Key] && !hiddenGroups[endpointStatus.Group]
+		})
+	}
+}
+
+// TenantEndpointStatus handles requests to retrieve the status of an endpoint assigned to a tenant
+func TenantEndpointStatus(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !getTenantEndpointKeys(cfg, c.Params("tenant"))[c.Params("key")] {
+			return c.Status(404).SendString("endpoint with key=" + c.Params("key") + " not found")
+		}
+		return EndpointStatus(c)
+	}
+}
+
+// isAuthorizedForTenant returns whether the request is authenticated through the security configuration of the
+// tenant passed as parameter or through the one of Gatus. If neither is configured, every request is authorized.
+func isAuthorizedForTenant(c *fiber.Ctx, cfg *config.Config, t *tenant.Tenant) bool {
+	if t.Security == nil && cfg.Security == nil {
+		return true
+	}
+	return (t.Security != nil && t.Security.IsAuthenticated(c)) || (cfg.Security != nil && cfg.Security.IsAuthenticated(c))
+}
+
+// getTenantEndpointKeys returns the keys of the endpoints and of the external endpoints assigned to the tenant whose
+// name is passed as parameter
+func getTenantEndpointKeys(cfg *config.Config, name string) map[string]bool {
+	keys := make(map[string]bool)
+	for _, ep := range cfg.Endpoints {
+		if ep.Tenant == name {
+			keys[ep.Key()] = true
+		}
+	}
+	for _, ee := range cfg.ExternalEndpoints {
+		if ee.Tenant == name {
+			keys[ee.Key()] = true
+		}
+	}
+	return keys
+}
@@ -0,0 +1,168 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/tenant"
+	"github.com/TwiN/gatus/v5/config/ui"
+	"github.com/TwiN/gatus/v5/security"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/watchdog"
+)
+
+func TestTenants(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	basicConfig := &security.BasicConfig{
+		Username:                        "john.doe",
+		PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT",
+	}
+	cfg := &config.Config{
+		Endpoints: []*endpoint.Endpoint{
+			{Name: "website", Group: "acme", Tenant: "acme"},
+			{Name: "website", Group: "globex", Tenant: "globex"},
+			{Name: "internal"},
+		},
+		Tenants: []*tenant.Tenant{
+			{Name: "acme", Header: "Acme Status", Security: &security.Config{Basic: &security.BasicConfig{Username: "acme", PasswordBcryptHashBase64Encoded: basicConfig.PasswordBcryptHashBase64Encoded}}},
+			{Name: "globex"},
+		},
+		UI:       ui.GetDefaultConfig(),
+		Security: &security.Config{Basic: basicConfig},
+	}
+	for _, ep := range cfg.Endpoints {
+		watchdog.UpdateEndpointStatuses(ep, &endpoint.Result{Success: true, Duration: time.Millisecond, Timestamp: time.Now()})
+	}
+	router := New(cfg).Router()
+	scenarios := []struct {
+		Name                  string
+		Path                  string
+		Username              string
+		ExpectedCode          int
+		ExpectedKeys          []string
+		ExpectedBodyToContain string
+	}{
+		{
+			Name:         "statuses-without-authentication",
+			Path:         "/api/v1/tenants/acme/endpoints/statuses",
+			ExpectedCode: http.StatusUnauthorized,
+		},
+		{
+			Name:         "statuses-as-user-of-the-tenant",
+			Path:         "/api/v1/tenants/acme/endpoints/statuses",
+			Username:     "acme",
+			ExpectedCode: http.StatusOK,
+			ExpectedKeys: []string{"acme_website"},
+		},
+		{
+			Name:         "statuses-as-user-of-gatus",
+			Path:         "/api/v1/tenants/acme/endpoints/statuses",
+			Username:     "john.doe",
+			ExpectedCode: http.StatusOK,
+			ExpectedKeys: []string{"acme_website"},
+		},
+		{
+			Name:         "statuses-of-other-tenant-as-user-of-the-tenant",
+			Path:         "/api/v1/tenants/globex/endpoints/statuses",
+			Username:     "acme",
+			ExpectedCode: http.StatusUnauthorized,
+		},
+		{
+			Name:         "statuses-of-tenant-without-security-as-user-of-gatus",
+			Path:         "/api/v1/tenants/globex/endpoints/statuses",
+			Username:     "john.doe",
+			ExpectedCode: http.StatusOK,
+			ExpectedKeys: []string{"globex_website"},
+		},
+		{
+			Name:         "all-statuses-as-user-of-the-tenant",
+			Path:         "/api/v1/endpoints/statuses",
+			Username:     "acme",
+			ExpectedCode: http.StatusUnauthorized,
+		},
+		{
+			Name:         "unknown-tenant",
+			Path:         "/api/v1/tenants/nope/endpoints/statuses",
+			Username:     "john.doe",
+			ExpectedCode: http.StatusNotFound,
+		},
+		{
+			Name:                  "status-as-user-of-the-tenant",
+			Path:                  "/api/v1/tenants/acme/endpoints/acme_website/statuses",
+			Username:              "acme",
+			ExpectedCode:          http.StatusOK,
+			ExpectedBodyToContain: `"key":"acme_website"`,
+		},
+		{
+			Name:         "status-of-endpoint-of-other-tenant",
+			Path:         "/api/v1/tenants/acme/endpoints/globex_website/statuses",
+			Username:     "acme",
+			ExpectedCode: http.StatusNotFound,
+		},
+		{
+			Name:         "status-of-endpoint-without-tenant",
+			Path:         "/api/v1/tenants/acme/endpoints/_internal/statuses",
+			Username:     "john.doe",
+			ExpectedCode: http.StatusNotFound,
+		},
+		{
+			Name:                  "dashboard",
+			Path:                  "/tenants/acme",
+			ExpectedCode:          http.StatusOK,
+			ExpectedBodyToContain: "Acme Status",
+		},
+		{
+			Name:                  "dashboard-of-endpoint",
+			Path:                  "/tenants/acme/endpoints/acme_website",
+			ExpectedCode:          http.StatusOK,
+			ExpectedBodyToContain: "Acme Status",
+		},
+		{
+			Name:         "dashboard-of-unknown-tenant",
+			Path:         "/tenants/nope",
+			ExpectedCode: http.StatusNotFound,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", scenario.Path, http.NoBody)
+			if len(scenario.Username) > 0 {
+				request.SetBasicAuth(scenario.Username, "hunter2")
+			}
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+			body, _ := io.ReadAll(response.Body)
+			if len(scenario.ExpectedBodyToContain) > 0 && !strings.Contains(string(body), scenario.ExpectedBodyToContain) {
+				t.Errorf("expected body to contain %s, got %s", scenario.ExpectedBodyToContain, body)
+			}
+			if scenario.ExpectedKeys != nil {
+				var endpointStatuses []*endpoint.Status
+				if err := json.Unmarshal(body, &endpointStatuses); err != nil {
+					t.Fatal(err)
+				}
+				if len(endpointStatuses) != len(scenario.ExpectedKeys) {
+					t.Fatalf("expected %d endpoint statuses, got %d", len(scenario.ExpectedKeys), len(endpointStatuses))
+				}
+				for i, endpointStatus := range endpointStatuses {
+					if endpointStatus.Key != scenario.ExpectedKeys[i] {
+						t.Errorf("expected key %s, got %s", scenario.ExpectedKeys[i], endpointStatus.Key)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TwiN/gatus/v5/audit"
+	"github.com/TwiN/gatus/v5/security"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/gatus/v5/token"
+	"github.com/gofiber/fiber/v2"
+)
+
+// tokenLocalsKey is the key of the locals of the requests authenticated by an API token, whose value is the token
+const tokenLocalsKey = "token"
+
+var (
+	errTokensNotSupported = errors.New("tokens are not supported by the configured storage type")
+	errInvalidToken       = errors.New("invalid token")
+)
+
+// tokenRequest is the body of the requests to create an API token
+type tokenRequest struct {
+	Name      string        `json:"name"`
+	Scopes    []token.Scope `json:"scopes"`
+	ExpiresAt *time.Time    `json:"expiresAt,omitempty"`
+}
+
+// createdTokenResponse is the response to the creation of an API token, which is the only time its secret is returned
+type createdTokenResponse struct {
+	*token.Token
+	Secret string `json:"token"`
+}
+
+// CreateToken handles requests to create an API token
+func CreateToken(c *fiber.Ctx) error {
+	tokenStore, ok := store.Get().(store.TokenStore)
+	if !ok {
+		return c.Status(404).SendString(errTokensNotSupported.Error())
+	}
+	request := &tokenRequest{}
+	if err := json.Unmarshal(c.Body(), request); err != nil {
+		return c.Status(400).SendString("invalid token: " + err.Error())
+	}
+	t := &token.Token{Name: strings.TrimSpace(request.Name), Timestamp: time.Now(), ExpiresAt: request.ExpiresAt}
+	if len(t.Name) == 0 {
+		return c.Status(400).SendString("token name must not be empty")
+	}
+	if len(request.Scopes) == 0 {
+		return c.Status(400).SendString("token must have at least one scope")
+	}
+	for _, scope := range request.Scopes {
+		if !scope.IsValid() {
+			return c.Status(400).SendString("invalid token scope " + string(scope) + ", must be one of read-statuses, operate, push-external or admin")
+		}
+		if !slices.Contains(t.Scopes, scope) {
+			t.Scopes = append(t.Scopes, scope)
+		}
+	}
+	if t.ExpiresAt != nil && !t.ExpiresAt.After(t.Timestamp) {
+		return c.Status(400).SendString("token expiration must be in the future")
+	}
+	secret, hash, err := token.Generate()
+	if err != nil {
+		log.Printf("[api.CreateToken] Failed to generate token: %s", err.Error())
+		return c.Status(500).SendString("unable to generate token")
+	}
+	t.Hash = hash
+	if err = tokenStore.InsertToken(t); err != nil {
+		log.Printf("[api.CreateToken] Failed to insert token in storage: %s", err.Error())
+		return c.Status(500).SendString(err.Error())
+	}
+	store.Audit(audit.NewEntry(audit.ActionTokenCreation, getActor(c), strconv.FormatInt(t.ID, 10), true, t.Name))
+	output, err := json.Marshal(createdTokenResponse{Token: t, Secret: secret})
+	if err != nil {
+		log.Printf("[api.CreateToken] Unable to marshal object to JSON: %s", err.Error())
+		return c.Status(500).SendString("unable to marshal object to JSON")
+	}
+	c.Set("Content-Type", "application/json")
+	return c.Status(201).Send(output)
+}
+
+// Tokens handles requests to retrieve every API token, without their secret, from newest to oldest
+func Tokens(c *fiber.Ctx) error {
+	tokenStore, ok := store.Get().(store.TokenStore)
+	if !ok {
+		return c.Status(404).SendString(errTokensNotSupported.Error())
+	}
+	tokens, err := tokenStore.GetTokens()
+	if err != nil {
+		log.Printf("[api.Tokens] Failed to retrieve tokens: %s", err.Error())
+		return c.Status(500).SendString(err.Error())
+	}
+	output, err := json.Marshal(tokens)
+	if err != nil {
+		log.Printf("[api.Tokens] Unable to marshal object to JSON: %s", err.Error())
+		return c.Status(500).SendString("unable to marshal object to JSON")
+	}
+	c.Set("Content-Type", "application/json")
+	return c.Status(200).Send(output)
+}
+
+// RevokeToken handles requests to revoke an API token, which stops granting access right away
+func RevokeToken(c *fiber.Ctx) error {
+	tokenStore, ok := store.Get().(store.TokenStore)
+	if !ok {
+		return c.Status(404).SendString(errTokensNotSupported.Error())
+	}
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(400).SendString("invalid token id")
+	}
+	if err = tokenStore.RevokeToken(id, time.Now()); err != nil {
+		if errors.Is(err, common.ErrTokenNotFound) {
+			return c.Status(404).SendString(err.Error())
+		}
+		log.Printf("[api.RevokeToken] Failed to revoke token with id=%d: %s", id, err.Error())
+		return c.Status(500).SendString(err.Error())
+	}
+	store.Audit(audit.NewEntry(audit.ActionTokenRevocation, getActor(c), strconv.FormatInt(id, 10), true, ""))
+	return c.Status(200).SendString("")
+}
+
+// withTokens returns a handler authenticating the requests bearing an API token, which are then only authorized to
+// access the routes requiring a role granted by the scopes of the token (see requireRole). The other requests are
+// authenticated by the security middleware passed as parameter, if any. The uses of API tokens are recorded in the audit
+// of the security configuration passed as parameter, if any.
+func withTokens(securityConfig *security.Config, securityMiddleware fiber.Handler) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if secret, err := getBearerToken(c); err == nil && token.IsToken(secret) {
+			t, err := getActiveToken(secret)
+			if err != nil {
+				if errors.Is(err, errInvalidToken) {
+					securityConfig.RecordAuditEvent(audit.NewEntry(audit.ActionTokenUsage, strings.Clone(c.IP()), "", false, err.Error()))
+					return c.Status(401).SendString(err.Error())
+				}
+				log.Printf("[api.withTokens] Failed to retrieve token: %s", err.Error())
+				return c.Status(500).SendString(err.Error())
+			}
+			c.Locals(tokenLocalsKey, t)
+			securityConfig.RecordAuditEvent(audit.NewEntry(audit.ActionTokenUsage, strings.Clone(c.IP()), strconv.FormatInt(t.ID, 10), true, c.Method()+" "+c.Path()))
+			return c.Next()
+		}
+		if securityMiddleware == nil {
+			return c.Next()
+		}
+		return securityMiddleware(c)
+	}
+}
+
+// getActiveToken returns the API token whose secret is passed as parameter, or errInvalidToken if there's no such token
+// or if it no longer grants access
+func getActiveToken(secret string) (*token.Token, error) {
+	tokenStore, ok := store.Get().(store.TokenStore)
+	if !ok {
+		return nil, errInvalidToken
+	}
+	t, err := tokenStore.GetTokenByHash(token.Hash(secret))
+	if err != nil {
+		if errors.Is(err, common.ErrTokenNotFound) {
+			return nil, errInvalidToken
+		}
+		return nil, err
+	}
+	if !t.IsActive(time.Now()) {
+		return nil, errInvalidToken
+	}
+	return t, nil
+}
+
+// getActor returns who performed the action requested, for the audit log: the API token that authenticated the
+// request, if any, or the IP address of the client otherwise. The IP address is copied, since it may point to a buffer
+// of fasthttp that is reused once the request has been handled.
+func getActor(c *fiber.Ctx) string {
+	if t, ok := c.Locals(tokenLocalsKey).(*token.Token); ok {
+		return "token:" + strconv.FormatInt(t.ID, 10)
+	}
+	return strings.Clone(c.IP())
+}
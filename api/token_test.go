@@ -0,0 +1,305 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/maintenance"
+	"github.com/TwiN/gatus/v5/security"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/token"
+)
+
+func TestCreateToken(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	router := New(&config.Config{}).Router()
+	scenarios := []struct {
+		Name         string
+		Body         string
+		ExpectedCode int
+	}{
+		{
+			Name:         "invalid-json",
+			Body:         "{",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "without-name",
+			Body:         `{"name":" ","scopes":["admin"]}`,
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "without-scopes",
+			Body:         `{"name":"ci"}`,
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "with-invalid-scope",
+			Body:         `{"name":"ci","scopes":["write-everything"]}`,
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "already-expired",
+			Body:         `{"name":"ci","scopes":["admin"],"expiresAt":"` + time.Now().Add(-time.Hour).Format(time.RFC3339) + `"}`,
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "valid",
+			Body:         `{"name":"ci","scopes":["read-statuses","push-external","read-statuses"],"expiresAt":"` + time.Now().Add(time.Hour).Format(time.RFC3339) + `"}`,
+			ExpectedCode: http.StatusCreated,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest("POST", "/api/v1/tokens", strings.NewReader(scenario.Body))
+			request.Header.Set("Content-Type", "application/json")
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+		})
+	}
+	t.Run("verify-tokens", func(t *testing.T) {
+		response, err := router.Test(httptest.NewRequest("GET", "/api/v1/tokens", http.NoBody))
+		if err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+		defer response.Body.Close()
+		var tokens []map[string]any
+		if err = json.NewDecoder(response.Body).Decode(&tokens); err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+		if len(tokens) != 1 {
+			t.Fatalf("expected 1 token, got %d", len(tokens))
+		}
+		if tokens[0]["name"] != "ci" || len(tokens[0]["scopes"].([]any)) != 2 || tokens[0]["expiresAt"] == nil {
+			t.Errorf("expected the token to be named ci with its 2 distinct scopes and its expiration, got %+v", tokens[0])
+		}
+		if _, exists := tokens[0]["token"]; exists {
+			t.Error("expected the secret of the token not to be returned")
+		}
+	})
+}
+
+func TestTokenAuthentication(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	cfg := &config.Config{
+		Security: &security.Config{
+			Basic: &security.BasicConfig{
+				Username:                        "john.doe",
+				PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT",
+			},
+		},
+		ExternalEndpoints: []*endpoint.ExternalEndpoint{
+			{Name: "n", Group: "g", Token: "token"},
+		},
+		Maintenance: &maintenance.Config{},
+	}
+	router := New(cfg).Router()
+	tokenStore := store.Get().(store.TokenStore)
+	createToken := func(name string, expiresAt *time.Time, scopes ...token.Scope) (*token.Token, string) {
+		secret, hash, err := token.Generate()
+		if err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+		tk := &token.Token{Name: name, Scopes: scopes, Hash: hash, Timestamp: time.Now(), ExpiresAt: expiresAt}
+		if err = tokenStore.InsertToken(tk); err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+		return tk, secret
+	}
+	expiredAt := time.Now().Add(-time.Minute)
+	_, readSecret := createToken("dashboard", nil, token.ScopeReadStatuses)
+	_, pushSecret := createToken("agent", nil, token.ScopePushExternal)
+	_, adminSecret := createToken("ops", nil, token.ScopeAdmin)
+	_, expiredSecret := createToken("expired", &expiredAt, token.ScopeAdmin)
+	revokedToken, revokedSecret := createToken("revoked", nil, token.ScopeAdmin)
+	scenarios := []struct {
+		Name         string
+		Method       string
+		Path         string
+		Body         string
+		BearerToken  string
+		ExpectedCode int
+	}{
+		{
+			Name:         "without-authentication",
+			Method:       "GET",
+			Path:         "/api/v1/endpoints/statuses",
+			ExpectedCode: http.StatusUnauthorized,
+		},
+		{
+			Name:         "unknown-token",
+			Method:       "GET",
+			Path:         "/api/v1/endpoints/statuses",
+			BearerToken:  token.Prefix + "unknown",
+			ExpectedCode: http.StatusUnauthorized,
+		},
+		{
+			Name:         "expired-token",
+			Method:       "GET",
+			Path:         "/api/v1/endpoints/statuses",
+			BearerToken:  expiredSecret,
+			ExpectedCode: http.StatusUnauthorized,
+		},
+		{
+			Name:         "read-statuses-token-reading-statuses",
+			Method:       "GET",
+			Path:         "/api/v1/endpoints/statuses",
+			BearerToken:  readSecret,
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "push-external-token-reading-statuses",
+			Method:       "GET",
+			Path:         "/api/v1/endpoints/statuses",
+			BearerToken:  pushSecret,
+			ExpectedCode: http.StatusForbidden,
+		},
+		{
+			Name:         "read-statuses-token-reading-audit-log",
+			Method:       "GET",
+			Path:         "/api/v1/audit",
+			BearerToken:  readSecret,
+			ExpectedCode: http.StatusForbidden,
+		},
+		{
+			Name:         "admin-token-reading-statuses",
+			Method:       "GET",
+			Path:         "/api/v1/endpoints/statuses",
+			BearerToken:  adminSecret,
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "read-statuses-token-pushing-external-endpoint-result",
+			Method:       "POST",
+			Path:         "/api/v1/endpoints/g_n/external?success=true",
+			BearerToken:  readSecret,
+			ExpectedCode: http.StatusForbidden,
+		},
+		{
+			Name:         "push-external-token-pushing-external-endpoint-result",
+			Method:       "POST",
+			Path:         "/api/v1/endpoints/g_n/external?success=true",
+			BearerToken:  pushSecret,
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "push-external-token-pushing-external-endpoint-results",
+			Method:       "POST",
+			Path:         "/api/v1/endpoints/external",
+			Body:         `[{"key":"g_n","success":false}]`,
+			BearerToken:  pushSecret,
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "expired-token-pushing-external-endpoint-result",
+			Method:       "POST",
+			Path:         "/api/v1/endpoints/g_n/external?success=true",
+			BearerToken:  expiredSecret,
+			ExpectedCode: http.StatusUnauthorized,
+		},
+		{
+			Name:         "read-statuses-token-creating-token",
+			Method:       "POST",
+			Path:         "/api/v1/tokens",
+			Body:         `{"name":"escalation","scopes":["admin"]}`,
+			BearerToken:  readSecret,
+			ExpectedCode: http.StatusForbidden,
+		},
+		{
+			Name:         "admin-token-creating-token",
+			Method:       "POST",
+			Path:         "/api/v1/tokens",
+			Body:         `{"name":"pipeline","scopes":["push-external"]}`,
+			BearerToken:  adminSecret,
+			ExpectedCode: http.StatusCreated,
+		},
+		{
+			Name:         "revoked-token-before-revocation",
+			Method:       "GET",
+			Path:         "/api/v1/endpoints/statuses",
+			BearerToken:  revokedSecret,
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "admin-token-revoking-unknown-token",
+			Method:       "POST",
+			Path:         "/api/v1/tokens/42/revoke",
+			BearerToken:  adminSecret,
+			ExpectedCode: http.StatusNotFound,
+		},
+		{
+			Name:         "admin-token-revoking-token",
+			Method:       "POST",
+			Path:         "/api/v1/tokens/" + strconv.FormatInt(revokedToken.ID, 10) + "/revoke",
+			BearerToken:  adminSecret,
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "revoked-token-after-revocation",
+			Method:       "GET",
+			Path:         "/api/v1/endpoints/statuses",
+			BearerToken:  revokedSecret,
+			ExpectedCode: http.StatusUnauthorized,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest(scenario.Method, scenario.Path, strings.NewReader(scenario.Body))
+			request.Header.Set("Content-Type", "application/json")
+			if len(scenario.BearerToken) > 0 {
+				request.Header.Set("Authorization", "Bearer "+scenario.BearerToken)
+			}
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+		})
+	}
+	t.Run("verify-audit-log", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "/api/v1/audit", http.NoBody)
+		request.SetBasicAuth("john.doe", "hunter2")
+		response, err := router.Test(request)
+		if err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+		defer response.Body.Close()
+		var entries []map[string]any
+		if err = json.NewDecoder(response.Body).Decode(&entries); err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+		var numberOfRevocations int
+		for _, entry := range entries {
+			if entry["action"] == "TOKEN_REVOCATION" {
+				numberOfRevocations++
+			}
+			if entry["action"] == "TOKEN_REVOCATION" && entry["actor"] != "token:3" {
+				t.Errorf("expected the revocation to have been performed by the admin token, got %+v", entry)
+			}
+			if entry["action"] == "EXTERNAL_ENDPOINT_TOKEN_USAGE" && entry["success"] == true && entry["actor"] != "token:2" {
+				t.Errorf("expected the results to have been pushed by the push-external token, got %+v", entry)
+			}
+		}
+		if numberOfRevocations != 1 {
+			t.Errorf("expected 1 revocation in the audit log, got %d", numberOfRevocations)
+		}
+	})
+}
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+// MaximumUptimeTimeRange is how far back the uptime can be computed, which is how long the daily uptimes are kept
+const MaximumUptimeTimeRange = 365 * 24 * time.Hour
+
+var errInvalidUptimeDuration = errors.New("duration must be a number of hours, days or weeks, e.g. 12h, 14d or 2w, of at most 365d")
+
+// uptimeResponse is the uptime of an endpoint during a time range
+type uptimeResponse struct {
+	Key  string    `json:"key"`
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+
+	// Uptime is the ratio of successful executions during the time range, from 0 to 1
+	Uptime float64 `json:"uptime"`
+}
+
+// EndpointUptime handles requests to retrieve the uptime of an endpoint during the time range described by either the
+// duration query parameter, e.g. 14d, or the from and to query parameters, which are RFC3339 timestamps.
+// If neither is passed, the uptime of the last 24 hours is returned.
+func EndpointUptime(c *fiber.Ctx) error {
+	from, to, _, err := getUptimeTimeRange(c, time.Now())
+	if err != nil {
+		return c.Status(400).SendString(err.Error())
+	}
+	key := c.Params("key")
+	uptime, err := store.Get().GetUptimeByKey(key, from, to)
+	if err != nil {
+		if errors.Is(err, common.ErrEndpointNotFound) {
+			return c.Status(404).SendString(err.Error())
+		} else if errors.Is(err, common.ErrInvalidTimeRange) {
+			return c.Status(400).SendString(err.Error())
+		}
+		log.Printf("[api.EndpointUptime] Failed to retrieve uptime for endpoint with key=%s: %s", key, err.Error())
+		return c.Status(500).SendString(err.Error())
+	}
+	output, err := json.Marshal(&uptimeResponse{Key: key, From: from, To: to, Uptime: uptime})
+	if err != nil {
+		log.Printf("[api.EndpointUptime] Unable to marshal object to JSON: %s", err.Error())
+		return c.Status(500).SendString("unable to marshal object to JSON")
+	}
+	c.Set("Content-Type", "application/json")
+	return c.Status(200).Send(output)
+}
+
+// getUptimeTimeRange returns the time range described by the query parameters of the request, along with the duration
+// it is labeled with on badges, which is empty if the time range was passed through the from and to query parameters.
+//
+// If none of these query parameters are passed, the time range is the last 24 hours.
+func getUptimeTimeRange(c *fiber.Ctx, now time.Time) (from, to time.Time, duration string, err error) {
+	if len(c.Query("from")) == 0 && len(c.Query("to")) == 0 {
+		duration = c.Query("duration", "24h")
+		from, err = getUptimeStartTimeFromDuration(duration, now)
+		return from, now, duration, err
+	}
+	if len(c.Query("duration")) > 0 {
+		return from, to, "", errors.New("duration cannot be combined with from and to")
+	}
+	if from, err = time.Parse(time.RFC3339, c.Query("from")); err != nil {
+		return from, to, "", errors.New("from must be an RFC3339 timestamp, e.g. 2024-06-01T00:00:00Z")
+	}
+	to = now
+	if len(c.Query("to")) > 0 {
+		if to, err = time.Parse(time.RFC3339, c.Query("to")); err != nil {
+			return from, to, "", errors.New("to must be an RFC3339 timestamp, e.g. 2024-06-15T00:00:00Z")
+		}
+		if to.After(now) {
+			to = now
+		}
+	}
+	if !from.Before(to) {
+		return from, to, "", errors.New("from must be before to")
+	}
+	if now.Sub(from) > MaximumUptimeTimeRange {
+		return from, to, "", fmt.Errorf("from cannot be more than %s ago", "365d")
+	}
+	return from, to, "", nil
+}
+
+// getUptimeStartTimeFromDuration returns the start of the time range of the duration passed as parameter, which is a
+// number of hours, days or weeks, e.g. 12h, 14d or 2w
+func getUptimeStartTimeFromDuration(duration string, now time.Time) (time.Time, error) {
+	if len(duration) < 2 {
+		return time.Time{}, errInvalidUptimeDuration
+	}
+	number, err := strconv.Atoi(duration[:len(duration)-1])
+	if err != nil || number <= 0 {
+		return time.Time{}, errInvalidUptimeDuration
+	}
+	var unit time.Duration
+	switch duration[len(duration)-1] {
+	case 'h':
+		unit = time.Hour
+	case 'd':
+		unit = 24 * time.Hour
+	case 'w':
+		unit = 7 * 24 * time.Hour
+	default:
+		return time.Time{}, errInvalidUptimeDuration
+	}
+	if number > int(MaximumUptimeTimeRange/unit) {
+		return time.Time{}, errInvalidUptimeDuration
+	}
+	if d := time.Duration(number) * unit; d > time.Hour {
+		return now.Add(-d), nil
+	}
+	return now.Add(-2 * time.Hour), nil // Because uptime metrics are stored by hour, we have to cheat a little
+}
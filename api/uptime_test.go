@@ -0,0 +1,133 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/watchdog"
+)
+
+func TestEndpointUptime(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	cfg := &config.Config{
+		Endpoints: []*endpoint.Endpoint{{Name: "frontend", Group: "core"}},
+	}
+	now := time.Now()
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: true, Timestamp: now})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: false, Timestamp: now})
+	router := New(cfg).Router()
+	scenarios := []struct {
+		Name           string
+		Path           string
+		ExpectedCode   int
+		ExpectedUptime float64
+	}{
+		{
+			Name:           "default-duration",
+			Path:           "/api/v1/endpoints/core_frontend/uptimes",
+			ExpectedCode:   http.StatusOK,
+			ExpectedUptime: 0.5,
+		},
+		{
+			Name:           "duration",
+			Path:           "/api/v1/endpoints/core_frontend/uptimes?duration=14d",
+			ExpectedCode:   http.StatusOK,
+			ExpectedUptime: 0.5,
+		},
+		{
+			Name:           "from-and-to",
+			Path:           "/api/v1/endpoints/core_frontend/uptimes?from=" + now.Add(-3*time.Hour).UTC().Format(time.RFC3339) + "&to=" + now.Add(time.Hour).UTC().Format(time.RFC3339),
+			ExpectedCode:   http.StatusOK,
+			ExpectedUptime: 0.5,
+		},
+		{
+			Name:           "from-and-to-before-the-results",
+			Path:           "/api/v1/endpoints/core_frontend/uptimes?from=" + now.Add(-72*time.Hour).UTC().Format(time.RFC3339) + "&to=" + now.Add(-48*time.Hour).UTC().Format(time.RFC3339),
+			ExpectedCode:   http.StatusOK,
+			ExpectedUptime: 0,
+		},
+		{
+			Name:         "invalid-duration",
+			Path:         "/api/v1/endpoints/core_frontend/uptimes?duration=forever",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "duration-with-from",
+			Path:         "/api/v1/endpoints/core_frontend/uptimes?duration=7d&from=" + now.Add(-time.Hour).UTC().Format(time.RFC3339),
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "from-after-to",
+			Path:         "/api/v1/endpoints/core_frontend/uptimes?from=" + now.Add(-time.Hour).UTC().Format(time.RFC3339) + "&to=" + now.Add(-2*time.Hour).UTC().Format(time.RFC3339),
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "invalid-key",
+			Path:         "/api/v1/endpoints/invalid_key/uptimes?duration=7d",
+			ExpectedCode: http.StatusNotFound,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			response, err := router.Test(httptest.NewRequest("GET", scenario.Path, http.NoBody))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Fatalf("%s %s should have returned %d, but returned %d instead", "GET", scenario.Path, scenario.ExpectedCode, response.StatusCode)
+			}
+			if response.StatusCode != http.StatusOK {
+				return
+			}
+			var uptime uptimeResponse
+			if err := json.NewDecoder(response.Body).Decode(&uptime); err != nil {
+				t.Fatal(err)
+			}
+			if uptime.Key != "core_frontend" || uptime.Uptime != scenario.ExpectedUptime || !uptime.From.Before(uptime.To) {
+				t.Errorf("expected the uptime of core_frontend to be %f, got %+v", scenario.ExpectedUptime, uptime)
+			}
+		})
+	}
+}
+
+func TestGetUptimeStartTimeFromDuration(t *testing.T) {
+	now := time.Now()
+	scenarios := []struct {
+		duration      string
+		expectedFrom  time.Time
+		expectedError error
+	}{
+		{duration: "1h", expectedFrom: now.Add(-2 * time.Hour)},
+		{duration: "12h", expectedFrom: now.Add(-12 * time.Hour)},
+		{duration: "14d", expectedFrom: now.Add(-14 * 24 * time.Hour)},
+		{duration: "2w", expectedFrom: now.Add(-14 * 24 * time.Hour)},
+		{duration: "365d", expectedFrom: now.Add(-365 * 24 * time.Hour)},
+		{duration: "366d", expectedError: errInvalidUptimeDuration},
+		{duration: "53w", expectedError: errInvalidUptimeDuration},
+		{duration: "0d", expectedError: errInvalidUptimeDuration},
+		{duration: "-7d", expectedError: errInvalidUptimeDuration},
+		{duration: "7m", expectedError: errInvalidUptimeDuration},
+		{duration: "d", expectedError: errInvalidUptimeDuration},
+		{duration: "", expectedError: errInvalidUptimeDuration},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.duration, func(t *testing.T) {
+			from, err := getUptimeStartTimeFromDuration(scenario.duration, now)
+			if !errors.Is(err, scenario.expectedError) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedError, err)
+			}
+			if !from.Equal(scenario.expectedFrom) {
+				t.Errorf("expected %s, got %s", scenario.expectedFrom, from)
+			}
+		})
+	}
+}
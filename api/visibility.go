@@ -0,0 +1,100 @@
+package api
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/group"
+	"github.com/TwiN/gatus/v5/security"
+	"github.com/gofiber/fiber/v2"
+)
+
+// getHiddenGroups returns the groups whose visibility is restricted and that the client of the request isn't allowed
+// to see, or nil if there are none
+func getHiddenGroups(c *fiber.Ctx, securityConfig *security.Config, groups []*group.Group) map[string]bool {
+	var hiddenGroups map[string]bool
+	var user *security.User
+	var userRetrieved bool
+	for _, g := range groups {
+		if g.VisibleTo == nil {
+			continue
+		}
+		if !userRetrieved {
+			user, userRetrieved = getUser(c, securityConfig), true
+		}
+		if !g.VisibleTo.IsVisibleTo(user) {
+			if hiddenGroups == nil {
+				hiddenGroups = make(map[string]bool)
+			}
+			hiddenGroups[g.Name] = true
+		}
+	}
+	return hiddenGroups
+}
+
+// hiddenGroupsCacheKey returns the suffix of the cache key of the responses that depend on the groups hidden from the
+// client, so that the clients who can't see the same groups don't share the same cached responses
+func hiddenGroupsCacheKey(hiddenGroups map[string]bool) string {
+	if len(hiddenGroups) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(hiddenGroups))
+	for name := range hiddenGroups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return "-hidden-" + url.QueryEscape(strings.Join(names, ","))
+}
+
+// withoutHiddenGroups returns the endpoint statuses passed as parameter that aren't part of the hidden groups
+func withoutHiddenGroups(endpointStatuses []*endpoint.Status, hiddenGroups map[string]bool) []*endpoint.Status {
+	if len(hiddenGroups) == 0 {
+		return endpointStatuses
+	}
+	visibleEndpointStatuses := make([]*endpoint.Status, 0, len(endpointStatuses))
+	for _, endpointStatus := range endpointStatuses {
+		if !hiddenGroups[endpointStatus.Group] {
+			visibleEndpointStatuses = append(visibleEndpointStatuses, endpointStatus)
+		}
+	}
+	return visibleEndpointStatuses
+}
+
+// withGroupVisibility returns a handler responding to the requests about an endpoint, through the key parameter, or
+// about a group, through the group parameter, with a 404 if the client isn't allowed to see the group, as if it didn't
+// exist. Since the widgets accept the name of a group as key, a key that is the name of a hidden group is rejected too.
+func withGroupVisibility(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		hiddenGroups := getHiddenGroups(c, cfg.Security, cfg.Groups)
+		if len(hiddenGroups) == 0 {
+			return c.Next()
+		}
+		if groupName, err := url.PathUnescape(c.Params("group")); err == nil && hiddenGroups[groupName] {
+			return c.Status(404).SendString("not found")
+		}
+		if key := c.Params("key"); len(key) > 0 {
+			if name, err := url.PathUnescape(key); err == nil && hiddenGroups[name] {
+				return c.Status(404).SendString("not found")
+			}
+			if group, exists := getGroupOfEndpoint(cfg, key); exists && hiddenGroups[group] {
+				return c.Status(404).SendString("not found")
+			}
+		}
+		return c.Next()
+	}
+}
+
+// getGroupOfEndpoint returns the group of the endpoint or external endpoint with the key passed as parameter, and
+// whether there's such an endpoint
+func getGroupOfEndpoint(cfg *config.Config, key string) (string, bool) {
+	if ep := cfg.GetEndpointByKey(key); ep != nil {
+		return ep.Group, true
+	}
+	if ee := cfg.GetExternalEndpointByKey(key); ee != nil {
+		return ee.Group, true
+	}
+	return "", false
+}
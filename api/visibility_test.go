@@ -0,0 +1,206 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/group"
+	"github.com/TwiN/gatus/v5/config/tenant"
+	"github.com/TwiN/gatus/v5/security"
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+func TestGroupVisibility(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	basic := &security.BasicConfig{
+		Username:                        "john.doe",
+		PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT",
+	}
+	endpoints := []*endpoint.Endpoint{
+		{Name: "frontend", Group: "core", URL: "https://example.org", Tenant: "acme"},
+		{Name: "ledger", Group: "billing", URL: "https://example.org", Tenant: "acme"},
+	}
+	for _, ep := range endpoints {
+		if err := store.Get().Insert(ep, &endpoint.Result{Success: true, Timestamp: time.Now()}); err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+	}
+	groups := []*group.Group{
+		{Name: "core"},
+		{Name: "billing", VisibleTo: &group.Visibility{Roles: []security.Role{security.RoleAdmin}}},
+	}
+	viewerSecurity := &security.Config{Basic: basic, DefaultRole: security.RoleViewer}
+	adminSecurity := &security.Config{Basic: basic, DefaultRole: security.RoleViewer, Roles: map[security.Role][]string{security.RoleAdmin: {"john.doe"}}}
+	scenarios := []struct {
+		Name              string
+		Security          *security.Config
+		Method            string
+		Path              string
+		WithoutBasicAuth  bool
+		ExpectedCode      int
+		ExpectedInBody    string
+		ExpectedNotInBody string
+	}{
+		{
+			Name:              "viewer-reading-statuses",
+			Security:          viewerSecurity,
+			Method:            "GET",
+			Path:              "/api/v1/endpoints/statuses",
+			ExpectedCode:      http.StatusOK,
+			ExpectedInBody:    "core_frontend",
+			ExpectedNotInBody: "billing_ledger",
+		},
+		{
+			Name:           "admin-reading-statuses",
+			Security:       adminSecurity,
+			Method:         "GET",
+			Path:           "/api/v1/endpoints/statuses",
+			ExpectedCode:   http.StatusOK,
+			ExpectedInBody: "billing_ledger",
+		},
+		{
+			Name:         "viewer-reading-status-of-hidden-endpoint",
+			Security:     viewerSecurity,
+			Method:       "GET",
+			Path:         "/api/v1/endpoints/billing_ledger/statuses",
+			ExpectedCode: http.StatusNotFound,
+		},
+		{
+			Name:         "admin-reading-status-of-hidden-endpoint",
+			Security:     adminSecurity,
+			Method:       "GET",
+			Path:         "/api/v1/endpoints/billing_ledger/statuses",
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "viewer-reading-status-of-visible-endpoint",
+			Security:     viewerSecurity,
+			Method:       "GET",
+			Path:         "/api/v1/endpoints/core_frontend/statuses",
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:              "viewer-reading-statuses-of-tenant",
+			Security:          viewerSecurity,
+			Method:            "GET",
+			Path:              "/api/v1/tenants/acme/endpoints/statuses",
+			ExpectedCode:      http.StatusOK,
+			ExpectedInBody:    "core_frontend",
+			ExpectedNotInBody: "billing_ledger",
+		},
+		{
+			Name:           "admin-reading-statuses-of-tenant",
+			Security:       adminSecurity,
+			Method:         "GET",
+			Path:           "/api/v1/tenants/acme/endpoints/statuses",
+			ExpectedCode:   http.StatusOK,
+			ExpectedInBody: "billing_ledger",
+		},
+		{
+			Name:         "viewer-reading-status-of-hidden-endpoint-of-tenant",
+			Security:     viewerSecurity,
+			Method:       "GET",
+			Path:         "/api/v1/tenants/acme/endpoints/billing_ledger/statuses",
+			ExpectedCode: http.StatusNotFound,
+		},
+		{
+			Name:             "anonymous-reading-badge-of-hidden-endpoint",
+			Security:         adminSecurity,
+			Method:           "GET",
+			Path:             "/api/v1/endpoints/billing_ledger/health/badge.svg",
+			WithoutBasicAuth: true,
+			ExpectedCode:     http.StatusNotFound,
+		},
+		{
+			Name:             "anonymous-reading-widget-of-hidden-group",
+			Security:         adminSecurity,
+			Method:           "GET",
+			Path:             "/widget/billing",
+			WithoutBasicAuth: true,
+			ExpectedCode:     http.StatusNotFound,
+		},
+		{
+			Name:             "anonymous-reading-widget-of-hidden-group-with-different-case",
+			Security:         adminSecurity,
+			Method:           "GET",
+			Path:             "/widget/BILLING",
+			WithoutBasicAuth: true,
+			ExpectedCode:     http.StatusNotFound,
+		},
+		{
+			Name:             "anonymous-reading-widget-of-visible-group-with-different-case",
+			Security:         adminSecurity,
+			Method:           "GET",
+			Path:             "/widget/CORE",
+			WithoutBasicAuth: true,
+			ExpectedCode:     http.StatusOK,
+		},
+		{
+			Name:           "admin-reading-widget-of-hidden-group-with-different-case",
+			Security:       adminSecurity,
+			Method:         "GET",
+			Path:           "/widget/BILLING",
+			ExpectedCode:   http.StatusOK,
+			ExpectedInBody: "ledger",
+		},
+		{
+			Name:              "viewer-reading-sla-report",
+			Security:          viewerSecurity,
+			Method:            "GET",
+			Path:              "/api/v1/reports/sla?period=" + time.Now().Format("2006-01"),
+			ExpectedCode:      http.StatusOK,
+			ExpectedNotInBody: "billing_ledger",
+		},
+		{
+			Name:              "viewer-reading-config",
+			Security:          viewerSecurity,
+			Method:            "GET",
+			Path:              "/api/v1/config",
+			ExpectedCode:      http.StatusOK,
+			ExpectedInBody:    `"name":"core"`,
+			ExpectedNotInBody: `"name":"billing"`,
+		},
+		{
+			Name:              "viewer-querying-graphql",
+			Security:          viewerSecurity,
+			Method:            "GET",
+			Path:              "/api/graphql?query=%7Bendpoints%7Bkey%7D%20groups%7Bname%7D%7D",
+			ExpectedCode:      http.StatusOK,
+			ExpectedInBody:    "core_frontend",
+			ExpectedNotInBody: "billing",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			cache.Clear()
+			cfg := &config.Config{Security: scenario.Security, Endpoints: endpoints, Groups: groups, Tenants: []*tenant.Tenant{{Name: "acme"}}}
+			router := New(cfg).Router()
+			request := httptest.NewRequest(scenario.Method, scenario.Path, http.NoBody)
+			if !scenario.WithoutBasicAuth {
+				request.SetBasicAuth("john.doe", "hunter2")
+			}
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+			body, _ := io.ReadAll(response.Body)
+			if len(scenario.ExpectedInBody) > 0 && !strings.Contains(string(body), scenario.ExpectedInBody) {
+				t.Errorf("expected body to contain %s, got %s", scenario.ExpectedInBody, body)
+			}
+			if len(scenario.ExpectedNotInBody) > 0 && strings.Contains(string(body), scenario.ExpectedNotInBody) {
+				t.Errorf("expected body not to contain %s, got %s", scenario.ExpectedNotInBody, body)
+			}
+		})
+	}
+}
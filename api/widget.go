@@ -0,0 +1,280 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/web"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/cors"
+)
+
+// widgetSparklineSize is the number of most recent results of each endpoint included in a widget
+const widgetSparklineSize = 20
+
+// States of the endpoints and of the groups in the widgets
+const (
+	widgetStateUp       = "up"
+	widgetStateDegraded = "degraded"
+	widgetStateDown     = "down"
+	widgetStateUnknown  = "unknown"
+)
+
+var errWidgetNotFound = errors.New("no endpoint or group found")
+
+// widget is the state of an endpoint, or of the endpoints of a group, in a form small enough to be embedded in
+// other sites
+type widget struct {
+	Name     string `json:"name"`
+	State    string `json:"state"`
+	Duration string `json:"duration"`
+
+	// Uptime is the ratio of successful results during the duration, from 0 to 1. For a group, it's the average of the
+	// uptime of its endpoints.
+	Uptime float64 `json:"uptime"`
+
+	Endpoints []*widgetEndpoint `json:"endpoints"`
+}
+
+type widgetEndpoint struct {
+	Key    string  `json:"key"`
+	Name   string  `json:"name"`
+	Group  string  `json:"group,omitempty"`
+	State  string  `json:"state"`
+	Uptime float64 `json:"uptime"`
+
+	// Sparkline is the most recent results, from oldest to newest
+	Sparkline []*widgetPoint `json:"sparkline"`
+}
+
+type widgetPoint struct {
+	Timestamp    time.Time `json:"timestamp"`
+	Success      bool      `json:"success"`
+	ResponseTime int64     `json:"responseTime"`
+}
+
+// Widget handles requests to the widget of the endpoint whose key is passed, or of the group whose name is passed if
+// no endpoint has that key. The widget is a self-contained HTML document meant to be embedded through an iframe, or
+// JSON if the format query parameter is json.
+//
+// The uptime covers the duration query parameter, which defaults to 24h.
+func Widget(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		format := c.Query("format", "html")
+		if format != "html" && format != "json" {
+			return c.Status(400).SendString("format must be either html or json")
+		}
+		duration := c.Query("duration", "24h")
+		from, err := getUptimeStartTimeFromDuration(duration, time.Now())
+		if err != nil {
+			return c.Status(400).SendString(err.Error())
+		}
+		name, err := url.PathUnescape(c.Params("key"))
+		if err != nil {
+			return c.Status(400).SendString("invalid key")
+		}
+		hiddenGroups := getHiddenGroups(c, cfg.Security, cfg.Groups)
+		cacheKey := fmt.Sprintf("widget-%s-%s-%s%s", name, duration, format, hiddenGroupsCacheKey(hiddenGroups))
+		if value, exists := cache.Get(cacheKey); exists {
+			return sendWidget(c, format, value.([]byte))
+		}
+		w, err := newWidget(cfg, name, duration, from, hiddenGroups)
+		if err != nil {
+			if errors.Is(err, errWidgetNotFound) {
+				return c.Status(404).SendString(err.Error())
+			}
+			log.Printf("[api.Widget] Failed to retrieve the widget of %s: %s", name, err.Error())
+			return c.Status(500).SendString(err.Error())
+		}
+		var data []byte
+		if format == "json" {
+			data, err = json.Marshal(w)
+		} else {
+			buffer := &bytes.Buffer{}
+			err = widgetTemplate.Execute(buffer, w)
+			data = buffer.Bytes()
+		}
+		if err != nil {
+			log.Printf("[api.Widget] Unable to render the widget of %s: %s", name, err.Error())
+			return c.Status(500).SendString("unable to render widget")
+		}
+		cache.SetWithTTL(cacheKey, data, cacheTTL)
+		return sendWidget(c, format, data)
+	}
+}
+
+func sendWidget(c *fiber.Ctx, format string, data []byte) error {
+	if format == "json" {
+		c.Set("Content-Type", "application/json")
+	} else {
+		c.Set("Content-Type", "text/html; charset=utf-8")
+	}
+	return c.Status(200).Send(data)
+}
+
+// withWidgetRules returns a handler replacing the CORS policy of the API by the one of the widgets, and only allowing
+// the widgets to be framed by the origins allowed to embed them. If the configuration is nil, every origin is allowed.
+func withWidgetRules(widgetsConfig *web.WidgetsConfig) fiber.Handler {
+	allowedOrigins := []string{"*"}
+	if widgetsConfig != nil {
+		allowedOrigins = widgetsConfig.AllowedOrigins
+	}
+	frameAncestors := make([]string, 0, len(allowedOrigins))
+	for _, origin := range allowedOrigins {
+		frameAncestors = append(frameAncestors, strings.TrimSuffix(origin, "/"))
+	}
+	contentSecurityPolicy := "frame-ancestors " + strings.Join(frameAncestors, " ")
+	widgetCORS := cors.New(cors.Config{
+		AllowOrigins: strings.Join(allowedOrigins, ","),
+		AllowMethods: "GET,HEAD",
+	})
+	return func(c *fiber.Ctx) error {
+		// The CORS policy of the API, if any, has already been applied by the time the widget is requested
+		c.Response().Header.Del(fiber.HeaderAccessControlAllowOrigin)
+		c.Response().Header.Del(fiber.HeaderAccessControlAllowCredentials)
+		c.Response().Header.Del(fiber.HeaderAccessControlExposeHeaders)
+		c.Set(fiber.HeaderContentSecurityPolicy, contentSecurityPolicy)
+		return widgetCORS(c)
+	}
+}
+
+// newWidget returns the widget of the endpoint whose key is passed, or of the group whose name is passed if no
+// endpoint has that key. The endpoints of the hidden groups passed are left out, so that the widget of an endpoint or
+// of a group the client isn't allowed to see isn't found, however the name of the group is written.
+func newWidget(cfg *config.Config, name, duration string, from time.Time, hiddenGroups map[string]bool) (*widget, error) {
+	w := &widget{Name: name, Duration: duration, Endpoints: []*widgetEndpoint{}}
+	var keys []reportEndpointKey
+	if ep := cfg.GetEndpointByKey(name); ep != nil {
+		w.Name, keys = ep.DisplayName(), []reportEndpointKey{{key: ep.Key(), name: ep.Name, group: ep.Group}}
+	} else if ee := cfg.GetExternalEndpointByKey(name); ee != nil {
+		w.Name, keys = ee.DisplayName(), []reportEndpointKey{{key: ee.Key(), name: ee.Name, group: ee.Group}}
+	} else {
+		for _, key := range getReportEndpointKeys(cfg, "") {
+			if len(key.group) > 0 && endpoint.ConvertGroupAndEndpointNameToKey(key.group, "") == endpoint.ConvertGroupAndEndpointNameToKey(name, "") {
+				w.Name, keys = key.group, append(keys, key)
+			}
+		}
+	}
+	keys = slices.DeleteFunc(keys, func(key reportEndpointKey) bool {
+		return hiddenGroups[key.group]
+	})
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w: %s", errWidgetNotFound, name)
+	}
+	numberOfEndpointsByState := make(map[string]int)
+	for _, key := range keys {
+		ep, err := newWidgetEndpoint(key, from)
+		if err != nil {
+			return nil, err
+		}
+		numberOfEndpointsByState[ep.State]++
+		w.Uptime += ep.Uptime / float64(len(keys))
+		w.Endpoints = append(w.Endpoints, ep)
+	}
+	switch {
+	case numberOfEndpointsByState[widgetStateUnknown] == len(keys):
+		w.State = widgetStateUnknown
+	case numberOfEndpointsByState[widgetStateDown] == 0:
+		w.State = widgetStateUp
+	case numberOfEndpointsByState[widgetStateDown] == len(keys):
+		w.State = widgetStateDown
+	default:
+		w.State = widgetStateDegraded
+	}
+	return w, nil
+}
+
+func newWidgetEndpoint(key reportEndpointKey, from time.Time) (*widgetEndpoint, error) {
+	ep := &widgetEndpoint{Key: key.key, Name: key.name, Group: key.group, State: widgetStateUnknown, Sparkline: []*widgetPoint{}}
+	status, err := store.Get().GetEndpointStatusByKey(key.key, paging.NewEndpointStatusParams().WithResults(1, widgetSparklineSize))
+	if err != nil {
+		if errors.Is(err, common.ErrEndpointNotFound) {
+			// The endpoint hasn't been evaluated yet
+			return ep, nil
+		}
+		return nil, err
+	}
+	if ep.Uptime, err = store.Get().GetUptimeByKey(key.key, from, time.Now()); err != nil && !errors.Is(err, common.ErrEndpointNotFound) {
+		return nil, err
+	}
+	for _, result := range status.Results {
+		ep.Sparkline = append(ep.Sparkline, &widgetPoint{Timestamp: result.Timestamp, Success: result.Success, ResponseTime: result.Duration.Milliseconds()})
+	}
+	if len(status.Results) > 0 {
+		if status.Results[len(status.Results)-1].Success {
+			ep.State = widgetStateUp
+		} else {
+			ep.State = widgetStateDown
+		}
+	}
+	return ep, nil
+}
+
+// getWidgetSparklinePoints returns the points of the polyline of the response times of a sparkline, scaled to a
+// viewBox of 100x20 where the slowest response time reaches the top
+func getWidgetSparklinePoints(sparkline []*widgetPoint) string {
+	var maximumResponseTime int64 = 1
+	for _, point := range sparkline {
+		if point.ResponseTime > maximumResponseTime {
+			maximumResponseTime = point.ResponseTime
+		}
+	}
+	points := make([]string, 0, len(sparkline))
+	for i, point := range sparkline {
+		x := 100.0
+		if len(sparkline) > 1 {
+			x = float64(i) * 100 / float64(len(sparkline)-1)
+		}
+		y := 19 - float64(point.ResponseTime)*18/float64(maximumResponseTime)
+		points = append(points, fmt.Sprintf("%.1f,%.1f", x, y))
+	}
+	return strings.Join(points, " ")
+}
+
+var widgetTemplate = template.Must(template.New("widget").Funcs(template.FuncMap{
+	"percentage": func(uptime float64) string {
+		return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.2f", uptime*100), "0"), ".") + "%"
+	},
+	"sparkline": getWidgetSparklinePoints,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{ .Name }}</title>
+<style>
+body{margin:0;padding:8px;font:13px/1.4 -apple-system,BlinkMacSystemFont,"Segoe UI",Roboto,sans-serif;color:#1f2937;background:transparent}
+header,li{display:flex;align-items:center;gap:8px}
+header{font-weight:600;margin-bottom:4px}
+ul{list-style:none;margin:0;padding:0}
+li{padding:2px 0}
+.name{flex:1;overflow:hidden;text-overflow:ellipsis;white-space:nowrap}
+.dot{width:10px;height:10px;border-radius:50%;flex-shrink:0}
+.up{background:#40cc11}.degraded{background:#ccb311}.down{background:#c7130a}.unknown{background:#9ca3af}
+.uptime{color:#6b7280;font-variant-numeric:tabular-nums}
+svg{width:100px;height:20px;flex-shrink:0}
+</style>
+</head>
+<body>
+<header><span class="dot {{ .State }}" title="{{ .State }}"></span><span class="name">{{ .Name }}</span><span class="uptime" title="uptime {{ .Duration }}">{{ percentage .Uptime }}</span></header>
+<ul>
+{{- range .Endpoints }}
+<li><span class="dot {{ .State }}" title="{{ .State }}"></span><span class="name">{{ .Name }}</span><svg viewBox="0 0 100 20" preserveAspectRatio="none" aria-hidden="true"><polyline fill="none" stroke="#6b7280" stroke-width="1.5" points="{{ sparkline .Sparkline }}"/></svg><span class="uptime">{{ percentage .Uptime }}</span></li>
+{{- end }}
+</ul>
+</body>
+</html>
+`))
@@ -0,0 +1,174 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/web"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/watchdog"
+)
+
+func TestWidget(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	cfg := &config.Config{
+		Endpoints: []*endpoint.Endpoint{
+			{Name: "frontend", Group: "core"},
+			{Name: "backend", Group: "core"},
+			{Name: "website"},
+		},
+	}
+	now := time.Now()
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: true, Duration: 100 * time.Millisecond, Timestamp: now.Add(-time.Minute)})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: true, Duration: 200 * time.Millisecond, Timestamp: now})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[1], &endpoint.Result{Success: false, Duration: time.Second, Timestamp: now})
+	router := New(cfg).Router()
+	scenarios := []struct {
+		Name              string
+		Path              string
+		ExpectedCode      int
+		ExpectedName      string
+		ExpectedState     string
+		ExpectedUptime    float64
+		ExpectedEndpoints int
+	}{
+		{
+			Name:              "endpoint",
+			Path:              "/widget/core_frontend?format=json",
+			ExpectedCode:      http.StatusOK,
+			ExpectedName:      "core/frontend",
+			ExpectedState:     widgetStateUp,
+			ExpectedUptime:    1,
+			ExpectedEndpoints: 1,
+		},
+		{
+			Name:              "group",
+			Path:              "/widget/core?format=json&duration=7d",
+			ExpectedCode:      http.StatusOK,
+			ExpectedName:      "core",
+			ExpectedState:     widgetStateDegraded,
+			ExpectedUptime:    0.5,
+			ExpectedEndpoints: 2,
+		},
+		{
+			Name:              "endpoint-not-evaluated-yet",
+			Path:              "/widget/_website?format=json",
+			ExpectedCode:      http.StatusOK,
+			ExpectedName:      "website",
+			ExpectedState:     widgetStateUnknown,
+			ExpectedEndpoints: 1,
+		},
+		{
+			Name:         "not-found",
+			Path:         "/widget/nope?format=json",
+			ExpectedCode: http.StatusNotFound,
+		},
+		{
+			Name:         "invalid-format",
+			Path:         "/widget/core_frontend?format=xml",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "invalid-duration",
+			Path:         "/widget/core_frontend?duration=forever",
+			ExpectedCode: http.StatusBadRequest,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			response, err := router.Test(httptest.NewRequest("GET", scenario.Path, http.NoBody))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Fatalf("%s %s should have returned %d, but returned %d instead", "GET", scenario.Path, scenario.ExpectedCode, response.StatusCode)
+			}
+			if response.StatusCode != http.StatusOK {
+				return
+			}
+			var w widget
+			if err := json.NewDecoder(response.Body).Decode(&w); err != nil {
+				t.Fatal(err)
+			}
+			if w.Name != scenario.ExpectedName || w.State != scenario.ExpectedState || w.Uptime != scenario.ExpectedUptime || len(w.Endpoints) != scenario.ExpectedEndpoints {
+				t.Errorf("expected widget %s to be %s with an uptime of %f and %d endpoints, got %+v", scenario.ExpectedName, scenario.ExpectedState, scenario.ExpectedUptime, scenario.ExpectedEndpoints, w)
+			}
+		})
+	}
+	t.Run("html", func(t *testing.T) {
+		response, err := router.Test(httptest.NewRequest("GET", "/widget/core", http.NoBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer response.Body.Close()
+		body, _ := io.ReadAll(response.Body)
+		if response.StatusCode != http.StatusOK || response.Header.Get("Content-Type") != "text/html; charset=utf-8" {
+			t.Fatalf("expected 200 with HTML, got %d with %s", response.StatusCode, response.Header.Get("Content-Type"))
+		}
+		if !strings.Contains(string(body), "<title>core</title>") || !strings.Contains(string(body), `class="dot degraded"`) || !strings.Contains(string(body), "<polyline") {
+			t.Errorf("expected the widget of the group core, got %s", body)
+		}
+	})
+}
+
+func TestWidget_rules(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	cfg := &config.Config{
+		Endpoints: []*endpoint.Endpoint{{Name: "frontend", Group: "core"}},
+		Web: &web.Config{
+			CORS:         &web.CORSConfig{AllowedOrigins: []string{"https://admin.example.org"}, AllowCredentials: true},
+			CacheControl: &web.CacheControlConfig{Widgets: "public, max-age=60"},
+			Widgets:      &web.WidgetsConfig{AllowedOrigins: []string{"https://*.example.com", "https://example.org/"}},
+		},
+	}
+	if err := cfg.Web.ValidateAndSetDefaults(); err != nil {
+		t.Fatal(err)
+	}
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: true, Timestamp: time.Now()})
+	router := New(cfg).Router()
+	scenarios := []struct {
+		Name                string
+		Origin              string
+		ExpectedAllowOrigin string
+	}{
+		{Name: "allowed-origin", Origin: "https://status.example.com", ExpectedAllowOrigin: "https://status.example.com"},
+		{Name: "origin-only-allowed-by-the-api", Origin: "https://admin.example.org"},
+		{Name: "disallowed-origin", Origin: "https://example.net"},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "/widget/core_frontend", http.NoBody)
+			request.Header.Set("Origin", scenario.Origin)
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != http.StatusOK {
+				t.Fatalf("expected 200, got %d", response.StatusCode)
+			}
+			if allowOrigin := response.Header.Get("Access-Control-Allow-Origin"); allowOrigin != scenario.ExpectedAllowOrigin {
+				t.Errorf("expected Access-Control-Allow-Origin to be %q, got %q", scenario.ExpectedAllowOrigin, allowOrigin)
+			}
+			if allowCredentials := response.Header.Get("Access-Control-Allow-Credentials"); len(allowCredentials) > 0 {
+				t.Errorf("expected credentials not to be allowed, got %q", allowCredentials)
+			}
+			if csp := response.Header.Get("Content-Security-Policy"); csp != "frame-ancestors https://*.example.com https://example.org" {
+				t.Errorf("expected the widget to only be framed by the allowed origins, got %q", csp)
+			}
+			if cacheControl := response.Header.Get("Cache-Control"); cacheControl != "public, max-age=60" {
+				t.Errorf("expected the Cache-Control header of the widgets, got %q", cacheControl)
+			}
+		})
+	}
+}
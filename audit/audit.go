@@ -0,0 +1,87 @@
+package audit
+
+import (
+	"time"
+)
+
+// Action is the type of administrative action recorded in the audit log
+type Action string
+
+var (
+	// ActionConfigurationReload is the action of reloading the configuration after the configuration file was modified,
+	// or on request through the API
+	ActionConfigurationReload Action = "CONFIGURATION_RELOAD"
+
+	// ActionExternalEndpointTokenUsage is the action of using the token of an external endpoint to push a result
+	ActionExternalEndpointTokenUsage Action = "EXTERNAL_ENDPOINT_TOKEN_USAGE"
+
+	// ActionAnnotationCreation is the action of annotating a change, such as a deployment, through the API
+	ActionAnnotationCreation Action = "ANNOTATION_CREATION"
+
+	// ActionAnnouncementCreation is the action of creating an announcement displayed on the dashboard through the API
+	ActionAnnouncementCreation Action = "ANNOUNCEMENT_CREATION"
+
+	// ActionAnnouncementExpiration is the action of expiring an announcement through the API
+	ActionAnnouncementExpiration Action = "ANNOUNCEMENT_EXPIRATION"
+
+	// ActionOnDemandCheck is the action of triggering the check of an endpoint through the API
+	ActionOnDemandCheck Action = "ON_DEMAND_CHECK"
+
+	// ActionEndpointPause is the action of pausing the monitoring of one or more endpoints through the API
+	ActionEndpointPause Action = "ENDPOINT_PAUSE"
+
+	// ActionEndpointResume is the action of resuming the monitoring of one or more endpoints through the API
+	ActionEndpointResume Action = "ENDPOINT_RESUME"
+
+	// ActionTokenCreation is the action of creating an API token through the API
+	ActionTokenCreation Action = "TOKEN_CREATION"
+
+	// ActionTokenRevocation is the action of revoking an API token through the API
+	ActionTokenRevocation Action = "TOKEN_REVOCATION"
+
+	// ActionLogin is the action of logging in through OIDC or SAML, which fails if the user isn't allowed to
+	ActionLogin Action = "LOGIN"
+
+	// ActionAuthenticationFailure is the action of failing to authenticate through basic authentication or LDAP
+	ActionAuthenticationFailure Action = "AUTHENTICATION_FAILURE"
+
+	// ActionTokenUsage is the action of authenticating a request with an API token, or with an invalid one
+	ActionTokenUsage Action = "TOKEN_USAGE"
+
+	// ActionAccessDenial is the action of denying a request, because its client doesn't have the role required, isn't
+	// allowed by the IP filter, or didn't present a trusted client certificate
+	ActionAccessDenial Action = "ACCESS_DENIAL"
+)
+
+// Entry is an administrative action recorded in the audit log
+type Entry struct {
+	// Timestamp is when the action happened
+	Timestamp time.Time `json:"timestamp"`
+
+	// Action is the type of action
+	Action Action `json:"action"`
+
+	// Actor is who performed the action, e.g. the IP address of the client. Empty if it was performed by Gatus itself.
+	Actor string `json:"actor,omitempty"`
+
+	// Target is what the action was performed on, e.g. the key of an endpoint
+	Target string `json:"target,omitempty"`
+
+	// Success is whether the action succeeded
+	Success bool `json:"success"`
+
+	// Details is additional information about the action, e.g. why it failed
+	Details string `json:"details,omitempty"`
+}
+
+// NewEntry creates a new Entry for an action that just happened
+func NewEntry(action Action, actor, target string, success bool, details string) *Entry {
+	return &Entry{
+		Timestamp: time.Now(),
+		Action:    action,
+		Actor:     actor,
+		Target:    target,
+		Success:   success,
+		Details:   details,
+	}
+}
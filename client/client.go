@@ -1,16 +1,20 @@
 package client
 
 import (
+	"bytes"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"net/smtp"
 	"runtime"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/TwiN/gocache/v2"
@@ -23,7 +27,8 @@ import (
 )
 
 const (
-	dnsPort = 53
+	dnsPort        = 53
+	dnsOverTLSPort = 853
 )
 
 var (
@@ -84,6 +89,60 @@ func CanCreateTCPConnection(address string, config *Config) bool {
 	return true
 }
 
+// TCPStep is a step of the conversation performed by QueryTCP
+type TCPStep struct {
+	// Send is the payload to send. If empty, nothing is sent.
+	Send []byte
+	// Expect returns whether the data received during the step is what was expected. If nil, nothing is read.
+	Expect func(received []byte) bool
+	// Timeout is the duration to wait for the step to complete. If zero, the timeout of the client is used.
+	Timeout time.Duration
+}
+
+// QueryTCP connects to a TCP endpoint and performs each step in order, and returns the data received during all steps
+func QueryTCP(address string, steps []TCPStep, config *Config) (bool, []byte, error) {
+	const MaximumResponseSizePerStep = 64 * 1024 // in bytes
+	conn, err := net.DialTimeout("tcp", address, config.Timeout)
+	if err != nil {
+		return false, nil, fmt.Errorf("error dialing tcp endpoint: %w", err)
+	}
+	defer conn.Close()
+	var received []byte
+	buffer := make([]byte, 4096)
+	for i, step := range steps {
+		timeout := step.Timeout
+		if timeout <= 0 {
+			timeout = config.Timeout
+		}
+		_ = conn.SetDeadline(time.Now().Add(timeout))
+		if len(step.Send) > 0 {
+			if _, err = conn.Write(step.Send); err != nil {
+				return true, received, fmt.Errorf("error sending payload of tcp step #%d: %w", i+1, err)
+			}
+		}
+		if step.Expect == nil {
+			continue
+		}
+		var stepReceived []byte
+		for !step.Expect(stepReceived) {
+			if len(stepReceived) > MaximumResponseSizePerStep {
+				return true, append(received, stepReceived...), fmt.Errorf("error waiting for expected response of tcp step #%d: response exceeded %d bytes", i+1, MaximumResponseSizePerStep)
+			}
+			n, err := conn.Read(buffer)
+			stepReceived = append(stepReceived, buffer[:n]...)
+			if err != nil {
+				// The data received with the error may be what was expected
+				if step.Expect(stepReceived) {
+					break
+				}
+				return true, append(received, stepReceived...), fmt.Errorf("error waiting for expected response of tcp step #%d: %w", i+1, err)
+			}
+		}
+		received = append(received, stepReceived...)
+	}
+	return true, received, nil
+}
+
 // CanCreateUDPConnection checks whether a connection can be established with a UDP endpoint
 func CanCreateUDPConnection(address string, config *Config) bool {
 	conn, err := net.DialTimeout("udp", address, config.Timeout)
@@ -94,6 +153,29 @@ func CanCreateUDPConnection(address string, config *Config) bool {
 	return true
 }
 
+// QueryUDP sends a payload to a UDP endpoint and returns the first datagram received in response
+//
+// Because UDP is connectionless, the endpoint is only considered connected if a response was received before the
+// timeout of the client.
+func QueryUDP(address string, payload []byte, config *Config) (bool, []byte, error) {
+	conn, err := net.DialTimeout("udp", address, config.Timeout)
+	if err != nil {
+		return false, nil, fmt.Errorf("error dialing udp endpoint: %w", err)
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(config.Timeout))
+	if _, err = conn.Write(payload); err != nil {
+		return false, nil, fmt.Errorf("error writing udp payload: %w", err)
+	}
+	// A datagram can't be larger than 65507 bytes over IPv4
+	response := make([]byte, 65507)
+	n, err := conn.Read(response)
+	if err != nil {
+		return false, nil, fmt.Errorf("error reading udp response: %w", err)
+	}
+	return true, response[:n], nil
+}
+
 // CanCreateSCTPConnection checks whether a connection can be established with a SCTP endpoint
 func CanCreateSCTPConnection(address string, config *Config) bool {
 	ch := make(chan bool)
@@ -199,7 +281,9 @@ func CanCreateSSHConnection(address, username, password string, config *Config)
 }
 
 // ExecuteSSHCommand executes a command to an address using the SSH protocol.
-func ExecuteSSHCommand(sshClient *ssh.Client, body string, config *Config) (bool, int, error) {
+//
+// Returns whether the command was executed, its exit code and its output, which is made of both stdout and stderr.
+func ExecuteSSHCommand(sshClient *ssh.Client, body string, config *Config) (bool, int, []byte, error) {
 	type Body struct {
 		Command string `json:"command"`
 	}
@@ -208,41 +292,92 @@ func ExecuteSSHCommand(sshClient *ssh.Client, body string, config *Config) (bool
 
 	var b Body
 	if err := json.Unmarshal([]byte(body), &b); err != nil {
-		return false, 0, err
+		return false, 0, nil, err
 	}
 
 	sess, err := sshClient.NewSession()
 	if err != nil {
-		return false, 0, err
+		return false, 0, nil, err
 	}
 
+	// stdout and stderr are copied by different goroutines, so the buffer must be safe for concurrent use
+	output := &synchronizedBuffer{}
+	sess.Stdout = output
+	sess.Stderr = output
+
 	err = sess.Start(b.Command)
 	if err != nil {
-		return false, 0, err
+		return false, 0, nil, err
 	}
 
 	defer sess.Close()
 
 	err = sess.Wait()
 	if err == nil {
-		return true, 0, nil
+		return true, 0, output.Bytes(), nil
 	}
 
 	e, ok := err.(*ssh.ExitError)
 	if !ok {
-		return false, 0, err
+		return false, 0, output.Bytes(), err
 	}
 
-	return true, e.ExitStatus(), nil
+	return true, e.ExitStatus(), output.Bytes(), nil
+}
+
+// synchronizedBuffer is a bytes.Buffer that is safe for concurrent writes
+type synchronizedBuffer struct {
+	buffer bytes.Buffer
+	mutex  sync.Mutex
+}
+
+func (b *synchronizedBuffer) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buffer.Write(p)
+}
+
+func (b *synchronizedBuffer) Bytes() []byte {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buffer.Bytes()
+}
+
+// PingStatistics are the statistics of the pings sent by PingWithStatistics
+type PingStatistics struct {
+	// PacketLoss is the percentage of pings that didn't receive a reply
+	PacketLoss float64
+	MinRTT     time.Duration
+	AvgRTT     time.Duration
+	MaxRTT     time.Duration
 }
 
 // Ping checks if an address can be pinged and returns the round-trip time if the address can be pinged
 //
 // Note that this function takes at least 100ms, even if the address is 127.0.0.1
 func Ping(address string, config *Config) (bool, time.Duration) {
+	success, statistics := PingWithStatistics(address, 1, 0, config)
+	if statistics == nil {
+		return success, 0
+	}
+	if !success {
+		return false, config.Timeout
+	}
+	return true, statistics.MaxRTT
+}
+
+// PingWithStatistics sends count pings to an address, waiting for interval between each of them, and returns whether
+// at least one of them received a reply along with the statistics of the pings.
+//
+// The timeout of the client applies to each ping, meaning that sending multiple pings may take up to
+// (count-1)*interval + timeout.
+func PingWithStatistics(address string, count int, interval time.Duration, config *Config) (bool, *PingStatistics) {
 	pinger := ping.New(address)
-	pinger.Count = 1
-	pinger.Timeout = config.Timeout
+	pinger.Count = count
+	if interval > 0 {
+		pinger.Interval = interval
+	}
+	pinger.Timeout = config.Timeout + time.Duration(count-1)*pinger.Interval
 	// Set the pinger's privileged mode to true for every GOOS except darwin
 	// See https://github.com/TwiN/gatus/issues/132
 	//
@@ -252,35 +387,28 @@ func Ping(address string, config *Config) (bool, time.Duration) {
 	pinger.SetNetwork(config.Network)
 	err := pinger.Run()
 	if err != nil {
-		return false, 0
+		return false, nil
 	}
-	if pinger.Statistics() != nil {
-		// If the packet loss is 100, it means that the packet didn't reach the host
-		if pinger.Statistics().PacketLoss == 100 {
-			return false, pinger.Timeout
-		}
-		return true, pinger.Statistics().MaxRtt
+	statistics := pinger.Statistics()
+	if statistics == nil {
+		return true, &PingStatistics{}
+	}
+	pingStatistics := &PingStatistics{
+		PacketLoss: statistics.PacketLoss,
+		MinRTT:     statistics.MinRtt,
+		AvgRTT:     statistics.AvgRtt,
+		MaxRTT:     statistics.MaxRtt,
 	}
-	return true, 0
+	// If the packet loss is 100, it means that none of the packets reached the host
+	return statistics.PacketLoss < 100, pingStatistics
 }
 
 // QueryWebSocket opens a websocket connection, write `body` and return a message from the server
-func QueryWebSocket(address, body string, config *Config) (bool, []byte, error) {
-	const (
-		Origin             = "http://localhost/"
-		MaximumMessageSize = 1024 // in bytes
-	)
-	wsConfig, err := websocket.NewConfig(address, Origin)
+func QueryWebSocket(address, body string, subprotocols []string, config *Config) (bool, []byte, error) {
+	const MaximumMessageSize = 1024 // in bytes
+	ws, err := dialWebSocket(address, subprotocols, config)
 	if err != nil {
-		return false, nil, fmt.Errorf("error configuring websocket connection: %w", err)
-	}
-	if config != nil {
-		wsConfig.Dialer = &net.Dialer{Timeout: config.Timeout}
-	}
-	// Dial URL
-	ws, err := websocket.DialConfig(wsConfig)
-	if err != nil {
-		return false, nil, fmt.Errorf("error dialing websocket: %w", err)
+		return false, nil, err
 	}
 	defer ws.Close()
 	// Write message
@@ -296,15 +424,92 @@ func QueryWebSocket(address, body string, config *Config) (bool, []byte, error)
 	return true, msg[:n], nil
 }
 
-func QueryDNS(queryType, queryName, url string) (connected bool, dnsRcode string, body []byte, err error) {
-	if !strings.Contains(url, ":") {
-		url = fmt.Sprintf("%s:%d", url, dnsPort)
+// QueryWebSocketWithMessages opens a websocket connection and, for each message, writes the message unless it's empty
+// and reads a message from the server.
+//
+// Returns the JSON-encoded array of the messages received. Messages that are valid JSON are embedded as-is, and all
+// other messages are embedded as strings.
+func QueryWebSocketWithMessages(address string, messages, subprotocols []string, config *Config) (bool, []byte, error) {
+	const MaximumMessageSize = 64 * 1024 // in bytes
+	ws, err := dialWebSocket(address, subprotocols, config)
+	if err != nil {
+		return false, nil, err
+	}
+	defer ws.Close()
+	ws.MaxPayloadBytes = MaximumMessageSize
+	if config != nil {
+		_ = ws.SetDeadline(time.Now().Add(config.Timeout))
+	}
+	responses := make([]interface{}, 0, len(messages))
+	for i, message := range messages {
+		if len(message) > 0 {
+			if err = websocket.Message.Send(ws, message); err != nil {
+				return true, nil, fmt.Errorf("error writing websocket message #%d: %w", i+1, err)
+			}
+		}
+		var response []byte
+		if err = websocket.Message.Receive(ws, &response); err != nil {
+			return true, nil, fmt.Errorf("error reading response to websocket message #%d: %w", i+1, err)
+		}
+		if json.Valid(response) {
+			responses = append(responses, json.RawMessage(response))
+		} else {
+			responses = append(responses, string(response))
+		}
+	}
+	body, err := json.Marshal(responses)
+	if err != nil {
+		return true, nil, fmt.Errorf("error encoding websocket responses: %w", err)
+	}
+	return true, body, nil
+}
+
+func dialWebSocket(address string, subprotocols []string, config *Config) (*websocket.Conn, error) {
+	const Origin = "http://localhost/"
+	wsConfig, err := websocket.NewConfig(address, Origin)
+	if err != nil {
+		return nil, fmt.Errorf("error configuring websocket connection: %w", err)
+	}
+	wsConfig.Protocol = subprotocols
+	if config != nil {
+		wsConfig.Dialer = &net.Dialer{Timeout: config.Timeout}
 	}
+	// Dial URL
+	ws, err := websocket.DialConfig(wsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error dialing websocket: %w", err)
+	}
+	return ws, nil
+}
+
+func QueryDNS(queryType, queryName, url string, config *Config) (connected bool, dnsRcode string, body []byte, err error) {
 	queryTypeAsUint16 := dns.StringToType[queryType]
-	c := new(dns.Client)
 	m := new(dns.Msg)
 	m.SetQuestion(queryName, queryTypeAsUint16)
-	r, _, err := c.Exchange(m, url)
+	var r *dns.Msg
+	if strings.HasPrefix(url, "https://") {
+		// DNS-over-HTTPS (RFC 8484)
+		r, err = exchangeDNSOverHTTPS(m, url, config)
+	} else if strings.HasPrefix(url, "tls://") {
+		// DNS-over-TLS (RFC 7858)
+		address := strings.TrimPrefix(url, "tls://")
+		if _, _, splitErr := net.SplitHostPort(address); splitErr != nil {
+			address = net.JoinHostPort(address, strconv.Itoa(dnsOverTLSPort))
+		}
+		host, _, _ := net.SplitHostPort(address)
+		tlsConfig := &tls.Config{InsecureSkipVerify: config.Insecure, ServerName: host}
+		if config.HasTlsConfig() && config.TLS.isValid() == nil {
+			tlsConfig = configureTLS(tlsConfig, *config.TLS)
+		}
+		c := &dns.Client{Net: "tcp-tls", Timeout: config.Timeout, TLSConfig: tlsConfig}
+		r, _, err = c.Exchange(m, address)
+	} else {
+		if !strings.Contains(url, ":") {
+			url = fmt.Sprintf("%s:%d", url, dnsPort)
+		}
+		c := new(dns.Client)
+		r, _, err = c.Exchange(m, url)
+	}
 	if err != nil {
 		return false, "", nil, err
 	}
@@ -339,6 +544,39 @@ func QueryDNS(queryType, queryName, url string) (connected bool, dnsRcode string
 	return connected, dnsRcode, body, nil
 }
 
+// exchangeDNSOverHTTPS sends the DNS message to the DNS-over-HTTPS resolver using the wire format, and returns its reply
+func exchangeDNSOverHTTPS(m *dns.Msg, url string, config *Config) (*dns.Msg, error) {
+	// RFC 8484 recommends using an ID of 0 to maximize HTTP cache friendliness
+	m.Id = 0
+	packed, err := m.Pack()
+	if err != nil {
+		return nil, err
+	}
+	request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(packed))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/dns-message")
+	request.Header.Set("Accept", "application/dns-message")
+	response, err := GetHTTPClient(config).Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code from DNS-over-HTTPS resolver: %d", response.StatusCode)
+	}
+	reply, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	r := new(dns.Msg)
+	if err = r.Unpack(reply); err != nil {
+		return nil, fmt.Errorf("error parsing DNS-over-HTTPS reply: %w", err)
+	}
+	return r, nil
+}
+
 // InjectHTTPClient is used to inject a custom HTTP client for testing purposes
 func InjectHTTPClient(httpClient *http.Client) {
 	injectedHTTPClient = httpClient
@@ -2,15 +2,25 @@ package client
 
 import (
 	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
 	"crypto/tls"
+	"encoding/binary"
+	"errors"
 	"io"
+	"net"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/TwiN/gatus/v5/config/endpoint/dns"
 	"github.com/TwiN/gatus/v5/pattern"
 	"github.com/TwiN/gatus/v5/test"
+	miekgdns "github.com/miekg/dns"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/net/websocket"
 )
 
 func TestGetHTTPClient(t *testing.T) {
@@ -114,6 +124,23 @@ func TestPing(t *testing.T) {
 	}
 }
 
+func TestPingWithStatistics(t *testing.T) {
+	t.Parallel()
+	success, statistics := PingWithStatistics("127.0.0.1", 3, 10*time.Millisecond, &Config{Timeout: 500 * time.Millisecond, Network: "ip"})
+	if !success {
+		t.Fatal("expected true")
+	}
+	if statistics.PacketLoss != 0 {
+		t.Errorf("expected no packet loss, got %f%%", statistics.PacketLoss)
+	}
+	if statistics.MinRTT <= 0 || statistics.MinRTT > statistics.AvgRTT || statistics.AvgRTT > statistics.MaxRTT {
+		t.Errorf("expected 0 < min-rtt <= avg-rtt <= max-rtt, got %s, %s and %s", statistics.MinRTT, statistics.AvgRTT, statistics.MaxRTT)
+	}
+	if success, statistics := PingWithStatistics("192.168.152.153", 2, 10*time.Millisecond, &Config{Timeout: 200 * time.Millisecond}); success || (statistics != nil && statistics.PacketLoss != 100) {
+		t.Error("expected false with 100% packet loss, because the IP is valid but the host should be unreachable")
+	}
+}
+
 func TestCanPerformStartTLS(t *testing.T) {
 	type args struct {
 		address  string
@@ -225,6 +252,104 @@ func TestCanCreateTCPConnection(t *testing.T) {
 	}
 }
 
+func TestQueryTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("failed to start tcp server:", err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				// Send the banner in two parts to make sure that reads are accumulated
+				_, _ = conn.Write([]byte("220 test"))
+				time.Sleep(10 * time.Millisecond)
+				_, _ = conn.Write([]byte(" ready\r\n"))
+				buffer := make([]byte, 1024)
+				n, err := conn.Read(buffer)
+				if err != nil {
+					return
+				}
+				if string(buffer[:n]) == "QUIT\r\n" {
+					_, _ = conn.Write([]byte("221 bye\r\n"))
+				}
+			}(conn)
+		}
+	}()
+	expect := func(prefix string) func([]byte) bool {
+		return func(received []byte) bool {
+			return strings.HasPrefix(string(received), prefix) && strings.HasSuffix(string(received), "\r\n")
+		}
+	}
+	steps := []TCPStep{{Expect: expect("220 test ready")}, {Send: []byte("QUIT\r\n"), Expect: expect("221")}}
+	connected, body, err := QueryTCP(listener.Addr().String(), steps, &Config{Timeout: 500 * time.Millisecond})
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if !connected {
+		t.Error("expected to be connected")
+	}
+	if string(body) != "220 test ready\r\n221 bye\r\n" {
+		t.Errorf("expected body to contain the data received during all steps, got %q", body)
+	}
+	steps = []TCPStep{{Expect: expect("220")}, {Send: []byte("HELO\r\n"), Expect: expect("250"), Timeout: 100 * time.Millisecond}}
+	connected, body, err = QueryTCP(listener.Addr().String(), steps, &Config{Timeout: 500 * time.Millisecond})
+	if err == nil || !strings.Contains(err.Error(), "tcp step #2") {
+		t.Errorf("expected an error for the second step, got %v", err)
+	}
+	if !connected {
+		t.Error("expected to be connected, because the connection was established")
+	}
+	if string(body) != "220 test ready\r\n" {
+		t.Errorf("expected body to contain the data received before the error, got %q", body)
+	}
+	if connected, _, err = QueryTCP("127.0.0.1", steps, &Config{Timeout: 500 * time.Millisecond}); connected || err == nil {
+		t.Error("expected an error, because there's no port in the address")
+	}
+}
+
+func TestQueryUDP(t *testing.T) {
+	server, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("failed to start udp server:", err)
+	}
+	defer server.Close()
+	go func() {
+		buffer := make([]byte, 1024)
+		for {
+			n, addr, err := server.ReadFrom(buffer)
+			if err != nil {
+				return
+			}
+			if string(buffer[:n]) == "ping" {
+				_, _ = server.WriteTo([]byte("pong"), addr)
+			}
+		}
+	}()
+	connected, body, err := QueryUDP(server.LocalAddr().String(), []byte("ping"), &Config{Timeout: 500 * time.Millisecond})
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if !connected {
+		t.Error("expected to be connected")
+	}
+	if string(body) != "pong" {
+		t.Errorf("expected body to be pong, got %s", body)
+	}
+	// The server doesn't reply to anything else, so the read should time out
+	if connected, _, err = QueryUDP(server.LocalAddr().String(), []byte("hello"), &Config{Timeout: 100 * time.Millisecond}); connected || err == nil {
+		t.Error("expected an error, because the server didn't reply")
+	}
+	if connected, _, err = QueryUDP("127.0.0.1", []byte("ping"), &Config{Timeout: 500 * time.Millisecond}); connected || err == nil {
+		t.Error("expected an error, because there's no port in the address")
+	}
+}
+
 // This test checks if a HTTP client configured with `configureOAuth2()` automatically
 // performs a Client Credentials OAuth2 flow and adds the obtained token as a `Authorization`
 // header to all outgoing HTTP calls.
@@ -284,16 +409,52 @@ func TestHttpClientProvidesOAuth2BearerToken(t *testing.T) {
 }
 
 func TestQueryWebSocket(t *testing.T) {
-	_, _, err := QueryWebSocket("", "body", &Config{Timeout: 2 * time.Second})
+	_, _, err := QueryWebSocket("", "body", nil, &Config{Timeout: 2 * time.Second})
 	if err == nil {
 		t.Error("expected an error due to the address being invalid")
 	}
-	_, _, err = QueryWebSocket("ws://example.org", "body", &Config{Timeout: 2 * time.Second})
+	_, _, err = QueryWebSocket("ws://example.org", "body", nil, &Config{Timeout: 2 * time.Second})
 	if err == nil {
 		t.Error("expected an error due to the target not being websocket-friendly")
 	}
 }
 
+func TestQueryWebSocketWithMessages(t *testing.T) {
+	server := httptest.NewServer(websocket.Server{
+		Handshake: func(config *websocket.Config, r *http.Request) error {
+			for _, protocol := range config.Protocol {
+				if protocol == "v2" {
+					config.Protocol = []string{protocol}
+					return nil
+				}
+			}
+			return errors.New("unsupported subprotocol")
+		},
+		Handler: func(ws *websocket.Conn) {
+			_ = websocket.Message.Send(ws, `{"type":"welcome"}`)
+			var message string
+			for websocket.Message.Receive(ws, &message) == nil {
+				_ = websocket.Message.Send(ws, "echo: "+message)
+			}
+		},
+	})
+	defer server.Close()
+	address := "ws" + strings.TrimPrefix(server.URL, "http")
+	connected, body, err := QueryWebSocketWithMessages(address, []string{"", "ping", "status"}, []string{"v1", "v2"}, &Config{Timeout: 2 * time.Second})
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if !connected {
+		t.Error("expected to be connected")
+	}
+	if expected := `[{"type":"welcome"},"echo: ping","echo: status"]`; string(body) != expected {
+		t.Errorf("expected body to be %s, got %s", expected, body)
+	}
+	if connected, _, err = QueryWebSocketWithMessages(address, []string{"ping"}, []string{"v1"}, &Config{Timeout: 2 * time.Second}); connected || err == nil {
+		t.Error("expected an error, because the server doesn't support any of the subprotocols")
+	}
+}
+
 func TestTlsRenegotiation(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -409,7 +570,7 @@ func TestQueryDNS(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			_, dnsRCode, body, err := QueryDNS(test.inputDNS.QueryType, test.inputDNS.QueryName, test.inputURL)
+			_, dnsRCode, body, err := QueryDNS(test.inputDNS.QueryType, test.inputDNS.QueryName, test.inputURL, GetDefaultConfig())
 			if test.isErrExpected && err == nil {
 				t.Errorf("there should be an error")
 			}
@@ -430,3 +591,172 @@ func TestQueryDNS(t *testing.T) {
 		time.Sleep(5 * time.Millisecond)
 	}
 }
+
+func TestQueryDNS_withDNSOverHTTPSAndDNSOverTLS(t *testing.T) {
+	answer := func(request *miekgdns.Msg) *miekgdns.Msg {
+		reply := new(miekgdns.Msg)
+		reply.SetReply(request)
+		reply.Answer = append(reply.Answer, &miekgdns.A{
+			Hdr: miekgdns.RR_Header{Name: request.Question[0].Name, Rrtype: miekgdns.TypeA, Class: miekgdns.ClassINET, Ttl: 60},
+			A:   net.ParseIP("192.0.2.1"),
+		})
+		return reply
+	}
+	dohServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/dns-message" {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		request := new(miekgdns.Msg)
+		if err := request.Unpack(body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		reply, _ := answer(request).Pack()
+		w.Header().Set("Content-Type", "application/dns-message")
+		_, _ = w.Write(reply)
+	}))
+	defer dohServer.Close()
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", dohServer.TLS.Clone())
+	if err != nil {
+		t.Fatal("failed to start listener:", err)
+	}
+	started := make(chan struct{})
+	dotServer := &miekgdns.Server{
+		Listener:          listener,
+		Net:               "tcp-tls",
+		NotifyStartedFunc: func() { close(started) },
+		Handler: miekgdns.HandlerFunc(func(w miekgdns.ResponseWriter, request *miekgdns.Msg) {
+			_ = w.WriteMsg(answer(request))
+		}),
+	}
+	go dotServer.ActivateAndServe()
+	defer dotServer.Shutdown()
+	<-started
+	cfg := &Config{Insecure: true, Timeout: 5 * time.Second}
+	for _, url := range []string{dohServer.URL + "/dns-query", "tls://" + listener.Addr().String()} {
+		t.Run(url, func(t *testing.T) {
+			connected, dnsRCode, body, err := QueryDNS("A", "example.org.", url, cfg)
+			if !connected || err != nil {
+				t.Fatalf("expected query to succeed, got connected=%v err=%v", connected, err)
+			}
+			if dnsRCode != "NOERROR" {
+				t.Errorf("expected DNSRCode to be NOERROR, got %s", dnsRCode)
+			}
+			if string(body) != "192.0.2.1" {
+				t.Errorf("expected body to be 192.0.2.1, got %s", body)
+			}
+		})
+	}
+	if connected, _, _, err := QueryDNS("A", "example.org.", dohServer.URL+"/dns-query", &Config{Timeout: 5 * time.Second}); connected || err == nil {
+		t.Error("expected an error due to the certificate of the resolver not being trusted")
+	}
+}
+
+func TestExecuteSSHCommand(t *testing.T) {
+	_, privateKey, _ := ed25519.GenerateKey(rand.Reader)
+	signer, err := ssh.NewSignerFromKey(privateKey)
+	if err != nil {
+		t.Fatal("failed to create host key:", err)
+	}
+	serverConfig := &ssh.ServerConfig{
+		PasswordCallback: func(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if conn.User() == "user" && string(password) == "pass" {
+				return nil, nil
+			}
+			return nil, errors.New("invalid credentials")
+		},
+	}
+	serverConfig.AddHostKey(signer)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("failed to start ssh server:", err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveFakeSSHCommands(conn, serverConfig)
+		}
+	}()
+	scenarios := []struct {
+		name             string
+		command          string
+		expectedExitCode int
+		expectedOutput   string
+	}{
+		{
+			name:             "success",
+			command:          "echo hello",
+			expectedExitCode: 0,
+			expectedOutput:   "hello\n",
+		},
+		{
+			name:             "failure",
+			command:          "missing-command",
+			expectedExitCode: 127,
+			expectedOutput:   "missing-command: command not found\n",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			connected, cli, err := CanCreateSSHConnection(listener.Addr().String(), "user", "pass", &Config{Timeout: 2 * time.Second})
+			if err != nil || !connected {
+				t.Fatal("expected to be connected, got", err)
+			}
+			executed, exitCode, output, err := ExecuteSSHCommand(cli, `{"command":"`+scenario.command+`"}`, &Config{Timeout: 2 * time.Second})
+			if err != nil || !executed {
+				t.Fatal("expected command to be executed, got", err)
+			}
+			if exitCode != scenario.expectedExitCode {
+				t.Errorf("expected exit code to be %d, got %d", scenario.expectedExitCode, exitCode)
+			}
+			if string(output) != scenario.expectedOutput {
+				t.Errorf("expected output to be %q, got %q", scenario.expectedOutput, output)
+			}
+		})
+	}
+}
+
+// serveFakeSSHCommands accepts exec requests and replies to "echo hello" on stdout, and to anything else on stderr
+// with the exit code 127
+func serveFakeSSHCommands(conn net.Conn, config *ssh.ServerConfig) {
+	_, channels, requests, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(requests)
+	for newChannel := range channels {
+		channel, channelRequests, err := newChannel.Accept()
+		if err != nil {
+			continue
+		}
+		go func() {
+			defer channel.Close()
+			for request := range channelRequests {
+				if request.Type != "exec" {
+					_ = request.Reply(false, nil)
+					continue
+				}
+				_ = request.Reply(true, nil)
+				// The payload is the length-prefixed command
+				command := string(request.Payload[4:])
+				exitCode := uint32(0)
+				if command == "echo hello" {
+					_, _ = channel.Write([]byte("hello\n"))
+				} else {
+					_, _ = channel.Stderr().Write([]byte(command + ": command not found\n"))
+					exitCode = 127
+				}
+				exitStatus := make([]byte, 4)
+				binary.BigEndian.PutUint32(exitStatus, exitCode)
+				_, _ = channel.SendRequest("exit-status", false, exitStatus)
+				return
+			}
+		}()
+	}
+}
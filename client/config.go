@@ -12,6 +12,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/quic-go/quic-go/http3"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/clientcredentials"
 	"google.golang.org/api/idtoken"
@@ -27,6 +28,7 @@ var (
 	ErrInvalidClientOAuth2Config = errors.New("invalid oauth2 configuration: must define all fields for client credentials flow (token-url, client-id, client-secret, scopes)")
 	ErrInvalidClientIAPConfig    = errors.New("invalid Identity-Aware-Proxy configuration: must define all fields for Google Identity-Aware-Proxy programmatic authentication (audience)")
 	ErrInvalidClientTLSConfig    = errors.New("invalid TLS configuration: certificate-file and private-key-file must be specified")
+	ErrInvalidClientHTTP3Config  = errors.New("invalid HTTP/3 configuration: proxy-url and dns-resolver are not supported with HTTP/3")
 
 	defaultConfig = Config{
 		Insecure:       false,
@@ -76,6 +78,13 @@ type Config struct {
 
 	// TLS configuration (optional)
 	TLS *TLSConfig `yaml:"tls,omitempty"`
+
+	// HTTP3 determines whether to send HTTP requests over HTTP/3 (QUIC) instead of HTTP/1.1 or HTTP/2
+	HTTP3 bool `yaml:"http3,omitempty"`
+
+	// CheckRevocation determines whether to check, through OCSP or the CRL of its issuer, that the certificate presented
+	// by the server hasn't been revoked
+	CheckRevocation bool `yaml:"check-revocation,omitempty"`
 }
 
 // DNSResolverConfig is the parsed configuration from the DNSResolver config string.
@@ -131,6 +140,9 @@ func (c *Config) ValidateAndSetDefaults() error {
 			return err
 		}
 	}
+	if c.HTTP3 && (len(c.ProxyURL) > 0 || c.HasCustomDNSResolver()) {
+		return ErrInvalidClientHTTP3Config
+	}
 	return nil
 }
 
@@ -212,24 +224,33 @@ func (c *Config) getHTTPClient() *http.Client {
 		tlsConfig = configureTLS(tlsConfig, *c.TLS)
 	}
 	if c.httpClient == nil {
-		c.httpClient = &http.Client{
-			Timeout: c.Timeout,
-			Transport: &http.Transport{
-				MaxIdleConns:        100,
-				MaxIdleConnsPerHost: 20,
-				Proxy:               http.ProxyFromEnvironment,
-				TLSClientConfig:     tlsConfig,
-			},
-			CheckRedirect: func(req *http.Request, via []*http.Request) error {
-				if c.IgnoreRedirect {
-					// Don't follow redirects
-					return http.ErrUseLastResponse
-				}
-				// Follow redirects
-				return nil
-			},
+		checkRedirect := func(req *http.Request, via []*http.Request) error {
+			if c.IgnoreRedirect {
+				// Don't follow redirects
+				return http.ErrUseLastResponse
+			}
+			// Follow redirects
+			return nil
+		}
+		if c.HTTP3 {
+			c.httpClient = &http.Client{
+				Timeout:       c.Timeout,
+				Transport:     &http3.RoundTripper{TLSClientConfig: tlsConfig},
+				CheckRedirect: checkRedirect,
+			}
+		} else {
+			c.httpClient = &http.Client{
+				Timeout: c.Timeout,
+				Transport: &http.Transport{
+					MaxIdleConns:        100,
+					MaxIdleConnsPerHost: 20,
+					Proxy:               http.ProxyFromEnvironment,
+					TLSClientConfig:     tlsConfig,
+				},
+				CheckRedirect: checkRedirect,
+			}
 		}
-		if c.ProxyURL != "" {
+		if c.ProxyURL != "" && !c.HTTP3 {
 			proxyURL, err := url.Parse(c.ProxyURL)
 			if err != nil {
 				log.Println("[client.getHTTPClient] THIS SHOULD NOT HAPPEN. Silently ignoring custom proxy due to error:", err.Error())
@@ -237,7 +258,7 @@ func (c *Config) getHTTPClient() *http.Client {
 				c.httpClient.Transport.(*http.Transport).Proxy = http.ProxyURL(proxyURL)
 			}
 		}
-		if c.HasCustomDNSResolver() {
+		if c.HasCustomDNSResolver() && !c.HTTP3 {
 			dnsResolver, err := c.parseDNSResolver()
 			if err != nil {
 				// We're ignoring the error, because it should have been validated on startup ValidateAndSetDefaults.
@@ -1,10 +1,14 @@
 package client
 
 import (
+	"net"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"testing"
 	"time"
+
+	"github.com/quic-go/quic-go/http3"
 )
 
 func TestConfig_getHTTPClient(t *testing.T) {
@@ -169,3 +173,50 @@ func TestConfig_TlsIsValid(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_getHTTPClient_withHTTP3(t *testing.T) {
+	cfg := &Config{HTTP3: true, Insecure: true, Timeout: 5 * time.Second}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	client := cfg.getHTTPClient()
+	transport, ok := client.Transport.(*http3.RoundTripper)
+	if !ok {
+		t.Fatalf("expected Config.HTTP3 to cause the HTTP client to use an HTTP/3 transport, got %T", client.Transport)
+	}
+	if !transport.TLSClientConfig.InsecureSkipVerify {
+		t.Error("expected Config.Insecure set to true to cause the HTTP client to skip certificate verification")
+	}
+	// Reuse the certificate of httptest's TLS server to serve requests over HTTP/3
+	tlsServer := httptest.NewTLSServer(nil)
+	defer tlsServer.Close()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("failed to start listener:", err)
+	}
+	server := &http3.Server{
+		TLSConfig: http3.ConfigureTLSConfig(tlsServer.TLS.Clone()),
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}),
+	}
+	go server.Serve(conn)
+	defer server.Close()
+	response, err := client.Get("https://" + conn.LocalAddr().String() + "/")
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusNoContent || response.Proto != "HTTP/3.0" {
+		t.Errorf("expected a 204 response over HTTP/3.0, got %d over %s", response.StatusCode, response.Proto)
+	}
+}
+
+func TestConfig_ValidateAndSetDefaults_withHTTP3(t *testing.T) {
+	if err := (&Config{HTTP3: true, ProxyURL: "http://proxy.example.com:8080"}).ValidateAndSetDefaults(); err != ErrInvalidClientHTTP3Config {
+		t.Errorf("expected %v, got %v", ErrInvalidClientHTTP3Config, err)
+	}
+	if err := (&Config{HTTP3: true, DNSResolver: "tcp://1.1.1.1:53"}).ValidateAndSetDefaults(); err != ErrInvalidClientHTTP3Config {
+		t.Errorf("expected %v, got %v", ErrInvalidClientHTTP3Config, err)
+	}
+}
@@ -0,0 +1,42 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// diskUsage is the usage of the space of a filesystem, in bytes
+type diskUsage struct {
+	Total uint64 `json:"total"`
+	Free  uint64 `json:"free"`
+	// Available is the free space available to unprivileged users, which excludes the blocks reserved for root
+	Available uint64 `json:"available"`
+}
+
+// QueryDiskUsage retrieves the usage of the space of the filesystem on which the path passed as parameter is located.
+//
+// The address is expected to be prefixed by disk://, e.g. disk:///var/lib/gatus.
+// Returns the percentage of the space available to unprivileged users as computed by df, which means that the blocks
+// reserved for root are excluded from the total, and a JSON object with the total, free and available space in bytes
+// as body.
+func QueryDiskUsage(address string) (int, []byte, error) {
+	path := strings.TrimPrefix(address, "disk://")
+	if len(path) == 0 {
+		return 0, nil, errors.New("invalid address for disk, format must be disk:///path/to/mount")
+	}
+	usage, err := getDiskUsage(path)
+	if err != nil {
+		return 0, nil, fmt.Errorf("error retrieving disk usage: %w", err)
+	}
+	freePercent := 0
+	if used := usage.Total - usage.Free; used+usage.Available > 0 {
+		freePercent = int(usage.Available * 100 / (used + usage.Available))
+	}
+	body, err := json.Marshal(usage)
+	if err != nil {
+		return 0, nil, err
+	}
+	return freePercent, body, nil
+}
@@ -0,0 +1,12 @@
+//go:build !linux && !darwin
+
+package client
+
+import (
+	"errors"
+	"runtime"
+)
+
+func getDiskUsage(_ string) (*diskUsage, error) {
+	return nil, errors.New("disk usage is not supported on " + runtime.GOOS)
+}
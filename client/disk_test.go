@@ -0,0 +1,37 @@
+package client
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestQueryDiskUsage(t *testing.T) {
+	if runtime.GOOS != "linux" && runtime.GOOS != "darwin" {
+		t.Skip("disk usage is not supported on " + runtime.GOOS)
+	}
+	freePercent, body, err := QueryDiskUsage("disk://" + t.TempDir())
+	if err != nil {
+		t.Fatalf("expected no error, got '%v'", err)
+	}
+	if freePercent < 0 || freePercent > 100 {
+		t.Errorf("expected free percent to be between 0 and 100, got %d", freePercent)
+	}
+	var usage diskUsage
+	if err = json.Unmarshal(body, &usage); err != nil {
+		t.Fatalf("expected body to be valid JSON, got '%v'", err)
+	}
+	if usage.Total == 0 || usage.Available > usage.Free || usage.Free > usage.Total {
+		t.Errorf("expected 0 < available <= free <= total, got %s", body)
+	}
+}
+
+func TestQueryDiskUsageWithInvalidAddress(t *testing.T) {
+	if _, _, err := QueryDiskUsage("disk://"); err == nil {
+		t.Error("expected an error, got none")
+	}
+	if _, _, err := QueryDiskUsage("disk://" + filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("expected an error, got none")
+	}
+}
@@ -0,0 +1,42 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"time"
+)
+
+// QueryFile retrieves the size and the modification time of a local file, as well as its content if readContent is
+// true.
+//
+// The address is expected to be prefixed by file://, e.g. file:///var/backups/db.sql.gz.
+// Returns whether the file exists, its size, the duration since it was last modified and its content. A file that
+// doesn't exist isn't considered as an error, so that conditions can assert the absence of a file.
+func QueryFile(address string, readContent bool) (bool, int64, time.Duration, []byte, error) {
+	path := strings.TrimPrefix(address, "file://")
+	if len(path) == 0 {
+		return false, 0, 0, nil, errors.New("invalid address for file, format must be file:///path/to/file")
+	}
+	fileInfo, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, 0, 0, nil, nil
+		}
+		return false, 0, 0, nil, fmt.Errorf("error retrieving information about file: %w", err)
+	}
+	age := time.Since(fileInfo.ModTime())
+	if !readContent {
+		return true, fileInfo.Size(), age, nil, nil
+	}
+	if fileInfo.IsDir() {
+		return true, fileInfo.Size(), age, nil, fmt.Errorf("cannot read content of %s: is a directory", path)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return true, fileInfo.Size(), age, nil, fmt.Errorf("error reading file: %w", err)
+	}
+	return true, fileInfo.Size(), age, content, nil
+}
@@ -0,0 +1,86 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestQueryFile(t *testing.T) {
+	directory := t.TempDir()
+	path := filepath.Join(directory, "backup.log")
+	if err := os.WriteFile(path, []byte("backup completed"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, time.Now().Add(-time.Hour), time.Now().Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	scenarios := []struct {
+		name               string
+		address            string
+		readContent        bool
+		expectedExists     bool
+		expectedSize       int64
+		expectedContent    string
+		expectedMinimumAge time.Duration
+		expectedErr        bool
+	}{
+		{
+			name:               "exists",
+			address:            "file://" + path,
+			expectedExists:     true,
+			expectedSize:       16,
+			expectedMinimumAge: time.Hour,
+		},
+		{
+			name:               "exists-with-content",
+			address:            "file://" + path,
+			readContent:        true,
+			expectedExists:     true,
+			expectedSize:       16,
+			expectedContent:    "backup completed",
+			expectedMinimumAge: time.Hour,
+		},
+		{
+			name:           "does-not-exist",
+			address:        "file://" + filepath.Join(directory, "missing.log"),
+			expectedExists: false,
+		},
+		{
+			name:           "directory-with-content",
+			address:        "file://" + directory,
+			readContent:    true,
+			expectedExists: true,
+			expectedErr:    true,
+		},
+		{
+			name:        "no-path",
+			address:     "file://",
+			expectedErr: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			exists, size, age, content, err := QueryFile(scenario.address, scenario.readContent)
+			if scenario.expectedErr != (err != nil) {
+				t.Fatalf("expected error to be %v, got '%v'", scenario.expectedErr, err)
+			}
+			if exists != scenario.expectedExists {
+				t.Errorf("expected exists to be %v, got %v", scenario.expectedExists, exists)
+			}
+			if err != nil {
+				return
+			}
+			if size != scenario.expectedSize {
+				t.Errorf("expected size to be %d, got %d", scenario.expectedSize, size)
+			}
+			if string(content) != scenario.expectedContent {
+				t.Errorf("expected content to be '%s', got '%s'", scenario.expectedContent, content)
+			}
+			if age < scenario.expectedMinimumAge {
+				t.Errorf("expected age to be at least %s, got %s", scenario.expectedMinimumAge, age)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/jlaffaye/ftp"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+var (
+	ErrSFTPWithoutUsername = errors.New("you must specify a username for SFTP endpoints")
+)
+
+// RemoteFile is the state of a file or directory on an FTP or SFTP server, which is used as body for FTP and SFTP
+// endpoints stating a file
+type RemoteFile struct {
+	Name             string    `json:"name"`
+	Size             int64     `json:"size"`
+	IsDirectory      bool      `json:"is-directory"`
+	ModificationTime time.Time `json:"modification-time"`
+	// Age is the number of seconds since the last modification of the file
+	Age int64 `json:"age"`
+}
+
+// RemoteDirectory is the content of a directory on an FTP or SFTP server, which is used as body for FTP and SFTP
+// endpoints listing a directory
+type RemoteDirectory struct {
+	Entries int `json:"entries"`
+	// NewestAge is the age of the most recently modified entry of the directory, and is omitted if it has no entries
+	NewestAge *int64       `json:"newest-age,omitempty"`
+	Files     []RemoteFile `json:"files"`
+}
+
+// QueryFTP connects to the FTP server, logs in and, if directory or file isn't empty, lists the directory or stats the
+// file. If username is empty, an anonymous login is performed.
+//
+// The address is expected to be prefixed by ftp://, e.g. ftp://ftp.example.com:21
+// Returns whether the connection was successful, and the body, which is the JSON-encoded RemoteDirectory or RemoteFile
+// if directory or file isn't empty respectively.
+func QueryFTP(address, username, password, directory, file string, config *Config) (bool, []byte, error) {
+	host, err := parseRemoteFileServerAddress(address, "ftp", "21")
+	if err != nil {
+		return false, nil, err
+	}
+	conn, err := ftp.Dial(host, ftp.DialWithTimeout(config.Timeout))
+	if err != nil {
+		return false, nil, fmt.Errorf("error connecting to ftp server: %w", err)
+	}
+	defer conn.Quit()
+	if len(username) == 0 {
+		username, password = "anonymous", "anonymous"
+	}
+	if err = conn.Login(username, password); err != nil {
+		return true, nil, fmt.Errorf("error logging in to ftp server: %w", err)
+	}
+	if len(directory) > 0 {
+		entries, err := conn.List(directory)
+		if err != nil {
+			return true, nil, fmt.Errorf("error listing ftp directory: %w", err)
+		}
+		var files []RemoteFile
+		for _, entry := range entries {
+			if entry.Name == "." || entry.Name == ".." {
+				continue
+			}
+			files = append(files, newRemoteFile(entry.Name, int64(entry.Size), entry.Type == ftp.EntryTypeFolder, entry.Time))
+		}
+		return true, encodeRemoteDirectory(files), nil
+	}
+	if len(file) > 0 {
+		size, err := conn.FileSize(file)
+		if err != nil {
+			return true, nil, fmt.Errorf("error retrieving size of ftp file: %w", err)
+		}
+		modificationTime, err := conn.GetTime(file)
+		if err != nil {
+			return true, nil, fmt.Errorf("error retrieving modification time of ftp file: %w", err)
+		}
+		body, _ := json.Marshal(newRemoteFile(path.Base(file), size, false, modificationTime))
+		return true, body, nil
+	}
+	return true, nil, nil
+}
+
+// QuerySFTP connects to the SSH server, authenticates using the password passed as parameter, opens an SFTP session
+// and, if directory or file isn't empty, lists the directory or stats the file.
+//
+// The address is expected to be prefixed by sftp://, e.g. sftp://sftp.example.com:22
+// Returns whether the connection was successful, and the body, which is the JSON-encoded RemoteDirectory or RemoteFile
+// if directory or file isn't empty respectively.
+func QuerySFTP(address, username, password, directory, file string, config *Config) (bool, []byte, error) {
+	if len(username) == 0 {
+		return false, nil, ErrSFTPWithoutUsername
+	}
+	host, err := parseRemoteFileServerAddress(address, "sftp", "22")
+	if err != nil {
+		return false, nil, err
+	}
+	sshClient, err := ssh.Dial("tcp", host, &ssh.ClientConfig{
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		User:            username,
+		Auth: []ssh.AuthMethod{
+			ssh.Password(password),
+		},
+		Timeout: config.Timeout,
+	})
+	if err != nil {
+		return false, nil, fmt.Errorf("error connecting to sftp server: %w", err)
+	}
+	defer sshClient.Close()
+	sftpClient, err := sftp.NewClient(sshClient)
+	if err != nil {
+		return true, nil, fmt.Errorf("error opening sftp session: %w", err)
+	}
+	defer sftpClient.Close()
+	return querySFTP(sftpClient, directory, file)
+}
+
+func querySFTP(sftpClient *sftp.Client, directory, file string) (bool, []byte, error) {
+	if len(directory) > 0 {
+		entries, err := sftpClient.ReadDir(directory)
+		if err != nil {
+			return true, nil, fmt.Errorf("error listing sftp directory: %w", err)
+		}
+		var files []RemoteFile
+		for _, entry := range entries {
+			files = append(files, newRemoteFile(entry.Name(), entry.Size(), entry.IsDir(), entry.ModTime()))
+		}
+		return true, encodeRemoteDirectory(files), nil
+	}
+	if len(file) > 0 {
+		info, err := sftpClient.Stat(file)
+		if err != nil {
+			return true, nil, fmt.Errorf("error retrieving sftp file: %w", err)
+		}
+		body, _ := json.Marshal(newRemoteFile(info.Name(), info.Size(), info.IsDir(), info.ModTime()))
+		return true, body, nil
+	}
+	return true, nil, nil
+}
+
+func parseRemoteFileServerAddress(address, scheme, defaultPort string) (string, error) {
+	serverURL, err := url.Parse(address)
+	if err != nil || serverURL.Scheme != scheme || len(serverURL.Hostname()) == 0 {
+		return "", fmt.Errorf("invalid address for %s, format must be %s://host[:port]", scheme, scheme)
+	}
+	port := serverURL.Port()
+	if len(port) == 0 {
+		port = defaultPort
+	}
+	return net.JoinHostPort(serverURL.Hostname(), port), nil
+}
+
+func newRemoteFile(name string, size int64, isDirectory bool, modificationTime time.Time) RemoteFile {
+	return RemoteFile{
+		Name:             name,
+		Size:             size,
+		IsDirectory:      isDirectory,
+		ModificationTime: modificationTime.UTC(),
+		Age:              int64(time.Since(modificationTime).Seconds()),
+	}
+}
+
+func encodeRemoteDirectory(files []RemoteFile) []byte {
+	directory := RemoteDirectory{Entries: len(files), Files: files}
+	if directory.Files == nil {
+		directory.Files = []RemoteFile{}
+	}
+	for _, file := range files {
+		if directory.NewestAge == nil || file.Age < *directory.NewestAge {
+			age := file.Age
+			directory.NewestAge = &age
+		}
+	}
+	body, _ := json.Marshal(directory)
+	return body
+}
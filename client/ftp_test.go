@@ -0,0 +1,99 @@
+package client
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/sftp"
+)
+
+func TestQueryFTP(t *testing.T) {
+	if connected, _, err := QueryFTP("sftp://localhost", "", "", "", "", &Config{Timeout: 500 * time.Millisecond}); connected || err == nil {
+		t.Error("expected an error due to the address being invalid")
+	}
+	if connected, _, err := QueryFTP("ftp://127.0.0.1:1", "", "", "", "", &Config{Timeout: 500 * time.Millisecond}); connected || err == nil {
+		t.Error("expected an error due to the server being unreachable")
+	}
+}
+
+func TestQuerySFTP(t *testing.T) {
+	if connected, _, err := QuerySFTP("sftp://localhost", "", "", "", "", &Config{Timeout: 500 * time.Millisecond}); connected || err != ErrSFTPWithoutUsername {
+		t.Errorf("expected %v, got %v", ErrSFTPWithoutUsername, err)
+	}
+	if connected, _, err := QuerySFTP("ftp://localhost", "gatus", "password", "", "", &Config{Timeout: 500 * time.Millisecond}); connected || err == nil {
+		t.Error("expected an error due to the address being invalid")
+	}
+	if connected, _, err := QuerySFTP("sftp://127.0.0.1:1", "gatus", "password", "", "", &Config{Timeout: 500 * time.Millisecond}); connected || err == nil {
+		t.Error("expected an error due to the server being unreachable")
+	}
+}
+
+func TestQuerySFTPWithSession(t *testing.T) {
+	directory := t.TempDir()
+	if err := os.WriteFile(filepath.Join(directory, "old.csv"), []byte("old"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(filepath.Join(directory, "old.csv"), time.Now().Add(-2*time.Hour), time.Now().Add(-2*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(directory, "new.csv"), []byte("new-file"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	serverReader, clientWriter := io.Pipe()
+	clientReader, serverWriter := io.Pipe()
+	server, err := sftp.NewServer(struct {
+		io.Reader
+		io.WriteCloser
+	}{serverReader, serverWriter})
+	if err != nil {
+		t.Fatal(err)
+	}
+	go server.Serve()
+	sftpClient, err := sftp.NewClientPipe(clientReader, clientWriter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		// The server must be closed first, as closing the client waits for its side of the pipe to be closed
+		server.Close()
+		sftpClient.Close()
+	}()
+	t.Run("list-directory", func(t *testing.T) {
+		connected, body, err := querySFTP(sftpClient, directory, "")
+		if !connected || err != nil {
+			t.Fatalf("expected listing to succeed, got connected=%v err=%v", connected, err)
+		}
+		var remoteDirectory RemoteDirectory
+		if err = json.Unmarshal(body, &remoteDirectory); err != nil {
+			t.Fatal(err)
+		}
+		if remoteDirectory.Entries != 2 || len(remoteDirectory.Files) != 2 {
+			t.Errorf("expected 2 entries, got %s", body)
+		}
+		if remoteDirectory.NewestAge == nil || *remoteDirectory.NewestAge > 60 {
+			t.Errorf("expected newest-age to be that of new.csv, got %s", body)
+		}
+	})
+	t.Run("stat-file", func(t *testing.T) {
+		connected, body, err := querySFTP(sftpClient, "", filepath.Join(directory, "old.csv"))
+		if !connected || err != nil {
+			t.Fatalf("expected stat to succeed, got connected=%v err=%v", connected, err)
+		}
+		var remoteFile RemoteFile
+		if err = json.Unmarshal(body, &remoteFile); err != nil {
+			t.Fatal(err)
+		}
+		if remoteFile.Name != "old.csv" || remoteFile.Size != 3 || remoteFile.IsDirectory || remoteFile.Age < 7190 {
+			t.Errorf("unexpected body %s", body)
+		}
+	})
+	t.Run("missing-file", func(t *testing.T) {
+		if connected, _, err := querySFTP(sftpClient, "", filepath.Join(directory, "missing.csv")); !connected || err == nil {
+			t.Errorf("expected an error due to the file not existing, got connected=%v err=%v", connected, err)
+		}
+	})
+}
@@ -0,0 +1,194 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+var (
+	ErrGRPCMethodNotFound     = errors.New("gRPC method not found")
+	ErrGRPCMethodNotUnary     = errors.New("gRPC method is not unary: streaming methods are not supported")
+	ErrGRPCReflectionResponse = errors.New("unexpected response from the gRPC reflection service")
+)
+
+// QueryGRPC invokes the unary gRPC method passed as parameter with `body` as JSON-encoded request message and returns
+// the JSON-encoded response message.
+//
+// The address is expected to be prefixed by either grpc:// or grpcs://, the latter of which uses TLS.
+// The method is expected to be in the package.Service/Method format. If descriptorSet is empty, the service is
+// resolved using server reflection, otherwise, it is resolved from the serialized FileDescriptorSet at that path.
+func QueryGRPC(address, method, descriptorSet, body string, headers map[string]string, config *Config) (bool, []byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), config.Timeout)
+	defer cancel()
+	var transportCredentials credentials.TransportCredentials
+	if strings.HasPrefix(address, "grpcs://") {
+		tlsConfig := &tls.Config{InsecureSkipVerify: config.Insecure}
+		if config.HasTlsConfig() && config.TLS.isValid() == nil {
+			tlsConfig = configureTLS(tlsConfig, *config.TLS)
+		}
+		transportCredentials = credentials.NewTLS(tlsConfig)
+	} else {
+		transportCredentials = insecure.NewCredentials()
+	}
+	dialOptions := []grpc.DialOption{grpc.WithTransportCredentials(transportCredentials), grpc.WithBlock()}
+	md := metadata.New(nil)
+	for k, v := range headers {
+		if strings.EqualFold(k, "User-Agent") {
+			dialOptions = append(dialOptions, grpc.WithUserAgent(v))
+			continue
+		}
+		md.Set(k, v)
+	}
+	target := strings.TrimPrefix(strings.TrimPrefix(address, "grpcs://"), "grpc://")
+	conn, err := grpc.DialContext(ctx, target, dialOptions...)
+	if err != nil {
+		return false, nil, fmt.Errorf("error dialing gRPC server: %w", err)
+	}
+	defer conn.Close()
+	ctx = metadata.NewOutgoingContext(ctx, md)
+	serviceName, methodName, _ := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+	var serviceDescriptor protoreflect.ServiceDescriptor
+	if len(descriptorSet) > 0 {
+		serviceDescriptor, err = resolveGRPCServiceFromDescriptorSet(descriptorSet, serviceName)
+	} else {
+		serviceDescriptor, err = resolveGRPCServiceUsingReflection(ctx, conn, serviceName)
+	}
+	if err != nil {
+		return true, nil, err
+	}
+	methodDescriptor := serviceDescriptor.Methods().ByName(protoreflect.Name(methodName))
+	if methodDescriptor == nil {
+		return true, nil, fmt.Errorf("%w: %s/%s", ErrGRPCMethodNotFound, serviceName, methodName)
+	}
+	if methodDescriptor.IsStreamingClient() || methodDescriptor.IsStreamingServer() {
+		return true, nil, ErrGRPCMethodNotUnary
+	}
+	request := dynamicpb.NewMessage(methodDescriptor.Input())
+	if len(body) > 0 {
+		if err = protojson.Unmarshal([]byte(body), request); err != nil {
+			return true, nil, fmt.Errorf("error parsing gRPC request body: %w", err)
+		}
+	}
+	response := dynamicpb.NewMessage(methodDescriptor.Output())
+	if err = conn.Invoke(ctx, "/"+serviceName+"/"+methodName, request, response); err != nil {
+		return true, nil, fmt.Errorf("error invoking gRPC method: %w", err)
+	}
+	output, err := protojson.Marshal(response)
+	if err != nil {
+		return true, nil, fmt.Errorf("error encoding gRPC response: %w", err)
+	}
+	// protojson randomly adds whitespaces to prevent users from relying on its output, so we compact it
+	compacted := new(bytes.Buffer)
+	if err = json.Compact(compacted, output); err != nil {
+		return true, output, nil
+	}
+	return true, compacted.Bytes(), nil
+}
+
+// resolveGRPCServiceFromDescriptorSet reads the serialized FileDescriptorSet at the given path and looks up the
+// service with the given fully-qualified name
+func resolveGRPCServiceFromDescriptorSet(path, serviceName string) (protoreflect.ServiceDescriptor, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading gRPC descriptor set: %w", err)
+	}
+	fileDescriptorSet := new(descriptorpb.FileDescriptorSet)
+	if err = proto.Unmarshal(data, fileDescriptorSet); err != nil {
+		return nil, fmt.Errorf("error parsing gRPC descriptor set: %w", err)
+	}
+	return findGRPCService(fileDescriptorSet, serviceName)
+}
+
+// resolveGRPCServiceUsingReflection retrieves the file descriptors of the service with the given fully-qualified
+// name, as well as all of their dependencies, from the server reflection service
+func resolveGRPCServiceUsingReflection(ctx context.Context, conn *grpc.ClientConn, serviceName string) (protoreflect.ServiceDescriptor, error) {
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error querying gRPC reflection service: %w", err)
+	}
+	defer stream.CloseSend()
+	fileDescriptorSet := new(descriptorpb.FileDescriptorSet)
+	received, requested := make(map[string]bool), make(map[string]bool)
+	pending := []*reflectionpb.ServerReflectionRequest{
+		{MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: serviceName}},
+	}
+	for len(pending) > 0 {
+		if err = stream.Send(pending[0]); err != nil {
+			return nil, fmt.Errorf("error querying gRPC reflection service: %w", err)
+		}
+		pending = pending[1:]
+		response, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, ErrGRPCReflectionResponse
+			}
+			return nil, fmt.Errorf("error querying gRPC reflection service: %w", err)
+		}
+		if errorResponse := response.GetErrorResponse(); errorResponse != nil {
+			return nil, fmt.Errorf("error querying gRPC reflection service: %s", errorResponse.GetErrorMessage())
+		}
+		fileDescriptorResponse := response.GetFileDescriptorResponse()
+		if fileDescriptorResponse == nil {
+			return nil, ErrGRPCReflectionResponse
+		}
+		for _, serializedFileDescriptor := range fileDescriptorResponse.GetFileDescriptorProto() {
+			fileDescriptor := new(descriptorpb.FileDescriptorProto)
+			if err = proto.Unmarshal(serializedFileDescriptor, fileDescriptor); err != nil {
+				return nil, fmt.Errorf("error parsing gRPC file descriptor: %w", err)
+			}
+			if received[fileDescriptor.GetName()] {
+				continue
+			}
+			received[fileDescriptor.GetName()] = true
+			fileDescriptorSet.File = append(fileDescriptorSet.File, fileDescriptor)
+		}
+		// Servers usually send all transitive dependencies along with the file, but that's not guaranteed
+		for _, fileDescriptor := range fileDescriptorSet.File {
+			for _, dependency := range fileDescriptor.GetDependency() {
+				if received[dependency] || requested[dependency] {
+					continue
+				}
+				requested[dependency] = true
+				pending = append(pending, &reflectionpb.ServerReflectionRequest{
+					MessageRequest: &reflectionpb.ServerReflectionRequest_FileByFilename{FileByFilename: dependency},
+				})
+			}
+		}
+	}
+	return findGRPCService(fileDescriptorSet, serviceName)
+}
+
+func findGRPCService(fileDescriptorSet *descriptorpb.FileDescriptorSet, serviceName string) (protoreflect.ServiceDescriptor, error) {
+	files, err := protodesc.NewFiles(fileDescriptorSet)
+	if err != nil {
+		return nil, fmt.Errorf("error building gRPC file descriptors: %w", err)
+	}
+	descriptor, err := files.FindDescriptorByName(protoreflect.FullName(serviceName))
+	if err != nil {
+		return nil, fmt.Errorf("error looking up gRPC service %s: %w", serviceName, err)
+	}
+	serviceDescriptor, ok := descriptor.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a gRPC service", serviceName)
+	}
+	return serviceDescriptor, nil
+}
@@ -0,0 +1,151 @@
+package client
+
+import (
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func startGRPCServer(t *testing.T, withReflection bool) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("failed to listen:", err)
+	}
+	server := grpc.NewServer()
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("gatus", healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+	if withReflection {
+		reflection.Register(server)
+	}
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+	return "grpc://" + listener.Addr().String()
+}
+
+func TestQueryGRPC(t *testing.T) {
+	addressWithReflection := startGRPCServer(t, true)
+	addressWithoutReflection := startGRPCServer(t, false)
+	descriptorSet := &descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{protodesc.ToFileDescriptorProto(healthpb.File_grpc_health_v1_health_proto)},
+	}
+	data, _ := proto.Marshal(descriptorSet)
+	descriptorSetPath := filepath.Join(t.TempDir(), "health.pb")
+	if err := os.WriteFile(descriptorSetPath, data, 0644); err != nil {
+		t.Fatal("failed to write descriptor set:", err)
+	}
+	scenarios := []struct {
+		name              string
+		address           string
+		method            string
+		descriptorSet     string
+		body              string
+		expectedConnected bool
+		expectedBody      string
+		expectedErr       error
+		expectErr         bool
+	}{
+		{
+			name:              "reflection",
+			address:           addressWithReflection,
+			method:            "grpc.health.v1.Health/Check",
+			expectedConnected: true,
+			expectedBody:      `{"status":"SERVING"}`,
+		},
+		{
+			name:              "reflection-with-body",
+			address:           addressWithReflection,
+			method:            "grpc.health.v1.Health/Check",
+			body:              `{"service":"gatus"}`,
+			expectedConnected: true,
+			expectedBody:      `{"status":"NOT_SERVING"}`,
+		},
+		{
+			name:              "descriptor-set",
+			address:           addressWithoutReflection,
+			method:            "grpc.health.v1.Health/Check",
+			descriptorSet:     descriptorSetPath,
+			body:              `{"service":"gatus"}`,
+			expectedConnected: true,
+			expectedBody:      `{"status":"NOT_SERVING"}`,
+		},
+		{
+			name:              "reflection-not-supported",
+			address:           addressWithoutReflection,
+			method:            "grpc.health.v1.Health/Check",
+			expectedConnected: true,
+			expectErr:         true,
+		},
+		{
+			name:              "unknown-method",
+			address:           addressWithReflection,
+			method:            "grpc.health.v1.Health/Unknown",
+			expectedConnected: true,
+			expectedErr:       ErrGRPCMethodNotFound,
+		},
+		{
+			name:              "streaming-method",
+			address:           addressWithReflection,
+			method:            "grpc.health.v1.Health/Watch",
+			expectedConnected: true,
+			expectedErr:       ErrGRPCMethodNotUnary,
+		},
+		{
+			name:              "invalid-body",
+			address:           addressWithReflection,
+			method:            "grpc.health.v1.Health/Check",
+			body:              `{"unknown":"field"}`,
+			expectedConnected: true,
+			expectErr:         true,
+		},
+		{
+			name:              "unknown-service-status",
+			address:           addressWithReflection,
+			method:            "grpc.health.v1.Health/Check",
+			body:              `{"service":"unknown"}`,
+			expectedConnected: true,
+			expectErr:         true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			connected, body, err := QueryGRPC(scenario.address, scenario.method, scenario.descriptorSet, scenario.body, map[string]string{"User-Agent": "Gatus/1.0", "X-Test": "test"}, &Config{Timeout: 5 * time.Second})
+			if connected != scenario.expectedConnected {
+				t.Errorf("expected connected to be %v, got %v", scenario.expectedConnected, connected)
+			}
+			if scenario.expectedErr != nil && !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected error to be '%v', got '%v'", scenario.expectedErr, err)
+			}
+			if scenario.expectErr && err == nil {
+				t.Error("expected an error, got none")
+			}
+			if !scenario.expectErr && scenario.expectedErr == nil && err != nil {
+				t.Errorf("expected no error, got '%v'", err)
+			}
+			if string(body) != scenario.expectedBody {
+				t.Errorf("expected body to be '%s', got '%s'", scenario.expectedBody, string(body))
+			}
+		})
+	}
+}
+
+func TestQueryGRPCWithUnreachableServer(t *testing.T) {
+	connected, _, err := QueryGRPC("grpc://127.0.0.1:1", "grpc.health.v1.Health/Check", "", "", nil, &Config{Timeout: 500 * time.Millisecond})
+	if connected {
+		t.Error("expected connected to be false")
+	}
+	if err == nil {
+		t.Error("expected an error due to the server being unreachable")
+	}
+}
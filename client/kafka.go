@@ -0,0 +1,153 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+)
+
+var (
+	ErrKafkaTopicNotFound         = errors.New("kafka topic not found")
+	ErrKafkaUnsupportedSASL       = errors.New("unsupported kafka SASL mechanism")
+	ErrKafkaCanaryMessageNotFound = errors.New("kafka canary message was not consumed back")
+	ErrKafkaNoBootstrapBroker     = errors.New("no kafka bootstrap broker specified")
+)
+
+// KafkaTopicMetadata is the metadata of a Kafka topic, which is used as body for Kafka endpoints not performing a
+// produce and consume round trip
+type KafkaTopicMetadata struct {
+	Topic                     string `json:"topic"`
+	Partitions                int    `json:"partitions"`
+	UnderReplicatedPartitions int    `json:"under-replicated-partitions"`
+	OfflinePartitions         int    `json:"offline-partitions"`
+}
+
+// QueryKafka connects to the Kafka brokers and either retrieves the metadata of the topic, or produces a canary
+// message to the partition of the topic and consumes it back if roundTrip is true.
+//
+// The address is expected to be prefixed by either kafka:// or kafkas://, the latter of which uses TLS, and may
+// contain multiple comma-separated bootstrap brokers, e.g. kafka://broker-1:9092,broker-2:9092
+// Returns whether the connection was successful, the body and the duration. If roundTrip is true, the body is the
+// value of the consumed message and the duration is the end-to-end latency; otherwise, the body is the JSON-encoded
+// KafkaTopicMetadata.
+func QueryKafka(address, topic string, partition int, roundTrip bool, saslMechanism, username, password string, config *Config) (bool, []byte, time.Duration, error) {
+	startTime := time.Now()
+	ctx, cancel := context.WithTimeout(context.Background(), config.Timeout)
+	defer cancel()
+	dialer := &kafka.Dialer{Timeout: config.Timeout, DualStack: true}
+	if strings.HasPrefix(address, "kafkas://") {
+		dialer.TLS = &tls.Config{InsecureSkipVerify: config.Insecure}
+		if config.HasTlsConfig() && config.TLS.isValid() == nil {
+			dialer.TLS = configureTLS(dialer.TLS, *config.TLS)
+		}
+	}
+	if len(saslMechanism) > 0 {
+		mechanism, err := newKafkaSASLMechanism(saslMechanism, username, password)
+		if err != nil {
+			return false, nil, 0, err
+		}
+		dialer.SASLMechanism = mechanism
+	}
+	var brokers []string
+	for _, broker := range strings.Split(strings.TrimPrefix(strings.TrimPrefix(address, "kafkas://"), "kafka://"), ",") {
+		if broker = strings.TrimSpace(broker); len(broker) > 0 {
+			brokers = append(brokers, broker)
+		}
+	}
+	if len(brokers) == 0 {
+		return false, nil, 0, ErrKafkaNoBootstrapBroker
+	}
+	// Try each bootstrap broker until one of them can be connected to
+	var conn *kafka.Conn
+	var err error
+	for _, broker := range brokers {
+		if conn, err = dialer.DialContext(ctx, "tcp", broker); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return false, nil, 0, fmt.Errorf("error connecting to kafka broker: %w", err)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	if !roundTrip {
+		partitions, err := conn.ReadPartitions(topic)
+		if err != nil {
+			return true, nil, 0, fmt.Errorf("error reading kafka topic metadata: %w", err)
+		}
+		metadata := KafkaTopicMetadata{Topic: topic}
+		for _, p := range partitions {
+			if p.Topic != topic {
+				continue
+			}
+			metadata.Partitions++
+			if p.Leader.ID < 0 || len(p.Leader.Host) == 0 {
+				metadata.OfflinePartitions++
+			}
+			if len(p.Isr) < len(p.Replicas) {
+				metadata.UnderReplicatedPartitions++
+			}
+		}
+		duration := time.Since(startTime)
+		if metadata.Partitions == 0 {
+			return true, nil, duration, fmt.Errorf("%w: %s", ErrKafkaTopicNotFound, topic)
+		}
+		body, _ := json.Marshal(metadata)
+		return true, body, duration, nil
+	}
+	// Perform the round trip on a connection to the leader of the partition
+	leader, err := dialer.DialLeader(ctx, "tcp", conn.RemoteAddr().String(), topic, partition)
+	if err != nil {
+		return true, nil, 0, fmt.Errorf("error connecting to kafka partition leader: %w", err)
+	}
+	defer leader.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = leader.SetDeadline(deadline)
+	}
+	canary := []byte("gatus-" + uuid.NewString())
+	startTime = time.Now()
+	_, _, offset, _, err := leader.WriteCompressedMessagesAt(nil, kafka.Message{Value: canary})
+	if err != nil {
+		return true, nil, 0, fmt.Errorf("error producing kafka canary message: %w", err)
+	}
+	if _, err = leader.Seek(offset, kafka.SeekAbsolute); err != nil {
+		return true, nil, 0, fmt.Errorf("error seeking kafka canary message: %w", err)
+	}
+	for {
+		message, err := leader.ReadMessage(10e6)
+		if err != nil {
+			return true, nil, 0, fmt.Errorf("error consuming kafka canary message: %w", err)
+		}
+		if bytes.Equal(message.Value, canary) {
+			return true, message.Value, time.Since(startTime), nil
+		}
+		if message.Offset > offset {
+			return true, nil, 0, ErrKafkaCanaryMessageNotFound
+		}
+	}
+}
+
+func newKafkaSASLMechanism(mechanism, username, password string) (sasl.Mechanism, error) {
+	switch strings.ToUpper(mechanism) {
+	case "PLAIN":
+		return plain.Mechanism{Username: username, Password: password}, nil
+	case "SCRAM-SHA-256":
+		return scram.Mechanism(scram.SHA256, username, password)
+	case "SCRAM-SHA-512":
+		return scram.Mechanism(scram.SHA512, username, password)
+	}
+	return nil, fmt.Errorf("%w: %s", ErrKafkaUnsupportedSASL, mechanism)
+}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestQueryKafka(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		address       string
+		saslMechanism string
+		expectedErr   error
+	}{
+		{
+			name:        "no-broker",
+			address:     "kafka://",
+			expectedErr: ErrKafkaNoBootstrapBroker,
+		},
+		{
+			name:          "unsupported-sasl-mechanism",
+			address:       "kafka://127.0.0.1:9092",
+			saslMechanism: "GSSAPI",
+			expectedErr:   ErrKafkaUnsupportedSASL,
+		},
+		{
+			name:    "unreachable-brokers",
+			address: "kafka://127.0.0.1:1,127.0.0.1:2",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			connected, _, _, err := QueryKafka(scenario.address, "gatus", 0, false, scenario.saslMechanism, "username", "password", &Config{Timeout: 500 * time.Millisecond})
+			if connected {
+				t.Error("expected connected to be false")
+			}
+			if err == nil {
+				t.Fatal("expected an error, got none")
+			}
+			if scenario.expectedErr != nil && !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected error to be '%v', got '%v'", scenario.expectedErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// QueryLDAP connects to the LDAP server, optionally upgrades the connection using StartTLS, and performs a simple bind
+// or, if bindDN is empty, an anonymous bind. If baseDN isn't empty, a search using the filter passed as parameter is
+// then performed from the base DN.
+//
+// The address is expected to be prefixed by either ldap:// or ldaps://, the latter of which uses TLS.
+// Returns whether the connection was successful, the certificate of the server if TLS was used, and the number of
+// entries found by the search as body.
+func QueryLDAP(address, bindDN, password string, startTLS bool, baseDN, filter string, config *Config) (bool, *x509.Certificate, []byte, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: config.Insecure}
+	if config.HasTlsConfig() && config.TLS.isValid() == nil {
+		tlsConfig = configureTLS(tlsConfig, *config.TLS)
+	}
+	conn, err := ldap.DialURL(address, ldap.DialWithDialer(&net.Dialer{Timeout: config.Timeout}), ldap.DialWithTLSConfig(tlsConfig))
+	if err != nil {
+		return false, nil, nil, fmt.Errorf("error connecting to LDAP server: %w", err)
+	}
+	defer conn.Close()
+	conn.SetTimeout(config.Timeout)
+	if startTLS {
+		if ldapURL, err := url.Parse(address); err == nil {
+			tlsConfig.ServerName = ldapURL.Hostname()
+		}
+		if err = conn.StartTLS(tlsConfig); err != nil {
+			return true, nil, nil, fmt.Errorf("error upgrading LDAP connection using StartTLS: %w", err)
+		}
+	}
+	var certificate *x509.Certificate
+	if state, ok := conn.TLSConnectionState(); ok && len(state.PeerCertificates) > 0 {
+		certificate = state.PeerCertificates[0]
+	}
+	if len(bindDN) == 0 {
+		err = conn.UnauthenticatedBind("")
+	} else {
+		err = conn.Bind(bindDN, password)
+	}
+	if err != nil {
+		return true, certificate, nil, fmt.Errorf("error binding to LDAP server: %w", err)
+	}
+	if len(baseDN) == 0 {
+		return true, certificate, nil, nil
+	}
+	searchRequest := ldap.NewSearchRequest(baseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, int(config.Timeout.Seconds()), false, filter, []string{"dn"}, nil)
+	searchResult, err := conn.Search(searchRequest)
+	if err != nil {
+		return true, certificate, nil, fmt.Errorf("error searching LDAP server: %w", err)
+	}
+	return true, certificate, []byte(strconv.Itoa(len(searchResult.Entries))), nil
+}
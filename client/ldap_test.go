@@ -0,0 +1,15 @@
+package client
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQueryLDAP(t *testing.T) {
+	if connected, _, _, err := QueryLDAP("invalid://localhost", "", "", false, "", "", &Config{Timeout: 500 * time.Millisecond}); connected || err == nil {
+		t.Error("expected an error due to the address being invalid")
+	}
+	if connected, _, _, err := QueryLDAP("ldap://127.0.0.1:1", "", "", false, "", "", &Config{Timeout: 500 * time.Millisecond}); connected || err == nil {
+		t.Error("expected an error due to the server being unreachable")
+	}
+}
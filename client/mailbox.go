@@ -0,0 +1,195 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/textproto"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var (
+	ErrIMAPCommandFailed      = errors.New("imap command failed")
+	ErrPOP3CommandFailed      = errors.New("pop3 command failed")
+	ErrMailboxLoginWithoutTLS = errors.New("refusing to log in over an unencrypted connection, use TLS or enable start-tls")
+)
+
+// QueryIMAP connects to the IMAP server and reads its greeting, optionally followed by a STARTTLS and by a LOGIN using
+// the credentials passed as parameter.
+//
+// The address is expected to be prefixed by either imap:// or imaps://, the latter of which uses implicit TLS. If no
+// port is specified, 143 is used for imap:// and 993 for imaps://.
+// Returns whether the connection was successful, and the certificate of the server if TLS was used.
+func QueryIMAP(address string, startTLS bool, username, password string, config *Config) (bool, *x509.Certificate, error) {
+	conn, tlsConfig, err := dialMailServer(address, "imap", "143", "993", config)
+	if err != nil {
+		return false, nil, err
+	}
+	defer func() { conn.Close() }()
+	text := textproto.NewConn(conn)
+	greeting, err := text.ReadLine()
+	if err != nil {
+		return false, nil, fmt.Errorf("error reading imap greeting: %w", err)
+	}
+	if !strings.HasPrefix(greeting, "* OK") && !strings.HasPrefix(greeting, "* PREAUTH") {
+		return false, nil, fmt.Errorf("%w: unexpected greeting: %s", ErrIMAPCommandFailed, greeting)
+	}
+	if _, isTLS := conn.(*tls.Conn); startTLS && !isTLS {
+		if err = executeIMAPCommand(text, "a1", "STARTTLS"); err != nil {
+			return true, nil, err
+		}
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err = tlsConn.Handshake(); err != nil {
+			return true, nil, fmt.Errorf("error upgrading imap connection using STARTTLS: %w", err)
+		}
+		conn, text = tlsConn, textproto.NewConn(tlsConn)
+	}
+	certificate := mailServerCertificate(conn)
+	if len(username) > 0 {
+		if certificate == nil && !isLocalhost(tlsConfig.ServerName) {
+			return true, nil, ErrMailboxLoginWithoutTLS
+		}
+		if err = executeIMAPCommand(text, "a2", "LOGIN "+quoteIMAPString(username)+" "+quoteIMAPString(password)); err != nil {
+			return true, certificate, err
+		}
+	}
+	_ = executeIMAPCommand(text, "a3", "LOGOUT")
+	return true, certificate, nil
+}
+
+// QueryPOP3 connects to the POP3 server and reads its greeting, optionally followed by a STLS and by a USER/PASS login
+// using the credentials passed as parameter.
+//
+// The address is expected to be prefixed by either pop3:// or pop3s://, the latter of which uses implicit TLS. If no
+// port is specified, 110 is used for pop3:// and 995 for pop3s://.
+// Returns whether the connection was successful, and the certificate of the server if TLS was used.
+func QueryPOP3(address string, startTLS bool, username, password string, config *Config) (bool, *x509.Certificate, error) {
+	conn, tlsConfig, err := dialMailServer(address, "pop3", "110", "995", config)
+	if err != nil {
+		return false, nil, err
+	}
+	defer func() { conn.Close() }()
+	text := textproto.NewConn(conn)
+	greeting, err := text.ReadLine()
+	if err != nil {
+		return false, nil, fmt.Errorf("error reading pop3 greeting: %w", err)
+	}
+	if !strings.HasPrefix(greeting, "+OK") {
+		return false, nil, fmt.Errorf("%w: unexpected greeting: %s", ErrPOP3CommandFailed, greeting)
+	}
+	if _, isTLS := conn.(*tls.Conn); startTLS && !isTLS {
+		if err = executePOP3Command(text, "STLS"); err != nil {
+			return true, nil, err
+		}
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err = tlsConn.Handshake(); err != nil {
+			return true, nil, fmt.Errorf("error upgrading pop3 connection using STLS: %w", err)
+		}
+		conn, text = tlsConn, textproto.NewConn(tlsConn)
+	}
+	certificate := mailServerCertificate(conn)
+	if len(username) > 0 {
+		if certificate == nil && !isLocalhost(tlsConfig.ServerName) {
+			return true, nil, ErrMailboxLoginWithoutTLS
+		}
+		if err = executePOP3Command(text, "USER "+username); err != nil {
+			return true, certificate, err
+		}
+		if err = executePOP3Command(text, "PASS "+password); err != nil {
+			return true, certificate, err
+		}
+	}
+	_ = executePOP3Command(text, "QUIT")
+	return true, certificate, nil
+}
+
+// dialMailServer connects to an address in the format <scheme>://host[:port] or <scheme>s://host[:port], the latter of
+// which uses implicit TLS, and returns the connection along with the TLS configuration to use for the server.
+func dialMailServer(address, scheme, defaultPort, defaultImplicitTLSPort string, config *Config) (net.Conn, *tls.Config, error) {
+	mailURL, err := url.Parse(address)
+	if err != nil || (mailURL.Scheme != scheme && mailURL.Scheme != scheme+"s") || len(mailURL.Hostname()) == 0 {
+		return nil, nil, fmt.Errorf("invalid address for %s, format must be %s://host[:port] or %ss://host[:port]", scheme, scheme, scheme)
+	}
+	host, port := mailURL.Hostname(), mailURL.Port()
+	implicitTLS := mailURL.Scheme == scheme+"s"
+	if len(port) == 0 {
+		if implicitTLS {
+			port = defaultImplicitTLSPort
+		} else {
+			port = defaultPort
+		}
+	}
+	tlsConfig := &tls.Config{InsecureSkipVerify: config.Insecure, ServerName: host}
+	if config.HasTlsConfig() && config.TLS.isValid() == nil {
+		tlsConfig = configureTLS(tlsConfig, *config.TLS)
+	}
+	dialer := &net.Dialer{Timeout: config.Timeout}
+	var conn net.Conn
+	if implicitTLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", net.JoinHostPort(host, port), tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", net.JoinHostPort(host, port))
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("error connecting to %s server: %w", scheme, err)
+	}
+	if config.Timeout > 0 {
+		_ = conn.SetDeadline(time.Now().Add(config.Timeout))
+	}
+	return conn, tlsConfig, nil
+}
+
+// mailServerCertificate returns the certificate of the server if the connection uses TLS, or nil otherwise
+func mailServerCertificate(conn net.Conn) *x509.Certificate {
+	if tlsConn, ok := conn.(*tls.Conn); ok {
+		if state := tlsConn.ConnectionState(); len(state.PeerCertificates) > 0 {
+			return state.PeerCertificates[0]
+		}
+	}
+	return nil
+}
+
+func isLocalhost(host string) bool {
+	return host == "localhost" || host == "127.0.0.1" || host == "::1"
+}
+
+func executeIMAPCommand(text *textproto.Conn, tag, command string) error {
+	if err := text.PrintfLine("%s %s", tag, command); err != nil {
+		return fmt.Errorf("error sending imap command: %w", err)
+	}
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return fmt.Errorf("error reading imap response: %w", err)
+		}
+		// Untagged responses are ignored, as only the status of the command matters
+		if status, found := strings.CutPrefix(line, tag+" "); found {
+			if strings.HasPrefix(strings.ToUpper(status), "OK") {
+				return nil
+			}
+			return fmt.Errorf("%w: %s", ErrIMAPCommandFailed, status)
+		}
+	}
+}
+
+func executePOP3Command(text *textproto.Conn, command string) error {
+	if err := text.PrintfLine("%s", command); err != nil {
+		return fmt.Errorf("error sending pop3 command: %w", err)
+	}
+	line, err := text.ReadLine()
+	if err != nil {
+		return fmt.Errorf("error reading pop3 response: %w", err)
+	}
+	if !strings.HasPrefix(line, "+OK") {
+		return fmt.Errorf("%w: %s", ErrPOP3CommandFailed, line)
+	}
+	return nil
+}
+
+func quoteIMAPString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
@@ -0,0 +1,133 @@
+package client
+
+import (
+	"errors"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQueryIMAP(t *testing.T) {
+	address := startFakeMailServer(t, "imap", serveFakeIMAP)
+	cfg := &Config{Timeout: 5 * time.Second}
+	if connected, certificate, err := QueryIMAP(address, false, "", "", cfg); !connected || certificate != nil || err != nil {
+		t.Errorf("expected greeting to succeed, got connected=%v certificate=%v err=%v", connected, certificate, err)
+	}
+	if connected, _, err := QueryIMAP(address, false, "gatus", `pass"word`, cfg); !connected || err != nil {
+		t.Errorf("expected login to succeed, got connected=%v err=%v", connected, err)
+	}
+	if connected, _, err := QueryIMAP(address, false, "gatus", "wrong-password", cfg); !connected || !errors.Is(err, ErrIMAPCommandFailed) {
+		t.Errorf("expected %v, got connected=%v err=%v", ErrIMAPCommandFailed, connected, err)
+	}
+	if connected, _, err := QueryIMAP(address, true, "", "", cfg); !connected || !errors.Is(err, ErrIMAPCommandFailed) {
+		t.Errorf("expected %v due to STARTTLS not being supported, got connected=%v err=%v", ErrIMAPCommandFailed, connected, err)
+	}
+	if connected, _, err := QueryIMAP(strings.Replace(address, "imap://", "pop3://", 1), false, "", "", cfg); connected || err == nil {
+		t.Error("expected an error due to the address being invalid")
+	}
+	if connected, _, err := QueryIMAP("imap://127.0.0.1:1", false, "", "", &Config{Timeout: 500 * time.Millisecond}); connected || err == nil {
+		t.Error("expected an error due to the server being unreachable")
+	}
+}
+
+func TestQueryPOP3(t *testing.T) {
+	address := startFakeMailServer(t, "pop3", serveFakePOP3)
+	cfg := &Config{Timeout: 5 * time.Second}
+	if connected, certificate, err := QueryPOP3(address, false, "", "", cfg); !connected || certificate != nil || err != nil {
+		t.Errorf("expected greeting to succeed, got connected=%v certificate=%v err=%v", connected, certificate, err)
+	}
+	if connected, _, err := QueryPOP3(address, false, "gatus", "password", cfg); !connected || err != nil {
+		t.Errorf("expected login to succeed, got connected=%v err=%v", connected, err)
+	}
+	if connected, _, err := QueryPOP3(address, false, "gatus", "wrong-password", cfg); !connected || !errors.Is(err, ErrPOP3CommandFailed) {
+		t.Errorf("expected %v, got connected=%v err=%v", ErrPOP3CommandFailed, connected, err)
+	}
+	if connected, _, err := QueryPOP3(address, true, "", "", cfg); !connected || !errors.Is(err, ErrPOP3CommandFailed) {
+		t.Errorf("expected %v due to STLS not being supported, got connected=%v err=%v", ErrPOP3CommandFailed, connected, err)
+	}
+	if connected, _, err := QueryPOP3("pop3://127.0.0.1:1", false, "", "", &Config{Timeout: 500 * time.Millisecond}); connected || err == nil {
+		t.Error("expected an error due to the server being unreachable")
+	}
+}
+
+func startFakeMailServer(t *testing.T, scheme string, serve func(*textproto.Conn)) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("failed to start listener:", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				serve(textproto.NewConn(conn))
+			}()
+		}
+	}()
+	return scheme + "://" + listener.Addr().String()
+}
+
+func serveFakeIMAP(text *textproto.Conn) {
+	_ = text.PrintfLine("* OK [CAPABILITY IMAP4rev1 AUTH=PLAIN] fake ready")
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		fields := strings.SplitN(line, " ", 3)
+		if len(fields) < 2 {
+			_ = text.PrintfLine("* BAD invalid command")
+			continue
+		}
+		tag, command := fields[0], strings.ToUpper(fields[1])
+		switch command {
+		case "LOGIN":
+			if fields[2] == `"gatus" "pass\"word"` {
+				_ = text.PrintfLine("* CAPABILITY IMAP4rev1")
+				_ = text.PrintfLine("%s OK LOGIN completed", tag)
+			} else {
+				_ = text.PrintfLine("%s NO [AUTHENTICATIONFAILED] invalid credentials", tag)
+			}
+		case "LOGOUT":
+			_ = text.PrintfLine("* BYE logging out")
+			_ = text.PrintfLine("%s OK LOGOUT completed", tag)
+			return
+		default:
+			_ = text.PrintfLine("%s BAD unsupported command", tag)
+		}
+	}
+}
+
+func serveFakePOP3(text *textproto.Conn) {
+	_ = text.PrintfLine("+OK fake POP3 server ready")
+	var username string
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		command, argument, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(command) {
+		case "USER":
+			username = argument
+			_ = text.PrintfLine("+OK")
+		case "PASS":
+			if username == "gatus" && argument == "password" {
+				_ = text.PrintfLine("+OK logged in")
+			} else {
+				_ = text.PrintfLine("-ERR [AUTH] invalid credentials")
+			}
+		case "QUIT":
+			_ = text.PrintfLine("+OK bye")
+			return
+		default:
+			_ = text.PrintfLine("-ERR unsupported command")
+		}
+	}
+}
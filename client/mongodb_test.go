@@ -0,0 +1,15 @@
+package client
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQueryMongoDB(t *testing.T) {
+	if connected, _, err := QueryMongoDB("invalid://localhost", &Config{Timeout: 500 * time.Millisecond}); connected || err == nil {
+		t.Error("expected an error due to the address being invalid")
+	}
+	if connected, _, err := QueryMongoDB("mongodb://127.0.0.1:1/?connect=direct", &Config{Timeout: 500 * time.Millisecond}); connected || err == nil {
+		t.Error("expected an error due to the server being unreachable")
+	}
+}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrMQTTTimeout           = errors.New("timed out waiting for the MQTT broker")
+	ErrMQTTNoMessageReceived = errors.New("no message received on the MQTT subscribe topic before the timeout")
+)
+
+// QueryMQTT connects to the MQTT broker, then publishes `body` to publishTopic and waits for a message on
+// subscribeTopic, if either of them are specified.
+//
+// The address is expected to be prefixed by either mqtt:// or mqtts://, the latter of which uses TLS.
+// Returns whether the connection was successful, the payload of the message received on subscribeTopic and the
+// round-trip duration, which is the time between the message being published and a message being received.
+func QueryMQTT(address, username, password, subscribeTopic, publishTopic, body string, qos byte, config *Config) (bool, []byte, time.Duration, error) {
+	deadline := time.Now().Add(config.Timeout)
+	brokerURL, err := url.Parse(address)
+	if err != nil {
+		return false, nil, 0, fmt.Errorf("error parsing MQTT broker address: %w", err)
+	}
+	opts := mqtt.NewClientOptions().
+		SetClientID("gatus-" + uuid.NewString()[:8]).
+		SetUsername(username).
+		SetPassword(password).
+		SetConnectTimeout(config.Timeout).
+		SetAutoReconnect(false).
+		SetConnectRetry(false)
+	if brokerURL.Scheme == "mqtts" {
+		if len(brokerURL.Port()) == 0 {
+			brokerURL.Host = net.JoinHostPort(brokerURL.Hostname(), "8883")
+		}
+		tlsConfig := &tls.Config{InsecureSkipVerify: config.Insecure}
+		if config.HasTlsConfig() && config.TLS.isValid() == nil {
+			tlsConfig = configureTLS(tlsConfig, *config.TLS)
+		}
+		opts.AddBroker("ssl://" + brokerURL.Host).SetTLSConfig(tlsConfig)
+	} else {
+		if len(brokerURL.Port()) == 0 {
+			brokerURL.Host = net.JoinHostPort(brokerURL.Hostname(), "1883")
+		}
+		opts.AddBroker("tcp://" + brokerURL.Host)
+	}
+	mqttClient := mqtt.NewClient(opts)
+	if err = waitForMQTTToken(mqttClient.Connect(), deadline); err != nil {
+		return false, nil, 0, fmt.Errorf("error connecting to MQTT broker: %w", err)
+	}
+	defer mqttClient.Disconnect(250)
+	messages := make(chan []byte, 1)
+	if len(subscribeTopic) > 0 {
+		token := mqttClient.Subscribe(subscribeTopic, qos, func(_ mqtt.Client, message mqtt.Message) {
+			select {
+			case messages <- message.Payload():
+			default:
+			}
+		})
+		if err = waitForMQTTToken(token, deadline); err != nil {
+			return true, nil, 0, fmt.Errorf("error subscribing to MQTT topic: %w", err)
+		}
+	}
+	startTime := time.Now()
+	if len(publishTopic) > 0 {
+		if err = waitForMQTTToken(mqttClient.Publish(publishTopic, qos, false, body), deadline); err != nil {
+			return true, nil, 0, fmt.Errorf("error publishing to MQTT topic: %w", err)
+		}
+	}
+	if len(subscribeTopic) == 0 {
+		return true, nil, time.Since(startTime), nil
+	}
+	select {
+	case payload := <-messages:
+		return true, payload, time.Since(startTime), nil
+	case <-time.After(time.Until(deadline)):
+		return true, nil, 0, ErrMQTTNoMessageReceived
+	}
+}
+
+func waitForMQTTToken(token mqtt.Token, deadline time.Time) error {
+	if !token.WaitTimeout(time.Until(deadline)) {
+		return ErrMQTTTimeout
+	}
+	return token.Error()
+}
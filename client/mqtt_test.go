@@ -0,0 +1,134 @@
+package client
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/eclipse/paho.mqtt.golang/packets"
+)
+
+// startMQTTBroker starts a minimal MQTT broker that only supports QoS 0 and which echoes every message published on
+// the "request" topic back on the "response" topic
+func startMQTTBroker(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("failed to listen:", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go handleMQTTConnection(conn)
+		}
+	}()
+	return "mqtt://" + listener.Addr().String()
+}
+
+func handleMQTTConnection(conn net.Conn) {
+	defer conn.Close()
+	for {
+		packet, err := packets.ReadPacket(conn)
+		if err != nil {
+			return
+		}
+		switch p := packet.(type) {
+		case *packets.ConnectPacket:
+			connack := packets.NewControlPacket(packets.Connack).(*packets.ConnackPacket)
+			if p.Username == "invalid" {
+				connack.ReturnCode = packets.ErrRefusedNotAuthorised
+			}
+			connack.Write(conn)
+		case *packets.SubscribePacket:
+			suback := packets.NewControlPacket(packets.Suback).(*packets.SubackPacket)
+			suback.MessageID = p.MessageID
+			suback.ReturnCodes = p.Qoss
+			suback.Write(conn)
+		case *packets.PublishPacket:
+			if p.TopicName == "request" {
+				response := packets.NewControlPacket(packets.Publish).(*packets.PublishPacket)
+				response.TopicName = "response"
+				response.Payload = append([]byte("echo: "), p.Payload...)
+				response.Write(conn)
+			}
+		case *packets.PingreqPacket:
+			packets.NewControlPacket(packets.Pingresp).Write(conn)
+		case *packets.DisconnectPacket:
+			return
+		}
+	}
+}
+
+func TestQueryMQTT(t *testing.T) {
+	address := startMQTTBroker(t)
+	scenarios := []struct {
+		name              string
+		username          string
+		subscribeTopic    string
+		publishTopic      string
+		expectedConnected bool
+		expectedPayload   string
+		expectedErr       error
+		expectErr         bool
+	}{
+		{
+			name:              "connect-only",
+			expectedConnected: true,
+		},
+		{
+			name:              "publish-only",
+			publishTopic:      "request",
+			expectedConnected: true,
+		},
+		{
+			name:              "round-trip",
+			subscribeTopic:    "response",
+			publishTopic:      "request",
+			expectedConnected: true,
+			expectedPayload:   "echo: ping",
+		},
+		{
+			name:              "no-message-received",
+			subscribeTopic:    "response",
+			publishTopic:      "elsewhere",
+			expectedConnected: true,
+			expectedErr:       ErrMQTTNoMessageReceived,
+		},
+		{
+			name:              "not-authorized",
+			username:          "invalid",
+			expectedConnected: false,
+			expectErr:         true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			connected, payload, _, err := QueryMQTT(address, scenario.username, "", scenario.subscribeTopic, scenario.publishTopic, "ping", 0, &Config{Timeout: time.Second})
+			if connected != scenario.expectedConnected {
+				t.Errorf("expected connected to be %v, got %v", scenario.expectedConnected, connected)
+			}
+			if scenario.expectedErr != nil && !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected error to be '%v', got '%v'", scenario.expectedErr, err)
+			}
+			if scenario.expectErr && err == nil {
+				t.Error("expected an error, got none")
+			}
+			if !scenario.expectErr && scenario.expectedErr == nil && err != nil {
+				t.Errorf("expected no error, got '%v'", err)
+			}
+			if string(payload) != scenario.expectedPayload {
+				t.Errorf("expected payload to be '%s', got '%s'", scenario.expectedPayload, string(payload))
+			}
+		})
+	}
+}
+
+func TestQueryMQTTWithInvalidAddress(t *testing.T) {
+	if connected, _, _, err := QueryMQTT("mqtt://127.0.0.1:1", "", "", "", "", "", 0, &Config{Timeout: 500 * time.Millisecond}); connected || err == nil {
+		t.Error("expected an error due to the broker being unreachable")
+	}
+}
@@ -0,0 +1,15 @@
+package client
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQueryNATS(t *testing.T) {
+	if connected, _, _, err := QueryNATS("nats://127.0.0.1:1", "health", false, "", "", "ping", &Config{Timeout: 500 * time.Millisecond}); connected || err == nil {
+		t.Error("expected an error due to the server being unreachable")
+	}
+	if connected, _, _, err := QueryNATS("natss://127.0.0.1:1", "", false, "ORDERS", "", "", &Config{Timeout: 500 * time.Millisecond}); connected || err == nil {
+		t.Error("expected an error due to the server being unreachable")
+	}
+}
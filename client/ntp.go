@@ -0,0 +1,86 @@
+package client
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+)
+
+const (
+	ntpPacketSize = 48
+	// ntpEpochOffset is the number of seconds between the NTP epoch (1900) and the Unix epoch (1970)
+	ntpEpochOffset = 2208988800
+)
+
+var (
+	ErrNTPInvalidResponse = errors.New("invalid ntp response")
+	ErrNTPKissOfDeath     = errors.New("ntp server sent a kiss-of-death packet")
+	ErrNTPUnsynchronized  = errors.New("ntp server clock is not synchronized")
+)
+
+// QueryNTP sends an SNTP request to the NTP server and computes the offset of the local clock relative to the clock of
+// the server.
+//
+// The address is expected to be prefixed by ntp://, e.g. ntp://pool.ntp.org. If no port is specified, 123 is used.
+// Returns whether the connection was successful, the offset, the stratum of the server and the round trip delay.
+// A positive offset means that the local clock is behind the clock of the server.
+func QueryNTP(address string, config *Config) (bool, time.Duration, int, time.Duration, error) {
+	ntpURL, err := url.Parse(address)
+	if err != nil || ntpURL.Scheme != "ntp" || len(ntpURL.Hostname()) == 0 {
+		return false, 0, 0, 0, errors.New("invalid address for ntp, format must be ntp://host[:port]")
+	}
+	port := ntpURL.Port()
+	if len(port) == 0 {
+		port = "123"
+	}
+	conn, err := net.DialTimeout("udp", net.JoinHostPort(ntpURL.Hostname(), port), config.Timeout)
+	if err != nil {
+		return false, 0, 0, 0, fmt.Errorf("error connecting to ntp server: %w", err)
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(config.Timeout))
+	request := make([]byte, ntpPacketSize)
+	// Leap indicator 0, version 4, mode 3 (client)
+	request[0] = 0<<6 | 4<<3 | 3
+	originTime := time.Now()
+	binary.BigEndian.PutUint64(request[40:], toNTPTimestamp(originTime))
+	if _, err = conn.Write(request); err != nil {
+		return false, 0, 0, 0, fmt.Errorf("error sending ntp request: %w", err)
+	}
+	response := make([]byte, ntpPacketSize)
+	n, err := conn.Read(response)
+	destinationTime := time.Now()
+	if err != nil {
+		return false, 0, 0, 0, fmt.Errorf("error reading ntp response: %w", err)
+	}
+	if n < ntpPacketSize || response[0]&0x7 != 4 || binary.BigEndian.Uint64(response[24:]) != binary.BigEndian.Uint64(request[40:]) {
+		return true, 0, 0, 0, ErrNTPInvalidResponse
+	}
+	stratum := int(response[1])
+	if stratum == 0 {
+		return true, 0, stratum, 0, fmt.Errorf("%w: %s", ErrNTPKissOfDeath, string(response[12:16]))
+	}
+	if response[0]>>6 == 3 {
+		return true, 0, stratum, 0, ErrNTPUnsynchronized
+	}
+	receiveTime := fromNTPTimestamp(binary.BigEndian.Uint64(response[32:]))
+	transmitTime := fromNTPTimestamp(binary.BigEndian.Uint64(response[40:]))
+	offset := (receiveTime.Sub(originTime) + transmitTime.Sub(destinationTime)) / 2
+	delay := destinationTime.Sub(originTime) - transmitTime.Sub(receiveTime)
+	return true, offset, stratum, delay, nil
+}
+
+func toNTPTimestamp(t time.Time) uint64 {
+	seconds := uint64(t.Unix() + ntpEpochOffset)
+	fraction := (uint64(t.Nanosecond()) << 32) / 1e9
+	return seconds<<32 | fraction
+}
+
+func fromNTPTimestamp(timestamp uint64) time.Time {
+	seconds := int64(timestamp>>32) - ntpEpochOffset
+	nanoseconds := int64(((timestamp & 0xffffffff) * 1e9) >> 32)
+	return time.Unix(seconds, nanoseconds)
+}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"encoding/binary"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestQueryNTP(t *testing.T) {
+	scenarios := []struct {
+		name            string
+		stratum         byte
+		leapIndicator   byte
+		expectedErr     error
+		expectedStratum int
+	}{
+		{
+			name:            "synchronized",
+			stratum:         2,
+			expectedStratum: 2,
+		},
+		{
+			name:        "kiss-of-death",
+			stratum:     0,
+			expectedErr: ErrNTPKissOfDeath,
+		},
+		{
+			name:            "unsynchronized",
+			stratum:         16,
+			leapIndicator:   3,
+			expectedErr:     ErrNTPUnsynchronized,
+			expectedStratum: 16,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			address := startFakeNTPServer(t, scenario.stratum, scenario.leapIndicator, 2*time.Second)
+			connected, offset, stratum, delay, err := QueryNTP(address, &Config{Timeout: 5 * time.Second})
+			if !connected {
+				t.Error("expected to be connected")
+			}
+			if !errors.Is(err, scenario.expectedErr) {
+				t.Fatalf("expected error to be '%v', got '%v'", scenario.expectedErr, err)
+			}
+			if stratum != scenario.expectedStratum {
+				t.Errorf("expected stratum to be %d, got %d", scenario.expectedStratum, stratum)
+			}
+			if err != nil {
+				return
+			}
+			if offset < 1900*time.Millisecond || offset > 2100*time.Millisecond {
+				t.Errorf("expected offset to be around 2s, got %s", offset)
+			}
+			if delay < 0 || delay > time.Second {
+				t.Errorf("expected delay to be small, got %s", delay)
+			}
+		})
+	}
+	if connected, _, _, _, err := QueryNTP("udp://127.0.0.1:123", &Config{Timeout: 500 * time.Millisecond}); connected || err == nil {
+		t.Error("expected an error due to the address being invalid")
+	}
+}
+
+// startFakeNTPServer starts an NTP server whose clock is ahead of the local clock by the offset passed as parameter
+func startFakeNTPServer(t *testing.T, stratum, leapIndicator byte, offset time.Duration) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("failed to start listener:", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	go func() {
+		request := make([]byte, ntpPacketSize)
+		for {
+			_, addr, err := conn.ReadFrom(request)
+			if err != nil {
+				return
+			}
+			response := make([]byte, ntpPacketSize)
+			response[0] = leapIndicator<<6 | 4<<3 | 4
+			response[1] = stratum
+			if stratum == 0 {
+				copy(response[12:16], "RATE")
+			}
+			copy(response[24:32], request[40:48])
+			binary.BigEndian.PutUint64(response[32:], toNTPTimestamp(time.Now().Add(offset)))
+			binary.BigEndian.PutUint64(response[40:], toNTPTimestamp(time.Now().Add(offset)))
+			_, _ = conn.WriteTo(response, addr)
+		}
+	}()
+	return "ntp://" + conn.LocalAddr().String()
+}
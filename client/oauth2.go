@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// supportedSigningAlgorithms are the algorithms accepted for the signature of a token whose key set isn't discovered
+// through the OpenID Connect discovery document, which would otherwise list them
+var supportedSigningAlgorithms = []string{
+	oidc.RS256, oidc.RS384, oidc.RS512,
+	oidc.ES256, oidc.ES384, oidc.ES512,
+	oidc.PS256, oidc.PS384, oidc.PS512,
+	oidc.EdDSA,
+}
+
+// OAuth2TokenRequest is the client credentials token request performed by QueryOAuth2Token, along with what is
+// needed to verify the token obtained
+type OAuth2TokenRequest struct {
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	Audience     string
+	Issuer       string
+	JWKSURL      string
+}
+
+// QueryOAuth2Token performs a token request against the token endpoint of an identity provider using the client
+// credentials flow, and verifies the signature and the expiry of the access token obtained, which must be a JWT.
+//
+// The key set used to verify the signature is retrieved from tokenRequest.JWKSURL if specified, or through the
+// OpenID Connect discovery document of tokenRequest.Issuer otherwise.
+//
+// Returns whether the token endpoint responded, the HTTP status of the token response, and the JSON-encoded claims of
+// the access token as body. If the token endpoint rejected the request, the body is the body of the error response.
+func QueryOAuth2Token(tokenURL string, tokenRequest *OAuth2TokenRequest, config *Config) (bool, int, []byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), config.Timeout)
+	defer cancel()
+	httpClient := GetHTTPClient(config)
+	clientCredentialsConfig := clientcredentials.Config{
+		ClientID:     tokenRequest.ClientID,
+		ClientSecret: tokenRequest.ClientSecret,
+		TokenURL:     tokenURL,
+		Scopes:       tokenRequest.Scopes,
+	}
+	if len(tokenRequest.Audience) > 0 {
+		clientCredentialsConfig.EndpointParams = url.Values{"audience": {tokenRequest.Audience}}
+	}
+	token, err := clientCredentialsConfig.Token(context.WithValue(ctx, oauth2.HTTPClient, httpClient))
+	if err != nil {
+		var retrieveErr *oauth2.RetrieveError
+		if errors.As(err, &retrieveErr) && retrieveErr.Response != nil {
+			return true, retrieveErr.Response.StatusCode, retrieveErr.Body, fmt.Errorf("error requesting oauth2 token: %w", err)
+		}
+		return false, 0, nil, fmt.Errorf("error requesting oauth2 token: %w", err)
+	}
+	ctx = oidc.ClientContext(ctx, httpClient)
+	verifierConfig := &oidc.Config{
+		ClientID:          tokenRequest.Audience,
+		SkipClientIDCheck: len(tokenRequest.Audience) == 0,
+		SkipIssuerCheck:   len(tokenRequest.Issuer) == 0,
+	}
+	var verifier *oidc.IDTokenVerifier
+	if len(tokenRequest.JWKSURL) > 0 {
+		verifierConfig.SupportedSigningAlgs = supportedSigningAlgorithms
+		verifier = oidc.NewVerifier(tokenRequest.Issuer, oidc.NewRemoteKeySet(ctx, tokenRequest.JWKSURL), verifierConfig)
+	} else {
+		provider, err := oidc.NewProvider(ctx, tokenRequest.Issuer)
+		if err != nil {
+			return true, http.StatusOK, nil, fmt.Errorf("error discovering oidc provider: %w", err)
+		}
+		verifier = provider.Verifier(verifierConfig)
+	}
+	verifiedToken, err := verifier.Verify(ctx, token.AccessToken)
+	if err != nil {
+		return true, http.StatusOK, nil, fmt.Errorf("error verifying oauth2 access token: %w", err)
+	}
+	var claims json.RawMessage
+	if err = verifiedToken.Claims(&claims); err != nil {
+		return true, http.StatusOK, nil, fmt.Errorf("error decoding claims of oauth2 access token: %w", err)
+	}
+	return true, http.StatusOK, claims, nil
+}
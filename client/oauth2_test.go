@@ -0,0 +1,195 @@
+package client
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQueryOAuth2Token(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scenarios := []struct {
+		name               string
+		clientSecret       string
+		audience           string
+		useJWKSURL         bool
+		signingKey         *rsa.PrivateKey
+		expiresIn          time.Duration
+		expectedConnected  bool
+		expectedStatus     int
+		expectedErr        string
+		expectedClaimScope string
+	}{
+		{
+			name:               "valid-token-using-discovery",
+			clientSecret:       "secret",
+			audience:           "https://api.example.org",
+			signingKey:         key,
+			expiresIn:          time.Hour,
+			expectedConnected:  true,
+			expectedStatus:     http.StatusOK,
+			expectedClaimScope: "read",
+		},
+		{
+			name:               "valid-token-using-jwks-url",
+			clientSecret:       "secret",
+			useJWKSURL:         true,
+			signingKey:         key,
+			expiresIn:          time.Hour,
+			expectedConnected:  true,
+			expectedStatus:     http.StatusOK,
+			expectedClaimScope: "read",
+		},
+		{
+			name:              "invalid-client-secret",
+			clientSecret:      "wrong",
+			signingKey:        key,
+			expiresIn:         time.Hour,
+			expectedConnected: true,
+			expectedStatus:    http.StatusUnauthorized,
+			expectedErr:       "error requesting oauth2 token",
+		},
+		{
+			name:              "expired-token",
+			clientSecret:      "secret",
+			signingKey:        key,
+			expiresIn:         -time.Hour,
+			expectedConnected: true,
+			expectedStatus:    http.StatusOK,
+			expectedErr:       "error verifying oauth2 access token",
+		},
+		{
+			name:              "invalid-signature",
+			clientSecret:      "secret",
+			signingKey:        otherKey,
+			expiresIn:         time.Hour,
+			expectedConnected: true,
+			expectedStatus:    http.StatusOK,
+			expectedErr:       "error verifying oauth2 access token",
+		},
+		{
+			name:              "unexpected-audience",
+			clientSecret:      "secret",
+			audience:          "https://other.example.org",
+			signingKey:        key,
+			expiresIn:         time.Hour,
+			expectedConnected: true,
+			expectedStatus:    http.StatusOK,
+			expectedErr:       "error verifying oauth2 access token",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			server := startFakeIdentityProvider(t, &key.PublicKey, scenario.signingKey, scenario.expiresIn)
+			tokenRequest := &OAuth2TokenRequest{
+				ClientID:     "gatus",
+				ClientSecret: scenario.clientSecret,
+				Scopes:       []string{"read"},
+				Audience:     scenario.audience,
+				Issuer:       server.URL,
+			}
+			if scenario.useJWKSURL {
+				tokenRequest.Issuer = ""
+				tokenRequest.JWKSURL = server.URL + "/jwks"
+			}
+			connected, status, body, err := QueryOAuth2Token(server.URL+"/token", tokenRequest, &Config{Timeout: 5 * time.Second})
+			if connected != scenario.expectedConnected {
+				t.Errorf("expected connected to be %v, got %v", scenario.expectedConnected, connected)
+			}
+			if status != scenario.expectedStatus {
+				t.Errorf("expected status to be %d, got %d", scenario.expectedStatus, status)
+			}
+			if len(scenario.expectedErr) > 0 {
+				if err == nil || !strings.HasPrefix(err.Error(), scenario.expectedErr) {
+					t.Errorf("expected error to start with '%s', got '%v'", scenario.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			var claims map[string]interface{}
+			if err = json.Unmarshal(body, &claims); err != nil {
+				t.Fatal("expected body to be the claims of the token, got", string(body))
+			}
+			if claims["scope"] != scenario.expectedClaimScope {
+				t.Errorf("expected scope claim to be %s, got %v", scenario.expectedClaimScope, claims["scope"])
+			}
+		})
+	}
+}
+
+// startFakeIdentityProvider starts an identity provider that issues tokens for the client gatus:secret, signed by
+// signingKey, and whose key set contains publicKey
+func startFakeIdentityProvider(t *testing.T, publicKey *rsa.PublicKey, signingKey *rsa.PrivateKey, expiresIn time.Duration) *httptest.Server {
+	encode := base64.RawURLEncoding.EncodeToString
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"issuer":         server.URL,
+				"token_endpoint": server.URL + "/token",
+				"jwks_uri":       server.URL + "/jwks",
+			})
+		case "/jwks":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"keys": []map[string]string{{
+					"kty": "RSA",
+					"kid": "1",
+					"alg": "RS256",
+					"use": "sig",
+					"n":   encode(publicKey.N.Bytes()),
+					"e":   encode(big.NewInt(int64(publicKey.E)).Bytes()),
+				}},
+			})
+		case "/token":
+			if clientID, clientSecret, ok := r.BasicAuth(); !ok || clientID != "gatus" || clientSecret != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"error":"invalid_client"}`))
+				return
+			}
+			header, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": "1", "typ": "JWT"})
+			payload, _ := json.Marshal(map[string]interface{}{
+				"iss":   server.URL,
+				"sub":   "gatus",
+				"aud":   "https://api.example.org",
+				"scope": r.FormValue("scope"),
+				"iat":   time.Now().Unix(),
+				"exp":   time.Now().Add(expiresIn).Unix(),
+			})
+			signingInput := encode(header) + "." + encode(payload)
+			hash := sha256.Sum256([]byte(signingInput))
+			signature, err := rsa.SignPKCS1v15(rand.Reader, signingKey, crypto.SHA256, hash[:])
+			if err != nil {
+				t.Error(err)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"access_token": signingInput + "." + encode(signature),
+				"token_type":   "Bearer",
+				"expires_in":   3600,
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+func TestQueryRedis(t *testing.T) {
+	server := miniredis.RunT(t)
+	server.Set("feature-flag", "enabled")
+	server.Lpush("queue", "job-1")
+	server.Lpush("queue", "job-2")
+	scenarios := []struct {
+		name              string
+		address           string
+		command           string
+		expectedConnected bool
+		expectedBody      string
+		expectErr         bool
+	}{
+		{
+			name:              "ping",
+			address:           "redis://" + server.Addr(),
+			command:           "PING",
+			expectedConnected: true,
+			expectedBody:      "PONG",
+		},
+		{
+			name:              "get",
+			address:           "redis://" + server.Addr() + "/0",
+			command:           "GET feature-flag",
+			expectedConnected: true,
+			expectedBody:      "enabled",
+		},
+		{
+			name:              "get-nil",
+			address:           "redis://" + server.Addr(),
+			command:           "GET unknown",
+			expectedConnected: true,
+			expectedBody:      "",
+		},
+		{
+			name:              "integer-reply",
+			address:           "redis://" + server.Addr(),
+			command:           "LLEN queue",
+			expectedConnected: true,
+			expectedBody:      "2",
+		},
+		{
+			name:              "array-reply",
+			address:           "redis://" + server.Addr(),
+			command:           "LRANGE queue 0 -1",
+			expectedConnected: true,
+			expectedBody:      `["job-2","job-1"]`,
+		},
+		{
+			name:              "quoted-arguments",
+			address:           "redis://" + server.Addr(),
+			command:           `ECHO "hello world"`,
+			expectedConnected: true,
+			expectedBody:      "hello world",
+		},
+		{
+			name:              "server-error",
+			address:           "redis://" + server.Addr(),
+			command:           "UNKNOWNCOMMAND",
+			expectedConnected: true,
+			expectErr:         true,
+		},
+		{
+			name:              "unreachable",
+			address:           "redis://127.0.0.1:1",
+			command:           "PING",
+			expectedConnected: false,
+			expectErr:         true,
+		},
+		{
+			name:              "invalid-address",
+			address:           "redis://localhost:6379/invalid-db",
+			command:           "PING",
+			expectedConnected: false,
+			expectErr:         true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			connected, body, err := QueryRedis(scenario.address, scenario.command, "", &Config{Timeout: 500 * time.Millisecond})
+			if connected != scenario.expectedConnected {
+				t.Errorf("expected connected to be %v, got %v", scenario.expectedConnected, connected)
+			}
+			if scenario.expectErr != (err != nil) {
+				t.Errorf("expected error to be %v, got '%v'", scenario.expectErr, err)
+			}
+			if string(body) != scenario.expectedBody {
+				t.Errorf("expected body to be '%s', got '%s'", scenario.expectedBody, string(body))
+			}
+		})
+	}
+}
+
+func TestQueryRedisWithSentinel(t *testing.T) {
+	if connected, _, err := QueryRedis("redis://127.0.0.1:1,127.0.0.1:2/0", "PING", "mymaster", &Config{Timeout: 500 * time.Millisecond}); connected || err == nil {
+		t.Error("expected an error due to the sentinels being unreachable")
+	}
+}
@@ -0,0 +1,193 @@
+package client
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+const (
+	// maximumRevocationResponseSize is the maximum size of an OCSP response, a CRL or an issuer certificate that will
+	// be downloaded. Some CRLs are several megabytes large, but none should come close to this.
+	maximumRevocationResponseSize = 64 << 20
+)
+
+var (
+	// ErrCertificateRevoked is the error returned when the certificate has been revoked by its issuer
+	ErrCertificateRevoked = errors.New("certificate has been revoked")
+
+	// ErrNoRevocationInformation is the error returned when the certificate has neither an OCSP responder nor a CRL
+	// distribution point, making it impossible to check whether it has been revoked
+	ErrNoRevocationInformation = errors.New("certificate has neither an OCSP responder nor a CRL distribution point")
+
+	// ErrNoIssuerCertificate is the error returned when the certificate of the issuer is neither part of the chain
+	// presented by the server nor retrievable through the authority information access extension of the certificate
+	ErrNoIssuerCertificate = errors.New("issuer certificate could not be found")
+
+	// crlCache caches revocation lists by URL until their next update, since they tend to be large
+	crlCache      = make(map[string]*x509.RevocationList)
+	crlCacheMutex sync.Mutex
+)
+
+// CheckCertificateRevocation checks whether the first certificate of the chain passed as parameter has been revoked.
+//
+// OCSP is used if the certificate specifies an OCSP responder, otherwise the CRL is downloaded from the distribution
+// points of the certificate. The second certificate of the chain, if any, is expected to be the issuer of the first.
+// If there's none, the issuer certificate is retrieved through the authority information access extension.
+//
+// Returns ErrCertificateRevoked if the certificate has been revoked, or another error if the revocation status could
+// not be determined, e.g. because the OCSP responder is unreachable.
+func CheckCertificateRevocation(chain []*x509.Certificate, config *Config) error {
+	if len(chain) == 0 {
+		return errors.New("no certificate to check the revocation status of")
+	}
+	certificate := chain[0]
+	if len(certificate.OCSPServer) == 0 && len(certificate.CRLDistributionPoints) == 0 {
+		return ErrNoRevocationInformation
+	}
+	httpClient := &http.Client{Timeout: config.Timeout}
+	var issuer *x509.Certificate
+	if len(chain) > 1 {
+		issuer = chain[1]
+	} else {
+		var err error
+		if issuer, err = fetchIssuerCertificate(httpClient, certificate); err != nil {
+			return err
+		}
+	}
+	if len(certificate.OCSPServer) > 0 {
+		return checkOCSP(httpClient, certificate, issuer)
+	}
+	return checkCRL(httpClient, certificate, issuer)
+}
+
+// checkOCSP queries the OCSP responders of the certificate until one of them provides a valid response
+func checkOCSP(httpClient *http.Client, certificate, issuer *x509.Certificate) error {
+	request, err := ocsp.CreateRequest(certificate, issuer, nil)
+	if err != nil {
+		return fmt.Errorf("error creating ocsp request: %w", err)
+	}
+	var lastErr error
+	for _, responderURL := range certificate.OCSPServer {
+		var body []byte
+		if body, lastErr = download(httpClient, http.MethodPost, responderURL, "application/ocsp-request", request); lastErr != nil {
+			lastErr = fmt.Errorf("error querying ocsp responder %s: %w", responderURL, lastErr)
+			continue
+		}
+		var response *ocsp.Response
+		if response, lastErr = ocsp.ParseResponseForCert(body, certificate, issuer); lastErr != nil {
+			lastErr = fmt.Errorf("error parsing response of ocsp responder %s: %w", responderURL, lastErr)
+			continue
+		}
+		if !response.NextUpdate.IsZero() && response.NextUpdate.Before(time.Now()) {
+			lastErr = fmt.Errorf("response of ocsp responder %s is stale since %s", responderURL, response.NextUpdate.Format(time.RFC3339))
+			continue
+		}
+		switch response.Status {
+		case ocsp.Good:
+			return nil
+		case ocsp.Revoked:
+			return fmt.Errorf("%w at %s", ErrCertificateRevoked, response.RevokedAt.Format(time.RFC3339))
+		default:
+			return fmt.Errorf("ocsp responder %s does not know the certificate", responderURL)
+		}
+	}
+	return lastErr
+}
+
+// checkCRL downloads the CRL of the certificate from its distribution points, and looks for the serial number of the
+// certificate in it
+func checkCRL(httpClient *http.Client, certificate, issuer *x509.Certificate) error {
+	var lastErr error
+	for _, distributionPoint := range certificate.CRLDistributionPoints {
+		var revocationList *x509.RevocationList
+		if revocationList, lastErr = fetchRevocationList(httpClient, distributionPoint, issuer); lastErr != nil {
+			continue
+		}
+		for _, entry := range revocationList.RevokedCertificateEntries {
+			if entry.SerialNumber.Cmp(certificate.SerialNumber) == 0 {
+				return fmt.Errorf("%w at %s", ErrCertificateRevoked, entry.RevocationTime.Format(time.RFC3339))
+			}
+		}
+		return nil
+	}
+	return lastErr
+}
+
+// fetchRevocationList retrieves the CRL at the URL passed as parameter, unless a version of it that's still up to
+// date was already retrieved, and verifies that it was signed by the issuer
+func fetchRevocationList(httpClient *http.Client, crlURL string, issuer *x509.Certificate) (*x509.RevocationList, error) {
+	crlCacheMutex.Lock()
+	revocationList, exists := crlCache[crlURL]
+	crlCacheMutex.Unlock()
+	if !exists || revocationList.NextUpdate.Before(time.Now()) {
+		body, err := download(httpClient, http.MethodGet, crlURL, "", nil)
+		if err != nil {
+			return nil, fmt.Errorf("error downloading crl %s: %w", crlURL, err)
+		}
+		if block, _ := pem.Decode(body); block != nil {
+			body = block.Bytes
+		}
+		if revocationList, err = x509.ParseRevocationList(body); err != nil {
+			return nil, fmt.Errorf("error parsing crl %s: %w", crlURL, err)
+		}
+		if !revocationList.NextUpdate.IsZero() && revocationList.NextUpdate.Before(time.Now()) {
+			return nil, fmt.Errorf("crl %s is stale since %s", crlURL, revocationList.NextUpdate.Format(time.RFC3339))
+		}
+		crlCacheMutex.Lock()
+		crlCache[crlURL] = revocationList
+		crlCacheMutex.Unlock()
+	}
+	// The signature is verified even if the list was cached, since the same URL may be used by different issuers
+	if err := revocationList.CheckSignatureFrom(issuer); err != nil {
+		return nil, fmt.Errorf("error verifying signature of crl %s: %w", crlURL, err)
+	}
+	return revocationList, nil
+}
+
+// fetchIssuerCertificate retrieves the certificate of the issuer of a certificate through the URLs in its authority
+// information access extension
+func fetchIssuerCertificate(httpClient *http.Client, certificate *x509.Certificate) (*x509.Certificate, error) {
+	for _, issuerURL := range certificate.IssuingCertificateURL {
+		body, err := download(httpClient, http.MethodGet, issuerURL, "", nil)
+		if err != nil {
+			continue
+		}
+		if block, _ := pem.Decode(body); block != nil {
+			body = block.Bytes
+		}
+		issuer, err := x509.ParseCertificate(body)
+		if err != nil || certificate.CheckSignatureFrom(issuer) != nil {
+			continue
+		}
+		return issuer, nil
+	}
+	return nil, ErrNoIssuerCertificate
+}
+
+func download(httpClient *http.Client, method, url, contentType string, body []byte) ([]byte, error) {
+	request, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if len(contentType) > 0 {
+		request.Header.Set("Content-Type", contentType)
+	}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", response.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(response.Body, maximumRevocationResponseSize))
+}
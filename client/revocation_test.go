@@ -0,0 +1,159 @@
+package client
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+func TestCheckCertificateRevocation(t *testing.T) {
+	issuerKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	issuerTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Gatus Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	issuerDER, _ := x509.CreateCertificate(rand.Reader, issuerTemplate, issuerTemplate, &issuerKey.PublicKey, issuerKey)
+	issuer, _ := x509.ParseCertificate(issuerDER)
+	revokedSerialNumber := big.NewInt(666)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ocsp", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		request, err := ocsp.ParseRequest(body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		template := ocsp.Response{Status: ocsp.Good, SerialNumber: request.SerialNumber, ThisUpdate: time.Now(), NextUpdate: time.Now().Add(time.Hour)}
+		if request.SerialNumber.Cmp(revokedSerialNumber) == 0 {
+			template.Status, template.RevokedAt, template.RevocationReason = ocsp.Revoked, time.Now().Add(-time.Minute), ocsp.KeyCompromise
+		}
+		response, _ := ocsp.CreateResponse(issuer, issuer, template, issuerKey)
+		_, _ = w.Write(response)
+	})
+	mux.HandleFunc("/crl", func(w http.ResponseWriter, r *http.Request) {
+		crl, _ := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+			Number:     big.NewInt(1),
+			ThisUpdate: time.Now(),
+			NextUpdate: time.Now().Add(time.Hour),
+			RevokedCertificateEntries: []x509.RevocationListEntry{
+				{SerialNumber: revokedSerialNumber, RevocationTime: time.Now().Add(-time.Minute)},
+			},
+		}, issuer, issuerKey)
+		_, _ = w.Write(crl)
+	})
+	mux.HandleFunc("/issuer", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(issuerDER)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	unreachableServer := httptest.NewServer(http.NotFoundHandler())
+	unreachableServer.Close()
+	newCertificate := func(serialNumber *big.Int, ocspServers, crlDistributionPoints, issuingCertificateURLs []string) *x509.Certificate {
+		key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+			SerialNumber:          serialNumber,
+			Subject:               pkix.Name{CommonName: "example.org"},
+			NotBefore:             time.Now().Add(-time.Hour),
+			NotAfter:              time.Now().Add(time.Hour),
+			OCSPServer:            ocspServers,
+			CRLDistributionPoints: crlDistributionPoints,
+			IssuingCertificateURL: issuingCertificateURLs,
+		}, issuer, &key.PublicKey, issuerKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		certificate, _ := x509.ParseCertificate(der)
+		return certificate
+	}
+	scenarios := []struct {
+		name          string
+		chain         []*x509.Certificate
+		expectedErr   error
+		expectedNoErr bool
+	}{
+		{
+			name:          "ocsp-good",
+			chain:         []*x509.Certificate{newCertificate(big.NewInt(2), []string{server.URL + "/ocsp"}, nil, nil), issuer},
+			expectedNoErr: true,
+		},
+		{
+			name:        "ocsp-revoked",
+			chain:       []*x509.Certificate{newCertificate(revokedSerialNumber, []string{server.URL + "/ocsp"}, nil, nil), issuer},
+			expectedErr: ErrCertificateRevoked,
+		},
+		{
+			name:  "ocsp-responder-unreachable",
+			chain: []*x509.Certificate{newCertificate(big.NewInt(3), []string{unreachableServer.URL + "/ocsp"}, nil, nil), issuer},
+		},
+		{
+			name:          "ocsp-with-first-responder-unreachable",
+			chain:         []*x509.Certificate{newCertificate(big.NewInt(4), []string{unreachableServer.URL + "/ocsp", server.URL + "/ocsp"}, nil, nil), issuer},
+			expectedNoErr: true,
+		},
+		{
+			name:          "crl-good",
+			chain:         []*x509.Certificate{newCertificate(big.NewInt(5), nil, []string{server.URL + "/crl"}, nil), issuer},
+			expectedNoErr: true,
+		},
+		{
+			name:        "crl-revoked",
+			chain:       []*x509.Certificate{newCertificate(revokedSerialNumber, nil, []string{server.URL + "/crl"}, nil), issuer},
+			expectedErr: ErrCertificateRevoked,
+		},
+		{
+			name:  "crl-unreachable",
+			chain: []*x509.Certificate{newCertificate(big.NewInt(6), nil, []string{unreachableServer.URL + "/crl"}, nil), issuer},
+		},
+		{
+			name:          "issuer-from-authority-information-access",
+			chain:         []*x509.Certificate{newCertificate(big.NewInt(7), []string{server.URL + "/ocsp"}, nil, []string{server.URL + "/issuer"})},
+			expectedNoErr: true,
+		},
+		{
+			name:        "no-issuer",
+			chain:       []*x509.Certificate{newCertificate(big.NewInt(8), []string{server.URL + "/ocsp"}, nil, nil)},
+			expectedErr: ErrNoIssuerCertificate,
+		},
+		{
+			name:        "no-revocation-information",
+			chain:       []*x509.Certificate{newCertificate(big.NewInt(9), nil, nil, nil), issuer},
+			expectedErr: ErrNoRevocationInformation,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			err := CheckCertificateRevocation(scenario.chain, &Config{Timeout: 5 * time.Second})
+			if scenario.expectedNoErr {
+				if err != nil {
+					t.Errorf("expected no error, got '%v'", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected an error, got none")
+			}
+			if scenario.expectedErr != nil && !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected error to be '%v', got '%v'", scenario.expectedErr, err)
+			}
+			if scenario.expectedErr == nil && errors.Is(err, ErrCertificateRevoked) {
+				t.Errorf("expected error to not be '%v' since the revocation status is unknown, got '%v'", ErrCertificateRevoked, err)
+			}
+		})
+	}
+}
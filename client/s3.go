@@ -0,0 +1,36 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+)
+
+// QueryS3Object sends a request with the given method (HEAD or GET) for an object of an S3-compatible object storage.
+//
+// The request is signed using AWS Signature Version 4, unless the credentials passed have no provider, in which case
+// the request is sent anonymously, which only works for objects that are publicly readable.
+// The caller is responsible for closing the body of the response.
+func QueryS3Object(method, objectURL, region string, creds *credentials.Credentials, config *Config) (*http.Response, error) {
+	request, err := http.NewRequest(method, objectURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating s3 request: %w", err)
+	}
+	if creds != nil {
+		_, err = v4.NewSigner(creds).Sign(request, nil, "s3", region, time.Now())
+		var awsErr awserr.Error
+		if err != nil && !(errors.As(err, &awsErr) && awsErr.Code() == "NoCredentialProviders") {
+			return nil, fmt.Errorf("error signing s3 request: %w", err)
+		}
+	}
+	response, err := GetHTTPClient(config).Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("error querying s3 object: %w", err)
+	}
+	return response, nil
+}
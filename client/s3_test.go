@@ -0,0 +1,68 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+)
+
+func TestQueryS3Object(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/backups/latest.sql.gz" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("X-Authorization", r.Header.Get("Authorization"))
+		w.Header().Set("X-Content-Sha256", r.Header.Get("X-Amz-Content-Sha256"))
+		_, _ = w.Write([]byte("backup"))
+	}))
+	defer server.Close()
+	scenarios := []struct {
+		name                  string
+		credentials           *credentials.Credentials
+		expectedAuthorization string
+	}{
+		{
+			name:                  "signed",
+			credentials:           credentials.NewStaticCredentials("AKID", "secret", ""),
+			expectedAuthorization: "/us-east-1/s3/aws4_request, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=",
+		},
+		{
+			name:                  "anonymous",
+			credentials:           credentials.NewCredentials(&credentials.ChainProvider{}),
+			expectedAuthorization: "",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			response, err := QueryS3Object(http.MethodHead, server.URL+"/backups/latest.sql.gz", "us-east-1", scenario.credentials, &Config{Timeout: 5 * time.Second})
+			if err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != http.StatusOK {
+				t.Errorf("expected status to be 200, got %d", response.StatusCode)
+			}
+			if response.ContentLength != 6 {
+				t.Errorf("expected content length to be 6, got %d", response.ContentLength)
+			}
+			authorization := response.Header.Get("X-Authorization")
+			if len(scenario.expectedAuthorization) == 0 {
+				if len(authorization) > 0 {
+					t.Errorf("expected request to be sent anonymously, got authorization %s", authorization)
+				}
+				return
+			}
+			if !strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=AKID/") || !strings.Contains(authorization, scenario.expectedAuthorization) {
+				t.Errorf("expected authorization to be signed with AKID and contain %s, got %s", scenario.expectedAuthorization, authorization)
+			}
+			if len(response.Header.Get("X-Content-Sha256")) == 0 {
+				t.Error("expected the hash of the payload to be sent")
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/smtp"
+	"strings"
+)
+
+var (
+	ErrSMTPStartTLSNotSupported = errors.New("smtp server does not support STARTTLS")
+	ErrSMTPAuthNotSupported     = errors.New("smtp server does not support AUTH PLAIN")
+)
+
+// QuerySMTP connects to the SMTP server and performs the EHLO handshake, optionally followed by a STARTTLS and by the
+// verification of the credentials passed as parameter using AUTH PLAIN. No mail is sent.
+//
+// The address is expected to be prefixed by either smtp:// or smtps://, the latter of which uses implicit TLS. If no
+// port is specified, 25 is used for smtp:// and 465 for smtps://.
+// Returns whether the connection was successful, and the certificate of the server if TLS was used.
+func QuerySMTP(address string, startTLS bool, username, password string, config *Config) (bool, *x509.Certificate, error) {
+	connection, tlsConfig, err := dialMailServer(address, "smtp", "25", "465", config)
+	if err != nil {
+		return false, nil, err
+	}
+	defer connection.Close()
+	smtpClient, err := smtp.NewClient(connection, tlsConfig.ServerName)
+	if err != nil {
+		return false, nil, fmt.Errorf("error reading smtp greeting: %w", err)
+	}
+	defer smtpClient.Close()
+	if err = smtpClient.Hello("gatus"); err != nil {
+		return true, nil, fmt.Errorf("error performing smtp handshake: %w", err)
+	}
+	if _, isTLS := connection.(*tls.Conn); startTLS && !isTLS {
+		if ok, _ := smtpClient.Extension("STARTTLS"); !ok {
+			return true, nil, ErrSMTPStartTLSNotSupported
+		}
+		if err = smtpClient.StartTLS(tlsConfig); err != nil {
+			return true, nil, fmt.Errorf("error upgrading smtp connection using STARTTLS: %w", err)
+		}
+	}
+	var certificate *x509.Certificate
+	if state, ok := smtpClient.TLSConnectionState(); ok && len(state.PeerCertificates) > 0 {
+		certificate = state.PeerCertificates[0]
+	}
+	if len(username) > 0 {
+		if ok, mechanisms := smtpClient.Extension("AUTH"); !ok || !strings.Contains(strings.ToUpper(mechanisms), "PLAIN") {
+			return true, certificate, ErrSMTPAuthNotSupported
+		}
+		if err = smtpClient.Auth(smtp.PlainAuth("", username, password, tlsConfig.ServerName)); err != nil {
+			return true, certificate, fmt.Errorf("error authenticating to smtp server: %w", err)
+		}
+	}
+	_ = smtpClient.Quit()
+	return true, certificate, nil
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"encoding/base64"
+	"errors"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQuerySMTP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("failed to start listener:", err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveFakeSMTP(conn)
+		}
+	}()
+	address := "smtp://" + listener.Addr().String()
+	cfg := &Config{Timeout: 5 * time.Second}
+	if connected, certificate, err := QuerySMTP(address, false, "", "", cfg); !connected || certificate != nil || err != nil {
+		t.Errorf("expected handshake to succeed, got connected=%v certificate=%v err=%v", connected, certificate, err)
+	}
+	if connected, _, err := QuerySMTP(address, false, "gatus", "password", cfg); !connected || err != nil {
+		t.Errorf("expected authentication to succeed, got connected=%v err=%v", connected, err)
+	}
+	if connected, _, err := QuerySMTP(address, false, "gatus", "wrong-password", cfg); !connected || err == nil {
+		t.Errorf("expected authentication to fail, got connected=%v err=%v", connected, err)
+	}
+	if connected, _, err := QuerySMTP(address, true, "", "", cfg); !connected || !errors.Is(err, ErrSMTPStartTLSNotSupported) {
+		t.Errorf("expected %v, got connected=%v err=%v", ErrSMTPStartTLSNotSupported, connected, err)
+	}
+	if connected, _, err := QuerySMTP("invalid://"+listener.Addr().String(), false, "", "", cfg); connected || err == nil {
+		t.Error("expected an error due to the address being invalid")
+	}
+	if connected, _, err := QuerySMTP("smtp://127.0.0.1:1", false, "", "", &Config{Timeout: 500 * time.Millisecond}); connected || err == nil {
+		t.Error("expected an error due to the server being unreachable")
+	}
+}
+
+func serveFakeSMTP(conn net.Conn) {
+	defer conn.Close()
+	text := textproto.NewConn(conn)
+	_ = text.PrintfLine("220 localhost ESMTP fake")
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		command, argument, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(command) {
+		case "EHLO":
+			_ = text.PrintfLine("250-localhost")
+			_ = text.PrintfLine("250 AUTH PLAIN")
+		case "AUTH":
+			credentials, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(argument, "PLAIN "))
+			if string(credentials) == "\x00gatus\x00password" {
+				_ = text.PrintfLine("235 2.7.0 Authentication successful")
+			} else {
+				_ = text.PrintfLine("535 5.7.8 Authentication credentials invalid")
+			}
+		case "QUIT":
+			_ = text.PrintfLine("221 2.0.0 Bye")
+			return
+		default:
+			_ = text.PrintfLine("502 5.5.2 Command not recognized")
+		}
+	}
+}
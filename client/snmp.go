@@ -0,0 +1,131 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+var (
+	ErrSNMPNoSuchObject = errors.New("snmp object not found")
+
+	snmpAuthProtocols = map[string]gosnmp.SnmpV3AuthProtocol{
+		"":       gosnmp.NoAuth,
+		"MD5":    gosnmp.MD5,
+		"SHA":    gosnmp.SHA,
+		"SHA224": gosnmp.SHA224,
+		"SHA256": gosnmp.SHA256,
+		"SHA384": gosnmp.SHA384,
+		"SHA512": gosnmp.SHA512,
+	}
+	snmpPrivacyProtocols = map[string]gosnmp.SnmpV3PrivProtocol{
+		"":        gosnmp.NoPriv,
+		"DES":     gosnmp.DES,
+		"AES":     gosnmp.AES,
+		"AES192":  gosnmp.AES192,
+		"AES256":  gosnmp.AES256,
+		"AES192C": gosnmp.AES192C,
+		"AES256C": gosnmp.AES256C,
+	}
+)
+
+// SNMPv3Credentials are the credentials used to query an SNMP agent using SNMPv3
+type SNMPv3Credentials struct {
+	Username        string
+	AuthProtocol    string
+	AuthPassword    string
+	PrivacyProtocol string
+	PrivacyPassword string
+}
+
+// QuerySNMP sends a GET request for the OIDs passed as parameter to the SNMP agent, using either SNMPv2c with the
+// community passed as parameter, or SNMPv3 with the credentials passed as parameter if version is 3.
+//
+// The address is expected to be prefixed by snmp://, e.g. snmp://printer.example.com. If no port is specified, 161 is
+// used.
+// Returns whether the connection was successful, and the JSON-encoded array of the values of the OIDs, in the same
+// order as the OIDs were passed.
+func QuerySNMP(address, version, community string, oids []string, credentials *SNMPv3Credentials, config *Config) (bool, []byte, error) {
+	snmpURL, err := url.Parse(address)
+	if err != nil || snmpURL.Scheme != "snmp" || len(snmpURL.Hostname()) == 0 {
+		return false, nil, errors.New("invalid address for snmp, format must be snmp://host[:port]")
+	}
+	port := uint16(161)
+	if len(snmpURL.Port()) > 0 {
+		p, err := strconv.ParseUint(snmpURL.Port(), 10, 16)
+		if err != nil {
+			return false, nil, fmt.Errorf("invalid port for snmp: %s", snmpURL.Port())
+		}
+		port = uint16(p)
+	}
+	snmpClient := &gosnmp.GoSNMP{
+		Target:    snmpURL.Hostname(),
+		Port:      port,
+		Transport: "udp",
+		Community: community,
+		Version:   gosnmp.Version2c,
+		Timeout:   config.Timeout,
+		Retries:   0,
+		MaxOids:   gosnmp.MaxOids,
+	}
+	if version == "3" {
+		if credentials == nil {
+			return false, nil, errors.New("snmpv3 credentials must be specified")
+		}
+		msgFlags := gosnmp.NoAuthNoPriv
+		if len(credentials.PrivacyProtocol) > 0 {
+			msgFlags = gosnmp.AuthPriv
+		} else if len(credentials.AuthProtocol) > 0 {
+			msgFlags = gosnmp.AuthNoPriv
+		}
+		snmpClient.Version = gosnmp.Version3
+		snmpClient.SecurityModel = gosnmp.UserSecurityModel
+		snmpClient.MsgFlags = msgFlags
+		snmpClient.SecurityParameters = &gosnmp.UsmSecurityParameters{
+			UserName:                 credentials.Username,
+			AuthenticationProtocol:   snmpAuthProtocols[credentials.AuthProtocol],
+			AuthenticationPassphrase: credentials.AuthPassword,
+			PrivacyProtocol:          snmpPrivacyProtocols[credentials.PrivacyProtocol],
+			PrivacyPassphrase:        credentials.PrivacyPassword,
+		}
+	}
+	if err = snmpClient.Connect(); err != nil {
+		return false, nil, fmt.Errorf("error connecting to snmp agent: %w", err)
+	}
+	defer snmpClient.Conn.Close()
+	packet, err := snmpClient.Get(oids)
+	if err != nil {
+		// As SNMP uses UDP, failing to get a response is the only way to know that the agent is unreachable
+		return false, nil, fmt.Errorf("error querying snmp agent: %w", err)
+	}
+	if packet.Error != gosnmp.NoError {
+		return true, nil, fmt.Errorf("error querying snmp agent: %s", packet.Error)
+	}
+	values := make([]interface{}, 0, len(packet.Variables))
+	for _, variable := range packet.Variables {
+		value, err := snmpValue(variable)
+		if err != nil {
+			return true, nil, err
+		}
+		values = append(values, value)
+	}
+	body, _ := json.Marshal(values)
+	return true, body, nil
+}
+
+func snmpValue(variable gosnmp.SnmpPDU) (interface{}, error) {
+	switch variable.Type {
+	case gosnmp.NoSuchObject, gosnmp.NoSuchInstance, gosnmp.EndOfMibView, gosnmp.Null:
+		return nil, fmt.Errorf("%w: %s", ErrSNMPNoSuchObject, variable.Name)
+	case gosnmp.OctetString:
+		return string(variable.Value.([]byte)), nil
+	case gosnmp.Integer, gosnmp.Counter32, gosnmp.Gauge32, gosnmp.TimeTicks, gosnmp.Counter64, gosnmp.Uinteger32:
+		return gosnmp.ToBigInt(variable.Value), nil
+	default:
+		return fmt.Sprint(variable.Value), nil
+	}
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+func TestQuerySNMP(t *testing.T) {
+	address := startFakeSNMPAgent(t, "public", map[string]gosnmp.SnmpPDU{
+		".1.3.6.1.2.1.1.5.0":         {Type: gosnmp.OctetString, Value: []byte("printer-1")},
+		".1.3.6.1.2.1.1.3.0":         {Type: gosnmp.TimeTicks, Value: uint32(123456)},
+		".1.3.6.1.2.1.33.1.2.4.0":    {Type: gosnmp.Integer, Value: 87},
+		".1.3.6.1.2.1.2.2.1.10.1":    {Type: gosnmp.Counter32, Value: uint(42)},
+		".1.3.6.1.2.1.4.20.1.1.10.0": {Type: gosnmp.IPAddress, Value: "10.0.0.1"},
+	})
+	cfg := &Config{Timeout: 2 * time.Second}
+	connected, body, err := QuerySNMP(address, "2c", "public", []string{"1.3.6.1.2.1.1.5.0", ".1.3.6.1.2.1.1.3.0", "1.3.6.1.2.1.33.1.2.4.0", "1.3.6.1.2.1.2.2.1.10.1", "1.3.6.1.2.1.4.20.1.1.10.0"}, nil, cfg)
+	if !connected || err != nil {
+		t.Fatalf("expected query to succeed, got connected=%v err=%v", connected, err)
+	}
+	if expected := `["printer-1",123456,87,42,"10.0.0.1"]`; string(body) != expected {
+		t.Errorf("expected body to be %s, got %s", expected, body)
+	}
+	if connected, _, err := QuerySNMP(address, "2c", "public", []string{"1.3.6.1.2.1.1.1.0"}, nil, cfg); !connected || !errors.Is(err, ErrSNMPNoSuchObject) {
+		t.Errorf("expected %v, got connected=%v err=%v", ErrSNMPNoSuchObject, connected, err)
+	}
+	// Agents silently drop requests with an invalid community
+	if connected, _, err := QuerySNMP(address, "2c", "private", []string{"1.3.6.1.2.1.1.5.0"}, nil, &Config{Timeout: 200 * time.Millisecond}); connected || err == nil {
+		t.Errorf("expected an error due to the community being invalid, got connected=%v err=%v", connected, err)
+	}
+	if connected, _, err := QuerySNMP("udp://127.0.0.1", "2c", "public", []string{"1.3.6.1.2.1.1.5.0"}, nil, cfg); connected || err == nil {
+		t.Error("expected an error due to the address being invalid")
+	}
+}
+
+func startFakeSNMPAgent(t *testing.T, community string, objects map[string]gosnmp.SnmpPDU) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("failed to start listener:", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	go func() {
+		buffer := make([]byte, 65535)
+		decoder := &gosnmp.GoSNMP{Version: gosnmp.Version2c, Logger: gosnmp.NewLogger(nil)}
+		for {
+			n, addr, err := conn.ReadFrom(buffer)
+			if err != nil {
+				return
+			}
+			request, err := decoder.SnmpDecodePacket(buffer[:n])
+			if err != nil || request.Community != community {
+				continue
+			}
+			response := &gosnmp.SnmpPacket{
+				Version:   request.Version,
+				Community: request.Community,
+				PDUType:   gosnmp.GetResponse,
+				RequestID: request.RequestID,
+			}
+			for _, variable := range request.Variables {
+				object, exists := objects[variable.Name]
+				if !exists {
+					object = gosnmp.SnmpPDU{Type: gosnmp.NoSuchObject}
+				}
+				object.Name = variable.Name
+				response.Variables = append(response.Variables, object)
+			}
+			output, err := response.MarshalMsg()
+			if err != nil {
+				continue
+			}
+			_, _ = conn.WriteTo(output, addr)
+		}
+	}()
+	return "snmp://" + conn.LocalAddr().String()
+}
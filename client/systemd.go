@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	systemdBusName    = "org.freedesktop.systemd1"
+	systemdObjectPath = "/org/freedesktop/systemd1"
+	systemdInterface  = "org.freedesktop.systemd1"
+)
+
+var (
+	// systemdUnitProperties are the properties of the org.freedesktop.systemd1.Unit interface included in the body
+	systemdUnitProperties = []string{"Id", "Description", "LoadState", "ActiveState", "SubState", "UnitFileState", "ActiveEnterTimestamp", "StateChangeTimestamp"}
+
+	// systemdServiceProperties are the properties of the org.freedesktop.systemd1.Service interface included in the
+	// body of service units
+	systemdServiceProperties = []string{"MainPID", "NRestarts", "Result"}
+)
+
+// QuerySystemdUnit retrieves the state of a unit from systemd through the system D-Bus.
+//
+// The address is expected to be prefixed by systemd://, e.g. systemd://nginx.service. If the name of the unit has no
+// type suffix, .service is assumed. Returns whether the state of the unit could be retrieved, and a JSON object made
+// up of the ActiveState, SubState, LoadState and a few other properties of the unit as body.
+// Note that a unit that doesn't exist can still be retrieved, but with a LoadState of not-found.
+func QuerySystemdUnit(address string, config *Config) (bool, []byte, error) {
+	unit := strings.TrimPrefix(address, "systemd://")
+	if len(unit) == 0 || strings.Contains(unit, "/") {
+		return false, nil, errors.New("invalid address for systemd, format must be systemd://unit")
+	}
+	if !strings.Contains(unit, ".") {
+		unit += ".service"
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), config.Timeout)
+	defer cancel()
+	conn, err := dbus.ConnectSystemBus(dbus.WithContext(ctx))
+	if err != nil {
+		return false, nil, fmt.Errorf("error connecting to system bus: %w", err)
+	}
+	defer conn.Close()
+	var unitPath dbus.ObjectPath
+	if err = conn.Object(systemdBusName, systemdObjectPath).CallWithContext(ctx, systemdInterface+".Manager.LoadUnit", 0, unit).Store(&unitPath); err != nil {
+		return false, nil, fmt.Errorf("error loading systemd unit %s: %w", unit, err)
+	}
+	unitObject := conn.Object(systemdBusName, unitPath)
+	body := make(map[string]interface{})
+	if err = getSystemdProperties(ctx, unitObject, systemdInterface+".Unit", systemdUnitProperties, body); err != nil {
+		return false, nil, err
+	}
+	// Only services have a main process and may have been restarted
+	if strings.HasSuffix(unit, ".service") && body["LoadState"] == "loaded" {
+		if err = getSystemdProperties(ctx, unitObject, systemdInterface+".Service", systemdServiceProperties, body); err != nil {
+			return false, nil, err
+		}
+	}
+	encodedBody, err := json.Marshal(body)
+	if err != nil {
+		return false, nil, fmt.Errorf("error encoding properties of systemd unit %s: %w", unit, err)
+	}
+	return true, encodedBody, nil
+}
+
+// getSystemdProperties retrieves the properties of an interface of a systemd object, and copies those with the names
+// passed as parameter into the destination
+func getSystemdProperties(ctx context.Context, object dbus.BusObject, iface string, names []string, destination map[string]interface{}) error {
+	var properties map[string]dbus.Variant
+	if err := object.CallWithContext(ctx, "org.freedesktop.DBus.Properties.GetAll", 0, iface).Store(&properties); err != nil {
+		return fmt.Errorf("error retrieving properties of %s: %w", iface, err)
+	}
+	for _, name := range names {
+		if property, exists := properties[name]; exists {
+			destination[name] = property.Value()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,248 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestQuerySystemdUnit(t *testing.T) {
+	startFakeSystemd(t, map[string]map[string]map[string]dbus.Variant{
+		"nginx.service": {
+			"org.freedesktop.systemd1.Unit": {
+				"Id":          dbus.MakeVariant("nginx.service"),
+				"Description": dbus.MakeVariant("A high performance web server"),
+				"LoadState":   dbus.MakeVariant("loaded"),
+				"ActiveState": dbus.MakeVariant("active"),
+				"SubState":    dbus.MakeVariant("running"),
+				"Names":       dbus.MakeVariant([]string{"nginx.service"}),
+			},
+			"org.freedesktop.systemd1.Service": {
+				"MainPID":   dbus.MakeVariant(uint32(1234)),
+				"NRestarts": dbus.MakeVariant(uint32(2)),
+				"Result":    dbus.MakeVariant("success"),
+			},
+		},
+		"backup.timer": {
+			"org.freedesktop.systemd1.Unit": {
+				"Id":          dbus.MakeVariant("backup.timer"),
+				"LoadState":   dbus.MakeVariant("loaded"),
+				"ActiveState": dbus.MakeVariant("active"),
+				"SubState":    dbus.MakeVariant("waiting"),
+			},
+		},
+	})
+	scenarios := []struct {
+		name         string
+		address      string
+		expectedBody map[string]interface{}
+		expectedErr  bool
+	}{
+		{
+			name:    "service",
+			address: "systemd://nginx.service",
+			expectedBody: map[string]interface{}{
+				"Id":          "nginx.service",
+				"Description": "A high performance web server",
+				"LoadState":   "loaded",
+				"ActiveState": "active",
+				"SubState":    "running",
+				"MainPID":     float64(1234),
+				"NRestarts":   float64(2),
+				"Result":      "success",
+			},
+		},
+		{
+			name:    "service-without-suffix",
+			address: "systemd://nginx",
+			expectedBody: map[string]interface{}{
+				"Id":          "nginx.service",
+				"Description": "A high performance web server",
+				"LoadState":   "loaded",
+				"ActiveState": "active",
+				"SubState":    "running",
+				"MainPID":     float64(1234),
+				"NRestarts":   float64(2),
+				"Result":      "success",
+			},
+		},
+		{
+			name:    "timer",
+			address: "systemd://backup.timer",
+			expectedBody: map[string]interface{}{
+				"Id":          "backup.timer",
+				"LoadState":   "loaded",
+				"ActiveState": "active",
+				"SubState":    "waiting",
+			},
+		},
+		{
+			name:    "not-found",
+			address: "systemd://missing.service",
+			expectedBody: map[string]interface{}{
+				"Id":          "missing.service",
+				"LoadState":   "not-found",
+				"ActiveState": "inactive",
+				"SubState":    "dead",
+			},
+		},
+		{
+			name:        "no-unit",
+			address:     "systemd://",
+			expectedErr: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			connected, body, err := QuerySystemdUnit(scenario.address, &Config{Timeout: 5 * time.Second})
+			if scenario.expectedErr {
+				if err == nil {
+					t.Error("expected an error, got none")
+				}
+				if connected {
+					t.Error("expected connected to be false")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got '%v'", err)
+			}
+			if !connected {
+				t.Error("expected connected to be true")
+			}
+			var actualBody map[string]interface{}
+			if err = json.Unmarshal(body, &actualBody); err != nil {
+				t.Fatalf("expected body to be valid JSON, got '%v'", err)
+			}
+			if len(actualBody) != len(scenario.expectedBody) {
+				t.Errorf("expected body to be %v, got %s", scenario.expectedBody, body)
+			}
+			for key, expectedValue := range scenario.expectedBody {
+				if actualBody[key] != expectedValue {
+					t.Errorf("expected %s to be %v, got %v", key, expectedValue, actualBody[key])
+				}
+			}
+		})
+	}
+}
+
+func TestQuerySystemdUnitWithoutSystemBus(t *testing.T) {
+	t.Setenv("DBUS_SYSTEM_BUS_ADDRESS", "unix:path="+filepath.Join(t.TempDir(), "missing"))
+	connected, _, err := QuerySystemdUnit("systemd://nginx.service", &Config{Timeout: 5 * time.Second})
+	if err == nil {
+		t.Error("expected an error, got none")
+	}
+	if connected {
+		t.Error("expected connected to be false")
+	}
+}
+
+// startFakeSystemd starts a fake system bus on which systemd exposes the units passed as parameter, each of which
+// being a map of interfaces to properties, and points DBUS_SYSTEM_BUS_ADDRESS to it
+func startFakeSystemd(t *testing.T, units map[string]map[string]map[string]dbus.Variant) {
+	socketPath := filepath.Join(t.TempDir(), "system_bus_socket")
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	t.Setenv("DBUS_SYSTEM_BUS_ADDRESS", "unix:path="+socketPath)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveFakeSystemd(conn, units)
+		}
+	}()
+}
+
+func serveFakeSystemd(conn net.Conn, units map[string]map[string]map[string]dbus.Variant) {
+	defer conn.Close()
+	// Unit names are escaped in the path of the object of the unit
+	escaper, unescaper := strings.NewReplacer(".", "_2e", "-", "_2d"), strings.NewReplacer("_2e", ".", "_2d", "-")
+	reader := bufio.NewReader(conn)
+	if _, err := reader.ReadByte(); err != nil {
+		return
+	}
+	// SASL handshake, which accepts the EXTERNAL mechanism and declines the negotiation of unix file descriptors
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		switch command := strings.TrimSpace(line); {
+		case command == "AUTH":
+			conn.Write([]byte("REJECTED EXTERNAL\r\n"))
+		case strings.HasPrefix(command, "AUTH EXTERNAL"):
+			conn.Write([]byte("OK 0123456789abcdef0123456789abcdef\r\n"))
+		case command == "NEGOTIATE_UNIX_FD":
+			conn.Write([]byte("ERROR\r\n"))
+		case command == "BEGIN":
+			goto authenticated
+		}
+	}
+authenticated:
+	var serial uint32
+	for {
+		call, err := dbus.DecodeMessage(reader)
+		if err != nil {
+			return
+		}
+		member, _ := call.Headers[dbus.FieldMember].Value().(string)
+		path, _ := call.Headers[dbus.FieldPath].Value().(dbus.ObjectPath)
+		var reply []interface{}
+		switch member {
+		case "Hello":
+			reply = []interface{}{":1.1"}
+		case "LoadUnit":
+			reply = []interface{}{dbus.ObjectPath("/org/freedesktop/systemd1/unit/" + escaper.Replace(call.Body[0].(string)))}
+		case "GetAll":
+			unit := unescaper.Replace(strings.TrimPrefix(string(path), "/org/freedesktop/systemd1/unit/"))
+			properties, exists := units[unit][call.Body[0].(string)]
+			if !exists {
+				properties = map[string]dbus.Variant{}
+			}
+			reply = []interface{}{properties}
+			if _, exists = units[unit]; !exists && call.Body[0] == "org.freedesktop.systemd1.Unit" {
+				// systemd returns the properties of units that don't exist, but with a LoadState of not-found
+				reply = []interface{}{map[string]dbus.Variant{
+					"Id":          dbus.MakeVariant(unit),
+					"LoadState":   dbus.MakeVariant("not-found"),
+					"ActiveState": dbus.MakeVariant("inactive"),
+					"SubState":    dbus.MakeVariant("dead"),
+				}}
+			}
+		}
+		message := &dbus.Message{
+			Type: dbus.TypeMethodReply,
+			Headers: map[dbus.HeaderField]dbus.Variant{
+				dbus.FieldReplySerial: dbus.MakeVariant(call.Serial()),
+				dbus.FieldSender:      dbus.MakeVariant("org.freedesktop.systemd1"),
+				dbus.FieldSignature:   dbus.MakeVariant(dbus.SignatureOf(reply...)),
+			},
+			Body: reply,
+		}
+		var buffer bytes.Buffer
+		if err = message.EncodeTo(&buffer, binary.LittleEndian); err != nil {
+			return
+		}
+		// The serial of a message can't be set outside of godbus, so it's written directly in the fixed-length part
+		// of the header
+		serial++
+		encodedMessage := buffer.Bytes()
+		binary.LittleEndian.PutUint32(encodedMessage[8:12], serial)
+		if _, err = conn.Write(encodedMessage); err != nil {
+			return
+		}
+	}
+}
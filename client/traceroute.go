@@ -0,0 +1,174 @@
+package client
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"runtime"
+	"time"
+
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+)
+
+const (
+	icmpv4ProtocolNumber = 1
+	icmpv6ProtocolNumber = 58
+)
+
+var (
+	// ErrTracerouteDestinationUnreachable is the error returned when a hop reports that the destination is unreachable
+	ErrTracerouteDestinationUnreachable = errors.New("destination unreachable")
+)
+
+// TracerouteHop is a hop on the path to the destination of a traceroute, which is used as body for traceroute
+// endpoints
+type TracerouteHop struct {
+	TTL int `json:"ttl"`
+	// Address is the address of the hop, or empty if the hop didn't reply in time
+	Address string `json:"address,omitempty"`
+	// RTT is the round-trip time to the hop, in milliseconds
+	RTT int64 `json:"rtt"`
+}
+
+// Traceroute sends ICMP echo requests with an increasing TTL to an address until either the address replies, a hop
+// reports that the address is unreachable, or maxHops is reached.
+//
+// Returns whether the address was reached, the number of hops probed, and the JSON-encoded list of TracerouteHop as
+// body. Much like Ping, this requires Gatus to run with sudo privileges on every GOOS except darwin.
+func Traceroute(address string, maxHops int, hopTimeout time.Duration, config *Config) (bool, int, []byte, error) {
+	destination, err := net.ResolveIPAddr(config.Network, address)
+	if err != nil {
+		return false, 0, nil, fmt.Errorf("error resolving traceroute destination: %w", err)
+	}
+	isIPv4 := destination.IP.To4() != nil
+	// See Ping as to why the privileged mode is used for every GOOS except darwin
+	privileged := runtime.GOOS != "darwin"
+	network, listenAddress, protocol := "udp6", "::", icmpv6ProtocolNumber
+	if isIPv4 {
+		network, listenAddress, protocol = "udp4", "0.0.0.0", icmpv4ProtocolNumber
+	}
+	if privileged {
+		if isIPv4 {
+			network = "ip4:icmp"
+		} else {
+			network = "ip6:ipv6-icmp"
+		}
+	}
+	conn, err := icmp.ListenPacket(network, listenAddress)
+	if err != nil {
+		return false, 0, nil, fmt.Errorf("error listening for ICMP packets: %w", err)
+	}
+	defer conn.Close()
+	var target net.Addr = destination
+	if !privileged {
+		target = &net.UDPAddr{IP: destination.IP, Zone: destination.Zone}
+	}
+	id := rand.Intn(0xffff)
+	hops := make([]TracerouteHop, 0, maxHops)
+	buffer := make([]byte, 1500)
+	for ttl := 1; ttl <= maxHops; ttl++ {
+		message := icmp.Message{Body: &icmp.Echo{ID: id, Seq: ttl, Data: []byte("gatus")}}
+		if isIPv4 {
+			message.Type = ipv4.ICMPTypeEcho
+			err = conn.IPv4PacketConn().SetTTL(ttl)
+		} else {
+			message.Type = ipv6.ICMPTypeEchoRequest
+			err = conn.IPv6PacketConn().SetHopLimit(ttl)
+		}
+		if err != nil {
+			return false, len(hops), nil, fmt.Errorf("error setting TTL of ICMP packet: %w", err)
+		}
+		packet, err := message.Marshal(nil)
+		if err != nil {
+			return false, len(hops), nil, fmt.Errorf("error encoding ICMP packet: %w", err)
+		}
+		startTime := time.Now()
+		if _, err = conn.WriteTo(packet, target); err != nil {
+			return false, len(hops), nil, fmt.Errorf("error sending ICMP packet: %w", err)
+		}
+		_ = conn.SetReadDeadline(startTime.Add(hopTimeout))
+		hop := TracerouteHop{TTL: ttl}
+		for {
+			n, peer, err := conn.ReadFrom(buffer)
+			if err != nil {
+				// The hop didn't reply in time, which is common for routers that don't send ICMP time exceeded messages
+				break
+			}
+			reply, err := icmp.ParseMessage(protocol, buffer[:n])
+			if err != nil {
+				continue
+			}
+			// When not privileged, the ID of the echo request is replaced by the kernel, so only the sequence is checked
+			matches := func(replyID, replySeq int) bool {
+				return replySeq == ttl && (!privileged || replyID == id)
+			}
+			var reached, unreachable bool
+			switch body := reply.Body.(type) {
+			case *icmp.Echo:
+				if (reply.Type != ipv4.ICMPTypeEchoReply && reply.Type != ipv6.ICMPTypeEchoReply) || !matches(body.ID, body.Seq) {
+					continue
+				}
+				reached = true
+			case *icmp.TimeExceeded:
+				if originalID, originalSeq, ok := parseOriginalEcho(body.Data, isIPv4); !ok || !matches(originalID, originalSeq) {
+					continue
+				}
+			case *icmp.DstUnreach:
+				if originalID, originalSeq, ok := parseOriginalEcho(body.Data, isIPv4); !ok || !matches(originalID, originalSeq) {
+					continue
+				}
+				unreachable = true
+			default:
+				continue
+			}
+			hop.RTT = time.Since(startTime).Milliseconds()
+			hop.Address = peerIP(peer)
+			hops = append(hops, hop)
+			if reached || unreachable {
+				body, _ := json.Marshal(hops)
+				if unreachable {
+					return false, len(hops), body, fmt.Errorf("error reaching %s from %s: %w", destination.IP, hop.Address, ErrTracerouteDestinationUnreachable)
+				}
+				return true, len(hops), body, nil
+			}
+			break
+		}
+		if len(hops) < ttl {
+			hops = append(hops, hop)
+		}
+	}
+	body, _ := json.Marshal(hops)
+	return false, len(hops), body, nil
+}
+
+// parseOriginalEcho extracts the ID and the sequence of the echo request contained in an ICMP error message, which
+// starts with the IP header of the original packet followed by at least the first 8 bytes of its payload
+func parseOriginalEcho(data []byte, isIPv4 bool) (int, int, bool) {
+	headerLength := ipv6.HeaderLen
+	if isIPv4 {
+		if len(data) < ipv4.HeaderLen {
+			return 0, 0, false
+		}
+		headerLength = int(data[0]&0x0f) * 4
+	}
+	if len(data) < headerLength+8 {
+		return 0, 0, false
+	}
+	echo := data[headerLength:]
+	return int(binary.BigEndian.Uint16(echo[4:6])), int(binary.BigEndian.Uint16(echo[6:8])), true
+}
+
+func peerIP(peer net.Addr) string {
+	switch addr := peer.(type) {
+	case *net.IPAddr:
+		return addr.IP.String()
+	case *net.UDPAddr:
+		return addr.IP.String()
+	}
+	return peer.String()
+}
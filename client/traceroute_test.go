@@ -0,0 +1,40 @@
+package client
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTraceroute(t *testing.T) {
+	t.Parallel()
+	for _, address := range []string{"127.0.0.1", "::1"} {
+		reached, hopCount, body, err := Traceroute(address, 5, 500*time.Millisecond, &Config{Timeout: 500 * time.Millisecond, Network: "ip"})
+		if err != nil {
+			t.Fatalf("expected no error for %s, got %v", address, err)
+		}
+		if !reached {
+			t.Errorf("expected %s to be reached", address)
+		}
+		if hopCount != 1 {
+			t.Errorf("expected %s to be 1 hop away, got %d", address, hopCount)
+		}
+		var hops []TracerouteHop
+		if err = json.Unmarshal(body, &hops); err != nil {
+			t.Fatalf("expected body to be a list of hops, got %v", err)
+		}
+		if len(hops) != 1 || hops[0].TTL != 1 || hops[0].Address != address {
+			t.Errorf("expected a single hop with address %s, got %v", address, hops)
+		}
+	}
+}
+
+func TestTraceroute_withInvalidAddress(t *testing.T) {
+	t.Parallel()
+	if reached, _, _, err := Traceroute("127.0.0.1", 5, 500*time.Millisecond, &Config{Timeout: 500 * time.Millisecond, Network: "ip6"}); reached || err == nil {
+		t.Error("expected an error, because the IP isn't an IPv6 address")
+	}
+	if reached, _, _, err := Traceroute("invalid.invalid", 5, 500*time.Millisecond, &Config{Timeout: 500 * time.Millisecond, Network: "ip"}); reached || err == nil {
+		t.Error("expected an error, because the address can't be resolved")
+	}
+}
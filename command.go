@@ -0,0 +1,185 @@
+package main
+
+import (
+	"compress/gzip"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/TwiN/gatus/v5/storage"
+	"github.com/TwiN/gatus/v5/storage/backup"
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+var (
+	errMemoryStorageCannotBeImported = errors.New("cannot import into the memory storage, because it is not persistent unless storage.path is set")
+	errMigrationRequiresPaths        = errors.New("both --from and --to must be specified")
+)
+
+// runCommand runs the command with the name and the arguments passed as parameter
+//
+// Supported commands:
+//   - export [--output <path>]: writes a backup of the storage to the path, or to the standard output if the path is
+//     "-" or blank. The backup is compressed with gzip if the path ends with ".gz".
+//   - import [--input <path>]: replaces the data of the storage by the data of the backup read from the path, or from
+//     the standard input if the path is "-" or blank.
+//   - migrate --from <path> --to <path>: copies the data of a storage to another. The type of each storage is
+//     inferred from its path, unless specified with --from-type and --to-type. Encrypted sqlite storages require
+//     --from-encryption-key or --to-encryption-key.
+func runCommand(name string, args []string) error {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	switch name {
+	case "export":
+		output := flags.String("output", "-", "path of the backup to write, compressed with gzip if it ends with .gz")
+		if err := flags.Parse(args); err != nil {
+			return err
+		}
+		return exportBackup(*output)
+	case "import":
+		input := flags.String("input", "-", "path of the backup to read, decompressed with gzip if it ends with .gz")
+		if err := flags.Parse(args); err != nil {
+			return err
+		}
+		if flags.NArg() > 0 && *input == "-" {
+			*input = flags.Arg(0)
+		}
+		return importBackup(*input)
+	case "migrate":
+		from := flags.String("from", "", "path of the storage to copy the data from")
+		fromType := flags.String("from-type", "", "type of the storage to copy the data from, inferred from its path if blank")
+		to := flags.String("to", "", "path of the storage to copy the data to")
+		fromEncryptionKey := flags.String("from-encryption-key", "", "key the sqlite storage to copy the data from is encrypted with, if any")
+		toType := flags.String("to-type", "", "type of the storage to copy the data to, inferred from its path if blank")
+		toEncryptionKey := flags.String("to-encryption-key", "", "key to encrypt the sqlite storage to copy the data to with, if any")
+		if err := flags.Parse(args); err != nil {
+			return err
+		}
+		return migrateStorage(
+			&storage.Config{Type: storage.Type(*fromType), Path: *from, EncryptionKey: *fromEncryptionKey},
+			&storage.Config{Type: storage.Type(*toType), Path: *to, EncryptionKey: *toEncryptionKey},
+		)
+	default:
+		return fmt.Errorf("unknown command %q, supported commands: export, import, migrate", name)
+	}
+}
+
+func exportBackup(path string) error {
+	cfg, err := loadConfiguration()
+	if err != nil {
+		return err
+	}
+	if err = store.Initialize(cfg.Storage); err != nil {
+		return err
+	}
+	defer store.Get().Close()
+	if len(path) == 0 || path == "-" {
+		return backup.Export(store.Get(), os.Stdout)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if strings.HasSuffix(path, ".gz") {
+		gzipWriter := gzip.NewWriter(file)
+		if err = backup.Export(store.Get(), gzipWriter); err != nil {
+			return err
+		}
+		// Closing the writer flushes the compressed data, so its error must not be ignored
+		err = gzipWriter.Close()
+	} else {
+		err = backup.Export(store.Get(), file)
+	}
+	if err != nil {
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	log.Printf("[main.exportBackup] Exported storage to %s", path)
+	return nil
+}
+
+func importBackup(path string) error {
+	cfg, err := loadConfiguration()
+	if err != nil {
+		return err
+	}
+	if cfg.Storage == nil || (cfg.Storage.Type == storage.TypeMemory && len(cfg.Storage.Path) == 0) {
+		return errMemoryStorageCannotBeImported
+	}
+	if err = store.Initialize(cfg.Storage); err != nil {
+		return err
+	}
+	defer store.Get().Close()
+	var r io.Reader = os.Stdin
+	if len(path) > 0 && path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		r = file
+		if strings.HasSuffix(path, ".gz") {
+			gzipReader, err := gzip.NewReader(file)
+			if err != nil {
+				return err
+			}
+			defer gzipReader.Close()
+			r = gzipReader
+		}
+	}
+	numberOfEndpointsImported, err := backup.Import(store.Get(), r)
+	if err != nil {
+		return err
+	}
+	log.Printf("[main.importBackup] Imported %d endpoints from %s", numberOfEndpointsImported, path)
+	return store.Get().Save()
+}
+
+func migrateStorage(from, to *storage.Config) error {
+	if len(from.Path) == 0 || len(to.Path) == 0 {
+		return errMigrationRequiresPaths
+	}
+	for _, cfg := range []*storage.Config{from, to} {
+		if len(cfg.Type) == 0 {
+			cfg.Type = inferStorageType(cfg.Path)
+		}
+		if err := cfg.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+	}
+	source, err := store.New(from)
+	if err != nil {
+		return fmt.Errorf("error opening storage to migrate from: %w", err)
+	}
+	defer source.Close()
+	destination, err := store.New(to)
+	if err != nil {
+		return fmt.Errorf("error opening storage to migrate to: %w", err)
+	}
+	defer destination.Close()
+	numberOfEndpointsMigrated, err := backup.Copy(source, destination)
+	if err != nil {
+		return err
+	}
+	log.Printf("[main.migrateStorage] Migrated %d endpoints from storage of type=%s to storage of type=%s", numberOfEndpointsMigrated, from.Type, to.Type)
+	return nil
+}
+
+// inferStorageType returns the type of the storage whose path is passed as parameter, which is postgres for
+// postgres:// and postgresql:// URLs, clickhouse for http:// and https:// URLs, and sqlite otherwise
+func inferStorageType(path string) storage.Type {
+	switch {
+	case strings.HasPrefix(path, "postgres://"), strings.HasPrefix(path, "postgresql://"):
+		return storage.TypePostgres
+	case strings.HasPrefix(path, "http://"), strings.HasPrefix(path, "https://"):
+		return storage.TypeClickHouse
+	default:
+		return storage.TypeSQLite
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -15,13 +16,20 @@ import (
 	"github.com/TwiN/gatus/v5/alerting/alert"
 	"github.com/TwiN/gatus/v5/alerting/provider"
 	"github.com/TwiN/gatus/v5/config/connectivity"
+	"github.com/TwiN/gatus/v5/config/discovery"
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/group"
+	"github.com/TwiN/gatus/v5/config/grouppage"
 	"github.com/TwiN/gatus/v5/config/maintenance"
 	"github.com/TwiN/gatus/v5/config/remote"
+	"github.com/TwiN/gatus/v5/config/secret"
+	"github.com/TwiN/gatus/v5/config/tenant"
 	"github.com/TwiN/gatus/v5/config/ui"
 	"github.com/TwiN/gatus/v5/config/web"
+	"github.com/TwiN/gatus/v5/lifecycle"
 	"github.com/TwiN/gatus/v5/security"
 	"github.com/TwiN/gatus/v5/storage"
+	"github.com/TwiN/gatus/v5/subscription"
 	"gopkg.in/yaml.v3"
 )
 
@@ -42,9 +50,23 @@ var (
 	// ErrConfigFileNotFound is an error returned when a configuration file could not be found
 	ErrConfigFileNotFound = errors.New("configuration file not found")
 
+	// ErrUnresolvableSecret is an error returned when a secret referenced by the configuration couldn't be resolved
+	ErrUnresolvableSecret = errors.New("unable to resolve secret referenced by the configuration")
+
+	// ErrMissingEnvironmentVariable is an error returned when an environment variable required by the configuration,
+	// e.g. ${DOMAIN:?must be set}, is unset or empty
+	ErrMissingEnvironmentVariable = errors.New("missing environment variable required by the configuration")
+
+	// ErrInvalidDiscoveryConfig is an error returned when the discovery configuration is invalid
+	ErrInvalidDiscoveryConfig = errors.New("invalid discovery configuration")
+
 	// ErrInvalidSecurityConfig is an error returned when the security configuration is invalid
 	ErrInvalidSecurityConfig = errors.New("invalid security configuration")
 
+	// ErrMetricsClientCertificatesWithoutTLS is an error returned when the certificates of the clients of the metrics
+	// must be verified, but TLS isn't configured
+	ErrMetricsClientCertificatesWithoutTLS = errors.New("invalid metrics security configuration: client-certificate-authority-file requires web.tls to be configured")
+
 	// errEarlyReturn is returned to break out of a loop from a callback early
 	errEarlyReturn = errors.New("early escape")
 )
@@ -57,6 +79,10 @@ type Config struct {
 	// Metrics Whether to expose metrics at /metrics
 	Metrics bool `yaml:"metrics,omitempty"`
 
+	// MetricsSecurity is the configuration for securing access to the metrics, independently of Security (optional).
+	// If nil, the metrics can be accessed by anyone.
+	MetricsSecurity *security.MetricsConfig `yaml:"metrics-security,omitempty"`
+
 	// SkipInvalidConfigUpdate Whether to make the application ignore invalid configuration
 	// if the configuration file is updated while the application is running
 	SkipInvalidConfigUpdate bool `yaml:"skip-invalid-config-update,omitempty"`
@@ -78,6 +104,10 @@ type Config struct {
 	// ExternalEndpoints is the list of all external endpoints
 	ExternalEndpoints []*endpoint.ExternalEndpoint `yaml:"external-endpoints,omitempty"`
 
+	// Discovery is the configuration of the discovery of endpoints to monitor at runtime, in addition to Endpoints,
+	// e.g. from the annotations of the services of Kubernetes
+	Discovery *discovery.Config `yaml:"discovery,omitempty"`
+
 	// Storage is the configuration for how the data is stored
 	Storage *storage.Config `yaml:"storage,omitempty"`
 
@@ -97,8 +127,30 @@ type Config struct {
 	// Connectivity is the configuration for connectivity
 	Connectivity *connectivity.Config `yaml:"connectivity,omitempty"`
 
-	configPath      string    // path to the file or directory from which config was loaded
-	lastFileModTime time.Time // last modification time
+	// Subscriptions is the configuration for visitors subscribing to the changes of state of the endpoints. If nil,
+	// visitors cannot subscribe.
+	Subscriptions *subscription.Config `yaml:"subscriptions,omitempty"`
+
+	// Groups is the metadata of the groups of endpoints, such as their logo, their description and their order
+	Groups []*group.Group `yaml:"groups,omitempty"`
+
+	// GroupPages is the list of dashboards that only show the endpoints of a group
+	GroupPages []*grouppage.GroupPage `yaml:"group-pages,omitempty"`
+
+	// Tenants is the list of tenants the endpoints can be assigned to, each of which has its own dashboard and API
+	Tenants []*tenant.Tenant `yaml:"tenants,omitempty"`
+
+	// Webhooks is the list of webhooks the lifecycle events, such as endpoints being added or checks being skipped,
+	// are sent to
+	Webhooks []*lifecycle.Webhook `yaml:"webhooks,omitempty"`
+
+	configPath               string       // path to the file or directory from which config was loaded
+	lastFileModTime          time.Time    // last modification time
+	includes                 *includes    // files included by the configuration files, if any
+	loadedAt                 time.Time    // time at which the config was loaded
+	alertingProviders        []alert.Type // alerting providers whose configuration is valid
+	ignoredAlertingProviders []alert.Type // alerting providers ignored because their configuration is invalid
+	reloadRequests           chan *Config // configurations that were requested to replace this one
 }
 
 func (config *Config) GetEndpointByKey(key string) *endpoint.Endpoint {
@@ -121,10 +173,33 @@ func (config *Config) GetExternalEndpointByKey(key string) *endpoint.ExternalEnd
 	return nil
 }
 
+// GetGroupPageByGroup returns the page of the group passed as parameter, or nil if the group has no page
+func (config *Config) GetGroupPageByGroup(group string) *grouppage.GroupPage {
+	for _, page := range config.GroupPages {
+		if page.Group == group {
+			return page
+		}
+	}
+	return nil
+}
+
+// GetTenantByName returns the tenant whose name is passed as parameter, or nil if there is no such tenant
+func (config *Config) GetTenantByName(name string) *tenant.Tenant {
+	for _, t := range config.Tenants {
+		if t.Name == name {
+			return t
+		}
+	}
+	return nil
+}
+
 // HasLoadedConfigurationBeenModified returns whether one of the file that the
 // configuration has been loaded from has been modified since it was last read
 func (config *Config) HasLoadedConfigurationBeenModified() bool {
 	lastMod := config.lastFileModTime.Unix()
+	if config.includes != nil && config.includes.haveBeenModifiedSince(lastMod) {
+		return true
+	}
 	fileInfo, err := os.Stat(config.configPath)
 	if err != nil {
 		return false
@@ -141,11 +216,85 @@ func (config *Config) HasLoadedConfigurationBeenModified() bool {
 	return !fileInfo.ModTime().IsZero() && config.lastFileModTime.Unix() < fileInfo.ModTime().Unix()
 }
 
+// LoadedAt returns the time at which the configuration was loaded, or the zero time if it wasn't loaded from a file or
+// a directory
+func (config *Config) LoadedAt() time.Time {
+	return config.loadedAt
+}
+
+// AlertingProviders returns the alerting providers that were configured and whose configuration is valid
+func (config *Config) AlertingProviders() []alert.Type {
+	return config.alertingProviders
+}
+
+// IgnoredAlertingProviders returns the alerting providers that were configured, but ignored because their
+// configuration is invalid
+func (config *Config) IgnoredAlertingProviders() []alert.Type {
+	return config.ignoredAlertingProviders
+}
+
 // UpdateLastFileModTime refreshes Config.lastFileModTime
 func (config *Config) UpdateLastFileModTime() {
 	config.lastFileModTime = time.Now()
 }
 
+// Reload loads the configuration again from the file or directory it was loaded from, without replacing it.
+// The configuration returned can then be passed to RequestReload to replace this one.
+func (config *Config) Reload() (*Config, error) {
+	if len(config.configPath) == 0 {
+		return nil, ErrConfigFileNotFound
+	}
+	return LoadConfiguration(config.configPath)
+}
+
+// RequestReload requests the configuration passed as parameter to replace this one, and returns false if the
+// configuration was not loaded from a file or if another reload was already requested
+func (config *Config) RequestReload(updatedConfig *Config) bool {
+	select {
+	case config.reloadRequests <- updatedConfig:
+		return true
+	default:
+		return false
+	}
+}
+
+// DiscoverEndpoints discovers the endpoints from the sources of the discovery, if it's configured, and returns whether
+// they changed since they were last discovered, in which case the configuration must be loaded again for them to be
+// part of it. If they cannot be discovered, e.g. because a source is unreachable, the error is logged and the
+// endpoints discovered before, if any, keep being part of the configurations loaded.
+func (config *Config) DiscoverEndpoints() bool {
+	if config.Discovery == nil {
+		return false
+	}
+	changed, err := discovery.Refresh(config.Discovery)
+	if err != nil {
+		log.Printf("[config.DiscoverEndpoints] Failed to discover endpoints: %s", err.Error())
+		return false
+	}
+	return changed
+}
+
+// WatchDiscoveredEndpoints requests the configuration to be reloaded, through RequestReload, every time the endpoints
+// discovered change, until discovery.Shutdown is called. Does nothing if the discovery isn't configured.
+func (config *Config) WatchDiscoveredEndpoints() {
+	if config.Discovery == nil {
+		return
+	}
+	discovery.Watch(config.Discovery, func() {
+		updatedConfig, err := config.Reload()
+		if err != nil {
+			log.Printf("[config.WatchDiscoveredEndpoints] Failed to reload configuration with the endpoints discovered: %s", err.Error())
+			return
+		}
+		config.RequestReload(updatedConfig)
+	})
+}
+
+// ReloadRequests returns the channel through which the configurations passed to RequestReload are received
+func (config *Config) ReloadRequests() <-chan *Config {
+	return config.reloadRequests
+}
+
 // LoadConfiguration loads the full configuration composed of the main configuration file
 // and all composed configuration files
 func LoadConfiguration(configPath string) (*Config, error) {
@@ -169,6 +318,7 @@ func LoadConfiguration(configPath string) (*Config, error) {
 		return nil, ErrConfigFileNotFound
 	}
 	var config *Config
+	inc := &includes{}
 	if fileInfo.IsDir() {
 		err := walkConfigDir(configPath, func(path string, d fs.DirEntry, err error) error {
 			if err != nil {
@@ -176,10 +326,10 @@ func LoadConfiguration(configPath string) (*Config, error) {
 				return err
 			}
 			log.Printf("[config.LoadConfiguration] Reading configuration from %s", path)
-			data, err := os.ReadFile(path)
+			data, err := inc.readConfigurationFile(path, nil)
 			if err != nil {
 				log.Printf("[config.LoadConfiguration] Error reading configuration from %s: %s", path, err)
-				return fmt.Errorf("error reading configuration from file %s: %w", path, err)
+				return err
 			}
 			configBytes, err = deepmerge.YAML(configBytes, data)
 			return err
@@ -189,7 +339,7 @@ func LoadConfiguration(configPath string) (*Config, error) {
 		}
 	} else {
 		log.Printf("[config.LoadConfiguration] Reading configuration from configFile=%s", configPath)
-		if data, err := os.ReadFile(usedConfigPath); err != nil {
+		if data, err := inc.readConfigurationFile(usedConfigPath, nil); err != nil {
 			return nil, err
 		} else {
 			configBytes = data
@@ -203,7 +353,10 @@ func LoadConfiguration(configPath string) (*Config, error) {
 		return nil, err
 	}
 	config.configPath = usedConfigPath
+	config.includes = inc
+	config.reloadRequests = make(chan *Config, 1)
 	config.UpdateLastFileModTime()
+	config.loadedAt = time.Now()
 	return config, err
 }
 
@@ -228,24 +381,133 @@ func walkConfigDir(path string, fn fs.WalkDirFunc) error {
 	})
 }
 
+// expand replaces the ${...} and $... in the values of the configuration passed, see expandValue.
+//
+// The configuration is parsed before the values are replaced, so that whatever they contain, e.g. a secret with a
+// newline or a colon, is taken as part of the value rather than as YAML that could break or alter the configuration.
+func expand(yamlBytes []byte) ([]byte, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(yamlBytes, &document); err != nil {
+		return nil, err
+	}
+	if document.Kind == 0 {
+		return yamlBytes, nil
+	}
+	if err := expandScalars(&document, make(map[string]string)); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(&document)
+}
+
+// expandScalars replaces the ${...} and $... in the scalars of the node passed, and of its descendants
+func expandScalars(node *yaml.Node, resolvedSecrets map[string]string) error {
+	if node.Kind == yaml.ScalarNode && strings.Contains(node.Value, "$") {
+		value, err := expandValue(node.Value, resolvedSecrets)
+		if err != nil {
+			return err
+		}
+		// The type of an unquoted value depends on what it is, e.g. interval: ${INTERVAL} is a string until it's
+		// replaced by the duration the variable holds, so the type must be resolved again from the new value
+		if value != node.Value && node.Style&(yaml.TaggedStyle|yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle|yaml.LiteralStyle|yaml.FoldedStyle) == 0 {
+			node.Tag = ""
+		}
+		node.Value = value
+	}
+	for _, child := range node.Content {
+		if err := expandScalars(child, resolvedSecrets); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expandValue replaces the ${...} and $... in the value passed by the value of the environment variable they refer to,
+// or, if they refer to a secret (see secret.IsReference), by the value of the secret. The secrets already resolved are
+// read from resolvedSecrets, so that each secret is only resolved once, and the first secret that couldn't be
+// resolved, or the first required environment variable that isn't set, is returned as an error.
+func expandValue(value string, resolvedSecrets map[string]string) (string, error) {
+	var resolutionErr error
+	expanded := os.Expand(value, func(name string) string {
+		if !secret.IsReference(name) {
+			value, err := getEnvironmentVariable(name)
+			if err != nil && resolutionErr == nil {
+				resolutionErr = err
+			}
+			return value
+		}
+		if value, resolved := resolvedSecrets[name]; resolved {
+			return value
+		}
+		value, err := secret.Resolve(name)
+		if err != nil {
+			if resolutionErr == nil {
+				resolutionErr = fmt.Errorf("%w: %w", ErrUnresolvableSecret, err)
+			}
+			return ""
+		}
+		resolvedSecrets[name] = value
+		return value
+	})
+	return expanded, resolutionErr
+}
+
+// getEnvironmentVariable returns the value of the environment variable referenced by the expression passed, which is
+// either the name of the variable or, like in shells:
+//   - VAR:-default, to fall back to default if VAR is unset or empty
+//   - VAR:?message, to return an error with the message passed if VAR is unset or empty
+func getEnvironmentVariable(expression string) (string, error) {
+	name, operator, argument := expression, "", ""
+	if i := strings.Index(expression, ":"); i > 0 && i+1 < len(expression) && (expression[i+1] == '-' || expression[i+1] == '?') {
+		name, operator, argument = expression[:i], expression[i:i+2], expression[i+2:]
+	}
+	value := os.Getenv(name)
+	if len(value) > 0 {
+		return value, nil
+	}
+	switch operator {
+	case ":-":
+		return argument, nil
+	case ":?":
+		if len(argument) == 0 {
+			argument = "must be set"
+		}
+		return "", fmt.Errorf("%w: %s: %s", ErrMissingEnvironmentVariable, name, argument)
+	}
+	return value, nil
+}
+
 // parseAndValidateConfigBytes parses a Gatus configuration file into a Config struct and validates its parameters
 func parseAndValidateConfigBytes(yamlBytes []byte) (config *Config, err error) {
 	// Replace $$ with __GATUS_LITERAL_DOLLAR_SIGN__ to prevent os.ExpandEnv from treating "$$" as if it was an
 	// environment variable. This allows Gatus to support literal "$" in the configuration file.
 	yamlBytes = []byte(strings.ReplaceAll(string(yamlBytes), "$$", "__GATUS_LITERAL_DOLLAR_SIGN__"))
-	// Expand environment variables
-	yamlBytes = []byte(os.ExpandEnv(string(yamlBytes)))
+	// Expand environment variables and resolve the references to secrets, e.g. ${vault:secret/data/gatus#password}
+	if yamlBytes, err = expand(yamlBytes); err != nil {
+		return nil, err
+	}
 	// Replace __GATUS_LITERAL_DOLLAR_SIGN__ with "$" to restore the literal "$" in the configuration file
 	yamlBytes = []byte(strings.ReplaceAll(string(yamlBytes), "__GATUS_LITERAL_DOLLAR_SIGN__", "$"))
+	// Instantiate the endpoint templates, if any
+	var templates map[string]*endpointTemplate
+	if yamlBytes, templates, err = expandTemplates(yamlBytes); err != nil {
+		return nil, err
+	}
 	// Parse configuration file
 	if err = yaml.Unmarshal(yamlBytes, &config); err != nil {
 		return
 	}
-	// Check if the configuration file at least has endpoints configured
-	if config == nil || config.Endpoints == nil || len(config.Endpoints) == 0 {
+	// Add the endpoints last discovered, if the discovery is configured, without discovering them again, so that a
+	// source that is unreachable or slow doesn't prevent the configuration from loading
+	if config != nil && config.Discovery != nil {
+		if err = addDiscoveredEndpoints(config, templates); err != nil {
+			return
+		}
+	}
+	// Check if the configuration file at least has endpoints configured, unless they're discovered at runtime
+	if config == nil || ((config.Endpoints == nil || len(config.Endpoints) == 0) && config.Discovery == nil) {
 		err = ErrNoEndpointInConfig
 	} else {
-		validateAlertingConfig(config.Alerting, config.Endpoints, config.ExternalEndpoints, config.Debug)
+		config.alertingProviders, config.ignoredAlertingProviders = validateAlertingConfig(config.Alerting, config.Endpoints, config.ExternalEndpoints, config.Debug)
 		if err := validateSecurityConfig(config); err != nil {
 			return nil, err
 		}
@@ -255,6 +517,9 @@ func parseAndValidateConfigBytes(yamlBytes []byte) (config *Config, err error) {
 		if err := validateWebConfig(config); err != nil {
 			return nil, err
 		}
+		if err := validateMetricsSecurityConfig(config); err != nil {
+			return nil, err
+		}
 		if err := validateUIConfig(config); err != nil {
 			return nil, err
 		}
@@ -270,10 +535,123 @@ func parseAndValidateConfigBytes(yamlBytes []byte) (config *Config, err error) {
 		if err := validateConnectivityConfig(config); err != nil {
 			return nil, err
 		}
+		if err := validateSubscriptionsConfig(config); err != nil {
+			return nil, err
+		}
+		if err := validateGroupsConfig(config); err != nil {
+			return nil, err
+		}
+		if err := validateGroupPagesConfig(config); err != nil {
+			return nil, err
+		}
+		if err := validateTenantsConfig(config); err != nil {
+			return nil, err
+		}
+		if err := validateWebhooksConfig(config); err != nil {
+			return nil, err
+		}
 	}
 	return
 }
 
+func validateWebhooksConfig(config *Config) error {
+	for _, webhook := range config.Webhooks {
+		if err := webhook.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateTenantsConfig(config *Config) error {
+	duplicateValidationMap := make(map[string]bool)
+	for _, t := range config.Tenants {
+		if err := t.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+		if duplicateValidationMap[t.Name] {
+			return fmt.Errorf("invalid tenant: duplicate tenant with name %s", t.Name)
+		}
+		duplicateValidationMap[t.Name] = true
+	}
+	for _, ep := range config.Endpoints {
+		if len(ep.Tenant) > 0 && !duplicateValidationMap[ep.Tenant] {
+			return fmt.Errorf("invalid endpoint %s: tenant %s does not exist", ep.Key(), ep.Tenant)
+		}
+	}
+	for _, ee := range config.ExternalEndpoints {
+		if len(ee.Tenant) > 0 && !duplicateValidationMap[ee.Tenant] {
+			return fmt.Errorf("invalid external endpoint %s: tenant %s does not exist", ee.Key(), ee.Tenant)
+		}
+	}
+	if len(config.Tenants) > 0 && config.Security == nil {
+		log.Println("[config.validateTenantsConfig] WARNING: Tenants are configured, but security isn't, so the endpoints of every tenant can be seen by anyone on the main dashboard")
+	}
+	return nil
+}
+
+// validateGroupsConfig validates the metadata of the groups, and sorts them by the order they must be displayed in
+func validateGroupsConfig(config *Config) error {
+	groups := make(map[string]bool)
+	for _, ep := range config.Endpoints {
+		groups[ep.Group] = true
+	}
+	for _, ee := range config.ExternalEndpoints {
+		groups[ee.Group] = true
+	}
+	duplicateValidationMap := make(map[string]bool)
+	for _, g := range config.Groups {
+		if err := g.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+		if duplicateValidationMap[g.Name] {
+			return fmt.Errorf("invalid group: duplicate group %s", g.Name)
+		}
+		duplicateValidationMap[g.Name] = true
+		if !groups[g.Name] {
+			return fmt.Errorf("invalid group: group %s has no endpoints", g.Name)
+		}
+		if g.VisibleTo != nil && config.Security == nil {
+			return fmt.Errorf("invalid group: the visibility of group %s can't be restricted without a security configuration", g.Name)
+		}
+	}
+	sort.SliceStable(config.Groups, func(i, j int) bool {
+		return config.Groups[i].Order < config.Groups[j].Order
+	})
+	return nil
+}
+
+func validateGroupPagesConfig(config *Config) error {
+	groups := make(map[string]bool)
+	for _, ep := range config.Endpoints {
+		groups[ep.Group] = true
+	}
+	for _, ee := range config.ExternalEndpoints {
+		groups[ee.Group] = true
+	}
+	duplicateValidationMap := make(map[string]bool)
+	for _, page := range config.GroupPages {
+		if err := page.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+		if duplicateValidationMap[page.Group] {
+			return fmt.Errorf("invalid group page: duplicate page for group %s", page.Group)
+		}
+		duplicateValidationMap[page.Group] = true
+		if !groups[page.Group] {
+			return fmt.Errorf("invalid group page: group %s has no endpoints", page.Group)
+		}
+	}
+	return nil
+}
+
+func validateSubscriptionsConfig(config *Config) error {
+	if config.Subscriptions != nil {
+		return config.Subscriptions.ValidateAndSetDefaults()
+	}
+	return nil
+}
+
 func validateConnectivityConfig(config *Config) error {
 	if config.Connectivity != nil {
 		return config.Connectivity.ValidateAndSetDefaults()
@@ -281,6 +659,40 @@ func validateConnectivityConfig(config *Config) error {
 	return nil
 }
 
+// addDiscoveredEndpoints validates the discovery configuration and adds the endpoints last discovered, see
+// discovery.Refresh, to the endpoints of the configuration, except for those whose key is the same as the one of an
+// endpoint of the configuration. The endpoints discovered may be instances of the templates passed.
+func addDiscoveredEndpoints(config *Config, templates map[string]*endpointTemplate) error {
+	if err := config.Discovery.ValidateAndSetDefaults(); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidDiscoveryConfig, err)
+	}
+	for _, name := range config.Discovery.GetTemplates() {
+		if _, exists := templates[name]; !exists {
+			return fmt.Errorf("%w: template %s does not exist", ErrInvalidDiscoveryConfig, name)
+		}
+	}
+	config.Discovery.SetTemplateInstantiator(newTemplateInstantiator(templates))
+	discoveredEndpoints := discovery.GetEndpoints()
+	keys := make(map[string]bool, len(config.Endpoints)+len(config.ExternalEndpoints))
+	for _, ep := range config.Endpoints {
+		keys[ep.Key()] = true
+	}
+	for _, ee := range config.ExternalEndpoints {
+		keys[ee.Key()] = true
+	}
+	numberOfEndpointsDiscovered := 0
+	for _, ep := range discoveredEndpoints {
+		if keys[ep.Key()] {
+			log.Printf("[config.addDiscoveredEndpoints] Ignoring discovered endpoint with key=%s, because an endpoint with the same key is configured", ep.Key())
+			continue
+		}
+		config.Endpoints = append(config.Endpoints, ep)
+		numberOfEndpointsDiscovered++
+	}
+	log.Printf("[config.addDiscoveredEndpoints] Discovered %d endpoints", numberOfEndpointsDiscovered)
+	return nil
+}
+
 func validateRemoteConfig(config *Config) error {
 	if config.Remote != nil {
 		if err := config.Remote.ValidateAndSetDefaults(); err != nil {
@@ -334,6 +746,25 @@ func validateWebConfig(config *Config) error {
 	return nil
 }
 
+// validateMetricsSecurityConfig validates the security configuration of the metrics.
+// Note that it must be validated after the web configuration, because verifying the certificates of the clients
+// requires TLS to be configured.
+func validateMetricsSecurityConfig(config *Config) error {
+	if config.MetricsSecurity == nil {
+		return nil
+	}
+	if err := config.MetricsSecurity.ValidateAndSetDefaults(); err != nil {
+		return err
+	}
+	if len(config.MetricsSecurity.ClientCertificateAuthorityFile) > 0 && !config.Web.HasTLS() {
+		return ErrMetricsClientCertificatesWithoutTLS
+	}
+	if !config.Metrics {
+		log.Printf("[config.validateMetricsSecurityConfig] Metrics security is configured, but metrics are not enabled")
+	}
+	return nil
+}
+
 func validateEndpointsConfig(config *Config) error {
 	duplicateValidationMap := make(map[string]bool)
 	// Validate endpoints
@@ -371,6 +802,27 @@ func validateEndpointsConfig(config *Config) error {
 
 func validateSecurityConfig(config *Config) error {
 	if config.Security != nil {
+		if config.Security.LDAP != nil {
+			if err := config.Security.LDAP.ValidateAndSetDefaults(); err != nil {
+				return fmt.Errorf("%w: %w", ErrInvalidSecurityConfig, err)
+			}
+		}
+		if config.Security.BruteForceProtection != nil {
+			if !config.Security.UsesBasicAuthentication() {
+				return fmt.Errorf("%w: brute-force-protection requires basic or ldap to be configured", ErrInvalidSecurityConfig)
+			}
+			if err := config.Security.BruteForceProtection.ValidateAndSetDefaults(); err != nil {
+				return fmt.Errorf("%w: %w", ErrInvalidSecurityConfig, err)
+			}
+		}
+		if config.Security.Session != nil {
+			if config.Security.OIDC == nil && config.Security.SAML == nil {
+				return fmt.Errorf("%w: session requires oidc or saml to be configured", ErrInvalidSecurityConfig)
+			}
+			if err := config.Security.Session.ValidateAndSetDefaults(); err != nil {
+				return fmt.Errorf("%w: %w", ErrInvalidSecurityConfig, err)
+			}
+		}
 		if config.Security.IsValid() {
 			if config.Debug {
 				log.Printf("[config.validateSecurityConfig] Basic security configuration has been validated")
@@ -388,10 +840,13 @@ func validateSecurityConfig(config *Config) error {
 // Note that the alerting configuration has to be validated before the endpoint configuration, because the default alert
 // returned by provider.AlertProvider.GetDefaultAlert() must be parsed before endpoint.Endpoint.ValidateAndSetDefaults()
 // sets the default alert values when none are set.
-func validateAlertingConfig(alertingConfig *alerting.Config, endpoints []*endpoint.Endpoint, externalEndpoints []*endpoint.ExternalEndpoint, debug bool) {
+//
+// Returns the providers whose configuration is valid, and those that were configured, but ignored because their
+// configuration is invalid.
+func validateAlertingConfig(alertingConfig *alerting.Config, endpoints []*endpoint.Endpoint, externalEndpoints []*endpoint.ExternalEndpoint, debug bool) (validProviders, ignoredProviders []alert.Type) {
 	if alertingConfig == nil {
 		log.Printf("[config.validateAlertingConfig] Alerting is not configured")
-		return
+		return nil, nil
 	}
 	alertTypes := []alert.Type{
 		alert.TypeAWSSES,
@@ -415,7 +870,7 @@ func validateAlertingConfig(alertingConfig *alerting.Config, endpoints []*endpoi
 		alert.TypeTelegram,
 		alert.TypeTwilio,
 	}
-	var validProviders, invalidProviders []alert.Type
+	var invalidProviders []alert.Type
 	for _, alertType := range alertTypes {
 		alertProvider := alertingConfig.GetAlertingProviderByAlertType(alertType)
 		if alertProvider != nil {
@@ -447,6 +902,7 @@ func validateAlertingConfig(alertingConfig *alerting.Config, endpoints []*endpoi
 			} else {
 				log.Printf("[config.validateAlertingConfig] Ignoring provider=%s because configuration is invalid", alertType)
 				invalidProviders = append(invalidProviders, alertType)
+				ignoredProviders = append(ignoredProviders, alertType)
 				alertingConfig.SetAlertingProviderToNil(alertProvider)
 			}
 		} else {
@@ -454,4 +910,5 @@ func validateAlertingConfig(alertingConfig *alerting.Config, endpoints []*endpoi
 		}
 	}
 	log.Printf("[config.validateAlertingConfig] configuredProviders=%s; ignoredProviders=%s", validProviders, invalidProviders)
+	return validProviders, ignoredProviders
 }
@@ -3,8 +3,12 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -32,6 +36,7 @@ import (
 	"github.com/TwiN/gatus/v5/client"
 	"github.com/TwiN/gatus/v5/config/endpoint"
 	"github.com/TwiN/gatus/v5/config/web"
+	"github.com/TwiN/gatus/v5/security"
 	"github.com/TwiN/gatus/v5/storage"
 	"gopkg.in/yaml.v3"
 )
@@ -321,6 +326,62 @@ func TestConfig_HasLoadedConfigurationBeenModified(t *testing.T) {
 	})
 }
 
+func TestConfig_ReloadAndRequestReload(t *testing.T) {
+	dir := t.TempDir()
+	configFilePath := filepath.Join(dir, "config.yaml")
+	_ = os.WriteFile(configFilePath, []byte(`endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`), 0644)
+	config, err := LoadConfiguration(configFilePath)
+	if err != nil {
+		t.Fatalf("failed to load configuration: %v", err)
+	}
+	if err = os.WriteFile(configFilePath, []byte(`endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+  - name: api
+    url: https://twin.sh/api/health
+    conditions:
+      - "[STATUS] == 200"
+`), 0644); err != nil {
+		t.Fatalf("failed to overwrite config file: %v", err)
+	}
+	updatedConfig, err := config.Reload()
+	if err != nil {
+		t.Fatalf("failed to reload configuration: %v", err)
+	}
+	if len(config.Endpoints) != 1 {
+		t.Errorf("expected the configuration to be left as is, got %d endpoints", len(config.Endpoints))
+	}
+	if len(updatedConfig.Endpoints) != 2 {
+		t.Errorf("expected the reloaded configuration to have 2 endpoints, got %d", len(updatedConfig.Endpoints))
+	}
+	if !config.RequestReload(updatedConfig) {
+		t.Error("expected the first reload request to be accepted")
+	}
+	if config.RequestReload(updatedConfig) {
+		t.Error("expected a second reload request to be rejected while the first one has not been received")
+	}
+	if requestedConfig := <-config.ReloadRequests(); requestedConfig != updatedConfig {
+		t.Error("expected the requested configuration to be received")
+	}
+	if _, err = (&Config{}).Reload(); !errors.Is(err, ErrConfigFileNotFound) {
+		t.Errorf("expected %v for a configuration that was not loaded from a file, got %v", ErrConfigFileNotFound, err)
+	}
+	if (&Config{}).RequestReload(updatedConfig) {
+		t.Error("expected the reload request of a configuration that was not loaded from a file to be rejected")
+	}
+	_ = os.WriteFile(configFilePath, []byte(`endpoints: []`), 0644)
+	if _, err = config.Reload(); err == nil {
+		t.Error("expected an error, because the configuration has no endpoints")
+	}
+}
+
 func TestParseAndValidateConfigBytes(t *testing.T) {
 	file := t.TempDir() + "/test.db"
 	config, err := parseAndValidateConfigBytes([]byte(fmt.Sprintf(`
@@ -1492,6 +1553,12 @@ endpoints:
 	if config.Alerting.PagerDuty != nil {
 		t.Fatal("PagerDuty alerting config should've been set to nil, because its IsValid() method returned false and therefore alerting.Config.SetAlertingProviderToNil() should've been called")
 	}
+	if ignoredProviders := config.IgnoredAlertingProviders(); len(ignoredProviders) != 1 || ignoredProviders[0] != alert.TypePagerDuty {
+		t.Errorf("expected pagerduty to be the only ignored alerting provider, got %v", ignoredProviders)
+	}
+	if len(config.AlertingProviders()) != 0 {
+		t.Errorf("expected no valid alerting provider, got %v", config.AlertingProviders())
+	}
 }
 func TestParseAndValidateConfigBytesWithInvalidPushoverAlertingConfig(t *testing.T) {
 	config, err := parseAndValidateConfigBytes([]byte(`
@@ -1766,6 +1833,41 @@ endpoints:
 	}
 }
 
+func TestParseAndValidateConfigBytesWithSubscriptions(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+subscriptions:
+  url: https://status.example.org/
+  webhooks: true
+  email:
+    from: status@example.org
+    host: smtp.example.org
+    port: 587
+endpoints:
+  - name: example
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if config.Subscriptions == nil || config.Subscriptions.URL != "https://status.example.org" || !config.Subscriptions.Webhooks || config.Subscriptions.Email == nil || config.Subscriptions.Email.Port != 587 {
+		t.Errorf("expected the subscriptions to be configured, got %+v", config.Subscriptions)
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+subscriptions:
+  url: https://status.example.org
+endpoints:
+  - name: example
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err == nil {
+		t.Error("should've returned an error, because at least one type of subscription must be enabled")
+	}
+}
+
 func TestParseAndValidateConfigBytesWithInvalidYAML(t *testing.T) {
 	_, err := parseAndValidateConfigBytes([]byte(`
 storage:
@@ -1835,6 +1937,86 @@ endpoints:
 	}
 }
 
+func TestParseAndValidateConfigBytesWithLDAPSecurityConfig(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+security:
+  ldap:
+    url: "ldaps://ad.example.org:636"
+    bind-dn: "cn=gatus,dc=example,dc=org"
+    bind-password: "secret"
+    base-dn: "dc=example,dc=org"
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if config.Security == nil || config.Security.LDAP == nil || !config.Security.IsValid() {
+		t.Fatal("expected the LDAP security config to be valid")
+	}
+	if config.Security.LDAP.UserFilter != security.DefaultLDAPUserFilter {
+		t.Errorf("expected the user filter to default to %s, got %s", security.DefaultLDAPUserFilter, config.Security.LDAP.UserFilter)
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+security:
+  ldap:
+    url: "ldaps://ad.example.org:636"
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if !errors.Is(err, ErrInvalidSecurityConfig) || !errors.Is(err, security.ErrInvalidLDAPConfig) {
+		t.Errorf("expected %v, got %v", ErrInvalidSecurityConfig, err)
+	}
+}
+
+func TestParseAndValidateConfigBytesWithRoles(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+security:
+  basic:
+    username: "john.doe"
+    password-bcrypt-base64: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT"
+  default-role: viewer
+  roles:
+    operator: ["john.doe"]
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if config.Security.DefaultRole != security.RoleViewer {
+		t.Errorf("expected the default role to be %s, got %s", security.RoleViewer, config.Security.DefaultRole)
+	}
+	if users := config.Security.Roles[security.RoleOperator]; len(users) != 1 || users[0] != "john.doe" {
+		t.Errorf("expected john.doe to be assigned the operator role, got %v", config.Security.Roles)
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+security:
+  basic:
+    username: "john.doe"
+    password-bcrypt-base64: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT"
+  roles:
+    superuser: ["john.doe"]
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if !errors.Is(err, ErrInvalidSecurityConfig) {
+		t.Errorf("expected %v, got %v", ErrInvalidSecurityConfig, err)
+	}
+}
+
 func TestParseAndValidateConfigBytesWithLiteralDollarSign(t *testing.T) {
 	os.Setenv("GATUS_TestParseAndValidateConfigBytesWithLiteralDollarSign", "whatever")
 	config, err := parseAndValidateConfigBytes([]byte(`
@@ -1862,6 +2044,280 @@ endpoints:
 	}
 }
 
+func TestParseAndValidateConfigBytesWithSecretReferences(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(path, []byte("hunter2\n"), 0600); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	config, err := parseAndValidateConfigBytes([]byte(`
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    headers:
+      Authorization: "Basic ${file:` + path + `}"
+      X-Password: "${file:` + path + `}"
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if config.Endpoints[0].Headers["Authorization"] != "Basic hunter2" || config.Endpoints[0].Headers["X-Password"] != "hunter2" {
+		t.Errorf("expected the references to have been resolved, got %v", config.Endpoints[0].Headers)
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    headers:
+      X-Password: "${file:` + filepath.Join(t.TempDir(), "nonexistent") + `}"
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if !errors.Is(err, ErrUnresolvableSecret) {
+		t.Errorf("expected %v, got %v", ErrUnresolvableSecret, err)
+	}
+}
+
+func TestParseAndValidateConfigBytesWithSecretReferencesContainingYAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(path, []byte("\"\n  foo: bar"), 0600); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	t.Setenv("GATUS_TEST_ENABLED", "false")
+	t.Setenv("GATUS_TEST_VALUE", "'quoted # not a comment")
+	config, err := parseAndValidateConfigBytes([]byte(`
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    enabled: ${GATUS_TEST_ENABLED}
+    headers:
+      X-Password: ${file:` + path + `}
+      X-Quoted-Password: "${file:` + path + `}"
+      X-Value: ${GATUS_TEST_VALUE}
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	headers := config.Endpoints[0].Headers
+	if _, exists := headers["foo"]; exists || headers["X-Password"] != "\"\n  foo: bar" || headers["X-Quoted-Password"] != "\"\n  foo: bar" {
+		t.Errorf("expected the secret to have been taken as the value of the headers, got %v", headers)
+	}
+	if headers["X-Value"] != "'quoted # not a comment" {
+		t.Errorf("expected the environment variable to have been taken as the value of the header, got %s", headers["X-Value"])
+	}
+	if config.Endpoints[0].IsEnabled() {
+		t.Error("expected the value of the environment variable to have been parsed as a boolean")
+	}
+}
+
+func TestParseAndValidateConfigBytesWithEnvironmentVariableDefaults(t *testing.T) {
+	t.Setenv("GATUS_TEST_DOMAIN", "twin.sh")
+	t.Setenv("GATUS_TEST_EMPTY", "")
+	config, err := parseAndValidateConfigBytes([]byte(`
+endpoints:
+  - name: website
+    url: https://${GATUS_TEST_DOMAIN:-example.org}/health
+    headers:
+      X-Environment: "${GATUS_TEST_ENVIRONMENT:-development}"
+      X-Empty: "${GATUS_TEST_EMPTY:-default}"
+      X-Price: "${GATUS_TEST_PRICE:-$$5}"
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if config.Endpoints[0].URL != "https://twin.sh/health" {
+		t.Errorf("expected the value of the environment variable to take precedence over the default, got %s", config.Endpoints[0].URL)
+	}
+	if headers := config.Endpoints[0].Headers; headers["X-Environment"] != "development" || headers["X-Empty"] != "default" || headers["X-Price"] != "$5" {
+		t.Errorf("expected the defaults of the unset or empty environment variables, got %v", headers)
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+endpoints:
+  - name: website
+    url: https://${GATUS_TEST_UNSET:?the domain to monitor}/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if !errors.Is(err, ErrMissingEnvironmentVariable) {
+		t.Fatalf("expected %v, got %v", ErrMissingEnvironmentVariable, err)
+	}
+	if !strings.Contains(err.Error(), "GATUS_TEST_UNSET: the domain to monitor") {
+		t.Errorf("expected the error to mention the variable and the message, got %v", err)
+	}
+}
+
+func TestGetEnvironmentVariable(t *testing.T) {
+	t.Setenv("GATUS_TEST_SET", "value")
+	t.Setenv("GATUS_TEST_EMPTY", "")
+	scenarios := []struct {
+		expression    string
+		expectedValue string
+		expectedError error
+	}{
+		{expression: "GATUS_TEST_SET", expectedValue: "value"},
+		{expression: "GATUS_TEST_UNSET", expectedValue: ""},
+		{expression: "GATUS_TEST_SET:-default", expectedValue: "value"},
+		{expression: "GATUS_TEST_UNSET:-default", expectedValue: "default"},
+		{expression: "GATUS_TEST_EMPTY:-default", expectedValue: "default"},
+		{expression: "GATUS_TEST_UNSET:-", expectedValue: ""},
+		{expression: "GATUS_TEST_UNSET:-http://localhost:8080", expectedValue: "http://localhost:8080"},
+		{expression: "GATUS_TEST_SET:?required", expectedValue: "value"},
+		{expression: "GATUS_TEST_UNSET:?required", expectedError: ErrMissingEnvironmentVariable},
+		{expression: "GATUS_TEST_EMPTY:?", expectedError: ErrMissingEnvironmentVariable},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.expression, func(t *testing.T) {
+			value, err := getEnvironmentVariable(scenario.expression)
+			if !errors.Is(err, scenario.expectedError) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedError, err)
+			}
+			if value != scenario.expectedValue {
+				t.Errorf("expected %q, got %q", scenario.expectedValue, value)
+			}
+		})
+	}
+}
+
+func TestParseAndValidateConfigBytesWithDiscovery(t *testing.T) {
+	var unavailable atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if unavailable.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"items":[
+  {"metadata":{"name":"api","namespace":"backend","annotations":{"gatus.io/alerts":"slack"}},"spec":{"ports":[{"port":8080}]}},
+  {"metadata":{"name":"website","namespace":"frontend","annotations":{"gatus.io/path":"/health"}},"spec":{"ports":[{"port":80}]}}
+]}`))
+	}))
+	defer server.Close()
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	_ = os.WriteFile(tokenFile, []byte("token"), 0600)
+	yamlBytes := []byte(fmt.Sprintf(`
+alerting:
+  slack:
+    webhook-url: "https://hooks.slack.com/services/xxx/yyy/zzz"
+    default-alert:
+      failure-threshold: 5
+discovery:
+  kubernetes:
+    api-server-url: %s
+    token-file: %s
+    resources: [services]
+endpoints:
+  - name: website
+    group: frontend
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+`, server.URL, tokenFile))
+	config, err := parseAndValidateConfigBytes(yamlBytes)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(config.Endpoints) != 1 {
+		t.Fatalf("expected the endpoints not to be discovered while parsing the configuration, got %d endpoints", len(config.Endpoints))
+	}
+	if !config.DiscoverEndpoints() {
+		t.Fatal("expected the endpoints discovered to have changed")
+	}
+	if config.DiscoverEndpoints() {
+		t.Error("expected the endpoints discovered not to have changed, because they were just discovered")
+	}
+	if config, err = parseAndValidateConfigBytes(yamlBytes); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(config.Endpoints) != 2 {
+		t.Fatalf("expected the endpoint discovered with the same key as the one configured to be ignored, got %d endpoints", len(config.Endpoints))
+	}
+	discoveredEndpoint := config.Endpoints[1]
+	if discoveredEndpoint.Key() != "backend_api" || discoveredEndpoint.URL != "http://api.backend.svc:8080" {
+		t.Errorf("expected the endpoint discovered to be added, got %+v", discoveredEndpoint)
+	}
+	if discoveredEndpoint.Interval != time.Minute || discoveredEndpoint.Alerts[0].FailureThreshold != 5 {
+		t.Errorf("expected the defaults to be set on the endpoint discovered like on the others, got %+v", discoveredEndpoint)
+	}
+	// The endpoints discovered before are kept if a source becomes unavailable
+	unavailable.Store(true)
+	if config.DiscoverEndpoints() {
+		t.Error("expected the endpoints discovered not to have changed, because the source is unavailable")
+	}
+	if config, err = parseAndValidateConfigBytes(yamlBytes); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(config.Endpoints) != 2 {
+		t.Errorf("expected the endpoints discovered before to be kept, got %d endpoints", len(config.Endpoints))
+	}
+	// The endpoints may all be discovered
+	if _, err = parseAndValidateConfigBytes([]byte(fmt.Sprintf(`
+discovery:
+  kubernetes:
+    api-server-url: %s
+    token-file: %s
+`, server.URL, tokenFile))); err != nil {
+		t.Error("expected no error, got", err.Error())
+	}
+	if _, err = parseAndValidateConfigBytes([]byte(`
+discovery:
+  interval: 1s
+  kubernetes:
+    api-server-url: https://127.0.0.1:6443
+`)); !errors.Is(err, ErrInvalidDiscoveryConfig) {
+		t.Errorf("expected %v, got %v", ErrInvalidDiscoveryConfig, err)
+	}
+}
+
+func TestParseAndValidateConfigBytesWithDiscoveryTemplate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"Node":"node-1","Address":"10.0.0.1","ServiceID":"api-1","ServiceName":"api","ServicePort":8080,"ServiceMeta":{"health":"/healthz"}}]`))
+	}))
+	defer server.Close()
+	yamlBytes := []byte(fmt.Sprintf(`
+templates:
+  - name: consul-service
+    endpoint:
+      url: "http://{{ address }}:{{ port }}{{ meta-health }}"
+      interval: 30s
+      conditions:
+        - "[STATUS] == 200"
+discovery:
+  consul:
+    address: %s
+    services: [api]
+    template: consul-service
+`, server.URL))
+	config, err := parseAndValidateConfigBytes(yamlBytes)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if !config.DiscoverEndpoints() {
+		t.Fatal("expected the endpoints discovered to have changed")
+	}
+	if config, err = parseAndValidateConfigBytes(yamlBytes); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(config.Endpoints) != 1 {
+		t.Fatalf("expected 1 endpoint, got %d", len(config.Endpoints))
+	}
+	if ep := config.Endpoints[0]; ep.Key() != "api_api-1" || ep.URL != "http://10.0.0.1:8080/healthz" || ep.Interval != 30*time.Second {
+		t.Errorf("expected the instance of the template, got %+v", ep)
+	}
+	_, err = parseAndValidateConfigBytes([]byte(fmt.Sprintf(`
+discovery:
+  consul:
+    address: %s
+    template: nonexistent
+`, server.URL)))
+	if !errors.Is(err, ErrInvalidDiscoveryConfig) {
+		t.Errorf("expected %v, got %v", ErrInvalidDiscoveryConfig, err)
+	}
+}
+
 func TestParseAndValidateConfigBytesWithNoEndpoints(t *testing.T) {
 	_, err := parseAndValidateConfigBytes([]byte(``))
 	if !errors.Is(err, ErrNoEndpointInConfig) {
@@ -1921,3 +2377,303 @@ func TestGetAlertingProviderByAlertType(t *testing.T) {
 		})
 	}
 }
+
+func TestParseAndValidateConfigBytesWithGroupPages(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+group-pages:
+  - group: customers
+    header: "Acme Status"
+    public: true
+  - group: internal
+endpoints:
+  - name: website
+    group: customers
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+  - name: database
+    group: internal
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(config.GroupPages) != 2 {
+		t.Fatalf("expected 2 group pages, got %d", len(config.GroupPages))
+	}
+	if page := config.GetGroupPageByGroup("customers"); page == nil || !page.Public || page.Header != "Acme Status" {
+		t.Errorf("expected the page of the customers group to be public, got %+v", page)
+	}
+	if page := config.GetGroupPageByGroup("internal"); page == nil || page.Public {
+		t.Errorf("expected the page of the internal group not to be public, got %+v", page)
+	}
+	if page := config.GetGroupPageByGroup("nope"); page != nil {
+		t.Errorf("expected no page for a group without one, got %+v", page)
+	}
+	scenarios := map[string]string{
+		"missing-group": `
+group-pages:
+  - header: "Acme Status"
+endpoints:
+  - name: website
+    group: customers
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+`,
+		"duplicate-group": `
+group-pages:
+  - group: customers
+  - group: customers
+endpoints:
+  - name: website
+    group: customers
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+`,
+		"group-without-endpoints": `
+group-pages:
+  - group: nope
+endpoints:
+  - name: website
+    group: customers
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+`,
+	}
+	for name, yamlConfig := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseAndValidateConfigBytes([]byte(yamlConfig)); err == nil {
+				t.Error("should've returned an error")
+			}
+		})
+	}
+}
+
+func TestParseAndValidateConfigBytesWithTenants(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+tenants:
+  - name: acme
+    header: "Acme Status"
+    security:
+      basic:
+        username: "acme"
+        password-bcrypt-base64: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT"
+  - name: globex
+endpoints:
+  - name: website
+    tenant: acme
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+external-endpoints:
+  - name: backup
+    tenant: globex
+    token: "potato"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(config.Tenants) != 2 {
+		t.Fatalf("expected 2 tenants, got %d", len(config.Tenants))
+	}
+	if acme := config.GetTenantByName("acme"); acme == nil || acme.Header != "Acme Status" || acme.Security == nil || acme.Security.Basic == nil {
+		t.Errorf("expected tenant acme to be configured, got %+v", acme)
+	}
+	if config.GetTenantByName("nope") != nil {
+		t.Error("expected no tenant with name nope")
+	}
+	if config.Endpoints[0].Tenant != "acme" || config.ExternalEndpoints[0].Tenant != "globex" {
+		t.Error("expected the endpoints to be assigned to their tenant")
+	}
+	scenarios := map[string]string{
+		"invalid-name": `
+tenants:
+  - name: "Acme Corp"
+endpoints:
+  - name: website
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+`,
+		"duplicate-tenant": `
+tenants:
+  - name: acme
+  - name: acme
+endpoints:
+  - name: website
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+`,
+		"endpoint-with-unknown-tenant": `
+tenants:
+  - name: acme
+endpoints:
+  - name: website
+    tenant: globex
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+`,
+		"external-endpoint-with-unknown-tenant": `
+endpoints:
+  - name: website
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+external-endpoints:
+  - name: backup
+    tenant: globex
+    token: "potato"
+`,
+	}
+	for name, yamlConfig := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseAndValidateConfigBytes([]byte(yamlConfig)); err == nil {
+				t.Error("should've returned an error")
+			}
+		})
+	}
+}
+
+func TestParseAndValidateConfigBytesWithWebhooks(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+webhooks:
+  - url: https://automation.example.org/hooks/gatus
+    events:
+      - endpoint-added
+      - endpoint-removed
+    secret: "potato"
+endpoints:
+  - name: example
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(config.Webhooks) != 1 || len(config.Webhooks[0].Events) != 2 || config.Webhooks[0].Secret != "potato" || config.Webhooks[0].ClientConfig == nil {
+		t.Errorf("expected the webhook to be configured, got %+v", config.Webhooks)
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+webhooks:
+  - url: https://automation.example.org/hooks/gatus
+    events:
+      - endpoint-exploded
+endpoints:
+  - name: example
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err == nil {
+		t.Error("should've returned an error, because endpoint-exploded isn't a lifecycle event")
+	}
+}
+
+func TestParseAndValidateConfigBytesWithMetricsSecurity(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+metrics: true
+metrics-security:
+  bearer-token: "potato"
+endpoints:
+  - name: example
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if config.MetricsSecurity == nil || config.MetricsSecurity.BearerToken != "potato" {
+		t.Errorf("expected the metrics security to be configured, got %+v", config.MetricsSecurity)
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+metrics: true
+metrics-security:
+  client-certificate-authority-file: ../testdata/cert.pem
+endpoints:
+  - name: example
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if !errors.Is(err, ErrMetricsClientCertificatesWithoutTLS) {
+		t.Errorf("expected error %v, got %v", ErrMetricsClientCertificatesWithoutTLS, err)
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+metrics: true
+metrics-security: {}
+endpoints:
+  - name: example
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err == nil {
+		t.Error("should've returned an error, because no authentication method is configured")
+	}
+}
+
+func TestParseAndValidateConfigBytesWithGroups(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+groups:
+  - name: internal
+    order: 2
+  - name: core
+    description: "Core services"
+    logo: "https://example.org/core.png"
+    links:
+      - name: Runbook
+        link: "https://example.org/runbook"
+    order: 1
+endpoints:
+  - name: frontend
+    group: core
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+  - name: database
+    group: internal
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(config.Groups) != 2 || config.Groups[0].Name != "core" || config.Groups[1].Name != "internal" {
+		t.Fatalf("expected the groups to be sorted by order, got %+v", config.Groups)
+	}
+	if len(config.Groups[0].Links) != 1 || config.Groups[0].Links[0].Link != "https://example.org/runbook" {
+		t.Errorf("expected the link of the group to be configured, got %+v", config.Groups[0].Links)
+	}
+	for _, scenario := range []struct {
+		name   string
+		groups string
+	}{
+		{name: "duplicate", groups: "groups:\n  - name: core\n  - name: core\n"},
+		{name: "without-endpoints", groups: "groups:\n  - name: nope\n"},
+		{name: "invalid-logo", groups: "groups:\n  - name: core\n    logo: \"logo.png\"\n"},
+	} {
+		t.Run(scenario.name, func(t *testing.T) {
+			_, err := parseAndValidateConfigBytes([]byte(scenario.groups + `
+endpoints:
+  - name: frontend
+    group: core
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+`))
+			if err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
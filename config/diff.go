@@ -0,0 +1,74 @@
+package config
+
+import (
+	"bytes"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EndpointsDiff is the difference between the endpoints and external endpoints of two configurations, identified by
+// their keys
+type EndpointsDiff struct {
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+	Changed []string `json:"changed"`
+}
+
+// IsEmpty returns whether no endpoint was added, removed or changed
+func (diff *EndpointsDiff) IsEmpty() bool {
+	return len(diff.Added) == 0 && len(diff.Removed) == 0 && len(diff.Changed) == 0
+}
+
+// DiffEndpoints returns the endpoints and external endpoints that were added, removed or changed in the current
+// configuration compared to the previous one.
+//
+// An endpoint is considered changed if any of its configured parameters differ, which excludes its state, such as the
+// number of failures in a row or whether its alerts are triggered.
+func DiffEndpoints(previous, current *Config) *EndpointsDiff {
+	previousEndpoints, currentEndpoints := make(map[string]interface{}), make(map[string]interface{})
+	for _, ep := range previous.Endpoints {
+		previousEndpoints[ep.Key()] = ep
+	}
+	for _, ee := range previous.ExternalEndpoints {
+		previousEndpoints[ee.Key()] = ee
+	}
+	for _, ep := range current.Endpoints {
+		currentEndpoints[ep.Key()] = ep
+	}
+	for _, ee := range current.ExternalEndpoints {
+		currentEndpoints[ee.Key()] = ee
+	}
+	diff := &EndpointsDiff{Added: []string{}, Removed: []string{}, Changed: []string{}}
+	for key, currentEndpoint := range currentEndpoints {
+		previousEndpoint, exists := previousEndpoints[key]
+		if !exists {
+			diff.Added = append(diff.Added, key)
+		} else if !haveSameConfiguration(previousEndpoint, currentEndpoint) {
+			diff.Changed = append(diff.Changed, key)
+		}
+	}
+	for key := range previousEndpoints {
+		if _, exists := currentEndpoints[key]; !exists {
+			diff.Removed = append(diff.Removed, key)
+		}
+	}
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	sort.Strings(diff.Changed)
+	return diff
+}
+
+// haveSameConfiguration returns whether two endpoints have the same configuration, by comparing their YAML
+// representation, which leaves out their state
+func haveSameConfiguration(a, b interface{}) bool {
+	aYAML, err := yaml.Marshal(a)
+	if err != nil {
+		return false
+	}
+	bYAML, err := yaml.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(aYAML, bYAML)
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+)
+
+func TestDiffEndpoints(t *testing.T) {
+	previous := &Config{
+		Endpoints: []*endpoint.Endpoint{
+			{Name: "unchanged", Group: "core", URL: "https://example.org", Alerts: []*alert.Alert{{Type: alert.TypeSlack}}},
+			{Name: "changed", Group: "core", URL: "https://example.org"},
+			{Name: "removed", Group: "core", URL: "https://example.org"},
+		},
+		ExternalEndpoints: []*endpoint.ExternalEndpoint{
+			{Name: "job", Group: "core", Token: "token"},
+		},
+	}
+	current := &Config{
+		Endpoints: []*endpoint.Endpoint{
+			{Name: "unchanged", Group: "core", URL: "https://example.org", Alerts: []*alert.Alert{{Type: alert.TypeSlack}}},
+			{Name: "changed", Group: "core", URL: "https://example.com"},
+			{Name: "added", Group: "core", URL: "https://example.org"},
+		},
+		ExternalEndpoints: []*endpoint.ExternalEndpoint{
+			{Name: "job", Group: "core", Token: "new-token"},
+		},
+	}
+	// The state of an endpoint is not part of its configuration
+	previous.Endpoints[0].NumberOfFailuresInARow = 3
+	previous.Endpoints[0].Alerts[0].Triggered = true
+	diff := DiffEndpoints(previous, current)
+	if expected := []string{"core_added"}; !reflect.DeepEqual(diff.Added, expected) {
+		t.Errorf("expected added to be %v, got %v", expected, diff.Added)
+	}
+	if expected := []string{"core_removed"}; !reflect.DeepEqual(diff.Removed, expected) {
+		t.Errorf("expected removed to be %v, got %v", expected, diff.Removed)
+	}
+	if expected := []string{"core_changed", "core_job"}; !reflect.DeepEqual(diff.Changed, expected) {
+		t.Errorf("expected changed to be %v, got %v", expected, diff.Changed)
+	}
+	if diff.IsEmpty() {
+		t.Error("expected the diff not to be empty")
+	}
+	if diff = DiffEndpoints(current, current); !diff.IsEmpty() {
+		t.Errorf("expected no difference between a configuration and itself, got %+v", diff)
+	}
+}
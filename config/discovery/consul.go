@@ -0,0 +1,206 @@
+package discovery
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+)
+
+const (
+	// ConsulMetadataPrefix is the prefix of the metadata of the instances of the services of Consul describing the
+	// endpoint to monitor, e.g. gatus-interval
+	ConsulMetadataPrefix = "gatus-"
+
+	// DefaultConsulAddress is the default address of the HTTP API of Consul
+	DefaultConsulAddress = "http://127.0.0.1:8500"
+
+	consulRequestTimeout = 10 * time.Second
+)
+
+// ConsulConfig is the configuration of the discovery of the endpoints from the instances of the services of the catalog
+// of Consul.
+//
+// Each instance of the services matching Services and Tags is monitored, either as an instance of the endpoint
+// template named Template or, if it isn't set, as described by the metadata of the instance prefixed by
+// ConsulMetadataPrefix.
+type ConsulConfig struct {
+	// Address is the address of the HTTP API of Consul. Defaults to the CONSUL_HTTP_ADDR environment variable, or to
+	// DefaultConsulAddress if it's not set.
+	Address string `yaml:"address,omitempty"`
+
+	// Token is the ACL token to authenticate with. Defaults to the CONSUL_HTTP_TOKEN environment variable.
+	Token string `yaml:"token,omitempty"`
+
+	// Datacenter is the datacenter to discover the services from. Defaults to the datacenter of the agent.
+	Datacenter string `yaml:"datacenter,omitempty"`
+
+	// Services are the names of the services to discover. If empty, all services are discovered.
+	Services []string `yaml:"services,omitempty"`
+
+	// Tags are the tags the instances of the services must all have to be discovered
+	Tags []string `yaml:"tags,omitempty"`
+
+	// Template is the name of the endpoint template each instance is an instance of. The parameters of the template
+	// are service, id, node, address, port and datacenter, as well as meta-<key> for each metadata of the instance.
+	Template string `yaml:"template,omitempty"`
+
+	httpClient *http.Client
+}
+
+// ValidateAndSetDefaults validates the Consul discovery configuration and sets the default values if necessary
+func (c *ConsulConfig) ValidateAndSetDefaults() error {
+	if len(c.Address) == 0 {
+		if c.Address = os.Getenv("CONSUL_HTTP_ADDR"); len(c.Address) == 0 {
+			c.Address = DefaultConsulAddress
+		}
+	}
+	if !strings.HasPrefix(c.Address, "http://") && !strings.HasPrefix(c.Address, "https://") {
+		c.Address = "http://" + c.Address
+	}
+	if len(c.Token) == 0 {
+		c.Token = os.Getenv("CONSUL_HTTP_TOKEN")
+	}
+	c.httpClient = &http.Client{Timeout: consulRequestTimeout}
+	return nil
+}
+
+// consulServiceInstance is the part of an instance of a service of the catalog of Consul the endpoints are discovered
+// from
+type consulServiceInstance struct {
+	Node           string            `json:"Node"`
+	Address        string            `json:"Address"`
+	Datacenter     string            `json:"Datacenter"`
+	ServiceID      string            `json:"ServiceID"`
+	ServiceName    string            `json:"ServiceName"`
+	ServiceAddress string            `json:"ServiceAddress"`
+	ServicePort    int               `json:"ServicePort"`
+	ServiceTags    []string          `json:"ServiceTags"`
+	ServiceMeta    map[string]string `json:"ServiceMeta"`
+}
+
+// discover returns the endpoints of the instances of the services, instantiating the template with the function
+// passed if the template is set
+func (c *ConsulConfig) discover(instantiateTemplate TemplateInstantiator) ([]*endpoint.Endpoint, error) {
+	services := c.Services
+	if len(services) == 0 {
+		var tagsByService map[string][]string
+		if err := c.get("/v1/catalog/services", &tagsByService); err != nil {
+			return nil, err
+		}
+		for service := range tagsByService {
+			services = append(services, service)
+		}
+		slices.Sort(services)
+	}
+	var endpoints []*endpoint.Endpoint
+	for _, service := range services {
+		var instances []consulServiceInstance
+		if err := c.get("/v1/catalog/service/"+url.PathEscape(service), &instances); err != nil {
+			return nil, err
+		}
+		for _, instance := range instances {
+			if !c.hasTags(instance) {
+				continue
+			}
+			address := instance.ServiceAddress
+			if len(address) == 0 {
+				address = instance.Address
+			}
+			source := "instance " + instance.ServiceID + " of service " + instance.ServiceName
+			var ep *endpoint.Endpoint
+			if len(c.Template) > 0 {
+				ep = validateEndpoint(source, func() (*endpoint.Endpoint, error) {
+					return c.instantiate(instantiateTemplate, instance, address)
+				})
+			} else {
+				metadata := getMetadata(instance.ServiceMeta, ConsulMetadataPrefix)
+				if metadata == nil {
+					metadata = map[string]string{}
+				}
+				ep = newEndpoint(source, metadata, defaults{
+					name:  instance.ServiceID,
+					group: instance.ServiceName,
+					host:  address,
+					port:  formatPort(instance.ServicePort),
+				})
+			}
+			if ep != nil {
+				endpoints = append(endpoints, ep)
+			}
+		}
+	}
+	return endpoints, nil
+}
+
+// instantiate returns the endpoint of the instance passed, as an instance of the template. The name and the group of
+// the endpoint default to the ID of the instance and to the name of its service, if the template doesn't set them.
+func (c *ConsulConfig) instantiate(instantiateTemplate TemplateInstantiator, instance consulServiceInstance, address string) (*endpoint.Endpoint, error) {
+	if instantiateTemplate == nil {
+		return nil, fmt.Errorf("template %s cannot be instantiated", c.Template)
+	}
+	parameters := map[string]any{
+		"service":    instance.ServiceName,
+		"id":         instance.ServiceID,
+		"node":       instance.Node,
+		"address":    address,
+		"port":       instance.ServicePort,
+		"datacenter": instance.Datacenter,
+	}
+	for key, value := range instance.ServiceMeta {
+		parameters["meta-"+key] = value
+	}
+	ep, err := instantiateTemplate(c.Template, parameters)
+	if err != nil {
+		return nil, err
+	}
+	if len(ep.Name) == 0 {
+		ep.Name = instance.ServiceID
+	}
+	if len(ep.Group) == 0 {
+		ep.Group = instance.ServiceName
+	}
+	return ep, nil
+}
+
+// hasTags returns whether the instance passed has all the tags the instances must have
+func (c *ConsulConfig) hasTags(instance consulServiceInstance) bool {
+	for _, tag := range c.Tags {
+		if !slices.Contains(instance.ServiceTags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// get decodes the response of the HTTP API of Consul to a GET request on the path passed into the value passed
+func (c *ConsulConfig) get(path string, value any) error {
+	if len(c.Datacenter) > 0 {
+		path += "?dc=" + url.QueryEscape(c.Datacenter)
+	}
+	request, err := http.NewRequest(http.MethodGet, strings.TrimSuffix(c.Address, "/")+path, http.NoBody)
+	if err != nil {
+		return err
+	}
+	if len(c.Token) > 0 {
+		request.Header.Set("X-Consul-Token", c.Token)
+	}
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("unable to get %s: %w", path, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("unable to get %s: unexpected status code %d", path, response.StatusCode)
+	}
+	if err = json.NewDecoder(response.Body).Decode(value); err != nil {
+		return fmt.Errorf("unable to get %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package discovery
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+)
+
+func newTestConsulServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Consul-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/catalog/services":
+			_, _ = w.Write([]byte(`{"consul":[],"api":["http","production"],"cache":["tcp"]}`))
+		case "/v1/catalog/service/api":
+			_, _ = w.Write([]byte(`[
+  {"Node":"node-1","Address":"10.0.0.1","Datacenter":"dc1","ServiceID":"api-1","ServiceName":"api","ServiceAddress":"","ServicePort":8080,"ServiceTags":["http","production"],"ServiceMeta":{"gatus-path":"/health","version":"1.2.3"}},
+  {"Node":"node-2","Address":"10.0.0.2","Datacenter":"dc1","ServiceID":"api-2","ServiceName":"api","ServiceAddress":"10.1.0.2","ServicePort":8080,"ServiceTags":["http"],"ServiceMeta":{}}
+]`))
+		case "/v1/catalog/service/cache":
+			_, _ = w.Write([]byte(`[{"Node":"node-1","Address":"10.0.0.1","Datacenter":"dc1","ServiceID":"cache-1","ServiceName":"cache","ServicePort":6379,"ServiceTags":["tcp"],"ServiceMeta":{"gatus-url":"tcp://10.0.0.1:6379"}}]`))
+		case "/v1/catalog/service/consul":
+			_, _ = w.Write([]byte(`[{"Node":"node-1","Address":"10.0.0.1","Datacenter":"dc1","ServiceID":"consul","ServiceName":"consul","ServicePort":8300,"ServiceMeta":{"gatus-enabled":"false"}}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestConsulConfig_discover(t *testing.T) {
+	server := newTestConsulServer(t)
+	cfg := &Config{Consul: &ConsulConfig{Address: server.URL, Token: "token"}}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	endpoints, err := cfg.Discover()
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(endpoints) != 3 {
+		t.Fatalf("expected 3 endpoints, got %d", len(endpoints))
+	}
+	if endpoints[0].Key() != "api_api-1" || endpoints[0].URL != "http://10.0.0.1:8080/health" {
+		t.Errorf("expected the endpoint of the first instance at the address of its node, got %+v", endpoints[0])
+	}
+	if endpoints[1].Key() != "api_api-2" || endpoints[1].URL != "http://10.1.0.2:8080" {
+		t.Errorf("expected the endpoint of the second instance at the address of the service, got %+v", endpoints[1])
+	}
+	if endpoints[2].Key() != "cache_cache-1" || endpoints[2].URL != "tcp://10.0.0.1:6379" {
+		t.Errorf("expected the url of the metadata, got %+v", endpoints[2])
+	}
+	cfg.Consul.Services, cfg.Consul.Tags = []string{"api"}, []string{"production"}
+	if endpoints, err = cfg.Discover(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(endpoints) != 1 || endpoints[0].Key() != "api_api-1" {
+		t.Errorf("expected only the instance with the tags, got %d endpoints", len(endpoints))
+	}
+	cfg.Consul.Token = "invalid"
+	if _, err = cfg.Discover(); err == nil {
+		t.Error("expected an error, because the token is invalid")
+	}
+}
+
+func TestConsulConfig_discoverWithTemplate(t *testing.T) {
+	server := newTestConsulServer(t)
+	cfg := &Config{Consul: &ConsulConfig{Address: server.URL, Token: "token", Services: []string{"api"}, Template: "consul-service"}}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if templates := cfg.GetTemplates(); len(templates) != 1 || templates[0] != "consul-service" {
+		t.Errorf("expected the template of consul, got %v", templates)
+	}
+	if endpoints, _ := cfg.Discover(); len(endpoints) != 0 {
+		t.Errorf("expected the instances to be ignored without a template instantiator, got %d endpoints", len(endpoints))
+	}
+	var parameters []map[string]any
+	cfg.SetTemplateInstantiator(func(name string, p map[string]any) (*endpoint.Endpoint, error) {
+		if name != "consul-service" {
+			return nil, errors.New("unexpected template")
+		}
+		parameters = append(parameters, p)
+		if p["id"] == "api-2" {
+			return nil, errors.New("parameter meta-version of template consul-service is not set")
+		}
+		return &endpoint.Endpoint{URL: fmt.Sprintf("https://%s:%d/version/%s", p["address"], p["port"], p["meta-version"]), Conditions: []endpoint.Condition{"[STATUS] == 200"}}, nil
+	})
+	endpoints, err := cfg.Discover()
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(endpoints) != 1 {
+		t.Fatalf("expected the instance whose template couldn't be instantiated to be ignored, got %d endpoints", len(endpoints))
+	}
+	if endpoints[0].Key() != "api_api-1" || endpoints[0].URL != "https://10.0.0.1:8080/version/1.2.3" {
+		t.Errorf("expected the instance of the template, got %+v", endpoints[0])
+	}
+	if parameters[0]["service"] != "api" || parameters[0]["node"] != "node-1" || parameters[0]["datacenter"] != "dc1" {
+		t.Errorf("expected the parameters of the instance, got %v", parameters[0])
+	}
+}
+
+func TestConsulConfig_ValidateAndSetDefaults(t *testing.T) {
+	t.Setenv("CONSUL_HTTP_ADDR", "")
+	t.Setenv("CONSUL_HTTP_TOKEN", "token")
+	cfg := &ConsulConfig{}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if cfg.Address != DefaultConsulAddress || cfg.Token != "token" {
+		t.Errorf("expected the defaults, got address=%s and token=%s", cfg.Address, cfg.Token)
+	}
+	t.Setenv("CONSUL_HTTP_ADDR", "consul.service.consul:8500")
+	cfg = &ConsulConfig{}
+	_ = cfg.ValidateAndSetDefaults()
+	if cfg.Address != "http://consul.service.consul:8500" {
+		t.Errorf("expected the address of CONSUL_HTTP_ADDR with a scheme, got %s", cfg.Address)
+	}
+}
@@ -0,0 +1,367 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultInterval is the default interval at which the endpoints are discovered again
+	DefaultInterval = time.Minute
+
+	// MinimumInterval is the minimum interval at which the endpoints may be discovered again
+	MinimumInterval = 10 * time.Second
+
+	// Keys of the metadata, such as the annotations of Kubernetes, describing the endpoint to create, without the
+	// prefix of the source
+	metadataEnabled    = "enabled"
+	metadataName       = "name"
+	metadataGroup      = "group"
+	metadataURL        = "url"
+	metadataScheme     = "scheme"
+	metadataPort       = "port"
+	metadataPath       = "path"
+	metadataInterval   = "interval"
+	metadataConditions = "conditions"
+	metadataAlerts     = "alerts"
+)
+
+var (
+	// ErrInvalidInterval is an error returned when the interval of the discovery is too short
+	ErrInvalidInterval = fmt.Errorf("discovery.interval must be %s or higher", MinimumInterval)
+
+	// ErrNoSource is an error returned when the discovery has no source to discover the endpoints from
+	ErrNoSource = errors.New("discovery must have at least one source")
+
+	// ctx and cancelFunc are used to stop the discovery started by Watch
+	ctx        context.Context
+	cancelFunc context.CancelFunc
+
+	// discovered is the fingerprint of the endpoints last discovered by Refresh, which is the endpoints marshalled, so
+	// that every configuration loaded afterward gets its own copy of them through GetEndpoints
+	discovered      string
+	discoveredMutex sync.RWMutex
+)
+
+// Config is the configuration of the discovery of the endpoints to monitor at runtime, e.g. from the annotations of
+// the Kubernetes services, in addition to the endpoints of the configuration.
+type Config struct {
+	// Interval is the interval at which the endpoints are discovered again, in order to monitor the new ones and stop
+	// monitoring the ones that no longer exist
+	Interval time.Duration `yaml:"interval,omitempty"`
+
+	// Kubernetes is the configuration of the discovery of the endpoints from the services and ingresses of Kubernetes
+	Kubernetes *KubernetesConfig `yaml:"kubernetes,omitempty"`
+
+	// Docker is the configuration of the discovery of the endpoints from the containers of Docker
+	Docker *DockerConfig `yaml:"docker,omitempty"`
+
+	// Consul is the configuration of the discovery of the endpoints from the instances of the services of the catalog
+	// of Consul
+	Consul *ConsulConfig `yaml:"consul,omitempty"`
+
+	// File is the configuration of the discovery of the endpoints from a directory of endpoint definition fragments
+	File *FileConfig `yaml:"file,omitempty"`
+
+	instantiateTemplate TemplateInstantiator
+}
+
+// TemplateInstantiator returns the endpoint resulting from instantiating the endpoint template whose name is passed
+// with the parameters passed
+type TemplateInstantiator func(name string, parameters map[string]any) (*endpoint.Endpoint, error)
+
+// ValidateAndSetDefaults validates the discovery configuration and sets the default values if necessary
+func (c *Config) ValidateAndSetDefaults() error {
+	if c.Interval == 0 {
+		c.Interval = DefaultInterval
+	} else if c.Interval < MinimumInterval {
+		return ErrInvalidInterval
+	}
+	if c.Kubernetes == nil && c.Docker == nil && c.Consul == nil && c.File == nil {
+		return ErrNoSource
+	}
+	if c.Kubernetes != nil {
+		if err := c.Kubernetes.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+	}
+	if c.Docker != nil {
+		if err := c.Docker.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+	}
+	if c.Consul != nil {
+		if err := c.Consul.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+	}
+	if c.File != nil {
+		if err := c.File.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetTemplates returns the names of the endpoint templates the sources instantiate for the endpoints they discover
+func (c *Config) GetTemplates() []string {
+	var templates []string
+	if c.Consul != nil && len(c.Consul.Template) > 0 {
+		templates = append(templates, c.Consul.Template)
+	}
+	return templates
+}
+
+// SetTemplateInstantiator sets the function the sources instantiate the endpoint templates returned by GetTemplates
+// with
+func (c *Config) SetTemplateInstantiator(instantiateTemplate TemplateInstantiator) {
+	c.instantiateTemplate = instantiateTemplate
+}
+
+// Discover returns the endpoints discovered from all sources, sorted by key. The endpoints that are invalid, or whose
+// key is the same as the one of an endpoint discovered before, are ignored.
+func (c *Config) Discover() ([]*endpoint.Endpoint, error) {
+	var endpoints []*endpoint.Endpoint
+	if c.Kubernetes != nil {
+		discovered, err := c.Kubernetes.discover()
+		if err != nil {
+			return nil, fmt.Errorf("unable to discover endpoints from kubernetes: %w", err)
+		}
+		endpoints = append(endpoints, discovered...)
+	}
+	if c.Docker != nil {
+		discovered, err := c.Docker.discover()
+		if err != nil {
+			return nil, fmt.Errorf("unable to discover endpoints from docker: %w", err)
+		}
+		endpoints = append(endpoints, discovered...)
+	}
+	if c.Consul != nil {
+		discovered, err := c.Consul.discover(c.instantiateTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("unable to discover endpoints from consul: %w", err)
+		}
+		endpoints = append(endpoints, discovered...)
+	}
+	if c.File != nil {
+		discovered, err := c.File.discover()
+		if err != nil {
+			return nil, fmt.Errorf("unable to discover endpoints from files: %w", err)
+		}
+		endpoints = append(endpoints, discovered...)
+	}
+	keys := make(map[string]bool, len(endpoints))
+	var uniqueEndpoints []*endpoint.Endpoint
+	for _, ep := range endpoints {
+		if key := ep.Key(); keys[key] {
+			log.Printf("[discovery.Discover] Ignoring discovered endpoint with key=%s, because an endpoint with the same key was already discovered", key)
+		} else {
+			keys[key] = true
+			uniqueEndpoints = append(uniqueEndpoints, ep)
+		}
+	}
+	sort.Slice(uniqueEndpoints, func(i, j int) bool {
+		return uniqueEndpoints[i].Key() < uniqueEndpoints[j].Key()
+	})
+	return uniqueEndpoints, nil
+}
+
+// Fingerprint returns a string that changes whenever the endpoints discovered passed change, so that the changes can
+// be detected without comparing the endpoints themselves
+func Fingerprint(endpoints []*endpoint.Endpoint) string {
+	output, err := yaml.Marshal(endpoints)
+	if err != nil {
+		return ""
+	}
+	return string(output)
+}
+
+// Refresh discovers the endpoints from the sources of the configuration passed, and keeps them so that GetEndpoints
+// returns them until they're discovered again. Returns whether they changed since they were last discovered. If they
+// cannot be discovered, the endpoints discovered before are kept and the error is returned.
+func Refresh(c *Config) (bool, error) {
+	endpoints, err := c.Discover()
+	if err != nil {
+		return false, err
+	}
+	fingerprint := Fingerprint(endpoints)
+	discoveredMutex.Lock()
+	defer discoveredMutex.Unlock()
+	if fingerprint == discovered {
+		return false, nil
+	}
+	discovered = fingerprint
+	return true, nil
+}
+
+// GetEndpoints returns a copy of the endpoints last discovered by Refresh, if any, without discovering them again
+func GetEndpoints() []*endpoint.Endpoint {
+	discoveredMutex.RLock()
+	defer discoveredMutex.RUnlock()
+	var endpoints []*endpoint.Endpoint
+	if err := yaml.Unmarshal([]byte(discovered), &endpoints); err != nil {
+		log.Printf("[discovery.GetEndpoints] Failed to copy the endpoints discovered: %s", err.Error())
+		return nil
+	}
+	return endpoints
+}
+
+// Watch refreshes the endpoints discovered, through Refresh, at the interval of the configuration passed in the
+// background, and calls onChange every time they change, until Shutdown is called
+func Watch(c *Config, onChange func()) {
+	ctx, cancelFunc = context.WithCancel(context.Background())
+	go watch(ctx, c, onChange)
+}
+
+func watch(ctx context.Context, c *Config, onChange func()) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(c.Interval):
+		}
+		changed, err := Refresh(c)
+		if err != nil {
+			log.Printf("[discovery.watch] Failed to discover endpoints, the endpoints discovered before keep being monitored: %s", err.Error())
+			continue
+		}
+		if changed {
+			log.Printf("[discovery.watch] The endpoints discovered have changed")
+			onChange()
+		}
+	}
+}
+
+// Shutdown stops the discovery started by Watch
+func Shutdown() {
+	if cancelFunc != nil {
+		cancelFunc()
+		cancelFunc = nil
+	}
+}
+
+// defaults are the values of an endpoint discovered that the metadata of its source doesn't override
+type defaults struct {
+	name   string
+	group  string
+	scheme string
+	host   string
+	port   string
+	path   string
+}
+
+// newEndpoint returns the endpoint described by the metadata passed, whose keys are stripped of the prefix of the
+// source, or nil if the metadata disables it. The endpoint is validated, so that an invalid endpoint discovered cannot
+// invalidate the whole configuration, but its defaults are left for the configuration to set like for the other
+// endpoints.
+func newEndpoint(source string, metadata map[string]string, d defaults) *endpoint.Endpoint {
+	if enabled, exists := metadata[metadataEnabled]; exists && enabled != "true" {
+		return nil
+	}
+	return validateEndpoint(source, func() (*endpoint.Endpoint, error) {
+		return parseEndpoint(metadata, d)
+	})
+}
+
+// validateEndpoint returns the endpoint created by the function passed, or nil if it's invalid. Since validating an
+// endpoint sets its defaults, a copy of the endpoint, created by calling the function a second time, is validated.
+func validateEndpoint(source string, create func() (*endpoint.Endpoint, error)) *endpoint.Endpoint {
+	ep, err := create()
+	if err == nil {
+		validatedEndpoint, _ := create()
+		err = validatedEndpoint.ValidateAndSetDefaults()
+	}
+	if err != nil {
+		log.Printf("[discovery.validateEndpoint] Ignoring endpoint discovered from %s: %s", source, err.Error())
+		return nil
+	}
+	return ep
+}
+
+// parseEndpoint returns the endpoint described by the metadata passed
+func parseEndpoint(metadata map[string]string, d defaults) (*endpoint.Endpoint, error) {
+	ep := &endpoint.Endpoint{
+		Name:  valueOrDefault(metadata[metadataName], d.name),
+		Group: valueOrDefault(metadata[metadataGroup], d.group),
+		URL:   metadata[metadataURL],
+	}
+	if len(ep.URL) == 0 {
+		scheme := valueOrDefault(metadata[metadataScheme], d.scheme)
+		if len(scheme) == 0 {
+			scheme = "http"
+		}
+		host := d.host
+		if port := valueOrDefault(metadata[metadataPort], d.port); len(port) > 0 {
+			host += ":" + port
+		}
+		path := valueOrDefault(metadata[metadataPath], d.path)
+		if len(path) > 0 && !strings.HasPrefix(path, "/") {
+			path = "/" + path
+		}
+		ep.URL = scheme + "://" + host + path
+	}
+	if interval, exists := metadata[metadataInterval]; exists {
+		var err error
+		if ep.Interval, err = time.ParseDuration(interval); err != nil {
+			return nil, fmt.Errorf("invalid interval %s: %w", interval, err)
+		}
+	}
+	for _, condition := range strings.Split(metadata[metadataConditions], "\n") {
+		if condition = strings.TrimSpace(condition); len(condition) > 0 {
+			ep.Conditions = append(ep.Conditions, endpoint.Condition(condition))
+		}
+	}
+	if len(ep.Conditions) == 0 {
+		if strings.HasPrefix(ep.URL, "http://") || strings.HasPrefix(ep.URL, "https://") {
+			ep.Conditions = []endpoint.Condition{"[STATUS] == 200"}
+		} else {
+			ep.Conditions = []endpoint.Condition{"[CONNECTED] == true"}
+		}
+	}
+	for _, alertType := range strings.Split(metadata[metadataAlerts], ",") {
+		if alertType = strings.TrimSpace(alertType); len(alertType) > 0 {
+			ep.Alerts = append(ep.Alerts, &alert.Alert{Type: alert.Type(alertType)})
+		}
+	}
+	return ep, nil
+}
+
+// getMetadata returns the metadata passed whose key has the prefix passed, stripped of the prefix
+func getMetadata(metadata map[string]string, prefix string) map[string]string {
+	var stripped map[string]string
+	for key, value := range metadata {
+		if strings.HasPrefix(key, prefix) {
+			if stripped == nil {
+				stripped = make(map[string]string)
+			}
+			stripped[strings.TrimPrefix(key, prefix)] = value
+		}
+	}
+	return stripped
+}
+
+func valueOrDefault(value, defaultValue string) string {
+	if len(value) == 0 {
+		return defaultValue
+	}
+	return value
+}
+
+// formatPort returns the port passed as a string, or an empty string if it's not set
+func formatPort(port int) string {
+	if port <= 0 {
+		return ""
+	}
+	return strconv.Itoa(port)
+}
@@ -0,0 +1,174 @@
+package discovery
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	t.Setenv("KUBERNETES_SERVICE_HOST", "10.0.0.1")
+	t.Setenv("KUBERNETES_SERVICE_PORT", "443")
+	cfg := &Config{Kubernetes: &KubernetesConfig{}}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if cfg.Interval != DefaultInterval {
+		t.Errorf("expected the default interval, got %s", cfg.Interval)
+	}
+	if err := (&Config{Interval: time.Second, Kubernetes: &KubernetesConfig{}}).ValidateAndSetDefaults(); !errors.Is(err, ErrInvalidInterval) {
+		t.Errorf("expected %v, got %v", ErrInvalidInterval, err)
+	}
+	if err := (&Config{}).ValidateAndSetDefaults(); !errors.Is(err, ErrNoSource) {
+		t.Errorf("expected %v, got %v", ErrNoSource, err)
+	}
+}
+
+func TestNewEndpoint(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		metadata    map[string]string
+		defaults    defaults
+		expectedURL string
+		expectedNil bool
+	}{
+		{
+			name:        "defaults",
+			metadata:    map[string]string{},
+			defaults:    defaults{name: "api", group: "backend", host: "api.backend.svc", port: "8080"},
+			expectedURL: "http://api.backend.svc:8080",
+		},
+		{
+			name:        "scheme-port-and-path",
+			metadata:    map[string]string{"scheme": "https", "port": "8443", "path": "health"},
+			defaults:    defaults{name: "api", host: "api.backend.svc", port: "8080"},
+			expectedURL: "https://api.backend.svc:8443/health",
+		},
+		{
+			name:        "url",
+			metadata:    map[string]string{"url": "https://example.org/health", "path": "/ignored"},
+			defaults:    defaults{name: "api", host: "api.backend.svc"},
+			expectedURL: "https://example.org/health",
+		},
+		{
+			name:        "enabled",
+			metadata:    map[string]string{"enabled": "true"},
+			defaults:    defaults{name: "api", host: "api.backend.svc"},
+			expectedURL: "http://api.backend.svc",
+		},
+		{
+			name:        "disabled",
+			metadata:    map[string]string{"enabled": "false"},
+			defaults:    defaults{name: "api", host: "api.backend.svc"},
+			expectedNil: true,
+		},
+		{
+			name:        "invalid-interval",
+			metadata:    map[string]string{"interval": "often"},
+			defaults:    defaults{name: "api", host: "api.backend.svc"},
+			expectedNil: true,
+		},
+		{
+			name:        "invalid-name",
+			metadata:    map[string]string{"name": `"api"`},
+			defaults:    defaults{host: "api.backend.svc"},
+			expectedNil: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			ep := newEndpoint("test", scenario.metadata, scenario.defaults)
+			if scenario.expectedNil {
+				if ep != nil {
+					t.Errorf("expected no endpoint, got %+v", ep)
+				}
+				return
+			}
+			if ep == nil {
+				t.Fatal("expected an endpoint, got nil")
+			}
+			if ep.URL != scenario.expectedURL {
+				t.Errorf("expected url %s, got %s", scenario.expectedURL, ep.URL)
+			}
+		})
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	a := []*endpoint.Endpoint{{Name: "api", URL: "https://example.org"}}
+	b := []*endpoint.Endpoint{{Name: "api", URL: "https://example.org"}}
+	if Fingerprint(a) != Fingerprint(b) {
+		t.Error("expected the fingerprints of the same endpoints to be equal")
+	}
+	b[0].Interval = time.Minute
+	if Fingerprint(a) == Fingerprint(b) {
+		t.Error("expected the fingerprints of different endpoints to differ")
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	defer func() { discovered = "" }()
+	discovered = ""
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "api.yaml"), []byte("endpoints:\n  - name: api\n    url: https://example.org\n    conditions: [\"[STATUS] == 200\"]\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &Config{File: &FileConfig{Directory: dir}}
+	if changed, err := Refresh(cfg); err != nil || !changed {
+		t.Fatalf("expected the endpoints discovered to have changed, got changed=%v and err=%v", changed, err)
+	}
+	if changed, err := Refresh(cfg); err != nil || changed {
+		t.Errorf("expected the endpoints discovered not to have changed, got changed=%v and err=%v", changed, err)
+	}
+	endpoints := GetEndpoints()
+	if len(endpoints) != 1 || endpoints[0].Key() != "_api" || endpoints[0].URL != "https://example.org" {
+		t.Fatalf("expected the endpoint discovered, got %+v", endpoints)
+	}
+	if GetEndpoints()[0] == endpoints[0] {
+		t.Error("expected every call to return its own copy of the endpoints discovered")
+	}
+	cfg.File.Directory = filepath.Join(dir, "missing")
+	if changed, err := Refresh(cfg); err == nil || changed {
+		t.Errorf("expected an error, because the directory doesn't exist, got changed=%v and err=%v", changed, err)
+	}
+	if endpoints = GetEndpoints(); len(endpoints) != 1 {
+		t.Errorf("expected the endpoints discovered before to be kept, got %d endpoints", len(endpoints))
+	}
+}
+
+func TestWatch(t *testing.T) {
+	defer func() { discovered = "" }()
+	discovered = ""
+	cfg := &Config{Interval: 10 * time.Millisecond, Kubernetes: &KubernetesConfig{APIServerURL: "http://127.0.0.1:0", TokenFile: "/nonexistent"}}
+	_ = cfg.Kubernetes.ValidateAndSetDefaults()
+	changes := make(chan bool, 1)
+	Watch(cfg, func() {
+		select {
+		case changes <- true:
+		default:
+		}
+	})
+	defer Shutdown()
+	select {
+	case <-changes:
+		t.Fatal("expected no change to be detected, because the endpoints couldn't be discovered")
+	case <-time.After(50 * time.Millisecond):
+	}
+	Shutdown()
+	cfg = &Config{Interval: 10 * time.Millisecond, Kubernetes: &KubernetesConfig{}}
+	Watch(cfg, func() {
+		select {
+		case changes <- true:
+		default:
+		}
+	})
+	select {
+	case <-changes:
+	case <-time.After(time.Second):
+		t.Fatal("expected a change to be detected, because no endpoint was discovered")
+	}
+}
@@ -0,0 +1,144 @@
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+)
+
+const (
+	// DockerLabelPrefix is the prefix of the labels of the Docker containers describing the endpoint to monitor, e.g.
+	// gatus.interval
+	DockerLabelPrefix = "gatus."
+
+	// DefaultDockerHost is the default address of the Docker daemon
+	DefaultDockerHost = "unix:///var/run/docker.sock"
+
+	dockerComposeProjectLabel = "com.docker.compose.project"
+	dockerComposeServiceLabel = "com.docker.compose.service"
+
+	dockerRequestTimeout = 10 * time.Second
+)
+
+// ErrInvalidDockerHost is an error returned when the address of the Docker daemon is invalid
+var ErrInvalidDockerHost = errors.New("discovery.docker.host must start with unix://, tcp://, http:// or https://")
+
+// DockerConfig is the configuration of the discovery of the endpoints from the labels of the Docker containers.
+//
+// The containers with at least one label prefixed by DockerLabelPrefix are monitored, unless gatus.enabled is set to
+// anything other than "true".
+type DockerConfig struct {
+	// Host is the address of the Docker daemon, e.g. unix:///var/run/docker.sock or tcp://127.0.0.1:2375. Defaults to
+	// the DOCKER_HOST environment variable, or to DefaultDockerHost if it's not set.
+	Host string `yaml:"host,omitempty"`
+
+	// Network is the network whose IP address of the containers the endpoints monitor. If empty, the endpoints monitor
+	// the containers by name, which Docker resolves for the containers sharing a network with Gatus.
+	Network string `yaml:"network,omitempty"`
+
+	baseURL    string
+	httpClient *http.Client
+}
+
+// ValidateAndSetDefaults validates the Docker discovery configuration and sets the default values if necessary
+func (c *DockerConfig) ValidateAndSetDefaults() error {
+	if len(c.Host) == 0 {
+		if c.Host = os.Getenv("DOCKER_HOST"); len(c.Host) == 0 {
+			c.Host = DefaultDockerHost
+		}
+	}
+	transport := &http.Transport{}
+	switch {
+	case strings.HasPrefix(c.Host, "unix://"):
+		socket := strings.TrimPrefix(c.Host, "unix://")
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", socket)
+		}
+		c.baseURL = "http://docker"
+	case strings.HasPrefix(c.Host, "tcp://"):
+		c.baseURL = "http://" + strings.TrimPrefix(c.Host, "tcp://")
+	case strings.HasPrefix(c.Host, "http://"), strings.HasPrefix(c.Host, "https://"):
+		c.baseURL = strings.TrimSuffix(c.Host, "/")
+	default:
+		return ErrInvalidDockerHost
+	}
+	c.httpClient = &http.Client{Timeout: dockerRequestTimeout, Transport: transport}
+	return nil
+}
+
+// dockerContainer is the part of a container listed by the Docker daemon the endpoints are discovered from
+type dockerContainer struct {
+	ID     string            `json:"Id"`
+	Names  []string          `json:"Names"`
+	Labels map[string]string `json:"Labels"`
+	Ports  []struct {
+		PrivatePort int `json:"PrivatePort"`
+	} `json:"Ports"`
+	NetworkSettings struct {
+		Networks map[string]struct {
+			IPAddress string `json:"IPAddress"`
+		} `json:"Networks"`
+	} `json:"NetworkSettings"`
+}
+
+// discover returns the endpoints described by the labels of the containers. Stopped containers are also discovered,
+// so that the endpoints of the containers that exited keep being monitored until the containers are removed.
+func (c *DockerConfig) discover() ([]*endpoint.Endpoint, error) {
+	request, err := http.NewRequest(http.MethodGet, c.baseURL+"/containers/json?all=1", http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list containers: %w", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to list containers: unexpected status code %d", response.StatusCode)
+	}
+	var containers []dockerContainer
+	if err = json.NewDecoder(response.Body).Decode(&containers); err != nil {
+		return nil, fmt.Errorf("unable to list containers: %w", err)
+	}
+	var endpoints []*endpoint.Endpoint
+	for _, container := range containers {
+		metadata := getMetadata(container.Labels, DockerLabelPrefix)
+		if metadata == nil {
+			continue
+		}
+		name := container.ID
+		if len(container.Names) > 0 {
+			name = strings.TrimPrefix(container.Names[0], "/")
+		}
+		d := defaults{name: name, group: container.Labels[dockerComposeProjectLabel], host: name}
+		if service := container.Labels[dockerComposeServiceLabel]; len(service) > 0 {
+			d.name = service
+		}
+		if len(c.Network) > 0 {
+			if network, exists := container.NetworkSettings.Networks[c.Network]; exists && len(network.IPAddress) > 0 {
+				d.host = network.IPAddress
+			}
+		}
+		// The order of the ports isn't guaranteed, so the lowest one is used for the endpoint not to change every time
+		// the containers are listed
+		lowestPort := 0
+		for _, port := range container.Ports {
+			if lowestPort == 0 || (port.PrivatePort > 0 && port.PrivatePort < lowestPort) {
+				lowestPort = port.PrivatePort
+			}
+		}
+		d.port = formatPort(lowestPort)
+		if ep := newEndpoint("container "+name, metadata, d); ep != nil {
+			endpoints = append(endpoints, ep)
+		}
+	}
+	return endpoints, nil
+}
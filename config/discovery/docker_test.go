@@ -0,0 +1,102 @@
+package discovery
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+const testDockerContainers = `[
+  {"Id":"1","Names":["/homelab-jellyfin-1"],"Labels":{"gatus.path":"/health","gatus.alerts":"discord","com.docker.compose.project":"homelab","com.docker.compose.service":"jellyfin"},"Ports":[{"PrivatePort":8920},{"PrivatePort":8096}],"NetworkSettings":{"Networks":{"homelab_default":{"IPAddress":"172.18.0.2"}}}},
+  {"Id":"2","Names":["/pihole"],"Labels":{"gatus.url":"tcp://pihole:53","gatus.group":"dns"},"Ports":[]},
+  {"Id":"3","Names":["/watchtower"],"Labels":{"gatus.enabled":"false"}},
+  {"Id":"4","Names":["/postgres"],"Labels":{"maintainer":"someone"}}
+]`
+
+func TestDockerConfig_discover(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/containers/json" || r.URL.Query().Get("all") != "1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(testDockerContainers))
+	}))
+	defer server.Close()
+	cfg := &Config{Docker: &DockerConfig{Host: server.URL}}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	endpoints, err := cfg.Discover()
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(endpoints) != 2 {
+		t.Fatalf("expected 2 endpoints, got %d", len(endpoints))
+	}
+	pihole, jellyfin := endpoints[0], endpoints[1]
+	if jellyfin.Key() != "homelab_jellyfin" || jellyfin.URL != "http://homelab-jellyfin-1:8096/health" {
+		t.Errorf("expected the endpoint of the compose service on its lowest port, got %+v", jellyfin)
+	}
+	if len(jellyfin.Alerts) != 1 || jellyfin.Alerts[0].Type != "discord" {
+		t.Errorf("expected the alert of the label, got %+v", jellyfin.Alerts)
+	}
+	if pihole.Key() != "dns_pihole" || pihole.URL != "tcp://pihole:53" || pihole.Conditions[0] != "[CONNECTED] == true" {
+		t.Errorf("expected the url and the group of the labels, got %+v", pihole)
+	}
+	cfg.Docker.Network = "homelab_default"
+	if endpoints, err = cfg.Discover(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if endpoints[1].URL != "http://172.18.0.2:8096/health" {
+		t.Errorf("expected the IP address of the container in the network, got %s", endpoints[1].URL)
+	}
+}
+
+func TestDockerConfig_discoverWithUnixSocket(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "docker.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Skip("unix sockets are not supported:", err.Error())
+	}
+	server := &httptest.Server{Listener: listener, Config: &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testDockerContainers))
+	})}}
+	server.Start()
+	defer server.Close()
+	cfg := &DockerConfig{Host: "unix://" + socket}
+	if err = cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	endpoints, err := cfg.discover()
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(endpoints) != 2 {
+		t.Errorf("expected 2 endpoints, got %d", len(endpoints))
+	}
+}
+
+func TestDockerConfig_ValidateAndSetDefaults(t *testing.T) {
+	t.Setenv("DOCKER_HOST", "")
+	cfg := &DockerConfig{}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if cfg.Host != DefaultDockerHost {
+		t.Errorf("expected the default host, got %s", cfg.Host)
+	}
+	t.Setenv("DOCKER_HOST", "tcp://127.0.0.1:2375")
+	cfg = &DockerConfig{}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if cfg.baseURL != "http://127.0.0.1:2375" {
+		t.Errorf("expected the host of DOCKER_HOST, got %s", cfg.baseURL)
+	}
+	if err := (&DockerConfig{Host: "ssh://user@host"}).ValidateAndSetDefaults(); !errors.Is(err, ErrInvalidDockerHost) {
+		t.Errorf("expected %v, got %v", ErrInvalidDockerHost, err)
+	}
+}
@@ -0,0 +1,83 @@
+package discovery
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrNoFileDirectory is an error returned when the directory of the endpoint definition fragments isn't set
+var ErrNoFileDirectory = errors.New("discovery.file.directory must be set")
+
+// FileConfig is the configuration of the discovery of the endpoints from a directory of endpoint definition fragments,
+// so that the endpoints can be managed by external generators, e.g. Ansible, independently of the configuration.
+//
+// Each YAML file of the directory, or of its subdirectories, lists endpoints under the endpoints key, with the same
+// parameters as the endpoints of the configuration. Files and directories whose name starts with a dot are ignored.
+type FileConfig struct {
+	// Directory is the directory of the endpoint definition fragments
+	Directory string `yaml:"directory"`
+}
+
+// ValidateAndSetDefaults validates the file discovery configuration
+func (c *FileConfig) ValidateAndSetDefaults() error {
+	if len(c.Directory) == 0 {
+		return ErrNoFileDirectory
+	}
+	return nil
+}
+
+// fileFragment is an endpoint definition fragment. The endpoints are decoded one by one, so that an invalid endpoint
+// doesn't prevent the others of the fragment from being discovered.
+type fileFragment struct {
+	Endpoints []yaml.Node `yaml:"endpoints"`
+}
+
+// discover returns the endpoints of the fragments of the directory. A fragment that cannot be parsed, e.g. because it
+// is being written, fails the discovery instead of being ignored, so that its endpoints don't stop being monitored.
+func (c *FileConfig) discover() ([]*endpoint.Endpoint, error) {
+	var endpoints []*endpoint.Endpoint
+	err := filepath.WalkDir(c.Directory, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != c.Directory && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() || (filepath.Ext(path) != ".yaml" && filepath.Ext(path) != ".yml") {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var fragment fileFragment
+		if err = yaml.Unmarshal(data, &fragment); err != nil {
+			return fmt.Errorf("unable to parse %s: %w", path, err)
+		}
+		for i := range fragment.Endpoints {
+			node := &fragment.Endpoints[i]
+			ep := validateEndpoint(fmt.Sprintf("endpoint %d of file %s", i+1, path), func() (*endpoint.Endpoint, error) {
+				ep := &endpoint.Endpoint{}
+				return ep, node.Decode(ep)
+			})
+			if ep != nil {
+				endpoints = append(endpoints, ep)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return endpoints, nil
+}
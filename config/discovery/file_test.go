@@ -0,0 +1,76 @@
+package discovery
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileConfig_discover(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"web.yaml": `
+endpoints:
+  - name: website
+    group: web
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+  - name: invalid
+    url: https://example.org
+`,
+		"databases/postgres.yml": `
+endpoints:
+  - name: postgres
+    url: tcp://postgres:5432
+    conditions:
+      - "[CONNECTED] == true"
+`,
+		"notes.txt":             "endpoints: [",
+		".hidden.yaml":          "endpoints: [",
+		"..2024_01_01/web.yaml": "endpoints: [",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cfg := &Config{File: &FileConfig{Directory: dir}}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	endpoints, err := cfg.Discover()
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(endpoints) != 2 {
+		t.Fatalf("expected the invalid endpoint and the ignored files to be ignored, got %d endpoints", len(endpoints))
+	}
+	if endpoints[0].Key() != "_postgres" || endpoints[1].Key() != "web_website" {
+		t.Errorf("expected the endpoints of the fragments, got %s and %s", endpoints[0].Key(), endpoints[1].Key())
+	}
+	if endpoints[1].Interval != 0 {
+		t.Errorf("expected the defaults of the endpoints to be left for the configuration to set, got interval=%s", endpoints[1].Interval)
+	}
+	if err = os.WriteFile(filepath.Join(dir, "web.yaml"), []byte("endpoints: ["), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = cfg.Discover(); err == nil {
+		t.Error("expected an error, because a fragment cannot be parsed")
+	}
+	cfg.File.Directory = filepath.Join(dir, "missing")
+	if _, err = cfg.Discover(); err == nil {
+		t.Error("expected an error, because the directory doesn't exist")
+	}
+}
+
+func TestFileConfig_ValidateAndSetDefaults(t *testing.T) {
+	if err := (&FileConfig{}).ValidateAndSetDefaults(); !errors.Is(err, ErrNoFileDirectory) {
+		t.Errorf("expected %v, got %v", ErrNoFileDirectory, err)
+	}
+}
@@ -0,0 +1,255 @@
+package discovery
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+)
+
+const (
+	// KubernetesAnnotationPrefix is the prefix of the annotations of the Kubernetes services and ingresses describing
+	// the endpoint to monitor, e.g. gatus.io/interval
+	KubernetesAnnotationPrefix = "gatus.io/"
+
+	// KubernetesResourceServices are the services of Kubernetes
+	KubernetesResourceServices = "services"
+
+	// KubernetesResourceIngresses are the ingresses of Kubernetes
+	KubernetesResourceIngresses = "ingresses"
+
+	defaultKubernetesServiceAccountDirectory = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+	kubernetesRequestTimeout = 10 * time.Second
+)
+
+var (
+	// ErrInvalidKubernetesResource is an error returned when a resource of Kubernetes to discover the endpoints from
+	// isn't supported
+	ErrInvalidKubernetesResource = fmt.Errorf("discovery.kubernetes.resources must only contain %s and %s", KubernetesResourceServices, KubernetesResourceIngresses)
+
+	// ErrKubernetesAPIServerNotFound is an error returned when the URL of the API server of Kubernetes isn't configured
+	// and Gatus isn't running in Kubernetes
+	ErrKubernetesAPIServerNotFound = errors.New("discovery.kubernetes.api-server-url must be set when not running in kubernetes")
+)
+
+// KubernetesConfig is the configuration of the discovery of the endpoints from the annotations of the services and of
+// the ingresses of Kubernetes.
+//
+// The services and the ingresses with at least one annotation prefixed by KubernetesAnnotationPrefix are monitored,
+// unless gatus.io/enabled is set to anything other than "true".
+type KubernetesConfig struct {
+	// APIServerURL is the URL of the API server of Kubernetes. Defaults to the API server of the cluster Gatus is
+	// running in.
+	APIServerURL string `yaml:"api-server-url,omitempty"`
+
+	// TokenFile is the path of the file holding the token to authenticate with. Defaults to the token of the service
+	// account of the pod Gatus is running in.
+	TokenFile string `yaml:"token-file,omitempty"`
+
+	// CertificateAuthorityFile is the path of the bundle of certificate authorities to verify the certificate of the
+	// API server with, in PEM format. Defaults to the one of the service account of the pod Gatus is running in.
+	CertificateAuthorityFile string `yaml:"certificate-authority-file,omitempty"`
+
+	// Namespaces are the namespaces to discover the endpoints from. If empty, the endpoints are discovered from all
+	// namespaces.
+	Namespaces []string `yaml:"namespaces,omitempty"`
+
+	// Resources are the kinds of resources to discover the endpoints from. Defaults to services and ingresses.
+	Resources []string `yaml:"resources,omitempty"`
+
+	// LabelSelector is the selector the labels of the resources must match to be discovered, e.g. team=backend
+	LabelSelector string `yaml:"label-selector,omitempty"`
+
+	httpClient *http.Client
+}
+
+// ValidateAndSetDefaults validates the Kubernetes discovery configuration and sets the default values if necessary
+func (c *KubernetesConfig) ValidateAndSetDefaults() error {
+	if len(c.APIServerURL) == 0 {
+		host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+		if len(host) == 0 || len(port) == 0 {
+			return ErrKubernetesAPIServerNotFound
+		}
+		c.APIServerURL = "https://" + net.JoinHostPort(host, port)
+	}
+	if len(c.TokenFile) == 0 {
+		c.TokenFile = defaultKubernetesServiceAccountDirectory + "/token"
+	}
+	if len(c.CertificateAuthorityFile) == 0 {
+		if _, err := os.Stat(defaultKubernetesServiceAccountDirectory + "/ca.crt"); err == nil {
+			c.CertificateAuthorityFile = defaultKubernetesServiceAccountDirectory + "/ca.crt"
+		}
+	}
+	if len(c.Resources) == 0 {
+		c.Resources = []string{KubernetesResourceServices, KubernetesResourceIngresses}
+	}
+	for _, resource := range c.Resources {
+		if resource != KubernetesResourceServices && resource != KubernetesResourceIngresses {
+			return ErrInvalidKubernetesResource
+		}
+	}
+	tlsConfig := &tls.Config{}
+	if len(c.CertificateAuthorityFile) > 0 {
+		certificateAuthorities, err := os.ReadFile(c.CertificateAuthorityFile)
+		if err != nil {
+			return fmt.Errorf("unable to read discovery.kubernetes.certificate-authority-file: %w", err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(certificateAuthorities) {
+			return errors.New("discovery.kubernetes.certificate-authority-file must contain at least one certificate in PEM format")
+		}
+	}
+	c.httpClient = &http.Client{Timeout: kubernetesRequestTimeout, Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+	return nil
+}
+
+// kubernetesObjectMeta is the metadata of the resources of Kubernetes
+type kubernetesObjectMeta struct {
+	Name        string            `json:"name"`
+	Namespace   string            `json:"namespace"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+// kubernetesService is the part of a service of Kubernetes the endpoints are discovered from
+type kubernetesService struct {
+	Metadata kubernetesObjectMeta `json:"metadata"`
+	Spec     struct {
+		Ports []struct {
+			Name string `json:"name"`
+			Port int    `json:"port"`
+		} `json:"ports"`
+	} `json:"spec"`
+}
+
+// kubernetesIngress is the part of an ingress of Kubernetes the endpoints are discovered from
+type kubernetesIngress struct {
+	Metadata kubernetesObjectMeta `json:"metadata"`
+	Spec     struct {
+		TLS []struct {
+			Hosts []string `json:"hosts"`
+		} `json:"tls"`
+		Rules []struct {
+			Host string `json:"host"`
+		} `json:"rules"`
+	} `json:"spec"`
+}
+
+// discover returns the endpoints described by the annotations of the services and of the ingresses
+func (c *KubernetesConfig) discover() ([]*endpoint.Endpoint, error) {
+	namespaces := c.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{""}
+	}
+	var endpoints []*endpoint.Endpoint
+	for _, namespace := range namespaces {
+		if slices.Contains(c.Resources, KubernetesResourceServices) {
+			var services []kubernetesService
+			if err := c.list("/api/v1", namespace, KubernetesResourceServices, &services); err != nil {
+				return nil, err
+			}
+			for _, service := range services {
+				metadata := getMetadata(service.Metadata.Annotations, KubernetesAnnotationPrefix)
+				if metadata == nil {
+					continue
+				}
+				d := defaults{
+					name:  service.Metadata.Name,
+					group: service.Metadata.Namespace,
+					host:  service.Metadata.Name + "." + service.Metadata.Namespace + ".svc",
+				}
+				if len(service.Spec.Ports) > 0 {
+					d.port = formatPort(service.Spec.Ports[0].Port)
+					if service.Spec.Ports[0].Port == 443 || service.Spec.Ports[0].Name == "https" {
+						d.scheme = "https"
+					}
+				}
+				if ep := newEndpoint("service "+service.Metadata.Namespace+"/"+service.Metadata.Name, metadata, d); ep != nil {
+					endpoints = append(endpoints, ep)
+				}
+			}
+		}
+		if slices.Contains(c.Resources, KubernetesResourceIngresses) {
+			var ingresses []kubernetesIngress
+			if err := c.list("/apis/networking.k8s.io/v1", namespace, KubernetesResourceIngresses, &ingresses); err != nil {
+				return nil, err
+			}
+			for _, ingress := range ingresses {
+				metadata := getMetadata(ingress.Metadata.Annotations, KubernetesAnnotationPrefix)
+				if metadata == nil {
+					continue
+				}
+				d := defaults{name: ingress.Metadata.Name, group: ingress.Metadata.Namespace}
+				for _, rule := range ingress.Spec.Rules {
+					if len(rule.Host) > 0 {
+						d.host = rule.Host
+						break
+					}
+				}
+				for _, tls := range ingress.Spec.TLS {
+					if len(d.host) > 0 && slices.Contains(tls.Hosts, d.host) {
+						d.scheme = "https"
+					}
+				}
+				source := "ingress " + ingress.Metadata.Namespace + "/" + ingress.Metadata.Name
+				if len(d.host) == 0 && len(metadata[metadataURL]) == 0 {
+					log.Printf("[discovery.discover] Ignoring endpoint discovered from %s: the ingress has no host, so %s%s must be set", source, KubernetesAnnotationPrefix, metadataURL)
+					continue
+				}
+				if ep := newEndpoint(source, metadata, d); ep != nil {
+					endpoints = append(endpoints, ep)
+				}
+			}
+		}
+	}
+	return endpoints, nil
+}
+
+// list lists the resources passed from the API server, in the namespace passed or, if empty, in all namespaces, and
+// decodes their items into the slice passed
+func (c *KubernetesConfig) list(apiPath, namespace, resource string, items any) error {
+	path := apiPath
+	if len(namespace) > 0 {
+		path += "/namespaces/" + url.PathEscape(namespace)
+	}
+	path += "/" + resource
+	if len(c.LabelSelector) > 0 {
+		path += "?labelSelector=" + url.QueryEscape(c.LabelSelector)
+	}
+	request, err := http.NewRequest(http.MethodGet, strings.TrimSuffix(c.APIServerURL, "/")+path, http.NoBody)
+	if err != nil {
+		return err
+	}
+	token, err := os.ReadFile(c.TokenFile)
+	if err != nil {
+		return fmt.Errorf("unable to read token: %w", err)
+	}
+	request.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	request.Header.Set("Accept", "application/json")
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("unable to list %s: %w", resource, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("unable to list %s: unexpected status code %d", resource, response.StatusCode)
+	}
+	body := struct {
+		Items any `json:"items"`
+	}{Items: items}
+	if err = json.NewDecoder(response.Body).Decode(&body); err != nil {
+		return fmt.Errorf("unable to list %s: %w", resource, err)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package discovery
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const (
+	testKubernetesServices = `{"items":[
+  {"metadata":{"name":"api","namespace":"backend","annotations":{"gatus.io/path":"/health","gatus.io/interval":"30s","gatus.io/alerts":"slack, pagerduty"}},"spec":{"ports":[{"name":"http","port":8080}]}},
+  {"metadata":{"name":"database","namespace":"backend"},"spec":{"ports":[{"port":5432}]}},
+  {"metadata":{"name":"worker","namespace":"backend","annotations":{"gatus.io/enabled":"false"}},"spec":{"ports":[{"port":8080}]}},
+  {"metadata":{"name":"cache","namespace":"backend","annotations":{"gatus.io/url":"tcp://cache.backend.svc:6379","gatus.io/group":"storage"}},"spec":{"ports":[{"port":6379}]}},
+  {"metadata":{"name":"broken","namespace":"backend","annotations":{"gatus.io/interval":"often"}},"spec":{"ports":[{"port":80}]}}
+]}`
+	testKubernetesIngresses = `{"items":[
+  {"metadata":{"name":"website","namespace":"frontend","annotations":{"gatus.io/conditions":"[STATUS] == 200\n[RESPONSE_TIME] < 500"}},"spec":{"tls":[{"hosts":["example.org"]}],"rules":[{"host":"example.org"}]}},
+  {"metadata":{"name":"catch-all","namespace":"frontend","annotations":{"gatus.io/name":"catch-all"}},"spec":{"rules":[{}]}}
+]}`
+)
+
+func TestKubernetesConfig_discover(t *testing.T) {
+	var requestedPaths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		requestedPaths = append(requestedPaths, r.URL.RequestURI())
+		switch r.URL.Path {
+		case "/api/v1/services":
+			_, _ = w.Write([]byte(testKubernetesServices))
+		case "/apis/networking.k8s.io/v1/ingresses":
+			_, _ = w.Write([]byte(testKubernetesIngresses))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	_ = os.WriteFile(tokenFile, []byte("token\n"), 0600)
+	cfg := &Config{Kubernetes: &KubernetesConfig{APIServerURL: server.URL, TokenFile: tokenFile}}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	endpoints, err := cfg.Discover()
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(endpoints) != 3 {
+		t.Fatalf("expected 3 endpoints, got %d", len(endpoints))
+	}
+	api, website, cache := endpoints[0], endpoints[1], endpoints[2]
+	if api.Key() != "backend_api" || api.URL != "http://api.backend.svc:8080/health" || api.Interval != 30*time.Second {
+		t.Errorf("expected the endpoint of the service, got %+v", api)
+	}
+	if len(api.Conditions) != 1 || api.Conditions[0] != "[STATUS] == 200" {
+		t.Errorf("expected the default condition, got %v", api.Conditions)
+	}
+	if len(api.Alerts) != 2 || api.Alerts[0].Type != "slack" || api.Alerts[1].Type != "pagerduty" {
+		t.Errorf("expected the alerts of the annotation, got %+v", api.Alerts)
+	}
+	if cache.Key() != "storage_cache" || cache.URL != "tcp://cache.backend.svc:6379" || cache.Conditions[0] != "[CONNECTED] == true" {
+		t.Errorf("expected the url and the group of the annotations, got %+v", cache)
+	}
+	if website.Key() != "frontend_website" || website.URL != "https://example.org" || len(website.Conditions) != 2 {
+		t.Errorf("expected the endpoint of the ingress, got %+v", website)
+	}
+	if website.Interval != 0 || website.ClientConfig != nil {
+		t.Error("expected the defaults to be left for the configuration to set")
+	}
+	cfg.Kubernetes.Namespaces, cfg.Kubernetes.Resources, cfg.Kubernetes.LabelSelector = []string{"backend"}, []string{KubernetesResourceServices}, "team=backend"
+	requestedPaths = nil
+	if _, err = cfg.Discover(); err == nil {
+		t.Error("expected an error, because the namespace doesn't exist")
+	}
+	if len(requestedPaths) != 1 || requestedPaths[0] != "/api/v1/namespaces/backend/services?labelSelector=team%3Dbackend" {
+		t.Errorf("expected the services of the namespace matching the label selector to be listed, got %v", requestedPaths)
+	}
+}
+
+func TestKubernetesConfig_ValidateAndSetDefaults(t *testing.T) {
+	t.Setenv("KUBERNETES_SERVICE_HOST", "10.0.0.1")
+	t.Setenv("KUBERNETES_SERVICE_PORT", "443")
+	cfg := &KubernetesConfig{}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if cfg.APIServerURL != "https://10.0.0.1:443" {
+		t.Errorf("expected the API server of the cluster, got %s", cfg.APIServerURL)
+	}
+	if cfg.TokenFile != "/var/run/secrets/kubernetes.io/serviceaccount/token" {
+		t.Errorf("expected the token of the service account, got %s", cfg.TokenFile)
+	}
+	if len(cfg.Resources) != 2 {
+		t.Errorf("expected services and ingresses by default, got %v", cfg.Resources)
+	}
+	if err := (&KubernetesConfig{Resources: []string{"pods"}}).ValidateAndSetDefaults(); !errors.Is(err, ErrInvalidKubernetesResource) {
+		t.Errorf("expected %v, got %v", ErrInvalidKubernetesResource, err)
+	}
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	if err := (&KubernetesConfig{}).ValidateAndSetDefaults(); !errors.Is(err, ErrKubernetesAPIServerNotFound) {
+		t.Errorf("expected %v, got %v", ErrKubernetesAPIServerNotFound, err)
+	}
+}
@@ -0,0 +1,16 @@
+package endpoint
+
+import "time"
+
+// Aggregate is the struct that contains the statistics of the results of an endpoint over a period of time, which is
+// what old results are downsampled into
+type Aggregate struct {
+	Timestamp            time.Time `json:"timestamp"`            // Start of the period
+	TotalExecutions      int64     `json:"totalExecutions"`      // Total number of checks
+	SuccessfulExecutions int64     `json:"successfulExecutions"` // Number of successful checks
+	Uptime               float64   `json:"uptime"`               // Ratio of successful checks, from 0 to 1
+	AverageResponseTime  int64     `json:"averageResponseTime"`  // Average response time in milliseconds
+	MinimumResponseTime  int64     `json:"minimumResponseTime"`  // Minimum response time in milliseconds
+	MaximumResponseTime  int64     `json:"maximumResponseTime"`  // Maximum response time in milliseconds
+	P95ResponseTime      int64     `json:"p95ResponseTime"`      // 95th percentile of the response time in milliseconds
+}
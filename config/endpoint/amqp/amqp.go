@@ -0,0 +1,8 @@
+package amqp
+
+type Config struct {
+	// Queue is the name of the queue to passively declare in order to retrieve its depth.
+	//
+	// If empty, only the connection and the channel are checked.
+	Queue string `yaml:"queue,omitempty"`
+}
@@ -0,0 +1,34 @@
+package endpoint
+
+import (
+	"time"
+)
+
+// Annotation is something that happened at a specific time outside of Gatus, such as a deployment or a configuration
+// change, which is displayed along with the events and the response times so that outages can be correlated with it
+type Annotation struct {
+	// Timestamp is the moment at which the annotated change happened
+	Timestamp time.Time `json:"timestamp"`
+
+	// Title is a short description of the change, e.g. "Deployed v1.2.3"
+	Title string `json:"title"`
+
+	// Description is additional information about the change
+	Description string `json:"description,omitempty"`
+
+	// EndpointKeys are the keys of the endpoints affected by the change. If empty, every endpoint is affected.
+	EndpointKeys []string `json:"endpoints,omitempty"`
+}
+
+// Affects returns whether the change annotated affects the endpoint with the key passed as parameter
+func (annotation *Annotation) Affects(key string) bool {
+	if len(annotation.EndpointKeys) == 0 {
+		return true
+	}
+	for _, endpointKey := range annotation.EndpointKeys {
+		if endpointKey == key {
+			return true
+		}
+	}
+	return false
+}
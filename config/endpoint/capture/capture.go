@@ -0,0 +1,55 @@
+package capture
+
+import (
+	"errors"
+)
+
+const (
+	// DefaultMaximumBodySize is the maximum number of bytes of the response body captured by default
+	DefaultMaximumBodySize = 4096
+
+	// DefaultMaximumCaptures is the number of captures kept for each endpoint by default
+	DefaultMaximumCaptures = 10
+
+	// maximumBodySizeLimit is the largest maximum body size allowed, so that captures don't bloat the storage
+	maximumBodySizeLimit = 1 << 20
+
+	// maximumCapturesLimit is the largest number of captures that may be kept for each endpoint
+	maximumCapturesLimit = 100
+)
+
+var (
+	// ErrInvalidMaximumBodySize is the error with which Gatus will panic if an endpoint captures the response of failed
+	// checks with a maximum body size that is negative or too large
+	ErrInvalidMaximumBodySize = errors.New("failure-capture maximum-body-size must be between 0 and 1048576 bytes")
+
+	// ErrInvalidMaximumCaptures is the error with which Gatus will panic if an endpoint keeps a number of captures of
+	// the response of failed checks that is negative or too large
+	ErrInvalidMaximumCaptures = errors.New("failure-capture maximum-captures must be between 0 and 100")
+)
+
+// Config is the configuration of the capture of the response of the checks of an endpoint that fail
+type Config struct {
+	// MaximumBodySize is the maximum number of bytes of the response body captured. Larger bodies are truncated.
+	MaximumBodySize int `yaml:"maximum-body-size,omitempty"`
+
+	// MaximumCaptures is the number of captures kept for the endpoint, beyond which the oldest captures are deleted
+	MaximumCaptures int `yaml:"maximum-captures,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the failure capture configuration and sets the default values if necessary
+func (cfg *Config) ValidateAndSetDefaults() error {
+	if cfg.MaximumBodySize < 0 || cfg.MaximumBodySize > maximumBodySizeLimit {
+		return ErrInvalidMaximumBodySize
+	}
+	if cfg.MaximumBodySize == 0 {
+		cfg.MaximumBodySize = DefaultMaximumBodySize
+	}
+	if cfg.MaximumCaptures < 0 || cfg.MaximumCaptures > maximumCapturesLimit {
+		return ErrInvalidMaximumCaptures
+	}
+	if cfg.MaximumCaptures == 0 {
+		cfg.MaximumCaptures = DefaultMaximumCaptures
+	}
+	return nil
+}
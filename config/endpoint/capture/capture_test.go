@@ -0,0 +1,50 @@
+package capture
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name                    string
+		cfg                     *Config
+		expectedErr             error
+		expectedMaximumBodySize int
+		expectedMaximumCaptures int
+	}{
+		{
+			name:                    "defaults",
+			cfg:                     &Config{},
+			expectedMaximumBodySize: DefaultMaximumBodySize,
+			expectedMaximumCaptures: DefaultMaximumCaptures,
+		},
+		{
+			name:                    "custom",
+			cfg:                     &Config{MaximumBodySize: 65536, MaximumCaptures: 5},
+			expectedMaximumBodySize: 65536,
+			expectedMaximumCaptures: 5,
+		},
+		{
+			name:        "body-too-large",
+			cfg:         &Config{MaximumBodySize: 2 << 20},
+			expectedErr: ErrInvalidMaximumBodySize,
+		},
+		{
+			name:        "negative-captures",
+			cfg:         &Config{MaximumCaptures: -1},
+			expectedErr: ErrInvalidMaximumCaptures,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			err := scenario.cfg.ValidateAndSetDefaults()
+			if !errors.Is(err, scenario.expectedErr) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if err == nil && (scenario.cfg.MaximumBodySize != scenario.expectedMaximumBodySize || scenario.cfg.MaximumCaptures != scenario.expectedMaximumCaptures) {
+				t.Errorf("expected maximum-body-size=%d and maximum-captures=%d, got %+v", scenario.expectedMaximumBodySize, scenario.expectedMaximumCaptures, scenario.cfg)
+			}
+		})
+	}
+}
@@ -1,14 +1,21 @@
 package endpoint
 
 import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"math"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/TwiN/gatus/v5/exposition"
 	"github.com/TwiN/gatus/v5/jsonpath"
 	"github.com/TwiN/gatus/v5/pattern"
+	"github.com/TwiN/gatus/v5/xpath"
 )
 
 // Placeholders
@@ -50,6 +57,101 @@ const (
 
 	// DomainExpirationPlaceholder is a placeholder for the duration before the domain expires, in milliseconds.
 	DomainExpirationPlaceholder = "[DOMAIN_EXPIRATION]"
+
+	// BodySHA256Placeholder is a placeholder for the hex-encoded SHA-256 checksum of the Body of the response
+	//
+	// Values that could replace the placeholder: e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855, ...
+	BodySHA256Placeholder = "[BODY_SHA256]"
+
+	// BodyMD5Placeholder is a placeholder for the hex-encoded MD5 checksum of the Body of the response
+	//
+	// Values that could replace the placeholder: d41d8cd98f00b204e9800998ecf8427e, ...
+	BodyMD5Placeholder = "[BODY_MD5]"
+
+	// BodySizePlaceholder is a placeholder for the size of the Body of the response, in bytes
+	//
+	// Values that could replace the placeholder: 0, 512, 1048576, ...
+	BodySizePlaceholder = "[BODY_SIZE]"
+
+	// ContentTypePlaceholder is a placeholder for the Content-Type header of the response
+	//
+	// Values that could replace the placeholder: application/json, text/html; charset=utf-8, ...
+	ContentTypePlaceholder = "[CONTENT_TYPE]"
+
+	// RedirectCountPlaceholder is a placeholder for the number of redirects that were followed before the final response
+	//
+	// Values that could replace the placeholder: 0, 1, 2, ...
+	RedirectCountPlaceholder = "[REDIRECT_COUNT]"
+
+	// FinalURLPlaceholder is a placeholder for the URL of the final response, after all redirects were followed
+	//
+	// Values that could replace the placeholder: https://example.org/, https://www.example.org/login, ...
+	FinalURLPlaceholder = "[FINAL_URL]"
+
+	// ProtocolPlaceholder is a placeholder for the protocol of the response
+	//
+	// Values that could replace the placeholder: HTTP/1.1, HTTP/2.0, HTTP/3.0
+	ProtocolPlaceholder = "[PROTOCOL]"
+
+	// PacketLossPlaceholder is a placeholder for the percentage of pings that didn't receive a reply
+	//
+	// Values that could replace the placeholder: 0, 33.33, 100
+	PacketLossPlaceholder = "[PACKET_LOSS]"
+
+	// MinRTTPlaceholder is a placeholder for the minimum round-trip time of the pings, in milliseconds
+	//
+	// Values that could replace the placeholder: 1, 12, ...
+	MinRTTPlaceholder = "[MIN_RTT]"
+
+	// AvgRTTPlaceholder is a placeholder for the average round-trip time of the pings, in milliseconds
+	//
+	// Values that could replace the placeholder: 1, 15, ...
+	AvgRTTPlaceholder = "[AVG_RTT]"
+
+	// MaxRTTPlaceholder is a placeholder for the maximum round-trip time of the pings, in milliseconds
+	//
+	// Values that could replace the placeholder: 2, 20, ...
+	MaxRTTPlaceholder = "[MAX_RTT]"
+
+	// HopCountPlaceholder is a placeholder for the number of hops to the destination of a traceroute.
+	// If the destination wasn't reached, this is the number of hops that were probed.
+	//
+	// Values that could replace the placeholder: 1, 12, ...
+	HopCountPlaceholder = "[HOP_COUNT]"
+
+	// ExitCodePlaceholder is a placeholder for the exit code of the command executed on SSH endpoints
+	//
+	// Values that could replace the placeholder: 0, 1, 127, ...
+	ExitCodePlaceholder = "[EXIT_CODE]"
+
+	// NTPOffsetPlaceholder is a placeholder for the offset of the local clock relative to the clock of the NTP server,
+	// in milliseconds. A positive offset means that the local clock is behind.
+	//
+	// Values that could replace the placeholder: -12, 0, 3, ...
+	NTPOffsetPlaceholder = "[NTP_OFFSET]"
+
+	// NTPStratumPlaceholder is a placeholder for the stratum of the NTP server
+	//
+	// Values that could replace the placeholder: 1, 2, 16, ...
+	NTPStratumPlaceholder = "[NTP_STRATUM]"
+
+	// ObjectSizePlaceholder is a placeholder for the size of the object of an S3 endpoint, or of the file of a file
+	// endpoint, in bytes
+	//
+	// Values that could replace the placeholder: 0, 1048576, ...
+	ObjectSizePlaceholder = "[OBJECT_SIZE]"
+
+	// ObjectAgePlaceholder is a placeholder for the duration since the object of an S3 endpoint, or the file of a file
+	// endpoint, was last modified, in milliseconds.
+	//
+	// Values that could replace the placeholder: 1000, 86400000, ...
+	ObjectAgePlaceholder = "[OBJECT_AGE]"
+
+	// DiskFreePercentPlaceholder is a placeholder for the percentage of the space of the filesystem of a disk endpoint
+	// that is available to unprivileged users
+	//
+	// Values that could replace the placeholder: 0, 42, 100, ...
+	DiskFreePercentPlaceholder = "[DISK_FREE_PERCENT]"
 )
 
 // Functions
@@ -69,11 +171,28 @@ const (
 	// Usage: [IP] == pat(192.168.*.*)
 	PatternFunctionPrefix = "pat("
 
+	// RegexFunctionPrefix is the prefix for the regex function, which matches if the regular expression passed as
+	// parameter matches any part of the string it's compared to
+	//
+	// Usage: [BODY] == regex(^backup completed), [BODY] != regex((?i)error)
+	RegexFunctionPrefix = "regex("
+
 	// AnyFunctionPrefix is the prefix for the any function
 	//
 	// Usage: [IP] == any(1.1.1.1, 1.0.0.1)
 	AnyFunctionPrefix = "any("
 
+	// MetricFunctionPrefix is the prefix for the metric function, which extracts the value of a sample from a body
+	// using the Prometheus text exposition format
+	//
+	// Usage: metric(queue_depth{queue="email"}) < 1000
+	MetricFunctionPrefix = "metric("
+
+	// XPathFunctionPrefix is the prefix for the xpath function, which extracts the value of a node from an XML body
+	//
+	// Usage: xpath(/Envelope/Body/GetPriceResponse/Price) == 10, xpath(count(//Fault)) == 0
+	XPathFunctionPrefix = "xpath("
+
 	// FunctionSuffix is the suffix for all functions
 	FunctionSuffix = ")"
 )
@@ -156,10 +275,16 @@ func (c Condition) evaluate(result *Result, dontResolveFailedConditions bool) bo
 	return success
 }
 
-// hasBodyPlaceholder checks whether the condition has a BodyPlaceholder
+// hasBodyPlaceholder checks whether the condition has a BodyPlaceholder, or a placeholder or function derived from
+// the body (e.g. BodySHA256Placeholder, MetricFunctionPrefix, XPathFunctionPrefix)
 // Used for determining whether the response body should be read or not
 func (c Condition) hasBodyPlaceholder() bool {
-	return strings.Contains(string(c), BodyPlaceholder)
+	return strings.Contains(string(c), BodyPlaceholder) ||
+		strings.Contains(string(c), BodySHA256Placeholder) ||
+		strings.Contains(string(c), BodyMD5Placeholder) ||
+		strings.Contains(string(c), BodySizePlaceholder) ||
+		strings.Contains(string(c), MetricFunctionPrefix) ||
+		strings.Contains(string(c), XPathFunctionPrefix)
 }
 
 // hasDomainExpirationPlaceholder checks whether the condition has a DomainExpirationPlaceholder
@@ -176,7 +301,7 @@ func (c Condition) hasIPPlaceholder() bool {
 
 // isEqual compares two strings.
 //
-// Supports the "pat" and the "any" functions.
+// Supports the "pat", the "regex" and the "any" functions.
 // i.e. if one of the parameters starts with PatternFunctionPrefix and ends with FunctionSuffix, it will be treated like
 // a pattern.
 func isEqual(first, second string) bool {
@@ -197,6 +322,22 @@ func isEqual(first, second string) bool {
 		} else if !isFirstPattern && isSecondPattern {
 			return pattern.Match(second, first)
 		}
+		var isFirstRegex, isSecondRegex bool
+		if strings.HasPrefix(first, RegexFunctionPrefix) && firstHasFunctionSuffix {
+			isFirstRegex = true
+			first = strings.TrimSuffix(strings.TrimPrefix(first, RegexFunctionPrefix), FunctionSuffix)
+		}
+		if strings.HasPrefix(second, RegexFunctionPrefix) && secondHasFunctionSuffix {
+			isSecondRegex = true
+			second = strings.TrimSuffix(strings.TrimPrefix(second, RegexFunctionPrefix), FunctionSuffix)
+		}
+		if isFirstRegex && !isSecondRegex {
+			matched, err := regexp.MatchString(first, second)
+			return err == nil && matched
+		} else if !isFirstRegex && isSecondRegex {
+			matched, err := regexp.MatchString(second, first)
+			return err == nil && matched
+		}
 		var isFirstAny, isSecondAny bool
 		if strings.HasPrefix(first, AnyFunctionPrefix) && firstHasFunctionSuffix {
 			isFirstAny = true
@@ -261,9 +402,71 @@ func sanitizeAndResolve(elements []string, result *Result) ([]string, []string)
 			element = strconv.FormatInt(result.CertificateExpiration.Milliseconds(), 10)
 		case DomainExpirationPlaceholder:
 			element = strconv.FormatInt(result.DomainExpiration.Milliseconds(), 10)
+		case BodySHA256Placeholder:
+			checksum := sha256.Sum256(result.Body)
+			element = hex.EncodeToString(checksum[:])
+		case BodyMD5Placeholder:
+			checksum := md5.Sum(result.Body)
+			element = hex.EncodeToString(checksum[:])
+		case BodySizePlaceholder:
+			element = strconv.Itoa(len(result.Body))
+		case ContentTypePlaceholder:
+			element = result.ContentType
+		case RedirectCountPlaceholder:
+			element = strconv.Itoa(result.RedirectCount)
+		case FinalURLPlaceholder:
+			element = result.FinalURL
+		case ProtocolPlaceholder:
+			element = result.Protocol
+		case PacketLossPlaceholder:
+			element = strconv.FormatFloat(math.Round(result.PacketLoss*100)/100, 'f', -1, 64)
+		case MinRTTPlaceholder:
+			element = strconv.FormatInt(result.MinRTT.Milliseconds(), 10)
+		case AvgRTTPlaceholder:
+			element = strconv.FormatInt(result.AvgRTT.Milliseconds(), 10)
+		case MaxRTTPlaceholder:
+			element = strconv.FormatInt(result.MaxRTT.Milliseconds(), 10)
+		case HopCountPlaceholder:
+			element = strconv.Itoa(result.HopCount)
+		case ExitCodePlaceholder:
+			element = strconv.Itoa(result.ExitCode)
+		case NTPOffsetPlaceholder:
+			element = strconv.FormatInt(result.NTPOffset.Milliseconds(), 10)
+		case NTPStratumPlaceholder:
+			element = strconv.Itoa(result.NTPStratum)
+		case ObjectSizePlaceholder:
+			element = strconv.FormatInt(result.ObjectSize, 10)
+		case ObjectAgePlaceholder:
+			element = strconv.FormatInt(result.ObjectAge.Milliseconds(), 10)
+		case DiskFreePercentPlaceholder:
+			element = strconv.Itoa(result.DiskFreePercent)
 		default:
-			// if contains the BodyPlaceholder, then evaluate json path
-			if strings.Contains(element, BodyPlaceholder) {
+			// if it's the metric function, then parse the body using the Prometheus text exposition format
+			if strings.HasPrefix(element, MetricFunctionPrefix) && strings.HasSuffix(element, FunctionSuffix) {
+				resolvedElement, err := exposition.Eval(strings.TrimSuffix(strings.TrimPrefix(element, MetricFunctionPrefix), FunctionSuffix), result.Body)
+				if err != nil {
+					// An empty body is expected when the condition is being validated, so we only report syntax errors
+					if len(result.Body) > 0 || errors.Is(err, exposition.ErrInvalidSelector) {
+						result.AddError(err.Error())
+					}
+					element = element + " " + InvalidConditionElementSuffix
+				} else {
+					element = resolvedElement
+				}
+			} else if strings.HasPrefix(element, XPathFunctionPrefix) && strings.HasSuffix(element, FunctionSuffix) {
+				// if it's the xpath function, then parse the body as XML
+				resolvedElement, err := xpath.Eval(strings.TrimSuffix(strings.TrimPrefix(element, XPathFunctionPrefix), FunctionSuffix), result.Body)
+				if err != nil {
+					// An empty body is expected when the condition is being validated, so we only report syntax errors
+					if len(result.Body) > 0 || errors.Is(err, xpath.ErrInvalidExpression) {
+						result.AddError(err.Error())
+					}
+					element = element + " " + InvalidConditionElementSuffix
+				} else {
+					element = resolvedElement
+				}
+			} else if strings.Contains(element, BodyPlaceholder) {
+				// if contains the BodyPlaceholder, then evaluate json path
 				checkingForLength := false
 				checkingForExistence := false
 				if strings.HasPrefix(element, LengthFunctionPrefix) && strings.HasSuffix(element, FunctionSuffix) {
@@ -340,11 +543,12 @@ func prettify(parameters []string, resolvedParameters []string, operator string)
 	if strings.HasSuffix(resolvedParameters[0], InvalidConditionElementSuffix) || strings.HasSuffix(resolvedParameters[1], InvalidConditionElementSuffix) {
 		return resolvedParameters[0] + " " + operator + " " + resolvedParameters[1]
 	}
-	// If using the pattern function, truncate the parameter it's being compared to if said parameter is long enough
-	if strings.HasPrefix(parameters[0], PatternFunctionPrefix) && strings.HasSuffix(parameters[0], FunctionSuffix) && len(resolvedParameters[1]) > maximumLengthBeforeTruncatingWhenComparedWithPattern {
+	// If using the pattern or the regex function, truncate the parameter it's being compared to if said parameter is
+	// long enough
+	if isPatternOrRegex(parameters[0]) && len(resolvedParameters[1]) > maximumLengthBeforeTruncatingWhenComparedWithPattern {
 		resolvedParameters[1] = fmt.Sprintf("%.25s...(truncated)", resolvedParameters[1])
 	}
-	if strings.HasPrefix(parameters[1], PatternFunctionPrefix) && strings.HasSuffix(parameters[1], FunctionSuffix) && len(resolvedParameters[0]) > maximumLengthBeforeTruncatingWhenComparedWithPattern {
+	if isPatternOrRegex(parameters[1]) && len(resolvedParameters[0]) > maximumLengthBeforeTruncatingWhenComparedWithPattern {
 		resolvedParameters[0] = fmt.Sprintf("%.25s...(truncated)", resolvedParameters[0])
 	}
 	// First element is a placeholder
@@ -362,3 +566,8 @@ func prettify(parameters []string, resolvedParameters []string, operator string)
 	// Neither elements are placeholders
 	return parameters[0] + " " + operator + " " + parameters[1]
 }
+
+// isPatternOrRegex checks whether a parameter is a call to the pattern or the regex function
+func isPatternOrRegex(parameter string) bool {
+	return (strings.HasPrefix(parameter, PatternFunctionPrefix) || strings.HasPrefix(parameter, RegexFunctionPrefix)) && strings.HasSuffix(parameter, FunctionSuffix)
+}
@@ -36,6 +36,15 @@ func TestCondition_Validate(t *testing.T) {
 		{condition: "[BODY].name == pat(john*)", expectedErr: nil},
 		{condition: "[CERTIFICATE_EXPIRATION] > 48h", expectedErr: nil},
 		{condition: "[DOMAIN_EXPIRATION] > 720h", expectedErr: nil},
+		{condition: "[BODY_SHA256] == e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", expectedErr: nil},
+		{condition: "[BODY_MD5] != d41d8cd98f00b204e9800998ecf8427e", expectedErr: nil},
+		{condition: "[BODY_SIZE] > 0", expectedErr: nil},
+		{condition: "[CONTENT_TYPE] == application/json", expectedErr: nil},
+		{condition: "[PROTOCOL] == HTTP/3.0", expectedErr: nil},
+		{condition: `metric(queue_depth{queue="email"}) < 1000`, expectedErr: nil},
+		{condition: `metric(queue_depth{queue=email}) < 1000`, expectedErr: errors.New(`invalid metric selector: expected format is name or name{label="value",...}`)},
+		{condition: "xpath(/Envelope/Body/GetPriceResponse/Price) == 10", expectedErr: nil},
+		{condition: "xpath(Envelope/Body) == 10", expectedErr: errors.New("invalid xpath expression: expected an absolute location path such as /Envelope/Body/Price or //Price")},
 		{condition: "raw == raw", expectedErr: nil},
 		{condition: "[STATUS] ? 201", expectedErr: errors.New("invalid condition: [STATUS] ? 201")},
 		{condition: "[STATUS]==201", expectedErr: errors.New("invalid condition: [STATUS]==201")},
@@ -490,6 +499,251 @@ func TestCondition_evaluate(t *testing.T) {
 			ExpectedSuccess: false,
 			ExpectedOutput:  "[CERTIFICATE_EXPIRATION] (86400000) > 48h (172800000)",
 		},
+		{
+			Name:            "body-sha256",
+			Condition:       Condition("[BODY_SHA256] == 2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"),
+			Result:          &Result{Body: []byte("hello")},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY_SHA256] == 2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+		},
+		{
+			Name:            "body-sha256-failure",
+			Condition:       Condition("[BODY_SHA256] == 2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"),
+			Result:          &Result{Body: []byte("defaced")},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY_SHA256] (2c8d79f36c7758d1268998039ca8a565cb6687958516f370e10086b45c2a1ea7) == 2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+		},
+		{
+			Name:            "body-md5",
+			Condition:       Condition("[BODY_MD5] == 5d41402abc4b2a76b9719d911017c592"),
+			Result:          &Result{Body: []byte("hello")},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY_MD5] == 5d41402abc4b2a76b9719d911017c592",
+		},
+		{
+			Name:            "body-md5-empty-body",
+			Condition:       Condition("[BODY_MD5] != d41d8cd98f00b204e9800998ecf8427e"),
+			Result:          &Result{},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY_MD5] (d41d8cd98f00b204e9800998ecf8427e) != d41d8cd98f00b204e9800998ecf8427e",
+		},
+		{
+			Name:            "body-size",
+			Condition:       Condition("[BODY_SIZE] > 1024"),
+			Result:          &Result{Body: []byte("{}")},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY_SIZE] (2) > 1024",
+		},
+		{
+			Name:            "body-size-empty-body",
+			Condition:       Condition("[BODY_SIZE] == 0"),
+			Result:          &Result{},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY_SIZE] == 0",
+		},
+		{
+			Name:            "content-type",
+			Condition:       Condition("[CONTENT_TYPE] == application/json"),
+			Result:          &Result{ContentType: "application/json"},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[CONTENT_TYPE] == application/json",
+		},
+		{
+			Name:            "content-type-pattern-failure",
+			Condition:       Condition("[CONTENT_TYPE] == pat(application/json*)"),
+			Result:          &Result{ContentType: "text/html; charset=utf-8"},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[CONTENT_TYPE] (text/html; charset=utf-8) == pat(application/json*)",
+		},
+		{
+			Name:            "redirect-count",
+			Condition:       Condition("[REDIRECT_COUNT] == 0"),
+			Result:          &Result{RedirectCount: 0},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[REDIRECT_COUNT] == 0",
+		},
+		{
+			Name:            "redirect-count-failure",
+			Condition:       Condition("[REDIRECT_COUNT] == 0"),
+			Result:          &Result{RedirectCount: 2},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[REDIRECT_COUNT] (2) == 0",
+		},
+		{
+			Name:            "final-url",
+			Condition:       Condition("[FINAL_URL] == pat(https://www.example.org/*)"),
+			Result:          &Result{FinalURL: "https://www.example.org/login"},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[FINAL_URL] == pat(https://www.example.org/*)",
+		},
+		{
+			Name:            "final-url-failure",
+			Condition:       Condition("[FINAL_URL] == https://example.org/"),
+			Result:          &Result{FinalURL: "https://login.example.com/"},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[FINAL_URL] (https://login.example.com/) == https://example.org/",
+		},
+		{
+			Name:            "protocol",
+			Condition:       Condition("[PROTOCOL] == HTTP/3.0"),
+			Result:          &Result{Protocol: "HTTP/3.0"},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[PROTOCOL] == HTTP/3.0",
+		},
+		{
+			Name:            "protocol-failure",
+			Condition:       Condition("[PROTOCOL] == HTTP/3.0"),
+			Result:          &Result{Protocol: "HTTP/2.0"},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[PROTOCOL] (HTTP/2.0) == HTTP/3.0",
+		},
+		{
+			Name:            "ntp-offset",
+			Condition:       Condition("[NTP_OFFSET] > -100"),
+			Result:          &Result{NTPOffset: -20 * time.Millisecond},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[NTP_OFFSET] > -100",
+		},
+		{
+			Name:            "ntp-offset-failure",
+			Condition:       Condition("[NTP_OFFSET] < 100"),
+			Result:          &Result{NTPOffset: 1500 * time.Millisecond},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[NTP_OFFSET] (1500) < 100",
+		},
+		{
+			Name:            "ntp-stratum",
+			Condition:       Condition("[NTP_STRATUM] <= 3"),
+			Result:          &Result{NTPStratum: 2},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[NTP_STRATUM] <= 3",
+		},
+		{
+			Name:            "packet-loss",
+			Condition:       Condition("[PACKET_LOSS] == 0"),
+			Result:          &Result{PacketLoss: 0},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[PACKET_LOSS] == 0",
+		},
+		{
+			Name:            "packet-loss-failure",
+			Condition:       Condition("[PACKET_LOSS] < 20"),
+			Result:          &Result{PacketLoss: 100.0 / 3},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[PACKET_LOSS] (33) < 20",
+		},
+		{
+			Name:            "min-rtt",
+			Condition:       Condition("[MIN_RTT] < 10"),
+			Result:          &Result{MinRTT: 5 * time.Millisecond},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[MIN_RTT] < 10",
+		},
+		{
+			Name:            "avg-rtt",
+			Condition:       Condition("[AVG_RTT] < 50"),
+			Result:          &Result{AvgRTT: 25 * time.Millisecond},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[AVG_RTT] < 50",
+		},
+		{
+			Name:            "max-rtt-failure",
+			Condition:       Condition("[MAX_RTT] < 100"),
+			Result:          &Result{MaxRTT: 250 * time.Millisecond},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[MAX_RTT] (250) < 100",
+		},
+		{
+			Name:            "hop-count",
+			Condition:       Condition("[HOP_COUNT] <= 15"),
+			Result:          &Result{HopCount: 12},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[HOP_COUNT] <= 15",
+		},
+		{
+			Name:            "hop-count-failure",
+			Condition:       Condition("[HOP_COUNT] <= 15"),
+			Result:          &Result{HopCount: 30},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[HOP_COUNT] (30) <= 15",
+		},
+		{
+			Name:            "exit-code",
+			Condition:       Condition("[EXIT_CODE] == 0"),
+			Result:          &Result{ExitCode: 0},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[EXIT_CODE] == 0",
+		},
+		{
+			Name:            "exit-code-failure",
+			Condition:       Condition("[EXIT_CODE] == 0"),
+			Result:          &Result{ExitCode: 127},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[EXIT_CODE] (127) == 0",
+		},
+		{
+			Name:            "object-size",
+			Condition:       Condition("[OBJECT_SIZE] > 1048576"),
+			Result:          &Result{ObjectSize: 5242880},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[OBJECT_SIZE] > 1048576",
+		},
+		{
+			Name:            "object-age",
+			Condition:       Condition("[OBJECT_AGE] < 24h"),
+			Result:          &Result{ObjectAge: 2 * time.Hour},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[OBJECT_AGE] < 24h",
+		},
+		{
+			Name:            "object-age-failure",
+			Condition:       Condition("[OBJECT_AGE] < 24h"),
+			Result:          &Result{ObjectAge: 48 * time.Hour},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[OBJECT_AGE] (172800000) < 24h (86400000)",
+		},
+		{
+			Name:            "disk-free-percent",
+			Condition:       Condition("[DISK_FREE_PERCENT] > 10"),
+			Result:          &Result{DiskFreePercent: 42},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[DISK_FREE_PERCENT] > 10",
+		},
+		{
+			Name:            "disk-free-percent-failure",
+			Condition:       Condition("[DISK_FREE_PERCENT] > 10"),
+			Result:          &Result{DiskFreePercent: 5},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[DISK_FREE_PERCENT] (5) > 10",
+		},
+		{
+			Name:            "regex",
+			Condition:       Condition("[BODY] == regex(^backup completed in [0-9]+s$)"),
+			Result:          &Result{Body: []byte("backup completed in 42s")},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY] == regex(^backup completed in [0-9]+s$)",
+		},
+		{
+			Name:            "regex-partial-match",
+			Condition:       Condition("[BODY] != regex((?i)error)"),
+			Result:          &Result{Body: []byte("line 1\nline 2: ERROR disk full\nline 3")},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY] (line 1\nline 2: ERROR disk...(truncated)) != regex((?i)error)",
+		},
+		{
+			Name:            "regex-failure",
+			Condition:       Condition("[BODY] == regex(^backup completed)"),
+			Result:          &Result{Body: []byte("backup failed")},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY] (backup failed) == regex(^backup completed)",
+		},
+		{
+			Name:            "regex-invalid",
+			Condition:       Condition("[BODY] == regex([a-)"),
+			Result:          &Result{Body: []byte("a")},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY] (a) == regex([a-)",
+		},
 		{
 			Name:            "no-placeholders",
 			Condition:       Condition("1 == 2"),
@@ -721,6 +975,64 @@ func TestCondition_evaluate(t *testing.T) {
 			ExpectedSuccess:             false,
 			ExpectedOutput:              "[STATUS] == any(200, 429)",
 		},
+		// metric
+		{
+			Name:            "metric",
+			Condition:       Condition(`metric(queue_depth{queue="email"}) < 1000`),
+			Result:          &Result{Body: []byte("# TYPE queue_depth gauge\nqueue_depth{queue=\"email\"} 42\nqueue_depth{queue=\"sms\"} 5000\n")},
+			ExpectedSuccess: true,
+			ExpectedOutput:  `metric(queue_depth{queue="email"}) < 1000`,
+		},
+		{
+			Name:            "metric-failure",
+			Condition:       Condition(`metric(queue_depth{queue="sms"}) < 1000`),
+			Result:          &Result{Body: []byte("# TYPE queue_depth gauge\nqueue_depth{queue=\"email\"} 42\nqueue_depth{queue=\"sms\"} 5000\n")},
+			ExpectedSuccess: false,
+			ExpectedOutput:  `metric(queue_depth{queue="sms"}) (5000) < 1000`,
+		},
+		{
+			Name:            "metric-equal",
+			Condition:       Condition(`metric(up) == 1`),
+			Result:          &Result{Body: []byte("up 1\n")},
+			ExpectedSuccess: true,
+			ExpectedOutput:  `metric(up) == 1`,
+		},
+		{
+			Name:            "metric-not-found",
+			Condition:       Condition(`metric(queue_depth{queue="push"}) == 42`),
+			Result:          &Result{Body: []byte("# TYPE queue_depth gauge\nqueue_depth{queue=\"email\"} 42\n")},
+			ExpectedSuccess: false,
+			ExpectedOutput:  `metric(queue_depth{queue="push"}) (INVALID) == 42`,
+		},
+		// xpath
+		{
+			Name:            "xpath",
+			Condition:       Condition("xpath(/Envelope/Body/GetPriceResponse/Price) == 10"),
+			Result:          &Result{Body: []byte(`<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body><m:GetPriceResponse xmlns:m="https://example.org/prices"><m:Price>10</m:Price></m:GetPriceResponse></soap:Body></soap:Envelope>`)},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "xpath(/Envelope/Body/GetPriceResponse/Price) == 10",
+		},
+		{
+			Name:            "xpath-failure",
+			Condition:       Condition("xpath(//Price) < 5"),
+			Result:          &Result{Body: []byte(`<GetPriceResponse><Price>10</Price></GetPriceResponse>`)},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "xpath(//Price) (10) < 5",
+		},
+		{
+			Name:            "xpath-count",
+			Condition:       Condition("xpath(count(//Fault)) == 0"),
+			Result:          &Result{Body: []byte(`<GetPriceResponse><Price>10</Price></GetPriceResponse>`)},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "xpath(count(//Fault)) == 0",
+		},
+		{
+			Name:            "xpath-not-found",
+			Condition:       Condition("xpath(//Currency) == USD"),
+			Result:          &Result{Body: []byte(`<GetPriceResponse><Price>10</Price></GetPriceResponse>`)},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "xpath(//Currency) (INVALID) == USD",
+		},
 		// has
 		{
 			Name:            "has",
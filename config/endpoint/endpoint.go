@@ -3,7 +3,6 @@ package endpoint
 import (
 	"bytes"
 	"crypto/x509"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -15,9 +14,30 @@ import (
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
 	"github.com/TwiN/gatus/v5/client"
+	amqpconfig "github.com/TwiN/gatus/v5/config/endpoint/amqp"
+	"github.com/TwiN/gatus/v5/config/endpoint/capture"
 	"github.com/TwiN/gatus/v5/config/endpoint/dns"
+	ftpconfig "github.com/TwiN/gatus/v5/config/endpoint/ftp"
+	grpcconfig "github.com/TwiN/gatus/v5/config/endpoint/grpc"
+	icmpconfig "github.com/TwiN/gatus/v5/config/endpoint/icmp"
+	kafkaconfig "github.com/TwiN/gatus/v5/config/endpoint/kafka"
+	ldapconfig "github.com/TwiN/gatus/v5/config/endpoint/ldap"
+	mailboxconfig "github.com/TwiN/gatus/v5/config/endpoint/mailbox"
+	mqttconfig "github.com/TwiN/gatus/v5/config/endpoint/mqtt"
+	natsconfig "github.com/TwiN/gatus/v5/config/endpoint/nats"
+	oauth2config "github.com/TwiN/gatus/v5/config/endpoint/oauth2"
+	redisconfig "github.com/TwiN/gatus/v5/config/endpoint/redis"
+	s3config "github.com/TwiN/gatus/v5/config/endpoint/s3"
+	smtpconfig "github.com/TwiN/gatus/v5/config/endpoint/smtp"
+	snmpconfig "github.com/TwiN/gatus/v5/config/endpoint/snmp"
+	soapconfig "github.com/TwiN/gatus/v5/config/endpoint/soap"
+	sqlconfig "github.com/TwiN/gatus/v5/config/endpoint/sql"
 	sshconfig "github.com/TwiN/gatus/v5/config/endpoint/ssh"
+	tcpconfig "github.com/TwiN/gatus/v5/config/endpoint/tcp"
+	tracerouteconfig "github.com/TwiN/gatus/v5/config/endpoint/traceroute"
+	udpconfig "github.com/TwiN/gatus/v5/config/endpoint/udp"
 	"github.com/TwiN/gatus/v5/config/endpoint/ui"
+	websocketconfig "github.com/TwiN/gatus/v5/config/endpoint/websocket"
 	"golang.org/x/crypto/ssh"
 )
 
@@ -36,17 +56,40 @@ const (
 	// GatusUserAgent is the default user agent that Gatus uses to send requests.
 	GatusUserAgent = "Gatus/1.0"
 
-	TypeDNS      Type = "DNS"
-	TypeTCP      Type = "TCP"
-	TypeSCTP     Type = "SCTP"
-	TypeUDP      Type = "UDP"
-	TypeICMP     Type = "ICMP"
-	TypeSTARTTLS Type = "STARTTLS"
-	TypeTLS      Type = "TLS"
-	TypeHTTP     Type = "HTTP"
-	TypeWS       Type = "WEBSOCKET"
-	TypeSSH      Type = "SSH"
-	TypeUNKNOWN  Type = "UNKNOWN"
+	TypeDNS        Type = "DNS"
+	TypeTCP        Type = "TCP"
+	TypeSCTP       Type = "SCTP"
+	TypeUDP        Type = "UDP"
+	TypeICMP       Type = "ICMP"
+	TypeSTARTTLS   Type = "STARTTLS"
+	TypeTLS        Type = "TLS"
+	TypeHTTP       Type = "HTTP"
+	TypeWS         Type = "WEBSOCKET"
+	TypeSSH        Type = "SSH"
+	TypeGRPC       Type = "GRPC"
+	TypeMQTT       Type = "MQTT"
+	TypeKafka      Type = "KAFKA"
+	TypeAMQP       Type = "AMQP"
+	TypeNATS       Type = "NATS"
+	TypeRedis      Type = "REDIS"
+	TypePostgres   Type = "POSTGRES"
+	TypeMySQL      Type = "MYSQL"
+	TypeMongoDB    Type = "MONGODB"
+	TypeLDAP       Type = "LDAP"
+	TypeSMTP       Type = "SMTP"
+	TypeIMAP       Type = "IMAP"
+	TypePOP3       Type = "POP3"
+	TypeFTP        Type = "FTP"
+	TypeSFTP       Type = "SFTP"
+	TypeNTP        Type = "NTP"
+	TypeSNMP       Type = "SNMP"
+	TypeTraceroute Type = "TRACEROUTE"
+	TypeS3         Type = "S3"
+	TypeOAuth2     Type = "OAUTH2"
+	TypeSystemd    Type = "SYSTEMD"
+	TypeFile       Type = "FILE"
+	TypeDisk       Type = "DISK"
+	TypeUNKNOWN    Type = "UNKNOWN"
 )
 
 var (
@@ -67,6 +110,12 @@ var (
 	// This is because the free whois service we are using should not be abused, especially considering the fact that
 	// the data takes a while to be updated.
 	ErrInvalidEndpointIntervalForDomainExpirationPlaceholder = errors.New("the minimum interval for an endpoint with a condition using the " + DomainExpirationPlaceholder + " placeholder is 300s (5m)")
+
+	// ErrEndpointWithSOAPAndNonHTTPType is the error with which Gatus will panic if an endpoint that isn't of type HTTP is configured with SOAP
+	ErrEndpointWithSOAPAndNonHTTPType = errors.New("soap is only supported for endpoints of type HTTP")
+
+	// ErrEndpointWithSOAPAndGraphQL is the error with which Gatus will panic if an endpoint is configured with both SOAP and GraphQL
+	ErrEndpointWithSOAPAndGraphQL = errors.New("soap and graphql cannot be used together")
 )
 
 // Endpoint is the configuration of a service to be monitored
@@ -80,6 +129,9 @@ type Endpoint struct {
 	// Group the endpoint is a part of. Used for grouping multiple endpoints together on the front end.
 	Group string `yaml:"group,omitempty"`
 
+	// Tenant the endpoint is assigned to, if any. See config.Config.Tenants
+	Tenant string `yaml:"tenant,omitempty"`
+
 	// URL to send the request to
 	URL string `yaml:"url"`
 
@@ -89,9 +141,16 @@ type Endpoint struct {
 	// Body of the request
 	Body string `yaml:"body,omitempty"`
 
-	// GraphQL is whether to wrap the body in a query param ({"query":"$body"})
+	// GraphQL is whether to wrap the body in a query param ({"query":"$body","variables":$graphql-variables})
 	GraphQL bool `yaml:"graphql,omitempty"`
 
+	// GraphQLVariables are the variables sent along with the query when GraphQL is set to true
+	GraphQLVariables map[string]interface{} `yaml:"graphql-variables,omitempty"`
+
+	// GraphQLFailOnErrors is whether the check should fail when the response of a GraphQL request has a non-empty
+	// errors array
+	GraphQLFailOnErrors bool `yaml:"graphql-fail-on-errors,omitempty"`
+
 	// Headers of the request
 	Headers map[string]string `yaml:"headers,omitempty"`
 
@@ -101,15 +160,83 @@ type Endpoint struct {
 	// Conditions used to determine the health of the endpoint
 	Conditions []Condition `yaml:"conditions"`
 
+	// Steps is the sequence of requests to perform for endpoints of type HTTP. If specified, the conditions of the
+	// endpoint are evaluated against the response of the last step instead of a request to the URL of the endpoint.
+	Steps []*Step `yaml:"steps,omitempty"`
+
 	// Alerts is the alerting configuration for the endpoint in case of failure
 	Alerts []*alert.Alert `yaml:"alerts,omitempty"`
 
 	// DNSConfig is the configuration for DNS monitoring
 	DNSConfig *dns.Config `yaml:"dns,omitempty"`
 
+	// ICMPConfig is the configuration for ICMP monitoring
+	ICMPConfig *icmpconfig.Config `yaml:"icmp,omitempty"`
+
+	// WebSocketConfig is the configuration for WebSocket monitoring
+	WebSocketConfig *websocketconfig.Config `yaml:"websocket,omitempty"`
+
+	// TCPConfig is the configuration for TCP monitoring
+	TCPConfig *tcpconfig.Config `yaml:"tcp,omitempty"`
+
+	// UDPConfig is the configuration for UDP monitoring
+	UDPConfig *udpconfig.Config `yaml:"udp,omitempty"`
+
+	// TracerouteConfig is the configuration for traceroute monitoring
+	TracerouteConfig *tracerouteconfig.Config `yaml:"traceroute,omitempty"`
+
 	// SSH is the configuration for SSH monitoring
 	SSHConfig *sshconfig.Config `yaml:"ssh,omitempty"`
 
+	// GRPCConfig is the configuration for gRPC monitoring
+	GRPCConfig *grpcconfig.Config `yaml:"grpc,omitempty"`
+
+	// MQTTConfig is the configuration for MQTT monitoring
+	MQTTConfig *mqttconfig.Config `yaml:"mqtt,omitempty"`
+
+	// KafkaConfig is the configuration for Kafka monitoring
+	KafkaConfig *kafkaconfig.Config `yaml:"kafka,omitempty"`
+
+	// AMQPConfig is the configuration for AMQP monitoring
+	AMQPConfig *amqpconfig.Config `yaml:"amqp,omitempty"`
+
+	// NATSConfig is the configuration for NATS monitoring
+	NATSConfig *natsconfig.Config `yaml:"nats,omitempty"`
+
+	// RedisConfig is the configuration for Redis monitoring
+	RedisConfig *redisconfig.Config `yaml:"redis,omitempty"`
+
+	// SQLConfig is the configuration for monitoring databases using SQL queries
+	SQLConfig *sqlconfig.Config `yaml:"sql,omitempty"`
+
+	// LDAPConfig is the configuration for LDAP monitoring
+	LDAPConfig *ldapconfig.Config `yaml:"ldap,omitempty"`
+
+	// SMTPConfig is the configuration for SMTP monitoring
+	SMTPConfig *smtpconfig.Config `yaml:"smtp,omitempty"`
+
+	// MailboxConfig is the configuration for IMAP and POP3 monitoring
+	MailboxConfig *mailboxconfig.Config `yaml:"mailbox,omitempty"`
+
+	// FTPConfig is the configuration for FTP and SFTP monitoring
+	FTPConfig *ftpconfig.Config `yaml:"ftp,omitempty"`
+
+	// SNMPConfig is the configuration for SNMP monitoring
+	SNMPConfig *snmpconfig.Config `yaml:"snmp,omitempty"`
+
+	// SOAPConfig is the configuration for SOAP monitoring
+	SOAPConfig *soapconfig.Config `yaml:"soap,omitempty"`
+
+	// S3Config is the configuration for S3 monitoring
+	S3Config *s3config.Config `yaml:"s3,omitempty"`
+
+	// OAuth2Config is the configuration for OAuth2 monitoring
+	OAuth2Config *oauth2config.Config `yaml:"oauth2,omitempty"`
+
+	// FailureCaptureConfig is the configuration of the capture of the response of the checks that fail.
+	// If nil, failures are not captured.
+	FailureCaptureConfig *capture.Config `yaml:"failure-capture,omitempty"`
+
 	// ClientConfig is the configuration of the client used to communicate with the endpoint's target
 	ClientConfig *client.Config `yaml:"client,omitempty"`
 
@@ -136,6 +263,8 @@ func (e *Endpoint) Type() Type {
 	switch {
 	case e.DNSConfig != nil:
 		return TypeDNS
+	case e.OAuth2Config != nil:
+		return TypeOAuth2
 	case strings.HasPrefix(e.URL, "tcp://"):
 		return TypeTCP
 	case strings.HasPrefix(e.URL, "sctp://"):
@@ -154,6 +283,50 @@ func (e *Endpoint) Type() Type {
 		return TypeWS
 	case strings.HasPrefix(e.URL, "ssh://"):
 		return TypeSSH
+	case strings.HasPrefix(e.URL, "grpc://") || strings.HasPrefix(e.URL, "grpcs://"):
+		return TypeGRPC
+	case strings.HasPrefix(e.URL, "mqtt://") || strings.HasPrefix(e.URL, "mqtts://"):
+		return TypeMQTT
+	case strings.HasPrefix(e.URL, "kafka://") || strings.HasPrefix(e.URL, "kafkas://"):
+		return TypeKafka
+	case strings.HasPrefix(e.URL, "amqp://") || strings.HasPrefix(e.URL, "amqps://"):
+		return TypeAMQP
+	case strings.HasPrefix(e.URL, "nats://") || strings.HasPrefix(e.URL, "natss://"):
+		return TypeNATS
+	case strings.HasPrefix(e.URL, "redis://") || strings.HasPrefix(e.URL, "rediss://"):
+		return TypeRedis
+	case strings.HasPrefix(e.URL, "postgres://") || strings.HasPrefix(e.URL, "postgresql://"):
+		return TypePostgres
+	case strings.HasPrefix(e.URL, "mysql://") || strings.HasPrefix(e.URL, "mariadb://"):
+		return TypeMySQL
+	case strings.HasPrefix(e.URL, "mongodb://") || strings.HasPrefix(e.URL, "mongodb+srv://"):
+		return TypeMongoDB
+	case strings.HasPrefix(e.URL, "ldap://") || strings.HasPrefix(e.URL, "ldaps://"):
+		return TypeLDAP
+	case strings.HasPrefix(e.URL, "smtp://") || strings.HasPrefix(e.URL, "smtps://"):
+		return TypeSMTP
+	case strings.HasPrefix(e.URL, "imap://") || strings.HasPrefix(e.URL, "imaps://"):
+		return TypeIMAP
+	case strings.HasPrefix(e.URL, "pop3://") || strings.HasPrefix(e.URL, "pop3s://"):
+		return TypePOP3
+	case strings.HasPrefix(e.URL, "ftp://"):
+		return TypeFTP
+	case strings.HasPrefix(e.URL, "sftp://"):
+		return TypeSFTP
+	case strings.HasPrefix(e.URL, "ntp://"):
+		return TypeNTP
+	case strings.HasPrefix(e.URL, "snmp://"):
+		return TypeSNMP
+	case strings.HasPrefix(e.URL, "traceroute://"):
+		return TypeTraceroute
+	case strings.HasPrefix(e.URL, "s3://"):
+		return TypeS3
+	case strings.HasPrefix(e.URL, "systemd://"):
+		return TypeSystemd
+	case strings.HasPrefix(e.URL, "file://"):
+		return TypeFile
+	case strings.HasPrefix(e.URL, "disk://"):
+		return TypeDisk
 	default:
 		return TypeUNKNOWN
 	}
@@ -184,6 +357,26 @@ func (e *Endpoint) ValidateAndSetDefaults() error {
 	if e.Interval == 0 {
 		e.Interval = 1 * time.Minute
 	}
+	if e.FailureCaptureConfig != nil {
+		if err := e.FailureCaptureConfig.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+	}
+	if e.SOAPConfig != nil {
+		if e.Type() != TypeHTTP {
+			return ErrEndpointWithSOAPAndNonHTTPType
+		}
+		if e.GraphQL {
+			return ErrEndpointWithSOAPAndGraphQL
+		}
+		if err := e.SOAPConfig.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+		// SOAP requests are always sent using POST unless specified otherwise
+		if len(e.Method) == 0 {
+			e.Method = http.MethodPost
+		}
+	}
 	if len(e.Method) == 0 {
 		e.Method = http.MethodGet
 	}
@@ -199,6 +392,17 @@ func (e *Endpoint) ValidateAndSetDefaults() error {
 	if _, contentTypeHeaderExists := e.Headers[ContentTypeHeader]; !contentTypeHeaderExists && e.GraphQL {
 		e.Headers[ContentTypeHeader] = "application/json"
 	}
+	// Automatically add the headers required by the SOAP version if they're not specified in the endpoint configuration
+	if e.SOAPConfig != nil {
+		for k, v := range e.SOAPConfig.Headers() {
+			if _, headerExists := e.Headers[k]; !headerExists {
+				e.Headers[k] = v
+			}
+		}
+	}
+	if !e.GraphQL && (len(e.GraphQLVariables) > 0 || e.GraphQLFailOnErrors) {
+		return ErrEndpointWithGraphQLOptionsAndGraphQLDisabled
+	}
 	if len(e.Conditions) == 0 {
 		return ErrEndpointWithNoCondition
 	}
@@ -210,12 +414,132 @@ func (e *Endpoint) ValidateAndSetDefaults() error {
 			return fmt.Errorf("%v: %w", ErrInvalidConditionFormat, err)
 		}
 	}
+	if len(e.Steps) > 0 {
+		if e.Type() != TypeHTTP {
+			return ErrEndpointWithStepsAndNonHTTPType
+		}
+		stepNames := make(map[string]bool, len(e.Steps))
+		for _, step := range e.Steps {
+			if err := step.ValidateAndSetDefaults(e); err != nil {
+				return err
+			}
+			if stepNames[step.Name] {
+				return fmt.Errorf("%w: %s", ErrStepWithDuplicateName, step.Name)
+			}
+			stepNames[step.Name] = true
+		}
+	}
 	if e.DNSConfig != nil {
 		return e.DNSConfig.ValidateAndSetDefault()
 	}
 	if e.SSHConfig != nil {
 		return e.SSHConfig.Validate()
 	}
+	if e.Type() == TypeGRPC {
+		if e.GRPCConfig == nil {
+			e.GRPCConfig = &grpcconfig.Config{}
+		}
+		return e.GRPCConfig.Validate()
+	}
+	if e.Type() == TypeMQTT {
+		if e.MQTTConfig == nil {
+			e.MQTTConfig = &mqttconfig.Config{}
+		}
+		return e.MQTTConfig.Validate()
+	}
+	if e.Type() == TypeKafka {
+		if e.KafkaConfig == nil {
+			e.KafkaConfig = &kafkaconfig.Config{}
+		}
+		return e.KafkaConfig.Validate()
+	}
+	if e.Type() == TypeNATS {
+		if e.NATSConfig == nil {
+			e.NATSConfig = &natsconfig.Config{}
+		}
+		return e.NATSConfig.ValidateAndSetDefaults()
+	}
+	if e.Type() == TypeRedis {
+		if e.RedisConfig == nil {
+			e.RedisConfig = &redisconfig.Config{}
+		}
+		return e.RedisConfig.ValidateAndSetDefaults()
+	}
+	if e.Type() == TypePostgres || e.Type() == TypeMySQL {
+		if e.SQLConfig == nil {
+			e.SQLConfig = &sqlconfig.Config{}
+		}
+		return e.SQLConfig.ValidateAndSetDefaults()
+	}
+	if e.Type() == TypeLDAP {
+		if e.LDAPConfig == nil {
+			e.LDAPConfig = &ldapconfig.Config{}
+		}
+		return e.LDAPConfig.ValidateAndSetDefaults()
+	}
+	if e.Type() == TypeSMTP {
+		if e.SMTPConfig == nil {
+			e.SMTPConfig = &smtpconfig.Config{}
+		}
+		return e.SMTPConfig.Validate()
+	}
+	if e.Type() == TypeIMAP || e.Type() == TypePOP3 {
+		if e.MailboxConfig == nil {
+			e.MailboxConfig = &mailboxconfig.Config{}
+		}
+		return e.MailboxConfig.Validate()
+	}
+	if e.Type() == TypeFTP || e.Type() == TypeSFTP {
+		if e.FTPConfig == nil {
+			e.FTPConfig = &ftpconfig.Config{}
+		}
+		return e.FTPConfig.Validate()
+	}
+	if e.Type() == TypeSNMP {
+		if e.SNMPConfig == nil {
+			e.SNMPConfig = &snmpconfig.Config{}
+		}
+		return e.SNMPConfig.ValidateAndSetDefaults()
+	}
+	if e.Type() == TypeICMP {
+		if e.ICMPConfig == nil {
+			e.ICMPConfig = &icmpconfig.Config{}
+		}
+		return e.ICMPConfig.ValidateAndSetDefaults()
+	}
+	if e.Type() == TypeTraceroute {
+		if e.TracerouteConfig == nil {
+			e.TracerouteConfig = &tracerouteconfig.Config{}
+		}
+		return e.TracerouteConfig.ValidateAndSetDefaults()
+	}
+	if e.Type() == TypeUDP {
+		if e.UDPConfig == nil {
+			e.UDPConfig = &udpconfig.Config{}
+		}
+		return e.UDPConfig.ValidateAndSetDefaults(e.Body)
+	}
+	if e.Type() == TypeTCP {
+		if e.TCPConfig == nil {
+			e.TCPConfig = &tcpconfig.Config{}
+		}
+		return e.TCPConfig.ValidateAndSetDefaults()
+	}
+	if e.Type() == TypeWS {
+		if e.WebSocketConfig == nil {
+			e.WebSocketConfig = &websocketconfig.Config{}
+		}
+		return e.WebSocketConfig.Validate(e.Body)
+	}
+	if e.Type() == TypeS3 {
+		if e.S3Config == nil {
+			e.S3Config = &s3config.Config{}
+		}
+		return e.S3Config.ValidateAndSetDefaults(e.URL)
+	}
+	if e.Type() == TypeOAuth2 {
+		return e.OAuth2Config.Validate(e.URL)
+	}
 	if e.Type() == TypeUNKNOWN {
 		return ErrUnknownEndpointType
 	}
@@ -253,8 +577,16 @@ func (e *Endpoint) Close() {
 func (e *Endpoint) EvaluateHealth() *Result {
 	result := &Result{Success: true, Errors: []string{}}
 	// Parse or extract hostname from URL
-	if e.DNSConfig != nil {
+	if e.DNSConfig != nil && !strings.Contains(e.URL, "://") {
 		result.Hostname = strings.TrimSuffix(e.URL, ":53")
+	} else if e.Type() == TypeKafka || e.Type() == TypeRedis || e.Type() == TypeMongoDB {
+		// Kafka, Redis Sentinel and MongoDB URLs may contain multiple comma-separated hosts, in which case we only use the first one
+		firstBroker, _, _ := strings.Cut(e.URL, ",")
+		if urlObject, err := url.Parse(firstBroker); err != nil {
+			result.AddError(err.Error())
+		} else {
+			result.Hostname = urlObject.Hostname()
+		}
 	} else {
 		urlObject, err := url.Parse(e.URL)
 		if err != nil {
@@ -280,6 +612,13 @@ func (e *Endpoint) EvaluateHealth() *Result {
 	} else {
 		result.Success = false
 	}
+	// Check whether the certificate has been revoked if necessary
+	if e.ClientConfig.CheckRevocation && len(result.certificateChain) > 0 {
+		if err := client.CheckCertificateRevocation(result.certificateChain, e.ClientConfig); err != nil {
+			result.AddError(err.Error())
+			result.Success = false
+		}
+	}
 	// Evaluate the conditions
 	for _, condition := range e.Conditions {
 		success := condition.evaluate(result, e.UIConfig.DontResolveFailedConditions)
@@ -303,6 +642,9 @@ func (e *Endpoint) EvaluateHealth() *Result {
 	if e.UIConfig.HideConditions {
 		result.ConditionResults = nil
 	}
+	if e.FailureCaptureConfig != nil && !result.Success {
+		result.FailureCapture = newFailureCapture(result, e.FailureCaptureConfig.MaximumBodySize)
+	}
 	return result
 }
 
@@ -321,12 +663,16 @@ func (e *Endpoint) call(result *Result) {
 	var err error
 	var certificate *x509.Certificate
 	endpointType := e.Type()
+	if endpointType == TypeHTTP && len(e.Steps) > 0 {
+		e.callSteps(result)
+		return
+	}
 	if endpointType == TypeHTTP {
 		request = e.buildHTTPRequest()
 	}
 	startTime := time.Now()
 	if endpointType == TypeDNS {
-		result.Connected, result.DNSRCode, result.Body, err = client.QueryDNS(e.DNSConfig.QueryType, e.DNSConfig.QueryName, e.URL)
+		result.Connected, result.DNSRCode, result.Body, err = client.QueryDNS(e.DNSConfig.QueryType, e.DNSConfig.QueryName, e.URL, e.ClientConfig)
 		if err != nil {
 			result.AddError(err.Error())
 			return
@@ -344,19 +690,78 @@ func (e *Endpoint) call(result *Result) {
 		}
 		result.Duration = time.Since(startTime)
 		result.CertificateExpiration = time.Until(certificate.NotAfter)
+		result.certificateChain = []*x509.Certificate{certificate}
 	} else if endpointType == TypeTCP {
-		result.Connected = client.CanCreateTCPConnection(strings.TrimPrefix(e.URL, "tcp://"), e.ClientConfig)
-		result.Duration = time.Since(startTime)
+		if e.TCPConfig == nil || len(e.TCPConfig.Steps) == 0 {
+			result.Connected = client.CanCreateTCPConnection(strings.TrimPrefix(e.URL, "tcp://"), e.ClientConfig)
+			result.Duration = time.Since(startTime)
+		} else {
+			steps := make([]client.TCPStep, 0, len(e.TCPConfig.Steps))
+			for _, step := range e.TCPConfig.Steps {
+				tcpStep := client.TCPStep{Send: step.Payload(), Timeout: step.Timeout}
+				if step.HasExpectation() {
+					step := step
+					tcpStep.Expect = func(received []byte) bool {
+						return e.TCPConfig.Matches(step, received)
+					}
+				}
+				steps = append(steps, tcpStep)
+			}
+			var received []byte
+			result.Connected, received, err = client.QueryTCP(strings.TrimPrefix(e.URL, "tcp://"), steps, e.ClientConfig)
+			result.Body = e.TCPConfig.Encode(received)
+			if err != nil {
+				result.AddError(err.Error())
+				result.Success = false
+				return
+			}
+			result.Duration = time.Since(startTime)
+		}
 	} else if endpointType == TypeUDP {
-		result.Connected = client.CanCreateUDPConnection(strings.TrimPrefix(e.URL, "udp://"), e.ClientConfig)
-		result.Duration = time.Since(startTime)
+		if len(e.Body) == 0 {
+			result.Connected = client.CanCreateUDPConnection(strings.TrimPrefix(e.URL, "udp://"), e.ClientConfig)
+			result.Duration = time.Since(startTime)
+		} else {
+			var payload, response []byte
+			if payload, err = e.UDPConfig.Payload(e.Body); err != nil {
+				result.AddError(err.Error())
+				return
+			}
+			result.Connected, response, err = client.QueryUDP(strings.TrimPrefix(e.URL, "udp://"), payload, e.ClientConfig)
+			if err != nil {
+				result.AddError(err.Error())
+				return
+			}
+			result.Duration = time.Since(startTime)
+			result.Body = e.UDPConfig.EncodeResponse(response)
+			if !e.UDPConfig.MatchesExpectedResponse(result.Body) {
+				result.AddError("udp response did not match expected-response")
+				result.Success = false
+			}
+		}
 	} else if endpointType == TypeSCTP {
 		result.Connected = client.CanCreateSCTPConnection(strings.TrimPrefix(e.URL, "sctp://"), e.ClientConfig)
 		result.Duration = time.Since(startTime)
 	} else if endpointType == TypeICMP {
-		result.Connected, result.Duration = client.Ping(strings.TrimPrefix(e.URL, "icmp://"), e.ClientConfig)
+		var statistics *client.PingStatistics
+		result.Connected, statistics = client.PingWithStatistics(strings.TrimPrefix(e.URL, "icmp://"), e.ICMPConfig.Count, e.ICMPConfig.Interval, e.ClientConfig)
+		if statistics != nil {
+			result.PacketLoss, result.MinRTT, result.AvgRTT, result.MaxRTT = statistics.PacketLoss, statistics.MinRTT, statistics.AvgRTT, statistics.MaxRTT
+			result.Duration = statistics.AvgRTT
+			if !result.Connected {
+				result.Duration = e.ClientConfig.Timeout
+			}
+		}
 	} else if endpointType == TypeWS {
-		result.Connected, result.Body, err = client.QueryWebSocket(e.URL, e.Body, e.ClientConfig)
+		if e.WebSocketConfig != nil && len(e.WebSocketConfig.Messages) > 0 {
+			result.Connected, result.Body, err = client.QueryWebSocketWithMessages(e.URL, e.WebSocketConfig.Messages, e.WebSocketConfig.Subprotocols, e.ClientConfig)
+		} else {
+			var subprotocols []string
+			if e.WebSocketConfig != nil {
+				subprotocols = e.WebSocketConfig.Subprotocols
+			}
+			result.Connected, result.Body, err = client.QueryWebSocket(e.URL, e.Body, subprotocols, e.ClientConfig)
+		}
 		if err != nil {
 			result.AddError(err.Error())
 			return
@@ -369,12 +774,219 @@ func (e *Endpoint) call(result *Result) {
 			result.AddError(err.Error())
 			return
 		}
-		result.Success, result.HTTPStatus, err = client.ExecuteSSHCommand(cli, e.Body, e.ClientConfig)
+		result.Success, result.ExitCode, result.Body, err = client.ExecuteSSHCommand(cli, e.Body, e.ClientConfig)
+		// For backward compatibility, the exit code is also available through the [STATUS] placeholder
+		result.HTTPStatus = result.ExitCode
+		if err != nil {
+			result.AddError(err.Error())
+			return
+		}
+		result.Duration = time.Since(startTime)
+	} else if endpointType == TypeGRPC {
+		result.Connected, result.Body, err = client.QueryGRPC(e.URL, e.GRPCConfig.Method, e.GRPCConfig.DescriptorSet, e.Body, e.Headers, e.ClientConfig)
+		if err != nil {
+			result.AddError(err.Error())
+			return
+		}
+		result.Duration = time.Since(startTime)
+	} else if endpointType == TypeMQTT {
+		result.Connected, result.Body, result.Duration, err = client.QueryMQTT(e.URL, e.MQTTConfig.Username, e.MQTTConfig.Password, e.MQTTConfig.SubscribeTopic, e.MQTTConfig.PublishTopic, e.Body, e.MQTTConfig.QoS, e.ClientConfig)
+		if err != nil {
+			result.AddError(err.Error())
+			return
+		}
+	} else if endpointType == TypeAMQP {
+		var queue string
+		if e.AMQPConfig != nil {
+			queue = e.AMQPConfig.Queue
+		}
+		result.Connected, result.Body, err = client.QueryAMQP(e.URL, queue, e.ClientConfig)
+		if err != nil {
+			result.AddError(err.Error())
+			return
+		}
+		result.Duration = time.Since(startTime)
+	} else if endpointType == TypeNATS {
+		result.Connected, result.Body, result.Duration, err = client.QueryNATS(e.URL, e.NATSConfig.Subject, e.NATSConfig.Mode == natsconfig.ModePublishSubscribe, e.NATSConfig.Stream, e.NATSConfig.Consumer, e.Body, e.ClientConfig)
+		if err != nil {
+			result.AddError(err.Error())
+			return
+		}
+	} else if endpointType == TypeRedis {
+		result.Connected, result.Body, err = client.QueryRedis(e.URL, e.RedisConfig.Command, e.RedisConfig.SentinelMaster, e.ClientConfig)
 		if err != nil {
 			result.AddError(err.Error())
 			return
 		}
 		result.Duration = time.Since(startTime)
+	} else if endpointType == TypePostgres {
+		result.Connected, result.Body, err = client.QuerySQL("postgres", e.URL, e.SQLConfig.Query, e.ClientConfig)
+		if err != nil {
+			result.AddError(err.Error())
+			return
+		}
+		result.Duration = time.Since(startTime)
+	} else if endpointType == TypeMySQL {
+		result.Connected, result.Body, err = client.QueryMySQL(e.URL, e.SQLConfig.Query, e.ClientConfig)
+		if err != nil {
+			result.AddError(err.Error())
+			return
+		}
+		result.Duration = time.Since(startTime)
+	} else if endpointType == TypeMongoDB {
+		result.Connected, result.Body, err = client.QueryMongoDB(e.URL, e.ClientConfig)
+		if err != nil {
+			result.AddError(err.Error())
+			return
+		}
+		result.Duration = time.Since(startTime)
+	} else if endpointType == TypeLDAP {
+		result.Connected, certificate, result.Body, err = client.QueryLDAP(e.URL, e.LDAPConfig.BindDN, e.LDAPConfig.Password, e.LDAPConfig.StartTLS, e.LDAPConfig.BaseDN, e.LDAPConfig.Filter, e.ClientConfig)
+		if certificate != nil {
+			result.CertificateExpiration = time.Until(certificate.NotAfter)
+			result.certificateChain = []*x509.Certificate{certificate}
+		}
+		if err != nil {
+			result.AddError(err.Error())
+			return
+		}
+		result.Duration = time.Since(startTime)
+	} else if endpointType == TypeSMTP {
+		result.Connected, certificate, err = client.QuerySMTP(e.URL, e.SMTPConfig.StartTLS, e.SMTPConfig.Username, e.SMTPConfig.Password, e.ClientConfig)
+		if certificate != nil {
+			result.CertificateExpiration = time.Until(certificate.NotAfter)
+			result.certificateChain = []*x509.Certificate{certificate}
+		}
+		if err != nil {
+			result.AddError(err.Error())
+			return
+		}
+		result.Duration = time.Since(startTime)
+	} else if endpointType == TypeIMAP || endpointType == TypePOP3 {
+		if endpointType == TypeIMAP {
+			result.Connected, certificate, err = client.QueryIMAP(e.URL, e.MailboxConfig.StartTLS, e.MailboxConfig.Username, e.MailboxConfig.Password, e.ClientConfig)
+		} else {
+			result.Connected, certificate, err = client.QueryPOP3(e.URL, e.MailboxConfig.StartTLS, e.MailboxConfig.Username, e.MailboxConfig.Password, e.ClientConfig)
+		}
+		if certificate != nil {
+			result.CertificateExpiration = time.Until(certificate.NotAfter)
+			result.certificateChain = []*x509.Certificate{certificate}
+		}
+		if err != nil {
+			result.AddError(err.Error())
+			return
+		}
+		result.Duration = time.Since(startTime)
+	} else if endpointType == TypeFTP || endpointType == TypeSFTP {
+		if endpointType == TypeFTP {
+			result.Connected, result.Body, err = client.QueryFTP(e.URL, e.FTPConfig.Username, e.FTPConfig.Password, e.FTPConfig.Directory, e.FTPConfig.File, e.ClientConfig)
+		} else {
+			result.Connected, result.Body, err = client.QuerySFTP(e.URL, e.FTPConfig.Username, e.FTPConfig.Password, e.FTPConfig.Directory, e.FTPConfig.File, e.ClientConfig)
+		}
+		if err != nil {
+			result.AddError(err.Error())
+			return
+		}
+		result.Duration = time.Since(startTime)
+	} else if endpointType == TypeNTP {
+		result.Connected, result.NTPOffset, result.NTPStratum, result.Duration, err = client.QueryNTP(e.URL, e.ClientConfig)
+		if err != nil {
+			result.AddError(err.Error())
+			return
+		}
+	} else if endpointType == TypeSNMP {
+		credentials := &client.SNMPv3Credentials{
+			Username:        e.SNMPConfig.Username,
+			AuthProtocol:    e.SNMPConfig.AuthProtocol,
+			AuthPassword:    e.SNMPConfig.AuthPassword,
+			PrivacyProtocol: e.SNMPConfig.PrivacyProtocol,
+			PrivacyPassword: e.SNMPConfig.PrivacyPassword,
+		}
+		result.Connected, result.Body, err = client.QuerySNMP(e.URL, e.SNMPConfig.Version, e.SNMPConfig.Community, e.SNMPConfig.OIDs, credentials, e.ClientConfig)
+		if err != nil {
+			result.AddError(err.Error())
+			return
+		}
+		result.Duration = time.Since(startTime)
+	} else if endpointType == TypeTraceroute {
+		result.Connected, result.HopCount, result.Body, err = client.Traceroute(strings.TrimPrefix(e.URL, "traceroute://"), e.TracerouteConfig.MaxHops, e.TracerouteConfig.HopTimeout, e.ClientConfig)
+		if err != nil {
+			result.AddError(err.Error())
+			return
+		}
+		result.Duration = time.Since(startTime)
+	} else if endpointType == TypeS3 {
+		// The object is only downloaded if a condition needs its content
+		method := http.MethodHead
+		if e.needsToReadBody() {
+			method = http.MethodGet
+		}
+		response, err = client.QueryS3Object(method, e.S3Config.ObjectURL(), e.S3Config.Region, e.S3Config.Credentials(), e.ClientConfig)
+		result.Duration = time.Since(startTime)
+		if err != nil {
+			result.AddError(err.Error())
+			return
+		}
+		defer response.Body.Close()
+		result.populateFromHTTPResponse(response)
+		result.ObjectSize = response.ContentLength
+		if lastModified, err := http.ParseTime(response.Header.Get("Last-Modified")); err == nil {
+			result.ObjectAge = time.Since(lastModified)
+		}
+		if method == http.MethodGet {
+			result.Body, err = io.ReadAll(response.Body)
+			if err != nil {
+				result.AddError("error reading response body:" + err.Error())
+			}
+			if result.ObjectSize < 0 {
+				result.ObjectSize = int64(len(result.Body))
+			}
+		}
+	} else if endpointType == TypeOAuth2 {
+		tokenRequest := &client.OAuth2TokenRequest{
+			ClientID:     e.OAuth2Config.ClientID,
+			ClientSecret: e.OAuth2Config.ClientSecret,
+			Scopes:       e.OAuth2Config.Scopes,
+			Audience:     e.OAuth2Config.Audience,
+			Issuer:       e.OAuth2Config.Issuer,
+			JWKSURL:      e.OAuth2Config.JWKSURL,
+		}
+		result.Connected, result.HTTPStatus, result.Body, err = client.QueryOAuth2Token(e.URL, tokenRequest, e.ClientConfig)
+		if err != nil {
+			result.AddError(err.Error())
+			result.Success = false
+			return
+		}
+		result.Duration = time.Since(startTime)
+	} else if endpointType == TypeSystemd {
+		result.Connected, result.Body, err = client.QuerySystemdUnit(e.URL, e.ClientConfig)
+		if err != nil {
+			result.AddError(err.Error())
+			return
+		}
+		result.Duration = time.Since(startTime)
+	} else if endpointType == TypeFile {
+		// The file is only read if a condition needs its content
+		result.Connected, result.ObjectSize, result.ObjectAge, result.Body, err = client.QueryFile(e.URL, e.needsToReadBody())
+		if err != nil {
+			result.AddError(err.Error())
+			return
+		}
+		result.Duration = time.Since(startTime)
+	} else if endpointType == TypeDisk {
+		result.DiskFreePercent, result.Body, err = client.QueryDiskUsage(e.URL)
+		if err != nil {
+			result.AddError(err.Error())
+			return
+		}
+		result.Connected = true
+		result.Duration = time.Since(startTime)
+	} else if endpointType == TypeKafka {
+		result.Connected, result.Body, result.Duration, err = client.QueryKafka(e.URL, e.KafkaConfig.Topic, e.KafkaConfig.Partition, e.KafkaConfig.RoundTrip, e.KafkaConfig.SASLMechanism, e.KafkaConfig.Username, e.KafkaConfig.Password, e.ClientConfig)
+		if err != nil {
+			result.AddError(err.Error())
+			return
+		}
 	} else {
 		response, err = client.GetHTTPClient(e.ClientConfig).Do(request)
 		result.Duration = time.Since(startTime)
@@ -383,18 +995,23 @@ func (e *Endpoint) call(result *Result) {
 			return
 		}
 		defer response.Body.Close()
-		if response.TLS != nil && len(response.TLS.PeerCertificates) > 0 {
-			certificate = response.TLS.PeerCertificates[0]
-			result.CertificateExpiration = time.Until(certificate.NotAfter)
-		}
-		result.HTTPStatus = response.StatusCode
-		result.Connected = response.StatusCode > 0
+		result.populateFromHTTPResponse(response)
 		// Only read the Body if there's a condition that uses the BodyPlaceholder
 		if e.needsToReadBody() {
 			result.Body, err = io.ReadAll(response.Body)
 			if err != nil {
 				result.AddError("error reading response body:" + err.Error())
 			}
+		} else if e.FailureCaptureConfig != nil {
+			// Otherwise, only read as much as can be captured in case the check fails, plus a byte to tell whether
+			// the body is truncated
+			result.Body, _ = io.ReadAll(io.LimitReader(response.Body, int64(e.FailureCaptureConfig.MaximumBodySize)+1))
+		}
+		if e.GraphQL && e.GraphQLFailOnErrors {
+			for _, message := range graphQLErrorMessages(result.Body) {
+				result.AddError("graphql error: " + message)
+				result.Success = false
+			}
 		}
 	}
 }
@@ -402,11 +1019,10 @@ func (e *Endpoint) call(result *Result) {
 func (e *Endpoint) buildHTTPRequest() *http.Request {
 	var bodyBuffer *bytes.Buffer
 	if e.GraphQL {
-		graphQlBody := map[string]string{
-			"query": e.Body,
-		}
-		body, _ := json.Marshal(graphQlBody)
+		body, _ := e.buildGraphQLBody()
 		bodyBuffer = bytes.NewBuffer(body)
+	} else if e.SOAPConfig != nil {
+		bodyBuffer = bytes.NewBuffer(e.SOAPConfig.Envelope(e.Body))
 	} else {
 		bodyBuffer = bytes.NewBuffer([]byte(e.Body))
 	}
@@ -422,6 +1038,9 @@ func (e *Endpoint) buildHTTPRequest() *http.Request {
 
 // needsToReadBody checks if there's any condition that requires the response Body to be read
 func (e *Endpoint) needsToReadBody() bool {
+	if e.GraphQL && e.GraphQLFailOnErrors {
+		return true
+	}
 	for _, condition := range e.Conditions {
 		if condition.hasBodyPlaceholder() {
 			return true
@@ -2,6 +2,7 @@ package endpoint
 
 import (
 	"errors"
+	"time"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
 )
@@ -9,6 +10,10 @@ import (
 var (
 	// ErrExternalEndpointWithNoToken is the error with which Gatus will panic if an external endpoint is configured without a token.
 	ErrExternalEndpointWithNoToken = errors.New("you must specify a token for each external endpoint")
+
+	// ErrExternalEndpointWithInvalidExpectedInterval is the error with which Gatus will panic if an external endpoint is
+	// configured with a negative expected interval.
+	ErrExternalEndpointWithInvalidExpectedInterval = errors.New("the expected interval of an external endpoint must not be negative")
 )
 
 // ExternalEndpoint is an endpoint whose result is pushed from outside Gatus, which means that
@@ -24,12 +29,19 @@ type ExternalEndpoint struct {
 	// Group the endpoint is a part of. Used for grouping multiple endpoints together on the front end.
 	Group string `yaml:"group,omitempty"`
 
+	// Tenant the endpoint is assigned to, if any. See config.Config.Tenants
+	Tenant string `yaml:"tenant,omitempty"`
+
 	// Token is the bearer token that must be provided through the Authorization header to push results to the endpoint
 	Token string `yaml:"token,omitempty"`
 
 	// Alerts is the alerting configuration for the endpoint in case of failure
 	Alerts []*alert.Alert `yaml:"alerts,omitempty"`
 
+	// ExpectedInterval is the maximum duration between two results pushed to the endpoint. If set, a failure is
+	// recorded every time it elapses without a result being pushed, like a dead man's switch would.
+	ExpectedInterval time.Duration `yaml:"expected-interval,omitempty"`
+
 	// NumberOfFailuresInARow is the number of unsuccessful evaluations in a row
 	NumberOfFailuresInARow int `yaml:"-"`
 
@@ -45,6 +57,9 @@ func (externalEndpoint *ExternalEndpoint) ValidateAndSetDefaults() error {
 	if len(externalEndpoint.Token) == 0 {
 		return ErrExternalEndpointWithNoToken
 	}
+	if externalEndpoint.ExpectedInterval < 0 {
+		return ErrExternalEndpointWithInvalidExpectedInterval
+	}
 	return nil
 }
 
@@ -75,6 +90,7 @@ func (externalEndpoint *ExternalEndpoint) ToEndpoint() *Endpoint {
 		Enabled:                 externalEndpoint.Enabled,
 		Name:                    externalEndpoint.Name,
 		Group:                   externalEndpoint.Group,
+		Tenant:                  externalEndpoint.Tenant,
 		Alerts:                  externalEndpoint.Alerts,
 		NumberOfFailuresInARow:  externalEndpoint.NumberOfFailuresInARow,
 		NumberOfSuccessesInARow: externalEndpoint.NumberOfSuccessesInARow,
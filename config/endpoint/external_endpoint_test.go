@@ -1,7 +1,9 @@
 package endpoint
 
 import (
+	"errors"
 	"testing"
+	"time"
 )
 
 func TestExternalEndpoint_ToEndpoint(t *testing.T) {
@@ -23,3 +25,37 @@ func TestExternalEndpoint_ToEndpoint(t *testing.T) {
 		t.Errorf("expected %s, got %s", externalEndpoint.DisplayName(), convertedEndpoint.DisplayName())
 	}
 }
+
+func TestExternalEndpoint_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name             string
+		externalEndpoint *ExternalEndpoint
+		expectedErr      error
+	}{
+		{
+			name:             "valid",
+			externalEndpoint: &ExternalEndpoint{Name: "job", Token: "token"},
+		},
+		{
+			name:             "valid-with-expected-interval",
+			externalEndpoint: &ExternalEndpoint{Name: "job", Token: "token", ExpectedInterval: time.Hour},
+		},
+		{
+			name:             "no-token",
+			externalEndpoint: &ExternalEndpoint{Name: "job"},
+			expectedErr:      ErrExternalEndpointWithNoToken,
+		},
+		{
+			name:             "negative-expected-interval",
+			externalEndpoint: &ExternalEndpoint{Name: "job", Token: "token", ExpectedInterval: -time.Hour},
+			expectedErr:      ErrExternalEndpointWithInvalidExpectedInterval,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.externalEndpoint.ValidateAndSetDefaults(); !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package endpoint
+
+import (
+	"strings"
+	"time"
+)
+
+// redactedResponseHeaders are the headers of the response whose values are never captured
+var redactedResponseHeaders = []string{"Set-Cookie", "Www-Authenticate", "Proxy-Authenticate"}
+
+// FailureCapture is what the endpoint returned during a check that failed, which is kept to diagnose the failure
+type FailureCapture struct {
+	// Timestamp of the result that failed
+	Timestamp time.Time `json:"timestamp"`
+
+	// HTTPStatus is the HTTP response status code, if applicable
+	HTTPStatus int `json:"status,omitempty"`
+
+	// Headers of the HTTP response, if applicable
+	Headers map[string][]string `json:"headers,omitempty"`
+
+	// Body of the response, truncated to the maximum body size of the failure capture configuration of the endpoint
+	Body string `json:"body"`
+
+	// Truncated is whether the Body was truncated
+	Truncated bool `json:"truncated,omitempty"`
+
+	// Errors of the result that failed
+	Errors []string `json:"errors,omitempty"`
+}
+
+// newFailureCapture creates a FailureCapture from a result, keeping at most maximumBodySize bytes of its body
+func newFailureCapture(result *Result, maximumBodySize int) *FailureCapture {
+	capture := &FailureCapture{
+		Timestamp:  result.Timestamp,
+		HTTPStatus: result.HTTPStatus,
+		Errors:     append([]string(nil), result.Errors...),
+	}
+	body := result.Body
+	if len(body) > maximumBodySize {
+		body, capture.Truncated = body[:maximumBodySize], true
+	}
+	// Bodies are stored as text, which must be valid UTF-8 and may not contain NUL characters
+	capture.Body = strings.ReplaceAll(strings.ToValidUTF8(string(body), "�"), "\x00", "�")
+	if len(result.responseHeader) > 0 {
+		headers := result.responseHeader.Clone()
+		for _, name := range redactedResponseHeaders {
+			if _, exists := headers[name]; exists {
+				headers[name] = []string{"<redacted>"}
+			}
+		}
+		capture.Headers = headers
+	}
+	return capture
+}
@@ -0,0 +1,74 @@
+package endpoint
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/config/endpoint/capture"
+)
+
+func TestNewFailureCapture(t *testing.T) {
+	result := &Result{
+		HTTPStatus: 503,
+		Body:       []byte("unavailable\x00\xff"),
+		Errors:     []string{"error-1"},
+		responseHeader: http.Header{
+			"Content-Type": {"text/plain"},
+			"Set-Cookie":   {"session=secret"},
+		},
+	}
+	failureCapture := newFailureCapture(result, 64)
+	if failureCapture.HTTPStatus != 503 || len(failureCapture.Errors) != 1 {
+		t.Errorf("expected status and errors of the result to be captured, got %d and %v", failureCapture.HTTPStatus, failureCapture.Errors)
+	}
+	if failureCapture.Body != "unavailable��" || failureCapture.Truncated {
+		t.Errorf("expected body to be captured as valid text without being truncated, got %q", failureCapture.Body)
+	}
+	if failureCapture.Headers["Content-Type"][0] != "text/plain" || failureCapture.Headers["Set-Cookie"][0] != "<redacted>" {
+		t.Errorf("expected headers to be captured and Set-Cookie to be redacted, got %v", failureCapture.Headers)
+	}
+	if result.responseHeader.Get("Set-Cookie") != "session=secret" {
+		t.Error("expected headers of the result to be left untouched")
+	}
+	failureCapture = newFailureCapture(result, 4)
+	if failureCapture.Body != "unav" || !failureCapture.Truncated {
+		t.Errorf("expected body to be truncated, got %q", failureCapture.Body)
+	}
+}
+
+func TestIntegrationEvaluateHealthWithFailureCapture(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "123")
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(strings.Repeat("a", 100)))
+	}))
+	defer server.Close()
+	endpoint := Endpoint{
+		Name:                 "failure-capture",
+		URL:                  server.URL,
+		Conditions:           []Condition{"[STATUS] == 200"},
+		FailureCaptureConfig: &capture.Config{MaximumBodySize: 10},
+	}
+	if err := endpoint.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("did not expect an error, got", err)
+	}
+	result := endpoint.EvaluateHealth()
+	if result.Success {
+		t.Fatal("expected failure")
+	}
+	if result.FailureCapture == nil {
+		t.Fatal("expected failure to be captured")
+	}
+	if result.FailureCapture.HTTPStatus != 500 || result.FailureCapture.Body != strings.Repeat("a", 10) || !result.FailureCapture.Truncated {
+		t.Errorf("expected truncated body and status to be captured, got %+v", result.FailureCapture)
+	}
+	if result.FailureCapture.Headers["X-Request-Id"][0] != "123" {
+		t.Errorf("expected headers to be captured, got %v", result.FailureCapture.Headers)
+	}
+	endpoint.Conditions = []Condition{"[STATUS] == 500"}
+	if result = endpoint.EvaluateHealth(); result.FailureCapture != nil {
+		t.Error("expected successful checks not to be captured")
+	}
+}
@@ -0,0 +1,37 @@
+package ftp
+
+import (
+	"errors"
+)
+
+var (
+	// ErrEndpointWithFTPPasswordWithoutUsername is the error with which Gatus will panic if an endpoint with FTP or SFTP monitoring is configured with a password but without a username.
+	ErrEndpointWithFTPPasswordWithoutUsername = errors.New("you must specify the username of the FTP endpoint's password")
+
+	// ErrEndpointWithFTPDirectoryAndFile is the error with which Gatus will panic if an endpoint with FTP or SFTP monitoring is configured with both a directory and a file.
+	ErrEndpointWithFTPDirectoryAndFile = errors.New("you may only specify either a directory to list or a file to stat for FTP endpoints, not both")
+)
+
+// Config is the configuration of FTP and SFTP endpoints
+type Config struct {
+	// Username is the username to authenticate with. If empty, FTP endpoints log in anonymously.
+	Username string `yaml:"username,omitempty"`
+	Password string `yaml:"password,omitempty"`
+
+	// Directory is the path of the directory to list after authenticating
+	Directory string `yaml:"directory,omitempty"`
+
+	// File is the path of the file to stat after authenticating
+	File string `yaml:"file,omitempty"`
+}
+
+// Validate validates the FTP configuration
+func (cfg *Config) Validate() error {
+	if len(cfg.Username) == 0 && len(cfg.Password) > 0 {
+		return ErrEndpointWithFTPPasswordWithoutUsername
+	}
+	if len(cfg.Directory) > 0 && len(cfg.File) > 0 {
+		return ErrEndpointWithFTPDirectoryAndFile
+	}
+	return nil
+}
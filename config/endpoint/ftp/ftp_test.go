@@ -0,0 +1,47 @@
+package ftp
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		cfg         *Config
+		expectedErr error
+	}{
+		{
+			name:        "anonymous",
+			cfg:         &Config{},
+			expectedErr: nil,
+		},
+		{
+			name:        "list-directory",
+			cfg:         &Config{Username: "gatus", Password: "password", Directory: "/incoming"},
+			expectedErr: nil,
+		},
+		{
+			name:        "stat-file",
+			cfg:         &Config{Username: "gatus", Password: "password", File: "/incoming/report.csv"},
+			expectedErr: nil,
+		},
+		{
+			name:        "password-without-username",
+			cfg:         &Config{Password: "password"},
+			expectedErr: ErrEndpointWithFTPPasswordWithoutUsername,
+		},
+		{
+			name:        "directory-and-file",
+			cfg:         &Config{Directory: "/incoming", File: "/incoming/report.csv"},
+			expectedErr: ErrEndpointWithFTPDirectoryAndFile,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.Validate(); !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected error to be '%v', got '%v'", scenario.expectedErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package endpoint
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+var (
+	// ErrEndpointWithGraphQLOptionsAndGraphQLDisabled is the error with which Gatus will panic if an endpoint is
+	// configured with GraphQL variables or with graphql-fail-on-errors without having graphql set to true
+	ErrEndpointWithGraphQLOptionsAndGraphQLDisabled = errors.New("graphql-variables and graphql-fail-on-errors require graphql to be set to true")
+)
+
+// graphQLRequest is the standard envelope of a GraphQL request sent over HTTP
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+// graphQLResponse is the subset of a GraphQL response that Gatus is aware of
+type graphQLResponse struct {
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// buildGraphQLBody wraps the body of the endpoint in a GraphQL envelope along with the variables of the endpoint
+func (e *Endpoint) buildGraphQLBody() ([]byte, error) {
+	return json.Marshal(graphQLRequest{Query: e.Body, Variables: e.GraphQLVariables})
+}
+
+// graphQLErrorMessages returns the message of each error in the errors array of a GraphQL response.
+// A body that isn't a JSON object or that has no errors array returns nil.
+func graphQLErrorMessages(body []byte) []string {
+	var response graphQLResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil
+	}
+	var messages []string
+	for _, graphQLError := range response.Errors {
+		messages = append(messages, graphQLError.Message)
+	}
+	return messages
+}
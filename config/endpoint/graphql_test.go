@@ -0,0 +1,142 @@
+package endpoint
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestEndpoint_ValidateAndSetDefaultsWithGraphQLOptions(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		graphQL     bool
+		variables   map[string]interface{}
+		failOnError bool
+		expectedErr error
+	}{
+		{
+			name:      "variables-with-graphql",
+			graphQL:   true,
+			variables: map[string]interface{}{"gender": "female"},
+		},
+		{
+			name:        "fail-on-errors-with-graphql",
+			graphQL:     true,
+			failOnError: true,
+		},
+		{
+			name:        "variables-without-graphql",
+			variables:   map[string]interface{}{"gender": "female"},
+			expectedErr: ErrEndpointWithGraphQLOptionsAndGraphQLDisabled,
+		},
+		{
+			name:        "fail-on-errors-without-graphql",
+			failOnError: true,
+			expectedErr: ErrEndpointWithGraphQLOptionsAndGraphQLDisabled,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			endpoint := Endpoint{
+				Name:                "graphql",
+				URL:                 "https://example.org/graphql",
+				GraphQL:             scenario.graphQL,
+				GraphQLVariables:    scenario.variables,
+				GraphQLFailOnErrors: scenario.failOnError,
+				Conditions:          []Condition{"[STATUS] == 200"},
+			}
+			if err := endpoint.ValidateAndSetDefaults(); err != scenario.expectedErr {
+				t.Errorf("expected error to be '%v', got '%v'", scenario.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestEndpoint_buildHTTPRequestWithGraphQLVariables(t *testing.T) {
+	endpoint := Endpoint{
+		Name:             "graphql",
+		URL:              "https://example.org/graphql",
+		Method:           "POST",
+		GraphQL:          true,
+		GraphQLVariables: map[string]interface{}{"gender": "female", "limit": 10},
+		Body:             "query($gender: String!, $limit: Int) { users(gender: $gender, limit: $limit) { id } }",
+		Conditions:       []Condition{"[STATUS] == 200"},
+	}
+	if err := endpoint.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("did not expect an error, got", err)
+	}
+	body, _ := io.ReadAll(endpoint.buildHTTPRequest().Body)
+	expectedBody := `{"query":"query($gender: String!, $limit: Int) { users(gender: $gender, limit: $limit) { id } }","variables":{"gender":"female","limit":10}}`
+	if string(body) != expectedBody {
+		t.Errorf("expected body to be %s, got %s", expectedBody, string(body))
+	}
+}
+
+func TestIntegrationEvaluateHealthWithGraphQLErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if strings.Contains(string(body), "unknownField") {
+			_, _ = w.Write([]byte(`{"data":{"users":[]},"errors":[{"message":"Cannot query field \"unknownField\""}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"users":[{"id":1}]}}`))
+	}))
+	defer server.Close()
+	scenarios := []struct {
+		name            string
+		query           string
+		failOnErrors    bool
+		expectedSuccess bool
+		expectedErrors  []string
+	}{
+		{
+			name:            "no-errors",
+			query:           "{ users { id } }",
+			failOnErrors:    true,
+			expectedSuccess: true,
+		},
+		{
+			name:            "errors",
+			query:           "{ users { id unknownField } }",
+			failOnErrors:    true,
+			expectedSuccess: false,
+			expectedErrors:  []string{`graphql error: Cannot query field "unknownField"`},
+		},
+		{
+			name:            "errors-ignored",
+			query:           "{ users { id unknownField } }",
+			failOnErrors:    false,
+			expectedSuccess: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			endpoint := Endpoint{
+				Name:                "graphql",
+				URL:                 server.URL,
+				Method:              "POST",
+				GraphQL:             true,
+				GraphQLFailOnErrors: scenario.failOnErrors,
+				Body:                scenario.query,
+				Conditions:          []Condition{"[STATUS] == 200"},
+			}
+			if err := endpoint.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("did not expect an error, got", err)
+			}
+			result := endpoint.EvaluateHealth()
+			if result.Success != scenario.expectedSuccess {
+				t.Errorf("expected success to be %v, got %v", scenario.expectedSuccess, result.Success)
+			}
+			if len(result.Errors) != len(scenario.expectedErrors) {
+				t.Fatalf("expected errors to be %v, got %v", scenario.expectedErrors, result.Errors)
+			}
+			for i := range result.Errors {
+				if result.Errors[i] != scenario.expectedErrors[i] {
+					t.Errorf("expected error #%d to be '%s', got '%s'", i, scenario.expectedErrors[i], result.Errors[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package grpc
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	// ErrEndpointWithoutGRPCMethod is the error with which Gatus will panic if an endpoint with gRPC monitoring is configured without a method.
+	ErrEndpointWithoutGRPCMethod = errors.New("you must specify a method for each gRPC endpoint")
+
+	// ErrEndpointWithInvalidGRPCMethod is the error with which Gatus will panic if an endpoint with gRPC monitoring is configured with a method
+	// that isn't in the package.Service/Method format.
+	ErrEndpointWithInvalidGRPCMethod = errors.New("invalid gRPC method: expected format is package.Service/Method")
+)
+
+type Config struct {
+	// Method is the fully-qualified name of the unary method to invoke, e.g. grpc.health.v1.Health/Check
+	Method string `yaml:"method,omitempty"`
+
+	// DescriptorSet is the path to a file containing a serialized FileDescriptorSet describing the service.
+	//
+	// If empty, the service is resolved using server reflection.
+	DescriptorSet string `yaml:"descriptor-set,omitempty"`
+}
+
+// Validate the gRPC configuration
+func (cfg *Config) Validate() error {
+	if len(cfg.Method) == 0 {
+		return ErrEndpointWithoutGRPCMethod
+	}
+	cfg.Method = strings.TrimPrefix(cfg.Method, "/")
+	if service, method, found := strings.Cut(cfg.Method, "/"); !found || len(service) == 0 || len(method) == 0 || strings.Contains(method, "/") {
+		return ErrEndpointWithInvalidGRPCMethod
+	}
+	return nil
+}
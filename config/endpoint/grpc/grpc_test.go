@@ -0,0 +1,30 @@
+package grpc
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGRPC_validate(t *testing.T) {
+	cfg := &Config{}
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected an error")
+	} else if !errors.Is(err, ErrEndpointWithoutGRPCMethod) {
+		t.Errorf("expected error to be '%v', got '%v'", ErrEndpointWithoutGRPCMethod, err)
+	}
+	for _, method := range []string{"Check", "grpc.health.v1.Health/", "/Check", "grpc.health.v1/Health/Check"} {
+		cfg.Method = method
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("expected an error for method '%s'", method)
+		} else if !errors.Is(err, ErrEndpointWithInvalidGRPCMethod) {
+			t.Errorf("expected error to be '%v', got '%v'", ErrEndpointWithInvalidGRPCMethod, err)
+		}
+	}
+	cfg.Method = "/grpc.health.v1.Health/Check"
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("expected no error, got '%v'", err)
+	}
+	if cfg.Method != "grpc.health.v1.Health/Check" {
+		t.Errorf("expected leading slash to be trimmed, got '%s'", cfg.Method)
+	}
+}
@@ -0,0 +1,44 @@
+package icmp
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// DefaultCount is the number of pings sent per check when none is specified
+	DefaultCount = 1
+
+	// DefaultInterval is the duration between each ping when none is specified
+	DefaultInterval = time.Second
+
+	// MaximumCount is the maximum number of pings that can be sent per check
+	MaximumCount = 100
+)
+
+var (
+	// ErrEndpointWithInvalidICMPCount is the error with which Gatus will panic if an endpoint with ICMP monitoring is configured with an invalid count.
+	ErrEndpointWithInvalidICMPCount = errors.New("ICMP count must be between 1 and 100")
+)
+
+type Config struct {
+	// Count is the number of pings to send per check
+	Count int `yaml:"count,omitempty"`
+
+	// Interval is the duration to wait between each ping
+	Interval time.Duration `yaml:"interval,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the ICMP configuration and sets the default values if necessary
+func (cfg *Config) ValidateAndSetDefaults() error {
+	if cfg.Count == 0 {
+		cfg.Count = DefaultCount
+	}
+	if cfg.Count < 1 || cfg.Count > MaximumCount {
+		return ErrEndpointWithInvalidICMPCount
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultInterval
+	}
+	return nil
+}
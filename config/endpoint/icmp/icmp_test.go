@@ -0,0 +1,55 @@
+package icmp
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name             string
+		cfg              *Config
+		expectedErr      error
+		expectedCount    int
+		expectedInterval time.Duration
+	}{
+		{
+			name:             "defaults",
+			cfg:              &Config{},
+			expectedCount:    DefaultCount,
+			expectedInterval: DefaultInterval,
+		},
+		{
+			name:             "custom",
+			cfg:              &Config{Count: 5, Interval: 200 * time.Millisecond},
+			expectedCount:    5,
+			expectedInterval: 200 * time.Millisecond,
+		},
+		{
+			name:          "negative-count",
+			cfg:           &Config{Count: -1},
+			expectedErr:   ErrEndpointWithInvalidICMPCount,
+			expectedCount: -1,
+		},
+		{
+			name:          "count-too-high",
+			cfg:           &Config{Count: 1000},
+			expectedErr:   ErrEndpointWithInvalidICMPCount,
+			expectedCount: 1000,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.ValidateAndSetDefaults(); !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected error to be '%v', got '%v'", scenario.expectedErr, err)
+			}
+			if scenario.cfg.Count != scenario.expectedCount {
+				t.Errorf("expected count to be %d, got %d", scenario.expectedCount, scenario.cfg.Count)
+			}
+			if scenario.cfg.Interval != scenario.expectedInterval {
+				t.Errorf("expected interval to be %s, got %s", scenario.expectedInterval, scenario.cfg.Interval)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package kafka
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	// ErrEndpointWithoutKafkaTopic is the error with which Gatus will panic if an endpoint with Kafka monitoring is configured without a topic.
+	ErrEndpointWithoutKafkaTopic = errors.New("you must specify a topic for each Kafka endpoint")
+
+	// ErrEndpointWithInvalidKafkaSASLMechanism is the error with which Gatus will panic if an endpoint with Kafka monitoring is configured with an unsupported SASL mechanism.
+	ErrEndpointWithInvalidKafkaSASLMechanism = errors.New("invalid Kafka sasl-mechanism: must be one of PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512")
+
+	// ErrEndpointWithoutKafkaUsername is the error with which Gatus will panic if an endpoint with Kafka monitoring is configured with a SASL mechanism but without a username.
+	ErrEndpointWithoutKafkaUsername = errors.New("you must specify a username for each Kafka endpoint with a sasl-mechanism")
+)
+
+type Config struct {
+	// Topic is the topic whose metadata to retrieve or, if RoundTrip is true, the canary topic to produce to and consume from
+	Topic string `yaml:"topic"`
+
+	// Partition is the partition used for the produce and consume round trip
+	Partition int `yaml:"partition,omitempty"`
+
+	// RoundTrip is whether to produce a message to the topic and consume it back instead of only retrieving the topic's metadata
+	RoundTrip bool `yaml:"round-trip,omitempty"`
+
+	// SASLMechanism is the SASL mechanism used to authenticate with the brokers (PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512)
+	//
+	// If empty, no SASL authentication is performed.
+	SASLMechanism string `yaml:"sasl-mechanism,omitempty"`
+
+	Username string `yaml:"username,omitempty"`
+	Password string `yaml:"password,omitempty"`
+}
+
+// Validate the Kafka configuration
+func (cfg *Config) Validate() error {
+	if len(cfg.Topic) == 0 {
+		return ErrEndpointWithoutKafkaTopic
+	}
+	if len(cfg.SASLMechanism) > 0 {
+		cfg.SASLMechanism = strings.ToUpper(cfg.SASLMechanism)
+		if cfg.SASLMechanism != "PLAIN" && cfg.SASLMechanism != "SCRAM-SHA-256" && cfg.SASLMechanism != "SCRAM-SHA-512" {
+			return ErrEndpointWithInvalidKafkaSASLMechanism
+		}
+		if len(cfg.Username) == 0 {
+			return ErrEndpointWithoutKafkaUsername
+		}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package kafka
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestKafka_validate(t *testing.T) {
+	cfg := &Config{}
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected an error")
+	} else if !errors.Is(err, ErrEndpointWithoutKafkaTopic) {
+		t.Errorf("expected error to be '%v', got '%v'", ErrEndpointWithoutKafkaTopic, err)
+	}
+	cfg.Topic = "gatus-canary"
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("expected no error, got '%v'", err)
+	}
+	cfg.SASLMechanism = "GSSAPI"
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected an error")
+	} else if !errors.Is(err, ErrEndpointWithInvalidKafkaSASLMechanism) {
+		t.Errorf("expected error to be '%v', got '%v'", ErrEndpointWithInvalidKafkaSASLMechanism, err)
+	}
+	cfg.SASLMechanism = "scram-sha-512"
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected an error")
+	} else if !errors.Is(err, ErrEndpointWithoutKafkaUsername) {
+		t.Errorf("expected error to be '%v', got '%v'", ErrEndpointWithoutKafkaUsername, err)
+	}
+	cfg.Username = "username"
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("expected no error, got '%v'", err)
+	}
+	if cfg.SASLMechanism != "SCRAM-SHA-512" {
+		t.Errorf("expected SASL mechanism to be normalized to uppercase, got '%s'", cfg.SASLMechanism)
+	}
+}
@@ -0,0 +1,54 @@
+package ldap
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// DefaultFilter is the filter used for the base search when none is specified
+const DefaultFilter = "(objectClass=*)"
+
+var (
+	// ErrEndpointWithLDAPPasswordWithoutBindDN is the error with which Gatus will panic if an endpoint with LDAP monitoring is configured with a password but without a bind DN.
+	ErrEndpointWithLDAPPasswordWithoutBindDN = errors.New("you must specify the bind-dn of the LDAP endpoint's password")
+
+	// ErrEndpointWithLDAPBindDNWithoutPassword is the error with which Gatus will panic if an endpoint with LDAP monitoring is configured with a bind DN but without a password.
+	ErrEndpointWithLDAPBindDNWithoutPassword = errors.New("you must specify a password for LDAP endpoints with a bind-dn")
+
+	// ErrEndpointWithInvalidLDAPFilter is the error with which Gatus will panic if an endpoint with LDAP monitoring is configured with an invalid filter.
+	ErrEndpointWithInvalidLDAPFilter = errors.New("invalid LDAP filter")
+)
+
+type Config struct {
+	// BindDN is the DN to perform a simple bind with. If empty, an anonymous bind is performed.
+	BindDN   string `yaml:"bind-dn,omitempty"`
+	Password string `yaml:"password,omitempty"`
+
+	// StartTLS is whether to upgrade the connection to TLS using the StartTLS extended operation
+	StartTLS bool `yaml:"start-tls,omitempty"`
+
+	// BaseDN is the DN to search from after binding. If empty, no search is performed.
+	BaseDN string `yaml:"base-dn,omitempty"`
+
+	// Filter is the filter of the base search
+	Filter string `yaml:"filter,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the LDAP configuration and sets the default values if necessary
+func (cfg *Config) ValidateAndSetDefaults() error {
+	if len(cfg.BindDN) == 0 && len(cfg.Password) > 0 {
+		return ErrEndpointWithLDAPPasswordWithoutBindDN
+	}
+	if len(cfg.BindDN) > 0 && len(cfg.Password) == 0 {
+		return ErrEndpointWithLDAPBindDNWithoutPassword
+	}
+	if len(cfg.Filter) == 0 {
+		cfg.Filter = DefaultFilter
+	}
+	if _, err := ldap.CompileFilter(cfg.Filter); err != nil {
+		return fmt.Errorf("%w: %v", ErrEndpointWithInvalidLDAPFilter, err)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package ldap
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		cfg         *Config
+		expectedErr error
+	}{
+		{
+			name:        "anonymous-bind",
+			cfg:         &Config{},
+			expectedErr: nil,
+		},
+		{
+			name:        "simple-bind-with-search",
+			cfg:         &Config{BindDN: "cn=gatus,dc=example,dc=org", Password: "password", BaseDN: "dc=example,dc=org", Filter: "(uid=john)"},
+			expectedErr: nil,
+		},
+		{
+			name:        "password-without-bind-dn",
+			cfg:         &Config{Password: "password"},
+			expectedErr: ErrEndpointWithLDAPPasswordWithoutBindDN,
+		},
+		{
+			name:        "bind-dn-without-password",
+			cfg:         &Config{BindDN: "cn=gatus,dc=example,dc=org"},
+			expectedErr: ErrEndpointWithLDAPBindDNWithoutPassword,
+		},
+		{
+			name:        "invalid-filter",
+			cfg:         &Config{BaseDN: "dc=example,dc=org", Filter: "(uid=john"},
+			expectedErr: ErrEndpointWithInvalidLDAPFilter,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.ValidateAndSetDefaults(); !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected error to be '%v', got '%v'", scenario.expectedErr, err)
+			}
+			if scenario.expectedErr == nil && len(scenario.cfg.Filter) == 0 {
+				t.Error("expected filter to have been set to its default value")
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package mailbox
+
+import (
+	"errors"
+)
+
+var (
+	// ErrEndpointWithMailboxPasswordWithoutUsername is the error with which Gatus will panic if an endpoint with IMAP or POP3 monitoring is configured with a password but without a username.
+	ErrEndpointWithMailboxPasswordWithoutUsername = errors.New("you must specify the username of the mailbox endpoint's password")
+
+	// ErrEndpointWithMailboxUsernameWithoutPassword is the error with which Gatus will panic if an endpoint with IMAP or POP3 monitoring is configured with a username but without a password.
+	ErrEndpointWithMailboxUsernameWithoutPassword = errors.New("you must specify a password for mailbox endpoints with a username")
+)
+
+// Config is the configuration of IMAP and POP3 endpoints
+type Config struct {
+	// StartTLS is whether to upgrade the connection to TLS after the greeting, using STARTTLS for IMAP and STLS for POP3
+	StartTLS bool `yaml:"start-tls,omitempty"`
+
+	// Username is the username to log in with. If empty, no login is performed.
+	Username string `yaml:"username,omitempty"`
+	Password string `yaml:"password,omitempty"`
+}
+
+// Validate validates the mailbox configuration
+func (cfg *Config) Validate() error {
+	if len(cfg.Username) == 0 && len(cfg.Password) > 0 {
+		return ErrEndpointWithMailboxPasswordWithoutUsername
+	}
+	if len(cfg.Username) > 0 && len(cfg.Password) == 0 {
+		return ErrEndpointWithMailboxUsernameWithoutPassword
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package mailbox
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		cfg         *Config
+		expectedErr error
+	}{
+		{
+			name:        "greeting-only",
+			cfg:         &Config{},
+			expectedErr: nil,
+		},
+		{
+			name:        "starttls-with-login",
+			cfg:         &Config{StartTLS: true, Username: "gatus", Password: "password"},
+			expectedErr: nil,
+		},
+		{
+			name:        "password-without-username",
+			cfg:         &Config{Password: "password"},
+			expectedErr: ErrEndpointWithMailboxPasswordWithoutUsername,
+		},
+		{
+			name:        "username-without-password",
+			cfg:         &Config{Username: "gatus"},
+			expectedErr: ErrEndpointWithMailboxUsernameWithoutPassword,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.Validate(); !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected error to be '%v', got '%v'", scenario.expectedErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package mqtt
+
+import (
+	"errors"
+)
+
+var (
+	// ErrEndpointWithInvalidMQTTQoS is the error with which Gatus will panic if an endpoint with MQTT monitoring is configured with an invalid QoS.
+	ErrEndpointWithInvalidMQTTQoS = errors.New("invalid MQTT qos: must be 0, 1 or 2")
+)
+
+type Config struct {
+	// SubscribeTopic is the topic to wait for a message on. If empty, no message is waited for.
+	SubscribeTopic string `yaml:"subscribe-topic,omitempty"`
+
+	// PublishTopic is the topic to publish the endpoint's body to. If empty, nothing is published.
+	PublishTopic string `yaml:"publish-topic,omitempty"`
+
+	// QoS is the quality of service level used to subscribe and publish
+	QoS byte `yaml:"qos,omitempty"`
+
+	Username string `yaml:"username,omitempty"`
+	Password string `yaml:"password,omitempty"`
+}
+
+// Validate the MQTT configuration
+func (cfg *Config) Validate() error {
+	if cfg.QoS > 2 {
+		return ErrEndpointWithInvalidMQTTQoS
+	}
+	return nil
+}
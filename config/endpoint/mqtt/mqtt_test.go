@@ -0,0 +1,19 @@
+package mqtt
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMQTT_validate(t *testing.T) {
+	cfg := &Config{QoS: 3}
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected an error")
+	} else if !errors.Is(err, ErrEndpointWithInvalidMQTTQoS) {
+		t.Errorf("expected error to be '%v', got '%v'", ErrEndpointWithInvalidMQTTQoS, err)
+	}
+	cfg.QoS = 2
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("expected no error, got '%v'", err)
+	}
+}
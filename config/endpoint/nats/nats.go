@@ -0,0 +1,54 @@
+package nats
+
+import (
+	"errors"
+)
+
+const (
+	// ModeRequest sends the endpoint's body as a request on the subject and waits for a reply
+	ModeRequest = "request"
+
+	// ModePublishSubscribe subscribes to the subject, publishes the endpoint's body on it and waits for the message
+	ModePublishSubscribe = "publish-subscribe"
+)
+
+var (
+	// ErrEndpointWithInvalidNATSMode is the error with which Gatus will panic if an endpoint with NATS monitoring is configured with an invalid mode.
+	ErrEndpointWithInvalidNATSMode = errors.New("invalid NATS mode: must be either " + ModeRequest + " or " + ModePublishSubscribe)
+
+	// ErrEndpointWithBothNATSSubjectAndStream is the error with which Gatus will panic if an endpoint with NATS monitoring is configured with both a subject and a stream.
+	ErrEndpointWithBothNATSSubjectAndStream = errors.New("a NATS endpoint may not have both a subject and a stream")
+
+	// ErrEndpointWithNATSConsumerWithoutStream is the error with which Gatus will panic if an endpoint with NATS monitoring is configured with a consumer but without a stream.
+	ErrEndpointWithNATSConsumerWithoutStream = errors.New("you must specify the stream of the NATS consumer")
+)
+
+type Config struct {
+	// Subject is the subject on which to perform the round trip. If empty, no round trip is performed.
+	Subject string `yaml:"subject,omitempty"`
+
+	// Mode is the type of round trip to perform on the subject, either request (default) or publish-subscribe
+	Mode string `yaml:"mode,omitempty"`
+
+	// Stream is the name of the JetStream stream whose state to retrieve
+	Stream string `yaml:"stream,omitempty"`
+
+	// Consumer is the name of the JetStream consumer of the Stream whose state to retrieve
+	Consumer string `yaml:"consumer,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the NATS configuration and sets the default values if necessary
+func (cfg *Config) ValidateAndSetDefaults() error {
+	if len(cfg.Mode) == 0 {
+		cfg.Mode = ModeRequest
+	} else if cfg.Mode != ModeRequest && cfg.Mode != ModePublishSubscribe {
+		return ErrEndpointWithInvalidNATSMode
+	}
+	if len(cfg.Subject) > 0 && len(cfg.Stream) > 0 {
+		return ErrEndpointWithBothNATSSubjectAndStream
+	}
+	if len(cfg.Consumer) > 0 && len(cfg.Stream) == 0 {
+		return ErrEndpointWithNATSConsumerWithoutStream
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package nats
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		cfg         *Config
+		expectedErr error
+	}{
+		{
+			name:        "connect-only",
+			cfg:         &Config{},
+			expectedErr: nil,
+		},
+		{
+			name:        "subject",
+			cfg:         &Config{Subject: "health", Mode: ModePublishSubscribe},
+			expectedErr: nil,
+		},
+		{
+			name:        "stream-and-consumer",
+			cfg:         &Config{Stream: "ORDERS", Consumer: "processor"},
+			expectedErr: nil,
+		},
+		{
+			name:        "invalid-mode",
+			cfg:         &Config{Subject: "health", Mode: "invalid"},
+			expectedErr: ErrEndpointWithInvalidNATSMode,
+		},
+		{
+			name:        "subject-and-stream",
+			cfg:         &Config{Subject: "health", Stream: "ORDERS"},
+			expectedErr: ErrEndpointWithBothNATSSubjectAndStream,
+		},
+		{
+			name:        "consumer-without-stream",
+			cfg:         &Config{Consumer: "processor"},
+			expectedErr: ErrEndpointWithNATSConsumerWithoutStream,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.ValidateAndSetDefaults(); !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected error to be '%v', got '%v'", scenario.expectedErr, err)
+			}
+			if scenario.expectedErr == nil && len(scenario.cfg.Mode) == 0 {
+				t.Error("expected mode to have been set to its default value")
+			}
+		})
+	}
+}
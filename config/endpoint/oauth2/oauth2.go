@@ -0,0 +1,53 @@
+package oauth2
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	// ErrEndpointWithInvalidOAuth2TokenURL is the error with which Gatus will panic if an endpoint with OAuth2 monitoring has a URL that isn't an HTTP(S) URL.
+	ErrEndpointWithInvalidOAuth2TokenURL = errors.New("url of an endpoint with oauth2 must be the http:// or https:// url of the token endpoint")
+
+	// ErrEndpointWithNoOAuth2ClientCredentials is the error with which Gatus will panic if an endpoint with OAuth2 monitoring is configured without a client-id or a client-secret.
+	ErrEndpointWithNoOAuth2ClientCredentials = errors.New("oauth2 client-id and client-secret are required")
+
+	// ErrEndpointWithNoOAuth2IssuerOrJWKSURL is the error with which Gatus will panic if an endpoint with OAuth2 monitoring is configured with neither an issuer nor a jwks-url, making it impossible to verify the signature of the token.
+	ErrEndpointWithNoOAuth2IssuerOrJWKSURL = errors.New("oauth2 requires an issuer or a jwks-url to verify the signature of the token")
+)
+
+type Config struct {
+	// ClientID of the client credentials
+	ClientID string `yaml:"client-id"`
+
+	// ClientSecret of the client credentials
+	ClientSecret string `yaml:"client-secret"`
+
+	// Scopes to request
+	Scopes []string `yaml:"scopes,omitempty"`
+
+	// Audience is sent as the audience parameter of the token request, and must be part of the aud claim of the token
+	// if specified
+	Audience string `yaml:"audience,omitempty"`
+
+	// Issuer of the token, which must match the iss claim of the token. Unless JWKSURL is specified, the key set used
+	// to verify the signature of the token is retrieved through the OpenID Connect discovery document of the issuer.
+	Issuer string `yaml:"issuer,omitempty"`
+
+	// JWKSURL is the URL of the key set used to verify the signature of the token
+	JWKSURL string `yaml:"jwks-url,omitempty"`
+}
+
+// Validate validates the OAuth2 configuration along with the URL of the endpoint, which is the URL of the token endpoint
+func (cfg *Config) Validate(tokenURL string) error {
+	if !strings.HasPrefix(tokenURL, "http://") && !strings.HasPrefix(tokenURL, "https://") {
+		return ErrEndpointWithInvalidOAuth2TokenURL
+	}
+	if len(cfg.ClientID) == 0 || len(cfg.ClientSecret) == 0 {
+		return ErrEndpointWithNoOAuth2ClientCredentials
+	}
+	if len(cfg.Issuer) == 0 && len(cfg.JWKSURL) == 0 {
+		return ErrEndpointWithNoOAuth2IssuerOrJWKSURL
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package oauth2
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		url         string
+		cfg         *Config
+		expectedErr error
+	}{
+		{
+			name: "issuer",
+			url:  "https://idp.example.org/oauth2/token",
+			cfg:  &Config{ClientID: "gatus", ClientSecret: "secret", Issuer: "https://idp.example.org"},
+		},
+		{
+			name: "jwks-url",
+			url:  "https://idp.example.org/oauth2/token",
+			cfg:  &Config{ClientID: "gatus", ClientSecret: "secret", JWKSURL: "https://idp.example.org/.well-known/jwks.json"},
+		},
+		{
+			name:        "non-http-url",
+			url:         "tcp://idp.example.org:443",
+			cfg:         &Config{ClientID: "gatus", ClientSecret: "secret", Issuer: "https://idp.example.org"},
+			expectedErr: ErrEndpointWithInvalidOAuth2TokenURL,
+		},
+		{
+			name:        "no-client-secret",
+			url:         "https://idp.example.org/oauth2/token",
+			cfg:         &Config{ClientID: "gatus", Issuer: "https://idp.example.org"},
+			expectedErr: ErrEndpointWithNoOAuth2ClientCredentials,
+		},
+		{
+			name:        "no-issuer-or-jwks-url",
+			url:         "https://idp.example.org/oauth2/token",
+			cfg:         &Config{ClientID: "gatus", ClientSecret: "secret"},
+			expectedErr: ErrEndpointWithNoOAuth2IssuerOrJWKSURL,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.Validate(scenario.url); !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected error to be '%v', got '%v'", scenario.expectedErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package redis
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/kballard/go-shellquote"
+)
+
+var (
+	// ErrEndpointWithInvalidRedisCommand is the error with which Gatus will panic if an endpoint with Redis monitoring is configured with a command that cannot be parsed.
+	ErrEndpointWithInvalidRedisCommand = errors.New("invalid Redis command: arguments must be separated by spaces and quotes must be closed")
+)
+
+type Config struct {
+	// Command is the command to execute, e.g. GET my-key. Defaults to PING.
+	Command string `yaml:"command,omitempty"`
+
+	// SentinelMaster is the name of the master to resolve through the Sentinel(s) specified in the endpoint's URL.
+	//
+	// If empty, the URL is expected to point directly to the Redis server.
+	SentinelMaster string `yaml:"sentinel-master,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the Redis configuration and sets the default values if necessary
+func (cfg *Config) ValidateAndSetDefaults() error {
+	if len(strings.TrimSpace(cfg.Command)) == 0 {
+		cfg.Command = "PING"
+	}
+	if args, err := shellquote.Split(cfg.Command); err != nil || len(args) == 0 {
+		return ErrEndpointWithInvalidRedisCommand
+	}
+	return nil
+}
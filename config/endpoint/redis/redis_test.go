@@ -0,0 +1,24 @@
+package redis
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	cfg := &Config{}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Errorf("expected no error, got '%v'", err)
+	}
+	if cfg.Command != "PING" {
+		t.Errorf("expected command to default to PING, got '%s'", cfg.Command)
+	}
+	cfg.Command = `GET "unclosed`
+	if err := cfg.ValidateAndSetDefaults(); !errors.Is(err, ErrEndpointWithInvalidRedisCommand) {
+		t.Errorf("expected error to be '%v', got '%v'", ErrEndpointWithInvalidRedisCommand, err)
+	}
+	cfg.Command = `SET "my key" "my value"`
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Errorf("expected no error, got '%v'", err)
+	}
+}
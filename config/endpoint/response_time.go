@@ -0,0 +1,15 @@
+package endpoint
+
+import (
+	"time"
+)
+
+// ResponseTime is how long a check of an endpoint took, along with when it was performed, which is all that is needed
+// to chart the response time of an endpoint without retrieving its entire results
+type ResponseTime struct {
+	// Timestamp is when the check was performed
+	Timestamp time.Time `json:"timestamp"`
+
+	// Duration is how long the check took
+	Duration time.Duration `json:"duration"`
+}
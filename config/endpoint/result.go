@@ -1,6 +1,8 @@
 package endpoint
 
 import (
+	"crypto/x509"
+	"net/http"
 	"time"
 )
 
@@ -38,17 +40,76 @@ type Result struct {
 	// Timestamp when the request was sent
 	Timestamp time.Time `json:"timestamp"`
 
+	// Metadata is arbitrary information attached to the result, such as the version of the job whose result was pushed
+	// to an external endpoint
+	Metadata map[string]string `json:"metadata,omitempty"`
+
 	// CertificateExpiration is the duration before the certificate expires
 	CertificateExpiration time.Duration `json:"-"`
 
 	// DomainExpiration is the duration before the domain expires
 	DomainExpiration time.Duration `json:"-"`
 
+	// ContentType is the value of the Content-Type header of the response
+	ContentType string `json:"-"`
+
+	// RedirectCount is the number of redirects that were followed before the final response
+	RedirectCount int `json:"-"`
+
+	// FinalURL is the URL of the final response, after all redirects were followed
+	FinalURL string `json:"-"`
+
+	// Protocol is the protocol of the response (e.g. HTTP/1.1, HTTP/2.0, HTTP/3.0)
+	Protocol string `json:"-"`
+
+	// PacketLoss is the percentage of pings that didn't receive a reply
+	PacketLoss float64 `json:"-"`
+
+	// MinRTT is the minimum round-trip time of the pings
+	MinRTT time.Duration `json:"-"`
+
+	// AvgRTT is the average round-trip time of the pings
+	AvgRTT time.Duration `json:"-"`
+
+	// MaxRTT is the maximum round-trip time of the pings
+	MaxRTT time.Duration `json:"-"`
+
+	// HopCount is the number of hops to the destination of a traceroute
+	HopCount int `json:"-"`
+
+	// ExitCode is the exit code of the command executed on SSH endpoints
+	ExitCode int `json:"-"`
+
+	// NTPOffset is the offset of the local clock relative to the clock of the NTP server
+	NTPOffset time.Duration `json:"-"`
+
+	// NTPStratum is the stratum of the NTP server
+	NTPStratum int `json:"-"`
+
+	// ObjectSize is the size of the object of an S3 endpoint, or of the file of a file endpoint, in bytes
+	ObjectSize int64 `json:"-"`
+
+	// ObjectAge is the duration since the object of an S3 endpoint, or the file of a file endpoint, was last modified
+	ObjectAge time.Duration `json:"-"`
+
+	// DiskFreePercent is the percentage of the space of the filesystem of a disk endpoint that is available
+	DiskFreePercent int `json:"-"`
+
 	// Body is the response body
 	//
 	// Note that this field is not persisted in the storage.
 	// It is used for health evaluation as well as debugging purposes.
 	Body []byte `json:"-"`
+
+	// FailureCapture is what the endpoint returned, if the check failed and the endpoint captures failures
+	FailureCapture *FailureCapture `json:"-"`
+
+	// certificateChain is the chain of certificates presented by the server, starting with the certificate of the server.
+	// It is only used to check whether the certificate has been revoked.
+	certificateChain []*x509.Certificate
+
+	// responseHeader is the header of the HTTP response, which is only used to capture failures
+	responseHeader http.Header
 }
 
 // AddError adds an error to the result's list of errors.
@@ -62,3 +123,29 @@ func (r *Result) AddError(error string) {
 	}
 	r.Errors = append(r.Errors, error)
 }
+
+// populateFromHTTPResponse populates the result using everything but the body of the HTTP response
+func (r *Result) populateFromHTTPResponse(response *http.Response) {
+	if response.TLS != nil && len(response.TLS.PeerCertificates) > 0 {
+		r.CertificateExpiration = time.Until(response.TLS.PeerCertificates[0].NotAfter)
+		// Unlike the peer certificates, the verified chain always includes the issuer, but it's empty if the
+		// verification was skipped
+		if len(response.TLS.VerifiedChains) > 0 {
+			r.certificateChain = response.TLS.VerifiedChains[0]
+		} else {
+			r.certificateChain = response.TLS.PeerCertificates
+		}
+	}
+	r.HTTPStatus = response.StatusCode
+	r.Connected = response.StatusCode > 0
+	r.ContentType = response.Header.Get(ContentTypeHeader)
+	r.responseHeader = response.Header
+	r.Protocol = response.Proto
+	if response.Request != nil {
+		r.FinalURL = response.Request.URL.String()
+		// Each request created by following a redirect references the response that caused it
+		for req := response.Request; req.Response != nil && req.Response.Request != nil; req = req.Response.Request {
+			r.RedirectCount++
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package s3
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+)
+
+const (
+	// DefaultRegion is the region used when none is specified
+	DefaultRegion = "us-east-1"
+)
+
+var (
+	// ErrEndpointWithInvalidS3URL is the error with which Gatus will panic if an endpoint of type S3 has a URL that doesn't include both a bucket and a key.
+	ErrEndpointWithInvalidS3URL = errors.New("invalid url for s3, format must be s3://bucket/key")
+
+	// ErrEndpointWithInvalidS3Endpoint is the error with which Gatus will panic if an endpoint of type S3 is configured with an endpoint that isn't an HTTP(S) URL.
+	ErrEndpointWithInvalidS3Endpoint = errors.New("s3 endpoint must be an url starting with http:// or https://")
+
+	// ErrEndpointWithPartialS3Credentials is the error with which Gatus will panic if an endpoint of type S3 is configured with only one of access-key-id and secret-access-key.
+	ErrEndpointWithPartialS3Credentials = errors.New("s3 access-key-id and secret-access-key must be specified together")
+)
+
+type Config struct {
+	// Region of the bucket
+	Region string `yaml:"region,omitempty"`
+
+	// Endpoint is the URL of an S3-compatible object storage (e.g. MinIO or GCS). Defaults to the endpoint of AWS S3 for
+	// the region.
+	Endpoint string `yaml:"endpoint,omitempty"`
+
+	// PathStyle is whether to address the bucket through the path (https://endpoint/bucket/key) instead of the
+	// host (https://bucket.endpoint/key)
+	PathStyle bool `yaml:"path-style,omitempty"`
+
+	// AccessKeyID used to sign the request. If empty, credentials are retrieved from the environment, and the request is
+	// sent anonymously if there are none.
+	AccessKeyID string `yaml:"access-key-id,omitempty"`
+
+	// SecretAccessKey used to sign the request
+	SecretAccessKey string `yaml:"secret-access-key,omitempty"`
+
+	bucket      string
+	key         string
+	credentials *credentials.Credentials
+}
+
+// ValidateAndSetDefaults validates the S3 configuration along with the URL of the endpoint, which has the format
+// s3://bucket/key, and sets the default values if necessary
+func (cfg *Config) ValidateAndSetDefaults(endpointURL string) error {
+	parsedURL, err := url.Parse(endpointURL)
+	if err != nil || len(parsedURL.Host) == 0 || len(strings.TrimPrefix(parsedURL.Path, "/")) == 0 {
+		return ErrEndpointWithInvalidS3URL
+	}
+	cfg.bucket, cfg.key = parsedURL.Host, strings.TrimPrefix(parsedURL.Path, "/")
+	if len(cfg.Region) == 0 {
+		cfg.Region = DefaultRegion
+	}
+	if len(cfg.Endpoint) == 0 {
+		cfg.Endpoint = "https://s3." + cfg.Region + ".amazonaws.com"
+	} else if !strings.HasPrefix(cfg.Endpoint, "http://") && !strings.HasPrefix(cfg.Endpoint, "https://") {
+		return ErrEndpointWithInvalidS3Endpoint
+	}
+	if _, err := url.Parse(cfg.Endpoint); err != nil {
+		return ErrEndpointWithInvalidS3Endpoint
+	}
+	if (len(cfg.AccessKeyID) == 0) != (len(cfg.SecretAccessKey) == 0) {
+		return ErrEndpointWithPartialS3Credentials
+	}
+	if len(cfg.AccessKeyID) > 0 {
+		cfg.credentials = credentials.NewStaticCredentials(cfg.AccessKeyID, cfg.SecretAccessKey, "")
+	} else {
+		sess, err := session.NewSession(&aws.Config{Region: aws.String(cfg.Region)})
+		if err != nil {
+			return err
+		}
+		cfg.credentials = sess.Config.Credentials
+	}
+	return nil
+}
+
+// ObjectURL returns the URL of the object, using either path-style or virtual-hosted-style addressing
+func (cfg *Config) ObjectURL() string {
+	objectURL, _ := url.Parse(cfg.Endpoint)
+	if cfg.PathStyle {
+		objectURL.Path = strings.TrimSuffix(objectURL.Path, "/") + "/" + cfg.bucket + "/" + cfg.key
+	} else {
+		objectURL.Host = cfg.bucket + "." + objectURL.Host
+		objectURL.Path = strings.TrimSuffix(objectURL.Path, "/") + "/" + cfg.key
+	}
+	return objectURL.String()
+}
+
+// Credentials returns the credentials used to sign the request
+func (cfg *Config) Credentials() *credentials.Credentials {
+	return cfg.credentials
+}
@@ -0,0 +1,82 @@
+package s3
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name              string
+		url               string
+		cfg               *Config
+		expectedErr       error
+		expectedObjectURL string
+	}{
+		{
+			name:              "defaults",
+			url:               "s3://backups/db/latest.sql.gz",
+			cfg:               &Config{AccessKeyID: "AKID", SecretAccessKey: "secret"},
+			expectedObjectURL: "https://backups.s3.us-east-1.amazonaws.com/db/latest.sql.gz",
+		},
+		{
+			name:              "region",
+			url:               "s3://backups/db/latest.sql.gz",
+			cfg:               &Config{Region: "eu-west-1", AccessKeyID: "AKID", SecretAccessKey: "secret"},
+			expectedObjectURL: "https://backups.s3.eu-west-1.amazonaws.com/db/latest.sql.gz",
+		},
+		{
+			name:              "custom-endpoint-with-path-style",
+			url:               "s3://backups/db/latest.sql.gz",
+			cfg:               &Config{Endpoint: "http://minio.example.org:9000", PathStyle: true, AccessKeyID: "AKID", SecretAccessKey: "secret"},
+			expectedObjectURL: "http://minio.example.org:9000/backups/db/latest.sql.gz",
+		},
+		{
+			name:              "key-with-space",
+			url:               "s3://backups/db/my backup.sql.gz",
+			cfg:               &Config{Endpoint: "https://storage.googleapis.com", PathStyle: true, AccessKeyID: "AKID", SecretAccessKey: "secret"},
+			expectedObjectURL: "https://storage.googleapis.com/backups/db/my%20backup.sql.gz",
+		},
+		{
+			name:        "url-without-key",
+			url:         "s3://backups",
+			cfg:         &Config{},
+			expectedErr: ErrEndpointWithInvalidS3URL,
+		},
+		{
+			name:        "url-without-bucket",
+			url:         "s3:///db/latest.sql.gz",
+			cfg:         &Config{},
+			expectedErr: ErrEndpointWithInvalidS3URL,
+		},
+		{
+			name:        "invalid-endpoint",
+			url:         "s3://backups/db/latest.sql.gz",
+			cfg:         &Config{Endpoint: "minio.example.org:9000"},
+			expectedErr: ErrEndpointWithInvalidS3Endpoint,
+		},
+		{
+			name:        "access-key-id-without-secret-access-key",
+			url:         "s3://backups/db/latest.sql.gz",
+			cfg:         &Config{AccessKeyID: "AKID"},
+			expectedErr: ErrEndpointWithPartialS3Credentials,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			err := scenario.cfg.ValidateAndSetDefaults(scenario.url)
+			if !errors.Is(err, scenario.expectedErr) {
+				t.Fatalf("expected error to be '%v', got '%v'", scenario.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if objectURL := scenario.cfg.ObjectURL(); objectURL != scenario.expectedObjectURL {
+				t.Errorf("expected object url to be %s, got %s", scenario.expectedObjectURL, objectURL)
+			}
+			if scenario.cfg.Credentials() == nil {
+				t.Error("expected credentials to be set")
+			}
+		})
+	}
+}
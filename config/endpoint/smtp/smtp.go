@@ -0,0 +1,33 @@
+package smtp
+
+import (
+	"errors"
+)
+
+var (
+	// ErrEndpointWithSMTPPasswordWithoutUsername is the error with which Gatus will panic if an endpoint with SMTP monitoring is configured with a password but without a username.
+	ErrEndpointWithSMTPPasswordWithoutUsername = errors.New("you must specify the username of the SMTP endpoint's password")
+
+	// ErrEndpointWithSMTPUsernameWithoutPassword is the error with which Gatus will panic if an endpoint with SMTP monitoring is configured with a username but without a password.
+	ErrEndpointWithSMTPUsernameWithoutPassword = errors.New("you must specify a password for SMTP endpoints with a username")
+)
+
+type Config struct {
+	// StartTLS is whether to upgrade the connection to TLS using the STARTTLS command after the EHLO
+	StartTLS bool `yaml:"start-tls,omitempty"`
+
+	// Username is the username to verify using AUTH PLAIN. If empty, no authentication is performed.
+	Username string `yaml:"username,omitempty"`
+	Password string `yaml:"password,omitempty"`
+}
+
+// Validate validates the SMTP configuration
+func (cfg *Config) Validate() error {
+	if len(cfg.Username) == 0 && len(cfg.Password) > 0 {
+		return ErrEndpointWithSMTPPasswordWithoutUsername
+	}
+	if len(cfg.Username) > 0 && len(cfg.Password) == 0 {
+		return ErrEndpointWithSMTPUsernameWithoutPassword
+	}
+	return nil
+}
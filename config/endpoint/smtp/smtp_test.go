@@ -0,0 +1,42 @@
+package smtp
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		cfg         *Config
+		expectedErr error
+	}{
+		{
+			name:        "handshake-only",
+			cfg:         &Config{},
+			expectedErr: nil,
+		},
+		{
+			name:        "starttls-with-auth",
+			cfg:         &Config{StartTLS: true, Username: "gatus@example.org", Password: "password"},
+			expectedErr: nil,
+		},
+		{
+			name:        "password-without-username",
+			cfg:         &Config{Password: "password"},
+			expectedErr: ErrEndpointWithSMTPPasswordWithoutUsername,
+		},
+		{
+			name:        "username-without-password",
+			cfg:         &Config{Username: "gatus@example.org"},
+			expectedErr: ErrEndpointWithSMTPUsernameWithoutPassword,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.Validate(); !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected error to be '%v', got '%v'", scenario.expectedErr, err)
+			}
+		})
+	}
+}
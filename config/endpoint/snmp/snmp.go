@@ -0,0 +1,101 @@
+package snmp
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// DefaultVersion is the SNMP version used when none is specified
+	DefaultVersion = "2c"
+
+	// DefaultCommunity is the community used for SNMPv2c when none is specified
+	DefaultCommunity = "public"
+)
+
+var (
+	// ErrEndpointWithoutSNMPOIDs is the error with which Gatus will panic if an endpoint with SNMP monitoring is configured without OIDs.
+	ErrEndpointWithoutSNMPOIDs = errors.New("you must specify at least one OID for each SNMP endpoint")
+
+	// ErrEndpointWithInvalidSNMPVersion is the error with which Gatus will panic if an endpoint with SNMP monitoring is configured with an invalid version.
+	ErrEndpointWithInvalidSNMPVersion = errors.New("invalid SNMP version, must be one of: 2c, 3")
+
+	// ErrEndpointWithoutSNMPUsername is the error with which Gatus will panic if an endpoint with SNMPv3 monitoring is configured without a username.
+	ErrEndpointWithoutSNMPUsername = errors.New("you must specify a username for each SNMPv3 endpoint")
+
+	// ErrEndpointWithInvalidSNMPAuthProtocol is the error with which Gatus will panic if an endpoint with SNMPv3 monitoring is configured with an invalid authentication protocol or without its password.
+	ErrEndpointWithInvalidSNMPAuthProtocol = errors.New("invalid SNMPv3 authentication protocol, must be one of: MD5, SHA, SHA224, SHA256, SHA384, SHA512, and the auth-password must be specified")
+
+	// ErrEndpointWithInvalidSNMPPrivacyProtocol is the error with which Gatus will panic if an endpoint with SNMPv3 monitoring is configured with an invalid privacy protocol, without its password or without authentication.
+	ErrEndpointWithInvalidSNMPPrivacyProtocol = errors.New("invalid SNMPv3 privacy protocol, must be one of: DES, AES, AES192, AES256, AES192C, AES256C, and both the privacy-password and auth-protocol must be specified")
+
+	validAuthProtocols    = []string{"MD5", "SHA", "SHA224", "SHA256", "SHA384", "SHA512"}
+	validPrivacyProtocols = []string{"DES", "AES", "AES192", "AES256", "AES192C", "AES256C"}
+)
+
+type Config struct {
+	// Version is the version of SNMP to use (2c or 3)
+	Version string `yaml:"version,omitempty"`
+
+	// Community is the community to use for SNMPv2c
+	Community string `yaml:"community,omitempty"`
+
+	// OIDs is the list of OIDs to fetch
+	OIDs []string `yaml:"oids"`
+
+	// Username is the security name to use for SNMPv3
+	Username string `yaml:"username,omitempty"`
+
+	// AuthProtocol is the authentication protocol to use for SNMPv3. If empty, no authentication is performed.
+	AuthProtocol string `yaml:"auth-protocol,omitempty"`
+	AuthPassword string `yaml:"auth-password,omitempty"`
+
+	// PrivacyProtocol is the privacy protocol to use for SNMPv3. If empty, messages are not encrypted.
+	PrivacyProtocol string `yaml:"privacy-protocol,omitempty"`
+	PrivacyPassword string `yaml:"privacy-password,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the SNMP configuration and sets the default values if necessary
+func (cfg *Config) ValidateAndSetDefaults() error {
+	if len(cfg.OIDs) == 0 {
+		return ErrEndpointWithoutSNMPOIDs
+	}
+	if len(cfg.Version) == 0 {
+		cfg.Version = DefaultVersion
+	}
+	switch cfg.Version {
+	case "2c":
+		if len(cfg.Community) == 0 {
+			cfg.Community = DefaultCommunity
+		}
+	case "3":
+		if len(cfg.Username) == 0 {
+			return ErrEndpointWithoutSNMPUsername
+		}
+		if len(cfg.AuthProtocol) > 0 {
+			cfg.AuthProtocol = strings.ToUpper(cfg.AuthProtocol)
+			if !contains(validAuthProtocols, cfg.AuthProtocol) || len(cfg.AuthPassword) == 0 {
+				return fmt.Errorf("%w: %s", ErrEndpointWithInvalidSNMPAuthProtocol, cfg.AuthProtocol)
+			}
+		}
+		if len(cfg.PrivacyProtocol) > 0 {
+			cfg.PrivacyProtocol = strings.ToUpper(cfg.PrivacyProtocol)
+			if !contains(validPrivacyProtocols, cfg.PrivacyProtocol) || len(cfg.PrivacyPassword) == 0 || len(cfg.AuthProtocol) == 0 {
+				return fmt.Errorf("%w: %s", ErrEndpointWithInvalidSNMPPrivacyProtocol, cfg.PrivacyProtocol)
+			}
+		}
+	default:
+		return ErrEndpointWithInvalidSNMPVersion
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package snmp
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name              string
+		cfg               *Config
+		expectedErr       error
+		expectedCommunity string
+	}{
+		{
+			name:              "v2c-with-defaults",
+			cfg:               &Config{OIDs: []string{"1.3.6.1.2.1.1.3.0"}},
+			expectedCommunity: DefaultCommunity,
+		},
+		{
+			name:              "v2c-with-community",
+			cfg:               &Config{Version: "2c", Community: "private", OIDs: []string{"1.3.6.1.2.1.1.3.0"}},
+			expectedCommunity: "private",
+		},
+		{
+			name: "v3-auth-priv",
+			cfg:  &Config{Version: "3", Username: "gatus", AuthProtocol: "sha256", AuthPassword: "password", PrivacyProtocol: "aes", PrivacyPassword: "password", OIDs: []string{"1.3.6.1.2.1.1.3.0"}},
+		},
+		{
+			name: "v3-no-auth-no-priv",
+			cfg:  &Config{Version: "3", Username: "gatus", OIDs: []string{"1.3.6.1.2.1.1.3.0"}},
+		},
+		{
+			name:        "without-oids",
+			cfg:         &Config{},
+			expectedErr: ErrEndpointWithoutSNMPOIDs,
+		},
+		{
+			name:        "invalid-version",
+			cfg:         &Config{Version: "1", OIDs: []string{"1.3.6.1.2.1.1.3.0"}},
+			expectedErr: ErrEndpointWithInvalidSNMPVersion,
+		},
+		{
+			name:        "v3-without-username",
+			cfg:         &Config{Version: "3", OIDs: []string{"1.3.6.1.2.1.1.3.0"}},
+			expectedErr: ErrEndpointWithoutSNMPUsername,
+		},
+		{
+			name:        "v3-invalid-auth-protocol",
+			cfg:         &Config{Version: "3", Username: "gatus", AuthProtocol: "SHA1024", AuthPassword: "password", OIDs: []string{"1.3.6.1.2.1.1.3.0"}},
+			expectedErr: ErrEndpointWithInvalidSNMPAuthProtocol,
+		},
+		{
+			name:        "v3-auth-protocol-without-password",
+			cfg:         &Config{Version: "3", Username: "gatus", AuthProtocol: "SHA", OIDs: []string{"1.3.6.1.2.1.1.3.0"}},
+			expectedErr: ErrEndpointWithInvalidSNMPAuthProtocol,
+		},
+		{
+			name:        "v3-privacy-without-auth",
+			cfg:         &Config{Version: "3", Username: "gatus", PrivacyProtocol: "AES", PrivacyPassword: "password", OIDs: []string{"1.3.6.1.2.1.1.3.0"}},
+			expectedErr: ErrEndpointWithInvalidSNMPPrivacyProtocol,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.ValidateAndSetDefaults(); !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected error to be '%v', got '%v'", scenario.expectedErr, err)
+			}
+			if scenario.cfg.Community != scenario.expectedCommunity {
+				t.Errorf("expected community to be '%s', got '%s'", scenario.expectedCommunity, scenario.cfg.Community)
+			}
+		})
+	}
+}
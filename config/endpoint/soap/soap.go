@@ -0,0 +1,146 @@
+package soap
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"time"
+)
+
+const (
+	// Version11 is SOAP 1.1, which is used when no version is specified
+	Version11 = "1.1"
+
+	// Version12 is SOAP 1.2
+	Version12 = "1.2"
+
+	// PasswordTypeText sends the password of the UsernameToken in clear text
+	PasswordTypeText = "text"
+
+	// PasswordTypeDigest sends a digest of the password of the UsernameToken, as well as the nonce and the creation
+	// time used to compute it
+	PasswordTypeDigest = "digest"
+
+	envelopeNamespace11 = "http://schemas.xmlsoap.org/soap/envelope/"
+	envelopeNamespace12 = "http://www.w3.org/2003/05/soap-envelope"
+
+	securityNamespace = "http://docs.oasis-open.org/wss/2004/01/oasis-200401-wss-wssecurity-secext-1.0.xsd"
+	utilityNamespace  = "http://docs.oasis-open.org/wss/2004/01/oasis-200401-wss-wssecurity-utility-1.0.xsd"
+	tokenProfile      = "http://docs.oasis-open.org/wss/2004/01/oasis-200401-wss-username-token-profile-1.0"
+)
+
+var (
+	// ErrEndpointWithInvalidSOAPVersion is the error with which Gatus will panic if an endpoint with SOAP is configured with an invalid version.
+	ErrEndpointWithInvalidSOAPVersion = errors.New("SOAP version must be either 1.1 or 1.2")
+
+	// ErrEndpointWithInvalidSOAPPasswordType is the error with which Gatus will panic if an endpoint with SOAP is configured with an invalid password type.
+	ErrEndpointWithInvalidSOAPPasswordType = errors.New("SOAP password-type must be either text or digest")
+
+	// ErrEndpointWithSOAPPasswordWithoutUsername is the error with which Gatus will panic if an endpoint with SOAP is configured with a password, but no username.
+	ErrEndpointWithSOAPPasswordWithoutUsername = errors.New("SOAP password requires a username")
+)
+
+type Config struct {
+	// Version of SOAP to use, which determines the namespace of the envelope and the Content-Type of the request
+	Version string `yaml:"version,omitempty"`
+
+	// Action is the SOAPAction of the request
+	Action string `yaml:"action,omitempty"`
+
+	// Username of the WS-Security UsernameToken. No security header is added if empty.
+	Username string `yaml:"username,omitempty"`
+
+	// Password of the WS-Security UsernameToken
+	Password string `yaml:"password,omitempty"`
+
+	// PasswordType is how the password of the UsernameToken is sent, either text or digest
+	PasswordType string `yaml:"password-type,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the SOAP configuration and sets the default values if necessary
+func (cfg *Config) ValidateAndSetDefaults() error {
+	if len(cfg.Version) == 0 {
+		cfg.Version = Version11
+	}
+	if cfg.Version != Version11 && cfg.Version != Version12 {
+		return ErrEndpointWithInvalidSOAPVersion
+	}
+	if len(cfg.PasswordType) == 0 {
+		cfg.PasswordType = PasswordTypeText
+	}
+	if cfg.PasswordType != PasswordTypeText && cfg.PasswordType != PasswordTypeDigest {
+		return ErrEndpointWithInvalidSOAPPasswordType
+	}
+	if len(cfg.Password) > 0 && len(cfg.Username) == 0 {
+		return ErrEndpointWithSOAPPasswordWithoutUsername
+	}
+	return nil
+}
+
+// Headers returns the headers required by the version of SOAP configured
+func (cfg *Config) Headers() map[string]string {
+	if cfg.Version == Version12 {
+		// SOAP 1.2 has no SOAPAction header, the action is instead a parameter of the Content-Type
+		contentType := "application/soap+xml; charset=utf-8"
+		if len(cfg.Action) > 0 {
+			contentType += "; action=\"" + cfg.Action + "\""
+		}
+		return map[string]string{"Content-Type": contentType}
+	}
+	return map[string]string{
+		"Content-Type": "text/xml; charset=utf-8",
+		"SOAPAction":   "\"" + cfg.Action + "\"",
+	}
+}
+
+// Envelope wraps the body passed as parameter in a SOAP envelope, along with a WS-Security header if a username is
+// configured. Since the nonce of a digest must never be reused, a new envelope must be created for each request.
+func (cfg *Config) Envelope(body string) []byte {
+	namespace := envelopeNamespace11
+	if cfg.Version == Version12 {
+		namespace = envelopeNamespace12
+	}
+	var buffer bytes.Buffer
+	buffer.WriteString(xml.Header)
+	buffer.WriteString(`<soap:Envelope xmlns:soap="` + namespace + `">`)
+	if len(cfg.Username) > 0 {
+		cfg.writeSecurityHeader(&buffer, time.Now())
+	}
+	buffer.WriteString("<soap:Body>")
+	buffer.WriteString(body)
+	buffer.WriteString("</soap:Body></soap:Envelope>")
+	return buffer.Bytes()
+}
+
+// writeSecurityHeader writes a header containing a WS-Security UsernameToken as described in the
+// Username Token Profile 1.0
+func (cfg *Config) writeSecurityHeader(buffer *bytes.Buffer, now time.Time) {
+	buffer.WriteString(`<soap:Header><wsse:Security soap:mustUnderstand="1" xmlns:wsse="` + securityNamespace + `" xmlns:wsu="` + utilityNamespace + `"><wsse:UsernameToken><wsse:Username>`)
+	_ = xml.EscapeText(buffer, []byte(cfg.Username))
+	buffer.WriteString("</wsse:Username>")
+	if cfg.PasswordType == PasswordTypeDigest {
+		nonce := make([]byte, 16)
+		_, _ = rand.Read(nonce)
+		created := now.UTC().Format(time.RFC3339)
+		buffer.WriteString(`<wsse:Password Type="` + tokenProfile + `#PasswordDigest">` + Digest(nonce, created, cfg.Password) + "</wsse:Password>")
+		buffer.WriteString(`<wsse:Nonce EncodingType="http://docs.oasis-open.org/wss/2004/01/oasis-200401-wss-soap-message-security-1.0#Base64Binary">` + base64.StdEncoding.EncodeToString(nonce) + "</wsse:Nonce>")
+		buffer.WriteString("<wsu:Created>" + created + "</wsu:Created>")
+	} else {
+		buffer.WriteString(`<wsse:Password Type="` + tokenProfile + `#PasswordText">`)
+		_ = xml.EscapeText(buffer, []byte(cfg.Password))
+		buffer.WriteString("</wsse:Password>")
+	}
+	buffer.WriteString("</wsse:UsernameToken></wsse:Security></soap:Header>")
+}
+
+// Digest computes the password digest of a UsernameToken, which is Base64(SHA-1(nonce + created + password))
+func Digest(nonce []byte, created, password string) string {
+	hash := sha1.New()
+	hash.Write(nonce)
+	hash.Write([]byte(created))
+	hash.Write([]byte(password))
+	return base64.StdEncoding.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,210 @@
+package soap
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name                 string
+		cfg                  *Config
+		expectedErr          error
+		expectedVersion      string
+		expectedPasswordType string
+	}{
+		{
+			name:                 "defaults",
+			cfg:                  &Config{},
+			expectedVersion:      Version11,
+			expectedPasswordType: PasswordTypeText,
+		},
+		{
+			name:                 "custom",
+			cfg:                  &Config{Version: Version12, Username: "john", Password: "hunter2", PasswordType: PasswordTypeDigest},
+			expectedVersion:      Version12,
+			expectedPasswordType: PasswordTypeDigest,
+		},
+		{
+			name:                 "invalid-version",
+			cfg:                  &Config{Version: "2.0"},
+			expectedErr:          ErrEndpointWithInvalidSOAPVersion,
+			expectedVersion:      "2.0",
+			expectedPasswordType: "",
+		},
+		{
+			name:                 "invalid-password-type",
+			cfg:                  &Config{PasswordType: "plain"},
+			expectedErr:          ErrEndpointWithInvalidSOAPPasswordType,
+			expectedVersion:      Version11,
+			expectedPasswordType: "plain",
+		},
+		{
+			name:                 "password-without-username",
+			cfg:                  &Config{Password: "hunter2"},
+			expectedErr:          ErrEndpointWithSOAPPasswordWithoutUsername,
+			expectedVersion:      Version11,
+			expectedPasswordType: PasswordTypeText,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.ValidateAndSetDefaults(); !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected error to be '%v', got '%v'", scenario.expectedErr, err)
+			}
+			if scenario.cfg.Version != scenario.expectedVersion {
+				t.Errorf("expected version to be %s, got %s", scenario.expectedVersion, scenario.cfg.Version)
+			}
+			if scenario.cfg.PasswordType != scenario.expectedPasswordType {
+				t.Errorf("expected password type to be %s, got %s", scenario.expectedPasswordType, scenario.cfg.PasswordType)
+			}
+		})
+	}
+}
+
+func TestConfig_Headers(t *testing.T) {
+	scenarios := []struct {
+		name            string
+		cfg             *Config
+		expectedHeaders map[string]string
+	}{
+		{
+			name:            "soap-1.1",
+			cfg:             &Config{Version: Version11, Action: "https://example.org/GetPrice"},
+			expectedHeaders: map[string]string{"Content-Type": "text/xml; charset=utf-8", "SOAPAction": `"https://example.org/GetPrice"`},
+		},
+		{
+			name:            "soap-1.2",
+			cfg:             &Config{Version: Version12, Action: "https://example.org/GetPrice"},
+			expectedHeaders: map[string]string{"Content-Type": `application/soap+xml; charset=utf-8; action="https://example.org/GetPrice"`},
+		},
+		{
+			name:            "soap-1.2-without-action",
+			cfg:             &Config{Version: Version12},
+			expectedHeaders: map[string]string{"Content-Type": "application/soap+xml; charset=utf-8"},
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			headers := scenario.cfg.Headers()
+			if len(headers) != len(scenario.expectedHeaders) {
+				t.Fatalf("expected headers to be %v, got %v", scenario.expectedHeaders, headers)
+			}
+			for k, v := range scenario.expectedHeaders {
+				if headers[k] != v {
+					t.Errorf("expected header %s to be '%s', got '%s'", k, v, headers[k])
+				}
+			}
+		})
+	}
+}
+
+func TestConfig_Envelope(t *testing.T) {
+	type usernameToken struct {
+		Username string `xml:"Username"`
+		Password struct {
+			Type  string `xml:"Type,attr"`
+			Value string `xml:",chardata"`
+		} `xml:"Password"`
+		Nonce   string `xml:"Nonce"`
+		Created string `xml:"Created"`
+	}
+	type envelope struct {
+		XMLName xml.Name
+		Header  *struct {
+			Security struct {
+				UsernameToken usernameToken `xml:"UsernameToken"`
+			} `xml:"Security"`
+		} `xml:"Header"`
+		Body struct {
+			Content string `xml:",innerxml"`
+		} `xml:"Body"`
+	}
+	scenarios := []struct {
+		name                 string
+		cfg                  *Config
+		expectedNamespace    string
+		expectedSecurity     bool
+		expectedPasswordType string
+	}{
+		{
+			name:              "soap-1.1",
+			cfg:               &Config{Version: Version11},
+			expectedNamespace: "http://schemas.xmlsoap.org/soap/envelope/",
+		},
+		{
+			name:              "soap-1.2",
+			cfg:               &Config{Version: Version12},
+			expectedNamespace: "http://www.w3.org/2003/05/soap-envelope",
+		},
+		{
+			name:                 "username-token-with-text-password",
+			cfg:                  &Config{Version: Version11, Username: "john", Password: "<hunter2>", PasswordType: PasswordTypeText},
+			expectedNamespace:    "http://schemas.xmlsoap.org/soap/envelope/",
+			expectedSecurity:     true,
+			expectedPasswordType: "#PasswordText",
+		},
+		{
+			name:                 "username-token-with-digest-password",
+			cfg:                  &Config{Version: Version11, Username: "john", Password: "<hunter2>", PasswordType: PasswordTypeDigest},
+			expectedNamespace:    "http://schemas.xmlsoap.org/soap/envelope/",
+			expectedSecurity:     true,
+			expectedPasswordType: "#PasswordDigest",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			var e envelope
+			if err := xml.Unmarshal(scenario.cfg.Envelope("<GetPrice><Item>apple</Item></GetPrice>"), &e); err != nil {
+				t.Fatal("expected the envelope to be valid XML, got", err)
+			}
+			if e.XMLName.Space != scenario.expectedNamespace || e.XMLName.Local != "Envelope" {
+				t.Errorf("expected envelope to be in namespace %s, got %s", scenario.expectedNamespace, e.XMLName.Space)
+			}
+			if e.Body.Content != "<GetPrice><Item>apple</Item></GetPrice>" {
+				t.Errorf("expected body to be wrapped as is, got %s", e.Body.Content)
+			}
+			if !scenario.expectedSecurity {
+				if e.Header != nil {
+					t.Error("expected no security header")
+				}
+				return
+			}
+			if e.Header == nil {
+				t.Fatal("expected a security header")
+			}
+			token := e.Header.Security.UsernameToken
+			if token.Username != scenario.cfg.Username {
+				t.Errorf("expected username to be %s, got %s", scenario.cfg.Username, token.Username)
+			}
+			if !strings.HasSuffix(token.Password.Type, scenario.expectedPasswordType) {
+				t.Errorf("expected password type to end with %s, got %s", scenario.expectedPasswordType, token.Password.Type)
+			}
+			if scenario.cfg.PasswordType == PasswordTypeText {
+				if token.Password.Value != scenario.cfg.Password {
+					t.Errorf("expected password to be %s, got %s", scenario.cfg.Password, token.Password.Value)
+				}
+				return
+			}
+			if len(token.Nonce) == 0 || len(token.Created) == 0 {
+				t.Fatal("expected digest to be sent along with a nonce and a creation time")
+			}
+			nonce, err := base64.StdEncoding.DecodeString(token.Nonce)
+			if err != nil {
+				t.Fatal("expected nonce to be base64-encoded, got", err)
+			}
+			if expectedDigest := Digest(nonce, token.Created, scenario.cfg.Password); token.Password.Value != expectedDigest {
+				t.Errorf("expected password digest to be %s, got %s", expectedDigest, token.Password.Value)
+			}
+		})
+	}
+}
+
+func TestDigest(t *testing.T) {
+	if digest := Digest([]byte("0123456789abcdef"), "2024-01-01T00:00:00Z", "password"); digest != "tbv+qI6jgvLVoTMZ5kmm5OrOL7c=" {
+		t.Errorf("expected digest to be tbv+qI6jgvLVoTMZ5kmm5OrOL7c=, got %s", digest)
+	}
+}
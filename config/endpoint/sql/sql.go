@@ -0,0 +1,21 @@
+package sql
+
+import (
+	"strings"
+)
+
+// DefaultQuery is the query executed when none is specified, which only ensures that the database can execute queries
+const DefaultQuery = "SELECT 1"
+
+type Config struct {
+	// Query is the query to execute in a read-only transaction. The first column of the first row is used as body.
+	Query string `yaml:"query,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the SQL configuration and sets the default values if necessary
+func (cfg *Config) ValidateAndSetDefaults() error {
+	if len(strings.TrimSpace(cfg.Query)) == 0 {
+		cfg.Query = DefaultQuery
+	}
+	return nil
+}
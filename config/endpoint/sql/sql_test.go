@@ -0,0 +1,22 @@
+package sql
+
+import (
+	"testing"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	cfg := &Config{}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Errorf("expected no error, got '%v'", err)
+	}
+	if cfg.Query != DefaultQuery {
+		t.Errorf("expected query to default to '%s', got '%s'", DefaultQuery, cfg.Query)
+	}
+	cfg.Query = "SELECT COUNT(*) FROM users"
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Errorf("expected no error, got '%v'", err)
+	}
+	if cfg.Query != "SELECT COUNT(*) FROM users" {
+		t.Errorf("expected query to be left untouched, got '%s'", cfg.Query)
+	}
+}
@@ -17,6 +17,11 @@ type Status struct {
 	// Events is a list of events
 	Events []*Event `json:"events,omitempty"`
 
+	// Paused is whether the monitoring of the endpoint is paused, in which case its results are no longer updated.
+	//
+	// Not persisted by the store, since the state of the monitoring is kept by the watchdog.
+	Paused bool `json:"paused,omitempty"`
+
 	// Uptime information on the endpoint's uptime
 	//
 	// Used by the memory store.
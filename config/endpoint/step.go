@@ -0,0 +1,202 @@
+package endpoint
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/TwiN/gatus/v5/client"
+)
+
+const (
+	// VariablePlaceholderPrefix is the prefix of the placeholder for a value extracted by a previous step.
+	// The placeholder can be used in the url, the body and the headers of a step.
+	//
+	// Usage: [VAR:token]
+	VariablePlaceholderPrefix = "[VAR:"
+)
+
+var (
+	// ErrEndpointWithStepsAndNonHTTPType is the error with which Gatus will panic if an endpoint that isn't of type HTTP is configured with steps
+	ErrEndpointWithStepsAndNonHTTPType = errors.New("steps are only supported for endpoints of type HTTP")
+
+	// ErrStepWithNoName is the error with which Gatus will panic if a step is configured without a name
+	ErrStepWithNoName = errors.New("you must specify a name for each step")
+
+	// ErrStepWithDuplicateName is the error with which Gatus will panic if two steps of an endpoint have the same name
+	ErrStepWithDuplicateName = errors.New("step names must be unique within an endpoint")
+
+	// ErrStepWithInvalidVariableName is the error with which Gatus will panic if a step extracts a value into a variable whose name is invalid
+	ErrStepWithInvalidVariableName = errors.New("variable names must only contain letters, digits, dashes and underscores")
+
+	validVariableNameRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+)
+
+// Step is a request performed as part of a multi-step HTTP endpoint
+type Step struct {
+	// Name of the step
+	Name string `yaml:"name"`
+
+	// Method of the request made for the step. Defaults to GET.
+	Method string `yaml:"method,omitempty"`
+
+	// URL to send the request to. Defaults to the URL of the endpoint.
+	URL string `yaml:"url,omitempty"`
+
+	// Body of the request
+	Body string `yaml:"body,omitempty"`
+
+	// Headers of the request
+	Headers map[string]string `yaml:"headers,omitempty"`
+
+	// Conditions used to determine whether the step succeeded. Subsequent steps are not performed if a step fails.
+	Conditions []Condition `yaml:"conditions,omitempty"`
+
+	// Extract is a map of variable names to placeholders (e.g. [BODY].token) whose resolved value will be available
+	// to subsequent steps through the VariablePlaceholderPrefix placeholder
+	Extract map[string]string `yaml:"extract,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the step's configuration and sets the default value of args that have one
+func (s *Step) ValidateAndSetDefaults(e *Endpoint) error {
+	if len(s.Name) == 0 {
+		return ErrStepWithNoName
+	}
+	if len(s.Method) == 0 {
+		s.Method = http.MethodGet
+	}
+	if len(s.URL) == 0 {
+		s.URL = e.URL
+	}
+	if len(s.Headers) == 0 {
+		s.Headers = make(map[string]string)
+	}
+	if _, userAgentHeaderExists := s.Headers[UserAgentHeader]; !userAgentHeaderExists {
+		s.Headers[UserAgentHeader] = GatusUserAgent
+	}
+	for _, c := range s.Conditions {
+		if err := c.Validate(); err != nil {
+			return fmt.Errorf("step %s: %w: %w", s.Name, ErrInvalidConditionFormat, err)
+		}
+	}
+	for name := range s.Extract {
+		if !validVariableNameRegex.MatchString(name) {
+			return fmt.Errorf("step %s: %w", s.Name, ErrStepWithInvalidVariableName)
+		}
+	}
+	return nil
+}
+
+// buildHTTPRequest builds the request of the step, replacing the variable placeholders by their value
+func (s *Step) buildHTTPRequest(variables map[string]string) (*http.Request, error) {
+	request, err := http.NewRequest(s.Method, replaceVariables(s.URL, variables), bytes.NewBufferString(replaceVariables(s.Body, variables)))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range s.Headers {
+		v = replaceVariables(v, variables)
+		request.Header.Set(k, v)
+		if k == HostHeader {
+			request.Host = v
+		}
+	}
+	return request, nil
+}
+
+// replaceVariables replaces each variable placeholder in the value passed as parameter by the value of the variable
+func replaceVariables(value string, variables map[string]string) string {
+	if !strings.Contains(value, VariablePlaceholderPrefix) {
+		return value
+	}
+	for name, variable := range variables {
+		value = strings.ReplaceAll(value, VariablePlaceholderPrefix+name+"]", variable)
+	}
+	return value
+}
+
+// callSteps performs each step of the endpoint in order, stopping at the first step that fails.
+//
+// The conditions of each step are added to the condition results of the endpoint, prefixed by the name of the step,
+// and the result of the endpoint is populated using the response of the last step performed, except for the duration,
+// which is the duration of all steps.
+func (e *Endpoint) callSteps(result *Result) {
+	// Copy the client so that cookies can be shared between the steps without being shared between checks
+	httpClient := *client.GetHTTPClient(e.ClientConfig)
+	httpClient.Jar, _ = cookiejar.New(nil)
+	variables := make(map[string]string)
+	startTime := time.Now()
+	defer func() {
+		result.Duration = time.Since(startTime)
+	}()
+	for _, step := range e.Steps {
+		request, err := step.buildHTTPRequest(variables)
+		if err != nil {
+			e.addStepError(result, step, err.Error())
+			return
+		}
+		response, err := httpClient.Do(request)
+		if err != nil {
+			e.addStepError(result, step, err.Error())
+			return
+		}
+		stepResult := &Result{Errors: []string{}}
+		stepResult.populateFromHTTPResponse(response)
+		stepResult.Body, err = io.ReadAll(response.Body)
+		response.Body.Close()
+		if err != nil {
+			e.addStepError(result, step, "error reading response body:"+err.Error())
+			return
+		}
+		// The result of the endpoint reflects the last step performed
+		result.HTTPStatus = stepResult.HTTPStatus
+		result.Connected = stepResult.Connected
+		result.CertificateExpiration = stepResult.CertificateExpiration
+		result.certificateChain = stepResult.certificateChain
+		result.ContentType = stepResult.ContentType
+		result.Protocol = stepResult.Protocol
+		result.FinalURL = stepResult.FinalURL
+		result.RedirectCount = stepResult.RedirectCount
+		result.Body = stepResult.Body
+		result.responseHeader = stepResult.responseHeader
+		stepSucceeded := true
+		for _, condition := range step.Conditions {
+			if !condition.evaluate(stepResult, e.UIConfig.DontResolveFailedConditions) {
+				stepSucceeded = false
+			}
+		}
+		for _, conditionResult := range stepResult.ConditionResults {
+			conditionResult.Condition = step.Name + ": " + conditionResult.Condition
+			result.ConditionResults = append(result.ConditionResults, conditionResult)
+		}
+		for _, stepError := range stepResult.Errors {
+			result.AddError(fmt.Sprintf("step %s: %s", step.Name, stepError))
+		}
+		if !stepSucceeded {
+			result.Success = false
+			return
+		}
+		for name, placeholder := range step.Extract {
+			_, resolved := sanitizeAndResolve([]string{placeholder}, stepResult)
+			if strings.HasSuffix(resolved[0], InvalidConditionElementSuffix) {
+				e.addStepError(result, step, fmt.Sprintf("failed to extract %s from %s", name, placeholder))
+				return
+			}
+			variables[name] = resolved[0]
+		}
+	}
+}
+
+// addStepError adds an error that occurred during a step to the result and marks the result as unsuccessful
+func (e *Endpoint) addStepError(result *Result, step *Step, err string) {
+	if e.UIConfig.HideURL {
+		err = strings.ReplaceAll(err, step.URL, "<redacted>")
+	}
+	result.AddError(fmt.Sprintf("step %s: %s", step.Name, err))
+	result.Success = false
+}
@@ -0,0 +1,205 @@
+package endpoint
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestEndpoint_ValidateAndSetDefaultsWithSteps(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		url         string
+		steps       []*Step
+		expectedErr error
+	}{
+		{
+			name:  "valid",
+			url:   "https://example.org",
+			steps: []*Step{{Name: "login", Extract: map[string]string{"token": "[BODY].token"}}, {Name: "fetch"}},
+		},
+		{
+			name:        "non-http-type",
+			url:         "tcp://example.org:80",
+			steps:       []*Step{{Name: "login"}},
+			expectedErr: ErrEndpointWithStepsAndNonHTTPType,
+		},
+		{
+			name:        "step-without-name",
+			url:         "https://example.org",
+			steps:       []*Step{{}},
+			expectedErr: ErrStepWithNoName,
+		},
+		{
+			name:        "steps-with-duplicate-name",
+			url:         "https://example.org",
+			steps:       []*Step{{Name: "login"}, {Name: "login"}},
+			expectedErr: ErrStepWithDuplicateName,
+		},
+		{
+			name:        "step-with-invalid-variable-name",
+			url:         "https://example.org",
+			steps:       []*Step{{Name: "login", Extract: map[string]string{"the token": "[BODY].token"}}},
+			expectedErr: ErrStepWithInvalidVariableName,
+		},
+		{
+			name:        "step-with-invalid-condition",
+			url:         "https://example.org",
+			steps:       []*Step{{Name: "login", Conditions: []Condition{"[STATUS] 200"}}},
+			expectedErr: ErrInvalidConditionFormat,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			endpoint := Endpoint{
+				Name:       "website-health",
+				URL:        scenario.url,
+				Steps:      scenario.steps,
+				Conditions: []Condition{"[STATUS] == 200"},
+			}
+			err := endpoint.ValidateAndSetDefaults()
+			if !errors.Is(err, scenario.expectedErr) {
+				t.Fatalf("expected error to be '%v', got '%v'", scenario.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			for _, step := range endpoint.Steps {
+				if step.Method != http.MethodGet {
+					t.Errorf("expected method of step %s to default to GET, got %s", step.Name, step.Method)
+				}
+				if step.URL != endpoint.URL {
+					t.Errorf("expected url of step %s to default to the url of the endpoint, got %s", step.Name, step.URL)
+				}
+				if step.Headers[UserAgentHeader] != GatusUserAgent {
+					t.Errorf("expected user agent of step %s to default to %s, got %s", step.Name, GatusUserAgent, step.Headers[UserAgentHeader])
+				}
+			}
+		})
+	}
+}
+
+func TestIntegrationEvaluateHealthWithSteps(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc"})
+			_, _ = w.Write([]byte(`{"token":"secret","user":{"id":42}}`))
+		case "/users/42":
+			if r.Header.Get("Authorization") != "Bearer secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"name":"john"}`))
+		case "/logout":
+			if cookie, err := r.Cookie("session"); err != nil || cookie.Value != "abc" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	scenarios := []struct {
+		name                     string
+		steps                    []*Step
+		conditions               []Condition
+		expectedSuccess          bool
+		expectedConditionResults []string
+		expectedErrorPrefix      string
+	}{
+		{
+			name: "login-fetch-logout",
+			steps: []*Step{
+				{
+					Name:       "login",
+					Method:     http.MethodPost,
+					URL:        server.URL + "/login",
+					Conditions: []Condition{"[STATUS] == 200"},
+					Extract:    map[string]string{"token": "[BODY].token", "user-id": "[BODY].user.id"},
+				},
+				{
+					Name:       "fetch",
+					URL:        server.URL + "/users/[VAR:user-id]",
+					Headers:    map[string]string{"Authorization": "Bearer [VAR:token]"},
+					Conditions: []Condition{"[STATUS] == 200", "[BODY].name == john"},
+				},
+				{
+					Name:   "logout",
+					Method: http.MethodPost,
+					URL:    server.URL + "/logout",
+				},
+			},
+			conditions:               []Condition{"[STATUS] == 204", "[RESPONSE_TIME] < 5000"},
+			expectedSuccess:          true,
+			expectedConditionResults: []string{"login: [STATUS] == 200", "fetch: [STATUS] == 200", "fetch: [BODY].name == john", "[STATUS] == 204", "[RESPONSE_TIME] < 5000"},
+		},
+		{
+			name: "failed-step-stops-sequence",
+			steps: []*Step{
+				{
+					Name:       "fetch",
+					URL:        server.URL + "/users/42",
+					Conditions: []Condition{"[STATUS] == 200"},
+				},
+				{
+					Name: "logout",
+					URL:  server.URL + "/logout",
+				},
+			},
+			conditions:               []Condition{"[STATUS] == 204"},
+			expectedSuccess:          false,
+			expectedConditionResults: []string{"fetch: [STATUS] (401) == 200", "[STATUS] (401) == 204"},
+		},
+		{
+			name: "failed-extraction",
+			steps: []*Step{
+				{
+					Name:    "login",
+					URL:     server.URL + "/login",
+					Extract: map[string]string{"token": "[BODY].access_token"},
+				},
+				{
+					Name: "fetch",
+					URL:  server.URL + "/users/42",
+				},
+			},
+			conditions:               []Condition{"[STATUS] == 200"},
+			expectedSuccess:          false,
+			expectedConditionResults: []string{"[STATUS] == 200"},
+			expectedErrorPrefix:      "step login: failed to extract token",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			endpoint := Endpoint{
+				Name:       "website-health",
+				URL:        server.URL,
+				Steps:      scenario.steps,
+				Conditions: scenario.conditions,
+			}
+			if err := endpoint.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("did not expect an error, got", err)
+			}
+			result := endpoint.EvaluateHealth()
+			if result.Success != scenario.expectedSuccess {
+				t.Errorf("expected success to be %v, got %v (errors: %v)", scenario.expectedSuccess, result.Success, result.Errors)
+			}
+			if len(result.ConditionResults) != len(scenario.expectedConditionResults) {
+				t.Fatalf("expected %d condition results, got %d", len(scenario.expectedConditionResults), len(result.ConditionResults))
+			}
+			for i, conditionResult := range result.ConditionResults {
+				if conditionResult.Condition != scenario.expectedConditionResults[i] {
+					t.Errorf("expected condition result #%d to be '%s', got '%s'", i, scenario.expectedConditionResults[i], conditionResult.Condition)
+				}
+			}
+			if len(scenario.expectedErrorPrefix) > 0 && (len(result.Errors) == 0 || !strings.HasPrefix(result.Errors[0], scenario.expectedErrorPrefix)) {
+				t.Errorf("expected an error starting with '%s', got %v", scenario.expectedErrorPrefix, result.Errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package tcp
+
+import (
+	"encoding/hex"
+	"errors"
+	"regexp"
+	"time"
+)
+
+const (
+	// EncodingText is the encoding with which the payloads are sent as-is and the responses are matched as-is
+	EncodingText = "text"
+
+	// EncodingHex is the encoding with which the payloads are decoded from hexadecimal before being sent, and the
+	// responses are encoded in hexadecimal before being matched and used as body, which is useful for binary protocols
+	EncodingHex = "hex"
+)
+
+var (
+	// ErrEndpointWithInvalidTCPEncoding is the error with which Gatus will panic if an endpoint with TCP monitoring is configured with an invalid encoding.
+	ErrEndpointWithInvalidTCPEncoding = errors.New("invalid TCP encoding, must be one of: text, hex")
+
+	// ErrEndpointWithEmptyTCPStep is the error with which Gatus will panic if an endpoint with TCP monitoring is configured with a step that neither sends nor expects anything.
+	ErrEndpointWithEmptyTCPStep = errors.New("TCP step must have at least one of send or expect")
+
+	// ErrEndpointWithInvalidTCPStepExpect is the error with which Gatus will panic if an endpoint with TCP monitoring is configured with a step whose expect isn't a valid regular expression.
+	ErrEndpointWithInvalidTCPStepExpect = errors.New("invalid TCP step expect, must be a valid regular expression")
+
+	// ErrEndpointWithInvalidTCPHexStepSend is the error with which Gatus will panic if an endpoint with TCP monitoring using the hex encoding is configured with a step whose send isn't valid hexadecimal.
+	ErrEndpointWithInvalidTCPHexStepSend = errors.New("invalid TCP step send for hex encoding, must be valid hexadecimal")
+)
+
+type Config struct {
+	// Encoding is the encoding of the payloads sent and of the responses received (text or hex)
+	Encoding string `yaml:"encoding,omitempty"`
+
+	// Steps is the sequence of send/expect steps to perform once connected
+	Steps []*Step `yaml:"steps,omitempty"`
+}
+
+// Step is a single step of the conversation with the TCP endpoint
+type Step struct {
+	// Send is the payload to send. If empty, nothing is sent.
+	Send string `yaml:"send,omitempty"`
+
+	// Expect is a regular expression that the data received must match before moving on to the next step.
+	// If empty, the next step is performed right after sending the payload.
+	Expect string `yaml:"expect,omitempty"`
+
+	// Timeout is the duration to wait for the step to complete. If not specified, the client timeout is used.
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+
+	payload     []byte
+	expectRegex *regexp.Regexp
+}
+
+// ValidateAndSetDefaults validates the TCP configuration and sets the default values if necessary
+func (cfg *Config) ValidateAndSetDefaults() error {
+	if len(cfg.Encoding) == 0 {
+		cfg.Encoding = EncodingText
+	}
+	if cfg.Encoding != EncodingText && cfg.Encoding != EncodingHex {
+		return ErrEndpointWithInvalidTCPEncoding
+	}
+	for _, step := range cfg.Steps {
+		if len(step.Send) == 0 && len(step.Expect) == 0 {
+			return ErrEndpointWithEmptyTCPStep
+		}
+		step.payload = []byte(step.Send)
+		if cfg.Encoding == EncodingHex {
+			payload, err := hex.DecodeString(step.Send)
+			if err != nil {
+				return ErrEndpointWithInvalidTCPHexStepSend
+			}
+			step.payload = payload
+		}
+		if len(step.Expect) > 0 {
+			regex, err := regexp.Compile(step.Expect)
+			if err != nil {
+				return ErrEndpointWithInvalidTCPStepExpect
+			}
+			step.expectRegex = regex
+		}
+	}
+	return nil
+}
+
+// Payload returns the bytes to send for the step
+func (step *Step) Payload() []byte {
+	return step.payload
+}
+
+// HasExpectation returns whether the step expects a response
+func (step *Step) HasExpectation() bool {
+	return step.expectRegex != nil
+}
+
+// Encode returns the data received encoded with the encoding of the configuration
+func (cfg *Config) Encode(received []byte) []byte {
+	if cfg.Encoding != EncodingHex {
+		return received
+	}
+	return []byte(hex.EncodeToString(received))
+}
+
+// Matches returns whether the data received during a step matches what the step expects
+func (cfg *Config) Matches(step *Step, received []byte) bool {
+	if step.expectRegex == nil {
+		return true
+	}
+	return step.expectRegex.Match(cfg.Encode(received))
+}
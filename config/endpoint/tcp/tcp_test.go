@@ -0,0 +1,76 @@
+package tcp
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		cfg         *Config
+		expectedErr error
+	}{
+		{
+			name: "defaults",
+			cfg:  &Config{},
+		},
+		{
+			name: "text",
+			cfg:  &Config{Steps: []*Step{{Expect: "^220"}, {Send: "QUIT\r\n", Expect: "^221"}}},
+		},
+		{
+			name: "hex",
+			cfg:  &Config{Encoding: EncodingHex, Steps: []*Step{{Send: "0102", Expect: "^03"}}},
+		},
+		{
+			name:        "invalid-encoding",
+			cfg:         &Config{Encoding: "base64"},
+			expectedErr: ErrEndpointWithInvalidTCPEncoding,
+		},
+		{
+			name:        "empty-step",
+			cfg:         &Config{Steps: []*Step{{}}},
+			expectedErr: ErrEndpointWithEmptyTCPStep,
+		},
+		{
+			name:        "invalid-expect",
+			cfg:         &Config{Steps: []*Step{{Expect: "(220"}}},
+			expectedErr: ErrEndpointWithInvalidTCPStepExpect,
+		},
+		{
+			name:        "hex-with-invalid-send",
+			cfg:         &Config{Encoding: EncodingHex, Steps: []*Step{{Send: "QUIT"}}},
+			expectedErr: ErrEndpointWithInvalidTCPHexStepSend,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.ValidateAndSetDefaults(); !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected error to be '%v', got '%v'", scenario.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestConfig_Matches(t *testing.T) {
+	cfg := &Config{Encoding: EncodingHex, Steps: []*Step{{Send: "0102", Expect: "^0304"}, {Send: "05"}}}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if payload := cfg.Steps[0].Payload(); string(payload) != "\x01\x02" {
+		t.Errorf("expected payload to be decoded from hexadecimal, got %q", payload)
+	}
+	if !cfg.Steps[0].HasExpectation() || cfg.Steps[1].HasExpectation() {
+		t.Error("expected only the first step to have an expectation")
+	}
+	if cfg.Matches(cfg.Steps[0], []byte{0x03}) {
+		t.Error("expected partial response not to match")
+	}
+	if !cfg.Matches(cfg.Steps[0], []byte{0x03, 0x04, 0x05}) {
+		t.Error("expected response to match")
+	}
+	if !cfg.Matches(cfg.Steps[1], nil) {
+		t.Error("expected step without expectation to always match")
+	}
+}
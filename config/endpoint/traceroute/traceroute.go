@@ -0,0 +1,44 @@
+package traceroute
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// DefaultMaxHops is the maximum number of hops probed when none is specified
+	DefaultMaxHops = 30
+
+	// DefaultHopTimeout is the duration to wait for a reply from each hop when none is specified
+	DefaultHopTimeout = time.Second
+
+	// MaximumMaxHops is the highest value the TTL of a packet can have
+	MaximumMaxHops = 255
+)
+
+var (
+	// ErrEndpointWithInvalidTracerouteMaxHops is the error with which Gatus will panic if an endpoint with traceroute monitoring is configured with an invalid maximum number of hops.
+	ErrEndpointWithInvalidTracerouteMaxHops = errors.New("traceroute max-hops must be between 1 and 255")
+)
+
+type Config struct {
+	// MaxHops is the maximum number of hops to probe before giving up on reaching the destination
+	MaxHops int `yaml:"max-hops,omitempty"`
+
+	// HopTimeout is the duration to wait for a reply from each hop
+	HopTimeout time.Duration `yaml:"hop-timeout,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the traceroute configuration and sets the default values if necessary
+func (cfg *Config) ValidateAndSetDefaults() error {
+	if cfg.MaxHops == 0 {
+		cfg.MaxHops = DefaultMaxHops
+	}
+	if cfg.MaxHops < 1 || cfg.MaxHops > MaximumMaxHops {
+		return ErrEndpointWithInvalidTracerouteMaxHops
+	}
+	if cfg.HopTimeout <= 0 {
+		cfg.HopTimeout = DefaultHopTimeout
+	}
+	return nil
+}
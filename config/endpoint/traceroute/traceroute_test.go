@@ -0,0 +1,55 @@
+package traceroute
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name               string
+		cfg                *Config
+		expectedErr        error
+		expectedMaxHops    int
+		expectedHopTimeout time.Duration
+	}{
+		{
+			name:               "defaults",
+			cfg:                &Config{},
+			expectedMaxHops:    DefaultMaxHops,
+			expectedHopTimeout: DefaultHopTimeout,
+		},
+		{
+			name:               "custom",
+			cfg:                &Config{MaxHops: 15, HopTimeout: 500 * time.Millisecond},
+			expectedMaxHops:    15,
+			expectedHopTimeout: 500 * time.Millisecond,
+		},
+		{
+			name:            "negative-max-hops",
+			cfg:             &Config{MaxHops: -1},
+			expectedErr:     ErrEndpointWithInvalidTracerouteMaxHops,
+			expectedMaxHops: -1,
+		},
+		{
+			name:            "max-hops-too-high",
+			cfg:             &Config{MaxHops: 256},
+			expectedErr:     ErrEndpointWithInvalidTracerouteMaxHops,
+			expectedMaxHops: 256,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.ValidateAndSetDefaults(); !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected error to be '%v', got '%v'", scenario.expectedErr, err)
+			}
+			if scenario.cfg.MaxHops != scenario.expectedMaxHops {
+				t.Errorf("expected max-hops to be %d, got %d", scenario.expectedMaxHops, scenario.cfg.MaxHops)
+			}
+			if scenario.cfg.HopTimeout != scenario.expectedHopTimeout {
+				t.Errorf("expected hop-timeout to be %s, got %s", scenario.expectedHopTimeout, scenario.cfg.HopTimeout)
+			}
+		})
+	}
+}
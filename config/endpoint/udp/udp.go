@@ -0,0 +1,94 @@
+package udp
+
+import (
+	"encoding/hex"
+	"errors"
+	"regexp"
+)
+
+const (
+	// EncodingText is the encoding with which the body is sent as-is and the response is used as body as-is
+	EncodingText = "text"
+
+	// EncodingHex is the encoding with which the body is decoded from hexadecimal before being sent, and the response
+	// is encoded in hexadecimal before being used as body, which is useful for binary protocols
+	EncodingHex = "hex"
+)
+
+var (
+	// ErrEndpointWithInvalidUDPEncoding is the error with which Gatus will panic if an endpoint with UDP monitoring is configured with an invalid encoding.
+	ErrEndpointWithInvalidUDPEncoding = errors.New("invalid UDP encoding, must be one of: text, hex")
+
+	// ErrEndpointWithInvalidUDPExpectedResponse is the error with which Gatus will panic if an endpoint with UDP monitoring is configured with an expected response that isn't a valid regular expression.
+	ErrEndpointWithInvalidUDPExpectedResponse = errors.New("invalid UDP expected-response, must be a valid regular expression")
+
+	// ErrEndpointWithInvalidUDPHexBody is the error with which Gatus will panic if an endpoint with UDP monitoring using the hex encoding is configured with a body that isn't valid hexadecimal.
+	ErrEndpointWithInvalidUDPHexBody = errors.New("invalid body for UDP endpoint with hex encoding, must be valid hexadecimal")
+
+	// ErrEndpointWithUDPExpectedResponseWithoutBody is the error with which Gatus will panic if an endpoint with UDP monitoring is configured with an expected response, but no body to send.
+	ErrEndpointWithUDPExpectedResponseWithoutBody = errors.New("UDP expected-response requires a body to be sent")
+)
+
+type Config struct {
+	// Encoding is the encoding of the body and of the response (text or hex)
+	Encoding string `yaml:"encoding,omitempty"`
+
+	// ExpectedResponse is a regular expression that the response must match. If the encoding is hex, the regular
+	// expression is matched against the hexadecimal representation of the response.
+	ExpectedResponse string `yaml:"expected-response,omitempty"`
+
+	expectedResponseRegex *regexp.Regexp
+}
+
+// ValidateAndSetDefaults validates the UDP configuration and sets the default values if necessary
+func (cfg *Config) ValidateAndSetDefaults(body string) error {
+	if len(cfg.Encoding) == 0 {
+		cfg.Encoding = EncodingText
+	}
+	if cfg.Encoding != EncodingText && cfg.Encoding != EncodingHex {
+		return ErrEndpointWithInvalidUDPEncoding
+	}
+	if _, err := cfg.Payload(body); err != nil {
+		return err
+	}
+	if len(cfg.ExpectedResponse) > 0 {
+		if len(body) == 0 {
+			return ErrEndpointWithUDPExpectedResponseWithoutBody
+		}
+		regex, err := regexp.Compile(cfg.ExpectedResponse)
+		if err != nil {
+			return ErrEndpointWithInvalidUDPExpectedResponse
+		}
+		cfg.expectedResponseRegex = regex
+	}
+	return nil
+}
+
+// Payload returns the bytes to send given the body of the endpoint
+func (cfg *Config) Payload(body string) ([]byte, error) {
+	if cfg.Encoding != EncodingHex {
+		return []byte(body), nil
+	}
+	payload, err := hex.DecodeString(body)
+	if err != nil {
+		return nil, ErrEndpointWithInvalidUDPHexBody
+	}
+	return payload, nil
+}
+
+// EncodeResponse returns the body to use for conditions given the response received
+func (cfg *Config) EncodeResponse(response []byte) []byte {
+	if cfg.Encoding != EncodingHex {
+		return response
+	}
+	return []byte(hex.EncodeToString(response))
+}
+
+// MatchesExpectedResponse returns whether the encoded response passed as parameter matches the expected response.
+// If no expected response is configured, any response matches.
+func (cfg *Config) MatchesExpectedResponse(body []byte) bool {
+	if cfg.expectedResponseRegex == nil {
+		return true
+	}
+	return cfg.expectedResponseRegex.Match(body)
+}
@@ -0,0 +1,93 @@
+package udp
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name             string
+		cfg              *Config
+		body             string
+		expectedErr      error
+		expectedEncoding string
+	}{
+		{
+			name:             "defaults",
+			cfg:              &Config{},
+			expectedEncoding: EncodingText,
+		},
+		{
+			name:             "text-with-expected-response",
+			cfg:              &Config{ExpectedResponse: "^pong"},
+			body:             "ping",
+			expectedEncoding: EncodingText,
+		},
+		{
+			name:             "hex",
+			cfg:              &Config{Encoding: EncodingHex},
+			body:             "ffffffff54",
+			expectedEncoding: EncodingHex,
+		},
+		{
+			name:             "invalid-encoding",
+			cfg:              &Config{Encoding: "base64"},
+			expectedErr:      ErrEndpointWithInvalidUDPEncoding,
+			expectedEncoding: "base64",
+		},
+		{
+			name:             "hex-with-invalid-body",
+			cfg:              &Config{Encoding: EncodingHex},
+			body:             "not-hex",
+			expectedErr:      ErrEndpointWithInvalidUDPHexBody,
+			expectedEncoding: EncodingHex,
+		},
+		{
+			name:             "invalid-expected-response",
+			cfg:              &Config{ExpectedResponse: "(pong"},
+			body:             "ping",
+			expectedErr:      ErrEndpointWithInvalidUDPExpectedResponse,
+			expectedEncoding: EncodingText,
+		},
+		{
+			name:             "expected-response-without-body",
+			cfg:              &Config{ExpectedResponse: "pong"},
+			expectedErr:      ErrEndpointWithUDPExpectedResponseWithoutBody,
+			expectedEncoding: EncodingText,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.ValidateAndSetDefaults(scenario.body); !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected error to be '%v', got '%v'", scenario.expectedErr, err)
+			}
+			if scenario.cfg.Encoding != scenario.expectedEncoding {
+				t.Errorf("expected encoding to be %s, got %s", scenario.expectedEncoding, scenario.cfg.Encoding)
+			}
+		})
+	}
+}
+
+func TestConfig_EncodeResponseAndMatchesExpectedResponse(t *testing.T) {
+	cfg := &Config{Encoding: EncodingHex, ExpectedResponse: "^ffffffff49"}
+	if err := cfg.ValidateAndSetDefaults("ffffffff54"); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if payload, _ := cfg.Payload("ffffffff54"); string(payload) != "\xff\xff\xff\xffT" {
+		t.Errorf("expected payload to be decoded from hexadecimal, got %q", payload)
+	}
+	body := cfg.EncodeResponse([]byte("\xff\xff\xff\xffI\x11"))
+	if string(body) != "ffffffff4911" {
+		t.Errorf("expected body to be encoded in hexadecimal, got %s", body)
+	}
+	if !cfg.MatchesExpectedResponse(body) {
+		t.Error("expected body to match the expected response")
+	}
+	if cfg.MatchesExpectedResponse([]byte("ffffffff6c")) {
+		t.Error("expected body not to match the expected response")
+	}
+	if !(&Config{}).MatchesExpectedResponse([]byte("anything")) {
+		t.Error("expected any body to match when no expected response is configured")
+	}
+}
@@ -1,6 +1,9 @@
 package ui
 
-import "errors"
+import (
+	"errors"
+	"regexp"
+)
 
 // Config is the UI configuration for endpoint.Endpoint
 type Config struct {
@@ -22,26 +25,75 @@ type Config struct {
 
 type Badge struct {
 	ResponseTime *ResponseTime `yaml:"response-time"`
+	Uptime       *Uptime       `yaml:"uptime"`
+	SLA          *SLA          `yaml:"sla"`
+
+	// Style is the style of the SVG badges. Defaults to BadgeStyleFlat.
+	Style string `yaml:"style,omitempty"`
+
+	// Colors are the 6 colors of the badges, from the best level to the worst. Defaults to the colors of Gatus.
+	Colors []string `yaml:"colors,omitempty"`
 }
 
 type ResponseTime struct {
 	Thresholds []int `yaml:"thresholds"`
 }
 
+// Uptime is the configuration for the uptime badge
+type Uptime struct {
+	// Thresholds are the 5 uptime percentages, in descending order, at which the badge changes color
+	Thresholds []float64 `yaml:"thresholds"`
+}
+
+// SLA is the configuration for the SLA badge
+type SLA struct {
+	// Target is the uptime percentage that the endpoint must reach for the SLA to be met, e.g. 99.9
+	Target float64 `yaml:"target"`
+}
+
+// Styles of the SVG badges
+const (
+	BadgeStyleFlat        = "flat"
+	BadgeStyleFlatSquare  = "flat-square"
+	BadgeStyleForTheBadge = "for-the-badge"
+)
+
 var (
 	ErrInvalidBadgeResponseTimeConfig = errors.New("invalid response time badge configuration: expected parameter 'response-time' to have 5 ascending numerical values")
+	ErrInvalidBadgeUptimeConfig       = errors.New("invalid uptime badge configuration: expected parameter 'thresholds' to have 5 descending percentages")
+	ErrInvalidBadgeSLAConfig          = errors.New("invalid SLA badge configuration: expected parameter 'target' to be a percentage greater than 0 and lower than or equal to 100")
+	ErrInvalidBadgeStyle              = errors.New("invalid badge style: expected one of 'flat', 'flat-square' or 'for-the-badge'")
+	ErrInvalidBadgeColorsConfig       = errors.New("invalid badge colors: expected 6 hexadecimal colors, e.g. '#40cc11'")
+
+	hexColorRegex = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
 )
 
 // ValidateAndSetDefaults validates the UI configuration and sets the default values
 func (config *Config) ValidateAndSetDefaults() error {
 	if config.Badge != nil {
-		if len(config.Badge.ResponseTime.Thresholds) != 5 {
-			return ErrInvalidBadgeResponseTimeConfig
+		if config.Badge.ResponseTime == nil {
+			config.Badge.ResponseTime = GetDefaultConfig().Badge.ResponseTime
 		}
-		for i := 4; i > 0; i-- {
-			if config.Badge.ResponseTime.Thresholds[i] < config.Badge.ResponseTime.Thresholds[i-1] {
-				return ErrInvalidBadgeResponseTimeConfig
-			}
+		if config.Badge.Uptime == nil {
+			config.Badge.Uptime = GetDefaultConfig().Badge.Uptime
+		}
+		if config.Badge.SLA == nil {
+			config.Badge.SLA = GetDefaultConfig().Badge.SLA
+		}
+		if err := config.Badge.ResponseTime.Validate(); err != nil {
+			return err
+		}
+		if err := config.Badge.Uptime.Validate(); err != nil {
+			return err
+		}
+		if err := config.Badge.SLA.Validate(); err != nil {
+			return err
+		}
+		if len(config.Badge.Style) > 0 && !IsValidBadgeStyle(config.Badge.Style) {
+			return ErrInvalidBadgeStyle
+		}
+		if len(config.Badge.Colors) > 0 && !AreValidBadgeColors(config.Badge.Colors) {
+			return ErrInvalidBadgeColorsConfig
 		}
 	} else {
 		config.Badge = GetDefaultConfig().Badge
@@ -49,6 +101,58 @@ func (config *Config) ValidateAndSetDefaults() error {
 	return nil
 }
 
+// Validate checks that there are 5 thresholds in ascending order
+func (responseTime *ResponseTime) Validate() error {
+	if len(responseTime.Thresholds) != 5 {
+		return ErrInvalidBadgeResponseTimeConfig
+	}
+	for i := 4; i > 0; i-- {
+		if responseTime.Thresholds[i] < responseTime.Thresholds[i-1] {
+			return ErrInvalidBadgeResponseTimeConfig
+		}
+	}
+	return nil
+}
+
+// Validate checks that there are 5 thresholds in descending order, all of which are between 0 and 100
+func (uptime *Uptime) Validate() error {
+	if len(uptime.Thresholds) != 5 {
+		return ErrInvalidBadgeUptimeConfig
+	}
+	for i, threshold := range uptime.Thresholds {
+		if threshold < 0 || threshold > 100 || (i > 0 && threshold > uptime.Thresholds[i-1]) {
+			return ErrInvalidBadgeUptimeConfig
+		}
+	}
+	return nil
+}
+
+// Validate checks that the target is a percentage greater than 0
+func (sla *SLA) Validate() error {
+	if sla.Target <= 0 || sla.Target > 100 {
+		return ErrInvalidBadgeSLAConfig
+	}
+	return nil
+}
+
+// IsValidBadgeStyle returns whether the style passed is one of the supported styles of the SVG badges
+func IsValidBadgeStyle(style string) bool {
+	return style == BadgeStyleFlat || style == BadgeStyleFlatSquare || style == BadgeStyleForTheBadge
+}
+
+// AreValidBadgeColors returns whether the colors passed are 6 hexadecimal colors
+func AreValidBadgeColors(colors []string) bool {
+	if len(colors) != 6 {
+		return false
+	}
+	for _, color := range colors {
+		if !hexColorRegex.MatchString(color) {
+			return false
+		}
+	}
+	return true
+}
+
 // GetDefaultConfig retrieves the default UI configuration
 func GetDefaultConfig() *Config {
 	return &Config{
@@ -60,6 +164,12 @@ func GetDefaultConfig() *Config {
 			ResponseTime: &ResponseTime{
 				Thresholds: []int{50, 200, 300, 500, 750},
 			},
+			Uptime: &Uptime{
+				Thresholds: []float64{97.5, 95, 90, 80, 65},
+			},
+			SLA: &SLA{
+				Target: 99.9,
+			},
 		},
 	}
 }
@@ -36,6 +36,73 @@ func TestValidateAndSetDefaults(t *testing.T) {
 			},
 			wantErr: ErrInvalidBadgeResponseTimeConfig,
 		},
+		{
+			name: "with-valid-sla-target",
+			config: &Config{
+				Badge: &Badge{SLA: &SLA{Target: 99.95}},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "with-invalid-sla-target",
+			config: &Config{
+				Badge: &Badge{SLA: &SLA{Target: 150}},
+			},
+			wantErr: ErrInvalidBadgeSLAConfig,
+		},
+		{
+			name: "with-zero-sla-target",
+			config: &Config{
+				Badge: &Badge{SLA: &SLA{}},
+			},
+			wantErr: ErrInvalidBadgeSLAConfig,
+		},
+		{
+			name: "with-valid-uptime-thresholds-style-and-colors",
+			config: &Config{
+				Badge: &Badge{
+					Uptime: &Uptime{Thresholds: []float64{99.9, 99, 98, 95, 90}},
+					Style:  BadgeStyleForTheBadge,
+					Colors: []string{"#000", "#111", "#222", "#333", "#444", "#555555"},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "with-invalid-uptime-thresholds-order",
+			config: &Config{
+				Badge: &Badge{Uptime: &Uptime{Thresholds: []float64{90, 95, 98, 99, 99.9}}},
+			},
+			wantErr: ErrInvalidBadgeUptimeConfig,
+		},
+		{
+			name: "with-invalid-uptime-threshold",
+			config: &Config{
+				Badge: &Badge{Uptime: &Uptime{Thresholds: []float64{200, 99, 98, 95, 90}}},
+			},
+			wantErr: ErrInvalidBadgeUptimeConfig,
+		},
+		{
+			name: "with-invalid-style",
+			config: &Config{
+				Badge: &Badge{Style: "plastic"},
+			},
+			wantErr: ErrInvalidBadgeStyle,
+		},
+		{
+			name: "with-invalid-colors",
+			config: &Config{
+				Badge: &Badge{Colors: []string{"green", "#111", "#222", "#333", "#444", "#555"}},
+			},
+			wantErr: ErrInvalidBadgeColorsConfig,
+		},
+		{
+			name: "with-invalid-number-of-colors",
+			config: &Config{
+				Badge: &Badge{Colors: []string{"#000"}},
+			},
+			wantErr: ErrInvalidBadgeColorsConfig,
+		},
 		{
 			name:    "with-no-badge-configured", // should give default badge cfg
 			config:  &Config{},
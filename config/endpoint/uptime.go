@@ -1,5 +1,9 @@
 package endpoint
 
+import (
+	"time"
+)
+
 // Uptime is the struct that contains the relevant data for calculating the uptime as well as the uptime itself
 // and some other statistics
 type Uptime struct {
@@ -7,9 +11,34 @@ type Uptime struct {
 	//
 	// Used only if the storage type is memory
 	HourlyStatistics map[int64]*HourlyUptimeStatistics `json:"-"`
+
+	// DailyStatistics is a map containing metrics collected (value) for every daily unix timestamps (key), which are
+	// kept much longer than the hourly statistics so that the uptime over long durations can be computed cheaply
+	//
+	// Used only if the storage type is memory
+	DailyStatistics map[int64]*HourlyUptimeStatistics `json:"-"`
+}
+
+// HourlyStatisticsByDay returns the hourly statistics summed up for every daily unix timestamp (key), with days
+// starting at midnight UTC
+func (uptime *Uptime) HourlyStatisticsByDay() map[int64]*HourlyUptimeStatistics {
+	dailyStatistics := make(map[int64]*HourlyUptimeStatistics)
+	for hourlyUnixTimestamp, hourlyStatistics := range uptime.HourlyStatistics {
+		dailyUnixTimestamp := hourlyUnixTimestamp - hourlyUnixTimestamp%int64((24*time.Hour).Seconds())
+		statistics, exists := dailyStatistics[dailyUnixTimestamp]
+		if !exists {
+			statistics = &HourlyUptimeStatistics{}
+			dailyStatistics[dailyUnixTimestamp] = statistics
+		}
+		statistics.TotalExecutions += hourlyStatistics.TotalExecutions
+		statistics.SuccessfulExecutions += hourlyStatistics.SuccessfulExecutions
+		statistics.TotalExecutionsResponseTime += hourlyStatistics.TotalExecutionsResponseTime
+	}
+	return dailyStatistics
 }
 
-// HourlyUptimeStatistics is a struct containing all metrics collected over the course of an hour
+// HourlyUptimeStatistics is a struct containing all metrics collected over the course of an hour, or of a day for the
+// daily statistics
 type HourlyUptimeStatistics struct {
 	TotalExecutions             uint64 // Total number of checks
 	SuccessfulExecutions        uint64 // Number of successful executions
@@ -20,5 +49,6 @@ type HourlyUptimeStatistics struct {
 func NewUptime() *Uptime {
 	return &Uptime{
 		HourlyStatistics: make(map[int64]*HourlyUptimeStatistics),
+		DailyStatistics:  make(map[int64]*HourlyUptimeStatistics),
 	}
 }
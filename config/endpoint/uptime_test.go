@@ -0,0 +1,22 @@
+package endpoint
+
+import (
+	"testing"
+)
+
+func TestUptime_HourlyStatisticsByDay(t *testing.T) {
+	uptime := NewUptime()
+	uptime.HourlyStatistics[86400] = &HourlyUptimeStatistics{TotalExecutions: 2, SuccessfulExecutions: 1, TotalExecutionsResponseTime: 100}
+	uptime.HourlyStatistics[86400+23*3600] = &HourlyUptimeStatistics{TotalExecutions: 3, SuccessfulExecutions: 3, TotalExecutionsResponseTime: 50}
+	uptime.HourlyStatistics[2*86400] = &HourlyUptimeStatistics{TotalExecutions: 1, SuccessfulExecutions: 0, TotalExecutionsResponseTime: 10}
+	dailyStatistics := uptime.HourlyStatisticsByDay()
+	if len(dailyStatistics) != 2 {
+		t.Fatalf("expected 2 days, got %d", len(dailyStatistics))
+	}
+	if statistics := dailyStatistics[86400]; statistics.TotalExecutions != 5 || statistics.SuccessfulExecutions != 4 || statistics.TotalExecutionsResponseTime != 150 {
+		t.Errorf("expected the statistics of the hours of the first day to be summed up, got %+v", statistics)
+	}
+	if statistics := dailyStatistics[2*86400]; statistics.TotalExecutions != 1 || statistics.SuccessfulExecutions != 0 || statistics.TotalExecutionsResponseTime != 10 {
+		t.Errorf("expected the statistics of the second day to be those of its only hour, got %+v", statistics)
+	}
+}
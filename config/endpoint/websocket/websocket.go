@@ -0,0 +1,36 @@
+package websocket
+
+import (
+	"errors"
+)
+
+var (
+	// ErrEndpointWithBodyAndWebSocketMessages is the error with which Gatus will panic if an endpoint with WebSocket monitoring is configured with both a body and messages.
+	ErrEndpointWithBodyAndWebSocketMessages = errors.New("cannot use both body and websocket messages, use one or the other")
+
+	// ErrEndpointWithEmptyWebSocketSubprotocol is the error with which Gatus will panic if an endpoint with WebSocket monitoring is configured with an empty subprotocol.
+	ErrEndpointWithEmptyWebSocketSubprotocol = errors.New("websocket subprotocols cannot be empty")
+)
+
+type Config struct {
+	// Subprotocols is the list of subprotocols to offer to the server during the handshake, in order of preference
+	Subprotocols []string `yaml:"subprotocols,omitempty"`
+
+	// Messages is the sequence of messages to send, each of which is followed by reading a response.
+	// An empty message means that nothing is sent before reading the response, which is useful for servers that send
+	// a message upon connecting.
+	Messages []string `yaml:"messages,omitempty"`
+}
+
+// Validate validates the WebSocket configuration
+func (cfg *Config) Validate(body string) error {
+	if len(body) > 0 && len(cfg.Messages) > 0 {
+		return ErrEndpointWithBodyAndWebSocketMessages
+	}
+	for _, subprotocol := range cfg.Subprotocols {
+		if len(subprotocol) == 0 {
+			return ErrEndpointWithEmptyWebSocketSubprotocol
+		}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package websocket
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		cfg         *Config
+		body        string
+		expectedErr error
+	}{
+		{
+			name: "empty",
+			cfg:  &Config{},
+		},
+		{
+			name: "body-with-subprotocols",
+			cfg:  &Config{Subprotocols: []string{"graphql-transport-ws"}},
+			body: "status",
+		},
+		{
+			name: "messages",
+			cfg:  &Config{Subprotocols: []string{"v2", "v1"}, Messages: []string{"", "ping"}},
+		},
+		{
+			name:        "body-and-messages",
+			cfg:         &Config{Messages: []string{"ping"}},
+			body:        "status",
+			expectedErr: ErrEndpointWithBodyAndWebSocketMessages,
+		},
+		{
+			name:        "empty-subprotocol",
+			cfg:         &Config{Subprotocols: []string{""}},
+			expectedErr: ErrEndpointWithEmptyWebSocketSubprotocol,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.Validate(scenario.body); !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected error to be '%v', got '%v'", scenario.expectedErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package group
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/TwiN/gatus/v5/config/ui"
+	"github.com/TwiN/gatus/v5/security"
+)
+
+var (
+	ErrMissingName       = errors.New("groups[].name must be set")
+	ErrInvalidLogo       = errors.New("groups[].logo must be an http or https URL, or a path starting with /")
+	ErrEmptyVisibility   = errors.New("groups[].visible-to must have at least one user, group or role")
+	ErrInvalidVisibility = errors.New("groups[].visible-to.roles must be viewer, operator or admin")
+)
+
+// Group is the metadata of a group of endpoints, which is served by the API so that the dashboard, as well as any
+// other frontend, can render the group with its branding
+type Group struct {
+	// Name is the name of the group, as set through endpoints[].group
+	Name string `yaml:"name"`
+
+	// Description of the group, displayed below its name
+	Description string `yaml:"description,omitempty"`
+
+	// Logo is the URL of the image displayed next to the name of the group
+	Logo string `yaml:"logo,omitempty"`
+
+	// Links are the external links of the group, such as its documentation or its runbook
+	Links []ui.Button `yaml:"links,omitempty"`
+
+	// Order is the position of the group on the dashboard, from lowest to highest. The groups with the same order are
+	// displayed in the order they are configured in, and the groups that aren't configured are displayed last.
+	Order int `yaml:"order,omitempty"`
+
+	// VisibleTo restricts who can see the group and its endpoints, on the dashboard as well as through the API. If
+	// nil, the group is visible to everyone allowed to see the dashboard.
+	VisibleTo *Visibility `yaml:"visible-to,omitempty"`
+}
+
+// Visibility is who a group is visible to: a user must be one of the users, be in one of the groups, or have one of
+// the roles, of the visibility
+type Visibility struct {
+	// Users are the users, identified by their username for Basic and LDAP, or by their subject for OIDC and SAML
+	Users []string `yaml:"users,omitempty"`
+
+	// Groups are the groups of the users, as provided by the identity provider through the groups claim of OIDC or the
+	// groups attribute of SAML
+	Groups []string `yaml:"groups,omitempty"`
+
+	// Roles are the roles of the users. A user whose role includes one of them, e.g. an admin for operator, is allowed.
+	Roles []security.Role `yaml:"roles,omitempty"`
+}
+
+// IsVisibleTo returns whether the user passed as parameter is allowed to see the group. Nobody is allowed if the user is
+// nil, which is the case of the requests that aren't authenticated.
+func (v *Visibility) IsVisibleTo(user *security.User) bool {
+	if user == nil {
+		return false
+	}
+	for _, u := range v.Users {
+		if strings.EqualFold(u, user.Subject) {
+			return true
+		}
+	}
+	for _, g := range v.Groups {
+		if slices.Contains(user.Groups, g) {
+			return true
+		}
+	}
+	for _, role := range v.Roles {
+		if user.Role.Includes(role) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateAndSetDefaults validates the group configuration
+func (g *Group) ValidateAndSetDefaults() error {
+	if len(g.Name) == 0 {
+		return ErrMissingName
+	}
+	if len(g.Logo) > 0 && !isValidLogo(g.Logo) {
+		return ErrInvalidLogo
+	}
+	for _, link := range g.Links {
+		if err := link.Validate(); err != nil {
+			return fmt.Errorf("invalid link of group %s: %w", g.Name, err)
+		}
+	}
+	if g.VisibleTo != nil {
+		if len(g.VisibleTo.Users) == 0 && len(g.VisibleTo.Groups) == 0 && len(g.VisibleTo.Roles) == 0 {
+			return fmt.Errorf("invalid visibility of group %s: %w", g.Name, ErrEmptyVisibility)
+		}
+		for _, role := range g.VisibleTo.Roles {
+			if !role.IsValid() {
+				return fmt.Errorf("invalid visibility of group %s: %w", g.Name, ErrInvalidVisibility)
+			}
+		}
+	}
+	return nil
+}
+
+// isValidLogo returns whether the logo passed as parameter is an absolute http or https URL, or an absolute path on the
+// host serving Gatus
+func isValidLogo(logo string) bool {
+	logoURL, err := url.Parse(logo)
+	if err != nil {
+		return false
+	}
+	if logoURL.Scheme == "http" || logoURL.Scheme == "https" {
+		return len(logoURL.Host) > 0
+	}
+	return len(logoURL.Scheme) == 0 && len(logoURL.Host) == 0 && strings.HasPrefix(logoURL.Path, "/")
+}
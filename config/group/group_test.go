@@ -0,0 +1,60 @@
+package group
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/config/ui"
+	"github.com/TwiN/gatus/v5/security"
+)
+
+func TestGroup_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		group       *Group
+		expectedErr error
+	}{
+		{name: "missing-name", group: &Group{}, expectedErr: ErrMissingName},
+		{name: "name-only", group: &Group{Name: "core"}},
+		{name: "https-logo", group: &Group{Name: "core", Logo: "https://example.org/logo.png"}},
+		{name: "path-logo", group: &Group{Name: "core", Logo: "/img/logo.png"}},
+		{name: "relative-logo", group: &Group{Name: "core", Logo: "logo.png"}, expectedErr: ErrInvalidLogo},
+		{name: "javascript-logo", group: &Group{Name: "core", Logo: "javascript:alert(1)"}, expectedErr: ErrInvalidLogo},
+		{name: "protocol-relative-logo", group: &Group{Name: "core", Logo: "//example.org/logo.png"}, expectedErr: ErrInvalidLogo},
+		{name: "link", group: &Group{Name: "core", Links: []ui.Button{{Name: "Runbook", Link: "https://example.org/runbook"}}}},
+		{name: "link-without-name", group: &Group{Name: "core", Links: []ui.Button{{Link: "https://example.org/runbook"}}}, expectedErr: ui.ErrButtonValidationFailed},
+		{name: "visible-to-role", group: &Group{Name: "core", VisibleTo: &Visibility{Roles: []security.Role{security.RoleOperator}}}},
+		{name: "visible-to-nobody", group: &Group{Name: "core", VisibleTo: &Visibility{}}, expectedErr: ErrEmptyVisibility},
+		{name: "visible-to-invalid-role", group: &Group{Name: "core", VisibleTo: &Visibility{Roles: []security.Role{"superuser"}}}, expectedErr: ErrInvalidVisibility},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.group.ValidateAndSetDefaults(); !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestVisibility_IsVisibleTo(t *testing.T) {
+	visibility := &Visibility{Users: []string{"john.doe"}, Groups: []string{"sre"}, Roles: []security.Role{security.RoleOperator}}
+	scenarios := []struct {
+		name     string
+		user     *security.User
+		expected bool
+	}{
+		{name: "anonymous", user: nil, expected: false},
+		{name: "user", user: &security.User{Subject: "John.Doe", Role: security.RoleViewer}, expected: true},
+		{name: "group", user: &security.User{Subject: "jane.doe", Groups: []string{"dev", "sre"}, Role: security.RoleViewer}, expected: true},
+		{name: "role", user: &security.User{Subject: "jane.doe", Role: security.RoleOperator}, expected: true},
+		{name: "higher-role", user: &security.User{Subject: "jane.doe", Role: security.RoleAdmin}, expected: true},
+		{name: "none", user: &security.User{Subject: "jane.doe", Groups: []string{"dev"}, Role: security.RoleViewer}, expected: false},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if actual := visibility.IsVisibleTo(scenario.user); actual != scenario.expected {
+				t.Errorf("expected %v, got %v", scenario.expected, actual)
+			}
+		})
+	}
+}
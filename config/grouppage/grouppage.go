@@ -0,0 +1,48 @@
+package grouppage
+
+import (
+	"errors"
+
+	"github.com/TwiN/gatus/v5/config/ui"
+)
+
+var (
+	ErrMissingGroup = errors.New("group-pages[].group must be set")
+)
+
+// GroupPage is the configuration of a dashboard served at /groups/{group}, which only shows the endpoints of a group
+type GroupPage struct {
+	// Group is the group of the endpoints shown by the page
+	Group string `yaml:"group"`
+
+	// Title of the page. Defaults to ui.title
+	Title string `yaml:"title,omitempty"`
+
+	// Header at the top of the page. Defaults to ui.header
+	Header string `yaml:"header,omitempty"`
+
+	// Public is whether the page and the statuses it shows can be accessed without being authenticated, even if
+	// security is configured
+	Public bool `yaml:"public,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the group page configuration
+func (p *GroupPage) ValidateAndSetDefaults() error {
+	if len(p.Group) == 0 {
+		return ErrMissingGroup
+	}
+	return nil
+}
+
+// UI returns the UI configuration of the page, which is the one passed as parameter with the title and the header
+// of the page, if they are set
+func (p *GroupPage) UI(uiConfig *ui.Config) *ui.Config {
+	pageUI := *uiConfig
+	if len(p.Title) > 0 {
+		pageUI.Title = p.Title
+	}
+	if len(p.Header) > 0 {
+		pageUI.Header = p.Header
+	}
+	return &pageUI
+}
@@ -0,0 +1,31 @@
+package grouppage
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/config/ui"
+)
+
+func TestGroupPage_ValidateAndSetDefaults(t *testing.T) {
+	if err := (&GroupPage{}).ValidateAndSetDefaults(); !errors.Is(err, ErrMissingGroup) {
+		t.Errorf("expected %v, got %v", ErrMissingGroup, err)
+	}
+	if err := (&GroupPage{Group: "core"}).ValidateAndSetDefaults(); err != nil {
+		t.Error("expected no error, got", err.Error())
+	}
+}
+
+func TestGroupPage_UI(t *testing.T) {
+	uiConfig := ui.GetDefaultConfig()
+	pageUI := (&GroupPage{Group: "core", Header: "Core Status"}).UI(uiConfig)
+	if pageUI.Header != "Core Status" {
+		t.Errorf("expected the header of the page, got %s", pageUI.Header)
+	}
+	if pageUI.Title != uiConfig.Title {
+		t.Errorf("expected the title to default to the one of the UI, got %s", pageUI.Title)
+	}
+	if uiConfig.Header == "Core Status" {
+		t.Error("the UI configuration passed as parameter should not have been modified")
+	}
+}
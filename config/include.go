@@ -0,0 +1,366 @@
+package config
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/TwiN/deepmerge"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// includeKey is the key of the parameter of a configuration file listing the files it includes
+	includeKey = "include"
+
+	// DefaultRemoteIncludeInterval is the default interval at which the configuration files included from a URL are
+	// fetched again to find out whether they have been modified
+	DefaultRemoteIncludeInterval = time.Minute
+
+	remoteIncludeRequestTimeout = 10 * time.Second
+)
+
+var (
+	// ErrIncludeCycle is an error returned when a configuration file includes itself, directly or indirectly
+	ErrIncludeCycle = errors.New("configuration file cannot include itself, directly or indirectly")
+
+	// ErrInvalidInclude is an error returned when the files included by a configuration file are invalid
+	ErrInvalidInclude = errors.New("invalid include")
+
+	// errInvalidIncludeEntry is an error returned when an entry of the include parameter is neither a path, a glob
+	// pattern nor a URL
+	errInvalidIncludeEntry = errors.New("include must be a path, a glob pattern, a URL or a list of them")
+)
+
+// includes keeps track of the files included while loading the configuration, so that modifying any of them, or
+// creating a file matching one of the patterns they were included through, causes the configuration to be reloaded
+type includes struct {
+	patterns []string
+	files    []string
+	remotes  []*remoteInclude
+}
+
+// includeEntry is an entry of the include parameter of a configuration file, which is either the pattern of the files
+// to include or a file to include from a URL
+type includeEntry struct {
+	pattern string
+	remote  *remoteInclude
+}
+
+// remoteInclude is a configuration file included from an HTTP(S) URL, e.g. to distribute the same configuration to
+// many instances of Gatus from a central location
+type remoteInclude struct {
+	// URL is the URL of the configuration file
+	URL string `yaml:"url"`
+
+	// Headers are the headers of the request fetching the configuration file, e.g. to authenticate. Their values may
+	// reference environment variables and secrets like the rest of the configuration.
+	Headers map[string]string `yaml:"headers,omitempty"`
+
+	// Interval is the interval at which the configuration file is fetched again to find out whether it has been
+	// modified. Defaults to DefaultRemoteIncludeInterval.
+	Interval time.Duration `yaml:"interval,omitempty"`
+
+	// AllowHTTP is whether the URL may use the http scheme, through which the configuration file could be tampered
+	// with by anyone between Gatus and the server
+	AllowHTTP bool `yaml:"allow-http,omitempty"`
+
+	checksum  [sha256.Size]byte // checksum of the configuration file when it was included
+	fetchedAt time.Time         // time at which the configuration file was last fetched
+}
+
+// readConfigurationFile reads the configuration file at the path passed and merges the files it includes, if any,
+// into it.
+//
+// The files included are merged in the order they're listed in, and the files matching a pattern in lexical order.
+// Maps are deep merged and slices are appended, like for the files of a configuration directory, but parameters with
+// a primitive value may be defined more than once: the including file takes precedence over the files it includes,
+// and each included file takes precedence over the ones included before it. Each file is only included once.
+func (inc *includes) readConfigurationFile(path string, ancestors []string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading configuration from file %s: %w", path, err)
+	}
+	var document map[string]any
+	if err = yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("error parsing configuration from file %s: %w", path, err)
+	}
+	if _, exists := document[includeKey]; !exists {
+		return data, nil
+	}
+	entries, err := getIncludeEntries(document[includeKey])
+	if err != nil {
+		return nil, fmt.Errorf("%w in file %s: %w", ErrInvalidInclude, path, err)
+	}
+	absolutePath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(ancestors, absolutePath) {
+		return nil, fmt.Errorf("%w: %s", ErrIncludeCycle, path)
+	}
+	ancestors = append(ancestors, absolutePath)
+	mergeConfig := deepmerge.Config{PreventMultipleDefinitionsOfKeysWithPrimitiveValue: false}
+	var merged []byte
+	for _, entry := range entries {
+		if entry.remote != nil {
+			if slices.ContainsFunc(inc.remotes, func(remote *remoteInclude) bool { return remote.URL == entry.remote.URL }) {
+				continue
+			}
+			log.Printf("[config.readConfigurationFile] Including configuration from %s in %s", entry.remote.URL, path)
+			includedData, err := entry.remote.fetch()
+			if err != nil {
+				return nil, err
+			}
+			if merged, err = deepmerge.YAML(merged, includedData, mergeConfig); err != nil {
+				return nil, fmt.Errorf("error merging configuration from %s: %w", entry.remote.URL, err)
+			}
+			inc.remotes = append(inc.remotes, entry.remote)
+			continue
+		}
+		pattern := entry.pattern
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(filepath.Dir(absolutePath), pattern)
+		}
+		files, err := resolveIncludePattern(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%w in file %s: %w", ErrInvalidInclude, path, err)
+		}
+		inc.patterns = append(inc.patterns, pattern)
+		for _, file := range files {
+			if slices.Contains(inc.files, file) {
+				// Files matching several patterns, or included by several files, are only merged once
+				continue
+			}
+			log.Printf("[config.readConfigurationFile] Including configuration from %s in %s", file, path)
+			includedData, err := inc.readConfigurationFile(file, ancestors)
+			if err != nil {
+				return nil, err
+			}
+			if merged, err = deepmerge.YAML(merged, includedData, mergeConfig); err != nil {
+				return nil, fmt.Errorf("error merging configuration from file %s: %w", file, err)
+			}
+			inc.files = append(inc.files, file)
+		}
+	}
+	delete(document, includeKey)
+	data, err = yaml.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+	return deepmerge.YAML(merged, data, mergeConfig)
+}
+
+// haveBeenModifiedSince returns whether any of the files included has been modified, or deleted, since the time passed,
+// or whether a file matching one of the patterns they were included through was created. The files included from a
+// URL are only fetched again once their interval has elapsed.
+func (inc *includes) haveBeenModifiedSince(lastModTime int64) bool {
+	for _, remote := range inc.remotes {
+		if remote.hasBeenModified() {
+			return true
+		}
+	}
+	for _, file := range inc.files {
+		fileInfo, err := os.Stat(file)
+		if err != nil || lastModTime < fileInfo.ModTime().Unix() {
+			return true
+		}
+	}
+	for _, pattern := range inc.patterns {
+		files, err := resolveIncludePattern(pattern)
+		if err != nil {
+			return true
+		}
+		for _, file := range files {
+			if !slices.Contains(inc.files, file) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getIncludeEntries returns the entries of the include parameter passed, which is either a single entry or a list of
+// them. Each entry is either the pattern of the files to include, the URL of a file to include, or a map with the URL
+// of a file to include and the parameters of the requests fetching it.
+func getIncludeEntries(value any) ([]includeEntry, error) {
+	values, ok := value.([]any)
+	if !ok {
+		values = []any{value}
+	}
+	var entries []includeEntry
+	for _, value := range values {
+		switch value := value.(type) {
+		case string:
+			if len(value) == 0 {
+				return nil, errInvalidIncludeEntry
+			}
+			if strings.HasPrefix(value, "http://") {
+				return nil, errors.New("remote include must use https://, unless allow-http is set to true")
+			} else if strings.HasPrefix(value, "https://") {
+				entries = append(entries, includeEntry{remote: &remoteInclude{URL: value, Interval: DefaultRemoteIncludeInterval}})
+			} else {
+				entries = append(entries, includeEntry{pattern: value})
+			}
+		case map[string]any:
+			remote, err := newRemoteInclude(value)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, includeEntry{remote: remote})
+		default:
+			return nil, errInvalidIncludeEntry
+		}
+	}
+	return entries, nil
+}
+
+// newRemoteInclude returns the file to include from a URL described by the entry of the include parameter passed
+func newRemoteInclude(entry map[string]any) (*remoteInclude, error) {
+	data, err := yaml.Marshal(entry)
+	if err != nil {
+		return nil, err
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	remote := &remoteInclude{}
+	if err = decoder.Decode(remote); err != nil {
+		return nil, fmt.Errorf("invalid remote include: %w", err)
+	}
+	if strings.HasPrefix(remote.URL, "http://") {
+		if !remote.AllowHTTP {
+			return nil, errors.New("remote include must use https://, unless allow-http is set to true")
+		}
+	} else if !strings.HasPrefix(remote.URL, "https://") {
+		return nil, errors.New("url of remote include must start with https:// or http://")
+	}
+	if remote.Interval < 0 {
+		return nil, errors.New("interval of remote include must be positive")
+	}
+	if remote.Interval == 0 {
+		remote.Interval = DefaultRemoteIncludeInterval
+	}
+	return remote, nil
+}
+
+// fetch returns the configuration file included from the URL, and keeps track of its checksum so that
+// hasBeenModified can find out whether it changes. The configuration file cannot include other files.
+//
+// Since the configuration is expanded once all files have been merged, every $ of the configuration file is escaped,
+// so that it cannot reference the environment variables and the secrets of Gatus, which could otherwise be sent to
+// whoever controls the configuration file, e.g. through the URL of an endpoint.
+func (remote *remoteInclude) fetch() ([]byte, error) {
+	data, err := remote.get()
+	if err != nil {
+		return nil, err
+	}
+	var document map[string]any
+	if err = yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("error parsing configuration from %s: %w", remote.URL, err)
+	}
+	if _, exists := document[includeKey]; exists {
+		return nil, fmt.Errorf("%w in %s: configuration files included from a URL cannot include other files", ErrInvalidInclude, remote.URL)
+	}
+	remote.checksum = sha256.Sum256(data)
+	remote.fetchedAt = time.Now()
+	return bytes.ReplaceAll(data, []byte("$"), []byte("$$")), nil
+}
+
+// hasBeenModified returns whether the configuration file included from the URL has been modified since it was
+// included, fetching it again if its interval has elapsed since it was last fetched. A configuration file that cannot
+// be fetched is considered not to have been modified, so that the configuration keeps being used until it can be.
+func (remote *remoteInclude) hasBeenModified() bool {
+	if time.Since(remote.fetchedAt) < remote.Interval {
+		return false
+	}
+	data, err := remote.get()
+	remote.fetchedAt = time.Now()
+	if err != nil {
+		log.Printf("[config.hasBeenModified] Failed to fetch configuration from %s: %s", remote.URL, err.Error())
+		return false
+	}
+	return sha256.Sum256(data) != remote.checksum
+}
+
+// get returns the body of the response to a GET request on the URL, with the headers
+func (remote *remoteInclude) get() ([]byte, error) {
+	request, err := http.NewRequest(http.MethodGet, remote.URL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range remote.Headers {
+		expandedValue, err := expandValue(value, make(map[string]string))
+		if err != nil {
+			return nil, fmt.Errorf("error expanding header %s of %s: %w", name, remote.URL, err)
+		}
+		request.Header.Set(name, expandedValue)
+	}
+	response, err := (&http.Client{Timeout: remoteIncludeRequestTimeout}).Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("error reading configuration from %s: %w", remote.URL, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error reading configuration from %s: unexpected status code %d", remote.URL, response.StatusCode)
+	}
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading configuration from %s: %w", remote.URL, err)
+	}
+	return data, nil
+}
+
+// resolveIncludePattern returns the configuration files matching the pattern passed, in lexical order. The
+// directories matching the pattern are replaced by the configuration files they contain, like a configuration
+// directory, and the files that aren't *.yaml or *.yml are ignored unless the pattern is the path of the file.
+func resolveIncludePattern(pattern string) ([]string, error) {
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 && !hasMeta(pattern) {
+		return nil, fmt.Errorf("file %s does not exist", pattern)
+	}
+	var files []string
+	for _, match := range matches {
+		fileInfo, err := os.Stat(match)
+		if err != nil {
+			return nil, err
+		}
+		if fileInfo.IsDir() {
+			err = walkConfigDir(match, func(path string, d fs.DirEntry, err error) error {
+				files = append(files, path)
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if ext := filepath.Ext(match); match == pattern || ext == ".yml" || ext == ".yaml" {
+			files = append(files, match)
+		}
+	}
+	return files, nil
+}
+
+// hasMeta returns whether the pattern passed has any of the special characters of glob patterns
+func hasMeta(pattern string) bool {
+	for _, c := range pattern {
+		switch c {
+		case '*', '?', '[':
+			return true
+		}
+	}
+	return false
+}
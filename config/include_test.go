@@ -0,0 +1,249 @@
+package config
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadConfigurationWithIncludes(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"config.yaml": `
+include:
+  - shared/alerting.yaml
+  - teams/*.yaml
+alerting:
+  slack:
+    webhook-url: "https://hooks.slack.com/services/xxx/yyy/override"`,
+		"shared/alerting.yaml": `
+alerting:
+  slack:
+    webhook-url: "https://hooks.slack.com/services/xxx/yyy/zzz"
+    default-alert:
+      failure-threshold: 5`,
+		"teams/backend.yaml": `
+endpoints:
+  - name: api
+    url: https://example.org/api
+    alerts:
+      - type: slack
+    conditions:
+      - "[STATUS] == 200"`,
+		"teams/frontend.yaml": `
+include: ../shared/alerting.yaml
+endpoints:
+  - name: website
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"`,
+		"teams/notes.txt": "not a configuration file",
+	}
+	for path, content := range files {
+		_ = os.MkdirAll(filepath.Dir(filepath.Join(dir, path)), 0755)
+		if err := os.WriteFile(filepath.Join(dir, path), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+	config, err := LoadConfiguration(filepath.Join(dir, "config.yaml"))
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if len(config.Endpoints) != 2 || config.Endpoints[0].Name != "api" || config.Endpoints[1].Name != "website" {
+		t.Fatalf("expected the endpoints of the included files in lexical order, got %+v", config.Endpoints)
+	}
+	if config.Alerting.Slack.WebhookURL != "https://hooks.slack.com/services/xxx/yyy/override" {
+		t.Errorf("expected the including file to take precedence, got %s", config.Alerting.Slack.WebhookURL)
+	}
+	if config.Alerting.Slack.DefaultAlert.FailureThreshold != 5 {
+		t.Errorf("expected the default alert of the included file, got %d", config.Alerting.Slack.DefaultAlert.FailureThreshold)
+	}
+	if config.Endpoints[0].Alerts[0].FailureThreshold != 5 {
+		t.Errorf("expected the alert to inherit the default alert of the included file, got %d", config.Endpoints[0].Alerts[0].FailureThreshold)
+	}
+	if config.HasLoadedConfigurationBeenModified() {
+		t.Error("expected config.HasLoadedConfigurationBeenModified() to return false because nothing has happened since it was loaded")
+	}
+	time.Sleep(time.Second) // Because the file mod time only has second precision, we have to wait for a second
+	if err = os.WriteFile(filepath.Join(dir, "teams", "storage.yaml"), []byte(`endpoints: []`), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if !config.HasLoadedConfigurationBeenModified() {
+		t.Error("expected config.HasLoadedConfigurationBeenModified() to return true because a file matching an include pattern has been created")
+	}
+}
+
+func TestLoadConfigurationWithIncludedDirectory(t *testing.T) {
+	dir := t.TempDir()
+	_ = os.MkdirAll(filepath.Join(dir, "endpoints", "team-a"), 0755)
+	_ = os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(`include: endpoints`), 0644)
+	_ = os.WriteFile(filepath.Join(dir, "endpoints", "team-a", "endpoints.yml"), []byte(`
+endpoints:
+  - name: website
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"`), 0644)
+	config, err := LoadConfiguration(filepath.Join(dir, "config.yaml"))
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if len(config.Endpoints) != 1 || config.Endpoints[0].Name != "website" {
+		t.Errorf("expected the endpoint of the included directory, got %+v", config.Endpoints)
+	}
+	time.Sleep(time.Second) // Because the file mod time only has second precision, we have to wait for a second
+	_ = os.Remove(filepath.Join(dir, "endpoints", "team-a", "endpoints.yml"))
+	if !config.HasLoadedConfigurationBeenModified() {
+		t.Error("expected config.HasLoadedConfigurationBeenModified() to return true because an included file has been deleted")
+	}
+}
+
+func TestLoadConfigurationWithRemoteIncludes(t *testing.T) {
+	t.Setenv("GATUS_TEST_CONFIG_TOKEN", "token")
+	endpoints := `
+endpoints:
+  - name: api
+    url: https://example.org/api
+    conditions:
+      - "[STATUS] == 200"`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/endpoints.yaml":
+			if r.Header.Get("Authorization") != "Bearer token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(endpoints))
+		case "/ui.yaml":
+			_, _ = w.Write([]byte(`
+ui:
+  title: Central
+  description: ${GATUS_TEST_CONFIG_TOKEN} costs $$5`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	dir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(`
+include:
+  - url: `+server.URL+`/endpoints.yaml
+    headers:
+      Authorization: "Bearer ${GATUS_TEST_CONFIG_TOKEN}"
+    interval: 1ms
+    allow-http: true
+  - url: `+server.URL+`/ui.yaml
+    allow-http: true`), 0644)
+	config, err := LoadConfiguration(filepath.Join(dir, "config.yaml"))
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if len(config.Endpoints) != 1 || config.Endpoints[0].Name != "api" {
+		t.Errorf("expected the endpoint of the remote file, got %+v", config.Endpoints)
+	}
+	if config.UI.Title != "Central" {
+		t.Errorf("expected the title of the remote file, got %s", config.UI.Title)
+	}
+	if config.UI.Description != "${GATUS_TEST_CONFIG_TOKEN} costs $$5" {
+		t.Errorf("expected the environment variables referenced by the remote file not to be expanded, got %s", config.UI.Description)
+	}
+	if config.includes.remotes[1].Interval != DefaultRemoteIncludeInterval {
+		t.Errorf("expected the default interval for the remote file without interval, got %s", config.includes.remotes[1].Interval)
+	}
+	time.Sleep(time.Millisecond)
+	if config.HasLoadedConfigurationBeenModified() {
+		t.Error("expected config.HasLoadedConfigurationBeenModified() to return false because the remote files haven't been modified")
+	}
+	endpoints += `
+  - name: website
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"`
+	time.Sleep(time.Millisecond)
+	if !config.HasLoadedConfigurationBeenModified() {
+		t.Error("expected config.HasLoadedConfigurationBeenModified() to return true because a remote file has been modified")
+	}
+	t.Setenv("GATUS_TEST_CONFIG_TOKEN", "invalid")
+	time.Sleep(time.Millisecond)
+	if config.HasLoadedConfigurationBeenModified() {
+		t.Error("expected config.HasLoadedConfigurationBeenModified() to return false because the remote file cannot be fetched")
+	}
+	if _, err = LoadConfiguration(filepath.Join(dir, "config.yaml")); err == nil {
+		t.Error("expected an error, because the remote file cannot be fetched")
+	}
+}
+
+func TestLoadConfigurationWithInvalidIncludes(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		files         map[string]string
+		expectedError error
+	}{
+		{
+			name: "cycle",
+			files: map[string]string{
+				"config.yaml": `include: other.yaml`,
+				"other.yaml":  `include: config.yaml`,
+			},
+			expectedError: ErrIncludeCycle,
+		},
+		{
+			name: "file-that-does-not-exist",
+			files: map[string]string{
+				"config.yaml": `include: nonexistent.yaml`,
+			},
+			expectedError: ErrInvalidInclude,
+		},
+		{
+			name: "invalid-type",
+			files: map[string]string{
+				"config.yaml": `include: {path: other.yaml}`,
+			},
+			expectedError: ErrInvalidInclude,
+		},
+		{
+			name: "remote-include-without-url",
+			files: map[string]string{
+				"config.yaml": `include: [{headers: {Authorization: token}}]`,
+			},
+			expectedError: ErrInvalidInclude,
+		},
+		{
+			name: "remote-include-over-http",
+			files: map[string]string{
+				"config.yaml": `include: http://config.example.org/gatus.yaml`,
+			},
+			expectedError: ErrInvalidInclude,
+		},
+		{
+			name: "remote-include-over-http-without-allow-http",
+			files: map[string]string{
+				"config.yaml": `include: [{url: "http://config.example.org/gatus.yaml"}]`,
+			},
+			expectedError: ErrInvalidInclude,
+		},
+		{
+			name: "glob-without-match",
+			files: map[string]string{
+				"config.yaml": `include: teams/*.yaml`,
+			},
+			expectedError: ErrNoEndpointInConfig,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for path, content := range scenario.files {
+				if err := os.WriteFile(filepath.Join(dir, path), []byte(content), 0644); err != nil {
+					t.Fatalf("failed to write file: %v", err)
+				}
+			}
+			if _, err := LoadConfiguration(filepath.Join(dir, "config.yaml")); !errors.Is(err, scenario.expectedError) {
+				t.Errorf("expected error %v, got %v", scenario.expectedError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package secret
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+)
+
+const (
+	// PrefixFile is the prefix of the references to a secret held by a file, e.g. file:/run/secrets/password
+	PrefixFile = "file:"
+
+	// PrefixVault is the prefix of the references to a secret held by HashiCorp Vault, e.g.
+	// vault:secret/data/gatus#password. The address of Vault is read from the VAULT_ADDR environment variable, and the
+	// token to authenticate with from the VAULT_TOKEN environment variable.
+	PrefixVault = "vault:"
+
+	// PrefixAWSSecretsManager is the prefix of the references to a secret held by AWS Secrets Manager, e.g.
+	// aws-sm:gatus#password. The credentials and the region are read from the environment, like for the AWS CLI.
+	PrefixAWSSecretsManager = "aws-sm:"
+
+	vaultRequestTimeout = 10 * time.Second
+)
+
+var (
+	// ErrInvalidReference is the error returned when a reference to a secret isn't in the expected format
+	ErrInvalidReference = errors.New("invalid secret reference")
+
+	// ErrSecretNotFound is the error returned when the secret a reference refers to doesn't exist
+	ErrSecretNotFound = errors.New("secret not found")
+)
+
+// IsReference returns whether the string passed is a reference to a secret, as opposed to, for instance, the name of an
+// environment variable
+func IsReference(s string) bool {
+	return strings.HasPrefix(s, PrefixFile) || strings.HasPrefix(s, PrefixVault) || strings.HasPrefix(s, PrefixAWSSecretsManager)
+}
+
+// Resolve returns the value of the secret the reference passed refers to
+func Resolve(reference string) (string, error) {
+	switch {
+	case strings.HasPrefix(reference, PrefixFile):
+		return resolveFile(strings.TrimPrefix(reference, PrefixFile))
+	case strings.HasPrefix(reference, PrefixVault):
+		return resolveVault(strings.TrimPrefix(reference, PrefixVault))
+	case strings.HasPrefix(reference, PrefixAWSSecretsManager):
+		return resolveAWSSecretsManager(strings.TrimPrefix(reference, PrefixAWSSecretsManager))
+	}
+	return "", fmt.Errorf("%w: %s must start with %s, %s or %s", ErrInvalidReference, reference, PrefixFile, PrefixVault, PrefixAWSSecretsManager)
+}
+
+// resolveFile returns the content of the file passed, without its trailing newline, if any
+func resolveFile(path string) (string, error) {
+	if len(path) == 0 {
+		return "", fmt.Errorf("%w: %s must be followed by the path of the file", ErrInvalidReference, PrefixFile)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to read secret from file %s: %w", path, err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// resolveVault returns the value of the key of the secret at the path passed, as <path>#<key>, from HashiCorp Vault.
+// Both the version 1 and the version 2 of the KV secrets engine are supported.
+func resolveVault(reference string) (string, error) {
+	path, key := splitKey(reference)
+	if len(path) == 0 || len(key) == 0 {
+		return "", fmt.Errorf("%w: %s%s must be in the format %s<path>#<key>", ErrInvalidReference, PrefixVault, reference, PrefixVault)
+	}
+	address := os.Getenv("VAULT_ADDR")
+	if len(address) == 0 {
+		return "", fmt.Errorf("%w: VAULT_ADDR must be set to resolve %s%s", ErrInvalidReference, PrefixVault, reference)
+	}
+	request, err := http.NewRequest(http.MethodGet, strings.TrimSuffix(address, "/")+"/v1/"+strings.TrimPrefix(path, "/"), http.NoBody)
+	if err != nil {
+		return "", err
+	}
+	request.Header.Set("X-Vault-Token", os.Getenv("VAULT_TOKEN"))
+	if namespace := os.Getenv("VAULT_NAMESPACE"); len(namespace) > 0 {
+		request.Header.Set("X-Vault-Namespace", namespace)
+	}
+	response, err := (&http.Client{Timeout: vaultRequestTimeout}).Do(request)
+	if err != nil {
+		return "", fmt.Errorf("unable to read secret %s from vault: %w", path, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("%w: %s in vault", ErrSecretNotFound, path)
+	}
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unable to read secret %s from vault: unexpected status code %d", path, response.StatusCode)
+	}
+	var body struct {
+		Data map[string]any `json:"data"`
+	}
+	if err = json.NewDecoder(response.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("unable to read secret %s from vault: %w", path, err)
+	}
+	data := body.Data
+	// The version 2 of the KV secrets engine nests the secret in data, alongside its metadata
+	if nestedData, ok := data["data"].(map[string]any); ok {
+		if _, isMetadataPresent := data["metadata"]; isMetadataPresent {
+			data = nestedData
+		}
+	}
+	return getKey(data, key, "vault", path)
+}
+
+// resolveAWSSecretsManager returns the value of the secret passed, optionally followed by the key to extract from
+// the secret if it's a JSON object, as <id>#<key>, from AWS Secrets Manager
+func resolveAWSSecretsManager(reference string) (string, error) {
+	id, key := splitKey(reference)
+	if len(id) == 0 {
+		return "", fmt.Errorf("%w: %s%s must be in the format %s<id> or %s<id>#<key>", ErrInvalidReference, PrefixAWSSecretsManager, reference, PrefixAWSSecretsManager, PrefixAWSSecretsManager)
+	}
+	sess, err := session.NewSessionWithOptions(session.Options{SharedConfigState: session.SharedConfigEnable})
+	if err != nil {
+		return "", fmt.Errorf("unable to create aws session: %w", err)
+	}
+	output, err := secretsmanager.New(sess).GetSecretValue(&secretsmanager.GetSecretValueInput{SecretId: aws.String(id)})
+	if err != nil {
+		return "", fmt.Errorf("unable to read secret %s from aws secrets manager: %w", id, err)
+	}
+	value := aws.StringValue(output.SecretString)
+	if len(key) == 0 {
+		return value, nil
+	}
+	var data map[string]any
+	if err = json.Unmarshal([]byte(value), &data); err != nil {
+		return "", fmt.Errorf("unable to read key %s of secret %s from aws secrets manager, which isn't a JSON object: %w", key, id, err)
+	}
+	return getKey(data, key, "aws secrets manager", id)
+}
+
+// splitKey splits the reference passed into the secret and the key to extract from it, which are separated by the
+// last #, if any
+func splitKey(reference string) (string, string) {
+	if i := strings.LastIndex(reference, "#"); i >= 0 {
+		return reference[:i], reference[i+1:]
+	}
+	return reference, ""
+}
+
+// getKey returns the value of the key passed from the data of the secret, which must be a string, a number or a
+// boolean
+func getKey(data map[string]any, key, source, secret string) (string, error) {
+	switch value := data[key].(type) {
+	case string:
+		return value, nil
+	case float64, bool:
+		return fmt.Sprint(value), nil
+	case nil:
+		return "", fmt.Errorf("%w: key %s of secret %s in %s", ErrSecretNotFound, key, secret, source)
+	}
+	return "", fmt.Errorf("key %s of secret %s in %s is neither a string, a number nor a boolean", key, secret, source)
+}
@@ -0,0 +1,130 @@
+package secret
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsReference(t *testing.T) {
+	scenarios := map[string]bool{
+		"file:/run/secrets/password":       true,
+		"vault:secret/data/gatus#password": true,
+		"aws-sm:gatus#password":            true,
+		"PASSWORD":                         false,
+		"files:/run/secrets/password":      false,
+	}
+	for s, expected := range scenarios {
+		if actual := IsReference(s); actual != expected {
+			t.Errorf("expected IsReference(%q) to be %v, got %v", s, expected, actual)
+		}
+	}
+}
+
+func TestResolveWithFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(path, []byte("hunter2\n"), 0600); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	value, err := Resolve(PrefixFile + path)
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if value != "hunter2" {
+		t.Errorf("expected hunter2 without its trailing newline, got %q", value)
+	}
+	if _, err = Resolve(PrefixFile + filepath.Join(t.TempDir(), "nonexistent")); err == nil {
+		t.Error("expected an error for a file that doesn't exist")
+	}
+	if _, err = Resolve(PrefixFile); !errors.Is(err, ErrInvalidReference) {
+		t.Errorf("expected %v, got %v", ErrInvalidReference, err)
+	}
+}
+
+func TestResolveWithVault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/gatus":
+			_, _ = w.Write([]byte(`{"data":{"data":{"password":"hunter2","port":5432},"metadata":{"version":1}}}`))
+		case "/v1/kv/gatus":
+			_, _ = w.Write([]byte(`{"data":{"password":"hunter3"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	t.Setenv("VAULT_ADDR", server.URL)
+	t.Setenv("VAULT_TOKEN", "token")
+	scenarios := []struct {
+		name          string
+		reference     string
+		expectedValue string
+		expectedErr   error
+	}{
+		{
+			name:          "kv-v2",
+			reference:     "vault:secret/data/gatus#password",
+			expectedValue: "hunter2",
+		},
+		{
+			name:          "kv-v2-number",
+			reference:     "vault:secret/data/gatus#port",
+			expectedValue: "5432",
+		},
+		{
+			name:          "kv-v1",
+			reference:     "vault:kv/gatus#password",
+			expectedValue: "hunter3",
+		},
+		{
+			name:        "nonexistent-key",
+			reference:   "vault:secret/data/gatus#username",
+			expectedErr: ErrSecretNotFound,
+		},
+		{
+			name:        "nonexistent-secret",
+			reference:   "vault:secret/data/nonexistent#password",
+			expectedErr: ErrSecretNotFound,
+		},
+		{
+			name:        "without-key",
+			reference:   "vault:secret/data/gatus",
+			expectedErr: ErrInvalidReference,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			value, err := Resolve(scenario.reference)
+			if !errors.Is(err, scenario.expectedErr) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if value != scenario.expectedValue {
+				t.Errorf("expected %q, got %q", scenario.expectedValue, value)
+			}
+		})
+	}
+}
+
+func TestResolveWithVaultWithoutAddress(t *testing.T) {
+	t.Setenv("VAULT_ADDR", "")
+	if _, err := Resolve("vault:secret/data/gatus#password"); !errors.Is(err, ErrInvalidReference) {
+		t.Errorf("expected %v, got %v", ErrInvalidReference, err)
+	}
+}
+
+func TestSplitKey(t *testing.T) {
+	secret, key := splitKey("arn:aws:secretsmanager:us-east-1:123456789012:secret:gatus#password")
+	if secret != "arn:aws:secretsmanager:us-east-1:123456789012:secret:gatus" || key != "password" {
+		t.Errorf("expected the arn and the key to be split on the last #, got %q and %q", secret, key)
+	}
+	if secret, key = splitKey("gatus"); secret != "gatus" || len(key) != 0 {
+		t.Errorf("expected no key, got %q and %q", secret, key)
+	}
+}
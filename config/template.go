@@ -0,0 +1,238 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+
+	"github.com/TwiN/gatus/v5/config/discovery"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// templatesKey is the key of the parameter of the configuration listing the endpoint templates
+	templatesKey = "templates"
+
+	// templateKey is the key of the parameter of an endpoint referencing the template it's an instance of
+	templateKey = "template"
+
+	// templateParametersKey is the key of the parameter of a template, or of an instance of a template, holding the
+	// values of the placeholders of the template
+	templateParametersKey = "parameters"
+)
+
+// ErrInvalidTemplate is an error returned when an endpoint template, or an instance of an endpoint template, is invalid
+var ErrInvalidTemplate = errors.New("invalid endpoint template")
+
+// templatePlaceholderRegex matches the placeholders of the parameters of a template, e.g. {{ host }}
+var templatePlaceholderRegex = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_-]+)\s*\}\}`)
+
+// endpointTemplate is the skeleton of an endpoint, defined once in the templates of the configuration and instantiated
+// by the endpoints referencing it with the template parameter
+type endpointTemplate struct {
+	// name is the name the endpoints reference the template with
+	name string
+
+	// parameters are the default values of the parameters of the template, which the instances may override
+	parameters map[string]any
+
+	// endpoint is the skeleton of the endpoint, whose string values may contain placeholders of the parameters
+	endpoint map[string]any
+}
+
+// expandTemplates replaces the endpoints of the configuration passed that reference a template by an instance of the
+// template, and removes the templates from the configuration, which are returned so that the endpoints discovered can
+// be instances of them as well. The configuration is returned as is if it has no templates.
+func expandTemplates(yamlBytes []byte) ([]byte, map[string]*endpointTemplate, error) {
+	var document map[string]any
+	if err := yaml.Unmarshal(yamlBytes, &document); err != nil {
+		return nil, nil, err
+	}
+	if _, exists := document[templatesKey]; !exists {
+		return yamlBytes, nil, nil
+	}
+	templates, err := parseTemplates(document[templatesKey])
+	if err != nil {
+		return nil, nil, err
+	}
+	endpoints, _ := document["endpoints"].([]any)
+	for i, ep := range endpoints {
+		instance, ok := ep.(map[string]any)
+		if !ok {
+			continue
+		}
+		if _, exists := instance[templateKey]; !exists {
+			continue
+		}
+		name, ok := instance[templateKey].(string)
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: template of endpoint must be the name of a template", ErrInvalidTemplate)
+		}
+		template, exists := templates[name]
+		if !exists {
+			return nil, nil, fmt.Errorf("%w: template %s does not exist", ErrInvalidTemplate, name)
+		}
+		if endpoints[i], err = template.instantiate(instance); err != nil {
+			return nil, nil, err
+		}
+	}
+	delete(document, templatesKey)
+	yamlBytes, err = yaml.Marshal(document)
+	return yamlBytes, templates, err
+}
+
+// newTemplateInstantiator returns a function instantiating the templates passed, for the endpoints discovered
+func newTemplateInstantiator(templates map[string]*endpointTemplate) discovery.TemplateInstantiator {
+	return func(name string, parameters map[string]any) (*endpoint.Endpoint, error) {
+		template, exists := templates[name]
+		if !exists {
+			return nil, fmt.Errorf("%w: template %s does not exist", ErrInvalidTemplate, name)
+		}
+		instance, err := template.instantiate(map[string]any{templateKey: name, templateParametersKey: parameters})
+		if err != nil {
+			return nil, err
+		}
+		data, err := yaml.Marshal(instance)
+		if err != nil {
+			return nil, err
+		}
+		ep := &endpoint.Endpoint{}
+		if err = yaml.Unmarshal(data, ep); err != nil {
+			return nil, fmt.Errorf("%w: instance of template %s is not a valid endpoint: %w", ErrInvalidTemplate, name, err)
+		}
+		return ep, nil
+	}
+}
+
+// parseTemplates returns the templates passed, by name
+func parseTemplates(value any) (map[string]*endpointTemplate, error) {
+	list, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: templates must be a list", ErrInvalidTemplate)
+	}
+	templates := make(map[string]*endpointTemplate, len(list))
+	for _, item := range list {
+		definition, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%w: each template must be a map", ErrInvalidTemplate)
+		}
+		name, _ := definition["name"].(string)
+		if len(name) == 0 {
+			return nil, fmt.Errorf("%w: each template must have a name", ErrInvalidTemplate)
+		}
+		if _, exists := templates[name]; exists {
+			return nil, fmt.Errorf("%w: template %s is defined more than once", ErrInvalidTemplate, name)
+		}
+		endpoint, ok := definition["endpoint"].(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%w: template %s must have an endpoint", ErrInvalidTemplate, name)
+		}
+		parameters, err := getTemplateParameters(definition)
+		if err != nil {
+			return nil, fmt.Errorf("%w: parameters of template %s must be a map", ErrInvalidTemplate, name)
+		}
+		templates[name] = &endpointTemplate{name: name, parameters: parameters, endpoint: endpoint}
+	}
+	return templates, nil
+}
+
+// instantiate returns the endpoint resulting from replacing the placeholders of the template by the parameters of the
+// instance passed, or by their default value, and from overriding the template with the other parameters of the
+// instance. Maps are deep merged, whereas the values and the lists of the instance replace those of the template.
+func (t *endpointTemplate) instantiate(instance map[string]any) (map[string]any, error) {
+	parameters, err := getTemplateParameters(instance)
+	if err != nil {
+		return nil, fmt.Errorf("%w: parameters of the instances of template %s must be a map", ErrInvalidTemplate, t.name)
+	}
+	values := maps.Clone(t.parameters)
+	maps.Copy(values, parameters)
+	endpoint, err := t.substitute(t.endpoint, values)
+	if err != nil {
+		return nil, err
+	}
+	overrides := maps.Clone(instance)
+	delete(overrides, templateKey)
+	delete(overrides, templateParametersKey)
+	return mergeTemplateValues(endpoint.(map[string]any), overrides), nil
+}
+
+// substitute returns a copy of the value passed in which the placeholders of the string values, and of the keys of the
+// maps, are replaced by the value of the parameter they refer to. A string value that is exactly a placeholder is
+// replaced by the value of the parameter as is, so that parameters aren't limited to strings.
+func (t *endpointTemplate) substitute(value any, parameters map[string]any) (any, error) {
+	switch value := value.(type) {
+	case string:
+		if match := templatePlaceholderRegex.FindStringSubmatch(value); match != nil && match[0] == value {
+			parameter, exists := parameters[match[1]]
+			if !exists {
+				return nil, fmt.Errorf("%w: parameter %s of template %s is not set", ErrInvalidTemplate, match[1], t.name)
+			}
+			return parameter, nil
+		}
+		var err error
+		substituted := templatePlaceholderRegex.ReplaceAllStringFunc(value, func(placeholder string) string {
+			name := templatePlaceholderRegex.FindStringSubmatch(placeholder)[1]
+			parameter, exists := parameters[name]
+			if !exists {
+				if err == nil {
+					err = fmt.Errorf("%w: parameter %s of template %s is not set", ErrInvalidTemplate, name, t.name)
+				}
+				return placeholder
+			}
+			return fmt.Sprint(parameter)
+		})
+		return substituted, err
+	case map[string]any:
+		substituted := make(map[string]any, len(value))
+		for key, v := range value {
+			substitutedKey, err := t.substitute(key, parameters)
+			if err != nil {
+				return nil, err
+			}
+			if substituted[fmt.Sprint(substitutedKey)], err = t.substitute(v, parameters); err != nil {
+				return nil, err
+			}
+		}
+		return substituted, nil
+	case []any:
+		substituted := make([]any, len(value))
+		for i, v := range value {
+			var err error
+			if substituted[i], err = t.substitute(v, parameters); err != nil {
+				return nil, err
+			}
+		}
+		return substituted, nil
+	}
+	return value, nil
+}
+
+// getTemplateParameters returns the parameters of the template or of the instance of a template passed, if any
+func getTemplateParameters(definition map[string]any) (map[string]any, error) {
+	value, exists := definition[templateParametersKey]
+	if !exists || value == nil {
+		return map[string]any{}, nil
+	}
+	parameters, ok := value.(map[string]any)
+	if !ok {
+		return nil, ErrInvalidTemplate
+	}
+	return parameters, nil
+}
+
+// mergeTemplateValues returns the values of the template passed overridden by the values passed, deep merging maps
+func mergeTemplateValues(template, overrides map[string]any) map[string]any {
+	merged := maps.Clone(template)
+	for key, override := range overrides {
+		if overrideMap, ok := override.(map[string]any); ok {
+			if templateMap, ok := merged[key].(map[string]any); ok {
+				merged[key] = mergeTemplateValues(templateMap, overrideMap)
+				continue
+			}
+		}
+		merged[key] = override
+	}
+	return merged
+}
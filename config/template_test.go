@@ -0,0 +1,153 @@
+package config
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+)
+
+func TestParseAndValidateConfigBytesWithTemplates(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+alerting:
+  slack:
+    webhook-url: "https://hooks.slack.com/services/xxx/yyy/zzz"
+templates:
+  - name: website
+    parameters:
+      group: websites
+      threshold: 3
+    endpoint:
+      name: "{{ host }}"
+      group: "{{group}}"
+      url: "https://{{ host }}/health"
+      interval: 1m
+      headers:
+        X-Host: "{{ host }}"
+      conditions:
+        - "[STATUS] == 200"
+        - "[BODY].host == {{ host }}"
+      alerts:
+        - type: slack
+          failure-threshold: "{{ threshold }}"
+endpoints:
+  - template: website
+    parameters:
+      host: example.org
+  - template: website
+    parameters:
+      host: example.com
+      group: core
+      threshold: 5
+    interval: 5m
+    headers:
+      Authorization: "Bearer token"
+  - name: standalone
+    url: https://example.net
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(config.Endpoints) != 3 {
+		t.Fatalf("expected 3 endpoints, got %d", len(config.Endpoints))
+	}
+	first, second := config.Endpoints[0], config.Endpoints[1]
+	if first.Name != "example.org" || first.Group != "websites" || first.URL != "https://example.org/health" || first.Interval != time.Minute {
+		t.Errorf("expected the placeholders to be replaced by the parameters and their defaults, got %+v", first)
+	}
+	if len(first.Conditions) != 2 || first.Conditions[1] != "[BODY].host == example.org" {
+		t.Errorf("expected the conditions of the template, got %v", first.Conditions)
+	}
+	if len(first.Alerts) != 1 || first.Alerts[0].Type != alert.TypeSlack || first.Alerts[0].FailureThreshold != 3 {
+		t.Errorf("expected the alert of the template with the default threshold, got %+v", first.Alerts)
+	}
+	if second.Group != "core" || second.Interval != 5*time.Minute || second.Alerts[0].FailureThreshold != 5 {
+		t.Errorf("expected the parameters and the values of the instance to take precedence, got %+v", second)
+	}
+	if second.Headers["X-Host"] != "example.com" || second.Headers["Authorization"] != "Bearer token" {
+		t.Errorf("expected the headers of the template and of the instance to be merged, got %v", second.Headers)
+	}
+	if config.Endpoints[2].Name != "standalone" {
+		t.Errorf("expected the endpoints that don't reference a template to be left as is, got %+v", config.Endpoints[2])
+	}
+}
+
+func TestParseAndValidateConfigBytesWithInvalidTemplates(t *testing.T) {
+	scenarios := []struct {
+		name   string
+		config string
+	}{
+		{
+			name: "template-does-not-exist",
+			config: `
+endpoints:
+  - template: nonexistent
+templates:
+  - name: website
+    endpoint:
+      url: https://example.org`,
+		},
+		{
+			name: "parameter-not-set",
+			config: `
+templates:
+  - name: website
+    endpoint:
+      name: website
+      url: "https://{{ host }}"
+endpoints:
+  - template: website`,
+		},
+		{
+			name: "template-without-name",
+			config: `
+templates:
+  - endpoint:
+      url: https://example.org
+endpoints:
+  - template: website`,
+		},
+		{
+			name: "template-defined-twice",
+			config: `
+templates:
+  - name: website
+    endpoint:
+      url: https://example.org
+  - name: website
+    endpoint:
+      url: https://example.com
+endpoints:
+  - template: website`,
+		},
+		{
+			name: "template-without-endpoint",
+			config: `
+templates:
+  - name: website
+endpoints:
+  - template: website`,
+		},
+		{
+			name: "invalid-parameters",
+			config: `
+templates:
+  - name: website
+    endpoint:
+      url: https://example.org
+endpoints:
+  - template: website
+    parameters: [example.org]`,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if _, err := parseAndValidateConfigBytes([]byte(scenario.config)); !errors.Is(err, ErrInvalidTemplate) {
+				t.Errorf("expected %v, got %v", ErrInvalidTemplate, err)
+			}
+		})
+	}
+}
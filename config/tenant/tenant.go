@@ -0,0 +1,74 @@
+package tenant
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/TwiN/gatus/v5/config/ui"
+	"github.com/TwiN/gatus/v5/security"
+)
+
+var (
+	ErrInvalidName           = errors.New("tenants[].name must be set and only contain lowercase letters, digits and dashes")
+	ErrInvalidSecurityConfig = errors.New("tenants[].security must be a valid basic or ldap security configuration, as oidc and saml are not supported for tenants")
+
+	validNamePattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+)
+
+// Tenant is a customer whose endpoints are shown by their own dashboard, served at /tenants/{name}, and whose API is
+// served at /api/v1/tenants/{name}
+type Tenant struct {
+	// Name of the tenant, which the endpoints are assigned to the tenant with, and which is part of the paths of the
+	// dashboard and of the API of the tenant
+	Name string `yaml:"name"`
+
+	// Title of the dashboard of the tenant. Defaults to ui.title
+	Title string `yaml:"title,omitempty"`
+
+	// Header at the top of the dashboard of the tenant. Defaults to ui.header
+	Header string `yaml:"header,omitempty"`
+
+	// Security is the configuration of the basic authentication required to access the dashboard and the API of the
+	// tenant, on top of the users authenticated through the security configuration of Gatus, who can access every
+	// tenant. If nil, the tenant can only be accessed by the latter, or by anyone if Gatus isn't secured.
+	Security *security.Config `yaml:"security,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the tenant configuration
+func (t *Tenant) ValidateAndSetDefaults() error {
+	if !validNamePattern.MatchString(t.Name) {
+		return ErrInvalidName
+	}
+	if t.Security == nil {
+		return nil
+	}
+	if t.Security.LDAP != nil {
+		if err := t.Security.LDAP.ValidateAndSetDefaults(); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidSecurityConfig, err)
+		}
+	}
+	if t.Security.BruteForceProtection != nil {
+		if err := t.Security.BruteForceProtection.ValidateAndSetDefaults(); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidSecurityConfig, err)
+		}
+	}
+	// Sessions are only created by logging in through oidc or saml, neither of which is supported for tenants
+	if t.Security.OIDC != nil || t.Security.SAML != nil || t.Security.Session != nil || !t.Security.IsValid() {
+		return ErrInvalidSecurityConfig
+	}
+	return nil
+}
+
+// UI returns the UI configuration of the dashboard of the tenant, which is the one passed as parameter with the title
+// and the header of the tenant, if they are set
+func (t *Tenant) UI(uiConfig *ui.Config) *ui.Config {
+	tenantUI := *uiConfig
+	if len(t.Title) > 0 {
+		tenantUI.Title = t.Title
+	}
+	if len(t.Header) > 0 {
+		tenantUI.Header = t.Header
+	}
+	return &tenantUI
+}
@@ -0,0 +1,129 @@
+package tenant
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/config/ui"
+	"github.com/TwiN/gatus/v5/security"
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestTenant_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		tenant        *Tenant
+		expectedError error
+	}{
+		{
+			name:   "valid",
+			tenant: &Tenant{Name: "acme-corp"},
+		},
+		{
+			name:   "valid-with-basic-security",
+			tenant: &Tenant{Name: "acme", Security: &security.Config{Basic: &security.BasicConfig{Username: "acme", PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT"}}},
+		},
+		{
+			name:   "valid-with-basic-security-and-brute-force-protection",
+			tenant: &Tenant{Name: "acme", Security: &security.Config{Basic: &security.BasicConfig{Username: "acme", PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT"}, BruteForceProtection: &security.BruteForceProtectionConfig{}}},
+		},
+		{
+			name:          "invalid-brute-force-protection",
+			tenant:        &Tenant{Name: "acme", Security: &security.Config{Basic: &security.BasicConfig{Username: "acme", PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT"}, BruteForceProtection: &security.BruteForceProtectionConfig{MaximumFailedAttempts: -1}}},
+			expectedError: ErrInvalidSecurityConfig,
+		},
+		{
+			name:          "session",
+			tenant:        &Tenant{Name: "acme", Security: &security.Config{Basic: &security.BasicConfig{Username: "acme", PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT"}, Session: &security.SessionConfig{}}},
+			expectedError: ErrInvalidSecurityConfig,
+		},
+		{
+			name:          "missing-name",
+			tenant:        &Tenant{},
+			expectedError: ErrInvalidName,
+		},
+		{
+			name:          "name-with-invalid-characters",
+			tenant:        &Tenant{Name: "Acme Corp"},
+			expectedError: ErrInvalidName,
+		},
+		{
+			name:          "name-ending-with-dash",
+			tenant:        &Tenant{Name: "acme-"},
+			expectedError: ErrInvalidName,
+		},
+		{
+			name:          "invalid-basic-security",
+			tenant:        &Tenant{Name: "acme", Security: &security.Config{Basic: &security.BasicConfig{Username: "acme"}}},
+			expectedError: ErrInvalidSecurityConfig,
+		},
+		{
+			name:   "ldap-security",
+			tenant: &Tenant{Name: "acme", Security: &security.Config{LDAP: &security.LDAPConfig{URL: "ldaps://ldap.acme.org", BaseDN: "dc=acme,dc=org"}}},
+		},
+		{
+			name:          "invalid-ldap-security",
+			tenant:        &Tenant{Name: "acme", Security: &security.Config{LDAP: &security.LDAPConfig{URL: "ldaps://ldap.acme.org"}}},
+			expectedError: ErrInvalidSecurityConfig,
+		},
+		{
+			name:          "oidc-security",
+			tenant:        &Tenant{Name: "acme", Security: &security.Config{OIDC: &security.OIDCConfig{IssuerURL: "https://sso.example.org", RedirectURL: "https://status.example.org/authorization-code/callback", ClientID: "id", ClientSecret: "secret", Scopes: []string{"openid"}}}},
+			expectedError: ErrInvalidSecurityConfig,
+		},
+		{
+			name:          "saml-security",
+			tenant:        &Tenant{Name: "acme", Security: &security.Config{SAML: &security.SAMLConfig{IDPMetadataURL: "https://sso.example.org/metadata", RootURL: "https://status.example.org", CertificateFile: "saml.crt", PrivateKeyFile: "saml.key"}}},
+			expectedError: ErrInvalidSecurityConfig,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.tenant.ValidateAndSetDefaults(); !errors.Is(err, scenario.expectedError) {
+				t.Errorf("expected error %v, got %v", scenario.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestTenant_ValidateAndSetDefaultsWithBruteForceProtection(t *testing.T) {
+	tenant := &Tenant{Name: "acme", Security: &security.Config{
+		Basic:                &security.BasicConfig{Username: "acme", PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT"},
+		BruteForceProtection: &security.BruteForceProtectionConfig{},
+	}}
+	if err := tenant.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if tenant.Security.BruteForceProtection.MaximumFailedAttempts != security.DefaultMaximumFailedAttempts {
+		t.Errorf("expected the defaults of the brute-force protection to be set, got max-failed-attempts=%d", tenant.Security.BruteForceProtection.MaximumFailedAttempts)
+	}
+	app := fiber.New()
+	app.Get("/test", func(c *fiber.Ctx) error {
+		if tenant.Security.IsAuthenticated(c) {
+			return c.SendStatus(200)
+		}
+		return c.SendStatus(401)
+	})
+	request := httptest.NewRequest("GET", "/test", http.NoBody)
+	request.SetBasicAuth("acme", "wrong-password")
+	response, err := app.Test(request)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if response.StatusCode != 401 {
+		t.Errorf("expected the failed attempt to be rejected, got %d", response.StatusCode)
+	}
+}
+
+func TestTenant_UI(t *testing.T) {
+	uiConfig := ui.GetDefaultConfig()
+	tenantUI := (&Tenant{Name: "acme", Title: "Acme Status"}).UI(uiConfig)
+	if tenantUI.Title != "Acme Status" {
+		t.Errorf("expected the title of the tenant, got %s", tenantUI.Title)
+	}
+	if tenantUI.Header != uiConfig.Header {
+		t.Errorf("expected the header to default to the one of the UI, got %s", tenantUI.Header)
+	}
+}
@@ -2,9 +2,15 @@ package web
 
 import (
 	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"math"
+	"net/netip"
+	"net/url"
+	"os"
+	"strings"
+	"time"
 )
 
 const (
@@ -20,8 +26,30 @@ const (
 	// MinimumReadBufferSize is the minimum value for ReadBufferSize, and also the default value set
 	// for fiber.Config.ReadBufferSize
 	MinimumReadBufferSize = 4096
+
+	// DefaultCacheControl is the default Cache-Control header of the statuses and the badges, which lets clients keep
+	// them as long as they check with a conditional request that they haven't changed before using them
+	DefaultCacheControl = "no-cache"
+
+	// DefaultRateLimitWindow is the default value for RateLimitConfig.Window
+	DefaultRateLimitWindow = time.Minute
+
+	// DefaultProxyHeader is the default value for Config.ProxyHeader
+	DefaultProxyHeader = "X-Forwarded-For"
+
+	// ClientAuthModeRequireAndVerify is the mode of the authentication of the clients in which every client must present
+	// a certificate signed by one of the certificate authorities
+	ClientAuthModeRequireAndVerify ClientAuthMode = "require-and-verify"
+
+	// ClientAuthModeVerifyIfGiven is the mode of the authentication of the clients in which the clients may connect
+	// without presenting a certificate, but the certificates that are presented must be signed by one of the certificate
+	// authorities
+	ClientAuthModeVerifyIfGiven ClientAuthMode = "verify-if-given"
 )
 
+// DefaultCORSAllowedMethods are the methods allowed by the CORS policy if none are specified
+var DefaultCORSAllowedMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD"}
+
 // Config is the structure which supports the configuration of the server listening to requests
 type Config struct {
 	// Address to listen on (defaults to 0.0.0.0 specified by DefaultAddress)
@@ -40,6 +68,151 @@ type Config struct {
 
 	// TLS configuration (optional)
 	TLS *TLSConfig `yaml:"tls,omitempty"`
+
+	// APIDocs is whether to serve the interactive documentation of the API at /api/docs.
+	// The assets of the documentation are loaded from a CDN by the browser.
+	APIDocs bool `yaml:"api-docs,omitempty"`
+
+	// CORS is the Cross-Origin Resource Sharing policy of the API (optional).
+	// If nil, browsers only allow the API to be called by the pages served by Gatus.
+	CORS *CORSConfig `yaml:"cors,omitempty"`
+
+	// CacheControl is the configuration of the Cache-Control header of the statuses, the badges and the widgets
+	CacheControl *CacheControlConfig `yaml:"cache-control,omitempty"`
+
+	// Widgets is the configuration of the sites allowed to embed the widgets (optional).
+	// If nil, the widgets can be embedded by every site.
+	Widgets *WidgetsConfig `yaml:"widgets,omitempty"`
+
+	// RateLimit is the configuration of the limit of requests to the API per IP (optional).
+	// If nil, requests are not limited.
+	RateLimit *RateLimitConfig `yaml:"rate-limit,omitempty"`
+
+	// TrustedProxies are the IPs and the CIDR ranges of the proxies in front of Gatus, whose ProxyHeader is trusted to
+	// hold the IP of the client (optional). If empty, the IP of the client is the one the request comes from.
+	TrustedProxies []string `yaml:"trusted-proxies,omitempty"`
+
+	// ProxyHeader is the header holding the IP of the client when the request comes from one of the TrustedProxies
+	// (defaults to DefaultProxyHeader). If it holds several IPs, the IP of the client is the rightmost one that isn't
+	// one of the TrustedProxies, since the IPs on its left may have been sent by the client itself.
+	ProxyHeader string `yaml:"proxy-header,omitempty"`
+
+	// IPFilter is the configuration of the IPs allowed to send requests (optional).
+	// If nil, requests are accepted from every IP.
+	IPFilter *IPFilterConfig `yaml:"ip-filter,omitempty"`
+
+	trustedProxies ipRanges
+}
+
+// IPFilterConfig is the configuration of the IPs allowed to send requests, whether to every route or to some of them
+//
+// A request is rejected if its IP is part of a denied range, or if there are allowed ranges and its IP isn't part of
+// any of them. The ranges of a rule only apply to the requests whose path matches one of the paths of the rule, in
+// addition to the ranges that apply to every request.
+type IPFilterConfig struct {
+	// Allow are the IPs and the CIDR ranges allowed to send requests to every route. If empty, every IP is allowed.
+	Allow []string `yaml:"allow,omitempty"`
+
+	// Deny are the IPs and the CIDR ranges not allowed to send requests to any route
+	Deny []string `yaml:"deny,omitempty"`
+
+	// Rules are the IPs and the CIDR ranges allowed or not allowed to send requests to some of the routes
+	Rules []*IPFilterRule `yaml:"rules,omitempty"`
+
+	allowed, denied ipRanges
+}
+
+// IPFilterRule is the configuration of the IPs allowed to send requests to the routes whose path matches one of its
+// paths
+type IPFilterRule struct {
+	// Paths are the paths the rule applies to, each of which also matches every path under it, e.g. /api matches
+	// /api/v1/endpoints/statuses. A segment may be replaced by *, e.g. /api/v1/endpoints/*/external.
+	Paths []string `yaml:"paths"`
+
+	// Allow are the IPs and the CIDR ranges allowed to send requests to the paths. If empty, every IP is allowed.
+	Allow []string `yaml:"allow,omitempty"`
+
+	// Deny are the IPs and the CIDR ranges not allowed to send requests to the paths
+	Deny []string `yaml:"deny,omitempty"`
+
+	allowed, denied ipRanges
+}
+
+// ipRanges are CIDR ranges, a single IP being a range of one IP
+type ipRanges []netip.Prefix
+
+// parseIPRanges parses IPs and CIDR ranges, e.g. 10.0.0.1 and 10.0.0.0/8
+func parseIPRanges(values []string) (ipRanges, error) {
+	ranges := make(ipRanges, 0, len(values))
+	for _, value := range values {
+		if prefix, err := netip.ParsePrefix(value); err == nil {
+			ranges = append(ranges, prefix.Masked())
+		} else if addr, err := netip.ParseAddr(value); err == nil {
+			ranges = append(ranges, netip.PrefixFrom(addr, addr.BitLen()))
+		} else {
+			return nil, fmt.Errorf("%s is neither an IP nor a CIDR range", value)
+		}
+	}
+	return ranges, nil
+}
+
+func (r ipRanges) contains(addr netip.Addr) bool {
+	for _, prefix := range r {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// RateLimitConfig is the configuration of the limit of requests that can be made to the API, including the badges, by
+// each IP. Authenticated users are exempt from it.
+type RateLimitConfig struct {
+	// MaximumRequests is the maximum number of requests an IP can make during each window
+	MaximumRequests int `yaml:"max-requests"`
+
+	// Window is the duration over which the requests are counted (defaults to DefaultRateLimitWindow)
+	Window time.Duration `yaml:"window,omitempty"`
+}
+
+// CacheControlConfig is the configuration of the Cache-Control header of the responses that are frequently requested,
+// such as the statuses polled by dashboards and the badges embedded in READMEs
+type CacheControlConfig struct {
+	// Statuses is the Cache-Control header of the statuses of the endpoints (defaults to DefaultCacheControl)
+	Statuses string `yaml:"statuses,omitempty"`
+
+	// Badges is the Cache-Control header of the badges (defaults to DefaultCacheControl)
+	Badges string `yaml:"badges,omitempty"`
+
+	// Widgets is the Cache-Control header of the widgets (defaults to DefaultCacheControl)
+	Widgets string `yaml:"widgets,omitempty"`
+}
+
+// WidgetsConfig is the configuration of the sites allowed to embed the widgets, whether through an iframe or by
+// fetching them from a browser. Unlike CORSConfig, it only applies to the widgets, which never require credentials.
+type WidgetsConfig struct {
+	// AllowedOrigins are the origins allowed to embed the widgets, e.g. https://example.org.
+	// A subdomain wildcard such as https://*.example.org is supported, and * allows every origin.
+	AllowedOrigins []string `yaml:"allowed-origins"`
+}
+
+// CORSConfig is the Cross-Origin Resource Sharing policy of the API, which allows pages hosted elsewhere, such as a
+// custom frontend or a widget, to call the API from a browser
+type CORSConfig struct {
+	// AllowedOrigins are the origins allowed to call the API, e.g. https://example.org.
+	// A subdomain wildcard such as https://*.example.org is supported, and * allows every origin.
+	AllowedOrigins []string `yaml:"allowed-origins"`
+
+	// AllowedMethods are the methods allowed when calling the API (defaults to DefaultCORSAllowedMethods)
+	AllowedMethods []string `yaml:"allowed-methods,omitempty"`
+
+	// AllowedHeaders are the headers allowed when calling the API.
+	// If empty, the headers requested by the browser are allowed.
+	AllowedHeaders []string `yaml:"allowed-headers,omitempty"`
+
+	// AllowCredentials is whether browsers may send credentials, such as cookies or the Authorization header, when
+	// calling the API. Cannot be used when every origin is allowed.
+	AllowCredentials bool `yaml:"allow-credentials,omitempty"`
 }
 
 type TLSConfig struct {
@@ -48,6 +221,24 @@ type TLSConfig struct {
 
 	// PrivateKeyFile is the private key file for TLS in PEM format.
 	PrivateKeyFile string `yaml:"private-key-file,omitempty"`
+
+	// ClientAuth is the configuration of the authentication of the clients through certificates, also known as mTLS
+	// (optional)
+	ClientAuth *ClientAuthConfig `yaml:"client-auth,omitempty"`
+}
+
+// ClientAuthMode is whether the clients must present a certificate
+type ClientAuthMode string
+
+// ClientAuthConfig is the configuration of the authentication of the clients through the certificates they present
+// during the TLS handshake, which protects the dashboard and the API without requiring a proxy in front of Gatus
+type ClientAuthConfig struct {
+	// CertificateAuthorityFile is the bundle of the certificate authorities, in PEM format, that the certificates
+	// presented by the clients must be signed by
+	CertificateAuthorityFile string `yaml:"certificate-authority-file"`
+
+	// Mode is whether the clients must present a certificate. Defaults to ClientAuthModeRequireAndVerify.
+	Mode ClientAuthMode `yaml:"mode,omitempty"`
 }
 
 // GetDefaultConfig returns a Config struct with the default values
@@ -56,6 +247,7 @@ func GetDefaultConfig() *Config {
 		Address:        DefaultAddress,
 		Port:           DefaultPort,
 		ReadBufferSize: DefaultReadBufferSize,
+		CacheControl:   &CacheControlConfig{Statuses: DefaultCacheControl, Badges: DefaultCacheControl, Widgets: DefaultCacheControl},
 	}
 }
 
@@ -82,6 +274,56 @@ func (web *Config) ValidateAndSetDefaults() error {
 		if err := web.TLS.isValid(); err != nil {
 			return fmt.Errorf("invalid tls config: %w", err)
 		}
+		if web.TLS.ClientAuth != nil {
+			if err := web.TLS.ClientAuth.validateAndSetDefaults(); err != nil {
+				return fmt.Errorf("invalid tls client-auth config: %w", err)
+			}
+		}
+	}
+	// Set the default Cache-Control headers
+	if web.CacheControl == nil {
+		web.CacheControl = &CacheControlConfig{}
+	}
+	if len(web.CacheControl.Statuses) == 0 {
+		web.CacheControl.Statuses = DefaultCacheControl
+	}
+	if len(web.CacheControl.Badges) == 0 {
+		web.CacheControl.Badges = DefaultCacheControl
+	}
+	if len(web.CacheControl.Widgets) == 0 {
+		web.CacheControl.Widgets = DefaultCacheControl
+	}
+	if web.RateLimit != nil {
+		if web.RateLimit.MaximumRequests <= 0 {
+			return errors.New("invalid rate-limit config: max-requests must be greater than 0")
+		}
+		if web.RateLimit.Window < 0 {
+			return errors.New("invalid rate-limit config: window cannot be negative")
+		} else if web.RateLimit.Window == 0 {
+			web.RateLimit.Window = DefaultRateLimitWindow
+		}
+	}
+	var err error
+	if web.trustedProxies, err = parseIPRanges(web.TrustedProxies); err != nil {
+		return fmt.Errorf("invalid trusted-proxies: %w", err)
+	}
+	if len(web.ProxyHeader) == 0 {
+		web.ProxyHeader = DefaultProxyHeader
+	}
+	if web.IPFilter != nil {
+		if err := web.IPFilter.validate(); err != nil {
+			return fmt.Errorf("invalid ip-filter config: %w", err)
+		}
+	}
+	if web.CORS != nil {
+		if err := web.CORS.validateAndSetDefaults(); err != nil {
+			return fmt.Errorf("invalid cors config: %w", err)
+		}
+	}
+	if web.Widgets != nil {
+		if err := web.Widgets.validate(); err != nil {
+			return fmt.Errorf("invalid widgets config: %w", err)
+		}
 	}
 	return nil
 }
@@ -90,6 +332,11 @@ func (web *Config) HasTLS() bool {
 	return web.TLS != nil && len(web.TLS.CertificateFile) > 0 && len(web.TLS.PrivateKeyFile) > 0
 }
 
+// HasClientAuth returns whether the clients are authenticated through the certificates they present
+func (web *Config) HasClientAuth() bool {
+	return web.HasTLS() && web.TLS.ClientAuth != nil
+}
+
 // SocketAddress returns the combination of the Address and the Port
 func (web *Config) SocketAddress() string {
 	return fmt.Sprintf("%s:%d", web.Address, web.Port)
@@ -105,3 +352,194 @@ func (t *TLSConfig) isValid() error {
 	}
 	return errors.New("certificate-file and private-key-file must be specified")
 }
+
+func (c *ClientAuthConfig) validateAndSetDefaults() error {
+	if len(c.CertificateAuthorityFile) == 0 {
+		return errors.New("certificate-authority-file must be specified")
+	}
+	if _, err := c.CertificateAuthorities(); err != nil {
+		return err
+	}
+	if len(c.Mode) == 0 {
+		c.Mode = ClientAuthModeRequireAndVerify
+	} else if c.Mode != ClientAuthModeRequireAndVerify && c.Mode != ClientAuthModeVerifyIfGiven {
+		return fmt.Errorf("mode must be %s or %s", ClientAuthModeRequireAndVerify, ClientAuthModeVerifyIfGiven)
+	}
+	return nil
+}
+
+// CertificateAuthorities returns the pool of the certificate authorities that the certificates of the clients must be
+// signed by
+func (c *ClientAuthConfig) CertificateAuthorities() (*x509.CertPool, error) {
+	pem, err := os.ReadFile(c.CertificateAuthorityFile)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, errors.New("certificate-authority-file must contain at least one PEM encoded certificate")
+	}
+	return pool, nil
+}
+
+// Type returns the policy of the TLS handshake regarding the certificates of the clients
+func (c *ClientAuthConfig) Type() tls.ClientAuthType {
+	if c.Mode == ClientAuthModeVerifyIfGiven {
+		return tls.VerifyClientCertIfGiven
+	}
+	return tls.RequireAndVerifyClientCert
+}
+
+func (f *IPFilterConfig) validate() error {
+	var err error
+	if f.allowed, err = parseIPRanges(f.Allow); err != nil {
+		return err
+	}
+	if f.denied, err = parseIPRanges(f.Deny); err != nil {
+		return err
+	}
+	for _, rule := range f.Rules {
+		if len(rule.Paths) == 0 {
+			return errors.New("every rule must have at least one path")
+		}
+		for _, p := range rule.Paths {
+			if !strings.HasPrefix(p, "/") {
+				return fmt.Errorf("path %s must start with /", p)
+			}
+		}
+		if len(rule.Allow) == 0 && len(rule.Deny) == 0 {
+			return errors.New("every rule must allow or deny at least one IP or CIDR range")
+		}
+		if rule.allowed, err = parseIPRanges(rule.Allow); err != nil {
+			return err
+		}
+		if rule.denied, err = parseIPRanges(rule.Deny); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientIP returns the IP of the client from the value of the ProxyHeader of a request that comes from one of the
+// TrustedProxies, which is the rightmost IP that isn't one of the TrustedProxies. If every IP is one of the
+// TrustedProxies, the leftmost one is returned, and if the rightmost IPs up to the one of the client include an IP that
+// cannot be parsed, an empty string is returned.
+func (web *Config) ClientIP(header string) string {
+	ips := strings.Split(header, ",")
+	for i := len(ips) - 1; i >= 0; i-- {
+		ip := strings.TrimSpace(ips[i])
+		addr, err := netip.ParseAddr(ip)
+		if err != nil {
+			return ""
+		}
+		if i == 0 || !web.trustedProxies.contains(addr.Unmap()) {
+			return ip
+		}
+	}
+	return ""
+}
+
+// IsAllowed returns whether the IP passed as parameter is allowed to send a request to the path passed as parameter.
+// An IP that cannot be parsed is never allowed.
+func (f *IPFilterConfig) IsAllowed(ip, path string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	if f.denied.contains(addr) || (len(f.allowed) > 0 && !f.allowed.contains(addr)) {
+		return false
+	}
+	for _, rule := range f.Rules {
+		if !rule.matches(path) {
+			continue
+		}
+		if rule.denied.contains(addr) || (len(rule.allowed) > 0 && !rule.allowed.contains(addr)) {
+			return false
+		}
+	}
+	return true
+}
+
+// matches returns whether the rule applies to the path passed as parameter. Like the routes, paths are matched
+// case-insensitively, and empty segments are ignored.
+func (r *IPFilterRule) matches(path string) bool {
+	segments := splitPath(path)
+	for _, p := range r.Paths {
+		patternSegments := splitPath(p)
+		if len(patternSegments) > len(segments) {
+			continue
+		}
+		matched := true
+		for i, patternSegment := range patternSegments {
+			if patternSegment != "*" && !strings.EqualFold(patternSegment, segments[i]) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// splitPath returns the non-empty segments of the path passed as parameter
+func splitPath(path string) []string {
+	return strings.FieldsFunc(path, func(r rune) bool { return r == '/' })
+}
+
+func (c *CORSConfig) validateAndSetDefaults() error {
+	if len(c.AllowedOrigins) == 0 {
+		return errors.New("at least one allowed origin must be specified")
+	}
+	for _, origin := range c.AllowedOrigins {
+		if origin == "*" {
+			if len(c.AllowedOrigins) > 1 {
+				return errors.New("* cannot be combined with other allowed origins")
+			}
+			if c.AllowCredentials {
+				return errors.New("credentials cannot be allowed when every origin is allowed")
+			}
+			continue
+		}
+		if err := validateOrigin(origin); err != nil {
+			return err
+		}
+	}
+	if len(c.AllowedMethods) == 0 {
+		c.AllowedMethods = append([]string{}, DefaultCORSAllowedMethods...)
+	}
+	for i, method := range c.AllowedMethods {
+		c.AllowedMethods[i] = strings.ToUpper(strings.TrimSpace(method))
+	}
+	return nil
+}
+
+func (w *WidgetsConfig) validate() error {
+	if len(w.AllowedOrigins) == 0 {
+		return errors.New("at least one allowed origin must be specified")
+	}
+	for _, origin := range w.AllowedOrigins {
+		if origin == "*" {
+			if len(w.AllowedOrigins) > 1 {
+				return errors.New("* cannot be combined with other allowed origins")
+			}
+			continue
+		}
+		if err := validateOrigin(origin); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateOrigin returns an error if the origin isn't a scheme and a host, optionally preceded by a subdomain wildcard
+func validateOrigin(origin string) error {
+	// A subdomain wildcard is only valid right after the scheme
+	parsedOrigin, err := url.Parse(strings.Replace(origin, "://*.", "://", 1))
+	if err != nil || (parsedOrigin.Scheme != "http" && parsedOrigin.Scheme != "https") || len(parsedOrigin.Host) == 0 || strings.Contains(parsedOrigin.Host, "*") || (len(parsedOrigin.Path) > 0 && parsedOrigin.Path != "/") || len(parsedOrigin.RawQuery) > 0 || len(parsedOrigin.Fragment) > 0 {
+		return fmt.Errorf("invalid allowed origin %q: must be a scheme and a host, e.g. https://example.org", origin)
+	}
+	return nil
+}
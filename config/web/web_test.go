@@ -1,7 +1,10 @@
 package web
 
 import (
+	"crypto/tls"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestGetDefaultConfig(t *testing.T) {
@@ -18,6 +21,25 @@ func TestGetDefaultConfig(t *testing.T) {
 	if defaultConfig.TLS != nil {
 		t.Error("expected default config to have TLS disabled")
 	}
+	if defaultConfig.CacheControl == nil || defaultConfig.CacheControl.Statuses != DefaultCacheControl || defaultConfig.CacheControl.Badges != DefaultCacheControl || defaultConfig.CacheControl.Widgets != DefaultCacheControl {
+		t.Error("expected default config to have the default Cache-Control headers")
+	}
+}
+
+func TestConfig_ValidateAndSetDefaultsWithCacheControl(t *testing.T) {
+	cfg := &Config{CacheControl: &CacheControlConfig{Badges: "public, max-age=300"}}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if cfg.CacheControl.Statuses != DefaultCacheControl {
+		t.Errorf("expected the Cache-Control header of the statuses to default to %q, got %q", DefaultCacheControl, cfg.CacheControl.Statuses)
+	}
+	if cfg.CacheControl.Badges != "public, max-age=300" {
+		t.Errorf("expected the Cache-Control header of the badges to be kept, got %q", cfg.CacheControl.Badges)
+	}
+	if cfg.CacheControl.Widgets != DefaultCacheControl {
+		t.Errorf("expected the Cache-Control header of the widgets to default to %q, got %q", DefaultCacheControl, cfg.CacheControl.Widgets)
+	}
 }
 
 func TestConfig_ValidateAndSetDefaults(t *testing.T) {
@@ -185,3 +207,332 @@ func TestConfig_isValid(t *testing.T) {
 		})
 	}
 }
+
+func TestCORSConfig_validateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name                   string
+		cfg                    *CORSConfig
+		expectedAllowedMethods []string
+		expectedErr            bool
+	}{
+		{
+			name:                   "origin",
+			cfg:                    &CORSConfig{AllowedOrigins: []string{"https://example.org", "http://localhost:3000/"}, AllowCredentials: true},
+			expectedAllowedMethods: DefaultCORSAllowedMethods,
+		},
+		{
+			name:                   "subdomain-wildcard",
+			cfg:                    &CORSConfig{AllowedOrigins: []string{"https://*.example.org"}, AllowedMethods: []string{"get", " POST"}},
+			expectedAllowedMethods: []string{"GET", "POST"},
+		},
+		{
+			name:                   "every-origin",
+			cfg:                    &CORSConfig{AllowedOrigins: []string{"*"}},
+			expectedAllowedMethods: DefaultCORSAllowedMethods,
+		},
+		{
+			name:        "no-origin",
+			cfg:         &CORSConfig{},
+			expectedErr: true,
+		},
+		{
+			name:        "every-origin-with-credentials",
+			cfg:         &CORSConfig{AllowedOrigins: []string{"*"}, AllowCredentials: true},
+			expectedErr: true,
+		},
+		{
+			name:        "every-origin-with-other-origins",
+			cfg:         &CORSConfig{AllowedOrigins: []string{"*", "https://example.org"}},
+			expectedErr: true,
+		},
+		{
+			name:        "origin-without-scheme",
+			cfg:         &CORSConfig{AllowedOrigins: []string{"example.org"}},
+			expectedErr: true,
+		},
+		{
+			name:        "origin-with-path",
+			cfg:         &CORSConfig{AllowedOrigins: []string{"https://example.org/status"}},
+			expectedErr: true,
+		},
+		{
+			name:        "origin-with-misplaced-wildcard",
+			cfg:         &CORSConfig{AllowedOrigins: []string{"https://status.*.org"}},
+			expectedErr: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			err := (&Config{CORS: scenario.cfg}).ValidateAndSetDefaults()
+			if (err != nil) != scenario.expectedErr {
+				t.Fatalf("expected the existence of an error to be %v, got %v", scenario.expectedErr, err)
+			}
+			if !scenario.expectedErr && strings.Join(scenario.cfg.AllowedMethods, ",") != strings.Join(scenario.expectedAllowedMethods, ",") {
+				t.Errorf("expected AllowedMethods to be %v, got %v", scenario.expectedAllowedMethods, scenario.cfg.AllowedMethods)
+			}
+		})
+	}
+}
+
+func TestWidgetsConfig_validate(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		cfg         *WidgetsConfig
+		expectedErr bool
+	}{
+		{
+			name: "origins",
+			cfg:  &WidgetsConfig{AllowedOrigins: []string{"https://example.org", "https://*.example.com"}},
+		},
+		{
+			name: "every-origin",
+			cfg:  &WidgetsConfig{AllowedOrigins: []string{"*"}},
+		},
+		{
+			name:        "no-origin",
+			cfg:         &WidgetsConfig{},
+			expectedErr: true,
+		},
+		{
+			name:        "every-origin-with-other-origins",
+			cfg:         &WidgetsConfig{AllowedOrigins: []string{"*", "https://example.org"}},
+			expectedErr: true,
+		},
+		{
+			name:        "origin-with-path",
+			cfg:         &WidgetsConfig{AllowedOrigins: []string{"https://example.org/status"}},
+			expectedErr: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			err := (&Config{Widgets: scenario.cfg}).ValidateAndSetDefaults()
+			if (err != nil) != scenario.expectedErr {
+				t.Fatalf("expected the existence of an error to be %v, got %v", scenario.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestConfig_ValidateAndSetDefaultsWithRateLimit(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		cfg            *RateLimitConfig
+		expectedWindow time.Duration
+		expectedErr    bool
+	}{
+		{
+			name:           "default-window",
+			cfg:            &RateLimitConfig{MaximumRequests: 60},
+			expectedWindow: DefaultRateLimitWindow,
+		},
+		{
+			name:           "custom-window",
+			cfg:            &RateLimitConfig{MaximumRequests: 60, Window: 10 * time.Second},
+			expectedWindow: 10 * time.Second,
+		},
+		{
+			name:        "no-maximum-requests",
+			cfg:         &RateLimitConfig{Window: time.Minute},
+			expectedErr: true,
+		},
+		{
+			name:        "negative-window",
+			cfg:         &RateLimitConfig{MaximumRequests: 60, Window: -time.Minute},
+			expectedErr: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			err := (&Config{RateLimit: scenario.cfg}).ValidateAndSetDefaults()
+			if (err != nil) != scenario.expectedErr {
+				t.Fatalf("expected the existence of an error to be %v, got %v", scenario.expectedErr, err)
+			}
+			if !scenario.expectedErr && scenario.cfg.Window != scenario.expectedWindow {
+				t.Errorf("expected Window to be %s, got %s", scenario.expectedWindow, scenario.cfg.Window)
+			}
+		})
+	}
+}
+
+func TestConfig_ValidateAndSetDefaultsWithClientAuth(t *testing.T) {
+	scenarios := []struct {
+		name               string
+		clientAuth         *ClientAuthConfig
+		expectedErr        bool
+		expectedMode       ClientAuthMode
+		expectedClientAuth tls.ClientAuthType
+	}{
+		{
+			name:               "default-mode",
+			clientAuth:         &ClientAuthConfig{CertificateAuthorityFile: "../../testdata/cert.pem"},
+			expectedMode:       ClientAuthModeRequireAndVerify,
+			expectedClientAuth: tls.RequireAndVerifyClientCert,
+		},
+		{
+			name:               "verify-if-given",
+			clientAuth:         &ClientAuthConfig{CertificateAuthorityFile: "../../testdata/cert.pem", Mode: ClientAuthModeVerifyIfGiven},
+			expectedMode:       ClientAuthModeVerifyIfGiven,
+			expectedClientAuth: tls.VerifyClientCertIfGiven,
+		},
+		{
+			name:        "invalid-mode",
+			clientAuth:  &ClientAuthConfig{CertificateAuthorityFile: "../../testdata/cert.pem", Mode: "request"},
+			expectedErr: true,
+		},
+		{
+			name:        "no-certificate-authority-file",
+			clientAuth:  &ClientAuthConfig{},
+			expectedErr: true,
+		},
+		{
+			name:        "missing-certificate-authority-file",
+			clientAuth:  &ClientAuthConfig{CertificateAuthorityFile: "doesnotexist"},
+			expectedErr: true,
+		},
+		{
+			name:        "certificate-authority-file-without-certificate",
+			clientAuth:  &ClientAuthConfig{CertificateAuthorityFile: "../../testdata/cert.key"},
+			expectedErr: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			cfg := &Config{TLS: &TLSConfig{CertificateFile: "../../testdata/cert.pem", PrivateKeyFile: "../../testdata/cert.key", ClientAuth: scenario.clientAuth}}
+			err := cfg.ValidateAndSetDefaults()
+			if (err != nil) != scenario.expectedErr {
+				t.Fatalf("expected the existence of an error to be %v, got %v", scenario.expectedErr, err)
+			}
+			if scenario.expectedErr {
+				return
+			}
+			if !cfg.HasClientAuth() {
+				t.Error("expected HasClientAuth to return true")
+			}
+			if cfg.TLS.ClientAuth.Mode != scenario.expectedMode {
+				t.Errorf("expected Mode to be %s, got %s", scenario.expectedMode, cfg.TLS.ClientAuth.Mode)
+			}
+			if cfg.TLS.ClientAuth.Type() != scenario.expectedClientAuth {
+				t.Errorf("expected Type to return %s, got %s", scenario.expectedClientAuth, cfg.TLS.ClientAuth.Type())
+			}
+		})
+	}
+}
+
+func TestIPFilterConfig_IsAllowed(t *testing.T) {
+	ipFilter := &IPFilterConfig{
+		Deny: []string{"203.0.113.7"},
+		Rules: []*IPFilterRule{
+			{Paths: []string{"/api/v1/endpoints/*/external", "/api/v1/endpoints/external"}, Allow: []string{"10.0.0.0/8", "fd00::/8"}},
+			{Paths: []string{"/metrics"}, Deny: []string{"10.1.0.0/16"}},
+		},
+	}
+	if err := (&Config{IPFilter: ipFilter}).ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	scenarios := []struct {
+		ip       string
+		path     string
+		expected bool
+	}{
+		{ip: "198.51.100.1", path: "/api/v1/endpoints/statuses", expected: true},
+		{ip: "203.0.113.7", path: "/api/v1/endpoints/statuses", expected: false},
+		{ip: "198.51.100.1", path: "/api/v1/endpoints/core_ext/external", expected: false},
+		{ip: "198.51.100.1", path: "/API/v1/endpoints/core_ext/external", expected: false},
+		{ip: "198.51.100.1", path: "//api/v1/endpoints//external", expected: false},
+		{ip: "10.2.3.4", path: "/api/v1/endpoints/core_ext/external", expected: true},
+		{ip: "::ffff:10.2.3.4", path: "/api/v1/endpoints/external", expected: true},
+		{ip: "fd12::1", path: "/api/v1/endpoints/external", expected: true},
+		{ip: "10.1.2.3", path: "/metrics", expected: false},
+		{ip: "10.2.3.4", path: "/metrics", expected: true},
+		{ip: "not-an-ip", path: "/", expected: false},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.ip+scenario.path, func(t *testing.T) {
+			if actual := ipFilter.IsAllowed(scenario.ip, scenario.path); actual != scenario.expected {
+				t.Errorf("expected IsAllowed(%s, %s) to be %v, got %v", scenario.ip, scenario.path, scenario.expected, actual)
+			}
+		})
+	}
+}
+
+func TestConfig_ClientIP(t *testing.T) {
+	cfg := &Config{TrustedProxies: []string{"10.0.0.0/8", "fd00::1"}}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	scenarios := []struct {
+		header   string
+		expected string
+	}{
+		{header: "198.51.100.1", expected: "198.51.100.1"},
+		{header: "198.51.100.1, 10.0.0.1", expected: "198.51.100.1"},
+		{header: "203.0.113.7, 198.51.100.1, 10.0.0.1,10.0.0.2", expected: "198.51.100.1"},
+		{header: "10.0.0.3, 198.51.100.1", expected: "198.51.100.1"},
+		{header: "198.51.100.1, ::ffff:10.0.0.1, fd00::1", expected: "198.51.100.1"},
+		{header: "10.0.0.3, 10.0.0.1", expected: "10.0.0.3"},
+		{header: "198.51.100.1, not-an-ip, 10.0.0.1", expected: ""},
+		{header: "not-an-ip", expected: ""},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.header, func(t *testing.T) {
+			if actual := cfg.ClientIP(scenario.header); actual != scenario.expected {
+				t.Errorf("expected ClientIP(%s) to be %s, got %s", scenario.header, scenario.expected, actual)
+			}
+		})
+	}
+}
+
+func TestConfig_ValidateAndSetDefaultsWithIPFilter(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		cfg         *Config
+		expectedErr bool
+	}{
+		{
+			name: "valid",
+			cfg:  &Config{TrustedProxies: []string{"10.0.0.1", "172.16.0.0/12"}, IPFilter: &IPFilterConfig{Allow: []string{"10.0.0.0/8"}, Rules: []*IPFilterRule{{Paths: []string{"/api"}, Deny: []string{"10.1.0.0/16"}}}}},
+		},
+		{
+			name:        "invalid-trusted-proxy",
+			cfg:         &Config{TrustedProxies: []string{"proxy.example.org"}},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid-allowed-range",
+			cfg:         &Config{IPFilter: &IPFilterConfig{Allow: []string{"10.0.0.0/33"}}},
+			expectedErr: true,
+		},
+		{
+			name:        "rule-without-paths",
+			cfg:         &Config{IPFilter: &IPFilterConfig{Rules: []*IPFilterRule{{Allow: []string{"10.0.0.0/8"}}}}},
+			expectedErr: true,
+		},
+		{
+			name:        "rule-with-relative-path",
+			cfg:         &Config{IPFilter: &IPFilterConfig{Rules: []*IPFilterRule{{Paths: []string{"api"}, Allow: []string{"10.0.0.0/8"}}}}},
+			expectedErr: true,
+		},
+		{
+			name:        "rule-without-ranges",
+			cfg:         &Config{IPFilter: &IPFilterConfig{Rules: []*IPFilterRule{{Paths: []string{"/api"}}}}},
+			expectedErr: true,
+		},
+		{
+			name:        "rule-with-invalid-denied-range",
+			cfg:         &Config{IPFilter: &IPFilterConfig{Rules: []*IPFilterRule{{Paths: []string{"/api"}, Deny: []string{"10.0.0"}}}}},
+			expectedErr: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			err := scenario.cfg.ValidateAndSetDefaults()
+			if (err != nil) != scenario.expectedErr {
+				t.Errorf("expected the existence of an error to be %v, got %v", scenario.expectedErr, err)
+			}
+			if err == nil && scenario.cfg.ProxyHeader != DefaultProxyHeader {
+				t.Errorf("expected ProxyHeader to default to %s, got %s", DefaultProxyHeader, scenario.cfg.ProxyHeader)
+			}
+		})
+	}
+}
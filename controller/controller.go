@@ -1,12 +1,17 @@
 package controller
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"log"
+	"net"
 	"os"
 	"time"
 
 	"github.com/TwiN/gatus/v5/api"
 	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/stream"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -26,7 +31,15 @@ func Handle(cfg *config.Config) {
 		return
 	}
 	log.Println("[controller.Handle] Listening on " + cfg.Web.SocketAddress())
-	if cfg.Web.HasTLS() {
+	if cfg.Web.HasClientAuth() || (cfg.Web.HasTLS() && cfg.MetricsSecurity != nil && len(cfg.MetricsSecurity.ClientCertificateAuthorityFile) > 0) {
+		listener, err := newMutualTLSListener(cfg)
+		if err != nil {
+			log.Fatal("[controller.Handle]", err)
+		}
+		if err = app.Listener(listener); err != nil {
+			log.Fatal("[controller.Handle]", err)
+		}
+	} else if cfg.Web.HasTLS() {
 		err := app.ListenTLS(cfg.Web.SocketAddress(), cfg.Web.TLS.CertificateFile, cfg.Web.TLS.PrivateKeyFile)
 		if err != nil {
 			log.Fatal("[controller.Handle]", err)
@@ -40,9 +53,49 @@ func Handle(cfg *config.Config) {
 	log.Println("[controller.Handle] Server has shut down successfully")
 }
 
+// newMutualTLSListener returns a TLS listener that verifies the certificates presented by the clients against the
+// certificate authorities of web.tls.client-auth and of the metrics security configuration.
+//
+// Unless web.tls.client-auth requires every client to present a certificate, presenting one is optional, because the
+// certificate authorities of the metrics security configuration are only used to authenticate the requests to the
+// metrics, and the dashboard must still be served to every other client.
+func newMutualTLSListener(cfg *config.Config) (net.Listener, error) {
+	certificate, err := tls.LoadX509KeyPair(cfg.Web.TLS.CertificateFile, cfg.Web.TLS.PrivateKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	clientAuth := tls.VerifyClientCertIfGiven
+	var certificateAuthorityFiles []string
+	if cfg.Web.HasClientAuth() {
+		clientAuth = cfg.Web.TLS.ClientAuth.Type()
+		certificateAuthorityFiles = append(certificateAuthorityFiles, cfg.Web.TLS.ClientAuth.CertificateAuthorityFile)
+	}
+	if cfg.MetricsSecurity != nil && len(cfg.MetricsSecurity.ClientCertificateAuthorityFile) > 0 {
+		certificateAuthorityFiles = append(certificateAuthorityFiles, cfg.MetricsSecurity.ClientCertificateAuthorityFile)
+	}
+	clientCertificateAuthorities := x509.NewCertPool()
+	for _, certificateAuthorityFile := range certificateAuthorityFiles {
+		pem, err := os.ReadFile(certificateAuthorityFile)
+		if err != nil {
+			return nil, err
+		}
+		if !clientCertificateAuthorities.AppendCertsFromPEM(pem) {
+			return nil, errors.New("no PEM encoded certificate found in " + certificateAuthorityFile)
+		}
+	}
+	return tls.Listen(fiber.NetworkTCP, cfg.Web.SocketAddress(), &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{certificate},
+		ClientAuth:   clientAuth,
+		ClientCAs:    clientCertificateAuthorities,
+	})
+}
+
 // Shutdown stops the server
 func Shutdown() {
 	if app != nil {
+		// Live status updates never end on their own, so they must be ended for the server to be able to shut down
+		stream.UnsubscribeAll()
 		_ = app.Shutdown()
 		app = nil
 	}
@@ -1,15 +1,28 @@
 package controller
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	crand "crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
 	"math/rand"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/TwiN/gatus/v5/config"
 	"github.com/TwiN/gatus/v5/config/endpoint"
 	"github.com/TwiN/gatus/v5/config/web"
+	"github.com/TwiN/gatus/v5/security"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -92,6 +105,167 @@ func TestHandleTLS(t *testing.T) {
 	}
 }
 
+func TestHandleMutualTLS(t *testing.T) {
+	certificateAuthorityFile, clientCertificate := newClientCertificate(t, "prometheus")
+	port := getFreePort(t)
+	cfg := &config.Config{
+		Metrics:         true,
+		MetricsSecurity: &security.MetricsConfig{ClientCertificateAuthorityFile: certificateAuthorityFile},
+		Web:             &web.Config{Address: "127.0.0.1", Port: port, TLS: &web.TLSConfig{CertificateFile: "../testdata/cert.pem", PrivateKeyFile: "../testdata/cert.key"}},
+		Endpoints:       []*endpoint.Endpoint{{Name: "frontend", Group: "core"}},
+	}
+	if err := cfg.Web.ValidateAndSetDefaults(); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.MetricsSecurity.ValidateAndSetDefaults(); err != nil {
+		t.Fatal(err)
+	}
+	go Handle(cfg)
+	defer Shutdown()
+	waitUntilListening(t, port, clientCertificate)
+	scenarios := []struct {
+		name               string
+		path               string
+		certificates       []tls.Certificate
+		expectedStatusCode int
+	}{
+		{name: "health-without-certificate", path: "/health", expectedStatusCode: 200},
+		{name: "metrics-without-certificate", path: "/metrics", expectedStatusCode: 401},
+		{name: "metrics-with-certificate", path: "/metrics", certificates: []tls.Certificate{clientCertificate}, expectedStatusCode: 200},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			statusCode, err := getOverTLS(port, scenario.path, scenario.certificates...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if statusCode != scenario.expectedStatusCode {
+				t.Errorf("GET %s should have returned %d, but returned %d instead", scenario.path, scenario.expectedStatusCode, statusCode)
+			}
+		})
+	}
+}
+
+func TestHandleClientAuth(t *testing.T) {
+	certificateAuthorityFile, clientCertificate := newClientCertificate(t, "john.doe")
+	metricsCertificateAuthorityFile, metricsClientCertificate := newClientCertificate(t, "prometheus")
+	port := getFreePort(t)
+	cfg := &config.Config{
+		Metrics:         true,
+		MetricsSecurity: &security.MetricsConfig{ClientCertificateAuthorityFile: metricsCertificateAuthorityFile},
+		Web: &web.Config{Address: "127.0.0.1", Port: port, TLS: &web.TLSConfig{
+			CertificateFile: "../testdata/cert.pem",
+			PrivateKeyFile:  "../testdata/cert.key",
+			ClientAuth:      &web.ClientAuthConfig{CertificateAuthorityFile: certificateAuthorityFile},
+		}},
+		Endpoints: []*endpoint.Endpoint{{Name: "frontend", Group: "core"}},
+	}
+	if err := cfg.Web.ValidateAndSetDefaults(); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.MetricsSecurity.ValidateAndSetDefaults(); err != nil {
+		t.Fatal(err)
+	}
+	go Handle(cfg)
+	defer Shutdown()
+	waitUntilListening(t, port, clientCertificate)
+	scenarios := []struct {
+		name               string
+		path               string
+		certificates       []tls.Certificate
+		expectedErr        bool
+		expectedStatusCode int
+	}{
+		{name: "health-without-certificate", path: "/health", expectedErr: true},
+		{name: "health-with-certificate", path: "/health", certificates: []tls.Certificate{clientCertificate}, expectedStatusCode: 200},
+		{name: "health-with-metrics-certificate", path: "/health", certificates: []tls.Certificate{metricsClientCertificate}, expectedStatusCode: 403},
+		{name: "metrics-with-certificate", path: "/metrics", certificates: []tls.Certificate{clientCertificate}, expectedStatusCode: 401},
+		{name: "metrics-with-metrics-certificate", path: "/metrics", certificates: []tls.Certificate{metricsClientCertificate}, expectedStatusCode: 200},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			statusCode, err := getOverTLS(port, scenario.path, scenario.certificates...)
+			if (err != nil) != scenario.expectedErr {
+				t.Fatalf("expected the existence of an error to be %v, got %v", scenario.expectedErr, err)
+			}
+			if statusCode != scenario.expectedStatusCode {
+				t.Errorf("GET %s should have returned %d, but returned %d instead", scenario.path, scenario.expectedStatusCode, statusCode)
+			}
+		})
+	}
+}
+
+// newClientCertificate generates a certificate authority, whose certificate is written to a temporary file, and a
+// client certificate signed by it
+func newClientCertificate(t *testing.T, commonName string) (string, tls.Certificate) {
+	certificateAuthorityKey, _ := ecdsa.GenerateKey(elliptic.P256(), crand.Reader)
+	certificateAuthorityTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Gatus test CA for " + commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	certificateAuthorityDER, err := x509.CreateCertificate(crand.Reader, certificateAuthorityTemplate, certificateAuthorityTemplate, &certificateAuthorityKey.PublicKey, certificateAuthorityKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientKey, _ := ecdsa.GenerateKey(elliptic.P256(), crand.Reader)
+	clientDER, err := x509.CreateCertificate(crand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, certificateAuthorityTemplate, &clientKey.PublicKey, certificateAuthorityKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificateAuthorityFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(certificateAuthorityFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificateAuthorityDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return certificateAuthorityFile, tls.Certificate{Certificate: [][]byte{clientDER}, PrivateKey: clientKey}
+}
+
+// getFreePort returns a port that is free to listen on
+func getFreePort(t *testing.T) int {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	_ = listener.Close()
+	return port
+}
+
+// getOverTLS sends a GET request to the server listening on the port passed as parameter, presenting the certificates
+// passed as parameter, and returns the status code of the response
+func getOverTLS(port int, path string, certificates ...tls.Certificate) (int, error) {
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true, Certificates: certificates}}}
+	response, err := client.Get(fmt.Sprintf("https://127.0.0.1:%d%s", port, path))
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	return response.StatusCode, nil
+}
+
+// waitUntilListening waits for the server listening on the port passed as parameter to respond to requests
+func waitUntilListening(t *testing.T, port int, certificates ...tls.Certificate) {
+	for i := 0; ; i++ {
+		if _, err := getOverTLS(port, "/health", certificates...); err == nil {
+			return
+		} else if i == 50 {
+			t.Fatal("server never started listening:", err)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
 func TestShutdown(t *testing.T) {
 	// Pretend that we called controller.Handle(), which initializes the server variable
 	app = fiber.New()
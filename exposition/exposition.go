@@ -0,0 +1,217 @@
+package exposition
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+var (
+	// ErrInvalidSelector is the error returned when a selector cannot be parsed
+	ErrInvalidSelector = errors.New("invalid metric selector: expected format is name or name{label=\"value\",...}")
+
+	// ErrMetricNotFound is the error returned when no sample matches the selector
+	ErrMetricNotFound = errors.New("no sample matching the metric selector was found")
+)
+
+// Eval parses a body in the Prometheus text exposition format and returns the value of the first sample that matches
+// the selector passed as parameter.
+//
+// The selector is made up of a metric name and, optionally, a set of labels that the sample must have,
+// e.g. queue_depth{queue="email"}. Labels that are not part of the selector are ignored.
+//
+// The _sum, _count and _bucket series of summaries and histograms are supported, as are quantiles through the
+// quantile label, e.g. http_request_duration_seconds{quantile="0.99"}.
+func Eval(selector string, body []byte) (string, error) {
+	name, labels, err := parseSelector(selector)
+	if err != nil {
+		return "", err
+	}
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("error parsing metrics: %w", err)
+	}
+	if family, exists := families[name]; exists {
+		for _, m := range family.GetMetric() {
+			if !hasLabels(m, labels) {
+				continue
+			}
+			if value, ok := sampleValue(family.GetType(), m, labels); ok {
+				return formatFloat(value), nil
+			}
+		}
+	}
+	// The sample may be part of a summary or histogram, in which case the family is registered under the base name
+	for _, suffix := range []string{"_sum", "_count", "_bucket"} {
+		if !strings.HasSuffix(name, suffix) {
+			continue
+		}
+		family, exists := families[strings.TrimSuffix(name, suffix)]
+		if !exists {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			if value, ok := seriesValue(family.GetType(), m, suffix, labels); ok {
+				return formatFloat(value), nil
+			}
+		}
+	}
+	return "", ErrMetricNotFound
+}
+
+// parseSelector parses a selector such as queue_depth{queue="email"} into a metric name and a map of labels
+func parseSelector(selector string) (string, map[string]string, error) {
+	selector = strings.TrimSpace(selector)
+	start := strings.Index(selector, "{")
+	if start == -1 {
+		if len(selector) == 0 {
+			return "", nil, ErrInvalidSelector
+		}
+		return selector, nil, nil
+	}
+	if !strings.HasSuffix(selector, "}") || start == 0 {
+		return "", nil, ErrInvalidSelector
+	}
+	name := strings.TrimSpace(selector[:start])
+	labels := make(map[string]string)
+	rest := selector[start+1 : len(selector)-1]
+	for {
+		rest = strings.TrimLeft(rest, " ,")
+		if len(rest) == 0 {
+			break
+		}
+		equalSignIndex := strings.Index(rest, "=")
+		if equalSignIndex <= 0 {
+			return "", nil, ErrInvalidSelector
+		}
+		key := strings.TrimSpace(rest[:equalSignIndex])
+		rest = strings.TrimSpace(rest[equalSignIndex+1:])
+		if len(rest) == 0 || rest[0] != '"' {
+			return "", nil, ErrInvalidSelector
+		}
+		// Look for the closing quote, taking escaped quotes into account
+		var value strings.Builder
+		closed := false
+		i := 1
+		for ; i < len(rest); i++ {
+			if rest[i] == '\\' && i+1 < len(rest) {
+				i++
+				switch rest[i] {
+				case 'n':
+					value.WriteByte('\n')
+				default:
+					value.WriteByte(rest[i])
+				}
+				continue
+			}
+			if rest[i] == '"' {
+				closed = true
+				break
+			}
+			value.WriteByte(rest[i])
+		}
+		if !closed {
+			return "", nil, ErrInvalidSelector
+		}
+		labels[key] = value.String()
+		rest = rest[i+1:]
+	}
+	return name, labels, nil
+}
+
+// hasLabels checks whether the metric has all the labels passed as parameter.
+// The quantile and le labels are ignored, since they're not labels of the metric but of its samples.
+func hasLabels(m *dto.Metric, labels map[string]string) bool {
+	for key, value := range labels {
+		if key == "quantile" || key == "le" {
+			continue
+		}
+		found := false
+		for _, pair := range m.GetLabel() {
+			if pair.GetName() == key && pair.GetValue() == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// sampleValue returns the value of a metric whose name matched the selector exactly
+func sampleValue(metricType dto.MetricType, m *dto.Metric, labels map[string]string) (float64, bool) {
+	switch metricType {
+	case dto.MetricType_COUNTER:
+		return m.GetCounter().GetValue(), true
+	case dto.MetricType_GAUGE:
+		return m.GetGauge().GetValue(), true
+	case dto.MetricType_UNTYPED:
+		return m.GetUntyped().GetValue(), true
+	case dto.MetricType_SUMMARY:
+		quantile, exists := labels["quantile"]
+		if !exists {
+			return 0, false
+		}
+		for _, q := range m.GetSummary().GetQuantile() {
+			if formatFloat(q.GetQuantile()) == quantile {
+				return q.GetValue(), true
+			}
+		}
+	}
+	return 0, false
+}
+
+// seriesValue returns the value of the _sum, _count or _bucket series of a summary or a histogram
+func seriesValue(metricType dto.MetricType, m *dto.Metric, suffix string, labels map[string]string) (float64, bool) {
+	if !hasLabels(m, labels) {
+		return 0, false
+	}
+	switch metricType {
+	case dto.MetricType_SUMMARY:
+		switch suffix {
+		case "_sum":
+			return m.GetSummary().GetSampleSum(), true
+		case "_count":
+			return float64(m.GetSummary().GetSampleCount()), true
+		}
+	case dto.MetricType_HISTOGRAM:
+		switch suffix {
+		case "_sum":
+			return m.GetHistogram().GetSampleSum(), true
+		case "_count":
+			return float64(m.GetHistogram().GetSampleCount()), true
+		case "_bucket":
+			le, exists := labels["le"]
+			if !exists {
+				return 0, false
+			}
+			if le == "+Inf" {
+				return float64(m.GetHistogram().GetSampleCount()), true
+			}
+			for _, bucket := range m.GetHistogram().GetBucket() {
+				if formatFloat(bucket.GetUpperBound()) == le {
+					return float64(bucket.GetCumulativeCount()), true
+				}
+			}
+		}
+	}
+	return 0, false
+}
+
+func formatFloat(f float64) string {
+	if math.IsInf(f, 1) {
+		return "+Inf"
+	} else if math.IsInf(f, -1) {
+		return "-Inf"
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
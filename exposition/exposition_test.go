@@ -0,0 +1,166 @@
+package exposition
+
+import (
+	"testing"
+)
+
+const data = `# HELP queue_depth Number of messages waiting in the queue.
+# TYPE queue_depth gauge
+queue_depth{queue="email"} 42
+queue_depth{queue="sms",region="us-east-1"} 7
+# HELP jobs_processed_total Total number of jobs processed.
+# TYPE jobs_processed_total counter
+jobs_processed_total 1.5e+06
+# TYPE up untyped
+up 1
+# HELP rpc_duration_seconds A summary of the RPC duration in seconds.
+# TYPE rpc_duration_seconds summary
+rpc_duration_seconds{quantile="0.5"} 0.05
+rpc_duration_seconds{quantile="0.99"} 0.25
+rpc_duration_seconds_sum 1234.5
+rpc_duration_seconds_count 9000
+# HELP http_request_duration_seconds A histogram of the request duration.
+# TYPE http_request_duration_seconds histogram
+http_request_duration_seconds_bucket{le="0.1"} 100
+http_request_duration_seconds_bucket{le="0.5"} 150
+http_request_duration_seconds_bucket{le="+Inf"} 160
+http_request_duration_seconds_sum 53.2
+http_request_duration_seconds_count 160
+`
+
+func TestEval(t *testing.T) {
+	type Scenario struct {
+		Name           string
+		Selector       string
+		Data           string
+		ExpectedOutput string
+		ExpectedError  bool
+	}
+	scenarios := []Scenario{
+		{
+			Name:           "gauge-with-label",
+			Selector:       `queue_depth{queue="email"}`,
+			Data:           data,
+			ExpectedOutput: "42",
+		},
+		{
+			Name:           "gauge-with-subset-of-labels",
+			Selector:       `queue_depth{queue="sms"}`,
+			Data:           data,
+			ExpectedOutput: "7",
+		},
+		{
+			Name:           "gauge-with-multiple-labels",
+			Selector:       `queue_depth{queue="sms", region="us-east-1"}`,
+			Data:           data,
+			ExpectedOutput: "7",
+		},
+		{
+			Name:           "gauge-without-labels-returns-first-sample",
+			Selector:       `queue_depth`,
+			Data:           data,
+			ExpectedOutput: "42",
+		},
+		{
+			Name:          "gauge-with-unknown-label-value",
+			Selector:      `queue_depth{queue="push"}`,
+			Data:          data,
+			ExpectedError: true,
+		},
+		{
+			Name:           "counter",
+			Selector:       `jobs_processed_total`,
+			Data:           data,
+			ExpectedOutput: "1500000",
+		},
+		{
+			Name:           "untyped",
+			Selector:       `up`,
+			Data:           data,
+			ExpectedOutput: "1",
+		},
+		{
+			Name:           "summary-quantile",
+			Selector:       `rpc_duration_seconds{quantile="0.99"}`,
+			Data:           data,
+			ExpectedOutput: "0.25",
+		},
+		{
+			Name:           "summary-sum",
+			Selector:       `rpc_duration_seconds_sum`,
+			Data:           data,
+			ExpectedOutput: "1234.5",
+		},
+		{
+			Name:           "summary-count",
+			Selector:       `rpc_duration_seconds_count`,
+			Data:           data,
+			ExpectedOutput: "9000",
+		},
+		{
+			Name:           "histogram-bucket",
+			Selector:       `http_request_duration_seconds_bucket{le="0.5"}`,
+			Data:           data,
+			ExpectedOutput: "150",
+		},
+		{
+			Name:           "histogram-bucket-inf",
+			Selector:       `http_request_duration_seconds_bucket{le="+Inf"}`,
+			Data:           data,
+			ExpectedOutput: "160",
+		},
+		{
+			Name:           "histogram-count",
+			Selector:       `http_request_duration_seconds_count`,
+			Data:           data,
+			ExpectedOutput: "160",
+		},
+		{
+			Name:          "unknown-metric",
+			Selector:      `unknown_metric`,
+			Data:          data,
+			ExpectedError: true,
+		},
+		{
+			Name:          "invalid-selector",
+			Selector:      `queue_depth{queue=email}`,
+			Data:          data,
+			ExpectedError: true,
+		},
+		{
+			Name:          "invalid-selector-unclosed-quote",
+			Selector:      `queue_depth{queue="email}`,
+			Data:          data,
+			ExpectedError: true,
+		},
+		{
+			Name:          "empty-selector",
+			Selector:      ``,
+			Data:          data,
+			ExpectedError: true,
+		},
+		{
+			Name:          "invalid-data",
+			Selector:      `queue_depth`,
+			Data:          `{"queue_depth": 42}`,
+			ExpectedError: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			output, err := Eval(scenario.Selector, []byte(scenario.Data))
+			if scenario.ExpectedError {
+				if err == nil {
+					t.Errorf("Expected error, got '%v'", output)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got '%v'", err)
+			}
+			if output != scenario.ExpectedOutput {
+				t.Errorf("Expected output to be %v, but was %v", scenario.ExpectedOutput, output)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+// Package lifecycle broadcasts the lifecycle events of Gatus, such as endpoints being added or removed, maintenance
+// windows starting or ending, the configuration being reloaded and checks being skipped, and sends them to the
+// configured webhooks so that external automation can react to them.
+package lifecycle
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// SubscriberBufferSize is the number of events that can be queued for a subscriber before the events sent to said
+// subscriber are dropped
+const SubscriberBufferSize = 256
+
+// EventType is the type of lifecycle event
+type EventType string
+
+const (
+	// EventTypeEndpointAdded is a type of event that represents an endpoint being added by a reload of the configuration
+	EventTypeEndpointAdded EventType = "endpoint-added"
+
+	// EventTypeEndpointRemoved is a type of event that represents an endpoint being removed by a reload of the
+	// configuration
+	EventTypeEndpointRemoved EventType = "endpoint-removed"
+
+	// EventTypeMaintenanceStarted is a type of event that represents the maintenance window starting
+	EventTypeMaintenanceStarted EventType = "maintenance-started"
+
+	// EventTypeMaintenanceEnded is a type of event that represents the maintenance window ending
+	EventTypeMaintenanceEnded EventType = "maintenance-ended"
+
+	// EventTypeConfigurationReloaded is a type of event that represents the configuration being reloaded
+	EventTypeConfigurationReloaded EventType = "configuration-reloaded"
+
+	// EventTypeCheckSkipped is a type of event that represents the check of an endpoint being skipped
+	EventTypeCheckSkipped EventType = "check-skipped"
+)
+
+// EventTypes is the list of every type of lifecycle event
+var EventTypes = []EventType{
+	EventTypeEndpointAdded,
+	EventTypeEndpointRemoved,
+	EventTypeMaintenanceStarted,
+	EventTypeMaintenanceEnded,
+	EventTypeConfigurationReloaded,
+	EventTypeCheckSkipped,
+}
+
+// IsValid returns whether the event type is one of EventTypes
+func (t EventType) IsValid() bool {
+	for _, eventType := range EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	// SkipReasonPaused is the reason of the check of an endpoint being skipped because the endpoint is paused
+	SkipReasonPaused = "paused"
+
+	// SkipReasonNoConnectivity is the reason of the check of an endpoint being skipped because Gatus has no
+	// connectivity
+	SkipReasonNoConnectivity = "no-connectivity"
+)
+
+// Event is a lifecycle event
+type Event struct {
+	// Type is the kind of event
+	Type EventType `json:"type"`
+
+	// Description is a human-readable description of the event
+	Description string `json:"description"`
+
+	// Key of the endpoint. Only set if the event concerns an endpoint.
+	Key string `json:"key,omitempty"`
+
+	// Name of the endpoint. Only set if the event concerns an endpoint.
+	Name string `json:"name,omitempty"`
+
+	// Group of the endpoint. Only set if the event concerns an endpoint that has a group.
+	Group string `json:"group,omitempty"`
+
+	// Reason the check of the endpoint was skipped, which is either SkipReasonPaused or SkipReasonNoConnectivity.
+	// Only set if Type is EventTypeCheckSkipped.
+	Reason string `json:"reason,omitempty"`
+
+	// Timestamp is when the event happened
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// NewEvent creates an event of the type passed as parameter which happens now
+func NewEvent(eventType EventType, description string) *Event {
+	return &Event{Type: eventType, Description: description, Timestamp: time.Now()}
+}
+
+// WithEndpoint sets the endpoint the event concerns
+func (event *Event) WithEndpoint(key, name, group string) *Event {
+	event.Key, event.Name, event.Group = key, name, group
+	return event
+}
+
+var (
+	subscribers = make(map[chan *Event]struct{})
+	mutex       sync.Mutex
+)
+
+// Subscribe returns a channel to which every event will be sent until the unsubscribe function is called, which
+// closes the channel
+func Subscribe() (events <-chan *Event, unsubscribe func()) {
+	channel := make(chan *Event, SubscriberBufferSize)
+	mutex.Lock()
+	subscribers[channel] = struct{}{}
+	mutex.Unlock()
+	return channel, func() {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if _, exists := subscribers[channel]; exists {
+			delete(subscribers, channel)
+			close(channel)
+		}
+	}
+}
+
+// Publish sends the event passed as parameter to every subscriber without blocking
+func Publish(event *Event) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	for channel := range subscribers {
+		select {
+		case channel <- event:
+		default:
+			log.Printf("[lifecycle.Publish] Dropped %s event because the subscriber is too slow", event.Type)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package lifecycle
+
+import (
+	"testing"
+)
+
+func TestPublish(t *testing.T) {
+	Publish(NewEvent(EventTypeConfigurationReloaded, "before")) // Published before subscribing, so not received
+	events, unsubscribe := Subscribe()
+	defer unsubscribe()
+	Publish(NewEvent(EventTypeEndpointAdded, "Endpoint core_frontend was added").WithEndpoint("core_frontend", "frontend", "core"))
+	Publish(NewEvent(EventTypeConfigurationReloaded, "after"))
+	select {
+	case event := <-events:
+		if event.Type != EventTypeEndpointAdded || event.Key != "core_frontend" || event.Name != "frontend" || event.Group != "core" || event.Timestamp.IsZero() {
+			t.Errorf("expected the endpoint-added event of core_frontend, got %+v", event)
+		}
+	default:
+		t.Fatal("expected the endpoint-added event to have been sent")
+	}
+	select {
+	case event := <-events:
+		if event.Type != EventTypeConfigurationReloaded || event.Description != "after" {
+			t.Errorf("expected the configuration-reloaded event published after subscribing, got %+v", event)
+		}
+	default:
+		t.Fatal("expected the configuration-reloaded event to have been sent")
+	}
+}
+
+func TestPublish_WithSlowSubscriber(t *testing.T) {
+	events, unsubscribe := Subscribe()
+	defer unsubscribe()
+	for i := 0; i < SubscriberBufferSize*2; i++ {
+		Publish(NewEvent(EventTypeCheckSkipped, "slow"))
+	}
+	if len(events) != SubscriberBufferSize {
+		t.Errorf("expected %d events to have been queued, got %d", SubscriberBufferSize, len(events))
+	}
+}
+
+func TestSubscribe_Unsubscribe(t *testing.T) {
+	events, unsubscribe := Subscribe()
+	unsubscribe()
+	unsubscribe() // Unsubscribing twice must not panic
+	Publish(NewEvent(EventTypeCheckSkipped, "after unsubscribing"))
+	if _, open := <-events; open {
+		t.Error("expected the channel to be closed")
+	}
+}
+
+func TestEventType_IsValid(t *testing.T) {
+	for _, eventType := range EventTypes {
+		if !eventType.IsValid() {
+			t.Errorf("expected %s to be valid", eventType)
+		}
+	}
+	if EventType("endpoint-exploded").IsValid() {
+		t.Error("expected endpoint-exploded to be invalid")
+	}
+}
@@ -0,0 +1,147 @@
+package lifecycle
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/TwiN/gatus/v5/client"
+)
+
+var (
+	ErrInvalidWebhookURL       = errors.New("webhooks[].url must be an absolute URL with the http or https scheme")
+	ErrInvalidWebhookEventType = errors.New("webhooks[].events must only contain valid event types")
+)
+
+// Webhook is the configuration of a webhook the lifecycle events are sent to
+type Webhook struct {
+	// URL the events are sent to as the JSON body of a POST request
+	URL string `yaml:"url"`
+
+	// Events is the list of types of events sent to the webhook. If empty, every event is sent.
+	Events []EventType `yaml:"events,omitempty"`
+
+	// Headers to add to the requests
+	Headers map[string]string `yaml:"headers,omitempty"`
+
+	// Secret used to sign the body of the requests with HMAC-SHA256. If set, the hex-encoded signature is passed
+	// through the X-Gatus-Signature header, prefixed by sha256=.
+	Secret string `yaml:"secret,omitempty"`
+
+	// ClientConfig is the configuration of the client used to call the webhook
+	ClientConfig *client.Config `yaml:"client,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the webhook configuration and sets the default values if necessary
+func (webhook *Webhook) ValidateAndSetDefaults() error {
+	parsedURL, err := url.Parse(webhook.URL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || len(parsedURL.Host) == 0 {
+		return ErrInvalidWebhookURL
+	}
+	for _, eventType := range webhook.Events {
+		if !eventType.IsValid() {
+			return fmt.Errorf("%w: %s", ErrInvalidWebhookEventType, eventType)
+		}
+	}
+	if webhook.ClientConfig == nil {
+		webhook.ClientConfig = client.GetDefaultConfig()
+	} else if err := webhook.ClientConfig.ValidateAndSetDefaults(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// IsInterestedIn returns whether the events of the type passed as parameter are sent to the webhook
+func (webhook *Webhook) IsInterestedIn(eventType EventType) bool {
+	if len(webhook.Events) == 0 {
+		return true
+	}
+	for _, t := range webhook.Events {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Send sends the event passed as parameter to the webhook
+func (webhook *Webhook) Send(event *Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	for name, value := range webhook.Headers {
+		request.Header.Set(name, value)
+	}
+	request.Header.Set("X-Gatus-Event", string(event.Type))
+	if len(webhook.Secret) > 0 {
+		mac := hmac.New(sha256.New, []byte(webhook.Secret))
+		mac.Write(body)
+		request.Header.Set("X-Gatus-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+	response, err := client.GetHTTPClient(webhook.ClientConfig).Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode > 399 {
+		body, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("call to webhook returned status code %d: %s", response.StatusCode, string(body))
+	}
+	return nil
+}
+
+var (
+	unsubscribe      func()
+	unsubscribeMutex sync.Mutex
+)
+
+// Start sends the lifecycle events to the webhooks passed as parameter until Shutdown is called. Does nothing if
+// there are no webhooks.
+//
+// Events are sent one after the other, in the order in which they were published.
+func Start(webhooks []*Webhook) {
+	if len(webhooks) == 0 {
+		return
+	}
+	unsubscribeMutex.Lock()
+	defer unsubscribeMutex.Unlock()
+	var events <-chan *Event
+	events, unsubscribe = Subscribe()
+	go func() {
+		for event := range events {
+			for _, webhook := range webhooks {
+				if !webhook.IsInterestedIn(event.Type) {
+					continue
+				}
+				if err := webhook.Send(event); err != nil {
+					log.Printf("[lifecycle.Start] Failed to send %s event to webhook with url=%s: %s", event.Type, webhook.URL, err.Error())
+				}
+			}
+		}
+	}()
+}
+
+// Shutdown stops sending the lifecycle events to the webhooks. The events that were already queued are still sent.
+func Shutdown() {
+	unsubscribeMutex.Lock()
+	defer unsubscribeMutex.Unlock()
+	if unsubscribe != nil {
+		unsubscribe()
+		unsubscribe = nil
+	}
+}
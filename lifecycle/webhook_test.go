@@ -0,0 +1,137 @@
+package lifecycle
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWebhook_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		webhook       *Webhook
+		expectedError error
+	}{
+		{
+			name:    "valid",
+			webhook: &Webhook{URL: "https://example.org/hooks/gatus", Events: []EventType{EventTypeEndpointAdded, EventTypeCheckSkipped}},
+		},
+		{
+			name:          "missing-url",
+			webhook:       &Webhook{},
+			expectedError: ErrInvalidWebhookURL,
+		},
+		{
+			name:          "invalid-scheme",
+			webhook:       &Webhook{URL: "ftp://example.org"},
+			expectedError: ErrInvalidWebhookURL,
+		},
+		{
+			name:          "invalid-event-type",
+			webhook:       &Webhook{URL: "https://example.org", Events: []EventType{"endpoint-exploded"}},
+			expectedError: ErrInvalidWebhookEventType,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			err := scenario.webhook.ValidateAndSetDefaults()
+			if !errors.Is(err, scenario.expectedError) {
+				t.Errorf("expected error %v, got %v", scenario.expectedError, err)
+			}
+			if err == nil && scenario.webhook.ClientConfig == nil {
+				t.Error("expected the client configuration to default to the default one")
+			}
+		})
+	}
+}
+
+func TestWebhook_IsInterestedIn(t *testing.T) {
+	if !(&Webhook{}).IsInterestedIn(EventTypeMaintenanceStarted) {
+		t.Error("expected a webhook without events to be interested in every event")
+	}
+	webhook := &Webhook{Events: []EventType{EventTypeMaintenanceStarted, EventTypeMaintenanceEnded}}
+	if !webhook.IsInterestedIn(EventTypeMaintenanceEnded) {
+		t.Error("expected the webhook to be interested in maintenance-ended")
+	}
+	if webhook.IsInterestedIn(EventTypeCheckSkipped) {
+		t.Error("expected the webhook not to be interested in check-skipped")
+	}
+}
+
+func TestWebhook_Send(t *testing.T) {
+	var request *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request = r
+		body, _ = io.ReadAll(r.Body)
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+	webhook := &Webhook{URL: server.URL, Headers: map[string]string{"Authorization": "Bearer token"}, Secret: "secret"}
+	if err := webhook.ValidateAndSetDefaults(); err != nil {
+		t.Fatal(err)
+	}
+	event := NewEvent(EventTypeCheckSkipped, "Check of endpoint core/frontend was skipped because it is paused").WithEndpoint("core_frontend", "frontend", "core")
+	event.Reason = SkipReasonPaused
+	if err := webhook.Send(event); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if request.Method != http.MethodPost || request.Header.Get("Content-Type") != "application/json" || request.Header.Get("X-Gatus-Event") != "check-skipped" {
+		t.Errorf("unexpected request: %s with headers %v", request.Method, request.Header)
+	}
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(body)
+	if expectedSignature := "sha256=" + hex.EncodeToString(mac.Sum(nil)); request.Header.Get("X-Gatus-Signature") != expectedSignature {
+		t.Errorf("expected signature %s, got %s", expectedSignature, request.Header.Get("X-Gatus-Signature"))
+	}
+	var sentEvent Event
+	if err := json.Unmarshal(body, &sentEvent); err != nil {
+		t.Fatal(err)
+	}
+	if sentEvent.Type != EventTypeCheckSkipped || sentEvent.Key != "core_frontend" || sentEvent.Reason != SkipReasonPaused {
+		t.Errorf("unexpected event sent: %s", body)
+	}
+	webhook.Headers = nil
+	if err := webhook.Send(event); err == nil {
+		t.Error("expected an error, because the webhook returned 401")
+	}
+}
+
+func TestStart(t *testing.T) {
+	received := make(chan EventType, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- EventType(r.Header.Get("X-Gatus-Event"))
+	}))
+	defer server.Close()
+	webhook := &Webhook{URL: server.URL, Events: []EventType{EventTypeMaintenanceStarted, EventTypeMaintenanceEnded}}
+	if err := webhook.ValidateAndSetDefaults(); err != nil {
+		t.Fatal(err)
+	}
+	Start([]*Webhook{webhook})
+	Publish(NewEvent(EventTypeCheckSkipped, "not sent, because the webhook isn't interested in it"))
+	Publish(NewEvent(EventTypeMaintenanceStarted, "Maintenance window started"))
+	select {
+	case eventType := <-received:
+		if eventType != EventTypeMaintenanceStarted {
+			t.Errorf("expected %s, got %s", EventTypeMaintenanceStarted, eventType)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the event to have been sent to the webhook")
+	}
+	Shutdown()
+	Publish(NewEvent(EventTypeMaintenanceEnded, "not sent, because the webhooks were shut down"))
+	select {
+	case eventType := <-received:
+		t.Errorf("expected no event to be sent after shutting down, got %s", eventType)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
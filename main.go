@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
@@ -8,13 +9,23 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/TwiN/gatus/v5/audit"
 	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/discovery"
 	"github.com/TwiN/gatus/v5/controller"
+	"github.com/TwiN/gatus/v5/lifecycle"
 	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/subscription/notifier"
 	"github.com/TwiN/gatus/v5/watchdog"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		if err := runCommand(os.Args[1], os.Args[2:]); err != nil {
+			log.Fatalln("Failed to run command:", err.Error())
+		}
+		return
+	}
 	if delayInSeconds, _ := strconv.Atoi(os.Getenv("GATUS_DELAY_START_SECONDS")); delayInSeconds > 0 {
 		log.Printf("Delaying start by %d seconds", delayInSeconds)
 		time.Sleep(time.Duration(delayInSeconds) * time.Second)
@@ -23,6 +34,13 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
+	// The endpoints are discovered once before starting, so that those discovered before the restart aren't removed
+	// from the storage, along with their history, for not being part of the configuration
+	if cfg.DiscoverEndpoints() {
+		if cfg, err = loadConfiguration(); err != nil {
+			panic(err)
+		}
+	}
 	initializeStorage(cfg)
 	start(cfg)
 	// Wait for termination signal
@@ -42,12 +60,18 @@ func main() {
 
 func start(cfg *config.Config) {
 	go controller.Handle(cfg)
+	notifier.Start(cfg.Subscriptions, cfg.Groups)
+	lifecycle.Start(cfg.Webhooks)
 	watchdog.Monitor(cfg)
+	cfg.WatchDiscoveredEndpoints()
 	go listenToConfigurationFileChanges(cfg)
 }
 
 func stop(cfg *config.Config) {
+	discovery.Shutdown()
 	watchdog.Shutdown(cfg)
+	notifier.Shutdown()
+	lifecycle.Shutdown()
 	controller.Shutdown()
 }
 
@@ -148,7 +172,20 @@ func initializeStorage(cfg *config.Config) {
 
 func listenToConfigurationFileChanges(cfg *config.Config) {
 	for {
-		time.Sleep(30 * time.Second)
+		select {
+		case updatedConfig := <-cfg.ReloadRequests():
+			// The configuration was already loaded and validated by whoever requested the reload
+			log.Println("[main.listenToConfigurationFileChanges] Configuration reload has been requested")
+			stop(cfg)
+			time.Sleep(time.Second) // Wait a bit to make sure everything is done.
+			save()
+			store.Get().Close()
+			initializeStorage(updatedConfig)
+			start(updatedConfig)
+			publishReloadEvents(cfg, updatedConfig)
+			return
+		case <-time.After(30 * time.Second):
+		}
 		if cfg.HasLoadedConfigurationBeenModified() {
 			log.Println("[main.listenToConfigurationFileChanges] Configuration file has been modified")
 			stop(cfg)
@@ -159,6 +196,7 @@ func listenToConfigurationFileChanges(cfg *config.Config) {
 				if cfg.SkipInvalidConfigUpdate {
 					log.Println("[main.listenToConfigurationFileChanges] Failed to load new configuration:", err.Error())
 					log.Println("[main.listenToConfigurationFileChanges] The configuration file was updated, but it is not valid. The old configuration will continue being used.")
+					store.Audit(audit.NewEntry(audit.ActionConfigurationReload, "", "", false, err.Error()))
 					// Update the last file modification time to avoid trying to process the same invalid configuration again
 					cfg.UpdateLastFileModTime()
 					continue
@@ -168,8 +206,37 @@ func listenToConfigurationFileChanges(cfg *config.Config) {
 			}
 			store.Get().Close()
 			initializeStorage(updatedConfig)
+			store.Audit(audit.NewEntry(audit.ActionConfigurationReload, "", "", true, ""))
 			start(updatedConfig)
+			publishReloadEvents(cfg, updatedConfig)
 			return
 		}
 	}
 }
+
+// publishReloadEvents publishes the lifecycle events of the endpoints that were added and removed by the reload of
+// the configuration, followed by the one of the configuration being reloaded
+func publishReloadEvents(previousConfig, updatedConfig *config.Config) {
+	diff := config.DiffEndpoints(previousConfig, updatedConfig)
+	for _, key := range diff.Added {
+		name, group := getEndpointNameAndGroupByKey(updatedConfig, key)
+		lifecycle.Publish(lifecycle.NewEvent(lifecycle.EventTypeEndpointAdded, "Endpoint "+key+" was added").WithEndpoint(key, name, group))
+	}
+	for _, key := range diff.Removed {
+		name, group := getEndpointNameAndGroupByKey(previousConfig, key)
+		lifecycle.Publish(lifecycle.NewEvent(lifecycle.EventTypeEndpointRemoved, "Endpoint "+key+" was removed").WithEndpoint(key, name, group))
+	}
+	lifecycle.Publish(lifecycle.NewEvent(lifecycle.EventTypeConfigurationReloaded, fmt.Sprintf("Configuration was reloaded with %d endpoints added, %d removed and %d changed", len(diff.Added), len(diff.Removed), len(diff.Changed))))
+}
+
+// getEndpointNameAndGroupByKey returns the name and the group of the endpoint or external endpoint whose key is
+// passed as parameter
+func getEndpointNameAndGroupByKey(cfg *config.Config, key string) (name, group string) {
+	if ep := cfg.GetEndpointByKey(key); ep != nil {
+		return ep.Name, ep.Group
+	}
+	if ee := cfg.GetExternalEndpointByKey(key); ee != nil {
+		return ee.Name, ee.Group
+	}
+	return "", ""
+}
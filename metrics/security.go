@@ -0,0 +1,40 @@
+package metrics
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	initializeSecurityMetricsOnce sync.Once // Ensures the metrics of the security are only initialized once
+
+	securityFailedAuthenticationsTotal prometheus.Counter
+	securityLockoutsTotal              prometheus.Counter
+)
+
+func initializeSecurityMetrics() {
+	securityFailedAuthenticationsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "security_failed_authentications_total",
+		Help:      "Total number of attempts to authenticate through basic authentication with invalid credentials",
+	})
+	securityLockoutsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "security_lockouts_total",
+		Help:      "Total number of times an IP was locked out after too many failed attempts to authenticate",
+	})
+}
+
+// IncrementFailedAuthentications publishes that an attempt to authenticate was made with invalid credentials
+func IncrementFailedAuthentications() {
+	initializeSecurityMetricsOnce.Do(initializeSecurityMetrics)
+	securityFailedAuthenticationsTotal.Inc()
+}
+
+// IncrementLockouts publishes that an IP was locked out after too many failed attempts to authenticate
+func IncrementLockouts() {
+	initializeSecurityMetricsOnce.Do(initializeSecurityMetrics)
+	securityLockoutsTotal.Inc()
+}
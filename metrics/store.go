@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	initializeStoreMetricsOnce sync.Once // Ensures the metrics of the store are only initialized once
+
+	storeOperationDurationSeconds *prometheus.HistogramVec
+	storeWriteBatchSize           *prometheus.HistogramVec
+	storeCleanUpDurationSeconds   *prometheus.HistogramVec
+	storeSQLiteBusyErrorsTotal    *prometheus.CounterVec
+)
+
+func initializeStoreMetrics() {
+	storeOperationDurationSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "store_operation_duration_seconds",
+		Help:      "Duration of the operations of the store in seconds",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	}, []string{"type", "operation", "success"})
+	storeWriteBatchSize = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "store_write_batch_size",
+		Help:      "Number of results inserted at once when the write buffer is flushed",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 10),
+	}, []string{"type"})
+	storeCleanUpDurationSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "store_cleanup_duration_seconds",
+		Help:      "Duration of the clean ups of old data of the store in seconds",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	}, []string{"type", "data"})
+	storeSQLiteBusyErrorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "store_sqlite_busy_errors_total",
+		Help:      "Total number of operations of the store that failed because the SQLite database was busy",
+	}, []string{"operation"})
+}
+
+// ObserveStoreOperation publishes the duration of an operation of the store that started at start, as well as
+// whether it succeeded
+func ObserveStoreOperation(storeType, operation string, start time.Time, success bool) {
+	initializeStoreMetricsOnce.Do(initializeStoreMetrics)
+	storeOperationDurationSeconds.WithLabelValues(storeType, operation, strconv.FormatBool(success)).Observe(time.Since(start).Seconds())
+}
+
+// ObserveStoreWriteBatch publishes the number of results inserted at once by a flush of the write buffer of the store
+func ObserveStoreWriteBatch(storeType string, size int) {
+	initializeStoreMetricsOnce.Do(initializeStoreMetrics)
+	storeWriteBatchSize.WithLabelValues(storeType).Observe(float64(size))
+}
+
+// ObserveStoreCleanUp publishes the duration of a clean up of old data of the store that started at start
+func ObserveStoreCleanUp(storeType, data string, start time.Time) {
+	initializeStoreMetricsOnce.Do(initializeStoreMetrics)
+	storeCleanUpDurationSeconds.WithLabelValues(storeType, data).Observe(time.Since(start).Seconds())
+}
+
+// IncrementStoreSQLiteBusyErrors publishes that an operation of the store failed because the SQLite database was busy
+func IncrementStoreSQLiteBusyErrors(operation string) {
+	initializeStoreMetricsOnce.Do(initializeStoreMetrics)
+	storeSQLiteBusyErrorsTotal.WithLabelValues(operation).Inc()
+}
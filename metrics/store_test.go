@@ -0,0 +1,31 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestPublishStoreMetrics(t *testing.T) {
+	ObserveStoreOperation("sqlite", "Insert", time.Now().Add(-10*time.Millisecond), true)
+	ObserveStoreOperation("sqlite", "Insert", time.Now(), false)
+	ObserveStoreOperation("memory", "GetAllEndpointStatuses", time.Now(), true)
+	if count := testutil.CollectAndCount(storeOperationDurationSeconds); count != 3 {
+		t.Errorf("expected 3 series of operation durations, got %d", count)
+	}
+	ObserveStoreWriteBatch("postgres", 100)
+	if count := testutil.CollectAndCount(storeWriteBatchSize); count != 1 {
+		t.Errorf("expected 1 series of write batch sizes, got %d", count)
+	}
+	ObserveStoreCleanUp("sqlite", "results", time.Now())
+	ObserveStoreCleanUp("sqlite", "events", time.Now())
+	if count := testutil.CollectAndCount(storeCleanUpDurationSeconds); count != 2 {
+		t.Errorf("expected 2 series of clean up durations, got %d", count)
+	}
+	IncrementStoreSQLiteBusyErrors("Insert")
+	IncrementStoreSQLiteBusyErrors("Insert")
+	if value := testutil.ToFloat64(storeSQLiteBusyErrorsTotal.WithLabelValues("Insert")); value != 2 {
+		t.Errorf("expected 2 busy errors, got %f", value)
+	}
+}
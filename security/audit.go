@@ -0,0 +1,45 @@
+package security
+
+import (
+	"encoding/json"
+	"log"
+	"net"
+	"net/http"
+
+	"github.com/TwiN/gatus/v5/audit"
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+// AuditConfig is the configuration of the audit of the authentication and authorization events, such as logins,
+// failed attempts to authenticate, uses of API tokens and requests denied for lack of the role required.
+//
+// Each event is logged as a JSON object, and is also recorded in the audit log of the storage if Store is true.
+type AuditConfig struct {
+	// Store is whether to record the events in the audit log of the storage, along with the administrative actions
+	Store bool `yaml:"store,omitempty"`
+}
+
+// RecordAuditEvent logs the authentication or authorization event passed and, if configured to, records it in the
+// audit log of the storage. Does nothing if Audit isn't configured.
+func (c *Config) RecordAuditEvent(entry *audit.Entry) {
+	if c == nil || c.Audit == nil {
+		return
+	}
+	output, err := json.Marshal(entry)
+	if err != nil {
+		log.Printf("[security.RecordAuditEvent] Unable to marshal action=%s to JSON: %s", entry.Action, err.Error())
+	} else {
+		log.Printf("[security.RecordAuditEvent] %s", output)
+	}
+	if c.Audit.Store {
+		store.Audit(entry)
+	}
+}
+
+// getRemoteIP returns the IP address of the client of the request passed, for the audit events
+func getRemoteIP(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
@@ -1,5 +1,12 @@
 package security
 
+import (
+	"encoding/base64"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
 // BasicConfig is the configuration for Basic authentication
 type BasicConfig struct {
 	// Username is the name which will need to be used for a successful authentication
@@ -14,3 +21,28 @@ type BasicConfig struct {
 func (c *BasicConfig) isValid() bool {
 	return len(c.Username) > 0 && len(c.PasswordBcryptHashBase64Encoded) > 0
 }
+
+// isAuthorized returns whether the credentials passed are the ones of the basic security configuration, given the
+// decoded bcrypt hash of its password. If no password is configured, every credential is authorized.
+func (c *BasicConfig) isAuthorized(decodedBcryptHash []byte, username, password string) bool {
+	if len(c.PasswordBcryptHashBase64Encoded) > 0 {
+		if username != c.Username || bcrypt.CompareHashAndPassword(decodedBcryptHash, []byte(password)) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// parseBasicAuthorizationHeader returns the credentials of the value of an Authorization header using the Basic
+// scheme, and whether the header is such a header
+func parseBasicAuthorizationHeader(header string) (username, password string, ok bool) {
+	encodedCredentials, found := strings.CutPrefix(header, "Basic ")
+	if !found {
+		return "", "", false
+	}
+	credentials, err := base64.StdEncoding.DecodeString(encodedCredentials)
+	if err != nil {
+		return "", "", false
+	}
+	return strings.Cut(string(credentials), ":")
+}
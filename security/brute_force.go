@@ -0,0 +1,139 @@
+package security
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/TwiN/gatus/v5/metrics"
+	"github.com/TwiN/gocache/v2"
+)
+
+const (
+	// DefaultMaximumFailedAttempts is the default value for BruteForceProtectionConfig.MaximumFailedAttempts
+	DefaultMaximumFailedAttempts = 5
+
+	// DefaultInitialDelay is the default value for BruteForceProtectionConfig.InitialDelay
+	DefaultInitialDelay = time.Second
+
+	// DefaultLockoutDuration is the default value for BruteForceProtectionConfig.LockoutDuration
+	DefaultLockoutDuration = 15 * time.Minute
+
+	// maximumNumberOfTrackedIPs is the maximum number of IPs whose failed attempts are kept track of, past which those
+	// of the IPs that failed the least recently are forgotten
+	maximumNumberOfTrackedIPs = 10000
+)
+
+// BruteForceProtectionConfig is the configuration of the protection of the basic authentication against brute-force
+// attacks, which slows down and then temporarily locks out the IPs that fail to authenticate.
+//
+// After each failed attempt, the IP must wait for a delay that doubles with every failed attempt before attempting to
+// authenticate again. Once it reached the maximum number of failed attempts, the IP is locked out for LockoutDuration.
+// The failed attempts of an IP are forgotten as soon as it authenticates, or LockoutDuration after its last one.
+type BruteForceProtectionConfig struct {
+	// MaximumFailedAttempts is the number of failed attempts after which the IP is locked out (defaults to
+	// DefaultMaximumFailedAttempts)
+	MaximumFailedAttempts int `yaml:"max-failed-attempts,omitempty"`
+
+	// InitialDelay is the delay to wait for after the first failed attempt (defaults to DefaultInitialDelay)
+	InitialDelay time.Duration `yaml:"initial-delay,omitempty"`
+
+	// LockoutDuration is how long an IP is locked out for (defaults to DefaultLockoutDuration)
+	LockoutDuration time.Duration `yaml:"lockout-duration,omitempty"`
+
+	mutex          sync.Mutex
+	failedAttempts *gocache.Cache
+}
+
+// failedAttempts are the failed attempts to authenticate of an IP
+type failedAttempts struct {
+	count       int
+	last        time.Time
+	lockedUntil time.Time
+}
+
+// ValidateAndSetDefaults validates the brute-force protection configuration and sets the default values if necessary
+func (c *BruteForceProtectionConfig) ValidateAndSetDefaults() error {
+	if c.MaximumFailedAttempts < 0 || c.InitialDelay < 0 || c.LockoutDuration < 0 {
+		return errors.New("max-failed-attempts, initial-delay and lockout-duration cannot be negative")
+	}
+	if c.MaximumFailedAttempts == 0 {
+		c.MaximumFailedAttempts = DefaultMaximumFailedAttempts
+	}
+	if c.InitialDelay == 0 {
+		c.InitialDelay = DefaultInitialDelay
+	}
+	if c.LockoutDuration == 0 {
+		c.LockoutDuration = DefaultLockoutDuration
+	}
+	c.failedAttempts = gocache.NewCache().WithEvictionPolicy(gocache.LeastRecentlyUsed).WithMaxSize(maximumNumberOfTrackedIPs)
+	return nil
+}
+
+// getRetryAfter returns how long the IP passed as parameter must wait for before attempting to authenticate again, or 0
+// if it may attempt to authenticate right away
+func (c *BruteForceProtectionConfig) getRetryAfter(ip string, now time.Time) time.Duration {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	attempts := c.getFailedAttempts(ip)
+	if attempts == nil {
+		return 0
+	}
+	if !attempts.lockedUntil.IsZero() {
+		if now.Before(attempts.lockedUntil) {
+			return attempts.lockedUntil.Sub(now)
+		}
+		// The lockout is over, so the IP starts over
+		c.failedAttempts.Delete(ip)
+		return 0
+	}
+	if next := attempts.last.Add(c.getDelay(attempts.count)); now.Before(next) {
+		return next.Sub(now)
+	}
+	return 0
+}
+
+// recordFailure records a failed attempt of the IP passed as parameter to authenticate as the user passed as parameter,
+// and locks the IP out if it reached the maximum number of failed attempts
+func (c *BruteForceProtectionConfig) recordFailure(ip, username string, now time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	attempts := c.getFailedAttempts(ip)
+	if attempts == nil {
+		attempts = &failedAttempts{}
+	}
+	attempts.count++
+	attempts.last = now
+	if attempts.count >= c.MaximumFailedAttempts {
+		attempts.lockedUntil = now.Add(c.LockoutDuration)
+		log.Printf("[security.recordFailure] Locking out %s for %s after %d failed attempts to authenticate, the last one as user %s", ip, c.LockoutDuration, attempts.count, username)
+		metrics.IncrementLockouts()
+	}
+	c.failedAttempts.SetWithTTL(ip, attempts, c.LockoutDuration)
+}
+
+// recordSuccess forgets the failed attempts of the IP passed as parameter, which just authenticated
+func (c *BruteForceProtectionConfig) recordSuccess(ip string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.failedAttempts.Delete(ip)
+}
+
+func (c *BruteForceProtectionConfig) getFailedAttempts(ip string) *failedAttempts {
+	value, exists := c.failedAttempts.Get(ip)
+	if !exists {
+		return nil
+	}
+	return value.(*failedAttempts)
+}
+
+// getDelay returns the delay to wait for after the number of consecutive failed attempts passed as parameter, which
+// doubles with every failed attempt, but never exceeds the lockout duration
+func (c *BruteForceProtectionConfig) getDelay(count int) time.Duration {
+	delay := c.InitialDelay
+	for i := 1; i < count && delay < c.LockoutDuration; i++ {
+		delay *= 2
+	}
+	return min(delay, c.LockoutDuration)
+}
@@ -0,0 +1,144 @@
+package security
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestBruteForceProtectionConfig_ValidateAndSetDefaults(t *testing.T) {
+	c := &BruteForceProtectionConfig{}
+	if err := c.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if c.MaximumFailedAttempts != DefaultMaximumFailedAttempts || c.InitialDelay != DefaultInitialDelay || c.LockoutDuration != DefaultLockoutDuration {
+		t.Errorf("expected the default values to be set, got %+v", c)
+	}
+	if err := (&BruteForceProtectionConfig{InitialDelay: -time.Second}).ValidateAndSetDefaults(); err == nil {
+		t.Error("expected an error for a negative initial delay")
+	}
+}
+
+func TestBruteForceProtectionConfig(t *testing.T) {
+	c := &BruteForceProtectionConfig{MaximumFailedAttempts: 3, InitialDelay: time.Second, LockoutDuration: time.Hour}
+	if err := c.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	now := time.Now()
+	if retryAfter := c.getRetryAfter("10.0.0.1", now); retryAfter != 0 {
+		t.Errorf("expected an IP without failed attempts to be allowed to attempt right away, got %s", retryAfter)
+	}
+	c.recordFailure("10.0.0.1", "john.doe", now)
+	if retryAfter := c.getRetryAfter("10.0.0.1", now); retryAfter != time.Second {
+		t.Errorf("expected to have to wait for 1s after the first failed attempt, got %s", retryAfter)
+	}
+	if retryAfter := c.getRetryAfter("10.0.0.2", now); retryAfter != 0 {
+		t.Errorf("expected the failed attempts of an IP not to affect another IP, got %s", retryAfter)
+	}
+	now = now.Add(time.Second)
+	if retryAfter := c.getRetryAfter("10.0.0.1", now); retryAfter != 0 {
+		t.Errorf("expected to be allowed to attempt once the delay elapsed, got %s", retryAfter)
+	}
+	c.recordFailure("10.0.0.1", "john.doe", now)
+	if retryAfter := c.getRetryAfter("10.0.0.1", now); retryAfter != 2*time.Second {
+		t.Errorf("expected the delay to double after the second failed attempt, got %s", retryAfter)
+	}
+	now = now.Add(2 * time.Second)
+	c.recordFailure("10.0.0.1", "john.doe", now)
+	if retryAfter := c.getRetryAfter("10.0.0.1", now.Add(30*time.Minute)); retryAfter != 30*time.Minute {
+		t.Errorf("expected the IP to be locked out for 1h after the third failed attempt, got %s", retryAfter)
+	}
+	if retryAfter := c.getRetryAfter("10.0.0.1", now.Add(time.Hour)); retryAfter != 0 {
+		t.Errorf("expected the IP to be allowed to attempt once the lockout is over, got %s", retryAfter)
+	}
+	if retryAfter := c.getRetryAfter("10.0.0.1", now.Add(time.Hour)); retryAfter != 0 {
+		t.Errorf("expected the failed attempts of the IP to have been forgotten after the lockout, got %s", retryAfter)
+	}
+	c.recordFailure("10.0.0.3", "john.doe", now)
+	c.recordSuccess("10.0.0.3")
+	if retryAfter := c.getRetryAfter("10.0.0.3", now); retryAfter != 0 {
+		t.Errorf("expected the failed attempts of the IP to have been forgotten after it authenticated, got %s", retryAfter)
+	}
+}
+
+func TestConfig_MiddlewareWithBruteForceProtection(t *testing.T) {
+	bruteForceProtection := &BruteForceProtectionConfig{MaximumFailedAttempts: 2, InitialDelay: time.Millisecond, LockoutDuration: time.Hour}
+	if err := bruteForceProtection.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	c := &Config{
+		Basic:                &BasicConfig{Username: "john.doe", PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT"},
+		BruteForceProtection: bruteForceProtection,
+	}
+	app := fiber.New()
+	if err := c.ApplySecurityMiddleware(app); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	app.Get("/test", func(ctx *fiber.Ctx) error {
+		// The credentials must only be counted once, however many times they're checked
+		if !c.IsAuthenticated(ctx) || c.GetUser(ctx) == nil {
+			return ctx.SendStatus(500)
+		}
+		return ctx.SendStatus(200)
+	})
+	test := func(password string, expectedCode int) {
+		request := httptest.NewRequest("GET", "/test", http.NoBody)
+		if len(password) > 0 {
+			request.SetBasicAuth("john.doe", password)
+		}
+		response, err := app.Test(request)
+		if err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+		if response.StatusCode != expectedCode {
+			t.Errorf("expected status code %d with password %q, got %d", expectedCode, password, response.StatusCode)
+		}
+		if expectedCode == 429 && len(response.Header.Get("Retry-After")) == 0 {
+			t.Error("expected the Retry-After header to be set")
+		}
+	}
+	test("", 401)
+	test("hunter2", 200)
+	test("hunter3", 401)
+	time.Sleep(2 * time.Millisecond)
+	test("hunter2", 200) // The failed attempts are forgotten after a successful one
+	test("hunter3", 401)
+	time.Sleep(2 * time.Millisecond)
+	test("hunter3", 401)
+	test("hunter2", 429) // Locked out, even with the right password
+}
+
+func TestConfig_MiddlewareWithBruteForceProtectionBehindTrustedProxy(t *testing.T) {
+	bruteForceProtection := &BruteForceProtectionConfig{MaximumFailedAttempts: 3, InitialDelay: time.Millisecond, LockoutDuration: time.Hour}
+	if err := bruteForceProtection.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	c := &Config{
+		Basic:                &BasicConfig{Username: "john.doe", PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT"},
+		BruteForceProtection: bruteForceProtection,
+	}
+	app := fiber.New(fiber.Config{ProxyHeader: "X-Forwarded-For", EnableTrustedProxyCheck: true, TrustedProxies: []string{"0.0.0.0"}})
+	if err := c.ApplySecurityMiddleware(app); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	app.Get("/test", func(ctx *fiber.Ctx) error {
+		return ctx.SendStatus(200)
+	})
+	// The IP of the client is read from a header, whose buffer is reused by the next requests
+	for _, ip := range []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"} {
+		request := httptest.NewRequest("GET", "/test", http.NoBody)
+		request.SetBasicAuth("john.doe", "hunter3")
+		request.Header.Set("X-Forwarded-For", ip)
+		if _, err := app.Test(request); err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+	}
+	for _, ip := range []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"} {
+		if attempts := bruteForceProtection.getFailedAttempts(ip); attempts == nil || attempts.count != 1 {
+			t.Errorf("expected 1 failed attempt for %s, got %+v", ip, attempts)
+		}
+	}
+}
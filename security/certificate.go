@@ -0,0 +1,27 @@
+package security
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+)
+
+// IsClientCertificateVerifiedBy returns whether the certificate presented by the client of the TLS connection passed as
+// parameter is signed by one of the certificate authorities passed as parameter.
+//
+// This is required whenever the TLS handshake accepts the certificates signed by several unrelated certificate
+// authorities, since the chains verified by the handshake don't tell which of them the certificate was signed by.
+func IsClientCertificateVerifiedBy(state *tls.ConnectionState, certificateAuthorities *x509.CertPool) bool {
+	if state == nil || len(state.PeerCertificates) == 0 || certificateAuthorities == nil {
+		return false
+	}
+	intermediates := x509.NewCertPool()
+	for _, certificate := range state.PeerCertificates[1:] {
+		intermediates.AddCert(certificate)
+	}
+	_, err := state.PeerCertificates[0].Verify(x509.VerifyOptions{
+		Roots:         certificateAuthorities,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	return err == nil
+}
@@ -3,19 +3,27 @@ package security
 import (
 	"encoding/base64"
 	"log"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	g8 "github.com/TwiN/g8/v2"
+	"github.com/TwiN/gatus/v5/audit"
+	"github.com/TwiN/gatus/v5/metrics"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/adaptor"
-	"github.com/gofiber/fiber/v2/middleware/basicauth"
-	"golang.org/x/crypto/bcrypt"
 )
 
 const (
 	cookieNameState   = "gatus_state"
 	cookieNameNonce   = "gatus_nonce"
 	cookieNameSession = "gatus_session"
+
+	// basicAuthenticationLocalsKey is the key of the locals of the requests holding the result of their basic
+	// authentication, so that the credentials of each request are only verified, and counted as an attempt, once
+	basicAuthenticationLocalsKey = "security.basic-authentication"
 )
 
 // Config is the security configuration for Gatus
@@ -23,11 +31,48 @@ type Config struct {
 	Basic *BasicConfig `yaml:"basic,omitempty"`
 	OIDC  *OIDCConfig  `yaml:"oidc,omitempty"`
 
+	// LDAP authenticates the users against an LDAP server through basic authentication. It can be combined with
+	// Basic, in which case the user of Basic can authenticate as well, but not with OIDC.
+	LDAP *LDAPConfig `yaml:"ldap,omitempty"`
+
+	// SAML authenticates the users through a SAML 2.0 identity provider. It can be combined with OIDC, in which case
+	// the users can log in with either, but not with Basic or LDAP.
+	SAML *SAMLConfig `yaml:"saml,omitempty"`
+
+	// DefaultRole is the role of the authenticated users who weren't assigned one. Defaults to RoleAdmin if no role
+	// can be assigned, through Roles or through the roles claim or the role mapping of OIDC, and to RoleViewer otherwise.
+	DefaultRole Role `yaml:"default-role,omitempty"`
+
+	// Roles assigns roles to the users, identified by their username for Basic and LDAP, or by their subject for OIDC
+	// and SAML. e.g. {"operator": ["jane.doe"]}
+	Roles map[Role][]string `yaml:"roles,omitempty"`
+
+	// BruteForceProtection slows down and locks out the IPs that fail to authenticate through Basic or LDAP (optional).
+	// BruteForceProtectionConfig.ValidateAndSetDefaults must have been called beforehand.
+	BruteForceProtection *BruteForceProtectionConfig `yaml:"brute-force-protection,omitempty"`
+
+	// Session is the configuration of the sessions created by logging in through OIDC or SAML (optional).
+	// SessionConfig.ValidateAndSetDefaults must have been called beforehand.
+	Session *SessionConfig `yaml:"session,omitempty"`
+
+	// Audit is the configuration of the audit of the authentication and authorization events (optional)
+	Audit *AuditConfig `yaml:"audit,omitempty"`
+
 	gate *g8.Gate
 }
 
-// IsValid returns whether the security configuration is valid or not
+// IsValid returns whether the security configuration is valid or not.
+// If LDAP is configured, LDAPConfig.ValidateAndSetDefaults must have been called beforehand.
 func (c *Config) IsValid() bool {
+	if !c.isValidRoles() {
+		return false
+	}
+	if c.SAML != nil {
+		return c.Basic == nil && c.LDAP == nil && c.SAML.isValid() && (c.OIDC == nil || c.OIDC.isValid())
+	}
+	if c.LDAP != nil {
+		return c.OIDC == nil && c.LDAP.isValid() && (c.Basic == nil || c.Basic.isValid())
+	}
 	return (c.Basic != nil && c.Basic.isValid()) || (c.OIDC != nil && c.OIDC.isValid())
 }
 
@@ -37,19 +82,44 @@ func (c *Config) RegisterHandlers(router fiber.Router) error {
 		if err := c.OIDC.initialize(); err != nil {
 			return err
 		}
+		c.OIDC.sessionConfig = c.getSessionConfig()
+		c.OIDC.securityConfig = c
 		router.All("/oidc/login", c.OIDC.loginHandler)
 		router.All("/authorization-code/callback", adaptor.HTTPHandlerFunc(c.OIDC.callbackHandler))
 	}
+	if c.SAML != nil {
+		if err := c.SAML.initialize(); err != nil {
+			return err
+		}
+		c.SAML.sessionConfig = c.getSessionConfig()
+		c.SAML.securityConfig = c
+		router.Get(samlMetadataPath, c.SAML.metadataHandler)
+		router.All("/saml/login", c.SAML.loginHandler)
+		router.Post(samlACSPath, adaptor.HTTPHandlerFunc(c.SAML.assertionConsumerServiceHandler))
+	}
 	return nil
 }
 
 // ApplySecurityMiddleware applies an authentication middleware to the router passed.
 // The router passed should be a sub-router in charge of handlers that require authentication.
 func (c *Config) ApplySecurityMiddleware(router fiber.Router) error {
-	if c.OIDC != nil {
+	middleware, err := c.Middleware()
+	if err != nil {
+		return err
+	}
+	if middleware != nil {
+		router.Use(middleware)
+	}
+	return nil
+}
+
+// Middleware returns the authentication middleware, or nil if the configuration doesn't warrant authentication
+func (c *Config) Middleware() (fiber.Handler, error) {
+	if c.OIDC != nil || c.SAML != nil {
 		// We're going to use g8 for session handling
+		sessionConfig := c.getSessionConfig()
 		clientProvider := g8.NewClientProvider(func(token string) *g8.Client {
-			if _, exists := sessions.Get(token); exists {
+			if _, exists := sessionConfig.getSession(token); exists {
 				return g8.NewClient(token)
 			}
 			return nil
@@ -61,50 +131,148 @@ func (c *Config) ApplySecurityMiddleware(router fiber.Router) error {
 			}
 			return sessionCookie.Value
 		}
-		// TODO: g8: Add a way to update cookie after? would need the writer
 		authorizationService := g8.NewAuthorizationService().WithClientProvider(clientProvider)
 		c.gate = g8.New().WithAuthorizationService(authorizationService).WithCustomTokenExtractor(customTokenExtractorFunc)
-		router.Use(adaptor.HTTPMiddleware(c.gate.Protect))
-	} else if c.Basic != nil {
-		var decodedBcryptHash []byte
-		if len(c.Basic.PasswordBcryptHashBase64Encoded) > 0 {
-			var err error
-			decodedBcryptHash, err = base64.URLEncoding.DecodeString(c.Basic.PasswordBcryptHashBase64Encoded)
-			if err != nil {
-				return err
+		gateMiddleware := adaptor.HTTPMiddleware(c.gate.Protect)
+		return func(ctx *fiber.Ctx) error {
+			// The session is refreshed before the gate, which would otherwise leave no way to update the cookie
+			if sessionCookie := sessionConfig.refresh(ctx.Cookies(cookieNameSession)); sessionCookie != nil {
+				ctx.Append(fiber.HeaderSetCookie, sessionCookie.String())
 			}
+			return gateMiddleware(ctx)
+		}, nil
+	} else if c.Basic != nil || c.LDAP != nil {
+		if _, err := c.getDecodedBcryptHash(); err != nil {
+			return nil, err
 		}
-		router.Use(basicauth.New(basicauth.Config{
-			Authorizer: func(username, password string) bool {
-				if len(c.Basic.PasswordBcryptHashBase64Encoded) > 0 {
-					if username != c.Basic.Username || bcrypt.CompareHashAndPassword(decodedBcryptHash, []byte(password)) != nil {
-						return false
-					}
-				}
-				return true
-			},
-			Unauthorized: func(ctx *fiber.Ctx) error {
+		return func(ctx *fiber.Ctx) error {
+			result := c.authenticateBasic(ctx)
+			if result.retryAfter > 0 {
+				ctx.Set("Retry-After", strconv.Itoa(int(math.Ceil(result.retryAfter.Seconds()))))
+				return ctx.Status(429).SendString("too many failed attempts to authenticate, try again later")
+			}
+			if !result.authorized {
 				ctx.Set("WWW-Authenticate", "Basic")
 				return ctx.Status(401).SendString("Unauthorized")
-			},
-		}))
+			}
+			return ctx.Next()
+		}, nil
 	}
-	return nil
+	return nil, nil
 }
 
 // IsAuthenticated checks whether the user is authenticated
 // If the Config does not warrant authentication, it will always return true.
 func (c *Config) IsAuthenticated(ctx *fiber.Ctx) bool {
 	if c.gate != nil {
-		// TODO: Update g8 to support fasthttp natively? (see g8's fasthttp branch)
-		request, err := adaptor.ConvertRequest(ctx, false)
-		if err != nil {
-			log.Printf("[security.IsAuthenticated] Unexpected error converting request: %v", err)
-			return false
-		}
-		token := c.gate.ExtractTokenFromRequest(request)
-		_, hasSession := sessions.Get(token)
+		_, hasSession := c.getSession(ctx)
 		return hasSession
 	}
+	if c.Basic != nil || c.LDAP != nil {
+		return c.authenticateBasic(ctx).authorized
+	}
 	return false
 }
+
+// basicAuthentication is the result of the basic authentication of a request
+type basicAuthentication struct {
+	// config is the configuration the request was authenticated through
+	config *Config
+
+	// username is the username of the credentials of the request, if any
+	username string
+
+	// authorized is whether the credentials of the request are valid
+	authorized bool
+
+	// retryAfter is how long the client must wait for before attempting to authenticate again, if the credentials
+	// weren't verified because the client failed to authenticate too many times
+	retryAfter time.Duration
+}
+
+// authenticateBasic verifies the credentials passed through the Authorization header of the request, unless the client
+// must wait before attempting to authenticate again because of the brute-force protection. The result is kept in the
+// locals of the request, so that the credentials are only verified once per request.
+func (c *Config) authenticateBasic(ctx *fiber.Ctx) *basicAuthentication {
+	// A request may be authenticated through several configurations, e.g. the one of Gatus and the one of a tenant
+	if result, ok := ctx.Locals(basicAuthenticationLocalsKey).(*basicAuthentication); ok && result.config == c {
+		return result
+	}
+	result := &basicAuthentication{config: c}
+	ctx.Locals(basicAuthenticationLocalsKey, result)
+	username, password, ok := parseBasicAuthorizationHeader(string(ctx.Request().Header.Peek("Authorization")))
+	if !ok {
+		// Browsers send a first request without credentials, which isn't an attempt to authenticate
+		return result
+	}
+	result.username = username
+	// The IP is copied, because it points to a buffer of fasthttp that is reused by the next requests when it's read
+	// from a header set by a trusted proxy, whereas it's kept by the brute-force protection and the audit log
+	ip, now := strings.Clone(ctx.IP()), time.Now()
+	if c.BruteForceProtection != nil {
+		if result.retryAfter = c.BruteForceProtection.getRetryAfter(ip, now); result.retryAfter > 0 {
+			c.RecordAuditEvent(audit.NewEntry(audit.ActionAuthenticationFailure, ip, username, false, "too many failed attempts to authenticate"))
+			return result
+		}
+	}
+	decodedBcryptHash, err := c.getDecodedBcryptHash()
+	if err != nil {
+		return result
+	}
+	result.authorized = c.isAuthorized(decodedBcryptHash, username, password)
+	if result.authorized {
+		if c.BruteForceProtection != nil {
+			c.BruteForceProtection.recordSuccess(ip)
+		}
+		return result
+	}
+	log.Printf("[security.authenticateBasic] Failed attempt to authenticate as user %s from %s", username, ip)
+	metrics.IncrementFailedAuthentications()
+	c.RecordAuditEvent(audit.NewEntry(audit.ActionAuthenticationFailure, ip, username, false, "invalid credentials"))
+	if c.BruteForceProtection != nil {
+		c.BruteForceProtection.recordFailure(ip, username, now)
+	}
+	return result
+}
+
+// getDecodedBcryptHash returns the decoded bcrypt hash of the password of the user of Basic, if any
+func (c *Config) getDecodedBcryptHash() ([]byte, error) {
+	if c.Basic == nil || len(c.Basic.PasswordBcryptHashBase64Encoded) == 0 {
+		return nil, nil
+	}
+	return base64.URLEncoding.DecodeString(c.Basic.PasswordBcryptHashBase64Encoded)
+}
+
+// getSession returns the session the request is authenticated with, if any
+func (c *Config) getSession(ctx *fiber.Ctx) (*session, bool) {
+	// TODO: Update g8 to support fasthttp natively? (see g8's fasthttp branch)
+	request, err := adaptor.ConvertRequest(ctx, false)
+	if err != nil {
+		log.Printf("[security.getSession] Unexpected error converting request: %v", err)
+		return nil, false
+	}
+	return c.getSessionConfig().getSession(c.gate.ExtractTokenFromRequest(request))
+}
+
+// getSessionConfig returns the configuration of the sessions, or the default one if there's none
+func (c *Config) getSessionConfig() *SessionConfig {
+	if c.Session == nil {
+		return &SessionConfig{Duration: DefaultSessionDuration}
+	}
+	return c.Session
+}
+
+// UsesBasicAuthentication returns whether the users are prompted for their credentials through basic authentication,
+// which is the case if either Basic or LDAP is configured, and neither OIDC nor SAML is
+func (c *Config) UsesBasicAuthentication() bool {
+	return c.OIDC == nil && c.SAML == nil && (c.Basic != nil || c.LDAP != nil)
+}
+
+// isAuthorized returns whether the credentials passed through basic authentication are the ones of the user of Basic,
+// given the decoded bcrypt hash of its password, or the ones of a user of the LDAP server
+func (c *Config) isAuthorized(decodedBcryptHash []byte, username, password string) bool {
+	if c.Basic != nil && c.Basic.isAuthorized(decodedBcryptHash, username, password) {
+		return true
+	}
+	return c.LDAP != nil && c.LDAP.isAuthorized(username, password)
+}
@@ -97,6 +97,46 @@ func TestConfig_ApplySecurityMiddleware(t *testing.T) {
 	})
 }
 
+func TestConfig_IsAuthenticatedWithBasic(t *testing.T) {
+	c := &Config{Basic: &BasicConfig{
+		Username:                        "john.doe",
+		PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT",
+	}}
+	app := fiber.New()
+	app.Get("/test", func(ctx *fiber.Ctx) error {
+		if c.IsAuthenticated(ctx) {
+			return ctx.SendStatus(200)
+		}
+		return ctx.SendStatus(401)
+	})
+	scenarios := []struct {
+		name         string
+		username     string
+		password     string
+		expectedCode int
+	}{
+		{name: "no-credentials", expectedCode: 401},
+		{name: "bad-password", username: "john.doe", password: "hunter3", expectedCode: 401},
+		{name: "bad-username", username: "jane.doe", password: "hunter2", expectedCode: 401},
+		{name: "good-credentials", username: "john.doe", password: "hunter2", expectedCode: 200},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "/test", http.NoBody)
+			if len(scenario.username) > 0 {
+				request.SetBasicAuth(scenario.username, scenario.password)
+			}
+			response, err := app.Test(request)
+			if err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if response.StatusCode != scenario.expectedCode {
+				t.Errorf("expected code to be %d, but was %d", scenario.expectedCode, response.StatusCode)
+			}
+		})
+	}
+}
+
 func TestConfig_RegisterHandlers(t *testing.T) {
 	c := &Config{}
 	app := fiber.New()
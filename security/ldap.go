@@ -0,0 +1,200 @@
+package security
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/TwiN/gocache/v2"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	// DefaultLDAPUserFilter is the filter used to search for the user trying to authenticate if none is specified
+	DefaultLDAPUserFilter = "(uid={username})"
+
+	// ldapUsernamePlaceholder is replaced by the escaped username in the user filter
+	ldapUsernamePlaceholder = "{username}"
+
+	// ldapTimeout is how long the LDAP server has to respond to each request
+	ldapTimeout = 5 * time.Second
+
+	// ldapAuthenticationTTL is how long a successful authentication is remembered, which spares the LDAP server from
+	// being queried each time the dashboard refreshes the statuses
+	ldapAuthenticationTTL = 5 * time.Minute
+)
+
+var (
+	// ErrInvalidLDAPConfig is the error returned when the LDAP configuration is invalid
+	ErrInvalidLDAPConfig = errors.New("invalid ldap configuration")
+
+	ldapAuthentications = gocache.NewCache().WithMaxSize(1000).WithEvictionPolicy(gocache.LeastRecentlyUsed)
+)
+
+// LDAPConfig is the configuration for the authentication of the users against an LDAP server, such as Active
+// Directory. Users are prompted for their credentials through basic authentication, and are authenticated by searching
+// for their entry with the bind DN, and then binding as that entry with the password they entered.
+type LDAPConfig struct {
+	// URL is the address of the LDAP server, prefixed by either ldap:// or ldaps://, the latter of which uses TLS.
+	// e.g. ldaps://ad.example.org:636
+	URL string `yaml:"url"`
+
+	// BindDN is the DN to bind with to search for the users. If empty, the search is performed after an anonymous bind.
+	BindDN       string `yaml:"bind-dn,omitempty"`
+	BindPassword string `yaml:"bind-password,omitempty"`
+
+	// BaseDN is the DN to search for the users from, e.g. dc=example,dc=org
+	BaseDN string `yaml:"base-dn"`
+
+	// UserFilter is the filter of the search for the user trying to authenticate, in which {username} is replaced by
+	// the username entered (defaults to DefaultLDAPUserFilter). e.g. (sAMAccountName={username}) for Active Directory
+	UserFilter string `yaml:"user-filter,omitempty"`
+
+	// GroupFilter is an additional filter that the user must match to be allowed to authenticate, which is typically
+	// used to only allow the members of a group. e.g. (memberOf=cn=gatus,ou=groups,dc=example,dc=org)
+	GroupFilter string `yaml:"group-filter,omitempty"`
+
+	// StartTLS is whether to upgrade the connection to TLS using the StartTLS extended operation. Only applies to ldap://
+	StartTLS bool `yaml:"start-tls,omitempty"`
+
+	// InsecureSkipVerify is whether to skip the verification of the certificate of the LDAP server
+	InsecureSkipVerify bool `yaml:"insecure-skip-verify,omitempty"`
+
+	// CertificateAuthorityFile is the file of the certificates, in PEM format, of the authorities that the certificate
+	// of the LDAP server must be issued by. If empty, the certificate authorities of the system are used.
+	CertificateAuthorityFile string `yaml:"certificate-authority-file,omitempty"`
+
+	certificateAuthorities *x509.CertPool
+
+	// dial connects to the LDAP server. Overridden in tests.
+	dial func(address string, tlsConfig *tls.Config) (ldap.Client, error)
+}
+
+// ValidateAndSetDefaults validates the LDAP configuration, sets the default values if necessary and loads the
+// certificate authorities
+func (c *LDAPConfig) ValidateAndSetDefaults() error {
+	if parsedURL, err := url.Parse(c.URL); err != nil || (parsedURL.Scheme != "ldap" && parsedURL.Scheme != "ldaps") || len(parsedURL.Host) == 0 {
+		return fmt.Errorf("%w: url must be prefixed by ldap:// or ldaps://", ErrInvalidLDAPConfig)
+	}
+	if len(c.BindDN) > 0 && len(c.BindPassword) == 0 {
+		return fmt.Errorf("%w: bind-password must be specified along with bind-dn", ErrInvalidLDAPConfig)
+	}
+	if len(c.BaseDN) == 0 {
+		return fmt.Errorf("%w: base-dn must be specified", ErrInvalidLDAPConfig)
+	}
+	if len(c.UserFilter) == 0 {
+		c.UserFilter = DefaultLDAPUserFilter
+	}
+	if !strings.Contains(c.UserFilter, ldapUsernamePlaceholder) {
+		return fmt.Errorf("%w: user-filter must contain %s", ErrInvalidLDAPConfig, ldapUsernamePlaceholder)
+	}
+	if _, err := ldap.CompileFilter(c.getSearchFilter("username")); err != nil {
+		return fmt.Errorf("%w: invalid user-filter or group-filter: %v", ErrInvalidLDAPConfig, err)
+	}
+	if len(c.CertificateAuthorityFile) > 0 {
+		certificates, err := os.ReadFile(c.CertificateAuthorityFile)
+		if err != nil {
+			return fmt.Errorf("%w: unable to read certificate-authority-file: %v", ErrInvalidLDAPConfig, err)
+		}
+		c.certificateAuthorities = x509.NewCertPool()
+		if !c.certificateAuthorities.AppendCertsFromPEM(certificates) {
+			return fmt.Errorf("%w: certificate-authority-file has no certificate in PEM format", ErrInvalidLDAPConfig)
+		}
+	}
+	return nil
+}
+
+// isValid returns whether the LDAP configuration is valid, which requires ValidateAndSetDefaults to have been called
+func (c *LDAPConfig) isValid() bool {
+	return len(c.URL) > 0 && len(c.BaseDN) > 0 && strings.Contains(c.UserFilter, ldapUsernamePlaceholder)
+}
+
+// getSearchFilter returns the filter of the search for the user whose username is passed, which must be escaped
+func (c *LDAPConfig) getSearchFilter(escapedUsername string) string {
+	filter := strings.ReplaceAll(c.UserFilter, ldapUsernamePlaceholder, escapedUsername)
+	if len(c.GroupFilter) > 0 {
+		filter = "(&" + filter + c.GroupFilter + ")"
+	}
+	return filter
+}
+
+// isAuthorized returns whether the credentials passed are the ones of a user of the LDAP server matching the filters.
+// Successful authentications are remembered for ldapAuthenticationTTL.
+func (c *LDAPConfig) isAuthorized(username, password string) bool {
+	// An unauthenticated bind, which is what a bind with an empty password is, always succeeds
+	if len(username) == 0 || len(password) == 0 {
+		return false
+	}
+	hash := sha256.Sum256([]byte(c.URL + "\x00" + username + "\x00" + password))
+	cacheKey := hex.EncodeToString(hash[:])
+	if _, exists := ldapAuthentications.Get(cacheKey); exists {
+		return true
+	}
+	if err := c.authenticate(username, password); err != nil {
+		log.Printf("[security.isAuthorized] Failed to authenticate user %s through LDAP: %s", username, err.Error())
+		return false
+	}
+	ldapAuthentications.SetWithTTL(cacheKey, true, ldapAuthenticationTTL)
+	return true
+}
+
+// authenticate searches for the entry of the user whose username is passed and binds as that entry with the password
+// passed, returning an error if the user can't be authenticated
+func (c *LDAPConfig) authenticate(username, password string) error {
+	tlsConfig := &tls.Config{InsecureSkipVerify: c.InsecureSkipVerify, RootCAs: c.certificateAuthorities}
+	if parsedURL, err := url.Parse(c.URL); err == nil {
+		tlsConfig.ServerName = parsedURL.Hostname()
+	}
+	dial := c.dial
+	if dial == nil {
+		dial = dialLDAP
+	}
+	conn, err := dial(c.URL, tlsConfig)
+	if err != nil {
+		return fmt.Errorf("error connecting to LDAP server: %w", err)
+	}
+	defer conn.Close()
+	if c.StartTLS {
+		if err = conn.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("error upgrading LDAP connection using StartTLS: %w", err)
+		}
+	}
+	if len(c.BindDN) == 0 {
+		err = conn.UnauthenticatedBind("")
+	} else {
+		err = conn.Bind(c.BindDN, c.BindPassword)
+	}
+	if err != nil {
+		return fmt.Errorf("error binding to LDAP server: %w", err)
+	}
+	searchRequest := ldap.NewSearchRequest(c.BaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 2, int(ldapTimeout.Seconds()), false, c.getSearchFilter(ldap.EscapeFilter(username)), []string{"dn"}, nil)
+	searchResult, err := conn.Search(searchRequest)
+	if err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+		return fmt.Errorf("error searching LDAP server: %w", err)
+	}
+	if searchResult == nil || len(searchResult.Entries) != 1 {
+		return errors.New("user not found, or more than one entry matches")
+	}
+	if err = conn.Bind(searchResult.Entries[0].DN, password); err != nil {
+		return fmt.Errorf("invalid credentials: %w", err)
+	}
+	return nil
+}
+
+func dialLDAP(address string, tlsConfig *tls.Config) (ldap.Client, error) {
+	conn, err := ldap.DialURL(address, ldap.DialWithDialer(&net.Dialer{Timeout: ldapTimeout}), ldap.DialWithTLSConfig(tlsConfig))
+	if err != nil {
+		return nil, err
+	}
+	conn.SetTimeout(ldapTimeout)
+	return conn, nil
+}
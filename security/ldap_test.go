@@ -0,0 +1,263 @@
+package security
+
+import (
+	"crypto/tls"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/gofiber/fiber/v2"
+)
+
+// fakeLDAPClient is an LDAP server with a service account and users, each of whom is in the groups listed
+type fakeLDAPClient struct {
+	ldap.Client
+
+	users   map[string]string // Password of each user, by DN
+	groups  map[string]string // Group of each user, by DN
+	binds   *[]string
+	boundAs string
+}
+
+func (c *fakeLDAPClient) StartTLS(*tls.Config) error {
+	return nil
+}
+
+func (c *fakeLDAPClient) Close() error {
+	return nil
+}
+
+func (c *fakeLDAPClient) UnauthenticatedBind(string) error {
+	return errors.New("anonymous bind not allowed")
+}
+
+func (c *fakeLDAPClient) Bind(username, password string) error {
+	*c.binds = append(*c.binds, username)
+	if expectedPassword, exists := c.users[username]; !exists || expectedPassword != password {
+		return ldap.NewError(ldap.LDAPResultInvalidCredentials, errors.New("invalid credentials"))
+	}
+	c.boundAs = username
+	return nil
+}
+
+func (c *fakeLDAPClient) Search(request *ldap.SearchRequest) (*ldap.SearchResult, error) {
+	if c.boundAs != "cn=gatus,dc=example,dc=org" {
+		return nil, ldap.NewError(ldap.LDAPResultInsufficientAccessRights, errors.New("insufficient access rights"))
+	}
+	result := &ldap.SearchResult{}
+	for dn, group := range c.groups {
+		uid := dn[len("uid=") : len(dn)-len(",dc=example,dc=org")]
+		if request.Filter == "(&(uid="+uid+")(memberOf="+group+"))" || request.Filter == "(uid="+uid+")" {
+			result.Entries = append(result.Entries, ldap.NewEntry(dn, nil))
+		}
+	}
+	return result, nil
+}
+
+func newFakeLDAPConfig(groupFilter string, binds *[]string) *LDAPConfig {
+	c := &LDAPConfig{
+		URL:          "ldaps://ldap.example.org",
+		BindDN:       "cn=gatus,dc=example,dc=org",
+		BindPassword: "secret",
+		BaseDN:       "dc=example,dc=org",
+		GroupFilter:  groupFilter,
+	}
+	c.dial = func(address string, tlsConfig *tls.Config) (ldap.Client, error) {
+		return &fakeLDAPClient{
+			users: map[string]string{
+				"cn=gatus,dc=example,dc=org":     "secret",
+				"uid=john.doe,dc=example,dc=org": "hunter2",
+				"uid=jane.doe,dc=example,dc=org": "hunter3",
+			},
+			groups: map[string]string{
+				"uid=john.doe,dc=example,dc=org": "cn=gatus-users,dc=example,dc=org",
+				"uid=jane.doe,dc=example,dc=org": "cn=other,dc=example,dc=org",
+			},
+			binds: binds,
+		}, nil
+	}
+	return c
+}
+
+func TestLDAPConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name               string
+		config             *LDAPConfig
+		expectedUserFilter string
+		expectedErr        bool
+	}{
+		{
+			name:               "default-user-filter",
+			config:             &LDAPConfig{URL: "ldap://ldap.example.org", BaseDN: "dc=example,dc=org"},
+			expectedUserFilter: DefaultLDAPUserFilter,
+		},
+		{
+			name:               "active-directory",
+			config:             &LDAPConfig{URL: "ldaps://ad.example.org:636", BindDN: "cn=gatus,dc=example,dc=org", BindPassword: "secret", BaseDN: "dc=example,dc=org", UserFilter: "(sAMAccountName={username})", GroupFilter: "(memberOf=cn=gatus,dc=example,dc=org)"},
+			expectedUserFilter: "(sAMAccountName={username})",
+		},
+		{
+			name:        "invalid-url",
+			config:      &LDAPConfig{URL: "https://ldap.example.org", BaseDN: "dc=example,dc=org"},
+			expectedErr: true,
+		},
+		{
+			name:        "bind-dn-without-password",
+			config:      &LDAPConfig{URL: "ldap://ldap.example.org", BindDN: "cn=gatus,dc=example,dc=org", BaseDN: "dc=example,dc=org"},
+			expectedErr: true,
+		},
+		{
+			name:        "no-base-dn",
+			config:      &LDAPConfig{URL: "ldap://ldap.example.org"},
+			expectedErr: true,
+		},
+		{
+			name:        "user-filter-without-username",
+			config:      &LDAPConfig{URL: "ldap://ldap.example.org", BaseDN: "dc=example,dc=org", UserFilter: "(uid=john.doe)"},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid-group-filter",
+			config:      &LDAPConfig{URL: "ldap://ldap.example.org", BaseDN: "dc=example,dc=org", GroupFilter: "memberOf=cn=gatus"},
+			expectedErr: true,
+		},
+		{
+			name:               "certificate-authority",
+			config:             &LDAPConfig{URL: "ldaps://ldap.example.org", BaseDN: "dc=example,dc=org", CertificateAuthorityFile: "../testdata/cert.pem"},
+			expectedUserFilter: DefaultLDAPUserFilter,
+		},
+		{
+			name:        "certificate-authority-without-certificate",
+			config:      &LDAPConfig{URL: "ldaps://ldap.example.org", BaseDN: "dc=example,dc=org", CertificateAuthorityFile: "../testdata/cert.key"},
+			expectedErr: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			err := scenario.config.ValidateAndSetDefaults()
+			if (err != nil) != scenario.expectedErr {
+				t.Fatalf("expected the existence of an error to be %v, got %v", scenario.expectedErr, err)
+			}
+			if err != nil {
+				if !errors.Is(err, ErrInvalidLDAPConfig) {
+					t.Errorf("expected %v, got %v", ErrInvalidLDAPConfig, err)
+				}
+				return
+			}
+			if scenario.config.UserFilter != scenario.expectedUserFilter {
+				t.Errorf("expected user filter %s, got %s", scenario.expectedUserFilter, scenario.config.UserFilter)
+			}
+		})
+	}
+}
+
+func TestLDAPConfig_isAuthorized(t *testing.T) {
+	defer ldapAuthentications.Clear()
+	var binds []string
+	c := newFakeLDAPConfig("(memberOf=cn=gatus-users,dc=example,dc=org)", &binds)
+	if err := c.ValidateAndSetDefaults(); err != nil {
+		t.Fatal(err)
+	}
+	scenarios := []struct {
+		name               string
+		username, password string
+		expected           bool
+	}{
+		{name: "member-of-group", username: "john.doe", password: "hunter2", expected: true},
+		{name: "wrong-password", username: "john.doe", password: "hunter3"},
+		{name: "empty-password", username: "john.doe"},
+		{name: "not-member-of-group", username: "jane.doe", password: "hunter3"},
+		{name: "unknown-user", username: "nobody", password: "hunter2"},
+		{name: "filter-injection", username: "*", password: "hunter2"},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if authorized := c.isAuthorized(scenario.username, scenario.password); authorized != scenario.expected {
+				t.Errorf("expected %v, got %v", scenario.expected, authorized)
+			}
+		})
+	}
+	t.Run("remembers-successful-authentications", func(t *testing.T) {
+		numberOfBinds := len(binds)
+		if !c.isAuthorized("john.doe", "hunter2") {
+			t.Fatal("expected john.doe to be authorized")
+		}
+		if len(binds) != numberOfBinds {
+			t.Errorf("expected the LDAP server not to be queried again, got binds %v", binds[numberOfBinds:])
+		}
+	})
+}
+
+func TestConfig_ApplySecurityMiddlewareWithLDAP(t *testing.T) {
+	defer ldapAuthentications.Clear()
+	var binds []string
+	c := &Config{
+		Basic: &BasicConfig{Username: "admin", PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT"},
+		LDAP:  newFakeLDAPConfig("", &binds),
+	}
+	if err := c.LDAP.ValidateAndSetDefaults(); err != nil {
+		t.Fatal(err)
+	}
+	if !c.IsValid() {
+		t.Fatal("expected the security configuration to be valid")
+	}
+	app := fiber.New()
+	if err := c.ApplySecurityMiddleware(app); err != nil {
+		t.Fatal(err)
+	}
+	app.Get("/test", func(ctx *fiber.Ctx) error {
+		return ctx.SendString(strconv.FormatBool(c.IsAuthenticated(ctx)))
+	})
+	scenarios := []struct {
+		name               string
+		username, password string
+		expectedCode       int
+	}{
+		{name: "no-credentials", expectedCode: 401},
+		{name: "ldap-user", username: "jane.doe", password: "hunter3", expectedCode: 200},
+		{name: "basic-user", username: "admin", password: "hunter2", expectedCode: 200},
+		{name: "wrong-password", username: "jane.doe", password: "hunter2", expectedCode: 401},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "/test", http.NoBody)
+			if len(scenario.username) > 0 {
+				request.SetBasicAuth(scenario.username, scenario.password)
+			}
+			response, err := app.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.expectedCode {
+				t.Fatalf("expected %d, got %d", scenario.expectedCode, response.StatusCode)
+			}
+			if body, _ := io.ReadAll(response.Body); response.StatusCode == 200 && string(body) != "true" {
+				t.Errorf("expected the user to be authenticated, got %s", body)
+			}
+		})
+	}
+}
+
+func TestConfig_IsValidWithLDAP(t *testing.T) {
+	ldapConfig := &LDAPConfig{URL: "ldap://ldap.example.org", BaseDN: "dc=example,dc=org"}
+	if err := ldapConfig.ValidateAndSetDefaults(); err != nil {
+		t.Fatal(err)
+	}
+	if !(&Config{LDAP: ldapConfig}).IsValid() {
+		t.Error("expected LDAP alone to be valid")
+	}
+	if (&Config{LDAP: ldapConfig, Basic: &BasicConfig{Username: "admin"}}).IsValid() {
+		t.Error("expected LDAP with an invalid basic configuration to be invalid")
+	}
+	if (&Config{LDAP: ldapConfig, OIDC: &OIDCConfig{}}).IsValid() {
+		t.Error("expected LDAP with OIDC to be invalid")
+	}
+	if !(&Config{LDAP: ldapConfig}).UsesBasicAuthentication() {
+		t.Error("expected LDAP to use basic authentication")
+	}
+}
@@ -0,0 +1,122 @@
+package security
+
+import (
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var (
+	// ErrInvalidMetricsSecurityConfig is returned when none of the authentication methods of the metrics security
+	// configuration are configured, or when its basic configuration is incomplete
+	ErrInvalidMetricsSecurityConfig = errors.New("invalid metrics security configuration: basic, bearer-token or client-certificate-authority-file must be configured")
+
+	// ErrInvalidMetricsClientCertificateAuthority is returned when the certificate authority used to verify the
+	// certificates of the clients cannot be loaded
+	ErrInvalidMetricsClientCertificateAuthority = errors.New("invalid metrics security configuration: client-certificate-authority-file must contain at least one PEM encoded certificate")
+)
+
+// MetricsConfig is the security configuration of the metrics endpoint, which is independent of the security
+// configuration of the dashboard and of the API.
+//
+// A request is authorized as soon as it's authenticated through any of the methods configured.
+type MetricsConfig struct {
+	// Basic is the configuration of the basic authentication of the metrics endpoint
+	Basic *BasicConfig `yaml:"basic,omitempty"`
+
+	// BearerToken is the token that must be passed through the Authorization header, e.g. Authorization: Bearer <token>
+	BearerToken string `yaml:"bearer-token,omitempty"`
+
+	// ClientCertificateAuthorityFile is the PEM encoded certificate authority that the certificate presented by the
+	// client must be signed by. Requires TLS to be configured through web.tls.
+	ClientCertificateAuthorityFile string `yaml:"client-certificate-authority-file,omitempty"`
+
+	decodedBcryptHash            []byte
+	clientCertificateAuthorities *x509.CertPool
+}
+
+// ValidateAndSetDefaults validates the metrics security configuration
+func (c *MetricsConfig) ValidateAndSetDefaults() error {
+	if c.Basic == nil && len(c.BearerToken) == 0 && len(c.ClientCertificateAuthorityFile) == 0 {
+		return ErrInvalidMetricsSecurityConfig
+	}
+	if c.Basic != nil {
+		if !c.Basic.isValid() {
+			return ErrInvalidMetricsSecurityConfig
+		}
+		decodedBcryptHash, err := base64.URLEncoding.DecodeString(c.Basic.PasswordBcryptHashBase64Encoded)
+		if err != nil {
+			return fmt.Errorf("invalid metrics security configuration: %w", err)
+		}
+		c.decodedBcryptHash = decodedBcryptHash
+	}
+	if len(c.ClientCertificateAuthorityFile) > 0 {
+		clientCertificateAuthorities, err := c.ClientCertificateAuthorities()
+		if err != nil {
+			return err
+		}
+		c.clientCertificateAuthorities = clientCertificateAuthorities
+	}
+	return nil
+}
+
+// ClientCertificateAuthorities returns the pool of the certificate authorities that the certificates of the clients
+// must be signed by, or nil if mTLS isn't configured
+func (c *MetricsConfig) ClientCertificateAuthorities() (*x509.CertPool, error) {
+	if len(c.ClientCertificateAuthorityFile) == 0 {
+		return nil, nil
+	}
+	pem, err := os.ReadFile(c.ClientCertificateAuthorityFile)
+	if err != nil {
+		return nil, fmt.Errorf("invalid metrics security configuration: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, ErrInvalidMetricsClientCertificateAuthority
+	}
+	return pool, nil
+}
+
+// IsAuthorized returns whether the request is authenticated through any of the methods configured
+func (c *MetricsConfig) IsAuthorized(ctx *fiber.Ctx) bool {
+	if len(c.ClientCertificateAuthorityFile) > 0 {
+		// The certificate of the client is verified against the certificate authority of the metrics specifically,
+		// because the TLS handshake also accepts the certificates signed by those of web.tls.client-auth
+		if IsClientCertificateVerifiedBy(ctx.Context().TLSConnectionState(), c.clientCertificateAuthorities) {
+			return true
+		}
+	}
+	authorizationHeader := string(ctx.Request().Header.Peek("Authorization"))
+	if len(c.BearerToken) > 0 {
+		if token, found := strings.CutPrefix(authorizationHeader, "Bearer "); found && subtle.ConstantTimeCompare([]byte(strings.TrimSpace(token)), []byte(c.BearerToken)) == 1 {
+			return true
+		}
+	}
+	if c.Basic != nil {
+		if username, password, ok := parseBasicAuthorizationHeader(authorizationHeader); ok && c.Basic.isAuthorized(c.decodedBcryptHash, username, password) {
+			return true
+		}
+	}
+	return false
+}
+
+// Middleware returns a handler that rejects the requests that aren't authorized
+func (c *MetricsConfig) Middleware() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		if !c.IsAuthorized(ctx) {
+			if c.Basic != nil {
+				ctx.Set("WWW-Authenticate", "Basic")
+			} else if len(c.BearerToken) > 0 {
+				ctx.Set("WWW-Authenticate", "Bearer")
+			}
+			return ctx.Status(401).SendString("Unauthorized")
+		}
+		return ctx.Next()
+	}
+}
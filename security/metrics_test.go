@@ -0,0 +1,103 @@
+package security
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestMetricsConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		config      *MetricsConfig
+		expectedErr error
+	}{
+		{
+			name:        "empty",
+			config:      &MetricsConfig{},
+			expectedErr: ErrInvalidMetricsSecurityConfig,
+		},
+		{
+			name:   "basic",
+			config: &MetricsConfig{Basic: &BasicConfig{Username: "prometheus", PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT"}},
+		},
+		{
+			name:        "basic-without-password",
+			config:      &MetricsConfig{Basic: &BasicConfig{Username: "prometheus"}},
+			expectedErr: ErrInvalidMetricsSecurityConfig,
+		},
+		{
+			name:   "bearer-token",
+			config: &MetricsConfig{BearerToken: "secret"},
+		},
+		{
+			name:   "client-certificate-authority",
+			config: &MetricsConfig{ClientCertificateAuthorityFile: "../testdata/cert.pem"},
+		},
+		{
+			name:        "client-certificate-authority-without-certificate",
+			config:      &MetricsConfig{ClientCertificateAuthorityFile: "../testdata/cert.key"},
+			expectedErr: ErrInvalidMetricsClientCertificateAuthority,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.config.ValidateAndSetDefaults(); !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+		})
+	}
+	t.Run("client-certificate-authority-file-not-found", func(t *testing.T) {
+		if err := (&MetricsConfig{ClientCertificateAuthorityFile: "../testdata/nope.pem"}).ValidateAndSetDefaults(); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
+
+func TestMetricsConfig_Middleware(t *testing.T) {
+	config := &MetricsConfig{
+		Basic:       &BasicConfig{Username: "prometheus", PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT"},
+		BearerToken: "secret",
+	}
+	if err := config.ValidateAndSetDefaults(); err != nil {
+		t.Fatal(err)
+	}
+	app := fiber.New()
+	app.Get("/metrics", config.Middleware(), func(c *fiber.Ctx) error {
+		return c.SendString("metrics")
+	})
+	scenarios := []struct {
+		name          string
+		authorization string
+		expectedCode  int
+	}{
+		{name: "no-credentials", expectedCode: 401},
+		{name: "basic", authorization: "Basic " + base64.StdEncoding.EncodeToString([]byte("prometheus:hunter2")), expectedCode: 200},
+		{name: "basic-with-wrong-password", authorization: "Basic " + base64.StdEncoding.EncodeToString([]byte("prometheus:hunter3")), expectedCode: 401},
+		{name: "bearer-token", authorization: "Bearer secret", expectedCode: 200},
+		{name: "wrong-bearer-token", authorization: "Bearer nope", expectedCode: 401},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "/metrics", http.NoBody)
+			if len(scenario.authorization) > 0 {
+				request.Header.Set("Authorization", scenario.authorization)
+			}
+			response, err := app.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.expectedCode {
+				t.Errorf("expected %d, got %d", scenario.expectedCode, response.StatusCode)
+			}
+			if response.StatusCode == 401 && response.Header.Get("WWW-Authenticate") != "Basic" {
+				t.Errorf("expected WWW-Authenticate header to be Basic, got %q", response.Header.Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
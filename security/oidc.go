@@ -4,9 +4,11 @@ import (
 	"context"
 	"log"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/TwiN/gatus/v5/audit"
 	"github.com/coreos/go-oidc/v3/oidc"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -22,13 +24,41 @@ type OIDCConfig struct {
 	Scopes          []string `yaml:"scopes"`           // e.g. ["openid"]
 	AllowedSubjects []string `yaml:"allowed-subjects"` // e.g. ["user1@example.com"]. If empty, all subjects are allowed
 
-	oauth2Config oauth2.Config
-	verifier     *oidc.IDTokenVerifier
+	// RolesClaim is the claim of the ID token holding the role, or the list of roles, of the user, e.g. roles.
+	// Values that aren't roles are ignored, and the highest of the roles is granted.
+	RolesClaim string `yaml:"roles-claim,omitempty"`
+
+	// GroupsClaim is the claim of the ID token holding the list of groups of the user, e.g. groups, which is required
+	// to restrict the visibility of groups to the groups of the users
+	GroupsClaim string `yaml:"groups-claim,omitempty"`
+
+	// RoleMapping grants roles to the users based on the values of their roles claim or of their groups claim, e.g.
+	// {"admin": ["gatus-admins"], "operator": ["sre"]}, for identity providers whose values aren't Gatus roles
+	RoleMapping map[Role][]string `yaml:"role-mapping,omitempty"`
+
+	// AllowedGroups is the list of groups allowed to log in, read from the groups claim. If both AllowedSubjects and
+	// AllowedGroups are set, users are only allowed if both their subject and one of their groups are allowed.
+	AllowedGroups []string `yaml:"allowed-groups,omitempty"`
+
+	oauth2Config   oauth2.Config
+	verifier       *oidc.IDTokenVerifier
+	sessionConfig  *SessionConfig
+	securityConfig *Config
 }
 
 // isValid returns whether the basic security configuration is valid or not
 func (c *OIDCConfig) isValid() bool {
-	return len(c.IssuerURL) > 0 && len(c.RedirectURL) > 0 && strings.HasSuffix(c.RedirectURL, "/authorization-code/callback") && len(c.ClientID) > 0 && len(c.ClientSecret) > 0 && len(c.Scopes) > 0
+	return len(c.IssuerURL) > 0 && len(c.RedirectURL) > 0 && strings.HasSuffix(c.RedirectURL, "/authorization-code/callback") && len(c.ClientID) > 0 && len(c.ClientSecret) > 0 && len(c.Scopes) > 0 && c.isValidRoleMapping() && (len(c.AllowedGroups) == 0 || len(c.GroupsClaim) > 0)
+}
+
+// isValidRoleMapping returns whether the roles granted by RoleMapping are supported roles
+func (c *OIDCConfig) isValidRoleMapping() bool {
+	for role := range c.RoleMapping {
+		if !role.IsValid() {
+			return false
+		}
+	}
+	return true
 }
 
 func (c *OIDCConfig) initialize() error {
@@ -66,6 +96,16 @@ func (c *OIDCConfig) loginHandler(ctx *fiber.Ctx) error {
 		SameSite: "lax",
 		HTTPOnly: true,
 	})
+	if c.sessionConfig.isRememberMeRequested(ctx) {
+		ctx.Cookie(&fiber.Cookie{
+			Name:     cookieNameRememberMe,
+			Value:    "true",
+			Path:     "/",
+			MaxAge:   int(time.Hour.Seconds()),
+			SameSite: "lax",
+			HTTPOnly: true,
+		})
+	}
 	return ctx.Redirect(c.oauth2Config.AuthCodeURL(state, oidc.Nonce(nonce)), http.StatusFound)
 }
 
@@ -98,6 +138,7 @@ func (c *OIDCConfig) callbackHandler(w http.ResponseWriter, r *http.Request) { /
 	}
 	idToken, err := c.verifier.Verify(r.Context(), rawIDToken)
 	if err != nil {
+		c.securityConfig.RecordAuditEvent(audit.NewEntry(audit.ActionLogin, getRemoteIP(r), "", false, "oidc: failed to verify id_token"))
 		http.Error(w, "Failed to verify id_token: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -111,32 +152,88 @@ func (c *OIDCConfig) callbackHandler(w http.ResponseWriter, r *http.Request) { /
 		http.Error(w, "nonce did not match", http.StatusBadRequest)
 		return
 	}
-	if len(c.AllowedSubjects) == 0 {
-		// If there's no allowed subjects, all subjects are allowed.
-		c.setSessionCookie(w, idToken)
-		http.Redirect(w, r, "/", http.StatusFound)
+	groups, role := c.getGroupsAndGrantedRole(idToken)
+	if !c.isAllowed(idToken.Subject, groups) {
+		log.Printf("[security.callbackHandler] Subject %s is not in the list of allowed subjects or not in any of the allowed groups", idToken.Subject)
+		c.securityConfig.RecordAuditEvent(audit.NewEntry(audit.ActionLogin, getRemoteIP(r), idToken.Subject, false, "oidc: subject is not allowed"))
+		http.Redirect(w, r, "/?error=access_denied", http.StatusFound)
 		return
 	}
-	for _, subject := range c.AllowedSubjects {
-		if strings.ToLower(subject) == strings.ToLower(idToken.Subject) {
-			c.setSessionCookie(w, idToken)
-			http.Redirect(w, r, "/", http.StatusFound)
-			return
+	c.sessionConfig.create(w, &session{subject: idToken.Subject, groups: groups, role: role}, popRememberMeCookie(w, r, "/"))
+	c.securityConfig.RecordAuditEvent(audit.NewEntry(audit.ActionLogin, getRemoteIP(r), idToken.Subject, true, "oidc"))
+	http.Redirect(w, r, "/", http.StatusFound)
+}
+
+// isAllowed returns whether the user whose subject and groups are passed is allowed to log in. If both allowed
+// subjects and allowed groups are set, the user must both be one of the allowed subjects and be part of one of the
+// allowed groups, like with SAMLConfig.isAllowed.
+func (c *OIDCConfig) isAllowed(subject string, groups []string) bool {
+	if len(c.AllowedSubjects) > 0 && !slices.ContainsFunc(c.AllowedSubjects, func(allowedSubject string) bool {
+		return strings.EqualFold(allowedSubject, subject)
+	}) {
+		return false
+	}
+	if len(c.AllowedGroups) > 0 && !slices.ContainsFunc(c.AllowedGroups, func(allowedGroup string) bool {
+		return slices.Contains(groups, allowedGroup)
+	}) {
+		return false
+	}
+	return true
+}
+
+// getGroupsAndGrantedRole returns the groups held by the groups claim of the ID token passed, if any, as well as the
+// highest of the roles held by its roles claim or granted through RoleMapping, if any
+func (c *OIDCConfig) getGroupsAndGrantedRole(idToken *oidc.IDToken) ([]string, Role) {
+	if len(c.GroupsClaim) == 0 && len(c.RolesClaim) == 0 {
+		return nil, ""
+	}
+	var claims map[string]any
+	if err := idToken.Claims(&claims); err != nil {
+		log.Printf("[security.getGroupsAndGrantedRole] Failed to parse claims of subject %s: %v", idToken.Subject, err)
+		return nil, ""
+	}
+	return c.getGroupsAndGrantedRoleFromClaims(claims)
+}
+
+// getGroupsAndGrantedRoleFromClaims returns the groups held by the groups claim of the claims passed, if any, as well
+// as the highest of the roles held by the roles claim or mapped from the values of either claim through RoleMapping
+func (c *OIDCConfig) getGroupsAndGrantedRoleFromClaims(claims map[string]any) (groups []string, role Role) {
+	var values []string
+	if len(c.GroupsClaim) > 0 {
+		groups = getStringsClaim(claims, c.GroupsClaim)
+		values = append(values, groups...)
+	}
+	var roles []Role
+	if len(c.RolesClaim) > 0 {
+		for _, value := range getStringsClaim(claims, c.RolesClaim) {
+			roles = append(roles, Role(value))
+			values = append(values, value)
+		}
+	}
+	for mappedRole, mappedValues := range c.RoleMapping {
+		for _, value := range values {
+			if slices.Contains(mappedValues, value) {
+				roles = append(roles, mappedRole)
+				break
+			}
 		}
 	}
-	log.Printf("[security.callbackHandler] Subject %s is not in the list of allowed subjects", idToken.Subject)
-	http.Redirect(w, r, "/?error=access_denied", http.StatusFound)
+	return groups, highestRole(roles...)
 }
 
-func (c *OIDCConfig) setSessionCookie(w http.ResponseWriter, idToken *oidc.IDToken) {
-	// At this point, the user has been confirmed. All that's left to do is create a session.
-	sessionID := uuid.NewString()
-	sessions.SetWithTTL(sessionID, idToken.Subject, time.Hour)
-	http.SetCookie(w, &http.Cookie{
-		Name:     cookieNameSession,
-		Value:    sessionID,
-		Path:     "/",
-		MaxAge:   int(time.Hour.Seconds()),
-		SameSite: http.SameSiteStrictMode,
-	})
+// getStringsClaim returns the value of the claim passed, which is either a string or a list of strings
+func getStringsClaim(claims map[string]any, name string) []string {
+	switch value := claims[name].(type) {
+	case string:
+		return []string{value}
+	case []any:
+		var values []string
+		for _, v := range value {
+			if s, ok := v.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
 }
@@ -4,8 +4,6 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
-
-	"github.com/coreos/go-oidc/v3/oidc"
 )
 
 func TestOIDCConfig_isValid(t *testing.T) {
@@ -60,11 +58,150 @@ func TestOIDCConfig_callbackHandler(t *testing.T) {
 	}
 }
 
-func TestOIDCConfig_setSessionCookie(t *testing.T) {
-	c := &OIDCConfig{}
-	responseRecorder := httptest.NewRecorder()
-	c.setSessionCookie(responseRecorder, &oidc.IDToken{Subject: "test@example.com"})
-	if len(responseRecorder.Result().Cookies()) == 0 {
-		t.Error("expected cookie to be set")
+func TestOIDCConfig_isValidWithRoleMappingAndAllowedGroups(t *testing.T) {
+	newConfig := func() *OIDCConfig {
+		return &OIDCConfig{IssuerURL: "https://sso.gatus.io/", RedirectURL: "https://gatus.io/authorization-code/callback", Scopes: []string{"openid"}, ClientID: "client-id", ClientSecret: "client-secret"}
+	}
+	c := newConfig()
+	c.GroupsClaim = "groups"
+	c.RoleMapping = map[Role][]string{RoleAdmin: {"gatus-admins"}}
+	c.AllowedGroups = []string{"gatus-admins"}
+	if !c.isValid() {
+		t.Error("OIDCConfig with a valid role mapping and allowed groups should've been valid")
+	}
+	c = newConfig()
+	c.RoleMapping = map[Role][]string{"superuser": {"gatus-admins"}}
+	if c.isValid() {
+		t.Error("OIDCConfig mapping to an invalid role should've been invalid")
+	}
+	c = newConfig()
+	c.AllowedGroups = []string{"gatus-admins"}
+	if c.isValid() {
+		t.Error("OIDCConfig with allowed groups but without groups claim should've been invalid")
+	}
+}
+
+func TestOIDCConfig_isAllowed(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		config   *OIDCConfig
+		subject  string
+		groups   []string
+		expected bool
+	}{
+		{
+			name:     "without-restrictions",
+			config:   &OIDCConfig{},
+			subject:  "john.doe@example.com",
+			expected: true,
+		},
+		{
+			name:     "allowed-subject",
+			config:   &OIDCConfig{AllowedSubjects: []string{"John.Doe@example.com"}},
+			subject:  "john.doe@example.com",
+			expected: true,
+		},
+		{
+			name:     "not-allowed-subject",
+			config:   &OIDCConfig{AllowedSubjects: []string{"jane.doe@example.com"}},
+			subject:  "john.doe@example.com",
+			expected: false,
+		},
+		{
+			name:     "allowed-group",
+			config:   &OIDCConfig{AllowedGroups: []string{"sre"}},
+			subject:  "john.doe@example.com",
+			groups:   []string{"developers", "sre"},
+			expected: true,
+		},
+		{
+			name:     "allowed-subject-and-allowed-group",
+			config:   &OIDCConfig{AllowedSubjects: []string{"john.doe@example.com"}, AllowedGroups: []string{"sre"}},
+			subject:  "john.doe@example.com",
+			groups:   []string{"developers", "sre"},
+			expected: true,
+		},
+		{
+			name:     "allowed-group-but-not-allowed-subject",
+			config:   &OIDCConfig{AllowedSubjects: []string{"jane.doe@example.com"}, AllowedGroups: []string{"sre"}},
+			subject:  "john.doe@example.com",
+			groups:   []string{"developers", "sre"},
+			expected: false,
+		},
+		{
+			name:     "allowed-subject-but-not-allowed-group",
+			config:   &OIDCConfig{AllowedSubjects: []string{"john.doe@example.com"}, AllowedGroups: []string{"sre"}},
+			subject:  "john.doe@example.com",
+			groups:   []string{"developers"},
+			expected: false,
+		},
+		{
+			name:     "not-allowed-group",
+			config:   &OIDCConfig{AllowedGroups: []string{"sre"}},
+			subject:  "john.doe@example.com",
+			groups:   []string{"developers"},
+			expected: false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if actual := scenario.config.isAllowed(scenario.subject, scenario.groups); actual != scenario.expected {
+				t.Errorf("expected %v, got %v", scenario.expected, actual)
+			}
+		})
+	}
+}
+
+func TestOIDCConfig_getGroupsAndGrantedRoleFromClaims(t *testing.T) {
+	c := &OIDCConfig{
+		RolesClaim:  "roles",
+		GroupsClaim: "groups",
+		RoleMapping: map[Role][]string{RoleOperator: {"sre"}, RoleAdmin: {"gatus-admins"}},
+	}
+	scenarios := []struct {
+		name           string
+		claims         map[string]any
+		expectedGroups int
+		expectedRole   Role
+	}{
+		{
+			name:         "without-claims",
+			claims:       map[string]any{},
+			expectedRole: "",
+		},
+		{
+			name:           "mapped-group",
+			claims:         map[string]any{"groups": []any{"developers", "sre"}},
+			expectedGroups: 2,
+			expectedRole:   RoleOperator,
+		},
+		{
+			name:           "highest-of-mapped-groups",
+			claims:         map[string]any{"groups": []any{"sre", "gatus-admins"}},
+			expectedGroups: 2,
+			expectedRole:   RoleAdmin,
+		},
+		{
+			name:         "mapped-role-claim",
+			claims:       map[string]any{"roles": "gatus-admins"},
+			expectedRole: RoleAdmin,
+		},
+		{
+			name:           "role-claim-and-mapped-group",
+			claims:         map[string]any{"roles": []any{"viewer"}, "groups": []any{"sre"}},
+			expectedGroups: 1,
+			expectedRole:   RoleOperator,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			groups, role := c.getGroupsAndGrantedRoleFromClaims(scenario.claims)
+			if len(groups) != scenario.expectedGroups {
+				t.Errorf("expected %d groups, got %d", scenario.expectedGroups, len(groups))
+			}
+			if role != scenario.expectedRole {
+				t.Errorf("expected role to be %q, got %q", scenario.expectedRole, role)
+			}
+		})
 	}
 }
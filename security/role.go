@@ -0,0 +1,107 @@
+package security
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Role is what an authenticated user is allowed to do. Each role is allowed to do everything the roles below it are.
+type Role string
+
+const (
+	// RoleViewer is the role allowed to view the dashboard and read the statuses of the endpoints
+	RoleViewer Role = "viewer"
+
+	// RoleOperator is the role allowed to act on the endpoints, such as checking them on demand, pausing them,
+	// resuming them, annotating changes and managing announcements
+	RoleOperator Role = "operator"
+
+	// RoleAdmin is the role allowed to do everything, including reloading the configuration, reading the audit log and
+	// managing the API tokens
+	RoleAdmin Role = "admin"
+)
+
+// IsValid returns whether the role is one of the supported roles
+func (r Role) IsValid() bool {
+	return r.rank() > 0
+}
+
+// Includes returns whether the role is allowed to do everything the role passed as parameter is
+func (r Role) Includes(role Role) bool {
+	return r.rank() > 0 && r.rank() >= role.rank()
+}
+
+func (r Role) rank() int {
+	switch r {
+	case RoleViewer:
+		return 1
+	case RoleOperator:
+		return 2
+	case RoleAdmin:
+		return 3
+	}
+	return 0
+}
+
+// highestRole returns the role that includes every role passed, ignoring the invalid ones
+func highestRole(roles ...Role) (highest Role) {
+	for _, role := range roles {
+		if role.rank() > highest.rank() {
+			highest = role
+		}
+	}
+	return highest
+}
+
+// GetRole returns the role of the user the request is authenticated as, or an empty role if the request isn't
+// authenticated
+func (c *Config) GetRole(ctx *fiber.Ctx) Role {
+	if user := c.GetUser(ctx); user != nil {
+		return user.Role
+	}
+	return ""
+}
+
+// getRoleOf returns the role of the user whose subject is passed, which is the highest of the role assigned to them
+// through Roles and of the role granted by the identity provider, if any, and defaults to the default role otherwise
+func (c *Config) getRoleOf(subject string, grantedRole Role) Role {
+	role := grantedRole
+	for assignedRole, subjects := range c.Roles {
+		for _, s := range subjects {
+			if strings.EqualFold(s, subject) {
+				role = highestRole(role, assignedRole)
+			}
+		}
+	}
+	if len(role) == 0 {
+		return c.getDefaultRole()
+	}
+	return role
+}
+
+// getDefaultRole returns the role of the authenticated users who weren't assigned one, which is DefaultRole if set.
+// Otherwise, it's RoleAdmin if no role can be assigned at all, and RoleViewer if roles can be assigned, so that the
+// users who weren't assigned a role aren't allowed to do more than those who were.
+func (c *Config) getDefaultRole() Role {
+	if len(c.DefaultRole) > 0 {
+		return c.DefaultRole
+	}
+	if len(c.Roles) > 0 || (c.OIDC != nil && (len(c.OIDC.RolesClaim) > 0 || len(c.OIDC.RoleMapping) > 0)) {
+		return RoleViewer
+	}
+	return RoleAdmin
+}
+
+// isValidRoles returns whether the roles assigned, as well as the default role, are supported roles
+func (c *Config) isValidRoles() bool {
+	if len(c.DefaultRole) > 0 && !c.DefaultRole.IsValid() {
+		return false
+	}
+	for role := range c.Roles {
+		if !role.IsValid() {
+			return false
+		}
+	}
+	return true
+}
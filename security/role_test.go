@@ -0,0 +1,153 @@
+package security
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestRole_Includes(t *testing.T) {
+	scenarios := []struct {
+		role     Role
+		included Role
+		expected bool
+	}{
+		{role: RoleAdmin, included: RoleViewer, expected: true},
+		{role: RoleAdmin, included: RoleAdmin, expected: true},
+		{role: RoleOperator, included: RoleViewer, expected: true},
+		{role: RoleOperator, included: RoleAdmin, expected: false},
+		{role: RoleViewer, included: RoleOperator, expected: false},
+		{role: "", included: RoleViewer, expected: false},
+		{role: "superuser", included: RoleViewer, expected: false},
+	}
+	for _, scenario := range scenarios {
+		t.Run(string(scenario.role)+"-"+string(scenario.included), func(t *testing.T) {
+			if actual := scenario.role.Includes(scenario.included); actual != scenario.expected {
+				t.Errorf("expected %s.Includes(%s) to be %v, got %v", scenario.role, scenario.included, scenario.expected, actual)
+			}
+		})
+	}
+}
+
+func TestConfig_IsValidWithRoles(t *testing.T) {
+	basic := &BasicConfig{Username: "john.doe", PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT"}
+	if !(&Config{Basic: basic, DefaultRole: RoleViewer, Roles: map[Role][]string{RoleAdmin: {"john.doe"}}}).IsValid() {
+		t.Error("expected config with valid roles to be valid")
+	}
+	if (&Config{Basic: basic, DefaultRole: "superuser"}).IsValid() {
+		t.Error("expected config with invalid default role to be invalid")
+	}
+	if (&Config{Basic: basic, Roles: map[Role][]string{"superuser": {"john.doe"}}}).IsValid() {
+		t.Error("expected config assigning an invalid role to be invalid")
+	}
+}
+
+func TestConfig_GetRoleWithBasic(t *testing.T) {
+	basic := &BasicConfig{Username: "john.doe", PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT"}
+	scenarios := []struct {
+		name          string
+		config        *Config
+		withBasicAuth bool
+		expectedRole  Role
+	}{
+		{
+			name:         "not-authenticated",
+			config:       &Config{Basic: basic},
+			expectedRole: "",
+		},
+		{
+			name:          "without-roles",
+			config:        &Config{Basic: basic},
+			withBasicAuth: true,
+			expectedRole:  RoleAdmin,
+		},
+		{
+			name:          "with-default-role",
+			config:        &Config{Basic: basic, DefaultRole: RoleViewer},
+			withBasicAuth: true,
+			expectedRole:  RoleViewer,
+		},
+		{
+			name:          "with-other-user-assigned-role-and-without-default-role",
+			config:        &Config{Basic: basic, Roles: map[Role][]string{RoleViewer: {"jane.doe"}}},
+			withBasicAuth: true,
+			expectedRole:  RoleViewer,
+		},
+		{
+			name:          "with-role-mapping-and-without-default-role",
+			config:        &Config{Basic: basic, OIDC: &OIDCConfig{RoleMapping: map[Role][]string{RoleAdmin: {"gatus-admins"}}}},
+			withBasicAuth: true,
+			expectedRole:  RoleViewer,
+		},
+		{
+			name:          "with-assigned-role",
+			config:        &Config{Basic: basic, DefaultRole: RoleViewer, Roles: map[Role][]string{RoleViewer: {"John.Doe"}, RoleOperator: {"john.doe"}, RoleAdmin: {"jane.doe"}}},
+			withBasicAuth: true,
+			expectedRole:  RoleOperator,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			app := fiber.New()
+			var role Role
+			app.Get("/test", func(c *fiber.Ctx) error {
+				role = scenario.config.GetRole(c)
+				return c.SendStatus(200)
+			})
+			request := httptest.NewRequest("GET", "/test", http.NoBody)
+			if scenario.withBasicAuth {
+				request.SetBasicAuth("john.doe", "hunter2")
+			}
+			if _, err := app.Test(request); err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if role != scenario.expectedRole {
+				t.Errorf("expected role to be %q, got %q", scenario.expectedRole, role)
+			}
+		})
+	}
+}
+
+func TestConfig_GetRoleWithSession(t *testing.T) {
+	c := &Config{
+		OIDC:        &OIDCConfig{IssuerURL: "https://sso.gatus.io/", RedirectURL: "http://localhost:80/authorization-code/callback", Scopes: []string{"openid"}},
+		DefaultRole: RoleViewer,
+		Roles:       map[Role][]string{RoleOperator: {"operator@example.com"}},
+	}
+	app := fiber.New()
+	if err := c.ApplySecurityMiddleware(app); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	var role Role
+	app.Get("/test", func(ctx *fiber.Ctx) error {
+		role = c.GetRole(ctx)
+		return ctx.SendStatus(200)
+	})
+	sessions.SetWithTTL("viewer-session", &session{subject: "viewer@example.com"}, time.Minute)
+	sessions.SetWithTTL("operator-session", &session{subject: "operator@example.com"}, time.Minute)
+	sessions.SetWithTTL("granted-admin-session", &session{subject: "operator@example.com", role: RoleAdmin}, time.Minute)
+	defer sessions.Clear()
+	scenarios := []struct {
+		sessionID    string
+		expectedRole Role
+	}{
+		{sessionID: "viewer-session", expectedRole: RoleViewer},
+		{sessionID: "operator-session", expectedRole: RoleOperator},
+		{sessionID: "granted-admin-session", expectedRole: RoleAdmin},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.sessionID, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "/test", http.NoBody)
+			request.AddCookie(&http.Cookie{Name: cookieNameSession, Value: scenario.sessionID})
+			if _, err := app.Test(request); err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if role != scenario.expectedRole {
+				t.Errorf("expected role to be %q, got %q", scenario.expectedRole, role)
+			}
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package security
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/TwiN/gatus/v5/audit"
+	"github.com/crewjam/saml"
+	"github.com/crewjam/saml/samlsp"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	cookieNameSAMLRequestID = "gatus_saml_request_id"
+
+	samlMetadataPath = "/saml/metadata"
+	samlACSPath      = "/saml/acs"
+
+	// samlMetadataTimeout is how long the identity provider has to return its metadata
+	samlMetadataTimeout = 10 * time.Second
+)
+
+// SAMLConfig is the configuration for SAML 2.0 authentication, in which Gatus is the service provider
+type SAMLConfig struct {
+	// IDPMetadataURL is the URL of the metadata of the identity provider.
+	// e.g. https://adfs.example.org/FederationMetadata/2007-06/FederationMetadata.xml
+	IDPMetadataURL string `yaml:"idp-metadata-url"`
+
+	// RootURL is the URL Gatus is reachable at by the users, which the metadata and assertion consumer service URLs
+	// are derived from. e.g. https://status.example.org
+	RootURL string `yaml:"root-url"`
+
+	// EntityID is the entity ID of Gatus as a service provider. Defaults to the URL of the metadata.
+	EntityID string `yaml:"entity-id,omitempty"`
+
+	// CertificateFile and PrivateKeyFile are the files of the RSA key pair, in PEM format, that the authentication
+	// requests are signed with and that the identity provider encrypts the assertions with
+	CertificateFile string `yaml:"certificate-file"`
+	PrivateKeyFile  string `yaml:"private-key-file"`
+
+	// AttributeMapping is which attributes of the assertions identify the user and their groups
+	AttributeMapping *SAMLAttributeMapping `yaml:"attribute-mapping,omitempty"`
+
+	AllowedSubjects []string `yaml:"allowed-subjects"` // e.g. ["user1@example.com"]. If empty, all subjects are allowed
+	AllowedGroups   []string `yaml:"allowed-groups"`   // e.g. ["gatus-users"]. If empty, all groups are allowed
+
+	serviceProvider *saml.ServiceProvider
+	sessionConfig   *SessionConfig
+	securityConfig  *Config
+}
+
+// SAMLAttributeMapping is the name, or friendly name, of the attributes of the assertions that Gatus relies on
+type SAMLAttributeMapping struct {
+	// Subject is the attribute identifying the user. If empty, the NameID of the subject of the assertion is used.
+	// e.g. http://schemas.xmlsoap.org/ws/2005/05/identity/claims/upn for ADFS
+	Subject string `yaml:"subject,omitempty"`
+
+	// Groups is the attribute listing the groups of the user, which is required to use allowed-groups.
+	// e.g. http://schemas.microsoft.com/ws/2008/06/identity/claims/groups for ADFS
+	Groups string `yaml:"groups,omitempty"`
+}
+
+// isValid returns whether the SAML security configuration is valid or not
+func (c *SAMLConfig) isValid() bool {
+	if len(c.AllowedGroups) > 0 && (c.AttributeMapping == nil || len(c.AttributeMapping.Groups) == 0) {
+		return false
+	}
+	rootURL, err := url.Parse(c.RootURL)
+	return len(c.IDPMetadataURL) > 0 && err == nil && (rootURL.Scheme == "http" || rootURL.Scheme == "https") && len(rootURL.Host) > 0 && len(c.CertificateFile) > 0 && len(c.PrivateKeyFile) > 0
+}
+
+func (c *SAMLConfig) initialize() error {
+	keyPair, err := tls.LoadX509KeyPair(c.CertificateFile, c.PrivateKeyFile)
+	if err != nil {
+		return fmt.Errorf("error loading saml key pair: %w", err)
+	}
+	key, ok := keyPair.PrivateKey.(*rsa.PrivateKey)
+	if !ok {
+		return errors.New("saml private key must be an RSA key")
+	}
+	certificate, err := x509.ParseCertificate(keyPair.Certificate[0])
+	if err != nil {
+		return fmt.Errorf("error parsing saml certificate: %w", err)
+	}
+	idpMetadataURL, err := url.Parse(c.IDPMetadataURL)
+	if err != nil {
+		return fmt.Errorf("error parsing saml idp-metadata-url: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), samlMetadataTimeout)
+	defer cancel()
+	idpMetadata, err := samlsp.FetchMetadata(ctx, http.DefaultClient, *idpMetadataURL)
+	if err != nil {
+		return fmt.Errorf("error fetching saml idp metadata: %w", err)
+	}
+	rootURL, _ := url.Parse(strings.TrimSuffix(c.RootURL, "/"))
+	c.serviceProvider = &saml.ServiceProvider{
+		EntityID:          c.EntityID,
+		Key:               key,
+		Certificate:       certificate,
+		MetadataURL:       *rootURL.JoinPath(samlMetadataPath),
+		AcsURL:            *rootURL.JoinPath(samlACSPath),
+		IDPMetadata:       idpMetadata,
+		AuthnNameIDFormat: saml.UnspecifiedNameIDFormat,
+	}
+	return nil
+}
+
+func (c *SAMLConfig) metadataHandler(ctx *fiber.Ctx) error {
+	metadata, err := xml.MarshalIndent(c.serviceProvider.Metadata(), "", "  ")
+	if err != nil {
+		return ctx.Status(500).SendString("unable to marshal metadata")
+	}
+	ctx.Set("Content-Type", "application/samlmetadata+xml")
+	return ctx.Status(200).Send(metadata)
+}
+
+func (c *SAMLConfig) loginHandler(ctx *fiber.Ctx) error {
+	location := c.serviceProvider.GetSSOBindingLocation(saml.HTTPRedirectBinding)
+	if len(location) == 0 {
+		return ctx.Status(500).SendString("identity provider does not support the HTTP-Redirect binding")
+	}
+	request, err := c.serviceProvider.MakeAuthenticationRequest(location, saml.HTTPRedirectBinding, saml.HTTPPostBinding)
+	if err != nil {
+		return ctx.Status(500).SendString("Error creating authentication request: " + err.Error())
+	}
+	redirectURL, err := request.Redirect("", c.serviceProvider)
+	if err != nil {
+		return ctx.Status(500).SendString("Error creating authentication request: " + err.Error())
+	}
+	// The identity provider posts the response to the assertion consumer service from its own site, so the cookie
+	// must not be restricted to same-site requests, which browsers only allow for secure cookies
+	secure := c.serviceProvider.AcsURL.Scheme == "https"
+	sameSite := "disabled"
+	if secure {
+		sameSite = "none"
+	}
+	ctx.Cookie(&fiber.Cookie{
+		Name:     cookieNameSAMLRequestID,
+		Value:    request.ID,
+		Path:     samlACSPath,
+		MaxAge:   int(time.Hour.Seconds()),
+		Secure:   secure,
+		SameSite: sameSite,
+		HTTPOnly: true,
+	})
+	if c.sessionConfig.isRememberMeRequested(ctx) {
+		ctx.Cookie(&fiber.Cookie{
+			Name:     cookieNameRememberMe,
+			Value:    "true",
+			Path:     samlACSPath,
+			MaxAge:   int(time.Hour.Seconds()),
+			Secure:   secure,
+			SameSite: sameSite,
+			HTTPOnly: true,
+		})
+	}
+	return ctx.Redirect(redirectURL.String(), http.StatusFound)
+}
+
+func (c *SAMLConfig) assertionConsumerServiceHandler(w http.ResponseWriter, r *http.Request) { // TODO: Migrate to a native fiber handler
+	requestID, err := r.Cookie(cookieNameSAMLRequestID)
+	if err != nil {
+		http.Error(w, "request id not found", http.StatusBadRequest)
+		return
+	}
+	if err = r.ParseForm(); err != nil {
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+	assertion, err := c.serviceProvider.ParseResponse(r, []string{requestID.Value})
+	if err != nil {
+		var invalidResponseError *saml.InvalidResponseError
+		if errors.As(err, &invalidResponseError) {
+			log.Printf("[security.assertionConsumerServiceHandler] Invalid SAML response: %v", invalidResponseError.PrivateErr)
+		}
+		c.securityConfig.RecordAuditEvent(audit.NewEntry(audit.ActionLogin, getRemoteIP(r), "", false, "saml: failed to verify SAML response"))
+		http.Error(w, "Failed to verify SAML response: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{Name: cookieNameSAMLRequestID, Path: samlACSPath, MaxAge: -1})
+	subject, groups := c.getSubjectAndGroups(assertion)
+	if len(subject) == 0 {
+		http.Error(w, "Missing subject in SAML assertion", http.StatusBadRequest)
+		return
+	}
+	if !c.isAllowed(subject, groups) {
+		log.Printf("[security.assertionConsumerServiceHandler] Subject %s is not in the list of allowed subjects or groups", subject)
+		c.securityConfig.RecordAuditEvent(audit.NewEntry(audit.ActionLogin, getRemoteIP(r), subject, false, "saml: subject is not allowed"))
+		http.Redirect(w, r, "/?error=access_denied", http.StatusFound)
+		return
+	}
+	// At this point, the user has been confirmed. All that's left to do is create a session.
+	c.sessionConfig.create(w, &session{subject: subject, groups: groups}, popRememberMeCookie(w, r, samlACSPath))
+	c.securityConfig.RecordAuditEvent(audit.NewEntry(audit.ActionLogin, getRemoteIP(r), subject, true, "saml"))
+	http.Redirect(w, r, "/", http.StatusFound)
+}
+
+// getSubjectAndGroups returns the subject and the groups of the user of the assertion passed, based on the attribute
+// mapping
+func (c *SAMLConfig) getSubjectAndGroups(assertion *saml.Assertion) (subject string, groups []string) {
+	if c.AttributeMapping == nil || len(c.AttributeMapping.Subject) == 0 {
+		if assertion.Subject != nil && assertion.Subject.NameID != nil {
+			subject = assertion.Subject.NameID.Value
+		}
+	}
+	if c.AttributeMapping == nil {
+		return subject, nil
+	}
+	for _, statement := range assertion.AttributeStatements {
+		for _, attribute := range statement.Attributes {
+			if isSAMLAttribute(attribute, c.AttributeMapping.Subject) && len(attribute.Values) > 0 {
+				subject = attribute.Values[0].Value
+			}
+			if isSAMLAttribute(attribute, c.AttributeMapping.Groups) {
+				for _, value := range attribute.Values {
+					groups = append(groups, value.Value)
+				}
+			}
+		}
+	}
+	return subject, groups
+}
+
+// isSAMLAttribute returns whether the name or the friendly name of the attribute passed is the name passed
+func isSAMLAttribute(attribute saml.Attribute, name string) bool {
+	return len(name) > 0 && (attribute.Name == name || attribute.FriendlyName == name)
+}
+
+// isAllowed returns whether the user whose subject and groups are passed is allowed to authenticate. If both allowed
+// subjects and allowed groups are set, the user must both be one of the allowed subjects and be part of one of the
+// allowed groups, like with OIDCConfig.isAllowed.
+func (c *SAMLConfig) isAllowed(subject string, groups []string) bool {
+	if len(c.AllowedSubjects) > 0 {
+		allowed := false
+		for _, allowedSubject := range c.AllowedSubjects {
+			if strings.EqualFold(allowedSubject, subject) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return false
+		}
+	}
+	if len(c.AllowedGroups) == 0 {
+		return true
+	}
+	for _, allowedGroup := range c.AllowedGroups {
+		for _, group := range groups {
+			if allowedGroup == group {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,272 @@
+package security
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crewjam/saml"
+	"github.com/gofiber/fiber/v2"
+)
+
+const testIDPMetadata = `<EntityDescriptor xmlns="urn:oasis:names:tc:SAML:2.0:metadata" entityID="https://idp.example.org">
+  <IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+    <SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="https://idp.example.org/sso"/>
+  </IDPSSODescriptor>
+</EntityDescriptor>`
+
+// newTestSAMLConfig returns a SAML configuration whose identity provider is served by an httptest server, and whose
+// RSA key pair is written to a temporary directory
+func newTestSAMLConfig(t *testing.T) *SAMLConfig {
+	idp := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, testIDPMetadata)
+	}))
+	t.Cleanup(idp.Close)
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "gatus"}, NotBefore: time.Now(), NotAfter: time.Now().Add(time.Hour)}
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	certificateFile, privateKeyFile := filepath.Join(dir, "saml.crt"), filepath.Join(dir, "saml.key")
+	if err := os.WriteFile(certificateFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(privateKeyFile, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return &SAMLConfig{
+		IDPMetadataURL:  idp.URL,
+		RootURL:         "https://status.example.org",
+		CertificateFile: certificateFile,
+		PrivateKeyFile:  privateKeyFile,
+	}
+}
+
+func TestSAMLConfig_isValid(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		config   *SAMLConfig
+		expected bool
+	}{
+		{
+			name:     "valid",
+			config:   &SAMLConfig{IDPMetadataURL: "https://idp.example.org/metadata", RootURL: "https://status.example.org", CertificateFile: "saml.crt", PrivateKeyFile: "saml.key"},
+			expected: true,
+		},
+		{
+			name:     "valid-with-allowed-groups",
+			config:   &SAMLConfig{IDPMetadataURL: "https://idp.example.org/metadata", RootURL: "http://localhost:8080", CertificateFile: "saml.crt", PrivateKeyFile: "saml.key", AttributeMapping: &SAMLAttributeMapping{Groups: "groups"}, AllowedGroups: []string{"gatus-users"}},
+			expected: true,
+		},
+		{
+			name:   "allowed-groups-without-groups-attribute",
+			config: &SAMLConfig{IDPMetadataURL: "https://idp.example.org/metadata", RootURL: "https://status.example.org", CertificateFile: "saml.crt", PrivateKeyFile: "saml.key", AllowedGroups: []string{"gatus-users"}},
+		},
+		{
+			name:   "no-idp-metadata-url",
+			config: &SAMLConfig{RootURL: "https://status.example.org", CertificateFile: "saml.crt", PrivateKeyFile: "saml.key"},
+		},
+		{
+			name:   "invalid-root-url",
+			config: &SAMLConfig{IDPMetadataURL: "https://idp.example.org/metadata", RootURL: "status.example.org", CertificateFile: "saml.crt", PrivateKeyFile: "saml.key"},
+		},
+		{
+			name:   "no-key-pair",
+			config: &SAMLConfig{IDPMetadataURL: "https://idp.example.org/metadata", RootURL: "https://status.example.org"},
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if valid := scenario.config.isValid(); valid != scenario.expected {
+				t.Errorf("expected %v, got %v", scenario.expected, valid)
+			}
+		})
+	}
+}
+
+func TestSAMLConfig_getSubjectAndGroups(t *testing.T) {
+	assertion := &saml.Assertion{
+		Subject: &saml.Subject{NameID: &saml.NameID{Value: "_a1b2c3"}},
+		AttributeStatements: []saml.AttributeStatement{{Attributes: []saml.Attribute{
+			{Name: "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/upn", Values: []saml.AttributeValue{{Value: "john.doe@example.org"}}},
+			{Name: "urn:oid:1.3.6.1.4.1.5923.1.5.1.1", FriendlyName: "groups", Values: []saml.AttributeValue{{Value: "gatus-users"}, {Value: "developers"}}},
+		}}},
+	}
+	scenarios := []struct {
+		name             string
+		attributeMapping *SAMLAttributeMapping
+		expectedSubject  string
+		expectedGroups   []string
+	}{
+		{
+			name:            "name-id",
+			expectedSubject: "_a1b2c3",
+		},
+		{
+			name:             "mapped-attributes",
+			attributeMapping: &SAMLAttributeMapping{Subject: "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/upn", Groups: "groups"},
+			expectedSubject:  "john.doe@example.org",
+			expectedGroups:   []string{"gatus-users", "developers"},
+		},
+		{
+			name:             "missing-subject-attribute",
+			attributeMapping: &SAMLAttributeMapping{Subject: "email"},
+			expectedSubject:  "",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			c := &SAMLConfig{AttributeMapping: scenario.attributeMapping}
+			subject, groups := c.getSubjectAndGroups(assertion)
+			if subject != scenario.expectedSubject {
+				t.Errorf("expected subject %q, got %q", scenario.expectedSubject, subject)
+			}
+			if strings.Join(groups, ",") != strings.Join(scenario.expectedGroups, ",") {
+				t.Errorf("expected groups %v, got %v", scenario.expectedGroups, groups)
+			}
+		})
+	}
+}
+
+func TestSAMLConfig_isAllowed(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		config   *SAMLConfig
+		subject  string
+		groups   []string
+		expected bool
+	}{
+		{name: "no-restriction", config: &SAMLConfig{}, subject: "john.doe@example.org", expected: true},
+		{name: "allowed-subject", config: &SAMLConfig{AllowedSubjects: []string{"John.Doe@example.org"}}, subject: "john.doe@example.org", expected: true},
+		{name: "disallowed-subject", config: &SAMLConfig{AllowedSubjects: []string{"jane.doe@example.org"}}, subject: "john.doe@example.org"},
+		{name: "allowed-group", config: &SAMLConfig{AllowedGroups: []string{"gatus-users"}}, subject: "john.doe@example.org", groups: []string{"developers", "gatus-users"}, expected: true},
+		{name: "disallowed-group", config: &SAMLConfig{AllowedGroups: []string{"gatus-users"}}, subject: "john.doe@example.org", groups: []string{"developers"}},
+		{name: "allowed-subject-and-allowed-group", config: &SAMLConfig{AllowedSubjects: []string{"john.doe@example.org"}, AllowedGroups: []string{"gatus-users"}}, subject: "john.doe@example.org", groups: []string{"gatus-users"}, expected: true},
+		{name: "allowed-subject-but-disallowed-group", config: &SAMLConfig{AllowedSubjects: []string{"john.doe@example.org"}, AllowedGroups: []string{"gatus-users"}}, subject: "john.doe@example.org", groups: []string{"developers"}},
+		{name: "allowed-group-but-disallowed-subject", config: &SAMLConfig{AllowedSubjects: []string{"jane.doe@example.org"}, AllowedGroups: []string{"gatus-users"}}, subject: "john.doe@example.org", groups: []string{"gatus-users"}},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if allowed := scenario.config.isAllowed(scenario.subject, scenario.groups); allowed != scenario.expected {
+				t.Errorf("expected %v, got %v", scenario.expected, allowed)
+			}
+		})
+	}
+}
+
+func TestConfig_RegisterHandlersWithSAML(t *testing.T) {
+	c := &Config{SAML: newTestSAMLConfig(t)}
+	if !c.IsValid() {
+		t.Fatal("expected the security configuration to be valid")
+	}
+	app := fiber.New()
+	if err := c.RegisterHandlers(app); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.ApplySecurityMiddleware(app); err != nil {
+		t.Fatal(err)
+	}
+	app.Get("/test", func(ctx *fiber.Ctx) error {
+		return ctx.SendStatus(200)
+	})
+	t.Run("metadata", func(t *testing.T) {
+		response, err := app.Test(httptest.NewRequest("GET", "/saml/metadata", http.NoBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer response.Body.Close()
+		body, _ := io.ReadAll(response.Body)
+		if response.StatusCode != 200 || !strings.Contains(string(body), `Location="https://status.example.org/saml/acs"`) || !strings.Contains(string(body), `entityID="https://status.example.org/saml/metadata"`) {
+			t.Errorf("expected the metadata of the service provider, got %d with %s", response.StatusCode, body)
+		}
+	})
+	t.Run("login", func(t *testing.T) {
+		response, err := app.Test(httptest.NewRequest("GET", "/saml/login", http.NoBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer response.Body.Close()
+		if response.StatusCode != http.StatusFound {
+			t.Fatalf("expected 302, got %d", response.StatusCode)
+		}
+		location, _ := url.Parse(response.Header.Get("Location"))
+		if location.Host != "idp.example.org" || len(location.Query().Get("SAMLRequest")) == 0 {
+			t.Errorf("expected to be redirected to the identity provider with an authentication request, got %s", location)
+		}
+		cookie := response.Header.Get("Set-Cookie")
+		if !strings.HasPrefix(cookie, cookieNameSAMLRequestID+"=") || !strings.Contains(cookie, "secure") || !strings.Contains(cookie, "SameSite=None") {
+			t.Errorf("expected a secure cookie with the id of the request sent cross-site, got %s", cookie)
+		}
+	})
+	t.Run("assertion-consumer-service-without-request-id", func(t *testing.T) {
+		request := httptest.NewRequest("POST", "/saml/acs", strings.NewReader("SAMLResponse=invalid"))
+		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		response, err := app.Test(request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer response.Body.Close()
+		if response.StatusCode != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", response.StatusCode)
+		}
+	})
+	t.Run("assertion-consumer-service-with-invalid-response", func(t *testing.T) {
+		request := httptest.NewRequest("POST", "/saml/acs", strings.NewReader("SAMLResponse=aW52YWxpZA%3D%3D"))
+		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		request.AddCookie(&http.Cookie{Name: cookieNameSAMLRequestID, Value: "id-123"})
+		response, err := app.Test(request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer response.Body.Close()
+		if response.StatusCode != http.StatusBadRequest || len(response.Header.Get("Set-Cookie")) > 0 {
+			t.Errorf("expected 400 without a session, got %d", response.StatusCode)
+		}
+	})
+	t.Run("protected-without-session", func(t *testing.T) {
+		response, err := app.Test(httptest.NewRequest("GET", "/test", http.NoBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer response.Body.Close()
+		if response.StatusCode != http.StatusUnauthorized {
+			t.Errorf("expected 401, got %d", response.StatusCode)
+		}
+	})
+}
+
+func TestConfig_IsValidWithSAML(t *testing.T) {
+	samlConfig := &SAMLConfig{IDPMetadataURL: "https://idp.example.org/metadata", RootURL: "https://status.example.org", CertificateFile: "saml.crt", PrivateKeyFile: "saml.key"}
+	if !(&Config{SAML: samlConfig}).IsValid() {
+		t.Error("expected SAML alone to be valid")
+	}
+	if !(&Config{SAML: samlConfig, OIDC: &OIDCConfig{IssuerURL: "https://sso.gatus.io/", RedirectURL: "http://localhost:80/authorization-code/callback", ClientID: "client-id", ClientSecret: "client-secret", Scopes: []string{"openid"}}}).IsValid() {
+		t.Error("expected SAML with OIDC to be valid")
+	}
+	if (&Config{SAML: samlConfig, OIDC: &OIDCConfig{}}).IsValid() {
+		t.Error("expected SAML with an invalid OIDC configuration to be invalid")
+	}
+	if (&Config{SAML: samlConfig, Basic: &BasicConfig{Username: "admin", PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT"}}).IsValid() {
+		t.Error("expected SAML with basic to be invalid")
+	}
+	if (&Config{SAML: samlConfig, Basic: &BasicConfig{Username: "admin"}}).UsesBasicAuthentication() {
+		t.Error("expected SAML not to use basic authentication")
+	}
+}
@@ -1,5 +1,159 @@
 package security
 
-import "github.com/TwiN/gocache/v2"
+import (
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/TwiN/gocache/v2"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultSessionDuration is the default value for SessionConfig.Duration
+	DefaultSessionDuration = time.Hour
+
+	// cookieNameRememberMe is the name of the cookie holding whether the user asked to be remembered while logging in
+	cookieNameRememberMe = "gatus_remember_me"
+)
 
 var sessions = gocache.NewCache().WithEvictionPolicy(gocache.LeastRecentlyUsed) // TODO: Move this to storage
+
+// session is what is known about the user of a session created by logging in through OIDC or SAML
+type session struct {
+	subject string
+
+	// groups are the groups of the user, as provided by the identity provider, if any
+	groups []string
+
+	// role is the role granted by the identity provider, if any
+	role Role
+
+	// rememberMe is whether the user asked to be remembered while logging in
+	rememberMe bool
+
+	// lastActivity is the time of the last request authenticated with the session, in Unix nanoseconds
+	lastActivity atomic.Int64
+}
+
+// SessionConfig is the configuration of the sessions created by logging in through OIDC or SAML
+type SessionConfig struct {
+	// Duration is how long a session lasts for, after which the user must log in again (defaults to
+	// DefaultSessionDuration)
+	Duration time.Duration `yaml:"duration,omitempty"`
+
+	// IdleTimeout is how long a session lasts for without any request, if set. Doesn't apply to the sessions of the
+	// users who asked to be remembered.
+	IdleTimeout time.Duration `yaml:"idle-timeout,omitempty"`
+
+	// Refresh is whether to extend the session on every request, so that the sessions in use never expire
+	Refresh bool `yaml:"refresh,omitempty"`
+
+	// RememberMeDuration is how long the sessions of the users who asked to be remembered while logging in last for,
+	// if set. If not set, users cannot ask to be remembered.
+	RememberMeDuration time.Duration `yaml:"remember-me-duration,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the session configuration and sets the default values if necessary
+func (c *SessionConfig) ValidateAndSetDefaults() error {
+	if c.Duration < 0 || c.IdleTimeout < 0 || c.RememberMeDuration < 0 {
+		return errors.New("duration, idle-timeout and remember-me-duration cannot be negative")
+	}
+	if c.Duration == 0 {
+		c.Duration = DefaultSessionDuration
+	}
+	if c.IdleTimeout > c.Duration {
+		return errors.New("idle-timeout cannot be longer than duration")
+	}
+	if c.RememberMeDuration > 0 && c.RememberMeDuration < c.Duration {
+		return errors.New("remember-me-duration cannot be shorter than duration")
+	}
+	return nil
+}
+
+// IsRememberMeEnabled returns whether the users can ask to be remembered while logging in
+func (c *SessionConfig) IsRememberMeEnabled() bool {
+	return c.RememberMeDuration > 0
+}
+
+// getDuration returns how long the session passed lasts for
+func (c *SessionConfig) getDuration(s *session) time.Duration {
+	if s.rememberMe && c.IsRememberMeEnabled() {
+		return c.RememberMeDuration
+	}
+	return c.Duration
+}
+
+// isRememberMeRequested returns whether the user logging in asked to be remembered through the remember-me query
+// parameter, and remembering users is enabled
+func (c *SessionConfig) isRememberMeRequested(ctx *fiber.Ctx) bool {
+	return c.IsRememberMeEnabled() && ctx.QueryBool("remember-me")
+}
+
+// create creates the session passed for the user that just logged in, and sets the session cookie
+func (c *SessionConfig) create(w http.ResponseWriter, s *session, rememberMe bool) {
+	s.rememberMe = rememberMe && c.IsRememberMeEnabled()
+	s.lastActivity.Store(time.Now().UnixNano())
+	sessionID := uuid.NewString()
+	sessions.SetWithTTL(sessionID, s, c.getDuration(s))
+	http.SetCookie(w, c.newSessionCookie(sessionID, s))
+}
+
+// popRememberMeCookie returns whether the user that just logged in asked to be remembered, as held by the cookie set
+// while they were redirected to the identity provider, which is then removed
+func popRememberMeCookie(w http.ResponseWriter, r *http.Request, path string) bool {
+	rememberMeCookie, err := r.Cookie(cookieNameRememberMe)
+	if err != nil {
+		return false
+	}
+	http.SetCookie(w, &http.Cookie{Name: cookieNameRememberMe, Path: path, MaxAge: -1})
+	return rememberMeCookie.Value == "true"
+}
+
+// newSessionCookie returns the session cookie of the session passed, which expires along with the session
+func (c *SessionConfig) newSessionCookie(sessionID string, s *session) *http.Cookie {
+	return &http.Cookie{
+		Name:     cookieNameSession,
+		Value:    sessionID,
+		Path:     "/",
+		MaxAge:   int(c.getDuration(s).Seconds()),
+		SameSite: http.SameSiteStrictMode,
+	}
+}
+
+// getSession returns the session whose id is passed, unless it doesn't exist or has been idle for longer than
+// IdleTimeout, in which case it's deleted
+func (c *SessionConfig) getSession(sessionID string) (*session, bool) {
+	value, exists := sessions.Get(sessionID)
+	if !exists {
+		return nil, false
+	}
+	s, ok := value.(*session)
+	if !ok {
+		return nil, false
+	}
+	if c.IdleTimeout > 0 && !s.rememberMe {
+		if lastActivity := s.lastActivity.Load(); lastActivity > 0 && time.Since(time.Unix(0, lastActivity)) > c.IdleTimeout {
+			sessions.Delete(sessionID)
+			return nil, false
+		}
+	}
+	return s, true
+}
+
+// refresh keeps track of the activity of the session whose id is passed and, if Refresh is enabled, extends it.
+// Returns the new session cookie if the session was extended, or nil otherwise.
+func (c *SessionConfig) refresh(sessionID string) *http.Cookie {
+	s, exists := c.getSession(sessionID)
+	if !exists {
+		return nil
+	}
+	s.lastActivity.Store(time.Now().UnixNano())
+	if !c.Refresh {
+		return nil
+	}
+	sessions.SetWithTTL(sessionID, s, c.getDuration(s))
+	return c.newSessionCookie(sessionID, s)
+}
@@ -0,0 +1,155 @@
+package security
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestSessionConfig_ValidateAndSetDefaults(t *testing.T) {
+	c := &SessionConfig{}
+	if err := c.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if c.Duration != DefaultSessionDuration {
+		t.Errorf("expected duration to default to %s, got %s", DefaultSessionDuration, c.Duration)
+	}
+	if c.IsRememberMeEnabled() {
+		t.Error("expected remember-me to be disabled by default")
+	}
+	scenarios := []struct {
+		name   string
+		config *SessionConfig
+	}{
+		{name: "negative-duration", config: &SessionConfig{Duration: -time.Hour}},
+		{name: "idle-timeout-longer-than-duration", config: &SessionConfig{Duration: time.Hour, IdleTimeout: 2 * time.Hour}},
+		{name: "remember-me-duration-shorter-than-duration", config: &SessionConfig{Duration: time.Hour, RememberMeDuration: time.Minute}},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.config.ValidateAndSetDefaults(); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestSessionConfig_create(t *testing.T) {
+	defer sessions.Clear()
+	c := &SessionConfig{Duration: time.Hour, RememberMeDuration: 30 * 24 * time.Hour}
+	scenarios := []struct {
+		name           string
+		rememberMe     bool
+		expectedMaxAge int
+	}{
+		{name: "without-remember-me", rememberMe: false, expectedMaxAge: int(time.Hour.Seconds())},
+		{name: "with-remember-me", rememberMe: true, expectedMaxAge: int((30 * 24 * time.Hour).Seconds())},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			responseRecorder := httptest.NewRecorder()
+			c.create(responseRecorder, &session{subject: "test@example.com"}, scenario.rememberMe)
+			cookies := responseRecorder.Result().Cookies()
+			if len(cookies) != 1 || cookies[0].Name != cookieNameSession {
+				t.Fatalf("expected the session cookie to be set, got %v", cookies)
+			}
+			if cookies[0].MaxAge != scenario.expectedMaxAge {
+				t.Errorf("expected the session cookie to have a max age of %d, got %d", scenario.expectedMaxAge, cookies[0].MaxAge)
+			}
+			s, exists := c.getSession(cookies[0].Value)
+			if !exists {
+				t.Fatal("expected the session to exist")
+			}
+			if s.rememberMe != scenario.rememberMe {
+				t.Errorf("expected rememberMe to be %v, got %v", scenario.rememberMe, s.rememberMe)
+			}
+		})
+	}
+}
+
+func TestPopRememberMeCookie(t *testing.T) {
+	request := httptest.NewRequest("GET", "/authorization-code/callback", http.NoBody)
+	if popRememberMeCookie(httptest.NewRecorder(), request, "/") {
+		t.Error("expected false without the remember-me cookie")
+	}
+	request.AddCookie(&http.Cookie{Name: cookieNameRememberMe, Value: "true"})
+	responseRecorder := httptest.NewRecorder()
+	if !popRememberMeCookie(responseRecorder, request, "/") {
+		t.Error("expected true with the remember-me cookie")
+	}
+	if cookies := responseRecorder.Result().Cookies(); len(cookies) != 1 || cookies[0].MaxAge >= 0 {
+		t.Errorf("expected the remember-me cookie to be removed, got %v", cookies)
+	}
+}
+
+func TestConfig_MiddlewareWithSession(t *testing.T) {
+	defer sessions.Clear()
+	scenarios := []struct {
+		name                  string
+		session               *SessionConfig
+		rememberMe            bool
+		idleFor               time.Duration
+		expectedCode          int
+		expectedSessionCookie bool
+	}{
+		{
+			name:         "active",
+			session:      &SessionConfig{Duration: time.Hour, IdleTimeout: 10 * time.Minute},
+			idleFor:      time.Minute,
+			expectedCode: 200,
+		},
+		{
+			name:         "idle",
+			session:      &SessionConfig{Duration: time.Hour, IdleTimeout: 10 * time.Minute},
+			idleFor:      20 * time.Minute,
+			expectedCode: 401,
+		},
+		{
+			name:         "idle-but-remembered",
+			session:      &SessionConfig{Duration: time.Hour, IdleTimeout: 10 * time.Minute, RememberMeDuration: 24 * time.Hour},
+			rememberMe:   true,
+			idleFor:      20 * time.Minute,
+			expectedCode: 200,
+		},
+		{
+			name:                  "refreshed",
+			session:               &SessionConfig{Duration: time.Hour, Refresh: true},
+			idleFor:               time.Minute,
+			expectedCode:          200,
+			expectedSessionCookie: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			c := &Config{
+				OIDC:    &OIDCConfig{IssuerURL: "https://sso.gatus.io/", RedirectURL: "http://localhost:80/authorization-code/callback", Scopes: []string{"openid"}},
+				Session: scenario.session,
+			}
+			app := fiber.New()
+			if err := c.ApplySecurityMiddleware(app); err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			app.Get("/test", func(ctx *fiber.Ctx) error {
+				return ctx.SendStatus(200)
+			})
+			s := &session{subject: "test@example.com", rememberMe: scenario.rememberMe}
+			s.lastActivity.Store(time.Now().Add(-scenario.idleFor).UnixNano())
+			sessions.SetWithTTL(scenario.name, s, time.Hour)
+			request := httptest.NewRequest("GET", "/test", http.NoBody)
+			request.AddCookie(&http.Cookie{Name: cookieNameSession, Value: scenario.name})
+			response, err := app.Test(request)
+			if err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if response.StatusCode != scenario.expectedCode {
+				t.Errorf("expected code %d, got %d", scenario.expectedCode, response.StatusCode)
+			}
+			if hasSessionCookie := len(response.Cookies()) > 0; hasSessionCookie != scenario.expectedSessionCookie {
+				t.Errorf("expected the session cookie to be set to be %v, got %v", scenario.expectedSessionCookie, hasSessionCookie)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package security
+
+import "github.com/gofiber/fiber/v2"
+
+// User is the user a request is authenticated as
+type User struct {
+	// Subject identifies the user, which is their username for Basic and LDAP, or their subject for OIDC and SAML
+	Subject string
+
+	// Groups are the groups of the user, as provided by the identity provider, if any
+	Groups []string
+
+	// Role is what the user is allowed to do
+	Role Role
+}
+
+// GetUser returns the user the request is authenticated as, or nil if the request isn't authenticated
+func (c *Config) GetUser(ctx *fiber.Ctx) *User {
+	if c.gate != nil {
+		s, exists := c.getSession(ctx)
+		if !exists {
+			return nil
+		}
+		return &User{Subject: s.subject, Groups: s.groups, Role: c.getRoleOf(s.subject, s.role)}
+	}
+	if c.Basic != nil || c.LDAP != nil {
+		result := c.authenticateBasic(ctx)
+		if !result.authorized {
+			return nil
+		}
+		return &User{Subject: result.username, Role: c.getRoleOf(result.username, "")}
+	}
+	return nil
+}
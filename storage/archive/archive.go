@@ -0,0 +1,325 @@
+package archive
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+)
+
+const (
+	// FormatJSONL archives each result as a JSON object on its own line
+	FormatJSONL = "jsonl"
+
+	// FormatCSV archives each result as a row of a CSV file with a header
+	FormatCSV = "csv"
+
+	// DefaultRegion is the region used when none is specified
+	DefaultRegion = "us-east-1"
+
+	// DefaultFlushInterval is the interval at which the archived results are uploaded by default
+	DefaultFlushInterval = time.Hour
+
+	// maximumBufferedRecords is the maximum number of results kept in memory while they cannot be uploaded, beyond
+	// which the oldest results are dropped
+	maximumBufferedRecords = 100000
+
+	uploadTimeout = time.Minute
+)
+
+var (
+	// ErrNoBucket is the error returned when the archive is configured without a bucket
+	ErrNoBucket = errors.New("archive bucket cannot be empty")
+
+	// ErrInvalidFormat is the error returned when the archive is configured with a format that isn't supported
+	ErrInvalidFormat = errors.New("archive format must be either jsonl or csv")
+
+	// ErrInvalidEndpoint is the error returned when the archive is configured with an endpoint that isn't an HTTP(S) URL
+	ErrInvalidEndpoint = errors.New("archive endpoint must be an url starting with http:// or https://")
+
+	// ErrPartialCredentials is the error returned when the archive is configured with only one of access-key-id and
+	// secret-access-key
+	ErrPartialCredentials = errors.New("archive access-key-id and secret-access-key must be specified together")
+
+	csvHeader = []string{"endpoint_key", "endpoint_group", "endpoint_name", "timestamp", "success", "status", "hostname", "duration_ms", "errors", "conditions"}
+)
+
+// Config is the configuration of the archival of the results that are cleaned up from the store
+type Config struct {
+	// Bucket in which the results are archived
+	Bucket string `yaml:"bucket"`
+
+	// Prefix of the key of each archived object
+	Prefix string `yaml:"prefix,omitempty"`
+
+	// Region of the bucket
+	Region string `yaml:"region,omitempty"`
+
+	// Endpoint is the URL of an S3-compatible object storage (e.g. MinIO or GCS). Defaults to the endpoint of AWS S3 for
+	// the region.
+	Endpoint string `yaml:"endpoint,omitempty"`
+
+	// PathStyle is whether to address the bucket through the path (https://endpoint/bucket/key) instead of the
+	// host (https://bucket.endpoint/key)
+	PathStyle bool `yaml:"path-style,omitempty"`
+
+	// AccessKeyID used to sign the requests. If empty, credentials are retrieved from the environment.
+	AccessKeyID string `yaml:"access-key-id,omitempty"`
+
+	// SecretAccessKey used to sign the requests
+	SecretAccessKey string `yaml:"secret-access-key,omitempty"`
+
+	// Format of the archived objects, either FormatJSONL or FormatCSV
+	Format string `yaml:"format,omitempty"`
+
+	// FlushInterval is the interval at which the results are uploaded. Each upload creates a new object.
+	FlushInterval time.Duration `yaml:"flush-interval,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the archive configuration and sets the default values if necessary
+func (cfg *Config) ValidateAndSetDefaults() error {
+	if len(cfg.Bucket) == 0 {
+		return ErrNoBucket
+	}
+	if len(cfg.Format) == 0 {
+		cfg.Format = FormatJSONL
+	}
+	if cfg.Format != FormatJSONL && cfg.Format != FormatCSV {
+		return ErrInvalidFormat
+	}
+	if len(cfg.Region) == 0 {
+		cfg.Region = DefaultRegion
+	}
+	if len(cfg.Endpoint) == 0 {
+		cfg.Endpoint = "https://s3." + cfg.Region + ".amazonaws.com"
+	} else if !strings.HasPrefix(cfg.Endpoint, "http://") && !strings.HasPrefix(cfg.Endpoint, "https://") {
+		return ErrInvalidEndpoint
+	}
+	if _, err := url.Parse(cfg.Endpoint); err != nil {
+		return ErrInvalidEndpoint
+	}
+	if (len(cfg.AccessKeyID) == 0) != (len(cfg.SecretAccessKey) == 0) {
+		return ErrPartialCredentials
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = DefaultFlushInterval
+	}
+	cfg.Prefix = strings.Trim(cfg.Prefix, "/")
+	return nil
+}
+
+// record is an archived result, along with the endpoint it belongs to
+type record struct {
+	EndpointKey   string `json:"endpointKey"`
+	EndpointGroup string `json:"endpointGroup"`
+	EndpointName  string `json:"endpointName"`
+	*endpoint.Result
+}
+
+// Archiver buffers the results that are about to be cleaned up from the store, and uploads them to an S3-compatible
+// object storage at every flush interval
+type Archiver struct {
+	config      *Config
+	credentials *credentials.Credentials
+	client      *http.Client
+
+	mutex   sync.Mutex
+	records []*record
+
+	// flushMutex prevents concurrent flushes from uploading the same records
+	flushMutex sync.Mutex
+
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+// NewArchiver creates an Archiver and starts uploading the archived results at every flush interval.
+//
+// The configuration passed as parameter must have been validated with Config.ValidateAndSetDefaults.
+func NewArchiver(cfg *Config) (*Archiver, error) {
+	archiver := &Archiver{
+		config:  cfg,
+		client:  &http.Client{Timeout: uploadTimeout},
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	if len(cfg.AccessKeyID) > 0 {
+		archiver.credentials = credentials.NewStaticCredentials(cfg.AccessKeyID, cfg.SecretAccessKey, "")
+	} else {
+		sess, err := session.NewSession(&aws.Config{Region: aws.String(cfg.Region)})
+		if err != nil {
+			return nil, err
+		}
+		archiver.credentials = sess.Config.Credentials
+	}
+	go archiver.flushPeriodically()
+	return archiver, nil
+}
+
+// Archive adds the results of an endpoint to the next upload. Results are only kept in memory until then.
+func (a *Archiver) Archive(key, group, name string, results []*endpoint.Result) {
+	if len(results) == 0 {
+		return
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	for _, result := range results {
+		a.records = append(a.records, &record{EndpointKey: key, EndpointGroup: group, EndpointName: name, Result: result})
+	}
+	if len(a.records) > maximumBufferedRecords {
+		log.Printf("[archive.Archive] Dropping %d archived results, because they could not be uploaded", len(a.records)-maximumBufferedRecords)
+		a.records = a.records[len(a.records)-maximumBufferedRecords:]
+	}
+}
+
+// Flush uploads the archived results as a new object. If the upload fails, the results are kept for the next flush.
+func (a *Archiver) Flush() error {
+	a.flushMutex.Lock()
+	defer a.flushMutex.Unlock()
+	a.mutex.Lock()
+	records := a.records
+	a.records = nil
+	a.mutex.Unlock()
+	if len(records) == 0 {
+		return nil
+	}
+	body, err := a.encode(records)
+	if err == nil {
+		err = a.upload(a.objectKey(time.Now()), body)
+	}
+	if err != nil {
+		a.mutex.Lock()
+		a.records = append(records, a.records...)
+		a.mutex.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Close uploads the archived results and stops uploading them periodically
+func (a *Archiver) Close() {
+	select {
+	case <-a.stop:
+		return
+	default:
+		close(a.stop)
+	}
+	<-a.stopped
+	if err := a.Flush(); err != nil {
+		log.Printf("[archive.Close] Failed to upload archived results: %s", err.Error())
+	}
+}
+
+func (a *Archiver) flushPeriodically() {
+	defer close(a.stopped)
+	ticker := time.NewTicker(a.config.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-a.stop:
+			return
+		case <-ticker.C:
+			if err := a.Flush(); err != nil {
+				log.Printf("[archive.flushPeriodically] Failed to upload archived results: %s", err.Error())
+			}
+		}
+	}
+}
+
+func (a *Archiver) encode(records []*record) ([]byte, error) {
+	var buffer bytes.Buffer
+	if a.config.Format == FormatCSV {
+		writer := csv.NewWriter(&buffer)
+		_ = writer.Write(csvHeader)
+		for _, r := range records {
+			conditions := make([]string, len(r.ConditionResults))
+			for i, conditionResult := range r.ConditionResults {
+				conditions[i] = conditionResult.Condition + "=" + strconv.FormatBool(conditionResult.Success)
+			}
+			_ = writer.Write([]string{
+				r.EndpointKey,
+				r.EndpointGroup,
+				r.EndpointName,
+				r.Timestamp.UTC().Format(time.RFC3339Nano),
+				strconv.FormatBool(r.Success),
+				strconv.Itoa(r.HTTPStatus),
+				r.Hostname,
+				strconv.FormatInt(r.Duration.Milliseconds(), 10),
+				strings.Join(r.Errors, "; "),
+				strings.Join(conditions, "; "),
+			})
+		}
+		writer.Flush()
+		return buffer.Bytes(), writer.Error()
+	}
+	encoder := json.NewEncoder(&buffer)
+	for _, r := range records {
+		if err := encoder.Encode(r); err != nil {
+			return nil, err
+		}
+	}
+	return buffer.Bytes(), nil
+}
+
+// objectKey returns the key of the object uploaded at the time passed as parameter, which is partitioned by day so
+// that archived results are easy to find and to expire with a lifecycle rule
+func (a *Archiver) objectKey(now time.Time) string {
+	now = now.UTC()
+	key := now.Format("2006/01/02") + "/results-" + now.Format("20060102T150405.000000000Z") + "." + a.config.Format
+	if len(a.config.Prefix) > 0 {
+		key = a.config.Prefix + "/" + key
+	}
+	return key
+}
+
+// upload puts an object in the bucket. The request is signed using AWS Signature Version 4, unless no credentials
+// could be found, in which case it's sent anonymously.
+func (a *Archiver) upload(key string, body []byte) error {
+	objectURL, _ := url.Parse(a.config.Endpoint)
+	if a.config.PathStyle {
+		objectURL.Path = strings.TrimSuffix(objectURL.Path, "/") + "/" + a.config.Bucket + "/" + key
+	} else {
+		objectURL.Host = a.config.Bucket + "." + objectURL.Host
+		objectURL.Path = strings.TrimSuffix(objectURL.Path, "/") + "/" + key
+	}
+	request, err := http.NewRequest(http.MethodPut, objectURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if a.config.Format == FormatCSV {
+		request.Header.Set("Content-Type", "text/csv")
+	} else {
+		request.Header.Set("Content-Type", "application/x-ndjson")
+	}
+	_, err = v4.NewSigner(a.credentials).Sign(request, bytes.NewReader(body), "s3", a.config.Region, time.Now())
+	var awsErr awserr.Error
+	if err != nil && !(errors.As(err, &awsErr) && awsErr.Code() == "NoCredentialProviders") {
+		return fmt.Errorf("error signing archive upload: %w", err)
+	}
+	response, err := a.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("error uploading archive: %w", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		responseBody, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return fmt.Errorf("error uploading archive: bucket responded with status %d: %s", response.StatusCode, strings.TrimSpace(string(responseBody)))
+	}
+	return nil
+}
@@ -0,0 +1,263 @@
+package archive
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+)
+
+// fakeBucket is a fake of an S3-compatible object storage, which stores the objects put in it
+type fakeBucket struct {
+	mutex    sync.Mutex
+	objects  map[string]string
+	requests []*http.Request
+	failures int
+}
+
+func newFakeBucket(t *testing.T) (*fakeBucket, *httptest.Server) {
+	bucket := &fakeBucket{objects: make(map[string]string)}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bucket.mutex.Lock()
+		defer bucket.mutex.Unlock()
+		bucket.requests = append(bucket.requests, r)
+		if bucket.failures > 0 {
+			bucket.failures--
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte("<Error><Code>AccessDenied</Code></Error>"))
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		bucket.objects[r.URL.Path] = string(body)
+	}))
+	t.Cleanup(server.Close)
+	return bucket, server
+}
+
+func (bucket *fakeBucket) Objects() map[string]string {
+	bucket.mutex.Lock()
+	defer bucket.mutex.Unlock()
+	objects := make(map[string]string, len(bucket.objects))
+	for key, object := range bucket.objects {
+		objects[key] = object
+	}
+	return objects
+}
+
+func newTestArchiver(t *testing.T, endpointURL, format string) *Archiver {
+	cfg := &Config{
+		Bucket:          "archive",
+		Prefix:          "/gatus/",
+		Endpoint:        endpointURL,
+		PathStyle:       true,
+		AccessKeyID:     "access-key-id",
+		SecretAccessKey: "secret-access-key",
+		Format:          format,
+	}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	archiver, err := NewArchiver(cfg)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	return archiver
+}
+
+var testResults = []*endpoint.Result{
+	{
+		HTTPStatus: 200,
+		Hostname:   "example.org",
+		Duration:   150 * time.Millisecond,
+		Success:    true,
+		Timestamp:  time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		ConditionResults: []*endpoint.ConditionResult{
+			{Condition: "[STATUS] == 200", Success: true},
+			{Condition: "[RESPONSE_TIME] < 500", Success: true},
+		},
+	},
+	{
+		HTTPStatus: 500,
+		Hostname:   "example.org",
+		Duration:   750 * time.Millisecond,
+		Errors:     []string{"error-1", "error-2"},
+		Success:    false,
+		Timestamp:  time.Date(2024, 1, 2, 3, 5, 5, 0, time.UTC),
+		ConditionResults: []*endpoint.ConditionResult{
+			{Condition: "[STATUS] == 200", Success: false},
+		},
+	},
+}
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		cfg           *Config
+		expectedErr   error
+		expectedCheck func(*Config) bool
+	}{
+		{
+			name:        "no-bucket",
+			cfg:         &Config{},
+			expectedErr: ErrNoBucket,
+		},
+		{
+			name:        "invalid-format",
+			cfg:         &Config{Bucket: "archive", Format: "parquet"},
+			expectedErr: ErrInvalidFormat,
+		},
+		{
+			name:        "invalid-endpoint",
+			cfg:         &Config{Bucket: "archive", Endpoint: "minio:9000"},
+			expectedErr: ErrInvalidEndpoint,
+		},
+		{
+			name:        "partial-credentials",
+			cfg:         &Config{Bucket: "archive", AccessKeyID: "access-key-id"},
+			expectedErr: ErrPartialCredentials,
+		},
+		{
+			name: "defaults",
+			cfg:  &Config{Bucket: "archive", Region: "eu-west-1"},
+			expectedCheck: func(cfg *Config) bool {
+				return cfg.Format == FormatJSONL && cfg.Endpoint == "https://s3.eu-west-1.amazonaws.com" && cfg.FlushInterval == DefaultFlushInterval
+			},
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			err := scenario.cfg.ValidateAndSetDefaults()
+			if !errors.Is(err, scenario.expectedErr) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if scenario.expectedCheck != nil && !scenario.expectedCheck(scenario.cfg) {
+				t.Errorf("unexpected configuration after setting defaults: %+v", scenario.cfg)
+			}
+		})
+	}
+}
+
+func TestArchiver_FlushWithJSONL(t *testing.T) {
+	bucket, server := newFakeBucket(t)
+	archiver := newTestArchiver(t, server.URL, FormatJSONL)
+	defer archiver.Close()
+	if err := archiver.Flush(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(bucket.Objects()) != 0 {
+		t.Fatal("expected nothing to be uploaded when there's no result to archive")
+	}
+	archiver.Archive("group_name", "group", "name", testResults)
+	if err := archiver.Flush(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	objects := bucket.Objects()
+	if len(objects) != 1 {
+		t.Fatalf("expected 1 object, got %d", len(objects))
+	}
+	for key, object := range objects {
+		if !strings.HasPrefix(key, "/archive/gatus/") || !strings.HasSuffix(key, ".jsonl") {
+			t.Errorf("expected object to be put in the bucket under the prefix, got %s", key)
+		}
+		var lines []map[string]any
+		scanner := bufio.NewScanner(strings.NewReader(object))
+		for scanner.Scan() {
+			line := make(map[string]any)
+			if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+				t.Fatal("expected valid json, got", err.Error())
+			}
+			lines = append(lines, line)
+		}
+		if len(lines) != 2 {
+			t.Fatalf("expected 2 lines, got %d", len(lines))
+		}
+		if lines[0]["endpointKey"] != "group_name" || lines[0]["endpointGroup"] != "group" || lines[0]["endpointName"] != "name" {
+			t.Errorf("expected endpoint of the result to be archived, got %v", lines[0])
+		}
+		if lines[1]["status"] != float64(500) || lines[1]["success"] != false || lines[1]["timestamp"] != "2024-01-02T03:05:05Z" {
+			t.Errorf("expected result to be archived, got %v", lines[1])
+		}
+	}
+	if authorization := bucket.requests[0].Header.Get("Authorization"); !strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=access-key-id/") {
+		t.Errorf("expected request to be signed, got Authorization=%s", authorization)
+	}
+	// Archived results are only uploaded once
+	if err := archiver.Flush(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(bucket.Objects()) != 1 {
+		t.Error("expected no new object to be uploaded")
+	}
+}
+
+func TestArchiver_FlushWithCSV(t *testing.T) {
+	bucket, server := newFakeBucket(t)
+	archiver := newTestArchiver(t, server.URL, FormatCSV)
+	archiver.Archive("group_name", "group", "name", testResults)
+	// Closing the archiver uploads the results that haven't been uploaded yet
+	archiver.Close()
+	objects := bucket.Objects()
+	if len(objects) != 1 {
+		t.Fatalf("expected 1 object, got %d", len(objects))
+	}
+	for key, object := range objects {
+		if !strings.HasSuffix(key, ".csv") {
+			t.Errorf("expected csv object, got %s", key)
+		}
+		rows, err := csv.NewReader(strings.NewReader(object)).ReadAll()
+		if err != nil {
+			t.Fatal("expected valid csv, got", err.Error())
+		}
+		if len(rows) != 3 {
+			t.Fatalf("expected header and 2 rows, got %d rows", len(rows))
+		}
+		if strings.Join(rows[0], ",") != strings.Join(csvHeader, ",") {
+			t.Errorf("expected header, got %v", rows[0])
+		}
+		expectedRow := []string{"group_name", "group", "name", "2024-01-02T03:05:05Z", "false", "500", "example.org", "750", "error-1; error-2", "[STATUS] == 200=false"}
+		if strings.Join(rows[2], ",") != strings.Join(expectedRow, ",") {
+			t.Errorf("expected row %v, got %v", expectedRow, rows[2])
+		}
+	}
+}
+
+func TestArchiver_FlushWithError(t *testing.T) {
+	bucket, server := newFakeBucket(t)
+	bucket.failures = 1
+	archiver := newTestArchiver(t, server.URL, FormatJSONL)
+	defer archiver.Close()
+	archiver.Archive("group_name", "group", "name", testResults[:1])
+	if err := archiver.Flush(); err == nil || !strings.Contains(err.Error(), "status 403") {
+		t.Fatal("expected error, got", err)
+	}
+	archiver.Archive("group_name", "group", "name", testResults[1:])
+	if err := archiver.Flush(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	for _, object := range bucket.Objects() {
+		if lines := strings.Count(object, "\n"); lines != 2 {
+			t.Errorf("expected results that failed to be uploaded to be uploaded with the next flush, got %d lines", lines)
+		}
+	}
+}
+
+func TestArchiver_ObjectKey(t *testing.T) {
+	archiver := &Archiver{config: &Config{Format: FormatJSONL}}
+	now := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+	if key := archiver.objectKey(now); key != "2024/01/02/results-20240102T030405.000000006Z.jsonl" {
+		t.Errorf("unexpected key %s", key)
+	}
+	archiver.config.Prefix = "gatus"
+	if key := archiver.objectKey(now); key != "gatus/2024/01/02/results-20240102T030405.000000006Z.jsonl" {
+		t.Errorf("unexpected key %s", key)
+	}
+}
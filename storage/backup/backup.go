@@ -0,0 +1,170 @@
+package backup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+)
+
+// Version is the version of the format of the backups created by Export
+const Version = 1
+
+var (
+	// ErrNotSupported is the error returned when the store doesn't support being exported or imported
+	ErrNotSupported = errors.New("storage type does not support export nor import")
+
+	// ErrUnsupportedVersion is the error returned when importing a backup whose version isn't supported
+	ErrUnsupportedVersion = errors.New("unsupported backup version")
+)
+
+// backup is everything the store holds, as written by Export and read by Import
+type backup struct {
+	Version   int               `json:"version"`
+	CreatedAt time.Time         `json:"createdAt"`
+	Endpoints []*endpointBackup `json:"endpoints"`
+}
+
+// endpointBackup is the results, events and uptime of an endpoint
+type endpointBackup struct {
+	Key     string            `json:"key"`
+	Group   string            `json:"group,omitempty"`
+	Name    string            `json:"name"`
+	Results []*result         `json:"results"`
+	Events  []*endpoint.Event `json:"events,omitempty"`
+	Uptime  []*hourlyUptime   `json:"uptime,omitempty"`
+}
+
+// result is an endpoint.Result along with the fields that are persisted by the store, but not exposed by the API
+type result struct {
+	*endpoint.Result
+	DNSRCode              string        `json:"dnsRcode,omitempty"`
+	IP                    string        `json:"ip,omitempty"`
+	Connected             bool          `json:"connected,omitempty"`
+	CertificateExpiration time.Duration `json:"certificateExpiration,omitempty"`
+	DomainExpiration      time.Duration `json:"domainExpiration,omitempty"`
+}
+
+// hourlyUptime is the uptime statistics of an endpoint during the hour starting at Hour, a unix timestamp
+type hourlyUptime struct {
+	Hour                 int64  `json:"hour"`
+	TotalExecutions      uint64 `json:"totalExecutions"`
+	SuccessfulExecutions uint64 `json:"successfulExecutions"`
+	TotalResponseTime    uint64 `json:"totalResponseTime"`
+}
+
+// Export writes the statuses, results, events and uptimes of every endpoint in the store as JSON
+func Export(s store.Store, w io.Writer) error {
+	backupStore, ok := s.(store.BackupStore)
+	if !ok {
+		return ErrNotSupported
+	}
+	statuses, err := s.GetAllEndpointStatuses(paging.NewEndpointStatusParams().WithResults(1, math.MaxInt32).WithEvents(1, math.MaxInt32))
+	if err != nil {
+		return err
+	}
+	b := &backup{Version: Version, CreatedAt: time.Now(), Endpoints: make([]*endpointBackup, 0, len(statuses))}
+	for _, status := range statuses {
+		hourlyStatistics, err := backupStore.GetHourlyUptimeStatisticsByKey(status.Key)
+		if err != nil {
+			return fmt.Errorf("error retrieving uptime of endpoint with key=%s: %w", status.Key, err)
+		}
+		eb := &endpointBackup{
+			Key:     status.Key,
+			Group:   status.Group,
+			Name:    status.Name,
+			Results: make([]*result, 0, len(status.Results)),
+			Events:  status.Events,
+		}
+		for _, r := range status.Results {
+			eb.Results = append(eb.Results, &result{
+				Result:                r,
+				DNSRCode:              r.DNSRCode,
+				IP:                    r.IP,
+				Connected:             r.Connected,
+				CertificateExpiration: r.CertificateExpiration,
+				DomainExpiration:      r.DomainExpiration,
+			})
+		}
+		for hour, statistics := range hourlyStatistics {
+			eb.Uptime = append(eb.Uptime, &hourlyUptime{
+				Hour:                 hour,
+				TotalExecutions:      statistics.TotalExecutions,
+				SuccessfulExecutions: statistics.SuccessfulExecutions,
+				TotalResponseTime:    statistics.TotalExecutionsResponseTime,
+			})
+		}
+		sort.Slice(eb.Uptime, func(i, j int) bool { return eb.Uptime[i].Hour < eb.Uptime[j].Hour })
+		b.Endpoints = append(b.Endpoints, eb)
+	}
+	return json.NewEncoder(w).Encode(b)
+}
+
+// Import reads a backup written by Export and replaces the results, events and uptime of each endpoint in the store
+// by those of the backup. Endpoints that aren't in the backup are left untouched.
+//
+// Returns the number of endpoints imported.
+func Import(s store.Store, r io.Reader) (int, error) {
+	backupStore, ok := s.(store.BackupStore)
+	if !ok {
+		return 0, ErrNotSupported
+	}
+	b := &backup{}
+	if err := json.NewDecoder(r).Decode(b); err != nil {
+		return 0, fmt.Errorf("error decoding backup: %w", err)
+	}
+	if b.Version != Version {
+		return 0, fmt.Errorf("%w: %d", ErrUnsupportedVersion, b.Version)
+	}
+	for i, eb := range b.Endpoints {
+		status := endpoint.NewStatus(eb.Group, eb.Name)
+		for _, r := range eb.Results {
+			if r.Result == nil {
+				continue
+			}
+			r.Result.DNSRCode = r.DNSRCode
+			r.Result.IP = r.IP
+			r.Result.Connected = r.Connected
+			r.Result.CertificateExpiration = r.CertificateExpiration
+			r.Result.DomainExpiration = r.DomainExpiration
+			status.Results = append(status.Results, r.Result)
+		}
+		status.Events = eb.Events
+		for _, uptime := range eb.Uptime {
+			status.Uptime.HourlyStatistics[uptime.Hour] = &endpoint.HourlyUptimeStatistics{
+				TotalExecutions:             uptime.TotalExecutions,
+				SuccessfulExecutions:        uptime.SuccessfulExecutions,
+				TotalExecutionsResponseTime: uptime.TotalResponseTime,
+			}
+		}
+		if err := backupStore.ImportEndpointStatus(status); err != nil {
+			return i, fmt.Errorf("error importing endpoint with key=%s: %w", status.Key, err)
+		}
+	}
+	return len(b.Endpoints), nil
+}
+
+// Copy replaces the results, events and uptime of each endpoint in the destination by those of the same endpoint in
+// the source, as if the source had been exported and then imported into the destination.
+//
+// Returns the number of endpoints copied.
+func Copy(source, destination store.Store) (int, error) {
+	if _, ok := destination.(store.BackupStore); !ok {
+		return 0, ErrNotSupported
+	}
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(Export(source, writer))
+	}()
+	numberOfEndpointsCopied, err := Import(destination, reader)
+	// Unblocks the export if the import stopped before reading everything
+	_ = reader.CloseWithError(err)
+	return numberOfEndpointsCopied, err
+}
@@ -0,0 +1,108 @@
+package backup
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+	"github.com/TwiN/gatus/v5/storage/store/memory"
+	"github.com/TwiN/gatus/v5/storage/store/sql"
+)
+
+func TestExportAndImport(t *testing.T) {
+	source, _ := memory.NewStore()
+	defer source.Close()
+	ep := &endpoint.Endpoint{Name: "frontend", Group: "core"}
+	now := time.Now().Truncate(time.Hour)
+	for i := 0; i < 5; i++ {
+		_ = source.Insert(ep, &endpoint.Result{
+			Success:               i%2 == 0,
+			HTTPStatus:            200,
+			IP:                    "127.0.0.1",
+			Connected:             true,
+			CertificateExpiration: 10 * time.Hour,
+			Duration:              time.Duration(i) * time.Millisecond,
+			Timestamp:             now.Add(time.Duration(i-5) * time.Hour),
+			ConditionResults:      []*endpoint.ConditionResult{{Condition: "[STATUS] == 200", Success: true}},
+		})
+	}
+	var buffer bytes.Buffer
+	if err := Export(source, &buffer); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	destination, err := sql.NewStore("sqlite", t.TempDir()+"/TestExportAndImport.db", false)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	defer destination.Close()
+	// Data of the endpoint that was already in the destination should be replaced
+	_ = destination.Insert(ep, &endpoint.Result{Success: true, Timestamp: now})
+	numberOfEndpointsImported, err := Import(destination, &buffer)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if numberOfEndpointsImported != 1 {
+		t.Errorf("expected 1 endpoint to be imported, got %d", numberOfEndpointsImported)
+	}
+	params := paging.NewEndpointStatusParams().WithResults(1, 100).WithEvents(1, 100)
+	expected, _ := source.GetEndpointStatusByKey(ep.Key(), params)
+	actual, err := destination.GetEndpointStatusByKey(ep.Key(), params)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(actual.Results) != len(expected.Results) || len(actual.Events) != len(expected.Events) {
+		t.Fatalf("expected %d results and %d events, got %d results and %d events", len(expected.Results), len(expected.Events), len(actual.Results), len(actual.Events))
+	}
+	for i, result := range actual.Results {
+		if !result.Timestamp.Equal(expected.Results[i].Timestamp) || result.Success != expected.Results[i].Success || result.IP != "127.0.0.1" || !result.Connected || result.CertificateExpiration != 10*time.Hour || len(result.ConditionResults) != 1 {
+			t.Errorf("expected result %+v, got %+v", expected.Results[i], result)
+		}
+	}
+	expectedUptime, _ := source.GetUptimeByKey(ep.Key(), now.Add(-24*time.Hour), now)
+	if actualUptime, _ := destination.GetUptimeByKey(ep.Key(), now.Add(-24*time.Hour), now); actualUptime != expectedUptime {
+		t.Errorf("expected uptime %f, got %f", expectedUptime, actualUptime)
+	}
+}
+
+func TestImportWithUnsupportedVersion(t *testing.T) {
+	s, _ := memory.NewStore()
+	defer s.Close()
+	if _, err := Import(s, strings.NewReader(`{"version":42}`)); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("expected %v, got %v", ErrUnsupportedVersion, err)
+	}
+	if _, err := Import(s, strings.NewReader(`not json`)); err == nil {
+		t.Error("expected error, got none")
+	}
+}
+
+func TestCopy(t *testing.T) {
+	source, err := sql.NewStore("sqlite", t.TempDir()+"/source.db", false)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	defer source.Close()
+	for i := 0; i < 3; i++ {
+		ep := &endpoint.Endpoint{Name: "endpoint-" + string(rune('a'+i)), Group: "core"}
+		_ = source.Insert(ep, &endpoint.Result{Success: true, Timestamp: time.Now()})
+	}
+	destination, err := sql.NewStore("sqlite", t.TempDir()+"/destination.db", false)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	defer destination.Close()
+	numberOfEndpointsCopied, err := Copy(source, destination)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if numberOfEndpointsCopied != 3 {
+		t.Errorf("expected 3 endpoints to be copied, got %d", numberOfEndpointsCopied)
+	}
+	statuses, _ := destination.GetAllEndpointStatuses(paging.NewEndpointStatusParams().WithResults(1, 20))
+	if len(statuses) != 3 || len(statuses[0].Results) != 1 {
+		t.Errorf("expected 3 endpoints with 1 result each, got %d endpoints", len(statuses))
+	}
+}
@@ -2,11 +2,32 @@ package storage
 
 import (
 	"errors"
+	"time"
+
+	"github.com/TwiN/gatus/v5/storage/archive"
+	"github.com/TwiN/gatus/v5/storage/store/common"
 )
 
 var (
-	ErrSQLStorageRequiresPath          = errors.New("sql storage requires a non-empty path to be defined")
-	ErrMemoryStorageDoesNotSupportPath = errors.New("memory storage does not support persistence, use sqlite if you want persistence on file")
+	ErrSQLStorageRequiresPath        = errors.New("sql storage requires a non-empty path to be defined")
+	ErrInvalidMemoryConfig           = errors.New("memory snapshot-interval cannot be negative")
+	ErrClickHouseStorageRequiresPath = errors.New("clickhouse storage requires a non-empty path to be defined")
+	ErrInvalidClickHouseConfig       = errors.New("clickhouse batch-size, flush-interval and retention cannot be negative")
+	ErrInvalidPostgresPartitioning   = errors.New("postgres partitioning must be either native or timescaledb")
+	ErrInvalidPostgresConfig         = errors.New("postgres retention and partition-interval cannot be negative")
+	ErrInvalidPostgresReplica        = errors.New("postgres replicas cannot be empty")
+	ErrInvalidRetention              = errors.New("retention must specify at least one of maximum-results, maximum-events and maximum-age, none of which can be negative")
+	ErrRetentionNotSupported         = errors.New("retention is not supported by the clickhouse storage, the external storage nor by partitioned postgres storage, whose results are expired by the database or the storage plugin")
+	ErrInvalidDownsampling           = errors.New("downsampling after, daily-after, retention and interval cannot be negative, and after cannot exceed daily-after, which cannot exceed retention")
+	ErrDownsamplingNotSupported      = errors.New("downsampling is only supported by the sqlite and postgres storage types")
+	ErrEncryptionNotSupported        = errors.New("encryption-key is only supported by the sqlite storage type")
+	ErrInvalidConnectionPool         = errors.New("max-open-conns, max-idle-conns and conn-max-lifetime cannot be negative, and max-open-conns cannot exceed 1 with the sqlite storage type")
+	ErrConnectionPoolNotSupported    = errors.New("max-open-conns, max-idle-conns and conn-max-lifetime are only supported by the sqlite and postgres storage types")
+	ErrInvalidWriteBuffer            = errors.New("write-buffer batch-size and flush-interval cannot be negative")
+	ErrWriteBufferNotSupported       = errors.New("write-buffer is only supported by the sqlite and postgres storage types")
+	ErrArchiveNotSupported           = errors.New("archive is not supported by the clickhouse storage, the external storage nor by partitioned postgres storage, whose results are expired by the database or the storage plugin")
+	ErrExternalStorageRequiresPath   = errors.New("external storage requires a non-empty path to be defined, which is the address of the storage plugin")
+	ErrInvalidExternalConfig         = errors.New("external timeout cannot be negative")
 )
 
 // Config is the configuration for storage
@@ -25,8 +46,182 @@ type Config struct {
 	// as they happen, also known as the write-through caching strategy.
 	// Does not apply if Config.Type is not TypePostgres or TypeSQLite.
 	Caching bool `yaml:"caching,omitempty"`
+
+	// EncryptionKey is the key used to encrypt the database file.
+	// If blank, the database file isn't encrypted.
+	// Only supported if Config.Type is TypeSQLite.
+	EncryptionKey string `yaml:"encryption-key,omitempty"`
+
+	// MaxOpenConns is the maximum number of open connections to the database.
+	// If 0, the number of open connections is unlimited, except with TypeSQLite, which only opens a single connection.
+	// Does not apply if Config.Type is not TypePostgres or TypeSQLite.
+	MaxOpenConns int `yaml:"max-open-conns,omitempty"`
+
+	// MaxIdleConns is the maximum number of idle connections kept open to the database.
+	// If 0, the default of 2 idle connections is used.
+	// Does not apply if Config.Type is not TypePostgres or TypeSQLite.
+	MaxIdleConns int `yaml:"max-idle-conns,omitempty"`
+
+	// ConnMaxLifetime is the maximum amount of time a connection to the database may be reused.
+	// If 0, connections are reused until they're closed due to an error.
+	// Does not apply if Config.Type is not TypePostgres or TypeSQLite.
+	ConnMaxLifetime time.Duration `yaml:"conn-max-lifetime,omitempty"`
+
+	// Memory is the configuration specific to the memory store.
+	// Does not apply if Config.Type is not TypeMemory.
+	Memory *MemoryConfig `yaml:"memory,omitempty"`
+
+	// ClickHouse is the configuration specific to the ClickHouse store.
+	// Does not apply if Config.Type is not TypeClickHouse.
+	ClickHouse *ClickHouseConfig `yaml:"clickhouse,omitempty"`
+
+	// Postgres is the configuration specific to the Postgres store.
+	// Does not apply if Config.Type is not TypePostgres.
+	Postgres *PostgresConfig `yaml:"postgres,omitempty"`
+
+	// External is the configuration specific to the external store.
+	// Does not apply if Config.Type is not TypeExternal.
+	External *ExternalConfig `yaml:"external,omitempty"`
+
+	// Archive is the configuration of the archival of results to an S3-compatible object storage before they're
+	// cleaned up from the store.
+	// If nil, results are not archived.
+	Archive *archive.Config `yaml:"archive,omitempty"`
+
+	// Retention is the list of retention policies of specific groups and endpoints.
+	// Endpoints that don't match any of them keep the last common.MaximumNumberOfResults results and the last
+	// common.MaximumNumberOfEvents events.
+	Retention []*RetentionConfig `yaml:"retention,omitempty"`
+
+	// WriteBuffer is the configuration of the buffer in which results are kept until they're inserted in batches.
+	// If nil, results are inserted as soon as they're observed.
+	// Does not apply if Config.Type is not TypePostgres or TypeSQLite.
+	WriteBuffer *WriteBufferConfig `yaml:"write-buffer,omitempty"`
+
+	// Downsampling is the configuration of the downsampling of old results into hourly and daily aggregates.
+	// If nil, results are not downsampled.
+	// Does not apply if Config.Type is not TypePostgres or TypeSQLite.
+	Downsampling *DownsamplingConfig `yaml:"downsampling,omitempty"`
+}
+
+// WriteBufferConfig is the configuration of the buffer in which results are kept until they're inserted in batches
+type WriteBufferConfig struct {
+	// BatchSize is the number of results buffered before they're inserted in a single transaction
+	BatchSize int `yaml:"batch-size,omitempty"`
+
+	// FlushInterval is the maximum duration during which results are buffered before they're inserted
+	FlushInterval time.Duration `yaml:"flush-interval,omitempty"`
+}
+
+// DownsamplingConfig is the configuration of the downsampling of old results into hourly and daily aggregates
+type DownsamplingConfig struct {
+	// After is the age beyond which results are collapsed into hourly aggregates
+	After time.Duration `yaml:"after,omitempty"`
+
+	// DailyAfter is the age beyond which hourly aggregates are collapsed into daily aggregates
+	DailyAfter time.Duration `yaml:"daily-after,omitempty"`
+
+	// Retention is the age beyond which daily aggregates are deleted
+	Retention time.Duration `yaml:"retention,omitempty"`
+
+	// Interval is the interval at which the downsampling job runs
+	Interval time.Duration `yaml:"interval,omitempty"`
+}
+
+const (
+	DefaultDownsamplingAfter      = 7 * 24 * time.Hour
+	DefaultDownsamplingDailyAfter = 30 * 24 * time.Hour
+	DefaultDownsamplingRetention  = 365 * 24 * time.Hour
+	DefaultDownsamplingInterval   = time.Hour
+)
+
+// RetentionConfig is the retention policy of the endpoints matching Group and Endpoint
+type RetentionConfig struct {
+	// Group of the endpoints to which the retention policy applies. If blank, applies to endpoints of every group.
+	Group string `yaml:"group,omitempty"`
+
+	// Endpoint is the name of the endpoints to which the retention policy applies. If blank, applies to every
+	// endpoint of the group.
+	Endpoint string `yaml:"endpoint,omitempty"`
+
+	// MaximumResults is the maximum number of results kept. If 0, common.MaximumNumberOfResults is used.
+	MaximumResults int `yaml:"maximum-results,omitempty"`
+
+	// MaximumEvents is the maximum number of events kept. If 0, common.MaximumNumberOfEvents is used.
+	MaximumEvents int `yaml:"maximum-events,omitempty"`
+
+	// MaximumAge is the age beyond which results and events are deleted. If 0, results and events don't expire.
+	MaximumAge time.Duration `yaml:"maximum-age,omitempty"`
+}
+
+// matches returns how specifically the retention policy matches the endpoint with the given group and name, or -1
+// if it doesn't match it
+func (r *RetentionConfig) matches(group, name string) int {
+	if (len(r.Group) > 0 && r.Group != group) || (len(r.Endpoint) > 0 && r.Endpoint != name) {
+		return -1
+	}
+	specificity := 0
+	if len(r.Endpoint) > 0 {
+		specificity += 2
+	}
+	if len(r.Group) > 0 {
+		specificity++
+	}
+	return specificity
 }
 
+// MemoryConfig is the configuration of the memory store
+type MemoryConfig struct {
+	// SnapshotInterval is the interval at which the state of the store is written to the snapshot file at Config.Path
+	SnapshotInterval time.Duration `yaml:"snapshot-interval,omitempty"`
+}
+
+// ClickHouseConfig is the configuration of the ClickHouse store
+type ClickHouseConfig struct {
+	// BatchSize is the number of results buffered before they're inserted
+	BatchSize int `yaml:"batch-size,omitempty"`
+
+	// FlushInterval is the maximum duration during which results are buffered before they're inserted
+	FlushInterval time.Duration `yaml:"flush-interval,omitempty"`
+
+	// Retention is how long results and events are kept
+	Retention time.Duration `yaml:"retention,omitempty"`
+}
+
+// PostgresConfig is the configuration of the Postgres store
+type PostgresConfig struct {
+	// Partitioning is how the results are partitioned by timestamp, either PostgresPartitioningNative or
+	// PostgresPartitioningTimescaleDB. If blank, results aren't partitioned.
+	Partitioning string `yaml:"partitioning,omitempty"`
+
+	// Retention is how long partitioned results are kept
+	Retention time.Duration `yaml:"retention,omitempty"`
+
+	// PartitionInterval is the duration spanned by each partition
+	PartitionInterval time.Duration `yaml:"partition-interval,omitempty"`
+
+	// Replicas are the paths of the replicas of the database that the dashboard and the API read from.
+	// Writes fail over to the first replica that is promoted when the database at Config.Path cannot be reached.
+	Replicas []string `yaml:"replicas,omitempty"`
+}
+
+// ExternalConfig is the configuration of the external store, which proxies to a storage plugin over gRPC
+type ExternalConfig struct {
+	// Command is the command starting the storage plugin, followed by its arguments.
+	// The plugin is expected to serve the contract of the storagepb package at Config.Path, which is passed to it
+	// through the GATUS_STORAGE_EXTERNAL_ADDRESS environment variable, and is stopped along with Gatus.
+	// If empty, the plugin is expected to be running already.
+	Command []string `yaml:"command,omitempty"`
+
+	// Timeout is the maximum duration of each call to the storage plugin
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+}
+
+const (
+	PostgresPartitioningNative      = "native"      // Partitions created and dropped by Gatus
+	PostgresPartitioningTimescaleDB = "timescaledb" // Hypertables whose chunks are dropped by TimescaleDB
+)
+
 // ValidateAndSetDefaults validates the configuration and sets the default values (if applicable)
 func (c *Config) ValidateAndSetDefaults() error {
 	if c.Type == "" {
@@ -35,8 +230,138 @@ func (c *Config) ValidateAndSetDefaults() error {
 	if (c.Type == TypePostgres || c.Type == TypeSQLite) && len(c.Path) == 0 {
 		return ErrSQLStorageRequiresPath
 	}
-	if c.Type == TypeMemory && len(c.Path) > 0 {
-		return ErrMemoryStorageDoesNotSupportPath
+	if c.Type == TypeMemory && c.Memory != nil && c.Memory.SnapshotInterval < 0 {
+		return ErrInvalidMemoryConfig
+	}
+	if c.Type != TypeSQLite && len(c.EncryptionKey) > 0 {
+		return ErrEncryptionNotSupported
+	}
+	if c.MaxOpenConns != 0 || c.MaxIdleConns != 0 || c.ConnMaxLifetime != 0 {
+		if c.Type != TypePostgres && c.Type != TypeSQLite {
+			return ErrConnectionPoolNotSupported
+		}
+		if c.MaxOpenConns < 0 || c.MaxIdleConns < 0 || c.ConnMaxLifetime < 0 || (c.Type == TypeSQLite && c.MaxOpenConns > 1) {
+			return ErrInvalidConnectionPool
+		}
+	}
+	if c.WriteBuffer != nil {
+		if c.Type != TypePostgres && c.Type != TypeSQLite {
+			return ErrWriteBufferNotSupported
+		}
+		if c.WriteBuffer.BatchSize < 0 || c.WriteBuffer.FlushInterval < 0 {
+			return ErrInvalidWriteBuffer
+		}
+	}
+	if c.Type == TypeClickHouse {
+		if len(c.Path) == 0 {
+			return ErrClickHouseStorageRequiresPath
+		}
+		if c.ClickHouse == nil {
+			c.ClickHouse = &ClickHouseConfig{}
+		}
+		if c.ClickHouse.BatchSize < 0 || c.ClickHouse.FlushInterval < 0 || c.ClickHouse.Retention < 0 {
+			return ErrInvalidClickHouseConfig
+		}
+	}
+	if c.Type == TypeExternal {
+		if len(c.Path) == 0 {
+			return ErrExternalStorageRequiresPath
+		}
+		if c.External == nil {
+			c.External = &ExternalConfig{}
+		}
+		if c.External.Timeout < 0 {
+			return ErrInvalidExternalConfig
+		}
+	}
+	if c.Type == TypePostgres && c.Postgres != nil {
+		if len(c.Postgres.Partitioning) > 0 && c.Postgres.Partitioning != PostgresPartitioningNative && c.Postgres.Partitioning != PostgresPartitioningTimescaleDB {
+			return ErrInvalidPostgresPartitioning
+		}
+		if c.Postgres.Retention < 0 || c.Postgres.PartitionInterval < 0 {
+			return ErrInvalidPostgresConfig
+		}
+		for _, replica := range c.Postgres.Replicas {
+			if len(replica) == 0 {
+				return ErrInvalidPostgresReplica
+			}
+		}
+	}
+	for _, retention := range c.Retention {
+		if c.Type == TypeClickHouse || c.Type == TypeExternal || (c.Type == TypePostgres && c.Postgres != nil && len(c.Postgres.Partitioning) > 0) {
+			return ErrRetentionNotSupported
+		}
+		if retention.MaximumResults < 0 || retention.MaximumEvents < 0 || retention.MaximumAge < 0 {
+			return ErrInvalidRetention
+		}
+		if retention.MaximumResults == 0 && retention.MaximumEvents == 0 && retention.MaximumAge == 0 {
+			return ErrInvalidRetention
+		}
+	}
+	if c.Downsampling != nil {
+		if c.Type != TypePostgres && c.Type != TypeSQLite {
+			return ErrDownsamplingNotSupported
+		}
+		if err := c.Downsampling.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+	}
+	if c.Archive != nil {
+		if c.Type == TypeClickHouse || c.Type == TypeExternal || (c.Type == TypePostgres && c.Postgres != nil && len(c.Postgres.Partitioning) > 0) {
+			return ErrArchiveNotSupported
+		}
+		if err := c.Archive.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
 	}
 	return nil
 }
+
+// ValidateAndSetDefaults validates the downsampling configuration and sets the default values (if applicable)
+func (c *DownsamplingConfig) ValidateAndSetDefaults() error {
+	if c.After < 0 || c.DailyAfter < 0 || c.Retention < 0 || c.Interval < 0 {
+		return ErrInvalidDownsampling
+	}
+	if c.After == 0 {
+		c.After = DefaultDownsamplingAfter
+	}
+	if c.DailyAfter == 0 {
+		c.DailyAfter = max(DefaultDownsamplingDailyAfter, c.After)
+	}
+	if c.Retention == 0 {
+		c.Retention = max(DefaultDownsamplingRetention, c.DailyAfter)
+	}
+	if c.Interval == 0 {
+		c.Interval = DefaultDownsamplingInterval
+	}
+	if c.After > c.DailyAfter || c.DailyAfter > c.Retention {
+		return ErrInvalidDownsampling
+	}
+	return nil
+}
+
+// RetentionPolicy returns the retention policy of the endpoint with the given group and name.
+//
+// If several retention policies match the endpoint, the most specific one is used: a policy matching both the group
+// and the endpoint takes precedence over one matching only the endpoint, which takes precedence over one matching only
+// the group, which takes precedence over one matching every endpoint.
+func (c *Config) RetentionPolicy(group, name string) common.RetentionPolicy {
+	policy := common.DefaultRetentionPolicy
+	var match *RetentionConfig
+	specificity := -1
+	for _, retention := range c.Retention {
+		if s := retention.matches(group, name); s > specificity {
+			match, specificity = retention, s
+		}
+	}
+	if match != nil {
+		if match.MaximumResults > 0 {
+			policy.MaximumNumberOfResults = match.MaximumResults
+		}
+		if match.MaximumEvents > 0 {
+			policy.MaximumNumberOfEvents = match.MaximumEvents
+		}
+		policy.MaximumAge = match.MaximumAge
+	}
+	return policy
+}
@@ -0,0 +1,234 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/storage/store/common"
+)
+
+func TestConfig_ValidateAndSetDefaultsWithRetention(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		cfg         *Config
+		expectedErr error
+	}{
+		{
+			name: "valid",
+			cfg:  &Config{Retention: []*RetentionConfig{{Group: "core", MaximumAge: time.Hour}}},
+		},
+		{
+			name:        "empty",
+			cfg:         &Config{Retention: []*RetentionConfig{{Group: "core"}}},
+			expectedErr: ErrInvalidRetention,
+		},
+		{
+			name:        "negative",
+			cfg:         &Config{Retention: []*RetentionConfig{{Group: "core", MaximumResults: -1}}},
+			expectedErr: ErrInvalidRetention,
+		},
+		{
+			name:        "clickhouse",
+			cfg:         &Config{Type: TypeClickHouse, Path: "http://localhost:8123", Retention: []*RetentionConfig{{MaximumResults: 10}}},
+			expectedErr: ErrRetentionNotSupported,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.ValidateAndSetDefaults(); !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestConfig_RetentionPolicy(t *testing.T) {
+	cfg := &Config{
+		Retention: []*RetentionConfig{
+			{MaximumAge: 24 * time.Hour},
+			{Group: "core", MaximumResults: 500},
+			{Endpoint: "frontend", MaximumEvents: 10},
+			{Group: "core", Endpoint: "frontend", MaximumResults: 1000, MaximumAge: 7 * 24 * time.Hour},
+		},
+	}
+	scenarios := []struct {
+		group, name    string
+		expectedPolicy common.RetentionPolicy
+	}{
+		{
+			group:          "core",
+			name:           "frontend",
+			expectedPolicy: common.RetentionPolicy{MaximumNumberOfResults: 1000, MaximumNumberOfEvents: common.MaximumNumberOfEvents, MaximumAge: 7 * 24 * time.Hour},
+		},
+		{
+			group:          "external",
+			name:           "frontend",
+			expectedPolicy: common.RetentionPolicy{MaximumNumberOfResults: common.MaximumNumberOfResults, MaximumNumberOfEvents: 10},
+		},
+		{
+			group:          "core",
+			name:           "backend",
+			expectedPolicy: common.RetentionPolicy{MaximumNumberOfResults: 500, MaximumNumberOfEvents: common.MaximumNumberOfEvents},
+		},
+		{
+			group:          "",
+			name:           "backend",
+			expectedPolicy: common.RetentionPolicy{MaximumNumberOfResults: common.MaximumNumberOfResults, MaximumNumberOfEvents: common.MaximumNumberOfEvents, MaximumAge: 24 * time.Hour},
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.group+"_"+scenario.name, func(t *testing.T) {
+			if policy := cfg.RetentionPolicy(scenario.group, scenario.name); policy != scenario.expectedPolicy {
+				t.Errorf("expected %+v, got %+v", scenario.expectedPolicy, policy)
+			}
+		})
+	}
+	if policy := (&Config{}).RetentionPolicy("core", "frontend"); policy != common.DefaultRetentionPolicy {
+		t.Errorf("expected default retention policy, got %+v", policy)
+	}
+}
+
+func TestConfig_ValidateAndSetDefaultsWithDownsampling(t *testing.T) {
+	scenarios := []struct {
+		name               string
+		cfg                *Config
+		expectedErr        error
+		expectedDailyAfter time.Duration
+	}{
+		{
+			name:               "defaults",
+			cfg:                &Config{Type: TypeSQLite, Path: "data.db", Downsampling: &DownsamplingConfig{}},
+			expectedDailyAfter: DefaultDownsamplingDailyAfter,
+		},
+		{
+			name:               "after-longer-than-default-daily-after",
+			cfg:                &Config{Type: TypeSQLite, Path: "data.db", Downsampling: &DownsamplingConfig{After: 60 * 24 * time.Hour}},
+			expectedDailyAfter: 60 * 24 * time.Hour,
+		},
+		{
+			name:        "after-longer-than-daily-after",
+			cfg:         &Config{Type: TypeSQLite, Path: "data.db", Downsampling: &DownsamplingConfig{After: 48 * time.Hour, DailyAfter: 24 * time.Hour}},
+			expectedErr: ErrInvalidDownsampling,
+		},
+		{
+			name:        "negative",
+			cfg:         &Config{Type: TypeSQLite, Path: "data.db", Downsampling: &DownsamplingConfig{Interval: -time.Hour}},
+			expectedErr: ErrInvalidDownsampling,
+		},
+		{
+			name:        "memory",
+			cfg:         &Config{Type: TypeMemory, Downsampling: &DownsamplingConfig{}},
+			expectedErr: ErrDownsamplingNotSupported,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.ValidateAndSetDefaults(); !errors.Is(err, scenario.expectedErr) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if scenario.expectedErr == nil && scenario.cfg.Downsampling.DailyAfter != scenario.expectedDailyAfter {
+				t.Errorf("expected daily-after to be %s, got %s", scenario.expectedDailyAfter, scenario.cfg.Downsampling.DailyAfter)
+			}
+		})
+	}
+}
+
+func TestConfig_ValidateAndSetDefaultsWithEncryptionKey(t *testing.T) {
+	if err := (&Config{Type: TypeSQLite, Path: "data.db", EncryptionKey: "key"}).ValidateAndSetDefaults(); err != nil {
+		t.Error("expected no error, got", err.Error())
+	}
+	if err := (&Config{Type: TypePostgres, Path: "postgres://localhost", EncryptionKey: "key"}).ValidateAndSetDefaults(); !errors.Is(err, ErrEncryptionNotSupported) {
+		t.Errorf("expected error %v, got %v", ErrEncryptionNotSupported, err)
+	}
+}
+
+func TestConfig_ValidateAndSetDefaultsWithPostgresReplicas(t *testing.T) {
+	cfg := &Config{Type: TypePostgres, Path: "postgres://primary/gatus", Postgres: &PostgresConfig{Replicas: []string{"postgres://replica/gatus"}}}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Error("expected no error, got", err.Error())
+	}
+	cfg.Postgres.Replicas = append(cfg.Postgres.Replicas, "")
+	if err := cfg.ValidateAndSetDefaults(); !errors.Is(err, ErrInvalidPostgresReplica) {
+		t.Errorf("expected error %v, got %v", ErrInvalidPostgresReplica, err)
+	}
+}
+
+func TestConfig_ValidateAndSetDefaultsWithConnectionPool(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		cfg         *Config
+		expectedErr error
+	}{
+		{
+			name: "postgres",
+			cfg:  &Config{Type: TypePostgres, Path: "postgres://localhost", MaxOpenConns: 20, MaxIdleConns: 10, ConnMaxLifetime: time.Hour},
+		},
+		{
+			name: "sqlite",
+			cfg:  &Config{Type: TypeSQLite, Path: "data.db", MaxOpenConns: 1, ConnMaxLifetime: time.Hour},
+		},
+		{
+			name:        "sqlite-with-multiple-open-conns",
+			cfg:         &Config{Type: TypeSQLite, Path: "data.db", MaxOpenConns: 2},
+			expectedErr: ErrInvalidConnectionPool,
+		},
+		{
+			name:        "negative",
+			cfg:         &Config{Type: TypePostgres, Path: "postgres://localhost", MaxIdleConns: -1},
+			expectedErr: ErrInvalidConnectionPool,
+		},
+		{
+			name:        "memory",
+			cfg:         &Config{Type: TypeMemory, MaxOpenConns: 10},
+			expectedErr: ErrConnectionPoolNotSupported,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.ValidateAndSetDefaults(); !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestConfig_ValidateAndSetDefaultsWithWriteBuffer(t *testing.T) {
+	if err := (&Config{Type: TypeSQLite, Path: "data.db", WriteBuffer: &WriteBufferConfig{BatchSize: 50}}).ValidateAndSetDefaults(); err != nil {
+		t.Error("expected no error, got", err.Error())
+	}
+	if err := (&Config{Type: TypePostgres, Path: "postgres://localhost", WriteBuffer: &WriteBufferConfig{FlushInterval: -time.Second}}).ValidateAndSetDefaults(); !errors.Is(err, ErrInvalidWriteBuffer) {
+		t.Errorf("expected error %v, got %v", ErrInvalidWriteBuffer, err)
+	}
+	if err := (&Config{Type: TypeMemory, WriteBuffer: &WriteBufferConfig{}}).ValidateAndSetDefaults(); !errors.Is(err, ErrWriteBufferNotSupported) {
+		t.Errorf("expected error %v, got %v", ErrWriteBufferNotSupported, err)
+	}
+}
+
+func TestConfig_ValidateAndSetDefaultsWithMemorySnapshot(t *testing.T) {
+	if err := (&Config{Type: TypeMemory, Path: "snapshot.gob", Memory: &MemoryConfig{SnapshotInterval: time.Minute}}).ValidateAndSetDefaults(); err != nil {
+		t.Error("expected no error, got", err.Error())
+	}
+	if err := (&Config{Type: TypeMemory, Path: "snapshot.gob", Memory: &MemoryConfig{SnapshotInterval: -time.Minute}}).ValidateAndSetDefaults(); !errors.Is(err, ErrInvalidMemoryConfig) {
+		t.Errorf("expected error %v, got %v", ErrInvalidMemoryConfig, err)
+	}
+}
+
+func TestConfig_ValidateAndSetDefaultsWithExternal(t *testing.T) {
+	cfg := &Config{Type: TypeExternal, Path: "unix:///var/run/gatus-storage.sock"}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Error("expected no error, got", err.Error())
+	}
+	if cfg.External == nil {
+		t.Error("expected external config to have been set")
+	}
+	if err := (&Config{Type: TypeExternal}).ValidateAndSetDefaults(); !errors.Is(err, ErrExternalStorageRequiresPath) {
+		t.Errorf("expected error %v, got %v", ErrExternalStorageRequiresPath, err)
+	}
+	if err := (&Config{Type: TypeExternal, Path: "localhost:50051", External: &ExternalConfig{Timeout: -time.Second}}).ValidateAndSetDefaults(); !errors.Is(err, ErrInvalidExternalConfig) {
+		t.Errorf("expected error %v, got %v", ErrInvalidExternalConfig, err)
+	}
+	if err := (&Config{Type: TypeExternal, Path: "localhost:50051", Retention: []*RetentionConfig{{MaximumResults: 10}}}).ValidateAndSetDefaults(); !errors.Is(err, ErrRetentionNotSupported) {
+		t.Errorf("expected error %v, got %v", ErrRetentionNotSupported, err)
+	}
+}
@@ -0,0 +1,477 @@
+package clickhouse
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+)
+
+var (
+	testEndpoint = endpoint.Endpoint{
+		Name:       "name",
+		Group:      "group",
+		URL:        "https://example.org/what/ever",
+		Conditions: []endpoint.Condition{"[STATUS] == 200"},
+	}
+	testTimestamp = time.Date(2024, 1, 2, 3, 4, 5, 678000000, time.UTC)
+)
+
+// fakeClickHouse is a fake of the HTTP interface of ClickHouse, which records the queries it receives and the rows
+// inserted, and responds to each SELECT with the response whose key is contained by the query
+type fakeClickHouse struct {
+	mutex     sync.Mutex
+	queries   []string
+	inserted  map[string][]map[string]any
+	responses map[string]string
+	failures  int
+	username  string
+	password  string
+	database  string
+}
+
+func newFakeClickHouse(t *testing.T) (*fakeClickHouse, *httptest.Server) {
+	fake := &fakeClickHouse{inserted: make(map[string][]map[string]any), responses: make(map[string]string)}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fake.mutex.Lock()
+		defer fake.mutex.Unlock()
+		fake.username, fake.password, _ = r.BasicAuth()
+		fake.database = r.URL.Query().Get("database")
+		if fake.failures > 0 {
+			fake.failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte("Code: 242. DB::Exception: Table is in readonly mode"))
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		query := string(body)
+		if r.URL.Query().Has("query") {
+			query = r.URL.Query().Get("query")
+			table := strings.Fields(strings.TrimPrefix(query, "INSERT INTO "))[0]
+			scanner := bufio.NewScanner(strings.NewReader(string(body)))
+			for scanner.Scan() {
+				row := make(map[string]any)
+				if err := json.Unmarshal(scanner.Bytes(), &row); err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				fake.inserted[table] = append(fake.inserted[table], row)
+			}
+		}
+		fake.queries = append(fake.queries, query)
+		for key, response := range fake.responses {
+			if strings.Contains(query, key) {
+				_, _ = w.Write([]byte(response))
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return fake, server
+}
+
+func (fake *fakeClickHouse) Queries() []string {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	return append([]string(nil), fake.queries...)
+}
+
+func (fake *fakeClickHouse) Inserted(table string) []map[string]any {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	return fake.inserted[table]
+}
+
+func (fake *fakeClickHouse) SetResponse(key, response string) {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	fake.responses[key] = response
+}
+
+func (fake *fakeClickHouse) SetFailures(failures int) {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	fake.failures = failures
+}
+
+func TestNewStore(t *testing.T) {
+	if _, err := NewStore("", 0, 0, 0); !errors.Is(err, ErrPathNotSpecified) {
+		t.Error("expected error", ErrPathNotSpecified, "got", err)
+	}
+	if _, err := NewStore("tcp://localhost:9000", 0, 0, 0); !errors.Is(err, ErrInvalidPath) {
+		t.Error("expected error", ErrInvalidPath, "got", err)
+	}
+	fake, server := newFakeClickHouse(t)
+	store, err := NewStore(strings.Replace(server.URL, "http://", "http://gatus:secret@", 1)+"/monitoring", 0, 0, 30*24*time.Hour)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	defer store.Close()
+	if store.batchSize != DefaultBatchSize {
+		t.Errorf("expected batch size to default to %d, got %d", DefaultBatchSize, store.batchSize)
+	}
+	if store.flushInterval != DefaultFlushInterval {
+		t.Errorf("expected flush interval to default to %s, got %s", DefaultFlushInterval, store.flushInterval)
+	}
+	if fake.username != "gatus" || fake.password != "secret" {
+		t.Errorf("expected credentials of the path to be used, got username=%s and password=%s", fake.username, fake.password)
+	}
+	if fake.database != "monitoring" {
+		t.Errorf("expected database to be monitoring, got %s", fake.database)
+	}
+	queries := strings.Join(fake.Queries(), "\n")
+	for _, table := range []string{"endpoints", "endpoint_results", "endpoint_events", "endpoint_alerts_triggered"} {
+		if !strings.Contains(queries, "CREATE TABLE IF NOT EXISTS "+table+" ") {
+			t.Errorf("expected table %s to be created", table)
+		}
+	}
+	if !strings.Contains(queries, "ALTER TABLE endpoint_results MODIFY TTL toDateTime(timestamp) + toIntervalSecond(2592000)") {
+		t.Error("expected retention of results to be updated")
+	}
+}
+
+func TestNewStore_WithDefaultDatabase(t *testing.T) {
+	fake, server := newFakeClickHouse(t)
+	store, err := NewStore(server.URL, 0, 0, 0)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	defer store.Close()
+	if fake.database != "default" {
+		t.Errorf("expected database to be default, got %s", fake.database)
+	}
+}
+
+func TestNewStore_WithError(t *testing.T) {
+	fake, server := newFakeClickHouse(t)
+	fake.SetFailures(1)
+	_, err := NewStore(server.URL, 0, 0, 0)
+	if err == nil || !strings.Contains(err.Error(), "status 503") || !strings.Contains(err.Error(), "readonly mode") {
+		t.Error("expected error from clickhouse to be returned, got", err)
+	}
+}
+
+func TestNewStore_LoadsEndpoints(t *testing.T) {
+	fake, server := newFakeClickHouse(t)
+	fake.SetResponse("FROM endpoints FINAL", `{"endpoint_key":"group_name","endpoint_name":"name","endpoint_group":"group"}`+"\n")
+	store, err := NewStore(server.URL, 0, 0, 0)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	defer store.Close()
+	endpointStatus, err := store.GetEndpointStatusByKey("group_name", paging.NewEndpointStatusParams())
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if endpointStatus.Name != "name" || endpointStatus.Group != "group" {
+		t.Errorf("expected endpoint status of group_name, got name=%s and group=%s", endpointStatus.Name, endpointStatus.Group)
+	}
+}
+
+func TestStore_InsertWithBatching(t *testing.T) {
+	fake, server := newFakeClickHouse(t)
+	store, err := NewStore(server.URL, 2, time.Hour, 0)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	defer store.Close()
+	firstResult := &endpoint.Result{
+		Success:          true,
+		Connected:        true,
+		HTTPStatus:       200,
+		Duration:         150 * time.Millisecond,
+		Timestamp:        testTimestamp,
+		ConditionResults: []*endpoint.ConditionResult{{Condition: "[STATUS] == 200", Success: true}},
+	}
+	if err = store.Insert(&testEndpoint, firstResult); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if endpoints := fake.Inserted("endpoints"); len(endpoints) != 1 || endpoints[0]["endpoint_key"] != "group_name" {
+		t.Errorf("expected endpoint to be inserted right away, got %v", endpoints)
+	}
+	if results := fake.Inserted("endpoint_results"); len(results) != 0 {
+		t.Errorf("expected result to be buffered, got %d results inserted", len(results))
+	}
+	secondResult := &endpoint.Result{Success: false, Errors: []string{"error-1"}, Timestamp: testTimestamp.Add(time.Minute)}
+	if err = store.Insert(&testEndpoint, secondResult); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if endpoints := fake.Inserted("endpoints"); len(endpoints) != 1 {
+		t.Errorf("expected endpoint to only be inserted once, got %d", len(endpoints))
+	}
+	results := fake.Inserted("endpoint_results")
+	if len(results) != 2 {
+		t.Fatalf("expected batch of 2 results to be inserted, got %d", len(results))
+	}
+	if results[0]["timestamp"] != "2024-01-02 03:04:05.678" {
+		t.Errorf("expected timestamp to be sent in UTC, got %v", results[0]["timestamp"])
+	}
+	if results[0]["duration"] != float64(150*time.Millisecond) {
+		t.Errorf("expected duration to be sent in nanoseconds, got %v", results[0]["duration"])
+	}
+	if errs, ok := results[0]["errors"].([]any); !ok || len(errs) != 0 {
+		t.Errorf("expected errors to be an empty array, got %v", results[0]["errors"])
+	}
+	if conditions, ok := results[0]["conditions"].([]any); !ok || len(conditions) != 1 || conditions[0] != "[STATUS] == 200" {
+		t.Errorf("expected conditions to be inserted, got %v", results[0]["conditions"])
+	}
+	events := fake.Inserted("endpoint_events")
+	if len(events) != 3 {
+		t.Fatalf("expected 3 events to be inserted, got %d", len(events))
+	}
+	for i, expectedType := range []endpoint.EventType{endpoint.EventStart, endpoint.EventHealthy, endpoint.EventUnhealthy} {
+		if events[i]["event_type"] != string(expectedType) {
+			t.Errorf("expected event #%d to be of type %s, got %v", i, expectedType, events[i]["event_type"])
+		}
+	}
+	if events[0]["event_timestamp"] != "2024-01-02 03:04:05.628" {
+		t.Errorf("expected start event to precede the first result, got %v", events[0]["event_timestamp"])
+	}
+	// A result with the same outcome as the previous one doesn't create a new event
+	if err = store.Insert(&testEndpoint, &endpoint.Result{Success: false, Timestamp: testTimestamp.Add(2 * time.Minute)}); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if err = store.Save(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if results = fake.Inserted("endpoint_results"); len(results) != 3 {
+		t.Errorf("expected buffered result to be inserted on save, got %d results", len(results))
+	}
+	if events = fake.Inserted("endpoint_events"); len(events) != 3 {
+		t.Errorf("expected no new event, got %d events", len(events))
+	}
+}
+
+func TestStore_InsertWithExistingResults(t *testing.T) {
+	fake, server := newFakeClickHouse(t)
+	fake.SetResponse("SELECT success\n", `{"success":true}`+"\n")
+	store, err := NewStore(server.URL, 1, time.Hour, 0)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	defer store.Close()
+	if err = store.Insert(&testEndpoint, &endpoint.Result{Success: true, Timestamp: testTimestamp}); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if events := fake.Inserted("endpoint_events"); len(events) != 0 {
+		t.Errorf("expected no event since the last result was also successful, got %v", events)
+	}
+	if err = store.Insert(&testEndpoint, &endpoint.Result{Success: false, Timestamp: testTimestamp.Add(time.Minute)}); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if events := fake.Inserted("endpoint_events"); len(events) != 1 || events[0]["event_type"] != string(endpoint.EventUnhealthy) {
+		t.Errorf("expected unhealthy event, got %v", events)
+	}
+	var lastResultQueries int
+	for _, query := range fake.Queries() {
+		if strings.Contains(query, "SELECT success\n") {
+			lastResultQueries++
+		}
+	}
+	if lastResultQueries != 1 {
+		t.Errorf("expected the last result to be queried once, got %d", lastResultQueries)
+	}
+}
+
+func TestStore_FlushWithError(t *testing.T) {
+	fake, server := newFakeClickHouse(t)
+	store, err := NewStore(server.URL, 1, time.Hour, 0)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	defer store.Close()
+	if err = store.Insert(&testEndpoint, &endpoint.Result{Success: true, Timestamp: testTimestamp}); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	fake.SetFailures(1)
+	if err = store.Insert(&testEndpoint, &endpoint.Result{Success: true, Timestamp: testTimestamp.Add(time.Minute)}); err == nil {
+		t.Error("expected error")
+	}
+	if len(store.pendingResults) != 1 {
+		t.Errorf("expected result to be kept in the buffer, got %d", len(store.pendingResults))
+	}
+	if err = store.Save(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(store.pendingResults) != 0 {
+		t.Errorf("expected buffer to be empty, got %d", len(store.pendingResults))
+	}
+	if results := fake.Inserted("endpoint_results"); len(results) != 2 {
+		t.Errorf("expected 2 results to have been inserted, got %d", len(results))
+	}
+}
+
+func TestStore_FlushPeriodically(t *testing.T) {
+	fake, server := newFakeClickHouse(t)
+	store, err := NewStore(server.URL, 100, 10*time.Millisecond, 0)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	defer store.Close()
+	if err = store.Insert(&testEndpoint, &endpoint.Result{Success: true, Timestamp: testTimestamp}); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	for i := 0; i < 100 && len(fake.Inserted("endpoint_results")) == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if results := fake.Inserted("endpoint_results"); len(results) != 1 {
+		t.Errorf("expected result to be inserted once the flush interval elapsed, got %d", len(results))
+	}
+}
+
+func TestStore_GetEndpointStatusByKey(t *testing.T) {
+	fake, server := newFakeClickHouse(t)
+	fake.SetResponse("FROM endpoints FINAL", `{"endpoint_key":"group_name","endpoint_name":"name","endpoint_group":"group"}`+"\n")
+	fake.SetResponse("FROM endpoint_results\n\t\tWHERE endpoint_key = {key:String}\n\t\tORDER BY timestamp DESC\n\t\tLIMIT {limit:UInt64}", strings.Join([]string{
+		`{"success":false,"errors":["error-1"],"connected":true,"status":500,"dns_rcode":"","certificate_expiration":0,"domain_expiration":0,"hostname":"example.org","ip":"127.0.0.1","duration":750000000,"metadata":{"version":"1.2.3"},"timestamp":"2024-01-02 03:05:05.678","conditions":["[STATUS] == 200"],"condition_successes":[false]}`,
+		`{"success":true,"errors":[],"connected":true,"status":200,"dns_rcode":"","certificate_expiration":36000000000000,"domain_expiration":0,"hostname":"example.org","ip":"127.0.0.1","duration":150000000,"metadata":{},"timestamp":"2024-01-02 03:04:05.678","conditions":["[STATUS] == 200"],"condition_successes":[true]}`,
+	}, "\n")+"\n")
+	fake.SetResponse("FROM endpoint_events", `{"event_type":"UNHEALTHY","event_timestamp":"2024-01-02 03:05:05.678"}`+"\n"+`{"event_type":"START","event_timestamp":"2024-01-02 03:04:05.628"}`+"\n")
+	store, err := NewStore(server.URL, 0, 0, 0)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	defer store.Close()
+	endpointStatus, err := store.GetEndpointStatusByKey("group_name", paging.NewEndpointStatusParams().WithResults(1, 20).WithEvents(1, 50))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(endpointStatus.Results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(endpointStatus.Results))
+	}
+	oldestResult, newestResult := endpointStatus.Results[0], endpointStatus.Results[1]
+	if !oldestResult.Success || oldestResult.Errors != nil || oldestResult.Metadata != nil || oldestResult.CertificateExpiration != 10*time.Hour || oldestResult.Duration != 150*time.Millisecond {
+		t.Errorf("expected oldest result to be first, got %+v", oldestResult)
+	}
+	if !oldestResult.Timestamp.Equal(testTimestamp) {
+		t.Errorf("expected timestamp to be %s, got %s", testTimestamp, oldestResult.Timestamp)
+	}
+	if newestResult.Success || len(newestResult.Errors) != 1 || newestResult.HTTPStatus != 500 || newestResult.Metadata["version"] != "1.2.3" {
+		t.Errorf("expected newest result to be last, got %+v", newestResult)
+	}
+	if len(newestResult.ConditionResults) != 1 || newestResult.ConditionResults[0].Condition != "[STATUS] == 200" || newestResult.ConditionResults[0].Success {
+		t.Errorf("expected failed condition result, got %v", newestResult.ConditionResults)
+	}
+	if len(endpointStatus.Events) != 2 || endpointStatus.Events[0].Type != endpoint.EventStart || endpointStatus.Events[1].Type != endpoint.EventUnhealthy {
+		t.Errorf("expected events in chronological order, got %v", endpointStatus.Events)
+	}
+	if _, err = store.GetEndpointStatusByKey("group_other", paging.NewEndpointStatusParams()); !errors.Is(err, common.ErrEndpointNotFound) {
+		t.Error("expected error", common.ErrEndpointNotFound, "got", err)
+	}
+	if endpointStatuses, _ := store.GetAllEndpointStatuses(paging.NewEndpointStatusParams()); len(endpointStatuses) != 1 {
+		t.Errorf("expected 1 endpoint status, got %d", len(endpointStatuses))
+	}
+}
+
+func TestStore_GetUptimeAndAverageResponseTime(t *testing.T) {
+	fake, server := newFakeClickHouse(t)
+	fake.SetResponse("FROM endpoints FINAL", `{"endpoint_key":"group_name","endpoint_name":"name","endpoint_group":"group"}`+"\n")
+	fake.SetResponse("countIf(success)", `{"total":4,"successful":3,"total_duration":1000000000}`+"\n")
+	fake.SetResponse("toStartOfHour", `{"hour":1704164400,"total":2,"total_duration":300000000}`+"\n"+`{"hour":1704168000,"total":1,"total_duration":750000000}`+"\n")
+	store, err := NewStore(server.URL, 0, 0, 0)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	defer store.Close()
+	from, to := testTimestamp.Add(-time.Hour), testTimestamp
+	if uptime, _ := store.GetUptimeByKey("group_name", from, to); uptime != 0.75 {
+		t.Errorf("expected uptime to be 0.75, got %f", uptime)
+	}
+	if averageResponseTime, _ := store.GetAverageResponseTimeByKey("group_name", from, to); averageResponseTime != 250 {
+		t.Errorf("expected average response time to be 250ms, got %d", averageResponseTime)
+	}
+	hourlyAverageResponseTimes, err := store.GetHourlyAverageResponseTimeByKey("group_name", from, to)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(hourlyAverageResponseTimes) != 2 || hourlyAverageResponseTimes[1704164400] != 150 || hourlyAverageResponseTimes[1704168000] != 750 {
+		t.Errorf("expected hourly average response times, got %v", hourlyAverageResponseTimes)
+	}
+	if _, err = store.GetUptimeByKey("group_name", to, from); !errors.Is(err, common.ErrInvalidTimeRange) {
+		t.Error("expected error", common.ErrInvalidTimeRange, "got", err)
+	}
+	if _, err = store.GetAverageResponseTimeByKey("group_other", from, to); !errors.Is(err, common.ErrEndpointNotFound) {
+		t.Error("expected error", common.ErrEndpointNotFound, "got", err)
+	}
+	if _, err = store.GetHourlyAverageResponseTimeByKey("group_other", from, to); !errors.Is(err, common.ErrEndpointNotFound) {
+		t.Error("expected error", common.ErrEndpointNotFound, "got", err)
+	}
+}
+
+func TestStore_DeleteAllEndpointStatusesNotInKeys(t *testing.T) {
+	fake, server := newFakeClickHouse(t)
+	fake.SetResponse("FROM endpoints FINAL", `{"endpoint_key":"group_name","endpoint_name":"name","endpoint_group":"group"}`+"\n"+`{"endpoint_key":"group_it's-gone","endpoint_name":"it's-gone","endpoint_group":"group"}`+"\n")
+	store, err := NewStore(server.URL, 0, 0, 0)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	defer store.Close()
+	if deleted := store.DeleteAllEndpointStatusesNotInKeys([]string{"group_name"}); deleted != 1 {
+		t.Errorf("expected 1 endpoint to be deleted, got %d", deleted)
+	}
+	queries := strings.Join(fake.Queries(), "\n")
+	if !strings.Contains(queries, `ALTER TABLE endpoint_results DELETE WHERE endpoint_key IN ('group_it\'s-gone')`) {
+		t.Errorf("expected results of the endpoint to be deleted, got queries:\n%s", queries)
+	}
+	if _, err = store.GetEndpointStatusByKey("group_it's-gone", paging.NewEndpointStatusParams()); !errors.Is(err, common.ErrEndpointNotFound) {
+		t.Error("expected error", common.ErrEndpointNotFound, "got", err)
+	}
+	if deleted := store.DeleteAllEndpointStatusesNotInKeys([]string{"group_name"}); deleted != 0 {
+		t.Errorf("expected no endpoint to be deleted, got %d", deleted)
+	}
+}
+
+func TestStore_TriggeredEndpointAlerts(t *testing.T) {
+	fake, server := newFakeClickHouse(t)
+	store, err := NewStore(server.URL, 0, 0, 0)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	defer store.Close()
+	ep := testEndpoint
+	ep.NumberOfSuccessesInARow = 2
+	firstAlert := &alert.Alert{Type: alert.TypePagerDuty, ResolveKey: "resolve-key"}
+	secondAlert := &alert.Alert{Type: alert.TypeSlack}
+	if exists, _, _, err := store.GetTriggeredEndpointAlert(&ep, firstAlert); exists || err != nil {
+		t.Error("expected no triggered alert, got exists =", exists, "and err =", err)
+	}
+	if err = store.UpsertTriggeredEndpointAlert(&ep, firstAlert); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if err = store.UpsertTriggeredEndpointAlert(&ep, firstAlert); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	inserted := fake.Inserted("endpoint_alerts_triggered")
+	if len(inserted) != 2 || inserted[0]["resolve_key"] != "resolve-key" || inserted[0]["number_of_successes_in_a_row"] != float64(2) {
+		t.Fatalf("expected triggered alert to be inserted, got %v", inserted)
+	}
+	if inserted[1]["version"].(float64) <= inserted[0]["version"].(float64) {
+		t.Error("expected version of each row to be greater than the previous one")
+	}
+	fake.SetResponse("argMax(resolve_key, version)", `{"configuration_checksum":"`+firstAlert.Checksum()+`","resolve_key":"resolve-key","number_of_successes_in_a_row":2}`+"\n"+`{"configuration_checksum":"`+secondAlert.Checksum()+`","resolve_key":"","number_of_successes_in_a_row":0}`+"\n")
+	exists, resolveKey, numberOfSuccessesInARow, err := store.GetTriggeredEndpointAlert(&ep, firstAlert)
+	if !exists || resolveKey != "resolve-key" || numberOfSuccessesInARow != 2 || err != nil {
+		t.Error("expected triggered alert, got exists =", exists, "resolveKey =", resolveKey, "numberOfSuccessesInARow =", numberOfSuccessesInARow, "and err =", err)
+	}
+	if deleted := store.DeleteAllTriggeredAlertsNotInChecksumsByEndpoint(&ep, []string{firstAlert.Checksum()}); deleted != 1 {
+		t.Errorf("expected 1 triggered alert to be deleted, got %d", deleted)
+	}
+	if err = store.DeleteTriggeredEndpointAlert(&ep, firstAlert); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	inserted = fake.Inserted("endpoint_alerts_triggered")
+	if len(inserted) != 4 || inserted[2]["configuration_checksum"] != secondAlert.Checksum() || inserted[2]["deleted"] != true || inserted[3]["deleted"] != true {
+		t.Errorf("expected tombstones to be inserted, got %v", inserted)
+	}
+}
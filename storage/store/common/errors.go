@@ -3,6 +3,11 @@ package common
 import "errors"
 
 var (
-	ErrEndpointNotFound = errors.New("endpoint not found")               // When an endpoint does not exist in the store
-	ErrInvalidTimeRange = errors.New("'from' cannot be older than 'to'") // When an invalid time range is provided
+	ErrEndpointNotFound  = errors.New("endpoint not found")                  // When an endpoint does not exist in the store
+	ErrInvalidTimeRange  = errors.New("'from' cannot be older than 'to'")    // When an invalid time range is provided
+	ErrInvalidResolution = errors.New("resolution must be either 1h or 24h") // When an invalid aggregate resolution is provided
+
+	ErrAnnouncementNotFound = errors.New("announcement not found") // When an announcement does not exist in the store
+	ErrSubscriptionNotFound = errors.New("subscription not found") // When a subscription does not exist in the store, or can no longer be confirmed
+	ErrTokenNotFound        = errors.New("token not found")        // When an API token does not exist in the store
 )
@@ -0,0 +1,25 @@
+package common
+
+import "time"
+
+// RetentionPolicy is how many results and events of an endpoint are kept, and for how long
+type RetentionPolicy struct {
+	// MaximumNumberOfResults is the maximum number of results kept
+	MaximumNumberOfResults int
+
+	// MaximumNumberOfEvents is the maximum number of events kept
+	MaximumNumberOfEvents int
+
+	// MaximumAge is the age beyond which results and events are deleted, regardless of how many there are.
+	// If 0, results and events are only deleted when there are too many of them.
+	MaximumAge time.Duration
+}
+
+// DefaultRetentionPolicy is the retention policy of the endpoints for which no retention policy is configured
+var DefaultRetentionPolicy = RetentionPolicy{
+	MaximumNumberOfResults: MaximumNumberOfResults,
+	MaximumNumberOfEvents:  MaximumNumberOfEvents,
+}
+
+// RetentionPolicyFunc returns the retention policy of the endpoint with the given group and name
+type RetentionPolicyFunc func(group, name string) RetentionPolicy
@@ -0,0 +1,381 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/metrics"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+	"github.com/TwiN/gatus/v5/storage/store/external/storagepb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// DefaultTimeout is the maximum duration of each call to the storage plugin by default
+	DefaultTimeout = 10 * time.Second
+
+	// AddressEnvironmentVariable is the environment variable through which the address at which the storage plugin
+	// must serve the contract is passed to the storage plugin started by the store
+	AddressEnvironmentVariable = "GATUS_STORAGE_EXTERNAL_ADDRESS"
+
+	// connectTimeout is the maximum duration during which the store waits for the storage plugin to be reachable
+	connectTimeout = 30 * time.Second
+
+	// stopTimeout is the maximum duration during which the store waits for the storage plugin it started to stop
+	// after being interrupted, beyond which it's killed
+	stopTimeout = 5 * time.Second
+
+	// maximumMessageSize is the maximum size of the messages received from the storage plugin, which is much larger
+	// than the default of gRPC because the statuses of all endpoints are returned at once
+	maximumMessageSize = 256 << 20
+)
+
+var (
+	// ErrAddressNotSpecified is the error returned when the address parameter passed in NewStore is blank
+	ErrAddressNotSpecified = errors.New("address cannot be empty")
+
+	// ErrPluginExited is the error returned when the storage plugin started by the store exits before being reachable
+	ErrPluginExited = errors.New("storage plugin exited before being reachable")
+)
+
+// Store that proxies every operation to a storage plugin, which is a gRPC server implementing the contract of the
+// storagepb package.
+//
+// The storage plugin is either already running, or started by the store, in which case it's stopped when the store is
+// closed.
+type Store struct {
+	connection *grpc.ClientConn
+	client     storagepb.StoreClient
+	timeout    time.Duration
+
+	plugin        *exec.Cmd
+	pluginExited  chan struct{}
+	pluginStopped atomic.Bool
+}
+
+// NewStore creates a new store connected to the storage plugin at the address passed as parameter, which is either
+// a unix socket (e.g. unix:///var/run/gatus-storage.sock) or a host and a port (e.g. localhost:50051).
+//
+// If command isn't empty, the storage plugin is started with it first, and the address is passed to the storage
+// plugin through the AddressEnvironmentVariable environment variable.
+// If timeout is 0, DefaultTimeout is used.
+func NewStore(address string, command []string, timeout time.Duration) (*Store, error) {
+	if len(address) == 0 {
+		return nil, ErrAddressNotSpecified
+	}
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	store := &Store{timeout: timeout}
+	ctx, cancel := context.WithTimeout(context.Background(), connectTimeout)
+	defer cancel()
+	if len(command) > 0 {
+		if err := store.startPlugin(address, command); err != nil {
+			return nil, err
+		}
+		go func() {
+			// Stop waiting for the storage plugin if it exits before being reachable
+			select {
+			case <-store.pluginExited:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+	}
+	connection, err := grpc.DialContext(ctx, address,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maximumMessageSize)),
+		grpc.WithBlock(),
+	)
+	if err != nil {
+		if store.plugin != nil {
+			select {
+			case <-store.pluginExited:
+				err = ErrPluginExited
+			default:
+			}
+			store.stopPlugin()
+		}
+		return nil, fmt.Errorf("failed to connect to storage plugin at %s: %w", address, err)
+	}
+	store.connection = connection
+	store.client = storagepb.NewStoreClient(connection)
+	return store, nil
+}
+
+// startPlugin starts the storage plugin with the command passed as parameter
+func (s *Store) startPlugin(address string, command []string) error {
+	s.plugin = exec.Command(command[0], command[1:]...)
+	s.plugin.Env = append(os.Environ(), AddressEnvironmentVariable+"="+address)
+	s.plugin.Stdout = os.Stdout
+	s.plugin.Stderr = os.Stderr
+	if err := s.plugin.Start(); err != nil {
+		return fmt.Errorf("failed to start storage plugin: %w", err)
+	}
+	s.pluginExited = make(chan struct{})
+	go func() {
+		if err := s.plugin.Wait(); err != nil && !s.pluginStopped.Load() {
+			log.Printf("[external.startPlugin] Storage plugin exited: %s", err.Error())
+		}
+		close(s.pluginExited)
+	}()
+	return nil
+}
+
+// stopPlugin interrupts the storage plugin started by the store, and kills it if it doesn't stop in time
+func (s *Store) stopPlugin() {
+	s.pluginStopped.Store(true)
+	if err := s.plugin.Process.Signal(os.Interrupt); err != nil {
+		_ = s.plugin.Process.Kill()
+	}
+	select {
+	case <-s.pluginExited:
+	case <-time.After(stopTimeout):
+		log.Printf("[external.stopPlugin] Storage plugin did not stop within %s, killing it", stopTimeout)
+		_ = s.plugin.Process.Kill()
+		<-s.pluginExited
+	}
+}
+
+// context returns the context of a call to the storage plugin
+func (s *Store) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), s.timeout)
+}
+
+// GetAllEndpointStatuses returns all monitored endpoint.Status
+// with a subset of endpoint.Result defined by the page and pageSize parameters
+func (s *Store) GetAllEndpointStatuses(params *paging.EndpointStatusParams) (_ []*endpoint.Status, err error) {
+	defer func(start time.Time) {
+		metrics.ObserveStoreOperation("external", "GetAllEndpointStatuses", start, err == nil)
+	}(time.Now())
+	ctx, cancel := s.context()
+	defer cancel()
+	response, err := s.client.GetAllEndpointStatuses(ctx, &storagepb.GetAllEndpointStatusesRequest{Paging: storagepb.FromPaging(params)})
+	if err != nil {
+		return nil, convertError(err)
+	}
+	statuses := make([]*endpoint.Status, 0, len(response.GetStatuses()))
+	for _, status := range response.GetStatuses() {
+		statuses = append(statuses, status.ToStatus())
+	}
+	return statuses, nil
+}
+
+// GetEndpointStatus returns the endpoint status for a given endpoint name in the given group
+func (s *Store) GetEndpointStatus(groupName, endpointName string, params *paging.EndpointStatusParams) (*endpoint.Status, error) {
+	return s.GetEndpointStatusByKey(endpoint.ConvertGroupAndEndpointNameToKey(groupName, endpointName), params)
+}
+
+// GetEndpointStatusByKey returns the endpoint status for a given key
+func (s *Store) GetEndpointStatusByKey(key string, params *paging.EndpointStatusParams) (_ *endpoint.Status, err error) {
+	defer func(start time.Time) {
+		metrics.ObserveStoreOperation("external", "GetEndpointStatusByKey", start, err == nil)
+	}(time.Now())
+	ctx, cancel := s.context()
+	defer cancel()
+	response, err := s.client.GetEndpointStatusByKey(ctx, &storagepb.GetEndpointStatusByKeyRequest{Key: key, Paging: storagepb.FromPaging(params)})
+	if err != nil {
+		return nil, convertError(err)
+	}
+	if response.GetStatus() == nil {
+		return nil, common.ErrEndpointNotFound
+	}
+	return response.GetStatus().ToStatus(), nil
+}
+
+// GetUptimeByKey returns the uptime percentage during a time range
+func (s *Store) GetUptimeByKey(key string, from, to time.Time) (_ float64, err error) {
+	defer func(start time.Time) { metrics.ObserveStoreOperation("external", "GetUptimeByKey", start, err == nil) }(time.Now())
+	if from.After(to) {
+		return 0, common.ErrInvalidTimeRange
+	}
+	ctx, cancel := s.context()
+	defer cancel()
+	response, err := s.client.GetUptimeByKey(ctx, newTimeRangeRequest(key, from, to))
+	if err != nil {
+		return 0, convertError(err)
+	}
+	return response.GetUptime(), nil
+}
+
+// GetAverageResponseTimeByKey returns the average response time in milliseconds (value) during a time range
+func (s *Store) GetAverageResponseTimeByKey(key string, from, to time.Time) (_ int, err error) {
+	defer func(start time.Time) {
+		metrics.ObserveStoreOperation("external", "GetAverageResponseTimeByKey", start, err == nil)
+	}(time.Now())
+	if from.After(to) {
+		return 0, common.ErrInvalidTimeRange
+	}
+	ctx, cancel := s.context()
+	defer cancel()
+	response, err := s.client.GetAverageResponseTimeByKey(ctx, newTimeRangeRequest(key, from, to))
+	if err != nil {
+		return 0, convertError(err)
+	}
+	return int(response.GetAverageResponseTimeMilliseconds()), nil
+}
+
+// GetHourlyAverageResponseTimeByKey returns a map of hourly (key) average response time in milliseconds (value) during a time range
+func (s *Store) GetHourlyAverageResponseTimeByKey(key string, from, to time.Time) (_ map[int64]int, err error) {
+	defer func(start time.Time) {
+		metrics.ObserveStoreOperation("external", "GetHourlyAverageResponseTimeByKey", start, err == nil)
+	}(time.Now())
+	if from.After(to) {
+		return nil, common.ErrInvalidTimeRange
+	}
+	ctx, cancel := s.context()
+	defer cancel()
+	response, err := s.client.GetHourlyAverageResponseTimeByKey(ctx, newTimeRangeRequest(key, from, to))
+	if err != nil {
+		return nil, convertError(err)
+	}
+	hourlyAverageResponseTimes := make(map[int64]int, len(response.GetHourlyAverageResponseTimeMilliseconds()))
+	for hourlyUnixTimestamp, averageResponseTime := range response.GetHourlyAverageResponseTimeMilliseconds() {
+		hourlyAverageResponseTimes[hourlyUnixTimestamp] = int(averageResponseTime)
+	}
+	return hourlyAverageResponseTimes, nil
+}
+
+// Insert adds the observed result for the specified endpoint into the store
+func (s *Store) Insert(ep *endpoint.Endpoint, result *endpoint.Result) (err error) {
+	defer func(start time.Time) { metrics.ObserveStoreOperation("external", "Insert", start, err == nil) }(time.Now())
+	ctx, cancel := s.context()
+	defer cancel()
+	_, err = s.client.Insert(ctx, &storagepb.InsertRequest{Endpoint: storagepb.FromEndpoint(ep), Result: storagepb.FromResult(result)})
+	return convertError(err)
+}
+
+// DeleteAllEndpointStatusesNotInKeys removes all Status that are not within the keys provided
+func (s *Store) DeleteAllEndpointStatusesNotInKeys(keys []string) int {
+	ctx, cancel := s.context()
+	defer cancel()
+	response, err := s.client.DeleteAllEndpointStatusesNotInKeys(ctx, &storagepb.DeleteAllEndpointStatusesNotInKeysRequest{Keys: keys})
+	if err != nil {
+		log.Printf("[external.DeleteAllEndpointStatusesNotInKeys] Failed to delete endpoints that do not belong to any of keys=%v: %s", keys, err.Error())
+		return 0
+	}
+	return int(response.GetNumberOfDeleted())
+}
+
+// GetTriggeredEndpointAlert returns whether the triggered alert for the specified endpoint as well as the necessary information to resolve it
+func (s *Store) GetTriggeredEndpointAlert(ep *endpoint.Endpoint, alert *alert.Alert) (exists bool, resolveKey string, numberOfSuccessesInARow int, err error) {
+	ctx, cancel := s.context()
+	defer cancel()
+	response, err := s.client.GetTriggeredEndpointAlert(ctx, &storagepb.TriggeredEndpointAlertRequest{Endpoint: storagepb.FromEndpoint(ep), Checksum: alert.Checksum()})
+	if err != nil {
+		return false, "", 0, convertError(err)
+	}
+	return response.GetExists(), response.GetResolveKey(), int(response.GetNumberOfSuccessesInARow()), nil
+}
+
+// UpsertTriggeredEndpointAlert inserts/updates a triggered alert for an endpoint
+// Used for persistence of triggered alerts across application restarts
+func (s *Store) UpsertTriggeredEndpointAlert(ep *endpoint.Endpoint, triggeredAlert *alert.Alert) error {
+	ctx, cancel := s.context()
+	defer cancel()
+	_, err := s.client.UpsertTriggeredEndpointAlert(ctx, &storagepb.TriggeredEndpointAlert{
+		Endpoint:                storagepb.FromEndpoint(ep),
+		Checksum:                triggeredAlert.Checksum(),
+		ResolveKey:              triggeredAlert.ResolveKey,
+		NumberOfSuccessesInARow: int64(ep.NumberOfSuccessesInARow), // We only persist NumberOfSuccessesInARow, because all persisted alerts are already triggered
+	})
+	if err != nil {
+		log.Printf("[external.UpsertTriggeredEndpointAlert] Failed to persist triggered alert for endpoint with key=%s: %s", ep.Key(), err.Error())
+	}
+	return convertError(err)
+}
+
+// DeleteTriggeredEndpointAlert deletes a triggered alert for an endpoint
+func (s *Store) DeleteTriggeredEndpointAlert(ep *endpoint.Endpoint, triggeredAlert *alert.Alert) error {
+	ctx, cancel := s.context()
+	defer cancel()
+	_, err := s.client.DeleteTriggeredEndpointAlert(ctx, &storagepb.TriggeredEndpointAlertRequest{Endpoint: storagepb.FromEndpoint(ep), Checksum: triggeredAlert.Checksum()})
+	return convertError(err)
+}
+
+// DeleteAllTriggeredAlertsNotInChecksumsByEndpoint removes all triggered alerts owned by an endpoint whose alert
+// configurations are not provided in the checksums list.
+// This prevents triggered alerts that have been removed or modified from lingering in the database.
+func (s *Store) DeleteAllTriggeredAlertsNotInChecksumsByEndpoint(ep *endpoint.Endpoint, checksums []string) int {
+	ctx, cancel := s.context()
+	defer cancel()
+	response, err := s.client.DeleteAllTriggeredAlertsNotInChecksumsByEndpoint(ctx, &storagepb.DeleteAllTriggeredAlertsNotInChecksumsByEndpointRequest{Endpoint: storagepb.FromEndpoint(ep), Checksums: checksums})
+	if err != nil {
+		log.Printf("[external.DeleteAllTriggeredAlertsNotInChecksumsByEndpoint] Failed to delete triggered alerts for endpoint with key=%s that do not belong to any of checksums=%v: %s", ep.Key(), checksums, err.Error())
+		return 0
+	}
+	return int(response.GetNumberOfDeleted())
+}
+
+// Clear deletes everything from the store
+func (s *Store) Clear() {
+	ctx, cancel := s.context()
+	defer cancel()
+	if _, err := s.client.Clear(ctx, &emptypb.Empty{}); err != nil {
+		log.Printf("[external.Clear] Failed to clear storage plugin: %s", err.Error())
+	}
+}
+
+// Save asks the storage plugin to persist the data if and where it needs to be persisted
+func (s *Store) Save() (err error) {
+	defer func(start time.Time) { metrics.ObserveStoreOperation("external", "Save", start, err == nil) }(time.Now())
+	ctx, cancel := s.context()
+	defer cancel()
+	_, err = s.client.Save(ctx, &emptypb.Empty{})
+	return convertError(err)
+}
+
+// Ping checks that the storage plugin can be reached, by waiting for the connection to it to be ready
+func (s *Store) Ping(ctx context.Context) error {
+	s.connection.Connect()
+	for state := s.connection.GetState(); state != connectivity.Ready; state = s.connection.GetState() {
+		if state == connectivity.Shutdown || !s.connection.WaitForStateChange(ctx, state) {
+			return fmt.Errorf("storage plugin is unreachable: connection is %s", strings.ToLower(state.String()))
+		}
+	}
+	return nil
+}
+
+// Close closes the connection to the storage plugin, and stops the storage plugin if it was started by the store
+func (s *Store) Close() {
+	if s.connection != nil {
+		_ = s.connection.Close()
+	}
+	if s.plugin != nil && !s.pluginStopped.Load() {
+		s.stopPlugin()
+	}
+}
+
+func newTimeRangeRequest(key string, from, to time.Time) *storagepb.TimeRangeRequest {
+	return &storagepb.TimeRangeRequest{Key: key, From: timestamppb.New(from), To: timestamppb.New(to)}
+}
+
+// convertError converts the status codes of the contract returned by the storage plugin to the errors of the store
+func convertError(err error) error {
+	if err == nil {
+		return nil
+	}
+	switch status.Code(err) {
+	case codes.NotFound:
+		return common.ErrEndpointNotFound
+	case codes.InvalidArgument:
+		return common.ErrInvalidTimeRange
+	}
+	return err
+}
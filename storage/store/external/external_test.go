@@ -0,0 +1,386 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+	"github.com/TwiN/gatus/v5/storage/store/external/storagepb"
+	"github.com/TwiN/gatus/v5/storage/store/memory"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+var (
+	testEndpoint = endpoint.Endpoint{
+		Name:                    "name",
+		Group:                   "group",
+		URL:                     "https://example.org/what/ever",
+		Conditions:              []endpoint.Condition{"[STATUS] == 200"},
+		NumberOfSuccessesInARow: 2,
+	}
+	testTimestamp = time.Now()
+
+	testSuccessfulResult = endpoint.Result{
+		Hostname:  "example.org",
+		IP:        "127.0.0.1",
+		Connected: true,
+		Duration:  150 * time.Millisecond,
+		Success:   true,
+		Timestamp: testTimestamp,
+		ConditionResults: []*endpoint.ConditionResult{
+			{Condition: "[STATUS] == 200", Success: true},
+		},
+		CertificateExpiration: 10 * 24 * time.Hour,
+	}
+)
+
+// fakePlugin is a storage plugin backed by the memory store, except for the triggered alerts, which it keeps by the
+// key of their endpoint and their checksum
+type fakePlugin struct {
+	storagepb.UnimplementedStoreServer
+
+	store *memory.Store
+
+	mutex           sync.Mutex
+	triggeredAlerts map[string]*storagepb.TriggeredEndpointAlert
+}
+
+func newFakePlugin() *fakePlugin {
+	store, _ := memory.NewStore()
+	return &fakePlugin{store: store, triggeredAlerts: make(map[string]*storagepb.TriggeredEndpointAlert)}
+}
+
+func (p *fakePlugin) GetAllEndpointStatuses(_ context.Context, request *storagepb.GetAllEndpointStatusesRequest) (*storagepb.GetAllEndpointStatusesResponse, error) {
+	statuses, err := p.store.GetAllEndpointStatuses(request.GetPaging().ToPaging())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	response := &storagepb.GetAllEndpointStatusesResponse{}
+	for _, endpointStatus := range statuses {
+		response.Statuses = append(response.Statuses, storagepb.FromStatus(endpointStatus))
+	}
+	return response, nil
+}
+
+func (p *fakePlugin) GetEndpointStatusByKey(_ context.Context, request *storagepb.GetEndpointStatusByKeyRequest) (*storagepb.GetEndpointStatusByKeyResponse, error) {
+	endpointStatus, err := p.store.GetEndpointStatusByKey(request.GetKey(), request.GetPaging().ToPaging())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &storagepb.GetEndpointStatusByKeyResponse{Status: storagepb.FromStatus(endpointStatus)}, nil
+}
+
+func (p *fakePlugin) GetUptimeByKey(_ context.Context, request *storagepb.TimeRangeRequest) (*storagepb.GetUptimeByKeyResponse, error) {
+	uptime, err := p.store.GetUptimeByKey(request.GetKey(), request.GetFrom().AsTime(), request.GetTo().AsTime())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &storagepb.GetUptimeByKeyResponse{Uptime: uptime}, nil
+}
+
+func (p *fakePlugin) GetAverageResponseTimeByKey(_ context.Context, request *storagepb.TimeRangeRequest) (*storagepb.GetAverageResponseTimeByKeyResponse, error) {
+	averageResponseTime, err := p.store.GetAverageResponseTimeByKey(request.GetKey(), request.GetFrom().AsTime(), request.GetTo().AsTime())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &storagepb.GetAverageResponseTimeByKeyResponse{AverageResponseTimeMilliseconds: int64(averageResponseTime)}, nil
+}
+
+func (p *fakePlugin) GetHourlyAverageResponseTimeByKey(_ context.Context, request *storagepb.TimeRangeRequest) (*storagepb.GetHourlyAverageResponseTimeByKeyResponse, error) {
+	hourlyAverageResponseTimes, err := p.store.GetHourlyAverageResponseTimeByKey(request.GetKey(), request.GetFrom().AsTime(), request.GetTo().AsTime())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	response := &storagepb.GetHourlyAverageResponseTimeByKeyResponse{HourlyAverageResponseTimeMilliseconds: make(map[int64]int64)}
+	for hourlyUnixTimestamp, averageResponseTime := range hourlyAverageResponseTimes {
+		response.HourlyAverageResponseTimeMilliseconds[hourlyUnixTimestamp] = int64(averageResponseTime)
+	}
+	return response, nil
+}
+
+func (p *fakePlugin) Insert(_ context.Context, request *storagepb.InsertRequest) (*emptypb.Empty, error) {
+	return &emptypb.Empty{}, toStatusError(p.store.Insert(request.GetEndpoint().ToEndpoint(), request.GetResult().ToResult()))
+}
+
+func (p *fakePlugin) DeleteAllEndpointStatusesNotInKeys(_ context.Context, request *storagepb.DeleteAllEndpointStatusesNotInKeysRequest) (*storagepb.DeleteResponse, error) {
+	return &storagepb.DeleteResponse{NumberOfDeleted: int64(p.store.DeleteAllEndpointStatusesNotInKeys(request.GetKeys()))}, nil
+}
+
+func (p *fakePlugin) GetTriggeredEndpointAlert(_ context.Context, request *storagepb.TriggeredEndpointAlertRequest) (*storagepb.GetTriggeredEndpointAlertResponse, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	triggeredAlert, exists := p.triggeredAlerts[request.GetEndpoint().GetKey()+"/"+request.GetChecksum()]
+	if !exists {
+		return &storagepb.GetTriggeredEndpointAlertResponse{}, nil
+	}
+	return &storagepb.GetTriggeredEndpointAlertResponse{Exists: true, ResolveKey: triggeredAlert.GetResolveKey(), NumberOfSuccessesInARow: triggeredAlert.GetNumberOfSuccessesInARow()}, nil
+}
+
+func (p *fakePlugin) UpsertTriggeredEndpointAlert(_ context.Context, request *storagepb.TriggeredEndpointAlert) (*emptypb.Empty, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.triggeredAlerts[request.GetEndpoint().GetKey()+"/"+request.GetChecksum()] = request
+	return &emptypb.Empty{}, nil
+}
+
+func (p *fakePlugin) DeleteTriggeredEndpointAlert(_ context.Context, request *storagepb.TriggeredEndpointAlertRequest) (*emptypb.Empty, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	delete(p.triggeredAlerts, request.GetEndpoint().GetKey()+"/"+request.GetChecksum())
+	return &emptypb.Empty{}, nil
+}
+
+func (p *fakePlugin) DeleteAllTriggeredAlertsNotInChecksumsByEndpoint(_ context.Context, request *storagepb.DeleteAllTriggeredAlertsNotInChecksumsByEndpointRequest) (*storagepb.DeleteResponse, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	checksumsToKeep := make(map[string]bool)
+	for _, checksum := range request.GetChecksums() {
+		checksumsToKeep[request.GetEndpoint().GetKey()+"/"+checksum] = true
+	}
+	var numberOfDeleted int64
+	for id := range p.triggeredAlerts {
+		if strings.HasPrefix(id, request.GetEndpoint().GetKey()+"/") && !checksumsToKeep[id] {
+			delete(p.triggeredAlerts, id)
+			numberOfDeleted++
+		}
+	}
+	return &storagepb.DeleteResponse{NumberOfDeleted: numberOfDeleted}, nil
+}
+
+func (p *fakePlugin) Clear(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	p.store.Clear()
+	p.mutex.Lock()
+	p.triggeredAlerts = make(map[string]*storagepb.TriggeredEndpointAlert)
+	p.mutex.Unlock()
+	return &emptypb.Empty{}, nil
+}
+
+func (p *fakePlugin) Save(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return &emptypb.Empty{}, nil
+}
+
+// toStatusError converts the errors of the store to the status codes of the contract
+func toStatusError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, common.ErrEndpointNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, common.ErrInvalidTimeRange):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return err
+}
+
+// serveFakePlugin serves the fake storage plugin at the address passed as parameter until the context is done
+func serveFakePlugin(ctx context.Context, address string) error {
+	listener, err := net.Listen("unix", strings.TrimPrefix(address, "unix://"))
+	if err != nil {
+		return err
+	}
+	server := grpc.NewServer()
+	storagepb.RegisterStoreServer(server, newFakePlugin())
+	go func() {
+		<-ctx.Done()
+		server.Stop()
+	}()
+	return server.Serve(listener)
+}
+
+func newTestStore(t *testing.T) *Store {
+	address := "unix://" + filepath.Join(t.TempDir(), "plugin.sock")
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go func() { _ = serveFakePlugin(ctx, address) }()
+	store, err := NewStore(address, nil, time.Second)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	t.Cleanup(store.Close)
+	return store
+}
+
+// TestHelperPlugin isn't a test, but the storage plugin started by TestNewStore_WithCommand
+func TestHelperPlugin(t *testing.T) {
+	address := os.Getenv(AddressEnvironmentVariable)
+	if len(address) == 0 {
+		t.Skip("only runs as the storage plugin of TestNewStore_WithCommand")
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := serveFakePlugin(ctx, address); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewStore(t *testing.T) {
+	if _, err := NewStore("", nil, 0); !errors.Is(err, ErrAddressNotSpecified) {
+		t.Error("expected ErrAddressNotSpecified, got", err)
+	}
+	store := newTestStore(t)
+	if store.timeout != time.Second {
+		t.Errorf("expected timeout to be %s, got %s", time.Second, store.timeout)
+	}
+}
+
+func TestNewStore_WithCommand(t *testing.T) {
+	address := "unix://" + filepath.Join(t.TempDir(), "plugin.sock")
+	store, err := NewStore(address, []string{os.Args[0], "-test.run=^TestHelperPlugin$"}, 0)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if store.timeout != DefaultTimeout {
+		t.Errorf("expected timeout to be %s, got %s", DefaultTimeout, store.timeout)
+	}
+	if err = store.Insert(&testEndpoint, &testSuccessfulResult); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	store.Close()
+	select {
+	case <-store.pluginExited:
+	default:
+		t.Error("expected storage plugin to have been stopped")
+	}
+}
+
+func TestNewStore_WithCommandExitingImmediately(t *testing.T) {
+	address := "unix://" + filepath.Join(t.TempDir(), "plugin.sock")
+	if _, err := NewStore(address, []string{os.Args[0], "-test.run=^$", "-test.count=invalid"}, 0); !errors.Is(err, ErrPluginExited) {
+		t.Error("expected ErrPluginExited, got", err)
+	}
+	if _, err := NewStore(address, []string{filepath.Join(t.TempDir(), "does-not-exist")}, 0); err == nil {
+		t.Error("expected error, got none")
+	}
+}
+
+func TestStore_Insert(t *testing.T) {
+	store := newTestStore(t)
+	successfulResultWithMetadata := testSuccessfulResult
+	successfulResultWithMetadata.Metadata = map[string]string{"version": "1.2.3"}
+	if err := store.Insert(&testEndpoint, &successfulResultWithMetadata); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	endpointStatus, err := store.GetEndpointStatus(testEndpoint.Group, testEndpoint.Name, paging.NewEndpointStatusParams().WithResults(1, 20).WithEvents(1, 20))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if endpointStatus.Key != testEndpoint.Key() || endpointStatus.Name != testEndpoint.Name || endpointStatus.Group != testEndpoint.Group {
+		t.Errorf("expected status of endpoint with key=%s, got key=%s", testEndpoint.Key(), endpointStatus.Key)
+	}
+	if len(endpointStatus.Results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(endpointStatus.Results))
+	}
+	result := endpointStatus.Results[0]
+	if !result.Timestamp.Equal(testSuccessfulResult.Timestamp) || result.Duration != testSuccessfulResult.Duration || result.CertificateExpiration != testSuccessfulResult.CertificateExpiration {
+		t.Errorf("expected result %+v, got %+v", testSuccessfulResult, result)
+	}
+	if len(result.ConditionResults) != 1 || result.ConditionResults[0].Condition != "[STATUS] == 200" || !result.ConditionResults[0].Success {
+		t.Error("expected condition results to be returned")
+	}
+	if len(result.Metadata) != 1 || result.Metadata["version"] != "1.2.3" {
+		t.Errorf("expected metadata to be returned, got %v", result.Metadata)
+	}
+	if len(endpointStatus.Events) != 2 || endpointStatus.Events[0].Type != endpoint.EventStart || endpointStatus.Events[1].Type != endpoint.EventHealthy {
+		t.Errorf("expected START and HEALTHY events, got %+v", endpointStatus.Events)
+	}
+	endpointStatuses, err := store.GetAllEndpointStatuses(paging.NewEndpointStatusParams().WithResults(1, 20))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(endpointStatuses) != 1 || len(endpointStatuses[0].Results) != 1 || len(endpointStatuses[0].Events) != 0 {
+		t.Errorf("expected 1 status with 1 result and no events, got %+v", endpointStatuses)
+	}
+	if _, err = store.GetEndpointStatusByKey("invalid_key", paging.NewEndpointStatusParams()); !errors.Is(err, common.ErrEndpointNotFound) {
+		t.Error("expected ErrEndpointNotFound, got", err)
+	}
+}
+
+func TestStore_GetUptimeAndResponseTimeByKey(t *testing.T) {
+	store := newTestStore(t)
+	if err := store.Insert(&testEndpoint, &testSuccessfulResult); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	from, to := testTimestamp.Add(-time.Hour), testTimestamp.Add(time.Hour)
+	if uptime, err := store.GetUptimeByKey(testEndpoint.Key(), from, to); err != nil || uptime != 1 {
+		t.Errorf("expected uptime of 1, got %f and %v", uptime, err)
+	}
+	if averageResponseTime, err := store.GetAverageResponseTimeByKey(testEndpoint.Key(), from, to); err != nil || averageResponseTime != 150 {
+		t.Errorf("expected average response time of 150ms, got %d and %v", averageResponseTime, err)
+	}
+	hourlyAverageResponseTimes, err := store.GetHourlyAverageResponseTimeByKey(testEndpoint.Key(), from, to)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if hourlyAverageResponseTimes[testTimestamp.Truncate(time.Hour).Unix()] != 150 {
+		t.Errorf("expected hourly average response time of 150ms, got %v", hourlyAverageResponseTimes)
+	}
+	if _, err = store.GetUptimeByKey(testEndpoint.Key(), to, from); !errors.Is(err, common.ErrInvalidTimeRange) {
+		t.Error("expected ErrInvalidTimeRange, got", err)
+	}
+	if _, err = store.GetAverageResponseTimeByKey("invalid_key", from, to); !errors.Is(err, common.ErrEndpointNotFound) {
+		t.Error("expected ErrEndpointNotFound, got", err)
+	}
+}
+
+func TestStore_DeleteAllEndpointStatusesNotInKeys(t *testing.T) {
+	store := newTestStore(t)
+	otherEndpoint := testEndpoint
+	otherEndpoint.Name = "other"
+	_ = store.Insert(&testEndpoint, &testSuccessfulResult)
+	_ = store.Insert(&otherEndpoint, &testSuccessfulResult)
+	if numberOfDeleted := store.DeleteAllEndpointStatusesNotInKeys([]string{testEndpoint.Key()}); numberOfDeleted != 1 {
+		t.Errorf("expected 1 endpoint to be deleted, got %d", numberOfDeleted)
+	}
+	if _, err := store.GetEndpointStatusByKey(otherEndpoint.Key(), paging.NewEndpointStatusParams()); !errors.Is(err, common.ErrEndpointNotFound) {
+		t.Error("expected ErrEndpointNotFound, got", err)
+	}
+	store.Clear()
+	if _, err := store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams()); !errors.Is(err, common.ErrEndpointNotFound) {
+		t.Error("expected ErrEndpointNotFound after clearing the store, got", err)
+	}
+	if err := store.Save(); err != nil {
+		t.Error("expected no error, got", err.Error())
+	}
+}
+
+func TestStore_TriggeredEndpointAlerts(t *testing.T) {
+	store := newTestStore(t)
+	firstAlert := &alert.Alert{Type: alert.TypePagerDuty, ResolveKey: "resolve-key"}
+	secondAlert := &alert.Alert{Type: alert.TypeSlack}
+	if exists, _, _, err := store.GetTriggeredEndpointAlert(&testEndpoint, firstAlert); err != nil || exists {
+		t.Errorf("expected no triggered alert, got exists=%v and %v", exists, err)
+	}
+	if err := store.UpsertTriggeredEndpointAlert(&testEndpoint, firstAlert); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if err := store.UpsertTriggeredEndpointAlert(&testEndpoint, secondAlert); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	exists, resolveKey, numberOfSuccessesInARow, err := store.GetTriggeredEndpointAlert(&testEndpoint, firstAlert)
+	if err != nil || !exists || resolveKey != "resolve-key" || numberOfSuccessesInARow != testEndpoint.NumberOfSuccessesInARow {
+		t.Errorf("expected triggered alert with resolveKey=resolve-key and numberOfSuccessesInARow=%d, got exists=%v, resolveKey=%s, numberOfSuccessesInARow=%d and %v", testEndpoint.NumberOfSuccessesInARow, exists, resolveKey, numberOfSuccessesInARow, err)
+	}
+	if numberOfDeleted := store.DeleteAllTriggeredAlertsNotInChecksumsByEndpoint(&testEndpoint, []string{firstAlert.Checksum()}); numberOfDeleted != 1 {
+		t.Errorf("expected 1 triggered alert to be deleted, got %d", numberOfDeleted)
+	}
+	if err = store.DeleteTriggeredEndpointAlert(&testEndpoint, firstAlert); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if exists, _, _, _ = store.GetTriggeredEndpointAlert(&testEndpoint, firstAlert); exists {
+		t.Error("expected triggered alert to have been deleted")
+	}
+}
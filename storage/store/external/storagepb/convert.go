@@ -0,0 +1,112 @@
+package storagepb
+
+import (
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// FromEndpoint returns the message identifying the endpoint passed as parameter
+func FromEndpoint(ep *endpoint.Endpoint) *Endpoint {
+	return &Endpoint{Key: ep.Key(), Group: ep.Group, Name: ep.Name}
+}
+
+// ToEndpoint returns an endpoint with the group and the name of the message
+func (x *Endpoint) ToEndpoint() *endpoint.Endpoint {
+	return &endpoint.Endpoint{Group: x.GetGroup(), Name: x.GetName()}
+}
+
+// FromPaging returns the message of the paging parameters passed as parameter
+func FromPaging(params *paging.EndpointStatusParams) *Paging {
+	return &Paging{
+		EventsPage:      int64(params.EventsPage),
+		EventsPageSize:  int64(params.EventsPageSize),
+		ResultsPage:     int64(params.ResultsPage),
+		ResultsPageSize: int64(params.ResultsPageSize),
+	}
+}
+
+// ToPaging returns the paging parameters of the message
+func (x *Paging) ToPaging() *paging.EndpointStatusParams {
+	return &paging.EndpointStatusParams{
+		EventsPage:      int(x.GetEventsPage()),
+		EventsPageSize:  int(x.GetEventsPageSize()),
+		ResultsPage:     int(x.GetResultsPage()),
+		ResultsPageSize: int(x.GetResultsPageSize()),
+	}
+}
+
+// FromResult returns the message of the result passed as parameter
+func FromResult(result *endpoint.Result) *Result {
+	x := &Result{
+		HttpStatus:            int64(result.HTTPStatus),
+		DnsRcode:              result.DNSRCode,
+		Hostname:              result.Hostname,
+		Ip:                    result.IP,
+		Connected:             result.Connected,
+		Duration:              durationpb.New(result.Duration),
+		Errors:                result.Errors,
+		Success:               result.Success,
+		Timestamp:             timestamppb.New(result.Timestamp),
+		CertificateExpiration: durationpb.New(result.CertificateExpiration),
+		DomainExpiration:      durationpb.New(result.DomainExpiration),
+		Metadata:              result.Metadata,
+	}
+	for _, conditionResult := range result.ConditionResults {
+		x.ConditionResults = append(x.ConditionResults, &ConditionResult{Condition: conditionResult.Condition, Success: conditionResult.Success})
+	}
+	return x
+}
+
+// ToResult returns the result of the message
+func (x *Result) ToResult() *endpoint.Result {
+	result := &endpoint.Result{
+		HTTPStatus:            int(x.GetHttpStatus()),
+		DNSRCode:              x.GetDnsRcode(),
+		Hostname:              x.GetHostname(),
+		IP:                    x.GetIp(),
+		Connected:             x.GetConnected(),
+		Duration:              x.GetDuration().AsDuration(),
+		Errors:                x.GetErrors(),
+		Success:               x.GetSuccess(),
+		Timestamp:             x.GetTimestamp().AsTime(),
+		CertificateExpiration: x.GetCertificateExpiration().AsDuration(),
+		DomainExpiration:      x.GetDomainExpiration().AsDuration(),
+		Metadata:              x.GetMetadata(),
+	}
+	for _, conditionResult := range x.GetConditionResults() {
+		result.ConditionResults = append(result.ConditionResults, &endpoint.ConditionResult{Condition: conditionResult.GetCondition(), Success: conditionResult.GetSuccess()})
+	}
+	return result
+}
+
+// FromStatus returns the message of the status passed as parameter
+func FromStatus(status *endpoint.Status) *EndpointStatus {
+	x := &EndpointStatus{Key: status.Key, Group: status.Group, Name: status.Name}
+	for _, result := range status.Results {
+		x.Results = append(x.Results, FromResult(result))
+	}
+	for _, event := range status.Events {
+		x.Events = append(x.Events, &Event{Type: string(event.Type), Timestamp: timestamppb.New(event.Timestamp)})
+	}
+	return x
+}
+
+// ToStatus returns the status of the message
+func (x *EndpointStatus) ToStatus() *endpoint.Status {
+	status := &endpoint.Status{
+		Key:     x.GetKey(),
+		Group:   x.GetGroup(),
+		Name:    x.GetName(),
+		Results: make([]*endpoint.Result, 0, len(x.GetResults())),
+		Events:  make([]*endpoint.Event, 0, len(x.GetEvents())),
+	}
+	for _, result := range x.GetResults() {
+		status.Results = append(status.Results, result.ToResult())
+	}
+	for _, event := range x.GetEvents() {
+		status.Events = append(status.Events, &endpoint.Event{Type: endpoint.EventType(event.GetType()), Timestamp: event.GetTimestamp().AsTime()})
+	}
+	return status
+}
@@ -0,0 +1,5 @@
+// Package storagepb is the gRPC contract between Gatus and the storage plugins of the external storage type, as
+// defined by store.proto, along with the conversions between its messages and the types they mirror.
+package storagepb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative store.proto
@@ -0,0 +1,46 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+)
+
+// MaximumNumberOfAnnotations is the number of annotations kept, beyond which the oldest annotations are deleted
+const MaximumNumberOfAnnotations = 1000
+
+// InsertAnnotation adds an annotation to the store
+func (s *Store) InsertAnnotation(annotation *endpoint.Annotation) error {
+	s.Lock()
+	defer s.Unlock()
+	// Annotations may be created after the fact, so they're kept sorted by timestamp rather than by insertion
+	i := sort.Search(len(s.annotations), func(i int) bool { return s.annotations[i].Timestamp.After(annotation.Timestamp) })
+	s.annotations = append(s.annotations, nil)
+	copy(s.annotations[i+1:], s.annotations[i:])
+	s.annotations[i] = annotation
+	if len(s.annotations) > MaximumNumberOfAnnotations {
+		s.annotations = s.annotations[len(s.annotations)-MaximumNumberOfAnnotations:]
+	}
+	return nil
+}
+
+// GetAnnotationsByKey returns the annotations affecting an endpoint during a time range, from oldest to newest
+func (s *Store) GetAnnotationsByKey(key string, from, to time.Time) ([]*endpoint.Annotation, error) {
+	if from.After(to) {
+		return nil, common.ErrInvalidTimeRange
+	}
+	s.RLock()
+	defer s.RUnlock()
+	if _, exists := s.cache.Get(key); !exists {
+		return nil, common.ErrEndpointNotFound
+	}
+	annotations := make([]*endpoint.Annotation, 0)
+	for _, annotation := range s.annotations {
+		if !annotation.Timestamp.Before(from) && !annotation.Timestamp.After(to) && annotation.Affects(key) {
+			annotations = append(annotations, annotation)
+		}
+	}
+	return annotations, nil
+}
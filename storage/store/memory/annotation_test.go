@@ -0,0 +1,35 @@
+package memory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+)
+
+func TestStore_InsertAnnotation(t *testing.T) {
+	store, _ := NewStore()
+	store.Insert(&testEndpoint, &testSuccessfulResult)
+	now := time.Now()
+	store.InsertAnnotation(&endpoint.Annotation{Timestamp: now.Add(-time.Hour), Title: "Deployed v1.2.3", EndpointKeys: []string{testEndpoint.Key()}})
+	store.InsertAnnotation(&endpoint.Annotation{Timestamp: now.Add(-2 * time.Hour), Title: "Migrated the database"})
+	store.InsertAnnotation(&endpoint.Annotation{Timestamp: now.Add(-30 * time.Minute), Title: "Deployed other endpoint", EndpointKeys: []string{"other_endpoint"}})
+	annotations, err := store.GetAnnotationsByKey(testEndpoint.Key(), now.Add(-24*time.Hour), now)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(annotations) != 2 || annotations[0].Title != "Migrated the database" || annotations[1].Title != "Deployed v1.2.3" {
+		t.Errorf("expected the annotations affecting the endpoint from oldest to newest, got %+v", annotations)
+	}
+	if annotations, _ = store.GetAnnotationsByKey(testEndpoint.Key(), now.Add(-90*time.Minute), now); len(annotations) != 1 {
+		t.Errorf("expected only the annotations of the time range, got %d", len(annotations))
+	}
+	if _, err = store.GetAnnotationsByKey("nope", now.Add(-time.Hour), now); err != common.ErrEndpointNotFound {
+		t.Errorf("expected error %v, got %v", common.ErrEndpointNotFound, err)
+	}
+	store.Clear()
+	if len(store.annotations) != 0 {
+		t.Error("expected annotations to be cleared")
+	}
+}
@@ -0,0 +1,59 @@
+package memory
+
+import (
+	"time"
+
+	"github.com/TwiN/gatus/v5/announcement"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+)
+
+// MaximumNumberOfAnnouncements is the number of announcements kept, beyond which the oldest announcements are deleted
+const MaximumNumberOfAnnouncements = 100
+
+// InsertAnnouncement adds an announcement to the store and sets its ID
+func (s *Store) InsertAnnouncement(a *announcement.Announcement) error {
+	s.Lock()
+	defer s.Unlock()
+	a.ID = 1
+	if len(s.announcements) > 0 {
+		a.ID = s.announcements[len(s.announcements)-1].ID + 1
+	}
+	s.announcements = append(s.announcements, a)
+	if len(s.announcements) > MaximumNumberOfAnnouncements {
+		s.announcements = s.announcements[len(s.announcements)-MaximumNumberOfAnnouncements:]
+	}
+	return nil
+}
+
+// ExpireAnnouncement makes the announcement with the ID passed as parameter expire at the time passed as parameter,
+// unless it already expired before then
+func (s *Store) ExpireAnnouncement(id int64, at time.Time) error {
+	s.Lock()
+	defer s.Unlock()
+	for i, a := range s.announcements {
+		if a.ID == id {
+			if a.IsActive(at) {
+				// The announcement is replaced rather than modified, since it may still be referenced by a caller
+				expired := *a
+				expired.ExpiresAt = &at
+				s.announcements[i] = &expired
+			}
+			return nil
+		}
+	}
+	return common.ErrAnnouncementNotFound
+}
+
+// GetActiveAnnouncements returns the announcements that haven't expired at the time passed as parameter, from newest
+// to oldest
+func (s *Store) GetActiveAnnouncements(now time.Time) ([]*announcement.Announcement, error) {
+	s.RLock()
+	defer s.RUnlock()
+	announcements := make([]*announcement.Announcement, 0)
+	for i := len(s.announcements) - 1; i >= 0; i-- {
+		if s.announcements[i].IsActive(now) {
+			announcements = append(announcements, s.announcements[i])
+		}
+	}
+	return announcements, nil
+}
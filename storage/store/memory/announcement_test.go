@@ -0,0 +1,89 @@
+package memory
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/announcement"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+)
+
+func TestStore_InsertAnnouncement(t *testing.T) {
+	store, _ := NewStore()
+	now := time.Now()
+	later := now.Add(time.Hour)
+	first := &announcement.Announcement{Type: announcement.TypeMaintenance, Message: "Maintenance tonight", Timestamp: now}
+	second := &announcement.Announcement{Type: announcement.TypeWarning, Message: "Degraded performance", Timestamp: now, ExpiresAt: &later}
+	store.InsertAnnouncement(first)
+	store.InsertAnnouncement(second)
+	if first.ID != 1 || second.ID != 2 {
+		t.Errorf("expected the IDs to be 1 and 2, got %d and %d", first.ID, second.ID)
+	}
+	announcements, _ := store.GetActiveAnnouncements(now)
+	if len(announcements) != 2 || announcements[0].ID != second.ID || announcements[1].ID != first.ID {
+		t.Errorf("expected both announcements from newest to oldest, got %+v", announcements)
+	}
+	if announcements, _ = store.GetActiveAnnouncements(later); len(announcements) != 1 || announcements[0].ID != first.ID {
+		t.Errorf("expected only the announcement without expiration to be active, got %+v", announcements)
+	}
+	for i := 0; i < MaximumNumberOfAnnouncements; i++ {
+		store.InsertAnnouncement(&announcement.Announcement{Type: announcement.TypeInformation, Message: "Hello", Timestamp: now})
+	}
+	if len(store.announcements) != MaximumNumberOfAnnouncements {
+		t.Errorf("expected %d announcements to be kept, got %d", MaximumNumberOfAnnouncements, len(store.announcements))
+	}
+	if store.announcements[len(store.announcements)-1].ID != MaximumNumberOfAnnouncements+2 {
+		t.Errorf("expected the IDs to keep increasing, got %d", store.announcements[len(store.announcements)-1].ID)
+	}
+	store.Clear()
+	if announcements, _ = store.GetActiveAnnouncements(now); len(announcements) != 0 {
+		t.Errorf("expected announcements to be cleared, got %d", len(announcements))
+	}
+}
+
+func TestStore_ExpireAnnouncement(t *testing.T) {
+	store, _ := NewStore()
+	now := time.Now()
+	a := &announcement.Announcement{Type: announcement.TypeOutage, Message: "Outage", Timestamp: now}
+	store.InsertAnnouncement(a)
+	if err := store.ExpireAnnouncement(a.ID, now.Add(time.Minute)); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if a.ExpiresAt != nil {
+		t.Error("expected the announcement passed to the store not to be modified")
+	}
+	if announcements, _ := store.GetActiveAnnouncements(now); len(announcements) != 1 {
+		t.Errorf("expected the announcement to be active until it expires, got %d announcements", len(announcements))
+	}
+	if announcements, _ := store.GetActiveAnnouncements(now.Add(time.Minute)); len(announcements) != 0 {
+		t.Errorf("expected the announcement to have expired, got %d announcements", len(announcements))
+	}
+	// Expiring an announcement that already expired must not bring it back
+	if err := store.ExpireAnnouncement(a.ID, now.Add(time.Hour)); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if announcements, _ := store.GetActiveAnnouncements(now.Add(30 * time.Minute)); len(announcements) != 0 {
+		t.Errorf("expected the announcement to remain expired, got %d announcements", len(announcements))
+	}
+	if err := store.ExpireAnnouncement(42, now); !errors.Is(err, common.ErrAnnouncementNotFound) {
+		t.Errorf("expected %v, got %v", common.ErrAnnouncementNotFound, err)
+	}
+}
+
+func TestStore_AnnouncementsSnapshot(t *testing.T) {
+	path := t.TempDir() + "/snapshot.gob"
+	store, _ := NewStoreWithSnapshot(path)
+	store.InsertAnnouncement(&announcement.Announcement{Type: announcement.TypeMaintenance, Message: "Maintenance tonight", Timestamp: time.Now()})
+	if err := store.Save(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	restoredStore, err := NewStoreWithSnapshot(path)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	announcements, _ := restoredStore.GetActiveAnnouncements(time.Now())
+	if len(announcements) != 1 || announcements[0].ID != 1 || announcements[0].Message != "Maintenance tonight" {
+		t.Errorf("expected announcements to be restored, got %+v", announcements)
+	}
+}
@@ -0,0 +1,37 @@
+package memory
+
+import (
+	"github.com/TwiN/gatus/v5/audit"
+)
+
+// MaximumNumberOfAuditEntries is the number of audit entries kept, beyond which the oldest entries are deleted
+const MaximumNumberOfAuditEntries = 1000
+
+// InsertAuditEntry records an administrative action in the audit log
+func (s *Store) InsertAuditEntry(entry *audit.Entry) error {
+	s.Lock()
+	defer s.Unlock()
+	s.auditEntries = append(s.auditEntries, entry)
+	if len(s.auditEntries) > MaximumNumberOfAuditEntries {
+		s.auditEntries = s.auditEntries[len(s.auditEntries)-MaximumNumberOfAuditEntries:]
+	}
+	return nil
+}
+
+// GetAuditEntries returns a page of the audit log, from newest to oldest
+func (s *Store) GetAuditEntries(page, pageSize int) ([]*audit.Entry, error) {
+	s.RLock()
+	defer s.RUnlock()
+	start, end := len(s.auditEntries)-(page-1)*pageSize, len(s.auditEntries)-page*pageSize
+	if start < 0 {
+		start = 0
+	}
+	if end < 0 {
+		end = 0
+	}
+	entries := make([]*audit.Entry, 0, start-end)
+	for i := start - 1; i >= end; i-- {
+		entries = append(entries, s.auditEntries[i])
+	}
+	return entries, nil
+}
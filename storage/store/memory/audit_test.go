@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/audit"
+)
+
+func TestStore_InsertAuditEntry(t *testing.T) {
+	store, _ := NewStore()
+	for i := 0; i < MaximumNumberOfAuditEntries+5; i++ {
+		store.InsertAuditEntry(audit.NewEntry(audit.ActionConfigurationReload, "", strconv.Itoa(i), true, ""))
+	}
+	if len(store.auditEntries) != MaximumNumberOfAuditEntries {
+		t.Errorf("expected %d audit entries to be kept, got %d", MaximumNumberOfAuditEntries, len(store.auditEntries))
+	}
+	entries, _ := store.GetAuditEntries(1, 2)
+	if len(entries) != 2 || entries[0].Target != strconv.Itoa(MaximumNumberOfAuditEntries+4) || entries[1].Target != strconv.Itoa(MaximumNumberOfAuditEntries+3) {
+		t.Errorf("expected the first page to hold the newest entries, got %+v", entries)
+	}
+	entries, _ = store.GetAuditEntries(MaximumNumberOfAuditEntries/3+1, 3)
+	if len(entries) != 1 || entries[0].Target != "5" {
+		t.Errorf("expected the last page to hold the oldest entry that was kept, got %+v", entries)
+	}
+	if entries, _ = store.GetAuditEntries(MaximumNumberOfAuditEntries, 3); len(entries) != 0 {
+		t.Errorf("expected no entries past the last page, got %d", len(entries))
+	}
+	store.Clear()
+	if entries, _ = store.GetAuditEntries(1, 20); len(entries) != 0 {
+		t.Errorf("expected audit log to be cleared, got %d entries", len(entries))
+	}
+}
+
+func TestStore_AuditEntriesSnapshot(t *testing.T) {
+	path := t.TempDir() + "/snapshot.gob"
+	store, _ := NewStoreWithSnapshot(path)
+	store.InsertAuditEntry(audit.NewEntry(audit.ActionExternalEndpointTokenUsage, "127.0.0.1", "g_n", false, "invalid token"))
+	if err := store.Save(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	restoredStore, err := NewStoreWithSnapshot(path)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	entries, _ := restoredStore.GetAuditEntries(1, 20)
+	if len(entries) != 1 || entries[0].Actor != "127.0.0.1" || entries[0].Details != "invalid token" {
+		t.Errorf("expected audit log to be restored, got %+v", entries)
+	}
+}
@@ -6,9 +6,15 @@ import (
 	"time"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/announcement"
+	"github.com/TwiN/gatus/v5/audit"
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/metrics"
+	"github.com/TwiN/gatus/v5/storage/archive"
 	"github.com/TwiN/gatus/v5/storage/store/common"
 	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+	"github.com/TwiN/gatus/v5/subscription"
+	"github.com/TwiN/gatus/v5/token"
 	"github.com/TwiN/gocache/v2"
 )
 
@@ -17,15 +23,42 @@ type Store struct {
 	sync.RWMutex
 
 	cache *gocache.Cache
+
+	// failureCaptures are the captures of the checks that failed of each endpoint, from oldest to newest
+	failureCaptures map[string][]*endpoint.FailureCapture
+
+	// annotations are the annotations of every endpoint, from oldest to newest
+	annotations []*endpoint.Annotation
+
+	// announcements are the announcements displayed on the dashboard, whether they expired or not, from oldest to newest
+	announcements []*announcement.Announcement
+
+	// subscriptions are the subscriptions of the visitors, whether they were confirmed or not, from oldest to newest
+	subscriptions []*subscription.Subscription
+
+	// auditEntries are the entries of the audit log, from oldest to newest
+	auditEntries []*audit.Entry
+
+	// tokens are the API tokens, whether they're active or not, from oldest to newest
+	tokens []*token.Token
+
+	// archiver is what the results are archived with before they're cleaned up. If nil, results aren't archived.
+	archiver *archive.Archiver
+
+	// retentionPolicy returns the retention policy of each endpoint. If nil, common.DefaultRetentionPolicy is used.
+	retentionPolicy common.RetentionPolicyFunc
+
+	// snapshotPath is the path of the file the state of the store is written to by Save. If empty, Save does nothing.
+	snapshotPath string
 }
 
 // NewStore creates a new store using gocache.Cache
 //
-// This store holds everything in memory, and if the file parameter is not blank,
-// supports eventual persistence.
+// This store holds everything in memory. See NewStoreWithSnapshot for eventual persistence.
 func NewStore() (*Store, error) {
 	store := &Store{
-		cache: gocache.NewCache().WithMaxSize(gocache.NoMaxSize),
+		cache:           gocache.NewCache().WithMaxSize(gocache.NoMaxSize),
+		failureCaptures: make(map[string][]*endpoint.FailureCapture),
 	}
 	return store, nil
 }
@@ -33,6 +66,7 @@ func NewStore() (*Store, error) {
 // GetAllEndpointStatuses returns all monitored endpoint.Status
 // with a subset of endpoint.Result defined by the page and pageSize parameters
 func (s *Store) GetAllEndpointStatuses(params *paging.EndpointStatusParams) ([]*endpoint.Status, error) {
+	defer metrics.ObserveStoreOperation("memory", "GetAllEndpointStatuses", time.Now(), true)
 	endpointStatuses := s.cache.GetAll()
 	pagedEndpointStatuses := make([]*endpoint.Status, 0, len(endpointStatuses))
 	for _, v := range endpointStatuses {
@@ -67,24 +101,11 @@ func (s *Store) GetUptimeByKey(key string, from, to time.Time) (float64, error)
 	if endpointStatus == nil || endpointStatus.(*endpoint.Status).Uptime == nil {
 		return 0, common.ErrEndpointNotFound
 	}
-	successfulExecutions := uint64(0)
-	totalExecutions := uint64(0)
-	current := from
-	for to.Sub(current) >= 0 {
-		hourlyUnixTimestamp := current.Truncate(time.Hour).Unix()
-		hourlyStats := endpointStatus.(*endpoint.Status).Uptime.HourlyStatistics[hourlyUnixTimestamp]
-		if hourlyStats == nil || hourlyStats.TotalExecutions == 0 {
-			current = current.Add(time.Hour)
-			continue
-		}
-		successfulExecutions += hourlyStats.SuccessfulExecutions
-		totalExecutions += hourlyStats.TotalExecutions
-		current = current.Add(time.Hour)
-	}
-	if totalExecutions == 0 {
+	statistics := getUptimeStatistics(endpointStatus.(*endpoint.Status).Uptime, from, to)
+	if statistics.TotalExecutions == 0 {
 		return 0, nil
 	}
-	return float64(successfulExecutions) / float64(totalExecutions), nil
+	return float64(statistics.SuccessfulExecutions) / float64(statistics.TotalExecutions), nil
 }
 
 // GetAverageResponseTimeByKey returns the average response time in milliseconds (value) during a time range
@@ -96,23 +117,11 @@ func (s *Store) GetAverageResponseTimeByKey(key string, from, to time.Time) (int
 	if endpointStatus == nil || endpointStatus.(*endpoint.Status).Uptime == nil {
 		return 0, common.ErrEndpointNotFound
 	}
-	current := from
-	var totalExecutions, totalResponseTime uint64
-	for to.Sub(current) >= 0 {
-		hourlyUnixTimestamp := current.Truncate(time.Hour).Unix()
-		hourlyStats := endpointStatus.(*endpoint.Status).Uptime.HourlyStatistics[hourlyUnixTimestamp]
-		if hourlyStats == nil || hourlyStats.TotalExecutions == 0 {
-			current = current.Add(time.Hour)
-			continue
-		}
-		totalExecutions += hourlyStats.TotalExecutions
-		totalResponseTime += hourlyStats.TotalExecutionsResponseTime
-		current = current.Add(time.Hour)
-	}
-	if totalExecutions == 0 {
+	statistics := getUptimeStatistics(endpointStatus.(*endpoint.Status).Uptime, from, to)
+	if statistics.TotalExecutions == 0 {
 		return 0, nil
 	}
-	return int(float64(totalResponseTime) / float64(totalExecutions)), nil
+	return int(float64(statistics.TotalExecutionsResponseTime) / float64(statistics.TotalExecutions)), nil
 }
 
 // GetHourlyAverageResponseTimeByKey returns a map of hourly (key) average response time in milliseconds (value) during a time range
@@ -141,6 +150,7 @@ func (s *Store) GetHourlyAverageResponseTimeByKey(key string, from, to time.Time
 
 // Insert adds the observed result for the specified endpoint into the store
 func (s *Store) Insert(ep *endpoint.Endpoint, result *endpoint.Result) error {
+	defer metrics.ObserveStoreOperation("memory", "Insert", time.Now(), true)
 	key := ep.Key()
 	s.Lock()
 	status, exists := s.cache.Get(key)
@@ -151,8 +161,80 @@ func (s *Store) Insert(ep *endpoint.Endpoint, result *endpoint.Result) error {
 			Timestamp: time.Now(),
 		})
 	}
-	AddResult(status.(*endpoint.Status), result)
+	policy := common.DefaultRetentionPolicy
+	if s.retentionPolicy != nil {
+		policy = s.retentionPolicy(ep.Group, ep.Name)
+	}
+	removedResults := addResult(status.(*endpoint.Status), result, policy)
+	if s.archiver != nil {
+		s.archiver.Archive(key, ep.Group, ep.Name, removedResults)
+	}
 	s.cache.Set(key, status)
+	if result.FailureCapture != nil && ep.FailureCaptureConfig != nil {
+		captures := append(s.failureCaptures[key], result.FailureCapture)
+		if len(captures) > ep.FailureCaptureConfig.MaximumCaptures {
+			captures = captures[len(captures)-ep.FailureCaptureConfig.MaximumCaptures:]
+		}
+		s.failureCaptures[key] = captures
+	}
+	s.Unlock()
+	return nil
+}
+
+// GetFailureCapturesByKey returns the captures of the checks that failed of an endpoint, from newest to oldest
+func (s *Store) GetFailureCapturesByKey(key string) ([]*endpoint.FailureCapture, error) {
+	s.RLock()
+	defer s.RUnlock()
+	if _, exists := s.cache.Get(key); !exists {
+		return nil, common.ErrEndpointNotFound
+	}
+	captures := make([]*endpoint.FailureCapture, 0, len(s.failureCaptures[key]))
+	for i := len(s.failureCaptures[key]) - 1; i >= 0; i-- {
+		captures = append(captures, s.failureCaptures[key][i])
+	}
+	return captures, nil
+}
+
+// GetHourlyUptimeStatisticsByKey returns the hourly uptime statistics (value) of an endpoint for every hourly unix
+// timestamp (key)
+func (s *Store) GetHourlyUptimeStatisticsByKey(key string) (map[int64]*endpoint.HourlyUptimeStatistics, error) {
+	s.RLock()
+	defer s.RUnlock()
+	endpointStatus := s.cache.GetValue(key)
+	if endpointStatus == nil || endpointStatus.(*endpoint.Status).Uptime == nil {
+		return nil, common.ErrEndpointNotFound
+	}
+	hourlyStatistics := make(map[int64]*endpoint.HourlyUptimeStatistics)
+	for hourlyUnixTimestamp, statistics := range endpointStatus.(*endpoint.Status).Uptime.HourlyStatistics {
+		statisticsCopy := *statistics
+		hourlyStatistics[hourlyUnixTimestamp] = &statisticsCopy
+	}
+	return hourlyStatistics, nil
+}
+
+// ImportEndpointStatus replaces the results, events and uptime of an endpoint by those of the status passed as
+// parameter, creating the endpoint if it doesn't exist
+func (s *Store) ImportEndpointStatus(status *endpoint.Status) error {
+	importedStatus := endpoint.NewStatus(status.Group, status.Name)
+	importedStatus.Results = append(importedStatus.Results, status.Results...)
+	importedStatus.Events = append(importedStatus.Events, status.Events...)
+	if status.Uptime != nil {
+		for hourlyUnixTimestamp, statistics := range status.Uptime.HourlyStatistics {
+			statisticsCopy := *statistics
+			importedStatus.Uptime.HourlyStatistics[hourlyUnixTimestamp] = &statisticsCopy
+		}
+		dailyStatistics := status.Uptime.DailyStatistics
+		if len(dailyStatistics) == 0 {
+			// Backups don't have daily statistics, so they're rolled up from the hourly statistics instead
+			dailyStatistics = status.Uptime.HourlyStatisticsByDay()
+		}
+		for dailyUnixTimestamp, statistics := range dailyStatistics {
+			statisticsCopy := *statistics
+			importedStatus.Uptime.DailyStatistics[dailyUnixTimestamp] = &statisticsCopy
+		}
+	}
+	s.Lock()
+	s.cache.Set(importedStatus.Key, importedStatus)
 	s.Unlock()
 	return nil
 }
@@ -172,6 +254,11 @@ func (s *Store) DeleteAllEndpointStatusesNotInKeys(keys []string) int {
 			keysToDelete = append(keysToDelete, existingKey)
 		}
 	}
+	s.Lock()
+	for _, key := range keysToDelete {
+		delete(s.failureCaptures, key)
+	}
+	s.Unlock()
 	return s.cache.DeleteAll(keysToDelete)
 }
 
@@ -209,14 +296,40 @@ func (s *Store) DeleteAllTriggeredAlertsNotInChecksumsByEndpoint(ep *endpoint.En
 // Clear deletes everything from the store
 func (s *Store) Clear() {
 	s.cache.Clear()
+	s.Lock()
+	s.failureCaptures = make(map[string][]*endpoint.FailureCapture)
+	s.annotations = nil
+	s.announcements = nil
+	s.subscriptions = nil
+	s.auditEntries = nil
+	s.tokens = nil
+	s.Unlock()
 }
 
-// Save persists the cache to the store file
+// Save writes the state of the store to the snapshot file, if applicable
 func (s *Store) Save() error {
-	return nil
+	if len(s.snapshotPath) == 0 {
+		return nil
+	}
+	start := time.Now()
+	err := s.writeSnapshot()
+	metrics.ObserveStoreOperation("memory", "Save", start, err == nil)
+	return err
+}
+
+// SetArchiver sets the archiver used to archive the results before they're cleaned up
+func (s *Store) SetArchiver(archiver *archive.Archiver) {
+	s.archiver = archiver
+}
+
+// SetRetentionPolicy sets the function returning the retention policy of each endpoint
+func (s *Store) SetRetentionPolicy(retentionPolicy common.RetentionPolicyFunc) {
+	s.retentionPolicy = retentionPolicy
 }
 
-// Close does nothing, because there's nothing to close
+// Close uploads the results that haven't been archived yet, if applicable
 func (s *Store) Close() {
-	return
+	if s.archiver != nil {
+		s.archiver.Close()
+	}
 }
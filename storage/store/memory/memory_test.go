@@ -1,10 +1,18 @@
 package memory
 
 import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/endpoint/capture"
+	"github.com/TwiN/gatus/v5/storage/archive"
+	"github.com/TwiN/gatus/v5/storage/store/common"
 	"github.com/TwiN/gatus/v5/storage/store/common/paging"
 )
 
@@ -121,6 +129,64 @@ func TestStore_SanityCheck(t *testing.T) {
 	}
 }
 
+func TestStore_InsertWithArchiver(t *testing.T) {
+	var archivedResults atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		archivedResults.Add(int64(strings.Count(string(body), "\n")))
+	}))
+	defer server.Close()
+	cfg := &archive.Config{Bucket: "archive", Endpoint: server.URL, PathStyle: true, AccessKeyID: "id", SecretAccessKey: "secret"}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	archiver, err := archive.NewArchiver(cfg)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	store, _ := NewStore()
+	store.SetArchiver(archiver)
+	for i := 0; i < common.MaximumNumberOfResults+5; i++ {
+		store.Insert(&testEndpoint, &testSuccessfulResult)
+	}
+	store.Close()
+	if archivedResults := int(archivedResults.Load()); archivedResults != 5 {
+		t.Errorf("expected %d results to be archived, got %d", 5, archivedResults)
+	}
+}
+
+func TestStore_InsertWithFailureCaptures(t *testing.T) {
+	store, _ := NewStore()
+	defer store.Clear()
+	now := time.Now()
+	ep := testEndpoint
+	ep.FailureCaptureConfig = &capture.Config{MaximumBodySize: 16, MaximumCaptures: 2}
+	for i := 0; i < 3; i++ {
+		result := testUnsuccessfulResult
+		result.FailureCapture = &endpoint.FailureCapture{Timestamp: now.Add(time.Duration(i) * time.Minute)}
+		store.Insert(&ep, &result)
+	}
+	// Successful results aren't captured
+	store.Insert(&ep, &testSuccessfulResult)
+	captures, err := store.GetFailureCapturesByKey(ep.Key())
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(captures) != 2 {
+		t.Fatalf("expected %d captures, got %d", 2, len(captures))
+	}
+	if !captures[0].Timestamp.Equal(now.Add(2*time.Minute)) || !captures[1].Timestamp.Equal(now.Add(time.Minute)) {
+		t.Error("expected the most recent captures to be kept, from newest to oldest")
+	}
+	if _, err := store.GetFailureCapturesByKey("nope"); err != common.ErrEndpointNotFound {
+		t.Errorf("expected error %v, got %v", common.ErrEndpointNotFound, err)
+	}
+	store.DeleteAllEndpointStatusesNotInKeys(nil)
+	if len(store.failureCaptures) != 0 {
+		t.Error("expected captures of deleted endpoints to be deleted")
+	}
+}
+
 func TestStore_Save(t *testing.T) {
 	store, err := NewStore()
 	if err != nil {
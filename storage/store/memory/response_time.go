@@ -0,0 +1,29 @@
+package memory
+
+import (
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+)
+
+// GetResponseTimesByKey returns the response time of every result of an endpoint during a time range, from oldest to
+// newest
+func (s *Store) GetResponseTimesByKey(key string, from, to time.Time) ([]*endpoint.ResponseTime, error) {
+	if from.After(to) {
+		return nil, common.ErrInvalidTimeRange
+	}
+	s.RLock()
+	defer s.RUnlock()
+	endpointStatus := s.cache.GetValue(key)
+	if endpointStatus == nil {
+		return nil, common.ErrEndpointNotFound
+	}
+	responseTimes := make([]*endpoint.ResponseTime, 0)
+	for _, result := range endpointStatus.(*endpoint.Status).Results {
+		if !result.Timestamp.Before(from) && !result.Timestamp.After(to) {
+			responseTimes = append(responseTimes, &endpoint.ResponseTime{Timestamp: result.Timestamp, Duration: result.Duration})
+		}
+	}
+	return responseTimes, nil
+}
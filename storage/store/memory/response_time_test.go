@@ -0,0 +1,39 @@
+package memory
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/storage/store/common"
+)
+
+func TestStore_GetResponseTimesByKey(t *testing.T) {
+	store, _ := NewStore()
+	now := time.Now().Truncate(time.Second)
+	for i, duration := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond} {
+		result := testSuccessfulResult
+		result.Timestamp = now.Add(time.Duration(i-3) * time.Hour)
+		result.Duration = duration
+		store.Insert(&testEndpoint, &result)
+	}
+	responseTimes, err := store.GetResponseTimesByKey(testEndpoint.Key(), now.Add(-150*time.Minute), now)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(responseTimes) != 2 {
+		t.Fatalf("expected only the %d response times of the time range, got %d", 2, len(responseTimes))
+	}
+	if responseTimes[0].Duration != 200*time.Millisecond || !responseTimes[0].Timestamp.Equal(now.Add(-2*time.Hour)) {
+		t.Errorf("expected the oldest response time to be 200ms at %s, got %+v", now.Add(-2*time.Hour), responseTimes[0])
+	}
+	if responseTimes[1].Duration != 300*time.Millisecond || !responseTimes[1].Timestamp.Equal(now.Add(-time.Hour)) {
+		t.Errorf("expected the newest response time to be 300ms at %s, got %+v", now.Add(-time.Hour), responseTimes[1])
+	}
+	if _, err = store.GetResponseTimesByKey("nope", now.Add(-time.Hour), now); !errors.Is(err, common.ErrEndpointNotFound) {
+		t.Errorf("expected error %v, got %v", common.ErrEndpointNotFound, err)
+	}
+	if _, err = store.GetResponseTimesByKey(testEndpoint.Key(), now, now.Add(-time.Hour)); !errors.Is(err, common.ErrInvalidTimeRange) {
+		t.Errorf("expected error %v, got %v", common.ErrInvalidTimeRange, err)
+	}
+}
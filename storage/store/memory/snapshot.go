@@ -0,0 +1,129 @@
+package memory
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/TwiN/gatus/v5/announcement"
+	"github.com/TwiN/gatus/v5/audit"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/subscription"
+	"github.com/TwiN/gatus/v5/token"
+)
+
+const (
+	// DefaultSnapshotInterval is the interval at which the snapshot is written by default
+	DefaultSnapshotInterval = 5 * time.Minute
+
+	// snapshotVersion is the version of the format of the snapshots
+	snapshotVersion = 1
+)
+
+// ErrUnsupportedSnapshotVersion is the error returned when restoring a snapshot whose version isn't supported
+var ErrUnsupportedSnapshotVersion = errors.New("unsupported snapshot version")
+
+// snapshot is the state of the store written to the snapshot file
+type snapshot struct {
+	Version   int
+	CreatedAt time.Time
+	Statuses  []*endpoint.Status
+
+	// Annotations are the annotations of every endpoint. Snapshots written before annotations were introduced don't
+	// have any.
+	Annotations []*endpoint.Annotation
+
+	// Announcements are the announcements displayed on the dashboard. Snapshots written before announcements were
+	// introduced don't have any.
+	Announcements []*announcement.Announcement
+
+	// Subscriptions are the subscriptions of the visitors. Snapshots written before subscriptions were introduced don't
+	// have any.
+	Subscriptions []*subscription.Subscription
+
+	// AuditEntries is the audit log. Snapshots written before the audit log was introduced don't have any.
+	AuditEntries []*audit.Entry
+
+	// Tokens are the API tokens. Snapshots written before API tokens were introduced don't have any.
+	Tokens []*token.Token
+}
+
+// NewStoreWithSnapshot creates a new store like NewStore, but which is restored from the snapshot file at the path
+// passed as parameter if it exists, and whose state is written to said file every time Save is called
+func NewStoreWithSnapshot(path string) (*Store, error) {
+	store, _ := NewStore()
+	store.snapshotPath = path
+	if err := store.restoreSnapshot(); err != nil {
+		return nil, fmt.Errorf("error restoring snapshot from %s: %w", path, err)
+	}
+	return store, nil
+}
+
+// restoreSnapshot populates the store with the statuses of the snapshot file, unless it doesn't exist yet
+func (s *Store) restoreSnapshot() error {
+	file, err := os.Open(s.snapshotPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	defer file.Close()
+	snap := &snapshot{}
+	if err = gob.NewDecoder(file).Decode(snap); err != nil {
+		return err
+	}
+	if snap.Version != snapshotVersion {
+		return fmt.Errorf("%w: %d", ErrUnsupportedSnapshotVersion, snap.Version)
+	}
+	s.Lock()
+	defer s.Unlock()
+	for _, status := range snap.Statuses {
+		if status.Uptime == nil || status.Uptime.HourlyStatistics == nil {
+			status.Uptime = endpoint.NewUptime()
+		}
+		if status.Uptime.DailyStatistics == nil {
+			// Snapshots written before daily statistics were introduced don't have any
+			status.Uptime.DailyStatistics = status.Uptime.HourlyStatisticsByDay()
+		}
+		s.cache.Set(status.Key, status)
+	}
+	s.annotations = snap.Annotations
+	s.announcements = snap.Announcements
+	s.subscriptions = snap.Subscriptions
+	s.auditEntries = snap.AuditEntries
+	s.tokens = snap.Tokens
+	return nil
+}
+
+// writeSnapshot writes the statuses of the store to the snapshot file. The snapshot is first written to a temporary
+// file, which then replaces the snapshot file, so that a crash while writing never leaves a partial snapshot behind.
+func (s *Store) writeSnapshot() error {
+	file, err := os.CreateTemp(filepath.Dir(s.snapshotPath), filepath.Base(s.snapshotPath)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	snap := &snapshot{Version: snapshotVersion, CreatedAt: time.Now()}
+	s.RLock()
+	for _, status := range s.cache.GetAll() {
+		snap.Statuses = append(snap.Statuses, status.(*endpoint.Status))
+	}
+	snap.Annotations = s.annotations
+	snap.Announcements = s.announcements
+	snap.Subscriptions = s.subscriptions
+	snap.AuditEntries = s.auditEntries
+	snap.Tokens = s.tokens
+	err = gob.NewEncoder(file).Encode(snap)
+	s.RUnlock()
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), s.snapshotPath)
+}
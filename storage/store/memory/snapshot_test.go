@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+)
+
+func TestNewStoreWithSnapshot(t *testing.T) {
+	path := t.TempDir() + "/snapshot.gob"
+	store, err := NewStoreWithSnapshot(path)
+	if err != nil {
+		t.Fatal("expected no error when the snapshot doesn't exist yet, got", err.Error())
+	}
+	store.Insert(&testEndpoint, &testSuccessfulResult)
+	store.Insert(&testEndpoint, &testUnsuccessfulResult)
+	if err = store.Save(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	restoredStore, err := NewStoreWithSnapshot(path)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	status, err := restoredStore.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, 20).WithEvents(1, 20))
+	if err != nil {
+		t.Fatal("expected endpoint to be restored, got", err.Error())
+	}
+	if len(status.Results) != 2 || len(status.Events) != 3 {
+		t.Fatalf("expected 2 results and 3 events to be restored, got %d results and %d events", len(status.Results), len(status.Events))
+	}
+	if status.Results[0].IP != testSuccessfulResult.IP || !status.Results[0].Timestamp.Equal(testSuccessfulResult.Timestamp) {
+		t.Errorf("expected result to be restored as is, got %+v", status.Results[0])
+	}
+	if uptime, _ := restoredStore.GetUptimeByKey(testEndpoint.Key(), time.Now().Add(-time.Hour), time.Now()); uptime != 0.5 {
+		t.Errorf("expected uptime to be restored, got %f", uptime)
+	}
+	// Inserting in the restored store works as usual
+	restoredStore.Insert(&testEndpoint, &testSuccessfulResult)
+	if status, _ = restoredStore.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, 20)); len(status.Results) != 3 {
+		t.Errorf("expected 3 results, got %d", len(status.Results))
+	}
+}
+
+func TestNewStoreWithSnapshot_InvalidSnapshot(t *testing.T) {
+	path := t.TempDir() + "/snapshot.gob"
+	if err := os.WriteFile(path, []byte("not a snapshot"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewStoreWithSnapshot(path); err == nil {
+		t.Error("expected an error when the snapshot is invalid")
+	}
+}
+
+func TestStore_SaveWithoutSnapshot(t *testing.T) {
+	store, _ := NewStore()
+	if err := store.Save(); err != nil {
+		t.Error("expected no error, got", err.Error())
+	}
+}
+
+func TestStore_SaveReplacesSnapshot(t *testing.T) {
+	directory := t.TempDir()
+	store, _ := NewStoreWithSnapshot(directory + "/snapshot.gob")
+	store.Insert(&testEndpoint, &testSuccessfulResult)
+	for i := 0; i < 2; i++ {
+		if err := store.Save(); err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+	}
+	if entries, _ := os.ReadDir(directory); len(entries) != 1 {
+		t.Errorf("expected only the snapshot to be left in the directory, got %d entries", len(entries))
+	}
+	store.Clear()
+	_ = store.Save()
+	restoredStore, _ := NewStoreWithSnapshot(directory + "/snapshot.gob")
+	if _, err := restoredStore.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams()); !errors.Is(err, common.ErrEndpointNotFound) {
+		t.Errorf("expected cleared store to be snapshotted, got %v", err)
+	}
+}
@@ -0,0 +1,105 @@
+package memory
+
+import (
+	"time"
+
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/gatus/v5/subscription"
+)
+
+// MaximumNumberOfPendingSubscriptions is the number of subscriptions that have yet to be confirmed kept, beyond which
+// the oldest of them are deleted
+const MaximumNumberOfPendingSubscriptions = 1000
+
+// InsertSubscription adds a subscription that has yet to be confirmed to the store and sets its ID. Subscriptions
+// that weren't confirmed within subscription.ConfirmationTimeout are deleted.
+func (s *Store) InsertSubscription(sub *subscription.Subscription) error {
+	s.Lock()
+	defer s.Unlock()
+	sub.ID = 1
+	numberOfPendingSubscriptions := 1
+	subscriptions := make([]*subscription.Subscription, 0, len(s.subscriptions)+1)
+	for _, existingSubscription := range s.subscriptions {
+		if existingSubscription.ID >= sub.ID {
+			sub.ID = existingSubscription.ID + 1
+		}
+		if !existingSubscription.Confirmed {
+			if sub.Timestamp.Sub(existingSubscription.Timestamp) > subscription.ConfirmationTimeout {
+				continue
+			}
+			numberOfPendingSubscriptions++
+		}
+		subscriptions = append(subscriptions, existingSubscription)
+	}
+	subscriptions = append(subscriptions, sub)
+	// Delete the oldest pending subscriptions if there are too many of them
+	for i := 0; numberOfPendingSubscriptions > MaximumNumberOfPendingSubscriptions; {
+		if !subscriptions[i].Confirmed {
+			subscriptions = append(subscriptions[:i], subscriptions[i+1:]...)
+			numberOfPendingSubscriptions--
+		} else {
+			i++
+		}
+	}
+	s.subscriptions = subscriptions
+	return nil
+}
+
+// ConfirmSubscription confirms the subscription whose confirmation token is passed as parameter, which replaces the
+// subscriptions of the same type and target that were confirmed before
+func (s *Store) ConfirmSubscription(confirmationToken string, now time.Time) error {
+	s.Lock()
+	defer s.Unlock()
+	var confirmedSubscription *subscription.Subscription
+	for _, sub := range s.subscriptions {
+		if sub.ConfirmationToken == confirmationToken {
+			if !sub.Confirmed && now.Sub(sub.Timestamp) > subscription.ConfirmationTimeout {
+				return common.ErrSubscriptionNotFound
+			}
+			// The subscription is replaced rather than modified, since it may still be referenced by a caller
+			confirmedSubscription = &subscription.Subscription{}
+			*confirmedSubscription = *sub
+			confirmedSubscription.Confirmed = true
+			break
+		}
+	}
+	if confirmedSubscription == nil {
+		return common.ErrSubscriptionNotFound
+	}
+	subscriptions := make([]*subscription.Subscription, 0, len(s.subscriptions))
+	for _, sub := range s.subscriptions {
+		if sub.ID == confirmedSubscription.ID {
+			subscriptions = append(subscriptions, confirmedSubscription)
+		} else if !sub.Confirmed || sub.Type != confirmedSubscription.Type || sub.Target != confirmedSubscription.Target {
+			subscriptions = append(subscriptions, sub)
+		}
+	}
+	s.subscriptions = subscriptions
+	return nil
+}
+
+// DeleteSubscription deletes the subscription whose unsubscribe token is passed as parameter
+func (s *Store) DeleteSubscription(unsubscribeToken string) error {
+	s.Lock()
+	defer s.Unlock()
+	for i, sub := range s.subscriptions {
+		if sub.UnsubscribeToken == unsubscribeToken {
+			s.subscriptions = append(s.subscriptions[:i], s.subscriptions[i+1:]...)
+			return nil
+		}
+	}
+	return common.ErrSubscriptionNotFound
+}
+
+// GetConfirmedSubscriptions returns every subscription that was confirmed, from oldest to newest
+func (s *Store) GetConfirmedSubscriptions() ([]*subscription.Subscription, error) {
+	s.RLock()
+	defer s.RUnlock()
+	subscriptions := make([]*subscription.Subscription, 0)
+	for _, sub := range s.subscriptions {
+		if sub.Confirmed {
+			subscriptions = append(subscriptions, sub)
+		}
+	}
+	return subscriptions, nil
+}
@@ -0,0 +1,123 @@
+package memory
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/gatus/v5/subscription"
+)
+
+func TestStore_InsertSubscription(t *testing.T) {
+	store, _ := NewStore()
+	first, _ := subscription.New(subscription.TypeEmail, "john.doe@example.org", nil)
+	first.Timestamp = time.Now().Add(-subscription.ConfirmationTimeout - time.Minute)
+	second, _ := subscription.New(subscription.TypeWebhook, "https://example.org/webhook", []string{"core"})
+	store.InsertSubscription(first)
+	if err := store.ConfirmSubscription(first.ConfirmationToken, time.Now()); !errors.Is(err, common.ErrSubscriptionNotFound) {
+		t.Errorf("expected subscriptions to no longer be confirmable after the timeout, got %v", err)
+	}
+	store.InsertSubscription(second)
+	if len(store.subscriptions) != 1 || store.subscriptions[0] != second {
+		t.Errorf("expected the subscription that wasn't confirmed in time to be deleted, got %d subscriptions", len(store.subscriptions))
+	}
+	if second.ID != first.ID+1 {
+		t.Errorf("expected the ID to be %d, got %d", first.ID+1, second.ID)
+	}
+	if subscriptions, _ := store.GetConfirmedSubscriptions(); len(subscriptions) != 0 {
+		t.Errorf("expected subscriptions that weren't confirmed not to be returned, got %d", len(subscriptions))
+	}
+	if err := store.ConfirmSubscription(second.ConfirmationToken, time.Now()); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	for i := 0; i < MaximumNumberOfPendingSubscriptions+5; i++ {
+		pending, _ := subscription.New(subscription.TypeEmail, "jane.doe@example.org", nil)
+		store.InsertSubscription(pending)
+	}
+	if len(store.subscriptions) != MaximumNumberOfPendingSubscriptions+1 {
+		t.Errorf("expected %d pending subscriptions to be kept along with the confirmed one, got %d subscriptions", MaximumNumberOfPendingSubscriptions, len(store.subscriptions))
+	}
+	subscriptions, _ := store.GetConfirmedSubscriptions()
+	if len(subscriptions) != 1 || subscriptions[0].Target != "https://example.org/webhook" || !subscriptions[0].Confirmed {
+		t.Errorf("expected the confirmed subscription to be kept, got %+v", subscriptions)
+	}
+	store.Clear()
+	if subscriptions, _ = store.GetConfirmedSubscriptions(); len(subscriptions) != 0 {
+		t.Errorf("expected subscriptions to be cleared, got %d", len(subscriptions))
+	}
+}
+
+func TestStore_ConfirmSubscription(t *testing.T) {
+	store, _ := NewStore()
+	previous, _ := subscription.New(subscription.TypeEmail, "john.doe@example.org", []string{"core"})
+	other, _ := subscription.New(subscription.TypeEmail, "jane.doe@example.org", nil)
+	replacement, _ := subscription.New(subscription.TypeEmail, "john.doe@example.org", []string{"frontend"})
+	for _, sub := range []*subscription.Subscription{previous, other, replacement} {
+		store.InsertSubscription(sub)
+	}
+	for _, sub := range []*subscription.Subscription{previous, other} {
+		if err := store.ConfirmSubscription(sub.ConfirmationToken, time.Now()); err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+	}
+	if previous.Confirmed {
+		t.Error("expected the subscription passed to the store not to be modified")
+	}
+	if subscriptions, _ := store.GetConfirmedSubscriptions(); len(subscriptions) != 2 {
+		t.Errorf("expected 2 confirmed subscriptions, got %d", len(subscriptions))
+	}
+	// Confirming the new subscription of the same address replaces the one that was confirmed before
+	if err := store.ConfirmSubscription(replacement.ConfirmationToken, time.Now()); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	subscriptions, _ := store.GetConfirmedSubscriptions()
+	if len(subscriptions) != 2 || subscriptions[0].ID != other.ID || subscriptions[1].ID != replacement.ID {
+		t.Errorf("expected the previous subscription to be replaced, got %+v", subscriptions)
+	}
+	// Confirming a subscription that was already confirmed does nothing
+	if err := store.ConfirmSubscription(replacement.ConfirmationToken, time.Now().Add(2*subscription.ConfirmationTimeout)); err != nil {
+		t.Error("expected no error, got", err.Error())
+	}
+	if err := store.ConfirmSubscription("nope", time.Now()); !errors.Is(err, common.ErrSubscriptionNotFound) {
+		t.Errorf("expected %v, got %v", common.ErrSubscriptionNotFound, err)
+	}
+}
+
+func TestStore_DeleteSubscription(t *testing.T) {
+	store, _ := NewStore()
+	sub, _ := subscription.New(subscription.TypeWebhook, "https://example.org/webhook", nil)
+	store.InsertSubscription(sub)
+	store.ConfirmSubscription(sub.ConfirmationToken, time.Now())
+	if err := store.DeleteSubscription(sub.ConfirmationToken); !errors.Is(err, common.ErrSubscriptionNotFound) {
+		t.Errorf("expected the confirmation token not to unsubscribe, got %v", err)
+	}
+	if err := store.DeleteSubscription(sub.UnsubscribeToken); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if subscriptions, _ := store.GetConfirmedSubscriptions(); len(subscriptions) != 0 {
+		t.Errorf("expected the subscription to be deleted, got %d", len(subscriptions))
+	}
+	if err := store.DeleteSubscription(sub.UnsubscribeToken); !errors.Is(err, common.ErrSubscriptionNotFound) {
+		t.Errorf("expected %v, got %v", common.ErrSubscriptionNotFound, err)
+	}
+}
+
+func TestStore_SubscriptionsSnapshot(t *testing.T) {
+	path := t.TempDir() + "/snapshot.gob"
+	store, _ := NewStoreWithSnapshot(path)
+	sub, _ := subscription.New(subscription.TypeEmail, "john.doe@example.org", []string{"core"})
+	store.InsertSubscription(sub)
+	store.ConfirmSubscription(sub.ConfirmationToken, time.Now())
+	if err := store.Save(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	restoredStore, err := NewStoreWithSnapshot(path)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	subscriptions, _ := restoredStore.GetConfirmedSubscriptions()
+	if len(subscriptions) != 1 || subscriptions[0].Target != "john.doe@example.org" || subscriptions[0].UnsubscribeToken != sub.UnsubscribeToken || len(subscriptions[0].Groups) != 1 {
+		t.Errorf("expected subscriptions to be restored, got %+v", subscriptions)
+	}
+}
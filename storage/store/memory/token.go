@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"time"
+
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/gatus/v5/token"
+)
+
+// InsertToken adds an API token to the store and sets its ID
+func (s *Store) InsertToken(t *token.Token) error {
+	s.Lock()
+	defer s.Unlock()
+	t.ID = 1
+	if len(s.tokens) > 0 {
+		t.ID = s.tokens[len(s.tokens)-1].ID + 1
+	}
+	s.tokens = append(s.tokens, t)
+	return nil
+}
+
+// GetTokenByHash returns the API token whose hash is passed as parameter, whether it's active or not
+func (s *Store) GetTokenByHash(hash string) (*token.Token, error) {
+	s.RLock()
+	defer s.RUnlock()
+	for _, t := range s.tokens {
+		if t.Hash == hash {
+			return t, nil
+		}
+	}
+	return nil, common.ErrTokenNotFound
+}
+
+// GetTokens returns every API token, whether it's active or not, from newest to oldest
+func (s *Store) GetTokens() ([]*token.Token, error) {
+	s.RLock()
+	defer s.RUnlock()
+	tokens := make([]*token.Token, 0, len(s.tokens))
+	for i := len(s.tokens) - 1; i >= 0; i-- {
+		tokens = append(tokens, s.tokens[i])
+	}
+	return tokens, nil
+}
+
+// RevokeToken revokes the API token with the ID passed as parameter at the time passed as parameter, unless it was
+// already revoked
+func (s *Store) RevokeToken(id int64, at time.Time) error {
+	s.Lock()
+	defer s.Unlock()
+	for i, t := range s.tokens {
+		if t.ID == id {
+			if t.RevokedAt == nil {
+				// The token is replaced rather than modified, since it may still be referenced by a caller
+				revoked := *t
+				revoked.RevokedAt = &at
+				s.tokens[i] = &revoked
+			}
+			return nil
+		}
+	}
+	return common.ErrTokenNotFound
+}
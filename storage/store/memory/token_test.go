@@ -0,0 +1,63 @@
+package memory
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/gatus/v5/token"
+)
+
+func TestStore_InsertToken(t *testing.T) {
+	store, _ := NewStore()
+	now := time.Now()
+	first := &token.Token{Name: "ci", Scopes: []token.Scope{token.ScopeReadStatuses}, Hash: token.Hash("gatus_first"), Timestamp: now}
+	second := &token.Token{Name: "agent", Scopes: []token.Scope{token.ScopePushExternal}, Hash: token.Hash("gatus_second"), Timestamp: now}
+	store.InsertToken(first)
+	store.InsertToken(second)
+	if first.ID != 1 || second.ID != 2 {
+		t.Errorf("expected the IDs to be 1 and 2, got %d and %d", first.ID, second.ID)
+	}
+	tokens, _ := store.GetTokens()
+	if len(tokens) != 2 || tokens[0].ID != second.ID || tokens[1].ID != first.ID {
+		t.Errorf("expected both tokens from newest to oldest, got %+v", tokens)
+	}
+	if found, err := store.GetTokenByHash(token.Hash("gatus_second")); err != nil || found.ID != second.ID {
+		t.Errorf("expected the second token to be found by its hash, got %+v and %v", found, err)
+	}
+	if _, err := store.GetTokenByHash(token.Hash("gatus_third")); !errors.Is(err, common.ErrTokenNotFound) {
+		t.Errorf("expected %v, got %v", common.ErrTokenNotFound, err)
+	}
+	store.Clear()
+	if tokens, _ = store.GetTokens(); len(tokens) != 0 {
+		t.Errorf("expected tokens to be cleared, got %d", len(tokens))
+	}
+}
+
+func TestStore_RevokeToken(t *testing.T) {
+	store, _ := NewStore()
+	now := time.Now()
+	tk := &token.Token{Name: "ci", Scopes: []token.Scope{token.ScopeAdmin}, Hash: token.Hash("gatus_secret"), Timestamp: now}
+	store.InsertToken(tk)
+	if err := store.RevokeToken(tk.ID, now); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if tk.RevokedAt != nil {
+		t.Error("expected the token passed to the store not to be modified")
+	}
+	revoked, _ := store.GetTokenByHash(tk.Hash)
+	if revoked.RevokedAt == nil || revoked.IsActive(now) {
+		t.Errorf("expected the token to have been revoked, got %+v", revoked)
+	}
+	// Revoking a token that was already revoked must not change when it was revoked
+	if err := store.RevokeToken(tk.ID, now.Add(time.Hour)); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if revoked, _ = store.GetTokenByHash(tk.Hash); !revoked.RevokedAt.Equal(now) {
+		t.Errorf("expected the token to remain revoked at %s, got %s", now, revoked.RevokedAt)
+	}
+	if err := store.RevokeToken(tk.ID+1, now); !errors.Is(err, common.ErrTokenNotFound) {
+		t.Errorf("expected %v, got %v", common.ErrTokenNotFound, err)
+	}
+}
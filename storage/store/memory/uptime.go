@@ -9,6 +9,9 @@ import (
 const (
 	numberOfHoursInTenDays = 10 * 24
 	sevenDays              = 7 * 24 * time.Hour
+
+	numberOfDaysInFourHundredDays = 400
+	oneYear                       = 365 * 24 * time.Hour
 )
 
 // processUptimeAfterResult processes the result by extracting the relevant from the result and recalculating the uptime
@@ -28,6 +31,20 @@ func processUptimeAfterResult(uptime *endpoint.Uptime, result *endpoint.Result)
 	}
 	hourlyStats.TotalExecutions++
 	hourlyStats.TotalExecutionsResponseTime += uint64(result.Duration.Milliseconds())
+	if uptime.DailyStatistics == nil {
+		uptime.DailyStatistics = make(map[int64]*endpoint.HourlyUptimeStatistics)
+	}
+	unixTimestampFlooredAtDay := result.Timestamp.Truncate(24 * time.Hour).Unix()
+	dailyStats := uptime.DailyStatistics[unixTimestampFlooredAtDay]
+	if dailyStats == nil {
+		dailyStats = &endpoint.HourlyUptimeStatistics{}
+		uptime.DailyStatistics[unixTimestampFlooredAtDay] = dailyStats
+	}
+	if result.Success {
+		dailyStats.SuccessfulExecutions++
+	}
+	dailyStats.TotalExecutions++
+	dailyStats.TotalExecutionsResponseTime += uint64(result.Duration.Milliseconds())
 	// Clean up only when we're starting to have too many useless keys
 	// Note that this is only triggered when there are more entries than there should be after
 	// 10 days, despite the fact that we are deleting everything that's older than 7 days.
@@ -39,5 +56,34 @@ func processUptimeAfterResult(uptime *endpoint.Uptime, result *endpoint.Result)
 				delete(uptime.HourlyStatistics, hourlyUnixTimestamp)
 			}
 		}
+	} // Similarly, daily statistics older than a year are only cleaned up once there are 400 days of them
+	if len(uptime.DailyStatistics) > numberOfDaysInFourHundredDays {
+		oneYearAgo := time.Now().Add(-(oneYear + 24*time.Hour)).Unix()
+		for dailyUnixTimestamp := range uptime.DailyStatistics {
+			if oneYearAgo > dailyUnixTimestamp {
+				delete(uptime.DailyStatistics, dailyUnixTimestamp)
+			}
+		}
+	}
+}
+
+// getUptimeStatistics returns the sum of the statistics of an uptime during a time range. The hourly statistics are
+// used if the time range is short enough and recent enough for them to still be kept, and the daily statistics
+// otherwise, so that long time ranges are computed in O(days).
+func getUptimeStatistics(uptime *endpoint.Uptime, from, to time.Time) *endpoint.HourlyUptimeStatistics {
+	statisticsByUnixTimestamp, step := uptime.HourlyStatistics, time.Hour
+	if to.Sub(from) > sevenDays+time.Hour || time.Since(from) > sevenDays+time.Hour {
+		statisticsByUnixTimestamp, step = uptime.DailyStatistics, 24*time.Hour
+	}
+	total := &endpoint.HourlyUptimeStatistics{}
+	for current := from; to.Sub(current) >= 0; current = current.Add(step) {
+		statistics := statisticsByUnixTimestamp[current.Truncate(step).Unix()]
+		if statistics == nil {
+			continue
+		}
+		total.TotalExecutions += statistics.TotalExecutions
+		total.SuccessfulExecutions += statistics.SuccessfulExecutions
+		total.TotalExecutionsResponseTime += statistics.TotalExecutionsResponseTime
 	}
+	return total
 }
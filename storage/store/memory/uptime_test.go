@@ -59,6 +59,42 @@ func TestAddResultUptimeIsCleaningUpAfterItself(t *testing.T) {
 	}
 }
 
+func TestProcessUptimeAfterResultUpdatesDailyStatistics(t *testing.T) {
+	status := endpoint.NewStatus("group", "name")
+	day := time.Now().Truncate(24 * time.Hour)
+	processUptimeAfterResult(status.Uptime, &endpoint.Result{Timestamp: day.Add(time.Hour), Success: true, Duration: 10 * time.Millisecond})
+	processUptimeAfterResult(status.Uptime, &endpoint.Result{Timestamp: day.Add(2 * time.Hour), Success: false, Duration: 20 * time.Millisecond})
+	processUptimeAfterResult(status.Uptime, &endpoint.Result{Timestamp: day.Add(-time.Hour), Success: true, Duration: 5 * time.Millisecond})
+	checkHourlyStatistics(t, status.Uptime.DailyStatistics[day.Unix()], 30, 2, 1)
+	checkHourlyStatistics(t, status.Uptime.DailyStatistics[day.Add(-24*time.Hour).Unix()], 5, 1, 1)
+}
+
+func TestAddResultDailyUptimeIsCleaningUpAfterItself(t *testing.T) {
+	status := endpoint.NewStatus("group", "name")
+	day := time.Now().Truncate(24 * time.Hour)
+	for i := 500; i >= 0; i-- {
+		AddResult(status, &endpoint.Result{Timestamp: day.Add(-time.Duration(i) * 24 * time.Hour), Success: true})
+		if len(status.Uptime.DailyStatistics) > numberOfDaysInFourHundredDays {
+			t.Fatalf("At no point in time should there be more than %d entries in status.Uptime.DailyStatistics, but there are %d", numberOfDaysInFourHundredDays, len(status.Uptime.DailyStatistics))
+		}
+	}
+	if _, exists := status.Uptime.DailyStatistics[day.Add(-365*24*time.Hour).Unix()]; !exists {
+		t.Error("expected the daily statistics of a year ago to have been kept")
+	}
+}
+
+func TestGetUptimeStatisticsUsesDailyStatisticsForTimeRangesStartingBeforeTheHourlyStatisticsKept(t *testing.T) {
+	status := endpoint.NewStatus("group", "name")
+	day := time.Now().Truncate(24 * time.Hour).Add(-30 * 24 * time.Hour)
+	for timestamp := day; timestamp.Before(time.Now()); timestamp = timestamp.Add(time.Hour) {
+		AddResult(status, &endpoint.Result{Timestamp: timestamp, Success: timestamp.Hour()%4 != 0, Duration: time.Millisecond})
+	}
+	if _, exists := status.Uptime.HourlyStatistics[day.Unix()]; exists {
+		t.Fatal("expected the hourly statistics of a month ago to have been deleted")
+	}
+	checkHourlyStatistics(t, getUptimeStatistics(status.Uptime, day, day.Add(47*time.Hour)), 48, 48, 36)
+}
+
 func checkHourlyStatistics(t *testing.T, hourlyUptimeStatistics *endpoint.HourlyUptimeStatistics, expectedTotalExecutionsResponseTime uint64, expectedTotalExecutions uint64, expectedSuccessfulExecutions uint64) {
 	if hourlyUptimeStatistics.TotalExecutionsResponseTime != expectedTotalExecutionsResponseTime {
 		t.Error("TotalExecutionsResponseTime should've been", expectedTotalExecutionsResponseTime, "got", hourlyUptimeStatistics.TotalExecutionsResponseTime)
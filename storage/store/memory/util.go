@@ -1,6 +1,8 @@
 package memory
 
 import (
+	"sort"
+
 	"github.com/TwiN/gatus/v5/config/endpoint"
 	"github.com/TwiN/gatus/v5/storage/store/common"
 	"github.com/TwiN/gatus/v5/storage/store/common/paging"
@@ -52,30 +54,52 @@ func getStartAndEndIndex(numberOfResults int, page, pageSize int) (int, int) {
 // AddResult adds a Result to Status.Results and makes sure that there are
 // no more than MaximumNumberOfResults results in the Results slice
 func AddResult(ss *endpoint.Status, result *endpoint.Result) {
+	addResult(ss, result, common.DefaultRetentionPolicy)
+}
+
+// addResult adds a Result to Status.Results and removes the results and events that are no longer retained by the
+// retention policy passed as parameter, and returns the results that have been removed
+func addResult(ss *endpoint.Status, result *endpoint.Result, policy common.RetentionPolicy) []*endpoint.Result {
 	if ss == nil {
-		return
+		return nil
 	}
 	if len(ss.Results) > 0 {
 		// Check if there's any change since the last result
 		if ss.Results[len(ss.Results)-1].Success != result.Success {
 			ss.Events = append(ss.Events, endpoint.NewEventFromResult(result))
-			if len(ss.Events) > common.MaximumNumberOfEvents {
-				// Doing ss.Events[1:] would usually be sufficient, but in the case where for some reason, the slice has
-				// more than one extra element, we can get rid of all of them at once and thus returning the slice to a
-				// length of MaximumNumberOfEvents by using ss.Events[len(ss.Events)-MaximumNumberOfEvents:] instead
-				ss.Events = ss.Events[len(ss.Events)-common.MaximumNumberOfEvents:]
-			}
 		}
 	} else {
 		// This is the first result, so we need to add the first healthy/unhealthy event
 		ss.Events = append(ss.Events, endpoint.NewEventFromResult(result))
 	}
+	if len(ss.Events) > policy.MaximumNumberOfEvents {
+		// Doing ss.Events[1:] would usually be sufficient, but in the case where for some reason, the slice has
+		// more than one extra element, we can get rid of all of them at once and thus returning the slice to a
+		// length of MaximumNumberOfEvents by using ss.Events[len(ss.Events)-MaximumNumberOfEvents:] instead
+		ss.Events = ss.Events[len(ss.Events)-policy.MaximumNumberOfEvents:]
+	}
 	ss.Results = append(ss.Results, result)
-	if len(ss.Results) > common.MaximumNumberOfResults {
-		// Doing ss.Results[1:] would usually be sufficient, but in the case where for some reason, the slice has more
-		// than one extra element, we can get rid of all of them at once and thus returning the slice to a length of
-		// MaximumNumberOfResults by using ss.Results[len(ss.Results)-MaximumNumberOfResults:] instead
-		ss.Results = ss.Results[len(ss.Results)-common.MaximumNumberOfResults:]
+	numberOfResultsToRemove := len(ss.Results) - policy.MaximumNumberOfResults
+	if policy.MaximumAge > 0 {
+		// Results are sorted from the oldest to the newest, so the results that are too old are all at the beginning
+		oldest := result.Timestamp.Add(-policy.MaximumAge)
+		numberOfResultsTooOld := sort.Search(len(ss.Results), func(i int) bool {
+			return !ss.Results[i].Timestamp.Before(oldest)
+		})
+		if numberOfResultsTooOld > numberOfResultsToRemove {
+			numberOfResultsToRemove = numberOfResultsTooOld
+		}
+		// The most recent event is always kept, since it's the current state of the endpoint
+		numberOfEventsTooOld := sort.Search(len(ss.Events)-1, func(i int) bool {
+			return !ss.Events[i].Timestamp.Before(oldest)
+		})
+		ss.Events = ss.Events[numberOfEventsTooOld:]
+	}
+	var removedResults []*endpoint.Result
+	if numberOfResultsToRemove > 0 {
+		removedResults = ss.Results[:numberOfResultsToRemove]
+		ss.Results = ss.Results[numberOfResultsToRemove:]
 	}
 	processUptimeAfterResult(ss.Uptime, result)
+	return removedResults
 }
@@ -25,6 +25,37 @@ func TestAddResult(t *testing.T) {
 	AddResult(nil, &endpoint.Result{Timestamp: time.Now()})
 }
 
+func TestAddResultWithRetentionPolicy(t *testing.T) {
+	ep := &endpoint.Endpoint{Name: "name", Group: "group"}
+	endpointStatus := endpoint.NewStatus(ep.Group, ep.Name)
+	policy := common.RetentionPolicy{MaximumNumberOfResults: 10, MaximumNumberOfEvents: 4, MaximumAge: 30 * time.Minute}
+	now := time.Now()
+	var numberOfRemovedResults int
+	for i := 0; i < 20; i++ {
+		numberOfRemovedResults += len(addResult(endpointStatus, &endpoint.Result{Success: i%2 == 0, Timestamp: now.Add(time.Duration(i) * time.Minute)}, policy))
+	}
+	if len(endpointStatus.Results) != 10 {
+		t.Errorf("expected endpointStatus.Results to not exceed a length of 10, got %d", len(endpointStatus.Results))
+	}
+	if len(endpointStatus.Events) != 4 {
+		t.Errorf("expected endpointStatus.Events to not exceed a length of 4, got %d", len(endpointStatus.Events))
+	}
+	if numberOfRemovedResults != 10 {
+		t.Errorf("expected 10 results to have been removed, got %d", numberOfRemovedResults)
+	}
+	// Adding a result an hour later should remove every result and event older than the maximum age, except the last event
+	removedResults := addResult(endpointStatus, &endpoint.Result{Success: true, Timestamp: now.Add(80 * time.Minute)}, policy)
+	if len(removedResults) != 10 {
+		t.Errorf("expected 10 results to have been removed, got %d", len(removedResults))
+	}
+	if len(endpointStatus.Results) != 1 {
+		t.Errorf("expected 1 result, got %d", len(endpointStatus.Results))
+	}
+	if len(endpointStatus.Events) != 1 {
+		t.Errorf("expected 1 event, got %d", len(endpointStatus.Events))
+	}
+}
+
 func TestShallowCopyEndpointStatus(t *testing.T) {
 	ep := &endpoint.Endpoint{Name: "name", Group: "group"}
 	endpointStatus := endpoint.NewStatus(ep.Group, ep.Name)
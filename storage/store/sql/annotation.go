@@ -0,0 +1,127 @@
+package sql
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+)
+
+// InsertAnnotation adds an annotation to the store.
+//
+// Unlike the results, annotations are never cleaned up.
+func (s *Store) InsertAnnotation(annotation *endpoint.Annotation) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	var annotationID int64
+	err = tx.QueryRow(
+		"INSERT INTO annotations (title, description, timestamp) VALUES ($1, $2, $3) RETURNING annotation_id",
+		annotation.Title,
+		annotation.Description,
+		annotation.Timestamp.UTC(),
+	).Scan(&annotationID)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	for _, key := range annotation.EndpointKeys {
+		if _, err = tx.Exec("INSERT INTO annotation_endpoints (annotation_id, endpoint_key) VALUES ($1, $2)", annotationID, key); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+	}
+	return err
+}
+
+// GetAnnotationsByKey returns the annotations affecting an endpoint during a time range, from oldest to newest
+func (s *Store) GetAnnotationsByKey(key string, from, to time.Time) ([]*endpoint.Annotation, error) {
+	if from.After(to) {
+		return nil, common.ErrInvalidTimeRange
+	}
+	tx, err := s.readDB().Begin()
+	if err != nil {
+		return nil, err
+	}
+	if _, _, _, err = s.getEndpointIDGroupAndNameByKey(tx, key); err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	annotations, err := s.getAnnotationsByEndpointKey(tx, key, from, to)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+	}
+	return annotations, nil
+}
+
+// getAnnotationsByEndpointKey returns the annotations that either affect the endpoint with the key passed as parameter
+// or every endpoint during a time range, from oldest to newest
+func (s *Store) getAnnotationsByEndpointKey(tx *sql.Tx, key string, from, to time.Time) ([]*endpoint.Annotation, error) {
+	rows, err := tx.Query(
+		`
+			SELECT annotation_id, title, description, timestamp
+			FROM annotations
+			WHERE timestamp >= $1
+				AND timestamp <= $2
+				AND (
+					NOT EXISTS (SELECT 1 FROM annotation_endpoints WHERE annotation_endpoints.annotation_id = annotations.annotation_id)
+					OR EXISTS (SELECT 1 FROM annotation_endpoints WHERE annotation_endpoints.annotation_id = annotations.annotation_id AND endpoint_key = $3)
+				)
+			ORDER BY timestamp, annotation_id
+		`,
+		from.UTC(),
+		to.UTC(),
+		key,
+	)
+	if err != nil {
+		return nil, err
+	}
+	var annotationIDs []int64
+	annotations := make([]*endpoint.Annotation, 0)
+	for rows.Next() {
+		var annotationID int64
+		annotation := &endpoint.Annotation{}
+		if err = rows.Scan(&annotationID, &annotation.Title, &annotation.Description, &annotation.Timestamp); err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		annotationIDs = append(annotationIDs, annotationID)
+		annotations = append(annotations, annotation)
+	}
+	_ = rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	for i, annotationID := range annotationIDs {
+		if annotations[i].EndpointKeys, err = s.getAnnotationEndpointKeys(tx, annotationID); err != nil {
+			return nil, err
+		}
+	}
+	return annotations, nil
+}
+
+// getAnnotationEndpointKeys returns the keys of the endpoints affected by an annotation
+func (s *Store) getAnnotationEndpointKeys(tx *sql.Tx, annotationID int64) (keys []string, err error) {
+	rows, err := tx.Query("SELECT endpoint_key FROM annotation_endpoints WHERE annotation_id = $1 ORDER BY endpoint_key", annotationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var key string
+		if err = rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
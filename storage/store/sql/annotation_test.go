@@ -0,0 +1,47 @@
+package sql
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+)
+
+func TestStore_InsertAnnotation(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_InsertAnnotation.db", false)
+	defer store.Close()
+	store.Insert(&testEndpoint, &testSuccessfulResult)
+	now := time.Now()
+	annotations := []*endpoint.Annotation{
+		{Timestamp: now.Add(-2 * time.Hour), Title: "Deployed v1.2.3", Description: "Rolled out to 100%", EndpointKeys: []string{"other_endpoint", testEndpoint.Key()}},
+		{Timestamp: now.Add(-3 * time.Hour), Title: "Migrated the database"},
+		{Timestamp: now.Add(-time.Hour), Title: "Deployed other endpoint", EndpointKeys: []string{"other_endpoint"}},
+		{Timestamp: now.Add(-48 * time.Hour), Title: "Too old"},
+	}
+	for _, annotation := range annotations {
+		if err := store.InsertAnnotation(annotation); err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+	}
+	retrievedAnnotations, err := store.GetAnnotationsByKey(testEndpoint.Key(), now.Add(-24*time.Hour), now)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(retrievedAnnotations) != 2 {
+		t.Fatalf("expected %d annotations, got %d", 2, len(retrievedAnnotations))
+	}
+	if retrievedAnnotations[0].Title != "Migrated the database" || len(retrievedAnnotations[0].EndpointKeys) != 0 {
+		t.Errorf("expected the oldest annotation to affect every endpoint, got %+v", retrievedAnnotations[0])
+	}
+	if annotation := retrievedAnnotations[1]; annotation.Title != "Deployed v1.2.3" || annotation.Description != "Rolled out to 100%" || len(annotation.EndpointKeys) != 2 || annotation.Timestamp.Unix() != now.Add(-2*time.Hour).Unix() {
+		t.Errorf("expected annotation to be persisted as is, got %+v", annotation)
+	}
+	if _, err = store.GetAnnotationsByKey("nope", now.Add(-time.Hour), now); !errors.Is(err, common.ErrEndpointNotFound) {
+		t.Errorf("expected error %v, got %v", common.ErrEndpointNotFound, err)
+	}
+	if _, err = store.GetAnnotationsByKey(testEndpoint.Key(), now, now.Add(-time.Hour)); !errors.Is(err, common.ErrInvalidTimeRange) {
+		t.Errorf("expected error %v, got %v", common.ErrInvalidTimeRange, err)
+	}
+}
@@ -0,0 +1,82 @@
+package sql
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/TwiN/gatus/v5/announcement"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+)
+
+// InsertAnnouncement adds an announcement to the store and sets its ID.
+//
+// Unlike the results, announcements are never cleaned up, even after they expired.
+func (s *Store) InsertAnnouncement(a *announcement.Announcement) error {
+	var expiresAt sql.NullTime
+	if a.ExpiresAt != nil {
+		expiresAt = sql.NullTime{Time: a.ExpiresAt.UTC(), Valid: true}
+	}
+	return s.db.QueryRow(
+		"INSERT INTO announcements (type, message, timestamp, expires_at) VALUES ($1, $2, $3, $4) RETURNING announcement_id",
+		a.Type,
+		a.Message,
+		a.Timestamp.UTC(),
+		expiresAt,
+	).Scan(&a.ID)
+}
+
+// ExpireAnnouncement makes the announcement with the ID passed as parameter expire at the time passed as parameter,
+// unless it already expired before then
+func (s *Store) ExpireAnnouncement(id int64, at time.Time) error {
+	result, err := s.db.Exec(
+		"UPDATE announcements SET expires_at = $1 WHERE announcement_id = $2 AND (expires_at IS NULL OR expires_at > $1)",
+		at.UTC(),
+		id,
+	)
+	if err != nil {
+		return err
+	}
+	if numberOfRowsUpdated, _ := result.RowsAffected(); numberOfRowsUpdated > 0 {
+		return nil
+	}
+	// Nothing was updated, either because the announcement already expired, or because it doesn't exist
+	var exists bool
+	if err = s.db.QueryRow("SELECT EXISTS (SELECT 1 FROM announcements WHERE announcement_id = $1)", id).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return common.ErrAnnouncementNotFound
+	}
+	return nil
+}
+
+// GetActiveAnnouncements returns the announcements that haven't expired at the time passed as parameter, from newest
+// to oldest
+func (s *Store) GetActiveAnnouncements(now time.Time) ([]*announcement.Announcement, error) {
+	rows, err := s.readDB().Query(
+		`
+			SELECT announcement_id, type, message, timestamp, expires_at
+			FROM announcements
+			WHERE expires_at IS NULL OR expires_at > $1
+			ORDER BY announcement_id DESC
+		`,
+		now.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	announcements := make([]*announcement.Announcement, 0)
+	for rows.Next() {
+		a := &announcement.Announcement{}
+		var expiresAt sql.NullTime
+		if err = rows.Scan(&a.ID, &a.Type, &a.Message, &a.Timestamp, &expiresAt); err != nil {
+			return nil, err
+		}
+		if expiresAt.Valid {
+			a.ExpiresAt = &expiresAt.Time
+		}
+		announcements = append(announcements, a)
+	}
+	return announcements, rows.Err()
+}
@@ -0,0 +1,76 @@
+package sql
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/announcement"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+)
+
+func TestStore_InsertAnnouncement(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_InsertAnnouncement.db", false)
+	defer store.Close()
+	now := time.Now().Truncate(time.Second)
+	later := now.Add(time.Hour)
+	first := &announcement.Announcement{Type: announcement.TypeMaintenance, Message: "Maintenance tonight", Timestamp: now}
+	second := &announcement.Announcement{Type: announcement.TypeWarning, Message: "Degraded performance", Timestamp: now, ExpiresAt: &later}
+	for _, a := range []*announcement.Announcement{first, second} {
+		if err := store.InsertAnnouncement(a); err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+	}
+	if first.ID == 0 || second.ID <= first.ID {
+		t.Errorf("expected the IDs to be set in increasing order, got %d and %d", first.ID, second.ID)
+	}
+	announcements, err := store.GetActiveAnnouncements(now)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(announcements) != 2 || announcements[0].ID != second.ID || announcements[1].ID != first.ID {
+		t.Fatalf("expected both announcements from newest to oldest, got %+v", announcements)
+	}
+	if a := announcements[0]; a.Type != announcement.TypeWarning || a.Message != "Degraded performance" || !a.Timestamp.Equal(now) || a.ExpiresAt == nil || !a.ExpiresAt.Equal(later) {
+		t.Errorf("expected announcement to be persisted as is, got %+v", a)
+	}
+	if announcements[1].ExpiresAt != nil {
+		t.Errorf("expected announcement without expiration to have none, got %s", announcements[1].ExpiresAt)
+	}
+	if announcements, _ = store.GetActiveAnnouncements(later); len(announcements) != 1 || announcements[0].ID != first.ID {
+		t.Errorf("expected only the announcement without expiration to be active, got %+v", announcements)
+	}
+	store.Clear()
+	if announcements, _ = store.GetActiveAnnouncements(now); len(announcements) != 0 {
+		t.Errorf("expected announcements to be cleared, got %d", len(announcements))
+	}
+}
+
+func TestStore_ExpireAnnouncement(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_ExpireAnnouncement.db", false)
+	defer store.Close()
+	now := time.Now()
+	a := &announcement.Announcement{Type: announcement.TypeOutage, Message: "Outage", Timestamp: now}
+	if err := store.InsertAnnouncement(a); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if err := store.ExpireAnnouncement(a.ID, now.Add(time.Minute)); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if announcements, _ := store.GetActiveAnnouncements(now); len(announcements) != 1 {
+		t.Errorf("expected the announcement to be active until it expires, got %d announcements", len(announcements))
+	}
+	if announcements, _ := store.GetActiveAnnouncements(now.Add(time.Minute)); len(announcements) != 0 {
+		t.Errorf("expected the announcement to have expired, got %d announcements", len(announcements))
+	}
+	// Expiring an announcement that already expired must not bring it back
+	if err := store.ExpireAnnouncement(a.ID, now.Add(time.Hour)); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if announcements, _ := store.GetActiveAnnouncements(now.Add(30 * time.Minute)); len(announcements) != 0 {
+		t.Errorf("expected the announcement to remain expired, got %d announcements", len(announcements))
+	}
+	if err := store.ExpireAnnouncement(a.ID+1, now); !errors.Is(err, common.ErrAnnouncementNotFound) {
+		t.Errorf("expected %v, got %v", common.ErrAnnouncementNotFound, err)
+	}
+}
@@ -0,0 +1,71 @@
+package sql
+
+import (
+	"time"
+
+	"github.com/TwiN/gatus/v5/audit"
+	"github.com/TwiN/gatus/v5/metrics"
+)
+
+// MaximumNumberOfAuditEntries is the number of audit entries kept, beyond which the oldest entries are deleted, so
+// that the audit log doesn't grow without bound with the requests authenticated with an API token and the failed
+// attempts to authenticate
+const MaximumNumberOfAuditEntries = 100000
+
+// InsertAuditEntry records an administrative action in the audit log, and deletes the entries beyond the
+// MaximumNumberOfAuditEntries most recent ones
+func (s *Store) InsertAuditEntry(entry *audit.Entry) error {
+	var auditEntryID int64
+	err := s.db.QueryRow(
+		"INSERT INTO audit_entries (timestamp, action, actor, target, success, details) VALUES ($1, $2, $3, $4, $5, $6) RETURNING audit_entry_id",
+		entry.Timestamp.UTC(),
+		entry.Action,
+		entry.Actor,
+		entry.Target,
+		entry.Success,
+		entry.Details,
+	).Scan(&auditEntryID)
+	if err != nil {
+		return err
+	}
+	return s.deleteOldAuditEntries(auditEntryID, MaximumNumberOfAuditEntries)
+}
+
+// deleteOldAuditEntries deletes the audit entries that are no longer needed, which are all entries but the
+// numberOfAuditEntriesToKeep ones up to the entry whose ID is passed. Since the IDs are increasing, this only relies on
+// the primary key rather than on counting the entries.
+func (s *Store) deleteOldAuditEntries(lastAuditEntryID int64, numberOfAuditEntriesToKeep int) error {
+	if lastAuditEntryID <= int64(numberOfAuditEntriesToKeep) {
+		return nil
+	}
+	defer metrics.ObserveStoreCleanUp(s.driver, "audit", time.Now())
+	_, err := s.db.Exec("DELETE FROM audit_entries WHERE audit_entry_id <= $1", lastAuditEntryID-int64(numberOfAuditEntriesToKeep))
+	return err
+}
+
+// GetAuditEntries returns a page of the audit log, from newest to oldest
+func (s *Store) GetAuditEntries(page, pageSize int) ([]*audit.Entry, error) {
+	rows, err := s.readDB().Query(
+		`
+			SELECT timestamp, action, actor, target, success, details
+			FROM audit_entries
+			ORDER BY audit_entry_id DESC
+			LIMIT $1 OFFSET $2
+		`,
+		pageSize,
+		(page-1)*pageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	entries := make([]*audit.Entry, 0, pageSize)
+	for rows.Next() {
+		entry := &audit.Entry{}
+		if err = rows.Scan(&entry.Timestamp, &entry.Action, &entry.Actor, &entry.Target, &entry.Success, &entry.Details); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
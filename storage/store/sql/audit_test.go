@@ -0,0 +1,58 @@
+package sql
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/audit"
+)
+
+func TestStore_InsertAuditEntry(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_InsertAuditEntry.db", false)
+	defer store.Close()
+	for i := 0; i < 5; i++ {
+		if err := store.InsertAuditEntry(audit.NewEntry(audit.ActionExternalEndpointTokenUsage, "127.0.0.1", strconv.Itoa(i), i%2 == 0, "")); err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+	}
+	entries, err := store.GetAuditEntries(1, 2)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(entries) != 2 || entries[0].Target != "4" || entries[1].Target != "3" {
+		t.Fatalf("expected the first page to hold the newest entries, got %+v", entries)
+	}
+	if entry := entries[0]; entry.Action != audit.ActionExternalEndpointTokenUsage || entry.Actor != "127.0.0.1" || !entry.Success || entry.Timestamp.IsZero() {
+		t.Errorf("expected entry to be persisted as is, got %+v", entry)
+	}
+	if entries, _ = store.GetAuditEntries(3, 2); len(entries) != 1 || entries[0].Target != "0" {
+		t.Errorf("expected the last page to hold the oldest entry, got %+v", entries)
+	}
+	store.Clear()
+	if entries, _ = store.GetAuditEntries(1, 20); len(entries) != 0 {
+		t.Errorf("expected audit log to be cleared, got %d entries", len(entries))
+	}
+}
+
+func TestStore_deleteOldAuditEntries(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_deleteOldAuditEntries.db", false)
+	defer store.Close()
+	for i := 0; i < 5; i++ {
+		if err := store.InsertAuditEntry(audit.NewEntry(audit.ActionTokenUsage, "127.0.0.1", strconv.Itoa(i), true, "")); err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+	}
+	if err := store.deleteOldAuditEntries(5, 10); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if entries, _ := store.GetAuditEntries(1, 20); len(entries) != 5 {
+		t.Errorf("expected no entry to be deleted while there are fewer entries than the maximum, got %d entries", len(entries))
+	}
+	if err := store.deleteOldAuditEntries(5, 2); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	entries, _ := store.GetAuditEntries(1, 20)
+	if len(entries) != 2 || entries[0].Target != "4" || entries[1].Target != "3" {
+		t.Errorf("expected only the 2 most recent entries to be kept, got %+v", entries)
+	}
+}
@@ -0,0 +1,301 @@
+package sql
+
+import (
+	"database/sql"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+)
+
+// aggregate is the statistics of the results of an endpoint over the hour or the day starting at bucket
+type aggregate struct {
+	bucket               int64
+	totalExecutions      int64
+	successfulExecutions int64
+	totalResponseTime    int64
+	minimumResponseTime  int64
+	maximumResponseTime  int64
+	p95ResponseTime      int64
+}
+
+// Downsample collapses the results older than hourlyAfter into hourly aggregates, and the hourly aggregates older
+// than dailyAfter into daily aggregates. Daily aggregates older than retention are deleted.
+//
+// Only complete hours and days are downsampled, so that results of the same hour aren't aggregated separately.
+func (s *Store) Downsample(now time.Time, hourlyAfter, dailyAfter, retention time.Duration) (err error) {
+	defer func(start time.Time) { s.observe("Downsample", start, err) }(time.Now())
+	rows, err := s.db.Query("SELECT endpoint_id, endpoint_key FROM endpoints")
+	if err != nil {
+		return err
+	}
+	endpointKeys := make(map[int64]string)
+	for rows.Next() {
+		var endpointID int64
+		var endpointKey string
+		if err = rows.Scan(&endpointID, &endpointKey); err != nil {
+			_ = rows.Close()
+			return err
+		}
+		endpointKeys[endpointID] = endpointKey
+	}
+	_ = rows.Close()
+	hourlyBefore := now.Add(-hourlyAfter).Truncate(time.Hour)
+	dailyBefore := now.Add(-dailyAfter).Truncate(24 * time.Hour)
+	for endpointID, endpointKey := range endpointKeys {
+		tx, err := s.db.Begin()
+		if err != nil {
+			return err
+		}
+		numberOfDownsampledResults, err := s.downsampleEndpointResults(tx, endpointID, hourlyBefore)
+		if err == nil {
+			err = s.downsampleEndpointHourlyAggregates(tx, endpointID, dailyBefore)
+		}
+		if err == nil {
+			_, err = tx.Exec("DELETE FROM endpoint_daily_aggregates WHERE endpoint_id = $1 AND bucket_unix_timestamp < $2", endpointID, now.Add(-retention).Unix())
+		}
+		if err != nil {
+			log.Printf("[sql.Downsample] Failed to downsample results of endpoint with key=%s: %s", endpointKey, err.Error())
+			_ = tx.Rollback()
+			continue
+		}
+		if err = tx.Commit(); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		if numberOfDownsampledResults > 0 && s.writeThroughCache != nil {
+			// The cached statuses may contain the results that were just deleted
+			_ = s.writeThroughCache.DeleteKeysByPattern(endpointKey + "*")
+		}
+	}
+	return nil
+}
+
+// GetAggregatesByKey returns the aggregates of an endpoint during a time range, sorted from oldest to newest, at the
+// resolution specified, which must be either time.Hour or 24*time.Hour
+func (s *Store) GetAggregatesByKey(key string, resolution time.Duration, from, to time.Time) ([]*endpoint.Aggregate, error) {
+	if from.After(to) {
+		return nil, common.ErrInvalidTimeRange
+	}
+	table, err := aggregateTable(resolution)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := s.readDB().Begin()
+	if err != nil {
+		return nil, err
+	}
+	endpointID, _, _, err := s.getEndpointIDGroupAndNameByKey(tx, key)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	aggregates, err := s.getEndpointAggregates(tx, table, endpointID, from.Truncate(resolution).Unix(), to.Unix())
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+	}
+	results := make([]*endpoint.Aggregate, 0, len(aggregates))
+	for _, a := range aggregates {
+		results = append(results, &endpoint.Aggregate{
+			Timestamp:            time.Unix(a.bucket, 0).UTC(),
+			TotalExecutions:      a.totalExecutions,
+			SuccessfulExecutions: a.successfulExecutions,
+			Uptime:               float64(a.successfulExecutions) / float64(a.totalExecutions),
+			AverageResponseTime:  a.totalResponseTime / a.totalExecutions,
+			MinimumResponseTime:  a.minimumResponseTime,
+			MaximumResponseTime:  a.maximumResponseTime,
+			P95ResponseTime:      a.p95ResponseTime,
+		})
+	}
+	return results, nil
+}
+
+func aggregateTable(resolution time.Duration) (string, error) {
+	switch resolution {
+	case time.Hour:
+		return "endpoint_hourly_aggregates", nil
+	case 24 * time.Hour:
+		return "endpoint_daily_aggregates", nil
+	default:
+		return "", common.ErrInvalidResolution
+	}
+}
+
+// downsampleEndpointResults aggregates the results older than before into hourly aggregates and deletes them.
+// Returns the number of results that were downsampled.
+func (s *Store) downsampleEndpointResults(tx *sql.Tx, endpointID int64, before time.Time) (int, error) {
+	rows, err := tx.Query(
+		`
+			SELECT success, duration, timestamp
+			FROM endpoint_results
+			WHERE endpoint_id = $1
+				AND timestamp < $2
+			ORDER BY timestamp
+		`,
+		endpointID,
+		before.UTC(),
+	)
+	if err != nil {
+		return 0, err
+	}
+	var aggregates []*aggregate
+	var responseTimes []int64
+	var numberOfResults int
+	for rows.Next() {
+		var success bool
+		var duration time.Duration
+		var timestamp time.Time
+		if err = rows.Scan(&success, &duration, &timestamp); err != nil {
+			_ = rows.Close()
+			return 0, err
+		}
+		numberOfResults++
+		bucket := timestamp.Truncate(time.Hour).Unix()
+		if len(aggregates) == 0 || aggregates[len(aggregates)-1].bucket != bucket {
+			if len(aggregates) > 0 {
+				aggregates[len(aggregates)-1].p95ResponseTime = percentile(responseTimes, 0.95)
+			}
+			aggregates = append(aggregates, &aggregate{bucket: bucket, minimumResponseTime: math.MaxInt64})
+			responseTimes = responseTimes[:0]
+		}
+		a, responseTime := aggregates[len(aggregates)-1], duration.Milliseconds()
+		a.totalExecutions++
+		if success {
+			a.successfulExecutions++
+		}
+		a.totalResponseTime += responseTime
+		a.minimumResponseTime = min(a.minimumResponseTime, responseTime)
+		a.maximumResponseTime = max(a.maximumResponseTime, responseTime)
+		responseTimes = append(responseTimes, responseTime)
+	}
+	_ = rows.Close()
+	if len(aggregates) == 0 {
+		return 0, nil
+	}
+	aggregates[len(aggregates)-1].p95ResponseTime = percentile(responseTimes, 0.95)
+	for _, a := range aggregates {
+		if err = s.upsertEndpointAggregate(tx, "endpoint_hourly_aggregates", endpointID, a); err != nil {
+			return 0, err
+		}
+	}
+	if len(s.partitioning) > 0 {
+		// Partitioned condition results don't reference the results, so they aren't deleted in cascade
+		_, err = tx.Exec("DELETE FROM endpoint_result_conditions WHERE endpoint_result_id IN (SELECT endpoint_result_id FROM endpoint_results WHERE endpoint_id = $1 AND timestamp < $2)", endpointID, before.UTC())
+		if err != nil {
+			return 0, err
+		}
+	}
+	_, err = tx.Exec("DELETE FROM endpoint_results WHERE endpoint_id = $1 AND timestamp < $2", endpointID, before.UTC())
+	return numberOfResults, err
+}
+
+// downsampleEndpointHourlyAggregates merges the hourly aggregates older than before into daily aggregates and
+// deletes them.
+//
+// Since the response times of each hour are no longer available, the 95th percentile of a day is approximated as the
+// highest 95th percentile of its hours.
+func (s *Store) downsampleEndpointHourlyAggregates(tx *sql.Tx, endpointID int64, before time.Time) error {
+	hourlyAggregates, err := s.getEndpointAggregates(tx, "endpoint_hourly_aggregates", endpointID, 0, before.Unix()-1)
+	if err != nil || len(hourlyAggregates) == 0 {
+		return err
+	}
+	var dailyAggregates []*aggregate
+	for _, hourlyAggregate := range hourlyAggregates {
+		bucket := time.Unix(hourlyAggregate.bucket, 0).Truncate(24 * time.Hour).Unix()
+		if len(dailyAggregates) == 0 || dailyAggregates[len(dailyAggregates)-1].bucket != bucket {
+			dailyAggregates = append(dailyAggregates, &aggregate{bucket: bucket, minimumResponseTime: math.MaxInt64})
+		}
+		mergeAggregates(dailyAggregates[len(dailyAggregates)-1], hourlyAggregate)
+	}
+	for _, dailyAggregate := range dailyAggregates {
+		if err = s.upsertEndpointAggregate(tx, "endpoint_daily_aggregates", endpointID, dailyAggregate); err != nil {
+			return err
+		}
+	}
+	_, err = tx.Exec("DELETE FROM endpoint_hourly_aggregates WHERE endpoint_id = $1 AND bucket_unix_timestamp < $2", endpointID, before.Unix())
+	return err
+}
+
+func (s *Store) getEndpointAggregates(tx *sql.Tx, table string, endpointID int64, from, to int64) ([]*aggregate, error) {
+	rows, err := tx.Query(
+		`
+			SELECT bucket_unix_timestamp, total_executions, successful_executions, total_response_time, minimum_response_time, maximum_response_time, p95_response_time
+			FROM `+table+`
+			WHERE endpoint_id = $1
+				AND total_executions > 0
+				AND bucket_unix_timestamp >= $2
+				AND bucket_unix_timestamp <= $3
+			ORDER BY bucket_unix_timestamp
+		`,
+		endpointID,
+		from,
+		to,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var aggregates []*aggregate
+	for rows.Next() {
+		a := &aggregate{}
+		if err = rows.Scan(&a.bucket, &a.totalExecutions, &a.successfulExecutions, &a.totalResponseTime, &a.minimumResponseTime, &a.maximumResponseTime, &a.p95ResponseTime); err != nil {
+			return nil, err
+		}
+		aggregates = append(aggregates, a)
+	}
+	return aggregates, rows.Err()
+}
+
+// upsertEndpointAggregate inserts an aggregate, or merges it with the existing aggregate of the same bucket, which
+// only happens if results were inserted with a timestamp older than the results already downsampled
+func (s *Store) upsertEndpointAggregate(tx *sql.Tx, table string, endpointID int64, a *aggregate) error {
+	_, err := tx.Exec(
+		`
+			INSERT INTO `+table+` (endpoint_id, bucket_unix_timestamp, total_executions, successful_executions, total_response_time, minimum_response_time, maximum_response_time, p95_response_time)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			ON CONFLICT(endpoint_id, bucket_unix_timestamp) DO UPDATE SET
+				total_executions = excluded.total_executions + `+table+`.total_executions,
+				successful_executions = excluded.successful_executions + `+table+`.successful_executions,
+				total_response_time = excluded.total_response_time + `+table+`.total_response_time,
+				minimum_response_time = CASE WHEN excluded.minimum_response_time < `+table+`.minimum_response_time THEN excluded.minimum_response_time ELSE `+table+`.minimum_response_time END,
+				maximum_response_time = CASE WHEN excluded.maximum_response_time > `+table+`.maximum_response_time THEN excluded.maximum_response_time ELSE `+table+`.maximum_response_time END,
+				p95_response_time = CASE WHEN excluded.p95_response_time > `+table+`.p95_response_time THEN excluded.p95_response_time ELSE `+table+`.p95_response_time END
+		`,
+		endpointID,
+		a.bucket,
+		a.totalExecutions,
+		a.successfulExecutions,
+		a.totalResponseTime,
+		a.minimumResponseTime,
+		a.maximumResponseTime,
+		a.p95ResponseTime,
+	)
+	return err
+}
+
+// mergeAggregates merges the aggregate src into dst, approximating the 95th percentile as the highest of both
+func mergeAggregates(dst, src *aggregate) {
+	dst.totalExecutions += src.totalExecutions
+	dst.successfulExecutions += src.successfulExecutions
+	dst.totalResponseTime += src.totalResponseTime
+	dst.minimumResponseTime = min(dst.minimumResponseTime, src.minimumResponseTime)
+	dst.maximumResponseTime = max(dst.maximumResponseTime, src.maximumResponseTime)
+	dst.p95ResponseTime = max(dst.p95ResponseTime, src.p95ResponseTime)
+}
+
+// percentile returns the value below which the fraction p of the values fall, using the nearest-rank method.
+// The values passed as parameter are sorted in place.
+func percentile(values []int64, p float64) int64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	return values[int(math.Ceil(p*float64(len(values))))-1]
+}
@@ -0,0 +1,111 @@
+package sql
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+)
+
+func TestStore_Downsample(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_Downsample.db", true)
+	defer store.Close()
+	now := time.Now().Truncate(time.Hour)
+	insert := func(timestamp time.Time, success bool, duration time.Duration) {
+		result := testSuccessfulResult
+		result.Timestamp, result.Success, result.Duration = timestamp, success, duration
+		if err := store.Insert(&testEndpoint, &result); err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+	}
+	// Results of 40 days ago, which should end up in a daily aggregate
+	day := now.Truncate(24 * time.Hour).Add(-40 * 24 * time.Hour)
+	insert(day.Add(time.Hour), true, 100*time.Millisecond)
+	insert(day.Add(2*time.Hour), false, 500*time.Millisecond)
+	// Results of 10 days ago, which should end up in an hourly aggregate
+	for i := 1; i <= 20; i++ {
+		insert(now.Add(-10*24*time.Hour+time.Duration(i)*time.Second), i != 20, time.Duration(i)*10*time.Millisecond)
+	}
+	// Recent result, which shouldn't be downsampled
+	insert(now, true, 150*time.Millisecond)
+	// Populate the cache
+	_, _ = store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, common.MaximumNumberOfResults))
+	if err := store.Downsample(now, 7*24*time.Hour, 30*24*time.Hour, 365*24*time.Hour); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	ss, _ := store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, common.MaximumNumberOfResults))
+	if len(ss.Results) != 1 {
+		t.Errorf("expected only the recent result to be left, got %d results", len(ss.Results))
+	}
+	hourlyAggregates, err := store.GetAggregatesByKey(testEndpoint.Key(), time.Hour, now.Add(-365*24*time.Hour), now)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(hourlyAggregates) != 1 {
+		t.Fatalf("expected 1 hourly aggregate, got %d", len(hourlyAggregates))
+	}
+	if a := hourlyAggregates[0]; a.TotalExecutions != 20 || a.SuccessfulExecutions != 19 || a.Uptime != 0.95 || a.AverageResponseTime != 105 || a.MinimumResponseTime != 10 || a.MaximumResponseTime != 200 || a.P95ResponseTime != 190 {
+		t.Errorf("unexpected hourly aggregate %+v", a)
+	}
+	if !hourlyAggregates[0].Timestamp.Equal(now.Add(-10 * 24 * time.Hour)) {
+		t.Errorf("expected hourly aggregate to start at %s, got %s", now.Add(-10*24*time.Hour), hourlyAggregates[0].Timestamp)
+	}
+	dailyAggregates, err := store.GetAggregatesByKey(testEndpoint.Key(), 24*time.Hour, now.Add(-365*24*time.Hour), now)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(dailyAggregates) != 1 {
+		t.Fatalf("expected 1 daily aggregate, got %d", len(dailyAggregates))
+	}
+	if a := dailyAggregates[0]; a.TotalExecutions != 2 || a.SuccessfulExecutions != 1 || a.AverageResponseTime != 300 || a.MinimumResponseTime != 100 || a.MaximumResponseTime != 500 || a.P95ResponseTime != 500 {
+		t.Errorf("unexpected daily aggregate %+v", a)
+	}
+	// Downsampling again shouldn't change anything
+	if err := store.Downsample(now, 7*24*time.Hour, 30*24*time.Hour, 365*24*time.Hour); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if hourlyAggregates, _ = store.GetAggregatesByKey(testEndpoint.Key(), time.Hour, now.Add(-365*24*time.Hour), now); len(hourlyAggregates) != 1 || hourlyAggregates[0].TotalExecutions != 20 {
+		t.Errorf("expected hourly aggregate to be unchanged, got %+v", hourlyAggregates)
+	}
+	// Daily aggregates older than the retention are deleted
+	if err := store.Downsample(now, 7*24*time.Hour, 30*24*time.Hour, 35*24*time.Hour); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if dailyAggregates, _ = store.GetAggregatesByKey(testEndpoint.Key(), 24*time.Hour, now.Add(-365*24*time.Hour), now); len(dailyAggregates) != 0 {
+		t.Errorf("expected daily aggregate to be deleted, got %d", len(dailyAggregates))
+	}
+}
+
+func TestStore_GetAggregatesByKey(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_GetAggregatesByKey.db", false)
+	defer store.Close()
+	if _, err := store.GetAggregatesByKey("nope", time.Hour, time.Now().Add(-time.Hour), time.Now()); !errors.Is(err, common.ErrEndpointNotFound) {
+		t.Errorf("expected %v, got %v", common.ErrEndpointNotFound, err)
+	}
+	if _, err := store.GetAggregatesByKey(testEndpoint.Key(), time.Minute, time.Now().Add(-time.Hour), time.Now()); !errors.Is(err, common.ErrInvalidResolution) {
+		t.Errorf("expected %v, got %v", common.ErrInvalidResolution, err)
+	}
+	if _, err := store.GetAggregatesByKey(testEndpoint.Key(), time.Hour, time.Now(), time.Now().Add(-time.Hour)); !errors.Is(err, common.ErrInvalidTimeRange) {
+		t.Errorf("expected %v, got %v", common.ErrInvalidTimeRange, err)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	scenarios := []struct {
+		values   []int64
+		p        float64
+		expected int64
+	}{
+		{values: nil, p: 0.95, expected: 0},
+		{values: []int64{42}, p: 0.95, expected: 42},
+		{values: []int64{5, 1, 4, 2, 3}, p: 0.5, expected: 3},
+		{values: []int64{5, 1, 4, 2, 3}, p: 0.95, expected: 5},
+	}
+	for _, scenario := range scenarios {
+		if actual := percentile(scenario.values, scenario.p); actual != scenario.expected {
+			t.Errorf("expected percentile %v of %v to be %d, got %d", scenario.p, scenario.values, scenario.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sync/atomic"
+
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrReplicasNotSupported is the error returned when SetReplicas is called on a store whose driver isn't postgres
+	ErrReplicasNotSupported = errors.New("replicas are only supported by postgres")
+
+	// errServerInRecovery is the error returned when connecting to write to a server that is in recovery, which is
+	// how Postgres describes a replica
+	errServerInRecovery = errors.New("server is in recovery, so it cannot be written to")
+)
+
+// SetReplicas sets the Postgres replicas that the dashboard and the API read from, given their data source names.
+//
+// Reads are spread across the replicas, and fall back to the next replica, then to the primary, when a replica cannot
+// be connected to. Writes keep going to the primary, unless it cannot be connected to, in which case they go to the
+// first replica that has been promoted, so that the store keeps working after a failover.
+//
+// Must be called before the store is used.
+func (s *Store) SetReplicas(paths []string) error {
+	if s.driver != "postgres" {
+		return ErrReplicasNotSupported
+	}
+	primary, err := pq.NewConnector(s.path)
+	if err != nil {
+		return err
+	}
+	replicas := make([]driver.Connector, 0, len(paths))
+	for i, path := range paths {
+		replica, err := pq.NewConnector(path)
+		if err != nil {
+			return fmt.Errorf("invalid replica #%d: %w", i+1, err)
+		}
+		replicas = append(replicas, replica)
+	}
+	_ = s.db.Close()
+	s.db = sql.OpenDB(&failoverConnector{servers: append([]driver.Connector{primary}, replicas...), writable: true})
+	s.replicas = sql.OpenDB(&failoverConnector{servers: replicas, fallback: primary})
+	return nil
+}
+
+// readDB returns the database that read-only queries of the dashboard and the API are made on
+func (s *Store) readDB() *sql.DB {
+	if s.replicas != nil {
+		return s.replicas
+	}
+	return s.db
+}
+
+// failoverConnector is a driver.Connector that connects to the first of its servers that can be connected to.
+//
+// Since connections are only created when the pool of the database has none to spare, and since the database retries
+// queries that failed with driver.ErrBadConn on a new connection, a server that goes down is seamlessly replaced by
+// the next one.
+type failoverConnector struct {
+	// servers are connected to in order, starting with the primary if writable, or with the next replica otherwise
+	servers []driver.Connector
+
+	// fallback is the server connected to when none of the servers can be connected to. May be nil.
+	fallback driver.Connector
+
+	// writable is whether the connections are used to write, in which case servers that are in recovery are skipped
+	writable bool
+
+	// next is the index of the server that the next read-only connection starts with, so that they're spread evenly
+	next atomic.Uint32
+}
+
+// Connect returns a connection to the first server that can be connected to
+func (c *failoverConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	start := 0
+	if !c.writable && len(c.servers) > 0 {
+		start = int((c.next.Add(1) - 1) % uint32(len(c.servers)))
+	}
+	servers := make([]driver.Connector, 0, len(c.servers)+1)
+	servers = append(servers, c.servers[start:]...)
+	servers = append(servers, c.servers[:start]...)
+	if c.fallback != nil {
+		servers = append(servers, c.fallback)
+	}
+	var err error
+	for i, server := range servers {
+		var conn driver.Conn
+		if conn, err = c.connect(ctx, server); err == nil {
+			return conn, nil
+		}
+		if i < len(servers)-1 {
+			log.Printf("[sql.Connect] Failed to connect to postgres server, trying the next one: %s", err.Error())
+		}
+	}
+	return nil, err
+}
+
+// Driver returns the driver of the servers
+func (c *failoverConnector) Driver() driver.Driver {
+	if len(c.servers) == 0 {
+		return c.fallback.Driver()
+	}
+	return c.servers[0].Driver()
+}
+
+func (c *failoverConnector) connect(ctx context.Context, server driver.Connector) (driver.Conn, error) {
+	conn, err := server.Connect(ctx)
+	if err != nil || !c.writable {
+		return conn, err
+	}
+	inRecovery, err := isInRecovery(ctx, conn)
+	if err == nil && inRecovery {
+		err = errServerInRecovery
+	}
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// isInRecovery returns whether the server of the connection passed as parameter is a replica
+func isInRecovery(ctx context.Context, conn driver.Conn) (bool, error) {
+	queryer, ok := conn.(driver.QueryerContext)
+	if !ok {
+		return false, errors.New("connection does not support queries")
+	}
+	rows, err := queryer.QueryContext(ctx, "SELECT pg_is_in_recovery()", nil)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+	values := make([]driver.Value, 1)
+	if err = rows.Next(values); err != nil {
+		if errors.Is(err, io.EOF) {
+			return false, errNoRowsReturned
+		}
+		return false, err
+	}
+	inRecovery, _ := values[0].(bool)
+	return inRecovery, nil
+}
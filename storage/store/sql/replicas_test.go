@@ -0,0 +1,139 @@
+package sql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+)
+
+// fakeServer is a driver.Connector to a fake Postgres server
+type fakeServer struct {
+	name       string
+	down       bool
+	inRecovery bool
+}
+
+func (server *fakeServer) Connect(context.Context) (driver.Conn, error) {
+	if server.down {
+		return nil, errors.New(server.name + " is down")
+	}
+	return &fakeConn{server: server}, nil
+}
+
+func (server *fakeServer) Driver() driver.Driver {
+	return nil
+}
+
+type fakeConn struct {
+	server *fakeServer
+	closed bool
+}
+
+func (conn *fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not implemented") }
+func (conn *fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not implemented") }
+func (conn *fakeConn) Close() error {
+	conn.closed = true
+	return nil
+}
+
+func (conn *fakeConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	return &fakeRows{values: []driver.Value{conn.server.inRecovery}}, nil
+}
+
+type fakeRows struct {
+	values []driver.Value
+}
+
+func (rows *fakeRows) Columns() []string { return []string{"pg_is_in_recovery"} }
+func (rows *fakeRows) Close() error      { return nil }
+func (rows *fakeRows) Next(dest []driver.Value) error {
+	if rows.values == nil {
+		return io.EOF
+	}
+	copy(dest, rows.values)
+	rows.values = nil
+	return nil
+}
+
+func connectTo(t *testing.T, connector *failoverConnector) string {
+	conn, err := connector.Connect(context.Background())
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	return conn.(*fakeConn).server.name
+}
+
+func TestFailoverConnector_ConnectToWrite(t *testing.T) {
+	primary := &fakeServer{name: "primary"}
+	replica1 := &fakeServer{name: "replica-1", inRecovery: true}
+	replica2 := &fakeServer{name: "replica-2", inRecovery: true}
+	connector := &failoverConnector{servers: []driver.Connector{primary, replica1, replica2}, writable: true}
+	if server := connectTo(t, connector); server != "primary" {
+		t.Errorf("expected writes to go to the primary, got %s", server)
+	}
+	primary.down = true
+	if _, err := connector.Connect(context.Background()); !errors.Is(err, errServerInRecovery) {
+		t.Errorf("expected error %v when no server can be written to, got %v", errServerInRecovery, err)
+	}
+	replica2.inRecovery = false
+	if server := connectTo(t, connector); server != "replica-2" {
+		t.Errorf("expected writes to go to the promoted replica, got %s", server)
+	}
+	// The old primary came back as a replica
+	primary.down, primary.inRecovery = false, true
+	if server := connectTo(t, connector); server != "replica-2" {
+		t.Errorf("expected writes to keep going to the promoted replica, got %s", server)
+	}
+}
+
+func TestFailoverConnector_ConnectToRead(t *testing.T) {
+	primary := &fakeServer{name: "primary"}
+	replica1 := &fakeServer{name: "replica-1", inRecovery: true}
+	replica2 := &fakeServer{name: "replica-2", inRecovery: true}
+	connector := &failoverConnector{servers: []driver.Connector{replica1, replica2}, fallback: primary}
+	if first, second, third := connectTo(t, connector), connectTo(t, connector), connectTo(t, connector); first != "replica-1" || second != "replica-2" || third != "replica-1" {
+		t.Errorf("expected reads to be spread across the replicas, got %s, %s and %s", first, second, third)
+	}
+	replica1.down = true
+	if first, second := connectTo(t, connector), connectTo(t, connector); first != "replica-2" || second != "replica-2" {
+		t.Errorf("expected reads to go to the replica that is up, got %s and %s", first, second)
+	}
+	replica2.down = true
+	if server := connectTo(t, connector); server != "primary" {
+		t.Errorf("expected reads to fall back to the primary, got %s", server)
+	}
+	primary.down = true
+	if _, err := connector.Connect(context.Background()); err == nil || err.Error() != "primary is down" {
+		t.Errorf("expected error of the primary when no server is up, got %v", err)
+	}
+}
+
+func TestFailoverConnector_ConnectClosesConnectionsInRecovery(t *testing.T) {
+	replica := &fakeServer{name: "replica", inRecovery: true}
+	conn, _ := replica.Connect(context.Background())
+	connector := &failoverConnector{servers: []driver.Connector{connectorFunc(func() driver.Conn { return conn })}, writable: true}
+	if _, err := connector.Connect(context.Background()); !errors.Is(err, errServerInRecovery) {
+		t.Fatalf("expected error %v, got %v", errServerInRecovery, err)
+	}
+	if !conn.(*fakeConn).closed {
+		t.Error("expected connection to the server in recovery to be closed")
+	}
+}
+
+type connectorFunc func() driver.Conn
+
+func (f connectorFunc) Connect(context.Context) (driver.Conn, error) { return f(), nil }
+func (f connectorFunc) Driver() driver.Driver                        { return nil }
+
+func TestStore_SetReplicas(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_SetReplicas.db", false)
+	defer store.Close()
+	if err := store.SetReplicas([]string{"postgres://replica:5432/gatus"}); !errors.Is(err, ErrReplicasNotSupported) {
+		t.Errorf("expected error %v, got %v", ErrReplicasNotSupported, err)
+	}
+	if store.readDB() != store.db {
+		t.Error("expected reads to be made on the database when there are no replicas")
+	}
+}
@@ -0,0 +1,66 @@
+package sql
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+)
+
+// GetResponseTimesByKey returns the response time of every result of an endpoint during a time range, from oldest to
+// newest
+func (s *Store) GetResponseTimesByKey(key string, from, to time.Time) (_ []*endpoint.ResponseTime, err error) {
+	defer func(start time.Time) { s.observe("GetResponseTimesByKey", start, err) }(time.Now())
+	if from.After(to) {
+		return nil, common.ErrInvalidTimeRange
+	}
+	tx, err := s.readDB().Begin()
+	if err != nil {
+		return nil, err
+	}
+	endpointID, _, _, err := s.getEndpointIDGroupAndNameByKey(tx, key)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	responseTimes, err := s.getEndpointResponseTimes(tx, endpointID, from, to)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+	}
+	return responseTimes, nil
+}
+
+func (s *Store) getEndpointResponseTimes(tx *sql.Tx, endpointID int64, from, to time.Time) ([]*endpoint.ResponseTime, error) {
+	rows, err := tx.Query(
+		`
+			SELECT timestamp, duration
+			FROM endpoint_results
+			WHERE endpoint_id = $1
+				AND timestamp >= $2
+				AND timestamp <= $3
+			ORDER BY endpoint_result_id
+		`,
+		endpointID,
+		from.UTC(),
+		to.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	responseTimes := make([]*endpoint.ResponseTime, 0)
+	for rows.Next() {
+		responseTime := &endpoint.ResponseTime{}
+		if err = rows.Scan(&responseTime.Timestamp, &responseTime.Duration); err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		responseTimes = append(responseTimes, responseTime)
+	}
+	_ = rows.Close()
+	return responseTimes, rows.Err()
+}
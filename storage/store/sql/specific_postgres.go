@@ -1,15 +1,59 @@
 package sql
 
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/TwiN/gatus/v5/metrics"
+)
+
+const (
+	// PartitioningNative partitions the results using the declarative partitioning of Postgres, and the partitions are
+	// created and dropped by Gatus
+	PartitioningNative = "native"
+
+	// PartitioningTimescaleDB turns the results into TimescaleDB hypertables, whose chunks are created by TimescaleDB
+	// and dropped by its retention policy
+	PartitioningTimescaleDB = "timescaledb"
+
+	// DefaultRetention is how long partitioned results are kept by default
+	DefaultRetention = 30 * 24 * time.Hour
+
+	// DefaultPartitionInterval is the duration spanned by each partition by default
+	DefaultPartitionInterval = 24 * time.Hour
+
+	// partitionMaintenanceInterval is the interval at which old partitions are dropped
+	partitionMaintenanceInterval = time.Hour
+
+	partitionBoundLayout = "2006-01-02 15:04:05"
+)
+
+var (
+	// ErrInvalidPartitioning is the error returned when the partitioning passed in NewPartitionedStore is neither
+	// PartitioningNative nor PartitioningTimescaleDB
+	ErrInvalidPartitioning = errors.New("partitioning must be either native or timescaledb")
+
+	// ErrInvalidPartitionInterval is the error returned when the partition interval passed in NewPartitionedStore is
+	// shorter than an hour or longer than the retention
+	ErrInvalidPartitionInterval = errors.New("partition interval must be at least 1h and cannot exceed the retention")
+
+	// ErrResultsNotPartitioned is the error returned when partitioning is enabled on a database whose results were
+	// created without partitioning, since existing tables cannot be converted in place
+	ErrResultsNotPartitioned = errors.New("endpoint_results already exists and is not partitioned, partitioning can only be enabled on a new database")
+
+	// partitionedTables are the tables that are partitioned by timestamp
+	partitionedTables = []string{"endpoint_results", "endpoint_result_conditions"}
+
+	partitionBoundRegex = regexp.MustCompile(`'([^']+)'`)
+)
+
 func (s *Store) createPostgresSchema() error {
-	_, err := s.db.Exec(`
-		CREATE TABLE IF NOT EXISTS endpoints (
-			endpoint_id    BIGSERIAL PRIMARY KEY,
-			endpoint_key   TEXT UNIQUE,
-			endpoint_name  TEXT NOT NULL,
-			endpoint_group TEXT NOT NULL,
-			UNIQUE(endpoint_name, endpoint_group)
-		)
-	`)
+	err := s.createPostgresEndpointsTable()
 	if err != nil {
 		return err
 	}
@@ -38,6 +82,7 @@ func (s *Store) createPostgresSchema() error {
 			hostname               TEXT      NOT NULL,
 			ip                     TEXT      NOT NULL,
 			duration               BIGINT    NOT NULL,
+			metadata               TEXT      NOT NULL,
 			timestamp              TIMESTAMP NOT NULL
 		)
 	`)
@@ -69,6 +114,163 @@ func (s *Store) createPostgresSchema() error {
 	if err != nil {
 		return err
 	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS endpoint_daily_uptimes (
+			endpoint_daily_uptime_id BIGSERIAL PRIMARY KEY,
+			endpoint_id              BIGINT NOT NULL REFERENCES endpoints(endpoint_id) ON DELETE CASCADE,
+			day_unix_timestamp       BIGINT NOT NULL,
+			total_executions         BIGINT NOT NULL,
+			successful_executions    BIGINT NOT NULL,
+			total_response_time      BIGINT NOT NULL,
+			UNIQUE(endpoint_id, day_unix_timestamp)
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS endpoint_hourly_aggregates (
+			endpoint_hourly_aggregate_id  BIGSERIAL PRIMARY KEY,
+			endpoint_id                   BIGINT NOT NULL REFERENCES endpoints(endpoint_id) ON DELETE CASCADE,
+			bucket_unix_timestamp         BIGINT NOT NULL,
+			total_executions              BIGINT NOT NULL,
+			successful_executions         BIGINT NOT NULL,
+			total_response_time           BIGINT NOT NULL,
+			minimum_response_time         BIGINT NOT NULL,
+			maximum_response_time         BIGINT NOT NULL,
+			p95_response_time             BIGINT NOT NULL,
+			UNIQUE(endpoint_id, bucket_unix_timestamp)
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS endpoint_daily_aggregates (
+			endpoint_daily_aggregate_id   BIGSERIAL PRIMARY KEY,
+			endpoint_id                   BIGINT NOT NULL REFERENCES endpoints(endpoint_id) ON DELETE CASCADE,
+			bucket_unix_timestamp         BIGINT NOT NULL,
+			total_executions              BIGINT NOT NULL,
+			successful_executions         BIGINT NOT NULL,
+			total_response_time           BIGINT NOT NULL,
+			minimum_response_time         BIGINT NOT NULL,
+			maximum_response_time         BIGINT NOT NULL,
+			p95_response_time             BIGINT NOT NULL,
+			UNIQUE(endpoint_id, bucket_unix_timestamp)
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS endpoint_failure_captures (
+			endpoint_failure_capture_id   BIGSERIAL PRIMARY KEY,
+			endpoint_id                   BIGINT    NOT NULL REFERENCES endpoints(endpoint_id) ON DELETE CASCADE,
+			status                        BIGINT    NOT NULL,
+			headers                       TEXT      NOT NULL,
+			body                          TEXT      NOT NULL,
+			truncated                     BOOLEAN   NOT NULL,
+			errors                        TEXT      NOT NULL,
+			timestamp                     TIMESTAMP NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS annotations (
+			annotation_id                 BIGSERIAL PRIMARY KEY,
+			title                         TEXT      NOT NULL,
+			description                   TEXT      NOT NULL,
+			timestamp                     TIMESTAMP NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS annotation_endpoints (
+			annotation_id                 BIGINT    NOT NULL REFERENCES annotations(annotation_id) ON DELETE CASCADE,
+			endpoint_key                  TEXT      NOT NULL,
+			UNIQUE(annotation_id, endpoint_key)
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS announcements (
+			announcement_id               BIGSERIAL PRIMARY KEY,
+			type                          TEXT      NOT NULL,
+			message                       TEXT      NOT NULL,
+			timestamp                     TIMESTAMP NOT NULL,
+			expires_at                    TIMESTAMP
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS subscriptions (
+			subscription_id               BIGSERIAL PRIMARY KEY,
+			type                          TEXT      NOT NULL,
+			target                        TEXT      NOT NULL,
+			confirmed                     BOOLEAN   NOT NULL,
+			confirmation_token            TEXT      NOT NULL UNIQUE,
+			unsubscribe_token             TEXT      NOT NULL UNIQUE,
+			timestamp                     TIMESTAMP NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS subscription_groups (
+			subscription_id               BIGINT    NOT NULL REFERENCES subscriptions(subscription_id) ON DELETE CASCADE,
+			group_name                    TEXT      NOT NULL,
+			UNIQUE(subscription_id, group_name)
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS audit_entries (
+			audit_entry_id                BIGSERIAL PRIMARY KEY,
+			timestamp                     TIMESTAMP NOT NULL,
+			action                        TEXT      NOT NULL,
+			actor                         TEXT      NOT NULL,
+			target                        TEXT      NOT NULL,
+			success                       BOOLEAN   NOT NULL,
+			details                       TEXT      NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS tokens (
+			token_id                      BIGSERIAL PRIMARY KEY,
+			name                          TEXT      NOT NULL,
+			hash                          TEXT      NOT NULL UNIQUE,
+			timestamp                     TIMESTAMP NOT NULL,
+			expires_at                    TIMESTAMP,
+			revoked_at                    TIMESTAMP
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS token_scopes (
+			token_id                      BIGINT    NOT NULL REFERENCES tokens(token_id) ON DELETE CASCADE,
+			scope                         TEXT      NOT NULL,
+			UNIQUE(token_id, scope)
+		)
+	`)
+	if err != nil {
+		return err
+	}
 	_, err = s.db.Exec(`
 		CREATE TABLE IF NOT EXISTS endpoint_alerts_triggered (
 			endpoint_alert_trigger_id     BIGSERIAL PRIMARY KEY,
@@ -81,5 +283,280 @@ func (s *Store) createPostgresSchema() error {
 	`)
 	// Silent table modifications TODO: Remove this in v6.0.0
 	_, _ = s.db.Exec(`ALTER TABLE endpoint_results ADD IF NOT EXISTS domain_expiration BIGINT NOT NULL DEFAULT 0`)
+	_, _ = s.db.Exec(`ALTER TABLE endpoint_results ADD IF NOT EXISTS metadata TEXT NOT NULL DEFAULT ''`)
 	return err
 }
+
+func (s *Store) createPostgresEndpointsTable() error {
+	_, err := s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS endpoints (
+			endpoint_id    BIGSERIAL PRIMARY KEY,
+			endpoint_key   TEXT UNIQUE,
+			endpoint_name  TEXT NOT NULL,
+			endpoint_group TEXT NOT NULL,
+			UNIQUE(endpoint_name, endpoint_group)
+		)
+	`)
+	return err
+}
+
+// createPartitionedPostgresSchema creates the same schema as createPostgresSchema, except that the results and the
+// condition results are partitioned by timestamp.
+//
+// Because the primary key of a partitioned table must include the partition key, the condition results cannot
+// reference the results. Instead, they're partitioned by the timestamp of their result, so that both are dropped
+// together.
+func (s *Store) createPartitionedPostgresSchema() error {
+	if s.partitioning == PartitioningTimescaleDB {
+		if _, err := s.db.Exec("CREATE EXTENSION IF NOT EXISTS timescaledb"); err != nil {
+			return err
+		}
+	}
+	if err := s.checkResultsPartitioning(); err != nil {
+		return err
+	}
+	if err := s.createPostgresEndpointsTable(); err != nil {
+		return err
+	}
+	partitionClause := ""
+	if s.partitioning == PartitioningNative {
+		partitionClause = "PARTITION BY RANGE (timestamp)"
+	}
+	_, err := s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS endpoint_results (
+			endpoint_result_id     BIGSERIAL,
+			endpoint_id            BIGINT    NOT NULL REFERENCES endpoints(endpoint_id) ON DELETE CASCADE,
+			success                BOOLEAN   NOT NULL,
+			errors                 TEXT      NOT NULL,
+			connected              BOOLEAN   NOT NULL,
+			status                 BIGINT    NOT NULL,
+			dns_rcode              TEXT      NOT NULL,
+			certificate_expiration BIGINT    NOT NULL,
+			domain_expiration      BIGINT    NOT NULL,
+			hostname               TEXT      NOT NULL,
+			ip                     TEXT      NOT NULL,
+			duration               BIGINT    NOT NULL,
+			metadata               TEXT      NOT NULL,
+			timestamp              TIMESTAMP NOT NULL,
+			PRIMARY KEY (endpoint_result_id, timestamp)
+		) ` + partitionClause)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS endpoint_result_conditions (
+			endpoint_result_condition_id  BIGSERIAL,
+			endpoint_result_id            BIGINT    NOT NULL,
+			condition                     TEXT      NOT NULL,
+			success                       BOOLEAN   NOT NULL,
+			timestamp                     TIMESTAMP NOT NULL,
+			PRIMARY KEY (endpoint_result_condition_id, timestamp)
+		) ` + partitionClause)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`CREATE INDEX IF NOT EXISTS endpoint_results_endpoint_id_idx ON endpoint_results (endpoint_id, endpoint_result_id)`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`CREATE INDEX IF NOT EXISTS endpoint_result_conditions_endpoint_result_id_idx ON endpoint_result_conditions (endpoint_result_id)`)
+	if err != nil {
+		return err
+	}
+	if s.partitioning == PartitioningTimescaleDB {
+		chunkInterval, retention := postgresInterval(s.partitionInterval), postgresInterval(s.retention)
+		for _, table := range partitionedTables {
+			statements := []string{
+				"SELECT create_hypertable('" + table + "', 'timestamp', chunk_time_interval => " + chunkInterval + ", if_not_exists => TRUE)",
+				// The chunk interval and the retention may have changed since the hypertable was created
+				"SELECT set_chunk_time_interval('" + table + "', " + chunkInterval + ")",
+				"SELECT remove_retention_policy('" + table + "', if_exists => TRUE)",
+				"SELECT add_retention_policy('" + table + "', " + retention + ")",
+			}
+			for _, statement := range statements {
+				if _, err = s.db.Exec(statement); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	// The rest of the schema isn't partitioned, and the partitioned tables created above are left as is
+	if err = s.createPostgresSchema(); err != nil {
+		return err
+	}
+	if s.partitioning == PartitioningNative {
+		return s.maintainPartitions(time.Now())
+	}
+	return nil
+}
+
+// checkResultsPartitioning returns ErrResultsNotPartitioned if the results already exist, but aren't partitioned the
+// way they should be
+func (s *Store) checkResultsPartitioning() error {
+	var relationKind string
+	err := s.db.QueryRow("SELECT relkind FROM pg_class WHERE oid = to_regclass('endpoint_results')").Scan(&relationKind)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if s.partitioning == PartitioningNative {
+		if relationKind != "p" {
+			return ErrResultsNotPartitioned
+		}
+		return nil
+	}
+	var isHypertable bool
+	err = s.db.QueryRow("SELECT EXISTS (SELECT 1 FROM timescaledb_information.hypertables WHERE hypertable_name = 'endpoint_results' AND hypertable_schema = current_schema())").Scan(&isHypertable)
+	if err != nil {
+		return err
+	}
+	if !isHypertable {
+		return ErrResultsNotPartitioned
+	}
+	return nil
+}
+
+// partition is the range of timestamps of a partition, which includes start, but excludes end
+type partition struct {
+	name       string
+	start, end time.Time
+}
+
+func (p *partition) contains(timestamp time.Time) bool {
+	return !timestamp.Before(p.start) && timestamp.Before(p.end)
+}
+
+// maintainPartitions creates the partitions required to insert a result with the timestamp passed as parameter as
+// well as the results of the next partition interval, and drops the partitions that are older than the retention.
+//
+// To avoid querying the database on every insert, this is only done if the partitions haven't been maintained for
+// partitionMaintenanceInterval, or if the timestamp is not covered by the partitions known to exist.
+func (s *Store) maintainPartitions(timestamp time.Time) error {
+	s.partitionMutex.Lock()
+	defer s.partitionMutex.Unlock()
+	now := time.Now()
+	if !timestamp.Before(s.partitionedFrom) && timestamp.Before(s.partitionedUntil) && now.Sub(s.lastPartitionMaintenance) < partitionMaintenanceInterval {
+		return nil
+	}
+	var partitionedFrom, partitionedUntil time.Time
+	for _, table := range partitionedTables {
+		partitions, err := s.getPartitions(table)
+		if err != nil {
+			return err
+		}
+		next := now.Add(s.partitionInterval)
+		for _, t := range []time.Time{timestamp, now, next} {
+			if containingPartition(partitions, t) != nil {
+				continue
+			}
+			p := newPartition(table, partitions, t, s.partitionInterval)
+			_, err = s.db.Exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s PARTITION OF %s FOR VALUES FROM ('%s') TO ('%s')", p.name, table, p.start.Format(partitionBoundLayout), p.end.Format(partitionBoundLayout)))
+			if err != nil {
+				return err
+			}
+			partitions = append(partitions, p)
+		}
+		oldest := now.Add(-s.retention)
+		for _, p := range partitions {
+			if !p.end.After(oldest) {
+				start := time.Now()
+				if _, err = s.db.Exec("DROP TABLE IF EXISTS " + p.name); err != nil {
+					return err
+				}
+				metrics.ObserveStoreCleanUp(s.driver, "partitions", start)
+			}
+		}
+		// Partitions are created for the current and the next interval, which leaves no gap between the two
+		if start := containingPartition(partitions, now).start; start.After(partitionedFrom) {
+			partitionedFrom = start
+		}
+		if end := containingPartition(partitions, next).end; partitionedUntil.IsZero() || end.Before(partitionedUntil) {
+			partitionedUntil = end
+		}
+	}
+	s.partitionedFrom, s.partitionedUntil = partitionedFrom, partitionedUntil
+	s.lastPartitionMaintenance = now
+	return nil
+}
+
+// getPartitions returns the partitions of a table, along with the range of timestamps of each partition
+func (s *Store) getPartitions(table string) ([]*partition, error) {
+	rows, err := s.db.Query(
+		`
+			SELECT child.relname, pg_get_expr(child.relpartbound, child.oid)
+			FROM pg_inherits
+				JOIN pg_class child ON child.oid = pg_inherits.inhrelid
+			WHERE pg_inherits.inhparent = to_regclass($1)
+		`,
+		table,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var partitions []*partition
+	for rows.Next() {
+		var name, bound string
+		if err = rows.Scan(&name, &bound); err != nil {
+			return nil, err
+		}
+		p, err := parsePartitionBound(name, bound)
+		if err != nil {
+			// Partitions that weren't created by Gatus, such as a default partition, are left alone
+			log.Printf("[sql.getPartitions] Ignoring partition of table=%s: %s", table, err.Error())
+			continue
+		}
+		partitions = append(partitions, p)
+	}
+	return partitions, rows.Err()
+}
+
+// parsePartitionBound parses the bound of a partition, e.g. FOR VALUES FROM ('2024-01-02 00:00:00') TO ('2024-01-03 00:00:00')
+func parsePartitionBound(name, bound string) (*partition, error) {
+	matches := partitionBoundRegex.FindAllStringSubmatch(bound, -1)
+	if len(matches) != 2 {
+		return nil, fmt.Errorf("unsupported bound for partition %s: %s", name, bound)
+	}
+	start, err := time.ParseInLocation(partitionBoundLayout, matches[0][1], time.UTC)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported bound for partition %s: %w", name, err)
+	}
+	end, err := time.ParseInLocation(partitionBoundLayout, matches[1][1], time.UTC)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported bound for partition %s: %w", name, err)
+	}
+	return &partition{name: name, start: start, end: end}, nil
+}
+
+func containingPartition(partitions []*partition, timestamp time.Time) *partition {
+	for _, p := range partitions {
+		if p.contains(timestamp) {
+			return p
+		}
+	}
+	return nil
+}
+
+// newPartition returns the partition of a table that should contain the timestamp passed as parameter.
+//
+// Partitions are aligned on the interval, but if the interval changed since the existing partitions were created,
+// the new partition is shortened so that it doesn't overlap any of them.
+func newPartition(table string, partitions []*partition, timestamp time.Time, interval time.Duration) *partition {
+	start := timestamp.UTC().Truncate(interval)
+	end := start.Add(interval)
+	for _, p := range partitions {
+		if !p.end.After(timestamp) && p.end.After(start) {
+			start = p.end
+		}
+		if p.start.After(timestamp) && p.start.Before(end) {
+			end = p.start
+		}
+	}
+	return &partition{name: table + "_p" + start.Format("20060102150405"), start: start, end: end}
+}
+
+// postgresInterval returns the literal of an interval
+func postgresInterval(duration time.Duration) string {
+	return "INTERVAL '" + strconv.FormatInt(int64(duration.Seconds()), 10) + " seconds'"
+}
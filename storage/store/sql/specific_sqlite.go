@@ -1,5 +1,63 @@
 package sql
 
+import (
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/ncruces/go-sqlite3"
+	_ "github.com/ncruces/go-sqlite3/driver"
+	_ "github.com/ncruces/go-sqlite3/embed"
+	_ "github.com/ncruces/go-sqlite3/vfs/adiantum"
+	"modernc.org/sqlite"
+	sqlite3lib "modernc.org/sqlite/lib"
+)
+
+// encryptedSQLiteDriver is the driver used to open encrypted SQLite databases.
+//
+// Unlike the driver used for SQLite databases that aren't encrypted, it supports VFS, which the adiantum VFS relies on
+// to encrypt each page of the database file, as well as its journal, with a key derived from the encryption key.
+// SQLite databases that aren't encrypted keep being opened with the other driver, because the two drivers don't store
+// timestamps in the same format, which the queries comparing timestamps rely on.
+const encryptedSQLiteDriver = "sqlite3"
+
+var (
+	// sqlitePragmas are the PRAGMAs set on every SQLite database once it's opened
+	sqlitePragmas = []string{"foreign_keys=ON", "journal_mode=WAL", "synchronous=NORMAL"}
+
+	// encryptedSQLitePragmas are the PRAGMAs set on encrypted SQLite databases once they're opened, in addition to the
+	// sqlitePragmas. The temporary files are kept in memory so that they don't need to be encrypted, and the busy timeout
+	// replaces the one the driver sets when the data source name has no PRAGMA.
+	encryptedSQLitePragmas = []string{"temp_store=MEMORY", "busy_timeout=10000"}
+)
+
+// encryptedSQLiteDataSourceName returns the URI of the SQLite database whose file is at the path passed as parameter,
+// encrypted with the key passed as parameter.
+//
+// The key is the only PRAGMA of the URI, since nothing can be read from the database before it's set on the connection.
+func encryptedSQLiteDataSourceName(path, encryptionKey string) string {
+	parameters := url.Values{}
+	parameters.Set("vfs", "adiantum")
+	parameters.Set("_pragma", "textkey('"+strings.ReplaceAll(encryptionKey, "'", "''")+"')")
+	return "file:" + (&url.URL{Path: path}).EscapedPath() + "?" + parameters.Encode()
+}
+
+// isSQLiteBusyError returns whether the error passed as parameter was returned because the SQLite database was locked
+// by another connection for longer than the busy timeout, regardless of which of the SQLite drivers returned it
+func isSQLiteBusyError(err error) bool {
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code()&0xff == sqlite3lib.SQLITE_BUSY
+	}
+	return errors.Is(err, sqlite3.BUSY)
+}
+
+// isSQLiteNotADatabaseError returns whether the error passed as parameter was returned because the file opened isn't an
+// SQLite database, which is the case of an encrypted database that is read with the wrong key
+func isSQLiteNotADatabaseError(err error) bool {
+	return errors.Is(err, sqlite3.NOTADB)
+}
+
 func (s *Store) createSQLiteSchema() error {
 	_, err := s.db.Exec(`
 		CREATE TABLE IF NOT EXISTS endpoints (
@@ -38,6 +96,7 @@ func (s *Store) createSQLiteSchema() error {
 			hostname               TEXT      NOT NULL,
 			ip                     TEXT      NOT NULL,
 			duration               INTEGER   NOT NULL,
+			metadata               TEXT      NOT NULL,
 			timestamp              TIMESTAMP NOT NULL
 		)
 	`)
@@ -69,6 +128,163 @@ func (s *Store) createSQLiteSchema() error {
 	if err != nil {
 		return err
 	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS endpoint_daily_uptimes (
+			endpoint_daily_uptime_id INTEGER PRIMARY KEY,
+			endpoint_id              INTEGER NOT NULL REFERENCES endpoints(endpoint_id) ON DELETE CASCADE,
+			day_unix_timestamp       INTEGER NOT NULL,
+			total_executions         INTEGER NOT NULL,
+			successful_executions    INTEGER NOT NULL,
+			total_response_time      INTEGER NOT NULL,
+			UNIQUE(endpoint_id, day_unix_timestamp)
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS endpoint_hourly_aggregates (
+			endpoint_hourly_aggregate_id  INTEGER PRIMARY KEY,
+			endpoint_id                   INTEGER NOT NULL REFERENCES endpoints(endpoint_id) ON DELETE CASCADE,
+			bucket_unix_timestamp         INTEGER NOT NULL,
+			total_executions              INTEGER NOT NULL,
+			successful_executions         INTEGER NOT NULL,
+			total_response_time           INTEGER NOT NULL,
+			minimum_response_time         INTEGER NOT NULL,
+			maximum_response_time         INTEGER NOT NULL,
+			p95_response_time             INTEGER NOT NULL,
+			UNIQUE(endpoint_id, bucket_unix_timestamp)
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS endpoint_daily_aggregates (
+			endpoint_daily_aggregate_id   INTEGER PRIMARY KEY,
+			endpoint_id                   INTEGER NOT NULL REFERENCES endpoints(endpoint_id) ON DELETE CASCADE,
+			bucket_unix_timestamp         INTEGER NOT NULL,
+			total_executions              INTEGER NOT NULL,
+			successful_executions         INTEGER NOT NULL,
+			total_response_time           INTEGER NOT NULL,
+			minimum_response_time         INTEGER NOT NULL,
+			maximum_response_time         INTEGER NOT NULL,
+			p95_response_time             INTEGER NOT NULL,
+			UNIQUE(endpoint_id, bucket_unix_timestamp)
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS endpoint_failure_captures (
+			endpoint_failure_capture_id   INTEGER PRIMARY KEY,
+			endpoint_id                   INTEGER   NOT NULL REFERENCES endpoints(endpoint_id) ON DELETE CASCADE,
+			status                        INTEGER   NOT NULL,
+			headers                       TEXT      NOT NULL,
+			body                          TEXT      NOT NULL,
+			truncated                     INTEGER   NOT NULL,
+			errors                        TEXT      NOT NULL,
+			timestamp                     TIMESTAMP NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS annotations (
+			annotation_id                 INTEGER PRIMARY KEY,
+			title                         TEXT      NOT NULL,
+			description                   TEXT      NOT NULL,
+			timestamp                     TIMESTAMP NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS annotation_endpoints (
+			annotation_id                 INTEGER   NOT NULL REFERENCES annotations(annotation_id) ON DELETE CASCADE,
+			endpoint_key                  TEXT      NOT NULL,
+			UNIQUE(annotation_id, endpoint_key)
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS announcements (
+			announcement_id               INTEGER PRIMARY KEY,
+			type                          TEXT      NOT NULL,
+			message                       TEXT      NOT NULL,
+			timestamp                     TIMESTAMP NOT NULL,
+			expires_at                    TIMESTAMP
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS subscriptions (
+			subscription_id               INTEGER PRIMARY KEY,
+			type                          TEXT      NOT NULL,
+			target                        TEXT      NOT NULL,
+			confirmed                     INTEGER   NOT NULL,
+			confirmation_token            TEXT      NOT NULL UNIQUE,
+			unsubscribe_token             TEXT      NOT NULL UNIQUE,
+			timestamp                     TIMESTAMP NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS subscription_groups (
+			subscription_id               INTEGER   NOT NULL REFERENCES subscriptions(subscription_id) ON DELETE CASCADE,
+			group_name                    TEXT      NOT NULL,
+			UNIQUE(subscription_id, group_name)
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS audit_entries (
+			audit_entry_id                INTEGER PRIMARY KEY,
+			timestamp                     TIMESTAMP NOT NULL,
+			action                        TEXT      NOT NULL,
+			actor                         TEXT      NOT NULL,
+			target                        TEXT      NOT NULL,
+			success                       INTEGER   NOT NULL,
+			details                       TEXT      NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS tokens (
+			token_id                      INTEGER PRIMARY KEY,
+			name                          TEXT      NOT NULL,
+			hash                          TEXT      NOT NULL UNIQUE,
+			timestamp                     TIMESTAMP NOT NULL,
+			expires_at                    TIMESTAMP,
+			revoked_at                    TIMESTAMP
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS token_scopes (
+			token_id                      INTEGER   NOT NULL REFERENCES tokens(token_id) ON DELETE CASCADE,
+			scope                         TEXT      NOT NULL,
+			UNIQUE(token_id, scope)
+		)
+	`)
+	if err != nil {
+		return err
+	}
 	_, err = s.db.Exec(`
 		CREATE TABLE IF NOT EXISTS endpoint_alerts_triggered (
 			endpoint_alert_trigger_id     INTEGER PRIMARY KEY,
@@ -81,5 +297,6 @@ func (s *Store) createSQLiteSchema() error {
 	`)
 	// Silent table modifications TODO: Remove this in v6.0.0
 	_, _ = s.db.Exec(`ALTER TABLE endpoint_results ADD domain_expiration INTEGER NOT NULL DEFAULT 0`)
+	_, _ = s.db.Exec(`ALTER TABLE endpoint_results ADD metadata TEXT NOT NULL DEFAULT ''`)
 	return err
 }
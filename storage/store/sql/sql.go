@@ -1,16 +1,22 @@
 package sql
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/metrics"
+	"github.com/TwiN/gatus/v5/storage/archive"
 	"github.com/TwiN/gatus/v5/storage/store/common"
 	"github.com/TwiN/gatus/v5/storage/store/common/paging"
 	"github.com/TwiN/gocache/v2"
@@ -28,13 +34,25 @@ const (
 	// for aesthetic purposes, I deemed it wasn't worth the performance impact of yet another one-to-many table.
 	arraySeparator = "|~|"
 
-	uptimeCleanUpThreshold  = 10 * 24 * time.Hour                // Maximum uptime age before triggering a cleanup
-	eventsCleanUpThreshold  = common.MaximumNumberOfEvents + 10  // Maximum number of events before triggering a cleanup
-	resultsCleanUpThreshold = common.MaximumNumberOfResults + 10 // Maximum number of results before triggering a cleanup
+	uptimeCleanUpThreshold  = 10 * 24 * time.Hour                           // Maximum uptime age before triggering a cleanup
+	cleanUpMargin           = 10                                            // Number of events or results in excess before triggering a cleanup
+	eventsCleanUpThreshold  = common.MaximumNumberOfEvents + cleanUpMargin  // Maximum number of events before triggering a cleanup
+	resultsCleanUpThreshold = common.MaximumNumberOfResults + cleanUpMargin // Maximum number of results before triggering a cleanup
 
 	uptimeRetention = 7 * 24 * time.Hour
 
+	// dailyUptimeRetention is how long the daily uptime rollups are kept, which is much longer than the hourly uptimes
+	// so that uptimes over long time ranges can be computed without scanning every hour
+	dailyUptimeRetention = 365 * 24 * time.Hour
+
 	cacheTTL = 10 * time.Minute
+
+	// DefaultBatchSize is the number of results buffered before they're inserted when the write buffer is enabled,
+	// unless the flush interval elapses first
+	DefaultBatchSize = 100
+
+	// DefaultFlushInterval is the maximum duration during which results are buffered when the write buffer is enabled
+	DefaultFlushInterval = time.Second
 )
 
 var (
@@ -44,6 +62,13 @@ var (
 	// ErrDatabaseDriverNotSpecified is the error returned when the driver parameter passed in NewStore is blank
 	ErrDatabaseDriverNotSpecified = errors.New("database driver cannot be empty")
 
+	// ErrEncryptionKeyNotSpecified is the error returned when the encryption key passed in NewEncryptedStore is blank
+	ErrEncryptionKeyNotSpecified = errors.New("encryption key cannot be empty")
+
+	// ErrInvalidEncryptionKey is the error returned by NewEncryptedStore when the existing database isn't encrypted, or
+	// is encrypted with another key
+	ErrInvalidEncryptionKey = errors.New("database is not encrypted with the encryption key")
+
 	errNoRowsReturned = errors.New("expected a row to be returned, but none was")
 )
 
@@ -53,9 +78,57 @@ type Store struct {
 
 	db *sql.DB
 
+	// replicas is the database that the dashboard and the API read from, which is spread across Postgres replicas.
+	// If nil, reads are made on db.
+	replicas *sql.DB
+
+	// encryptionKey is the key the SQLite database file is encrypted with. If empty, the database isn't encrypted.
+	encryptionKey string
+
 	// writeThroughCache is a cache used to drastically decrease read latency by pre-emptively
 	// caching writes as they happen. If nil, writes are not cached.
 	writeThroughCache *gocache.Cache
+
+	// archiver is what the results are archived with before they're cleaned up. If nil, results aren't archived.
+	// Results dropped along with their partition are not archived.
+	archiver *archive.Archiver
+
+	// retentionPolicy returns the retention policy of each endpoint. If nil, common.DefaultRetentionPolicy is used.
+	// Does not apply to partitioned results, whose retention is that of their partition.
+	retentionPolicy common.RetentionPolicyFunc
+
+	// partitioning is how the results are partitioned by timestamp, which is only supported by Postgres.
+	// If empty, results aren't partitioned, and only the last common.MaximumNumberOfResults results of each endpoint
+	// are kept. Otherwise, results are kept for the duration of the retention, after which their partition is dropped.
+	partitioning      string
+	retention         time.Duration
+	partitionInterval time.Duration
+
+	// partitionedFrom and partitionedUntil are the range of time in which partitions are known to exist, and
+	// lastPartitionMaintenance is when the partitions were last created and dropped. Only used with PartitioningNative.
+	partitionedFrom          time.Time
+	partitionedUntil         time.Time
+	lastPartitionMaintenance time.Time
+	partitionMutex           sync.Mutex
+
+	// batchSize is the number of results buffered before they're inserted in a single transaction, and flushInterval
+	// is the maximum duration during which they're buffered. If batchSize is 0, results are inserted right away.
+	batchSize      int
+	flushInterval  time.Duration
+	pendingInserts []*pendingInsert
+	bufferMutex    sync.Mutex
+
+	// flushMutex prevents concurrent flushes from inserting the buffered results out of order
+	flushMutex sync.Mutex
+
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+// pendingInsert is a result buffered by the write buffer, along with the endpoint it belongs to
+type pendingInsert struct {
+	endpoint *endpoint.Endpoint
+	result   *endpoint.Result
 }
 
 // NewStore initializes the database and creates the schema if it doesn't already exist in the path specified
@@ -66,18 +139,85 @@ func NewStore(driver, path string, caching bool) (*Store, error) {
 	if len(path) == 0 {
 		return nil, ErrPathNotSpecified
 	}
-	store := &Store{driver: driver, path: path}
+	return newStore(&Store{driver: driver, path: path}, caching)
+}
+
+// NewPartitionedStore initializes a Postgres database whose results are partitioned by timestamp using the
+// partitioning specified, either PartitioningNative or PartitioningTimescaleDB, and creates the schema if it doesn't
+// already exist.
+//
+// Rather than only keeping the most recent results of each endpoint, results are kept for the duration of the
+// retention, and partitions spanning partitionInterval are dropped once they're older than the retention.
+func NewPartitionedStore(path string, caching bool, partitioning string, retention, partitionInterval time.Duration) (*Store, error) {
+	if len(path) == 0 {
+		return nil, ErrPathNotSpecified
+	}
+	if partitioning != PartitioningNative && partitioning != PartitioningTimescaleDB {
+		return nil, ErrInvalidPartitioning
+	}
+	if retention <= 0 {
+		retention = DefaultRetention
+	}
+	if partitionInterval <= 0 {
+		partitionInterval = DefaultPartitionInterval
+	}
+	if partitionInterval < time.Hour || partitionInterval > retention {
+		return nil, ErrInvalidPartitionInterval
+	}
+	return newStore(&Store{
+		driver:            "postgres",
+		path:              path,
+		partitioning:      partitioning,
+		retention:         retention,
+		partitionInterval: partitionInterval,
+	}, caching)
+}
+
+// NewEncryptedStore initializes an SQLite database whose file is encrypted with the key passed as parameter, and
+// creates the schema if it doesn't already exist in the path specified.
+//
+// An existing database that isn't encrypted, or that is encrypted with another key, cannot be opened.
+func NewEncryptedStore(path, encryptionKey string, caching bool) (*Store, error) {
+	if len(path) == 0 {
+		return nil, ErrPathNotSpecified
+	}
+	if len(encryptionKey) == 0 {
+		return nil, ErrEncryptionKeyNotSpecified
+	}
+	return newStore(&Store{driver: "sqlite", path: path, encryptionKey: encryptionKey}, caching)
+}
+
+func newStore(store *Store, caching bool) (*Store, error) {
+	driver, path := store.driver, store.path
+	if len(store.encryptionKey) > 0 {
+		driver, path = encryptedSQLiteDriver, encryptedSQLiteDataSourceName(path, store.encryptionKey)
+	}
 	var err error
 	if store.db, err = sql.Open(driver, path); err != nil {
 		return nil, err
 	}
 	if err := store.db.Ping(); err != nil {
+		_ = store.db.Close()
 		return nil, err
 	}
-	if driver == "sqlite" {
-		_, _ = store.db.Exec("PRAGMA foreign_keys=ON")
-		_, _ = store.db.Exec("PRAGMA journal_mode=WAL")
-		_, _ = store.db.Exec("PRAGMA synchronous=NORMAL")
+	if len(store.encryptionKey) > 0 {
+		// The key is only checked once the database is read, which leaves the file untouched if it's the wrong key
+		if _, err := store.db.Exec("SELECT COUNT(*) FROM sqlite_master"); err != nil {
+			_ = store.db.Close()
+			if isSQLiteNotADatabaseError(err) {
+				return nil, fmt.Errorf("%w: %w", ErrInvalidEncryptionKey, err)
+			}
+			return nil, err
+		}
+	}
+	if store.driver == "sqlite" {
+		pragmas := sqlitePragmas
+		if len(store.encryptionKey) > 0 {
+			pragmas = append(slices.Clip(pragmas), encryptedSQLitePragmas...)
+		}
+		for _, pragma := range pragmas {
+			_, _ = store.db.Exec("PRAGMA " + pragma)
+		}
 		// Prevents driver from running into "database is locked" errors
 		// This is because we're using WAL to improve performance
 		store.db.SetMaxOpenConns(1)
@@ -94,16 +234,38 @@ func NewStore(driver, path string, caching bool) (*Store, error) {
 
 // createSchema creates the schema required to perform all database operations.
 func (s *Store) createSchema() error {
+	var err error
 	if s.driver == "sqlite" {
-		return s.createSQLiteSchema()
+		err = s.createSQLiteSchema()
+	} else if len(s.partitioning) > 0 {
+		err = s.createPartitionedPostgresSchema()
+	} else {
+		err = s.createPostgresSchema()
+	}
+	if err != nil {
+		return err
 	}
-	return s.createPostgresSchema()
+	return s.backfillDailyUptimes()
+}
+
+// backfillDailyUptimes rolls the hourly uptimes up into daily uptimes if there are none yet, which is the case for
+// databases created before daily uptimes were introduced
+func (s *Store) backfillDailyUptimes() error {
+	_, err := s.db.Exec(`
+		INSERT INTO endpoint_daily_uptimes (endpoint_id, day_unix_timestamp, total_executions, successful_executions, total_response_time)
+		SELECT endpoint_id, hour_unix_timestamp - hour_unix_timestamp % 86400, SUM(total_executions), SUM(successful_executions), SUM(total_response_time)
+		FROM endpoint_uptimes
+		WHERE NOT EXISTS (SELECT 1 FROM endpoint_daily_uptimes)
+		GROUP BY endpoint_id, hour_unix_timestamp - hour_unix_timestamp % 86400
+	`)
+	return err
 }
 
 // GetAllEndpointStatuses returns all monitored endpoint.Status
 // with a subset of endpoint.Result defined by the page and pageSize parameters
-func (s *Store) GetAllEndpointStatuses(params *paging.EndpointStatusParams) ([]*endpoint.Status, error) {
-	tx, err := s.db.Begin()
+func (s *Store) GetAllEndpointStatuses(params *paging.EndpointStatusParams) (_ []*endpoint.Status, err error) {
+	defer func(start time.Time) { s.observe("GetAllEndpointStatuses", start, err) }(time.Now())
+	tx, err := s.readDB().Begin()
 	if err != nil {
 		return nil, err
 	}
@@ -132,8 +294,9 @@ func (s *Store) GetEndpointStatus(groupName, endpointName string, params *paging
 }
 
 // GetEndpointStatusByKey returns the endpoint status for a given key
-func (s *Store) GetEndpointStatusByKey(key string, params *paging.EndpointStatusParams) (*endpoint.Status, error) {
-	tx, err := s.db.Begin()
+func (s *Store) GetEndpointStatusByKey(key string, params *paging.EndpointStatusParams) (_ *endpoint.Status, err error) {
+	defer func(start time.Time) { s.observe("GetEndpointStatusByKey", start, err) }(time.Now())
+	tx, err := s.readDB().Begin()
 	if err != nil {
 		return nil, err
 	}
@@ -149,11 +312,12 @@ func (s *Store) GetEndpointStatusByKey(key string, params *paging.EndpointStatus
 }
 
 // GetUptimeByKey returns the uptime percentage during a time range
-func (s *Store) GetUptimeByKey(key string, from, to time.Time) (float64, error) {
+func (s *Store) GetUptimeByKey(key string, from, to time.Time) (_ float64, err error) {
+	defer func(start time.Time) { s.observe("GetUptimeByKey", start, err) }(time.Now())
 	if from.After(to) {
 		return 0, common.ErrInvalidTimeRange
 	}
-	tx, err := s.db.Begin()
+	tx, err := s.readDB().Begin()
 	if err != nil {
 		return 0, err
 	}
@@ -174,11 +338,12 @@ func (s *Store) GetUptimeByKey(key string, from, to time.Time) (float64, error)
 }
 
 // GetAverageResponseTimeByKey returns the average response time in milliseconds (value) during a time range
-func (s *Store) GetAverageResponseTimeByKey(key string, from, to time.Time) (int, error) {
+func (s *Store) GetAverageResponseTimeByKey(key string, from, to time.Time) (_ int, err error) {
+	defer func(start time.Time) { s.observe("GetAverageResponseTimeByKey", start, err) }(time.Now())
 	if from.After(to) {
 		return 0, common.ErrInvalidTimeRange
 	}
-	tx, err := s.db.Begin()
+	tx, err := s.readDB().Begin()
 	if err != nil {
 		return 0, err
 	}
@@ -199,11 +364,12 @@ func (s *Store) GetAverageResponseTimeByKey(key string, from, to time.Time) (int
 }
 
 // GetHourlyAverageResponseTimeByKey returns a map of hourly (key) average response time in milliseconds (value) during a time range
-func (s *Store) GetHourlyAverageResponseTimeByKey(key string, from, to time.Time) (map[int64]int, error) {
+func (s *Store) GetHourlyAverageResponseTimeByKey(key string, from, to time.Time) (_ map[int64]int, err error) {
+	defer func(start time.Time) { s.observe("GetHourlyAverageResponseTimeByKey", start, err) }(time.Now())
 	if from.After(to) {
 		return nil, common.ErrInvalidTimeRange
 	}
-	tx, err := s.db.Begin()
+	tx, err := s.readDB().Begin()
 	if err != nil {
 		return nil, err
 	}
@@ -223,129 +389,129 @@ func (s *Store) GetHourlyAverageResponseTimeByKey(key string, from, to time.Time
 	return hourlyAverageResponseTimes, nil
 }
 
-// Insert adds the observed result for the specified endpoint into the store
-func (s *Store) Insert(ep *endpoint.Endpoint, result *endpoint.Result) error {
+// GetHourlyUptimeStatisticsByKey returns the hourly uptime statistics (value) of an endpoint for every hourly unix
+// timestamp (key)
+func (s *Store) GetHourlyUptimeStatisticsByKey(key string) (map[int64]*endpoint.HourlyUptimeStatistics, error) {
 	tx, err := s.db.Begin()
 	if err != nil {
-		return err
+		return nil, err
 	}
-	endpointID, err := s.getEndpointID(tx, ep)
+	endpointID, _, _, err := s.getEndpointIDGroupAndNameByKey(tx, key)
 	if err != nil {
-		if errors.Is(err, common.ErrEndpointNotFound) {
-			// Endpoint doesn't exist in the database, insert it
-			if endpointID, err = s.insertEndpoint(tx, ep); err != nil {
-				_ = tx.Rollback()
-				log.Printf("[sql.Insert] Failed to create endpoint with key=%s: %s", ep.Key(), err.Error())
-				return err
-			}
-		} else {
-			_ = tx.Rollback()
-			log.Printf("[sql.Insert] Failed to retrieve id of endpoint with key=%s: %s", ep.Key(), err.Error())
-			return err
-		}
+		_ = tx.Rollback()
+		return nil, err
 	}
-	// First, we need to check if we need to insert a new event.
-	//
-	// A new event must be added if either of the following cases happen:
-	// 1. There is only 1 event. The total number of events for an endpoint can only be 1 if the only existing event is
-	//    of type EventStart, in which case we will have to create a new event of type EventHealthy or EventUnhealthy
-	//    based on result.Success.
-	// 2. The lastResult.Success != result.Success. This implies that the endpoint went from healthy to unhealthy or
-	//    vice-versa, in which case we will have to create a new event of type EventHealthy or EventUnhealthy
-	//	  based on result.Success.
-	numberOfEvents, err := s.getNumberOfEventsByEndpointID(tx, endpointID)
+	hourlyStatistics, err := s.getEndpointHourlyUptimeStatistics(tx, endpointID)
 	if err != nil {
-		// Silently fail
-		log.Printf("[sql.Insert] Failed to retrieve total number of events for endpoint with key=%s: %s", ep.Key(), err.Error())
+		_ = tx.Rollback()
+		return nil, err
 	}
-	if numberOfEvents == 0 {
-		// There's no events yet, which means we need to add the EventStart and the first healthy/unhealthy event
-		err = s.insertEndpointEvent(tx, endpointID, &endpoint.Event{
-			Type:      endpoint.EventStart,
-			Timestamp: result.Timestamp.Add(-50 * time.Millisecond),
-		})
-		if err != nil {
-			// Silently fail
-			log.Printf("[sql.Insert] Failed to insert event=%s for endpoint with key=%s: %s", endpoint.EventStart, ep.Key(), err.Error())
-		}
-		event := endpoint.NewEventFromResult(result)
-		if err = s.insertEndpointEvent(tx, endpointID, event); err != nil {
-			// Silently fail
-			log.Printf("[sql.Insert] Failed to insert event=%s for endpoint with key=%s: %s", event.Type, ep.Key(), err.Error())
-		}
-	} else {
-		// Get the success value of the previous result
-		var lastResultSuccess bool
-		if lastResultSuccess, err = s.getLastEndpointResultSuccessValue(tx, endpointID); err != nil {
-			log.Printf("[sql.Insert] Failed to retrieve outcome of previous result for endpoint with key=%s: %s", ep.Key(), err.Error())
-		} else {
-			// If we managed to retrieve the outcome of the previous result, we'll compare it with the new result.
-			// If the final outcome (success or failure) of the previous and the new result aren't the same, it means
-			// that the endpoint either went from Healthy to Unhealthy or Unhealthy -> Healthy, therefore, we'll add
-			// an event to mark the change in state
-			if lastResultSuccess != result.Success {
-				event := endpoint.NewEventFromResult(result)
-				if err = s.insertEndpointEvent(tx, endpointID, event); err != nil {
-					// Silently fail
-					log.Printf("[sql.Insert] Failed to insert event=%s for endpoint with key=%s: %s", event.Type, ep.Key(), err.Error())
-				}
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+	}
+	return hourlyStatistics, nil
+}
+
+// ImportEndpointStatus replaces the results, events and uptime of an endpoint by those of the status passed as
+// parameter, creating the endpoint if it doesn't exist.
+//
+// With partitioning, results older than the retention are skipped, since their partition would be dropped anyway.
+func (s *Store) ImportEndpointStatus(status *endpoint.Status) error {
+	results := status.Results
+	if len(s.partitioning) > 0 {
+		oldest := time.Now().Add(-s.retention)
+		results = make([]*endpoint.Result, 0, len(status.Results))
+		for _, result := range status.Results {
+			if result.Timestamp.After(oldest) {
+				results = append(results, result)
 			}
 		}
-		// Clean up old events if there's more than twice the maximum number of events
-		// This lets us both keep the table clean without impacting performance too much
-		// (since we're only deleting MaximumNumberOfEvents at a time instead of 1)
-		if numberOfEvents > eventsCleanUpThreshold {
-			if err = s.deleteOldEndpointEvents(tx, endpointID); err != nil {
-				log.Printf("[sql.Insert] Failed to delete old events for endpoint with key=%s: %s", ep.Key(), err.Error())
+	}
+	if s.partitioning == PartitioningNative {
+		for _, result := range results {
+			if err := s.maintainPartitions(result.Timestamp); err != nil {
+				return err
 			}
 		}
 	}
-	// Second, we need to insert the result.
-	if err = s.insertEndpointResult(tx, endpointID, result); err != nil {
-		log.Printf("[sql.Insert] Failed to insert result for endpoint with key=%s: %s", ep.Key(), err.Error())
-		_ = tx.Rollback() // If we can't insert the result, we'll rollback now since there's no point continuing
+	ep := &endpoint.Endpoint{Name: status.Name, Group: status.Group}
+	tx, err := s.db.Begin()
+	if err != nil {
 		return err
 	}
-	// Clean up old results
-	numberOfResults, err := s.getNumberOfResultsByEndpointID(tx, endpointID)
-	if err != nil {
-		log.Printf("[sql.Insert] Failed to retrieve total number of results for endpoint with key=%s: %s", ep.Key(), err.Error())
-	} else {
-		if numberOfResults > resultsCleanUpThreshold {
-			if err = s.deleteOldEndpointResults(tx, endpointID); err != nil {
-				log.Printf("[sql.Insert] Failed to delete old results for endpoint with key=%s: %s", ep.Key(), err.Error())
+	endpointID, err := s.getEndpointID(tx, ep)
+	if errors.Is(err, common.ErrEndpointNotFound) {
+		endpointID, err = s.insertEndpoint(tx, ep)
+	}
+	if err == nil {
+		err = s.deleteEndpointData(tx, endpointID)
+	}
+	for i := 0; err == nil && i < len(status.Events); i++ {
+		err = s.insertEndpointEvent(tx, endpointID, status.Events[i])
+	}
+	for i := 0; err == nil && i < len(results); i++ {
+		err = s.insertEndpointResult(tx, endpointID, results[i])
+	}
+	if err == nil && status.Uptime != nil {
+		for hourlyUnixTimestamp, statistics := range status.Uptime.HourlyStatistics {
+			if err = s.insertEndpointHourlyUptimeStatistics(tx, endpointID, hourlyUnixTimestamp, statistics); err != nil {
+				break
 			}
 		}
 	}
-	// Finally, we need to insert the uptime data.
-	// Because the uptime data significantly outlives the results, we can't rely on the results for determining the uptime
-	if err = s.updateEndpointUptime(tx, endpointID, result); err != nil {
-		log.Printf("[sql.Insert] Failed to update uptime for endpoint with key=%s: %s", ep.Key(), err.Error())
-	}
-	// Clean up old uptime entries
-	ageOfOldestUptimeEntry, err := s.getAgeOfOldestEndpointUptimeEntry(tx, endpointID)
-	if err != nil {
-		log.Printf("[sql.Insert] Failed to retrieve oldest endpoint uptime entry for endpoint with key=%s: %s", ep.Key(), err.Error())
-	} else {
-		if ageOfOldestUptimeEntry > uptimeCleanUpThreshold {
-			if err = s.deleteOldUptimeEntries(tx, endpointID, time.Now().Add(-(uptimeRetention + time.Hour))); err != nil {
-				log.Printf("[sql.Insert] Failed to delete old uptime entries for endpoint with key=%s: %s", ep.Key(), err.Error())
+	if err == nil && status.Uptime != nil {
+		dailyStatistics := status.Uptime.DailyStatistics
+		if len(dailyStatistics) == 0 {
+			// Backups don't have daily statistics, so they're rolled up from the hourly statistics instead
+			dailyStatistics = status.Uptime.HourlyStatisticsByDay()
+		}
+		for dailyUnixTimestamp, statistics := range dailyStatistics {
+			if err = s.insertEndpointDailyUptimeStatistics(tx, endpointID, dailyUnixTimestamp, statistics); err != nil {
+				break
 			}
 		}
 	}
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
 	if s.writeThroughCache != nil {
-		cacheKeysToRefresh := s.writeThroughCache.GetKeysByPattern(ep.Key()+"*", 0)
-		for _, cacheKey := range cacheKeysToRefresh {
-			s.writeThroughCache.Delete(cacheKey)
-			endpointKey, params, err := extractKeyAndParamsFromCacheKey(cacheKey)
-			if err != nil {
-				log.Printf("[sql.Insert] Silently deleting cache key %s instead of refreshing due to error: %s", cacheKey, err.Error())
-				continue
-			}
-			// Retrieve the endpoint status by key, which will in turn refresh the cache
-			_, _ = s.getEndpointStatusByKey(tx, endpointKey, params)
+		_ = s.writeThroughCache.DeleteKeysByPattern(ep.Key() + "*")
+	}
+	return nil
+}
+
+// Insert adds the observed result for the specified endpoint into the store, or into the write buffer if it's enabled
+func (s *Store) Insert(ep *endpoint.Endpoint, result *endpoint.Result) (err error) {
+	defer func(start time.Time) { s.observe("Insert", start, err) }(time.Now())
+	if s.batchSize > 0 {
+		s.bufferMutex.Lock()
+		s.pendingInserts = append(s.pendingInserts, &pendingInsert{endpoint: ep, result: result})
+		isBatchFull := len(s.pendingInserts) >= s.batchSize
+		s.bufferMutex.Unlock()
+		if isBatchFull {
+			return s.Save()
+		}
+		return nil
+	}
+	if s.partitioning == PartitioningNative {
+		if err := s.maintainPartitions(result.Timestamp); err != nil {
+			log.Printf("[sql.Insert] Failed to maintain partitions: %s", err.Error())
+			return err
 		}
 	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	if err = s.insert(tx, ep, result); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
 	if err = tx.Commit(); err != nil {
 		_ = tx.Rollback()
 	}
@@ -354,6 +520,10 @@ func (s *Store) Insert(ep *endpoint.Endpoint, result *endpoint.Result) error {
 
 // DeleteAllEndpointStatusesNotInKeys removes all rows owned by an endpoint whose key is not within the keys provided
 func (s *Store) DeleteAllEndpointStatusesNotInKeys(keys []string) int {
+	// Buffered results are inserted first, so that they don't recreate the endpoints they belong to once deleted
+	if err := s.Save(); err != nil {
+		log.Printf("[sql.DeleteAllEndpointStatusesNotInKeys] Failed to insert buffered results: %s", err.Error())
+	}
 	var err error
 	var result sql.Result
 	if len(keys) == 0 {
@@ -489,28 +659,368 @@ func (s *Store) DeleteAllTriggeredAlertsNotInChecksumsByEndpoint(ep *endpoint.En
 	return int(rowsAffects)
 }
 
+// GetFailureCapturesByKey returns the captures of the checks that failed of an endpoint, from newest to oldest
+func (s *Store) GetFailureCapturesByKey(key string) ([]*endpoint.FailureCapture, error) {
+	tx, err := s.readDB().Begin()
+	if err != nil {
+		return nil, err
+	}
+	endpointID, _, _, err := s.getEndpointIDGroupAndNameByKey(tx, key)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	captures, err := s.getEndpointFailureCapturesByEndpointID(tx, endpointID)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+	}
+	return captures, nil
+}
+
 // Clear deletes everything from the store
 func (s *Store) Clear() {
+	s.bufferMutex.Lock()
+	s.pendingInserts = nil
+	s.bufferMutex.Unlock()
 	_, _ = s.db.Exec("DELETE FROM endpoints")
+	_, _ = s.db.Exec("DELETE FROM annotations")
+	_, _ = s.db.Exec("DELETE FROM announcements")
+	_, _ = s.db.Exec("DELETE FROM subscriptions")
+	_, _ = s.db.Exec("DELETE FROM audit_entries")
+	_, _ = s.db.Exec("DELETE FROM tokens")
+	if len(s.partitioning) > 0 {
+		// Partitioned condition results don't reference the results, so they aren't deleted in cascade
+		_, _ = s.db.Exec("DELETE FROM endpoint_result_conditions")
+	}
 	if s.writeThroughCache != nil {
 		_ = s.writeThroughCache.DeleteKeysByPattern("*")
 	}
 }
 
-// Save does nothing, because this store is immediately persistent.
-func (s *Store) Save() error {
+// Save inserts the results buffered by the write buffer in a single transaction. If that transaction fails, each
+// result is inserted in its own transaction instead, so that a result that cannot be inserted doesn't prevent the
+// others from being inserted.
+//
+// Does nothing if the write buffer is disabled, because this store is then immediately persistent.
+func (s *Store) Save() (err error) {
+	s.flushMutex.Lock()
+	defer s.flushMutex.Unlock()
+	s.bufferMutex.Lock()
+	pendingInserts := s.pendingInserts
+	s.pendingInserts = nil
+	s.bufferMutex.Unlock()
+	if len(pendingInserts) == 0 {
+		return nil
+	}
+	defer func(start time.Time) { s.observe("Save", start, err) }(time.Now())
+	metrics.ObserveStoreWriteBatch(s.driver, len(pendingInserts))
+	if s.partitioning == PartitioningNative {
+		for _, pending := range pendingInserts {
+			if err := s.maintainPartitions(pending.result.Timestamp); err != nil {
+				log.Printf("[sql.Save] Failed to maintain partitions: %s", err.Error())
+				return err
+			}
+		}
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	for _, pending := range pendingInserts {
+		if err = s.insert(tx, pending.endpoint, pending.result); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
+	if err == nil {
+		return nil
+	}
+	_ = tx.Rollback()
+	log.Printf("[sql.Save] Failed to insert %d buffered results at once, inserting them one by one: %s", len(pendingInserts), err.Error())
+	var lastErr error
+	for _, pending := range pendingInserts {
+		if tx, err = s.db.Begin(); err != nil {
+			return err
+		}
+		if err = s.insert(tx, pending.endpoint, pending.result); err == nil {
+			err = tx.Commit()
+		}
+		if err != nil {
+			_ = tx.Rollback()
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+// SetArchiver sets the archiver used to archive the results before they're cleaned up
+func (s *Store) SetArchiver(archiver *archive.Archiver) {
+	s.archiver = archiver
+}
+
+// SetRetentionPolicy sets the function returning the retention policy of each endpoint
+func (s *Store) SetRetentionPolicy(retentionPolicy common.RetentionPolicyFunc) {
+	s.retentionPolicy = retentionPolicy
+}
+
+// SetWriteBuffer enables the write buffer, which buffers the results inserted so that they're inserted batchSize at a
+// time in a single transaction, or once flushInterval elapses, whichever comes first. As a result, a result may take up
+// to flushInterval to be returned by the store.
+//
+// Must be called before the store is used.
+func (s *Store) SetWriteBuffer(batchSize int, flushInterval time.Duration) {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	if flushInterval <= 0 {
+		flushInterval = DefaultFlushInterval
+	}
+	s.batchSize, s.flushInterval = batchSize, flushInterval
+	s.stop, s.stopped = make(chan struct{}), make(chan struct{})
+	go s.flushPeriodically()
+}
+
+func (s *Store) flushPeriodically() {
+	defer close(s.stopped)
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			if err := s.Save(); err != nil {
+				log.Printf("[sql.flushPeriodically] Failed to insert buffered results: %s", err.Error())
+			}
+		}
+	}
+}
+
+// SetConnectionPool sets the maximum number of open and idle connections to the database, as well as the maximum
+// amount of time a connection may be reused. Parameters that are 0 are left to their default.
+//
+// With SQLite, maxOpenConns is ignored, because only a single connection is ever opened.
+func (s *Store) SetConnectionPool(maxOpenConns, maxIdleConns int, connMaxLifetime time.Duration) {
+	for _, db := range []*sql.DB{s.db, s.replicas} {
+		if db == nil {
+			continue
+		}
+		if maxOpenConns > 0 && s.driver != "sqlite" {
+			db.SetMaxOpenConns(maxOpenConns)
+		}
+		if maxIdleConns > 0 {
+			db.SetMaxIdleConns(maxIdleConns)
+		}
+		if connMaxLifetime > 0 {
+			db.SetConnMaxLifetime(connMaxLifetime)
+		}
+	}
+}
+
+// Ping checks that the database, as well as the replicas the reads are made on, if any, can be reached
+func (s *Store) Ping(ctx context.Context) error {
+	if err := s.db.PingContext(ctx); err != nil {
+		return err
+	}
+	if s.replicas != nil {
+		return s.replicas.PingContext(ctx)
+	}
 	return nil
 }
 
 // Close the database handle
 func (s *Store) Close() {
+	if s.stop != nil {
+		select {
+		case <-s.stop:
+		default:
+			close(s.stop)
+			<-s.stopped
+			if err := s.Save(); err != nil {
+				log.Printf("[sql.Close] Failed to insert buffered results: %s", err.Error())
+			}
+		}
+	}
+	if s.archiver != nil {
+		s.archiver.Close()
+	}
 	_ = s.db.Close()
+	if s.replicas != nil {
+		_ = s.replicas.Close()
+	}
 	if s.writeThroughCache != nil {
 		// Clear the cache too. If the store's been closed, we don't want to keep the cache around.
 		_ = s.writeThroughCache.DeleteKeysByPattern("*")
 	}
 }
 
+// insert adds the observed result for the specified endpoint, along with the events it results in, and cleans up the
+// old results, events and uptime entries of the endpoint
+func (s *Store) insert(tx *sql.Tx, ep *endpoint.Endpoint, result *endpoint.Result) error {
+	endpointID, err := s.getEndpointID(tx, ep)
+	if err != nil {
+		if errors.Is(err, common.ErrEndpointNotFound) {
+			// Endpoint doesn't exist in the database, insert it
+			if endpointID, err = s.insertEndpoint(tx, ep); err != nil {
+				log.Printf("[sql.Insert] Failed to create endpoint with key=%s: %s", ep.Key(), err.Error())
+				return err
+			}
+		} else {
+			log.Printf("[sql.Insert] Failed to retrieve id of endpoint with key=%s: %s", ep.Key(), err.Error())
+			return err
+		}
+	}
+	// First, we need to check if we need to insert a new event.
+	//
+	// A new event must be added if either of the following cases happen:
+	// 1. There is only 1 event. The total number of events for an endpoint can only be 1 if the only existing event is
+	//    of type EventStart, in which case we will have to create a new event of type EventHealthy or EventUnhealthy
+	//    based on result.Success.
+	// 2. The lastResult.Success != result.Success. This implies that the endpoint went from healthy to unhealthy or
+	//    vice-versa, in which case we will have to create a new event of type EventHealthy or EventUnhealthy
+	//	  based on result.Success.
+	policy := common.DefaultRetentionPolicy
+	if s.retentionPolicy != nil {
+		policy = s.retentionPolicy(ep.Group, ep.Name)
+	}
+	numberOfEvents, err := s.getNumberOfEventsByEndpointID(tx, endpointID)
+	if err != nil {
+		// Silently fail
+		log.Printf("[sql.Insert] Failed to retrieve total number of events for endpoint with key=%s: %s", ep.Key(), err.Error())
+	}
+	if numberOfEvents == 0 {
+		// There's no events yet, which means we need to add the EventStart and the first healthy/unhealthy event
+		err = s.insertEndpointEvent(tx, endpointID, &endpoint.Event{
+			Type:      endpoint.EventStart,
+			Timestamp: result.Timestamp.Add(-50 * time.Millisecond),
+		})
+		if err != nil {
+			// Silently fail
+			log.Printf("[sql.Insert] Failed to insert event=%s for endpoint with key=%s: %s", endpoint.EventStart, ep.Key(), err.Error())
+		}
+		event := endpoint.NewEventFromResult(result)
+		if err = s.insertEndpointEvent(tx, endpointID, event); err != nil {
+			// Silently fail
+			log.Printf("[sql.Insert] Failed to insert event=%s for endpoint with key=%s: %s", event.Type, ep.Key(), err.Error())
+		}
+	} else {
+		// Get the success value of the previous result
+		var lastResultSuccess bool
+		if lastResultSuccess, err = s.getLastEndpointResultSuccessValue(tx, endpointID); err != nil {
+			log.Printf("[sql.Insert] Failed to retrieve outcome of previous result for endpoint with key=%s: %s", ep.Key(), err.Error())
+		} else {
+			// If we managed to retrieve the outcome of the previous result, we'll compare it with the new result.
+			// If the final outcome (success or failure) of the previous and the new result aren't the same, it means
+			// that the endpoint either went from Healthy to Unhealthy or Unhealthy -> Healthy, therefore, we'll add
+			// an event to mark the change in state
+			if lastResultSuccess != result.Success {
+				event := endpoint.NewEventFromResult(result)
+				if err = s.insertEndpointEvent(tx, endpointID, event); err != nil {
+					// Silently fail
+					log.Printf("[sql.Insert] Failed to insert event=%s for endpoint with key=%s: %s", event.Type, ep.Key(), err.Error())
+				}
+			}
+		}
+		// Clean up old events if there's more than the maximum number of events plus a margin
+		// This lets us both keep the table clean without impacting performance too much
+		// (since we're deleting cleanUpMargin events at a time instead of 1)
+		if numberOfEvents > int64(policy.MaximumNumberOfEvents+cleanUpMargin) {
+			if err = s.deleteOldEndpointEvents(tx, endpointID, policy.MaximumNumberOfEvents); err != nil {
+				log.Printf("[sql.Insert] Failed to delete old events for endpoint with key=%s: %s", ep.Key(), err.Error())
+			}
+		}
+	}
+	// Second, we need to insert the result.
+	if err = s.insertEndpointResult(tx, endpointID, result); err != nil {
+		log.Printf("[sql.Insert] Failed to insert result for endpoint with key=%s: %s", ep.Key(), err.Error())
+		return err
+	}
+	if result.FailureCapture != nil && ep.FailureCaptureConfig != nil {
+		if err = s.insertEndpointFailureCapture(tx, endpointID, result.FailureCapture); err != nil {
+			// Silently fail
+			log.Printf("[sql.Insert] Failed to insert failure capture for endpoint with key=%s: %s", ep.Key(), err.Error())
+		} else if err = s.deleteOldEndpointFailureCaptures(tx, endpointID, ep.FailureCaptureConfig.MaximumCaptures); err != nil {
+			log.Printf("[sql.Insert] Failed to delete old failure captures for endpoint with key=%s: %s", ep.Key(), err.Error())
+		}
+	}
+	// Clean up old results, unless they're partitioned, in which case old results are dropped along with their partition
+	if len(s.partitioning) == 0 {
+		numberOfResults, err := s.getNumberOfResultsByEndpointID(tx, endpointID)
+		if err != nil {
+			log.Printf("[sql.Insert] Failed to retrieve total number of results for endpoint with key=%s: %s", ep.Key(), err.Error())
+		} else {
+			numberOfResultsToKeep := policy.MaximumNumberOfResults
+			shouldCleanUp := numberOfResults > int64(policy.MaximumNumberOfResults+cleanUpMargin)
+			if policy.MaximumAge > 0 {
+				// Similarly, results that are too old are only cleaned up once the oldest result exceeds the maximum age
+				// by a tenth, so that they're not deleted one at a time
+				oldest := result.Timestamp.Add(-policy.MaximumAge)
+				oldestResultTimestamp, err := s.getOldestEndpointResultTimestamp(tx, endpointID)
+				if err != nil {
+					log.Printf("[sql.Insert] Failed to retrieve oldest result for endpoint with key=%s: %s", ep.Key(), err.Error())
+				} else if oldestResultTimestamp.Before(oldest.Add(-policy.MaximumAge / 10)) {
+					numberOfRecentResults, err := s.getNumberOfResultsSinceByEndpointID(tx, endpointID, oldest)
+					if err != nil {
+						log.Printf("[sql.Insert] Failed to retrieve number of recent results for endpoint with key=%s: %s", ep.Key(), err.Error())
+					} else if numberOfRecentResults < int64(numberOfResultsToKeep) {
+						numberOfResultsToKeep, shouldCleanUp = int(numberOfRecentResults), true
+					}
+					if err = s.deleteEndpointEventsBefore(tx, endpointID, oldest); err != nil {
+						log.Printf("[sql.Insert] Failed to delete old events for endpoint with key=%s: %s", ep.Key(), err.Error())
+					}
+				}
+			}
+			if shouldCleanUp {
+				if s.archiver != nil {
+					s.archiveOldEndpointResults(tx, ep, endpointID, numberOfResultsToKeep)
+				}
+				if err = s.deleteOldEndpointResults(tx, endpointID, numberOfResultsToKeep); err != nil {
+					log.Printf("[sql.Insert] Failed to delete old results for endpoint with key=%s: %s", ep.Key(), err.Error())
+				}
+			}
+		}
+	}
+	// Finally, we need to insert the uptime data.
+	// Because the uptime data significantly outlives the results, we can't rely on the results for determining the uptime
+	if err = s.updateEndpointUptime(tx, endpointID, result); err != nil {
+		log.Printf("[sql.Insert] Failed to update uptime for endpoint with key=%s: %s", ep.Key(), err.Error())
+	}
+	// Clean up old uptime entries
+	ageOfOldestUptimeEntry, err := s.getAgeOfOldestEndpointUptimeEntry(tx, endpointID)
+	if err != nil {
+		log.Printf("[sql.Insert] Failed to retrieve oldest endpoint uptime entry for endpoint with key=%s: %s", ep.Key(), err.Error())
+	} else {
+		if ageOfOldestUptimeEntry > uptimeCleanUpThreshold {
+			if err = s.deleteOldUptimeEntries(tx, endpointID, time.Now().Add(-(uptimeRetention + time.Hour))); err != nil {
+				log.Printf("[sql.Insert] Failed to delete old uptime entries for endpoint with key=%s: %s", ep.Key(), err.Error())
+			}
+			if err = s.deleteOldDailyUptimeEntries(tx, endpointID, time.Now().Add(-(dailyUptimeRetention + 24*time.Hour))); err != nil {
+				log.Printf("[sql.Insert] Failed to delete old daily uptime entries for endpoint with key=%s: %s", ep.Key(), err.Error())
+			}
+		}
+	}
+	if s.writeThroughCache != nil {
+		cacheKeysToRefresh := s.writeThroughCache.GetKeysByPattern(ep.Key()+"*", 0)
+		for _, cacheKey := range cacheKeysToRefresh {
+			s.writeThroughCache.Delete(cacheKey)
+			endpointKey, params, err := extractKeyAndParamsFromCacheKey(cacheKey)
+			if err != nil {
+				log.Printf("[sql.Insert] Silently deleting cache key %s instead of refreshing due to error: %s", cacheKey, err.Error())
+				continue
+			}
+			// Retrieve the endpoint status by key, which will in turn refresh the cache
+			_, _ = s.getEndpointStatusByKey(tx, endpointKey, params)
+		}
+	}
+	return nil
+}
+
 // insertEndpoint inserts an endpoint in the store and returns the generated id of said endpoint
 func (s *Store) insertEndpoint(tx *sql.Tx, ep *endpoint.Endpoint) (int64, error) {
 	//log.Printf("[sql.insertEndpoint] Inserting endpoint with group=%s and name=%s", ep.Group, ep.Name)
@@ -527,6 +1037,23 @@ func (s *Store) insertEndpoint(tx *sql.Tx, ep *endpoint.Endpoint) (int64, error)
 	return id, nil
 }
 
+// deleteEndpointData deletes the results, events and uptime of an endpoint, but not the endpoint itself
+func (s *Store) deleteEndpointData(tx *sql.Tx, endpointID int64) error {
+	if len(s.partitioning) > 0 {
+		// Partitioned condition results don't reference the results, so they aren't deleted in cascade
+		_, err := tx.Exec("DELETE FROM endpoint_result_conditions WHERE endpoint_result_id IN (SELECT endpoint_result_id FROM endpoint_results WHERE endpoint_id = $1)", endpointID)
+		if err != nil {
+			return err
+		}
+	}
+	for _, table := range []string{"endpoint_results", "endpoint_events", "endpoint_uptimes", "endpoint_daily_uptimes"} {
+		if _, err := tx.Exec("DELETE FROM "+table+" WHERE endpoint_id = $1", endpointID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // insertEndpointEvent inserts en event in the store
 func (s *Store) insertEndpointEvent(tx *sql.Tx, endpointID int64, event *endpoint.Event) error {
 	_, err := tx.Exec(
@@ -543,11 +1070,18 @@ func (s *Store) insertEndpointEvent(tx *sql.Tx, endpointID int64, event *endpoin
 
 // insertEndpointResult inserts a result in the store
 func (s *Store) insertEndpointResult(tx *sql.Tx, endpointID int64, result *endpoint.Result) error {
+	var metadata []byte
+	if len(result.Metadata) > 0 {
+		var err error
+		if metadata, err = json.Marshal(result.Metadata); err != nil {
+			return err
+		}
+	}
 	var endpointResultID int64
 	err := tx.QueryRow(
 		`
-			INSERT INTO endpoint_results (endpoint_id, success, errors, connected, status, dns_rcode, certificate_expiration, domain_expiration, hostname, ip, duration, timestamp)
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			INSERT INTO endpoint_results (endpoint_id, success, errors, connected, status, dns_rcode, certificate_expiration, domain_expiration, hostname, ip, duration, metadata, timestamp)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 			RETURNING endpoint_result_id
 		`,
 		endpointID,
@@ -561,22 +1095,52 @@ func (s *Store) insertEndpointResult(tx *sql.Tx, endpointID int64, result *endpo
 		result.Hostname,
 		result.IP,
 		result.Duration,
+		string(metadata),
 		result.Timestamp.UTC(),
 	).Scan(&endpointResultID)
 	if err != nil {
 		return err
 	}
-	return s.insertConditionResults(tx, endpointResultID, result.ConditionResults)
+	return s.insertConditionResults(tx, endpointResultID, result.Timestamp, result.ConditionResults)
+}
+
+// insertEndpointFailureCapture inserts the capture of a check that failed in the store
+func (s *Store) insertEndpointFailureCapture(tx *sql.Tx, endpointID int64, capture *endpoint.FailureCapture) error {
+	headers, err := json.Marshal(capture.Headers)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(
+		"INSERT INTO endpoint_failure_captures (endpoint_id, status, headers, body, truncated, errors, timestamp) VALUES ($1, $2, $3, $4, $5, $6, $7)",
+		endpointID,
+		capture.HTTPStatus,
+		string(headers),
+		capture.Body,
+		capture.Truncated,
+		strings.Join(capture.Errors, arraySeparator),
+		capture.Timestamp.UTC(),
+	)
+	return err
 }
 
-func (s *Store) insertConditionResults(tx *sql.Tx, endpointResultID int64, conditionResults []*endpoint.ConditionResult) error {
+func (s *Store) insertConditionResults(tx *sql.Tx, endpointResultID int64, timestamp time.Time, conditionResults []*endpoint.ConditionResult) error {
 	var err error
 	for _, cr := range conditionResults {
-		_, err = tx.Exec("INSERT INTO endpoint_result_conditions (endpoint_result_id, condition, success) VALUES ($1, $2, $3)",
-			endpointResultID,
-			cr.Condition,
-			cr.Success,
-		)
+		if len(s.partitioning) > 0 {
+			// Partitioned condition results are partitioned by the timestamp of their result
+			_, err = tx.Exec("INSERT INTO endpoint_result_conditions (endpoint_result_id, condition, success, timestamp) VALUES ($1, $2, $3, $4)",
+				endpointResultID,
+				cr.Condition,
+				cr.Success,
+				timestamp.UTC(),
+			)
+		} else {
+			_, err = tx.Exec("INSERT INTO endpoint_result_conditions (endpoint_result_id, condition, success) VALUES ($1, $2, $3)",
+				endpointResultID,
+				cr.Condition,
+				cr.Success,
+			)
+		}
 		if err != nil {
 			return err
 		}
@@ -605,6 +1169,48 @@ func (s *Store) updateEndpointUptime(tx *sql.Tx, endpointID int64, result *endpo
 		successfulExecutions,
 		result.Duration.Milliseconds(),
 	)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(
+		`
+			INSERT INTO endpoint_daily_uptimes (endpoint_id, day_unix_timestamp, total_executions, successful_executions, total_response_time)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT(endpoint_id, day_unix_timestamp) DO UPDATE SET
+				total_executions = excluded.total_executions + endpoint_daily_uptimes.total_executions,
+				successful_executions = excluded.successful_executions + endpoint_daily_uptimes.successful_executions,
+				total_response_time = excluded.total_response_time + endpoint_daily_uptimes.total_response_time
+		`,
+		endpointID,
+		result.Timestamp.Truncate(24*time.Hour).Unix(),
+		1,
+		successfulExecutions,
+		result.Duration.Milliseconds(),
+	)
+	return err
+}
+
+func (s *Store) insertEndpointHourlyUptimeStatistics(tx *sql.Tx, endpointID, hourlyUnixTimestamp int64, statistics *endpoint.HourlyUptimeStatistics) error {
+	_, err := tx.Exec(
+		"INSERT INTO endpoint_uptimes (endpoint_id, hour_unix_timestamp, total_executions, successful_executions, total_response_time) VALUES ($1, $2, $3, $4, $5)",
+		endpointID,
+		hourlyUnixTimestamp,
+		int64(statistics.TotalExecutions),
+		int64(statistics.SuccessfulExecutions),
+		int64(statistics.TotalExecutionsResponseTime),
+	)
+	return err
+}
+
+func (s *Store) insertEndpointDailyUptimeStatistics(tx *sql.Tx, endpointID, dailyUnixTimestamp int64, statistics *endpoint.HourlyUptimeStatistics) error {
+	_, err := tx.Exec(
+		"INSERT INTO endpoint_daily_uptimes (endpoint_id, day_unix_timestamp, total_executions, successful_executions, total_response_time) VALUES ($1, $2, $3, $4, $5)",
+		endpointID,
+		dailyUnixTimestamp,
+		int64(statistics.TotalExecutions),
+		int64(statistics.SuccessfulExecutions),
+		int64(statistics.TotalExecutionsResponseTime),
+	)
 	return err
 }
 
@@ -698,7 +1304,7 @@ func (s *Store) getEndpointEventsByEndpointID(tx *sql.Tx, endpointID int64, page
 func (s *Store) getEndpointResultsByEndpointID(tx *sql.Tx, endpointID int64, page, pageSize int) (results []*endpoint.Result, err error) {
 	rows, err := tx.Query(
 		`
-			SELECT endpoint_result_id, success, errors, connected, status, dns_rcode, certificate_expiration, domain_expiration, hostname, ip, duration, timestamp
+			SELECT endpoint_result_id, success, errors, connected, status, dns_rcode, certificate_expiration, domain_expiration, hostname, ip, duration, metadata, timestamp
 			FROM endpoint_results
 			WHERE endpoint_id = $1
 			ORDER BY endpoint_result_id DESC -- Normally, we'd sort by timestamp, but sorting by endpoint_result_id is faster
@@ -712,11 +1318,12 @@ func (s *Store) getEndpointResultsByEndpointID(tx *sql.Tx, endpointID int64, pag
 		return nil, err
 	}
 	idResultMap := make(map[int64]*endpoint.Result)
+	var oldestTimestamp time.Time
 	for rows.Next() {
 		result := &endpoint.Result{}
 		var id int64
-		var joinedErrors string
-		err = rows.Scan(&id, &result.Success, &joinedErrors, &result.Connected, &result.HTTPStatus, &result.DNSRCode, &result.CertificateExpiration, &result.DomainExpiration, &result.Hostname, &result.IP, &result.Duration, &result.Timestamp)
+		var joinedErrors, metadata string
+		err = rows.Scan(&id, &result.Success, &joinedErrors, &result.Connected, &result.HTTPStatus, &result.DNSRCode, &result.CertificateExpiration, &result.DomainExpiration, &result.Hostname, &result.IP, &result.Duration, &metadata, &result.Timestamp)
 		if err != nil {
 			log.Printf("[sql.getEndpointResultsByEndpointID] Silently failed to retrieve endpoint result for endpointID=%d: %s", endpointID, err.Error())
 			err = nil
@@ -724,9 +1331,18 @@ func (s *Store) getEndpointResultsByEndpointID(tx *sql.Tx, endpointID int64, pag
 		if len(joinedErrors) != 0 {
 			result.Errors = strings.Split(joinedErrors, arraySeparator)
 		}
+		if len(metadata) != 0 {
+			if err = json.Unmarshal([]byte(metadata), &result.Metadata); err != nil {
+				log.Printf("[sql.getEndpointResultsByEndpointID] Silently failed to retrieve the metadata of endpoint result for endpointID=%d: %s", endpointID, err.Error())
+				err = nil
+			}
+		}
 		// This is faster than using a subselect
 		results = append([]*endpoint.Result{result}, results...)
 		idResultMap[id] = result
+		if oldestTimestamp.IsZero() || result.Timestamp.Before(oldestTimestamp) {
+			oldestTimestamp = result.Timestamp
+		}
 	}
 	if len(idResultMap) == 0 {
 		// If there's no result, we'll just return an empty/nil slice
@@ -744,6 +1360,11 @@ func (s *Store) getEndpointResultsByEndpointID(tx *sql.Tx, endpointID int64, pag
 		index++
 	}
 	query = query[:len(query)-1] + ")"
+	if len(s.partitioning) > 0 {
+		// Only scan the partitions that may contain the condition results
+		query += " AND timestamp >= $" + strconv.Itoa(index)
+		args = append(args, oldestTimestamp)
+	}
 	rows, err = tx.Query(query, args...)
 	if err != nil {
 		return nil, err
@@ -760,14 +1381,63 @@ func (s *Store) getEndpointResultsByEndpointID(tx *sql.Tx, endpointID int64, pag
 	return
 }
 
+// getEndpointFailureCapturesByEndpointID returns the captures of the checks that failed of an endpoint, from newest
+// to oldest
+func (s *Store) getEndpointFailureCapturesByEndpointID(tx *sql.Tx, endpointID int64) ([]*endpoint.FailureCapture, error) {
+	rows, err := tx.Query(
+		`
+			SELECT status, headers, body, truncated, errors, timestamp
+			FROM endpoint_failure_captures
+			WHERE endpoint_id = $1
+			ORDER BY endpoint_failure_capture_id DESC
+		`,
+		endpointID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	captures := make([]*endpoint.FailureCapture, 0)
+	for rows.Next() {
+		capture := &endpoint.FailureCapture{}
+		var headers, joinedErrors string
+		if err = rows.Scan(&capture.HTTPStatus, &headers, &capture.Body, &capture.Truncated, &joinedErrors, &capture.Timestamp); err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal([]byte(headers), &capture.Headers); err != nil {
+			return nil, err
+		}
+		if len(joinedErrors) != 0 {
+			capture.Errors = strings.Split(joinedErrors, arraySeparator)
+		}
+		captures = append(captures, capture)
+	}
+	return captures, rows.Err()
+}
+
+// uptimeTableAndColumn returns the table that the uptime during a time range is computed from, along with the column
+// of its timestamps, which is the daily uptimes if the time range is longer than the retention of the hourly uptimes,
+// or if it starts before the oldest of the hourly uptimes kept
+func uptimeTableAndColumn(from, to time.Time) (table, column string) {
+	if to.Sub(from) > uptimeRetention+time.Hour || time.Since(from) > uptimeRetention+time.Hour {
+		return "endpoint_daily_uptimes", "day_unix_timestamp"
+	}
+	return "endpoint_uptimes", "hour_unix_timestamp"
+}
+
+// getEndpointUptime returns the uptime and the average response time of an endpoint during a time range
 func (s *Store) getEndpointUptime(tx *sql.Tx, endpointID int64, from, to time.Time) (uptime float64, avgResponseTime time.Duration, err error) {
+	table, column := uptimeTableAndColumn(from, to)
+	if column == "day_unix_timestamp" {
+		from = from.Truncate(24 * time.Hour)
+	}
 	rows, err := tx.Query(
 		`
 			SELECT SUM(total_executions), SUM(successful_executions), SUM(total_response_time)
-			FROM endpoint_uptimes
+			FROM `+table+`
 			WHERE endpoint_id = $1
-				AND hour_unix_timestamp >= $2
-				AND hour_unix_timestamp <= $3
+				AND `+column+` >= $2
+				AND `+column+` <= $3
 		`,
 		endpointID,
 		from.Unix(),
@@ -787,15 +1457,41 @@ func (s *Store) getEndpointUptime(tx *sql.Tx, endpointID int64, from, to time.Ti
 	return
 }
 
+func (s *Store) getEndpointHourlyUptimeStatistics(tx *sql.Tx, endpointID int64) (map[int64]*endpoint.HourlyUptimeStatistics, error) {
+	rows, err := tx.Query("SELECT hour_unix_timestamp, total_executions, successful_executions, total_response_time FROM endpoint_uptimes WHERE endpoint_id = $1", endpointID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	hourlyStatistics := make(map[int64]*endpoint.HourlyUptimeStatistics)
+	for rows.Next() {
+		var hourlyUnixTimestamp, totalExecutions, successfulExecutions, totalResponseTime int64
+		if err = rows.Scan(&hourlyUnixTimestamp, &totalExecutions, &successfulExecutions, &totalResponseTime); err != nil {
+			return nil, err
+		}
+		hourlyStatistics[hourlyUnixTimestamp] = &endpoint.HourlyUptimeStatistics{
+			TotalExecutions:             uint64(totalExecutions),
+			SuccessfulExecutions:        uint64(successfulExecutions),
+			TotalExecutionsResponseTime: uint64(totalResponseTime),
+		}
+	}
+	return hourlyStatistics, rows.Err()
+}
+
+// getEndpointAverageResponseTime returns the average response time of an endpoint during a time range
 func (s *Store) getEndpointAverageResponseTime(tx *sql.Tx, endpointID int64, from, to time.Time) (int, error) {
+	table, column := uptimeTableAndColumn(from, to)
+	if column == "day_unix_timestamp" {
+		from = from.Truncate(24 * time.Hour)
+	}
 	rows, err := tx.Query(
 		`
 			SELECT SUM(total_executions), SUM(total_response_time)
-			FROM endpoint_uptimes
+			FROM `+table+`
 			WHERE endpoint_id = $1
 				AND total_executions > 0
-				AND hour_unix_timestamp >= $2
-				AND hour_unix_timestamp <= $3
+				AND `+column+` >= $2
+				AND `+column+` <= $3
 		`,
 		endpointID,
 		from.Unix(),
@@ -891,6 +1587,21 @@ func (s *Store) getAgeOfOldestEndpointUptimeEntry(tx *sql.Tx, endpointID int64)
 	return time.Since(time.Unix(oldestEndpointUptimeUnixTimestamp, 0)), nil
 }
 
+func (s *Store) getOldestEndpointResultTimestamp(tx *sql.Tx, endpointID int64) (time.Time, error) {
+	var timestamp time.Time
+	err := tx.QueryRow("SELECT timestamp FROM endpoint_results WHERE endpoint_id = $1 ORDER BY endpoint_result_id LIMIT 1", endpointID).Scan(&timestamp)
+	if errors.Is(err, sql.ErrNoRows) {
+		return timestamp, errNoRowsReturned
+	}
+	return timestamp, err
+}
+
+func (s *Store) getNumberOfResultsSinceByEndpointID(tx *sql.Tx, endpointID int64, since time.Time) (int64, error) {
+	var numberOfResults int64
+	err := tx.QueryRow("SELECT COUNT(1) FROM endpoint_results WHERE endpoint_id = $1 AND timestamp >= $2", endpointID, since.UTC()).Scan(&numberOfResults)
+	return numberOfResults, err
+}
+
 func (s *Store) getLastEndpointResultSuccessValue(tx *sql.Tx, endpointID int64) (bool, error) {
 	var success bool
 	err := tx.QueryRow("SELECT success FROM endpoint_results WHERE endpoint_id = $1 ORDER BY endpoint_result_id DESC LIMIT 1", endpointID).Scan(&success)
@@ -903,8 +1614,10 @@ func (s *Store) getLastEndpointResultSuccessValue(tx *sql.Tx, endpointID int64)
 	return success, nil
 }
 
-// deleteOldEndpointEvents deletes endpoint events that are no longer needed
-func (s *Store) deleteOldEndpointEvents(tx *sql.Tx, endpointID int64) error {
+// deleteOldEndpointEvents deletes endpoint events that are no longer needed, which are all events but the last
+// numberOfEventsToKeep
+func (s *Store) deleteOldEndpointEvents(tx *sql.Tx, endpointID int64, numberOfEventsToKeep int) error {
+	defer metrics.ObserveStoreCleanUp(s.driver, "events", time.Now())
 	_, err := tx.Exec(
 		`
 			DELETE FROM endpoint_events 
@@ -918,13 +1631,54 @@ func (s *Store) deleteOldEndpointEvents(tx *sql.Tx, endpointID int64) error {
 				)
 		`,
 		endpointID,
-		common.MaximumNumberOfEvents,
+		numberOfEventsToKeep,
+	)
+	return err
+}
+
+// deleteEndpointEventsBefore deletes the endpoint events that happened before the given time, except for the most
+// recent event, since it's the current state of the endpoint
+func (s *Store) deleteEndpointEventsBefore(tx *sql.Tx, endpointID int64, before time.Time) error {
+	defer metrics.ObserveStoreCleanUp(s.driver, "events", time.Now())
+	_, err := tx.Exec(
+		`
+			DELETE FROM endpoint_events
+			WHERE endpoint_id = $1
+				AND event_timestamp < $2
+				AND endpoint_event_id <> (SELECT MAX(endpoint_event_id) FROM endpoint_events WHERE endpoint_id = $1)
+		`,
+		endpointID,
+		before.UTC(),
 	)
 	return err
 }
 
-// deleteOldEndpointResults deletes endpoint results that are no longer needed
-func (s *Store) deleteOldEndpointResults(tx *sql.Tx, endpointID int64) error {
+// archiveOldEndpointResults archives the endpoint results that are about to be deleted by deleteOldEndpointResults,
+// which are all results but the last numberOfResultsToKeep
+func (s *Store) archiveOldEndpointResults(tx *sql.Tx, ep *endpoint.Endpoint, endpointID int64, numberOfResultsToKeep int) {
+	// The results to keep are skipped by retrieving them as the first page, and the results to archive are then
+	// retrieved in pages of the same size. If no result is kept, the first page is archived too.
+	page, pageSize := 2, numberOfResultsToKeep
+	if numberOfResultsToKeep == 0 {
+		page, pageSize = 1, common.MaximumNumberOfResults
+	}
+	for ; ; page++ {
+		results, err := s.getEndpointResultsByEndpointID(tx, endpointID, page, pageSize)
+		if err != nil {
+			log.Printf("[sql.archiveOldEndpointResults] Failed to retrieve old results for endpoint with key=%s: %s", ep.Key(), err.Error())
+			return
+		}
+		s.archiver.Archive(ep.Key(), ep.Group, ep.Name, results)
+		if len(results) < pageSize {
+			return
+		}
+	}
+}
+
+// deleteOldEndpointResults deletes endpoint results that are no longer needed, which are all results but the last
+// numberOfResultsToKeep
+func (s *Store) deleteOldEndpointResults(tx *sql.Tx, endpointID int64, numberOfResultsToKeep int) error {
+	defer metrics.ObserveStoreCleanUp(s.driver, "results", time.Now())
 	_, err := tx.Exec(
 		`
 			DELETE FROM endpoint_results
@@ -938,16 +1692,53 @@ func (s *Store) deleteOldEndpointResults(tx *sql.Tx, endpointID int64) error {
 				)
 		`,
 		endpointID,
-		common.MaximumNumberOfResults,
+		numberOfResultsToKeep,
+	)
+	return err
+}
+
+// deleteOldEndpointFailureCaptures deletes the captures of the checks that failed of an endpoint that are no longer
+// needed, which are all captures but the last numberOfCapturesToKeep
+func (s *Store) deleteOldEndpointFailureCaptures(tx *sql.Tx, endpointID int64, numberOfCapturesToKeep int) error {
+	defer metrics.ObserveStoreCleanUp(s.driver, "failure_captures", time.Now())
+	_, err := tx.Exec(
+		`
+			DELETE FROM endpoint_failure_captures
+			WHERE endpoint_id = $1
+				AND endpoint_failure_capture_id NOT IN (
+					SELECT endpoint_failure_capture_id
+					FROM endpoint_failure_captures
+					WHERE endpoint_id = $1
+					ORDER BY endpoint_failure_capture_id DESC
+					LIMIT $2
+				)
+		`,
+		endpointID,
+		numberOfCapturesToKeep,
 	)
 	return err
 }
 
 func (s *Store) deleteOldUptimeEntries(tx *sql.Tx, endpointID int64, maxAge time.Time) error {
+	defer metrics.ObserveStoreCleanUp(s.driver, "uptimes", time.Now())
 	_, err := tx.Exec("DELETE FROM endpoint_uptimes WHERE endpoint_id = $1 AND hour_unix_timestamp < $2", endpointID, maxAge.Unix())
 	return err
 }
 
+func (s *Store) deleteOldDailyUptimeEntries(tx *sql.Tx, endpointID int64, maxAge time.Time) error {
+	defer metrics.ObserveStoreCleanUp(s.driver, "daily_uptimes", time.Now())
+	_, err := tx.Exec("DELETE FROM endpoint_daily_uptimes WHERE endpoint_id = $1 AND day_unix_timestamp < $2", endpointID, maxAge.Unix())
+	return err
+}
+
+// observe publishes the metrics of an operation of the store that started at start and that returned err
+func (s *Store) observe(operation string, start time.Time, err error) {
+	metrics.ObserveStoreOperation(s.driver, operation, start, err == nil)
+	if err != nil && s.driver == "sqlite" && isSQLiteBusyError(err) {
+		metrics.IncrementStoreSQLiteBusyErrors(operation)
+	}
+}
+
 func generateCacheKey(endpointKey string, p *paging.EndpointStatusParams) string {
 	return fmt.Sprintf("%s-%d-%d-%d-%d", endpointKey, p.EventsPage, p.EventsPageSize, p.ResultsPage, p.ResultsPageSize)
 }
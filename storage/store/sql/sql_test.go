@@ -1,14 +1,27 @@
 package sql
 
 import (
+	"bytes"
+	"context"
 	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/endpoint/capture"
+	"github.com/TwiN/gatus/v5/storage/archive"
 	"github.com/TwiN/gatus/v5/storage/store/common"
 	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+	"github.com/ncruces/go-sqlite3"
 )
 
 var (
@@ -96,6 +109,32 @@ func TestNewStore(t *testing.T) {
 	}
 }
 
+func TestStore_Ping(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_Ping.db", false)
+	if err := store.Ping(context.Background()); err != nil {
+		t.Error("expected no error, got", err.Error())
+	}
+	store.Close()
+	if err := store.Ping(context.Background()); err == nil {
+		t.Error("expected an error, because the store is closed")
+	}
+}
+
+func TestNewPartitionedStore(t *testing.T) {
+	if _, err := NewPartitionedStore("", false, PartitioningNative, 0, 0); !errors.Is(err, ErrPathNotSpecified) {
+		t.Error("expected error due to blank path parameter")
+	}
+	if _, err := NewPartitionedStore("postgres://localhost/gatus", false, "hash", 0, 0); !errors.Is(err, ErrInvalidPartitioning) {
+		t.Error("expected error due to invalid partitioning parameter")
+	}
+	if _, err := NewPartitionedStore("postgres://localhost/gatus", false, PartitioningNative, 0, time.Minute); !errors.Is(err, ErrInvalidPartitionInterval) {
+		t.Error("expected error due to partition interval shorter than an hour")
+	}
+	if _, err := NewPartitionedStore("postgres://localhost/gatus", false, PartitioningTimescaleDB, 24*time.Hour, 48*time.Hour); !errors.Is(err, ErrInvalidPartitionInterval) {
+		t.Error("expected error due to partition interval longer than the retention")
+	}
+}
+
 func TestStore_InsertCleansUpOldUptimeEntriesProperly(t *testing.T) {
 	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_InsertCleansUpOldUptimeEntriesProperly.db", false)
 	defer store.Close()
@@ -170,6 +209,160 @@ func TestStore_InsertCleansUpEventsAndResultsProperly(t *testing.T) {
 	store.Clear()
 }
 
+func TestNewEncryptedStore(t *testing.T) {
+	if _, err := NewEncryptedStore("", "key", false); !errors.Is(err, ErrPathNotSpecified) {
+		t.Error("expected error", ErrPathNotSpecified, "got", err)
+	}
+	if _, err := NewEncryptedStore("TestNewEncryptedStore.db", "", false); !errors.Is(err, ErrEncryptionKeyNotSpecified) {
+		t.Error("expected error", ErrEncryptionKeyNotSpecified, "got", err)
+	}
+	path := t.TempDir() + "/TestNewEncryptedStore.db"
+	store, err := NewEncryptedStore(path, "secret-key", false)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	_ = store.Insert(&testEndpoint, &testSuccessfulResult)
+	store.Close()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if bytes.Contains(content, []byte("SQLite format 3")) || bytes.Contains(content, []byte(testSuccessfulResult.Hostname)) {
+		t.Error("expected database file to be encrypted")
+	}
+	// The database can only be opened with the same key
+	if store, err = NewEncryptedStore(path, "wrong-key", false); !errors.Is(err, ErrInvalidEncryptionKey) {
+		if err == nil {
+			store.Close()
+		}
+		t.Error("expected error", ErrInvalidEncryptionKey, "opening the database with the wrong key, got", err)
+	}
+	if store, err = NewStore("sqlite", path, false); err == nil {
+		store.Close()
+		t.Error("expected error opening the database without a key")
+	}
+	store, err = NewEncryptedStore(path, "secret-key", false)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	defer store.Close()
+	ss, _ := store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, common.MaximumNumberOfResults))
+	if ss == nil || len(ss.Results) != 1 || ss.Results[0].Hostname != testSuccessfulResult.Hostname {
+		t.Errorf("expected the result to be persisted, got %+v", ss)
+	}
+	for pragma, expected := range map[string]string{"foreign_keys": "1", "journal_mode": "wal", "synchronous": "1", "temp_store": "2", "busy_timeout": "10000"} {
+		var actual string
+		if err := store.db.QueryRow("PRAGMA " + pragma).Scan(&actual); err != nil || actual != expected {
+			t.Errorf("expected PRAGMA %s to be %s, got %s (error: %v)", pragma, expected, actual, err)
+		}
+	}
+}
+
+func TestNewEncryptedStoreWithUnencryptedDatabase(t *testing.T) {
+	path := t.TempDir() + "/TestNewEncryptedStoreWithUnencryptedDatabase.db"
+	store, err := NewStore("sqlite", path, false)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	_ = store.Insert(&testEndpoint, &testSuccessfulResult)
+	store.Close()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if store, err = NewEncryptedStore(path, "secret-key", false); !errors.Is(err, ErrInvalidEncryptionKey) {
+		if err == nil {
+			store.Close()
+		}
+		t.Fatal("expected error", ErrInvalidEncryptionKey, "got", err)
+	}
+	// The database must be left untouched, so that it can still be opened without a key
+	if contentAfter, _ := os.ReadFile(path); !bytes.Equal(content, contentAfter) {
+		t.Error("expected the database file not to be modified")
+	}
+	if store, err = NewStore("sqlite", path, false); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	defer store.Close()
+	ss, _ := store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, common.MaximumNumberOfResults))
+	if ss == nil || len(ss.Results) != 1 || ss.Results[0].Hostname != testSuccessfulResult.Hostname {
+		t.Errorf("expected the result to be kept, got %+v", ss)
+	}
+}
+
+func TestStore_InsertWithArchiver(t *testing.T) {
+	var archivedResults atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		archivedResults.Add(int64(strings.Count(string(body), "\n")))
+	}))
+	defer server.Close()
+	cfg := &archive.Config{Bucket: "archive", Endpoint: server.URL, PathStyle: true, AccessKeyID: "id", SecretAccessKey: "secret"}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	archiver, err := archive.NewArchiver(cfg)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_InsertWithArchiver.db", false)
+	store.SetArchiver(archiver)
+	for i := 0; i < resultsCleanUpThreshold+1; i++ {
+		store.Insert(&testEndpoint, &testSuccessfulResult)
+	}
+	store.Close()
+	if archivedResults := int(archivedResults.Load()); archivedResults != resultsCleanUpThreshold+1-common.MaximumNumberOfResults {
+		t.Errorf("expected %d results to be archived, got %d", resultsCleanUpThreshold+1-common.MaximumNumberOfResults, archivedResults)
+	}
+}
+
+func TestStore_InsertWithRetentionPolicy(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_InsertWithRetentionPolicy.db", false)
+	defer store.Close()
+	store.SetRetentionPolicy(func(group, name string) common.RetentionPolicy {
+		if group != testEndpoint.Group || name != testEndpoint.Name {
+			return common.DefaultRetentionPolicy
+		}
+		return common.RetentionPolicy{MaximumNumberOfResults: 5, MaximumNumberOfEvents: 2, MaximumAge: time.Hour}
+	})
+	// Insert results that alternate between success and failure every minute, starting 20 minutes ago
+	now := time.Now()
+	for i := 0; i < 20; i++ {
+		result := testSuccessfulResult
+		result.Success = i%2 == 0
+		result.Timestamp = now.Add(time.Duration(i-20) * time.Minute)
+		store.Insert(&testEndpoint, &result)
+	}
+	ss, _ := store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, common.MaximumNumberOfResults).WithEvents(1, common.MaximumNumberOfEvents))
+	if len(ss.Results) > 5+cleanUpMargin {
+		t.Errorf("expected at most %d results, got %d", 5+cleanUpMargin, len(ss.Results))
+	}
+	if len(ss.Events) > 2+cleanUpMargin {
+		t.Errorf("expected at most %d events, got %d", 2+cleanUpMargin, len(ss.Events))
+	}
+	// Insert a result 2 hours later, which should clean up every result older than the maximum age
+	result := testSuccessfulResult
+	result.Timestamp = now.Add(2 * time.Hour)
+	store.Insert(&testEndpoint, &result)
+	ss, _ = store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, common.MaximumNumberOfResults).WithEvents(1, common.MaximumNumberOfEvents))
+	if len(ss.Results) != 1 {
+		t.Errorf("expected 1 result, got %d", len(ss.Results))
+	}
+	if len(ss.Events) != 1 {
+		t.Errorf("expected only the last event to be kept, got %d", len(ss.Events))
+	}
+	// Endpoints that don't have a retention policy use the default one
+	otherEndpoint := testEndpoint
+	otherEndpoint.Name = "other"
+	for i := 0; i < 20; i++ {
+		store.Insert(&otherEndpoint, &testSuccessfulResult)
+	}
+	ss, _ = store.GetEndpointStatusByKey(otherEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, common.MaximumNumberOfResults))
+	if len(ss.Results) != 20 {
+		t.Errorf("expected 20 results, got %d", len(ss.Results))
+	}
+}
+
 func TestStore_InsertWithCaching(t *testing.T) {
 	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_InsertWithCaching.db", true)
 	defer store.Close()
@@ -355,7 +548,7 @@ func TestStore_InvalidTransaction(t *testing.T) {
 	if err := store.insertEndpointResult(tx, 1, &testSuccessfulResult); err == nil {
 		t.Error("should've returned an error, because the transaction was already committed")
 	}
-	if err := store.insertConditionResults(tx, 1, testSuccessfulResult.ConditionResults); err == nil {
+	if err := store.insertConditionResults(tx, 1, testSuccessfulResult.Timestamp, testSuccessfulResult.ConditionResults); err == nil {
 		t.Error("should've returned an error, because the transaction was already committed")
 	}
 	if err := store.updateEndpointUptime(tx, 1, &testSuccessfulResult); err == nil {
@@ -373,10 +566,10 @@ func TestStore_InvalidTransaction(t *testing.T) {
 	if _, err := store.getEndpointResultsByEndpointID(tx, 1, 1, 50); err == nil {
 		t.Error("should've returned an error, because the transaction was already committed")
 	}
-	if err := store.deleteOldEndpointEvents(tx, 1); err == nil {
+	if err := store.deleteOldEndpointEvents(tx, 1, common.MaximumNumberOfEvents); err == nil {
 		t.Error("should've returned an error, because the transaction was already committed")
 	}
-	if err := store.deleteOldEndpointResults(tx, 1); err == nil {
+	if err := store.deleteOldEndpointResults(tx, 1, common.MaximumNumberOfResults); err == nil {
 		t.Error("should've returned an error, because the transaction was already committed")
 	}
 	if _, _, err := store.getEndpointUptime(tx, 1, time.Now(), time.Now()); err == nil {
@@ -728,3 +921,222 @@ func TestStore_DeleteAllTriggeredAlertsNotInChecksumsByEndpoint(t *testing.T) {
 		t.Error("expected alert3 to exist for ep2")
 	}
 }
+
+func TestParsePartitionBound(t *testing.T) {
+	p, err := parsePartitionBound("endpoint_results_p20240102000000", "FOR VALUES FROM ('2024-01-02 00:00:00') TO ('2024-01-03 00:00:00')")
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if !p.start.Equal(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)) || !p.end.Equal(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected partition from 2024-01-02 to 2024-01-03, got %s to %s", p.start, p.end)
+	}
+	if _, err = parsePartitionBound("endpoint_results_default", "DEFAULT"); err == nil {
+		t.Error("expected error due to unsupported bound")
+	}
+}
+
+func TestNewPartition(t *testing.T) {
+	day := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	scenarios := []struct {
+		name          string
+		partitions    []*partition
+		timestamp     time.Time
+		interval      time.Duration
+		expectedName  string
+		expectedStart time.Time
+		expectedEnd   time.Time
+	}{
+		{
+			name:          "aligned-on-interval",
+			timestamp:     day.Add(13 * time.Hour),
+			interval:      24 * time.Hour,
+			expectedName:  "endpoint_results_p20240102000000",
+			expectedStart: day,
+			expectedEnd:   day.Add(24 * time.Hour),
+		},
+		{
+			name:          "shortened-after-existing-partition",
+			partitions:    []*partition{{start: day.Add(-24 * time.Hour), end: day.Add(6 * time.Hour)}},
+			timestamp:     day.Add(13 * time.Hour),
+			interval:      24 * time.Hour,
+			expectedName:  "endpoint_results_p20240102060000",
+			expectedStart: day.Add(6 * time.Hour),
+			expectedEnd:   day.Add(24 * time.Hour),
+		},
+		{
+			name:          "shortened-before-existing-partition",
+			partitions:    []*partition{{start: day.Add(18 * time.Hour), end: day.Add(42 * time.Hour)}},
+			timestamp:     day.Add(13 * time.Hour),
+			interval:      24 * time.Hour,
+			expectedName:  "endpoint_results_p20240102000000",
+			expectedStart: day,
+			expectedEnd:   day.Add(18 * time.Hour),
+		},
+		{
+			name:          "hourly",
+			partitions:    []*partition{{start: day.Add(12 * time.Hour), end: day.Add(13 * time.Hour)}},
+			timestamp:     day.Add(13*time.Hour + 30*time.Minute),
+			interval:      time.Hour,
+			expectedName:  "endpoint_results_p20240102130000",
+			expectedStart: day.Add(13 * time.Hour),
+			expectedEnd:   day.Add(14 * time.Hour),
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			p := newPartition("endpoint_results", scenario.partitions, scenario.timestamp, scenario.interval)
+			if p.name != scenario.expectedName {
+				t.Errorf("expected name %s, got %s", scenario.expectedName, p.name)
+			}
+			if !p.start.Equal(scenario.expectedStart) || !p.end.Equal(scenario.expectedEnd) {
+				t.Errorf("expected partition from %s to %s, got %s to %s", scenario.expectedStart, scenario.expectedEnd, p.start, p.end)
+			}
+			if !p.contains(scenario.timestamp) {
+				t.Error("expected partition to contain the timestamp")
+			}
+			if containingPartition(scenario.partitions, scenario.timestamp) != nil {
+				t.Error("expected no existing partition to contain the timestamp")
+			}
+		})
+	}
+}
+
+func TestStore_SetConnectionPool(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_SetConnectionPool.db", false)
+	defer store.Close()
+	store.SetConnectionPool(10, 5, time.Minute)
+	if maxOpenConnections := store.db.Stats().MaxOpenConnections; maxOpenConnections != 1 {
+		t.Errorf("expected sqlite to keep a single open connection, got %d", maxOpenConnections)
+	}
+	store.Insert(&testEndpoint, &testSuccessfulResult)
+	if statuses, err := store.GetAllEndpointStatuses(paging.NewEndpointStatusParams()); err != nil || len(statuses) != 1 {
+		t.Errorf("expected store to still work, got %d statuses and error %v", len(statuses), err)
+	}
+}
+
+func TestStore_InsertWithWriteBuffer(t *testing.T) {
+	path := t.TempDir() + "/TestStore_InsertWithWriteBuffer.db"
+	store, _ := NewStore("sqlite", path, false)
+	store.SetWriteBuffer(3, time.Hour)
+	store.Insert(&testEndpoint, &testSuccessfulResult)
+	store.Insert(&testEndpoint, &testUnsuccessfulResult)
+	if _, err := store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams()); !errors.Is(err, common.ErrEndpointNotFound) {
+		t.Fatalf("expected results to be buffered until the batch is full, got error %v", err)
+	}
+	store.Insert(&testEndpoint, &testSuccessfulResult)
+	status, err := store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, 10).WithEvents(1, 10))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(status.Results) != 3 {
+		t.Errorf("expected 3 results to be inserted once the batch is full, got %d", len(status.Results))
+	}
+	if len(status.Events) != 4 {
+		t.Errorf("expected 4 events (start, healthy, unhealthy, healthy), got %d", len(status.Events))
+	}
+	store.Insert(&testEndpoint, &testUnsuccessfulResult)
+	if err = store.Save(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if status, _ = store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, 10)); len(status.Results) != 4 {
+		t.Errorf("expected 4 results once saved, got %d", len(status.Results))
+	}
+	// Closing the store inserts the buffered results
+	store.Insert(&testEndpoint, &testSuccessfulResult)
+	store.Close()
+	store, _ = NewStore("sqlite", path, false)
+	defer store.Close()
+	if status, _ = store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, 10)); len(status.Results) != 5 {
+		t.Errorf("expected 5 results once closed, got %d", len(status.Results))
+	}
+}
+
+func TestStore_ClearWithWriteBuffer(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_ClearWithWriteBuffer.db", false)
+	defer store.Close()
+	store.SetWriteBuffer(10, time.Hour)
+	store.Insert(&testEndpoint, &testSuccessfulResult)
+	store.Clear()
+	_ = store.Save()
+	if statuses, _ := store.GetAllEndpointStatuses(paging.NewEndpointStatusParams()); len(statuses) != 0 {
+		t.Errorf("expected buffered results to be cleared, got %d statuses", len(statuses))
+	}
+}
+
+func TestStore_InsertWithFailureCaptures(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_InsertWithFailureCaptures.db", false)
+	defer store.Close()
+	now := time.Now()
+	ep := testEndpoint
+	ep.FailureCaptureConfig = &capture.Config{MaximumBodySize: 16, MaximumCaptures: 2}
+	for i := 0; i < 3; i++ {
+		result := testUnsuccessfulResult
+		result.Timestamp = now.Add(time.Duration(i) * time.Minute)
+		result.FailureCapture = &endpoint.FailureCapture{
+			Timestamp:  result.Timestamp,
+			HTTPStatus: 500,
+			Headers:    map[string][]string{"Content-Type": {"text/plain"}},
+			Body:       "failure #" + strconv.Itoa(i),
+			Truncated:  true,
+			Errors:     []string{"error-1", "error-2"},
+		}
+		if err := store.Insert(&ep, &result); err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+	}
+	captures, err := store.GetFailureCapturesByKey(ep.Key())
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(captures) != 2 {
+		t.Fatalf("expected %d captures, got %d", 2, len(captures))
+	}
+	if captures[0].Body != "failure #2" || captures[1].Body != "failure #1" {
+		t.Errorf("expected the most recent captures to be kept, from newest to oldest, got %s and %s", captures[0].Body, captures[1].Body)
+	}
+	if capture := captures[0]; capture.HTTPStatus != 500 || !capture.Truncated || len(capture.Errors) != 2 || capture.Headers["Content-Type"][0] != "text/plain" || capture.Timestamp.Unix() != now.Add(2*time.Minute).Unix() {
+		t.Errorf("expected capture to be persisted as is, got %+v", capture)
+	}
+	if _, err := store.GetFailureCapturesByKey("nope"); !errors.Is(err, common.ErrEndpointNotFound) {
+		t.Errorf("expected error %v, got %v", common.ErrEndpointNotFound, err)
+	}
+}
+
+func TestStore_InsertWithMetadata(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_InsertWithMetadata.db", false)
+	defer store.Close()
+	withMetadata := testSuccessfulResult
+	withMetadata.Metadata = map[string]string{"version": "1.2.3", "region": "eu-west-1"}
+	withoutMetadata := testSuccessfulResult
+	withoutMetadata.Timestamp = withMetadata.Timestamp.Add(time.Minute)
+	for _, result := range []*endpoint.Result{&withMetadata, &withoutMetadata} {
+		if err := store.Insert(&testEndpoint, result); err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+	}
+	endpointStatus, err := store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, 10))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(endpointStatus.Results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(endpointStatus.Results))
+	}
+	if metadata := endpointStatus.Results[0].Metadata; len(metadata) != 2 || metadata["version"] != "1.2.3" || metadata["region"] != "eu-west-1" {
+		t.Errorf("expected the metadata to be persisted as is, got %v", metadata)
+	}
+	if metadata := endpointStatus.Results[1].Metadata; metadata != nil {
+		t.Errorf("expected no metadata, got %v", metadata)
+	}
+}
+
+func TestIsSQLiteBusyError(t *testing.T) {
+	if !isSQLiteBusyError(fmt.Errorf("error inserting result: %w", sqlite3.BUSY)) {
+		t.Error("expected wrapped SQLITE_BUSY to be a busy error")
+	}
+	if isSQLiteBusyError(sqlite3.CONSTRAINT) {
+		t.Error("expected SQLITE_CONSTRAINT not to be a busy error")
+	}
+	if isSQLiteBusyError(errors.New("connection refused")) {
+		t.Error("expected an error that didn't come from SQLite not to be a busy error")
+	}
+}
@@ -0,0 +1,168 @@
+package sql
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/gatus/v5/subscription"
+)
+
+// InsertSubscription adds a subscription that has yet to be confirmed to the store and sets its ID. Subscriptions
+// that weren't confirmed within subscription.ConfirmationTimeout are deleted.
+func (s *Store) InsertSubscription(sub *subscription.Subscription) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err = tx.Exec("DELETE FROM subscriptions WHERE confirmed = $1 AND timestamp < $2", false, sub.Timestamp.Add(-subscription.ConfirmationTimeout).UTC()); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	err = tx.QueryRow(
+		"INSERT INTO subscriptions (type, target, confirmed, confirmation_token, unsubscribe_token, timestamp) VALUES ($1, $2, $3, $4, $5, $6) RETURNING subscription_id",
+		sub.Type,
+		sub.Target,
+		sub.Confirmed,
+		sub.ConfirmationToken,
+		sub.UnsubscribeToken,
+		sub.Timestamp.UTC(),
+	).Scan(&sub.ID)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	for _, group := range sub.Groups {
+		if _, err = tx.Exec("INSERT INTO subscription_groups (subscription_id, group_name) VALUES ($1, $2)", sub.ID, group); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+	}
+	return err
+}
+
+// ConfirmSubscription confirms the subscription whose confirmation token is passed as parameter, which replaces the
+// subscriptions of the same type and target that were confirmed before
+func (s *Store) ConfirmSubscription(confirmationToken string, now time.Time) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	var (
+		subscriptionID   int64
+		subscriptionType subscription.Type
+		target           string
+		confirmed        bool
+		timestamp        time.Time
+	)
+	err = tx.QueryRow(
+		"SELECT subscription_id, type, target, confirmed, timestamp FROM subscriptions WHERE confirmation_token = $1",
+		confirmationToken,
+	).Scan(&subscriptionID, &subscriptionType, &target, &confirmed, &timestamp)
+	if err != nil {
+		_ = tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return common.ErrSubscriptionNotFound
+		}
+		return err
+	}
+	if confirmed {
+		_ = tx.Rollback()
+		return nil
+	}
+	if now.Sub(timestamp) > subscription.ConfirmationTimeout {
+		_ = tx.Rollback()
+		return common.ErrSubscriptionNotFound
+	}
+	if _, err = tx.Exec("DELETE FROM subscriptions WHERE confirmed = $1 AND type = $2 AND target = $3", true, subscriptionType, target); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if _, err = tx.Exec("UPDATE subscriptions SET confirmed = $1 WHERE subscription_id = $2", true, subscriptionID); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+	}
+	return err
+}
+
+// DeleteSubscription deletes the subscription whose unsubscribe token is passed as parameter
+func (s *Store) DeleteSubscription(unsubscribeToken string) error {
+	result, err := s.db.Exec("DELETE FROM subscriptions WHERE unsubscribe_token = $1", unsubscribeToken)
+	if err != nil {
+		return err
+	}
+	if numberOfRowsDeleted, _ := result.RowsAffected(); numberOfRowsDeleted == 0 {
+		return common.ErrSubscriptionNotFound
+	}
+	return nil
+}
+
+// GetConfirmedSubscriptions returns every subscription that was confirmed, from oldest to newest
+func (s *Store) GetConfirmedSubscriptions() ([]*subscription.Subscription, error) {
+	tx, err := s.readDB().Begin()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(
+		`
+			SELECT subscription_id, type, target, confirmation_token, unsubscribe_token, timestamp
+			FROM subscriptions
+			WHERE confirmed = $1
+			ORDER BY subscription_id
+		`,
+		true,
+	)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	subscriptions := make([]*subscription.Subscription, 0)
+	for rows.Next() {
+		sub := &subscription.Subscription{Confirmed: true}
+		if err = rows.Scan(&sub.ID, &sub.Type, &sub.Target, &sub.ConfirmationToken, &sub.UnsubscribeToken, &sub.Timestamp); err != nil {
+			_ = rows.Close()
+			_ = tx.Rollback()
+			return nil, err
+		}
+		subscriptions = append(subscriptions, sub)
+	}
+	_ = rows.Close()
+	if err = rows.Err(); err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	for _, sub := range subscriptions {
+		if sub.Groups, err = s.getSubscriptionGroups(tx, sub.ID); err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+	}
+	return subscriptions, nil
+}
+
+// getSubscriptionGroups returns the groups a subscription is for
+func (s *Store) getSubscriptionGroups(tx *sql.Tx, subscriptionID int64) (groups []string, err error) {
+	rows, err := tx.Query("SELECT group_name FROM subscription_groups WHERE subscription_id = $1 ORDER BY group_name", subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var group string
+		if err = rows.Scan(&group); err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+	return groups, rows.Err()
+}
@@ -0,0 +1,114 @@
+package sql
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/gatus/v5/subscription"
+)
+
+func TestStore_InsertSubscription(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_InsertSubscription.db", false)
+	defer store.Close()
+	expired, _ := subscription.New(subscription.TypeEmail, "john.doe@example.org", nil)
+	expired.Timestamp = time.Now().Add(-subscription.ConfirmationTimeout - time.Minute)
+	sub, _ := subscription.New(subscription.TypeWebhook, "https://example.org/webhook", []string{"frontend", "core"})
+	for _, s := range []*subscription.Subscription{expired, sub} {
+		if err := store.InsertSubscription(s); err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+	}
+	if expired.ID == 0 || sub.ID == 0 {
+		t.Errorf("expected the IDs to be set, got %d and %d", expired.ID, sub.ID)
+	}
+	if err := store.ConfirmSubscription(expired.ConfirmationToken, time.Now()); !errors.Is(err, common.ErrSubscriptionNotFound) {
+		t.Errorf("expected the subscription that wasn't confirmed in time to be deleted, got %v", err)
+	}
+	if subscriptions, _ := store.GetConfirmedSubscriptions(); len(subscriptions) != 0 {
+		t.Errorf("expected subscriptions that weren't confirmed not to be returned, got %d", len(subscriptions))
+	}
+	if err := store.ConfirmSubscription(sub.ConfirmationToken, time.Now()); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	subscriptions, err := store.GetConfirmedSubscriptions()
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(subscriptions) != 1 {
+		t.Fatalf("expected 1 confirmed subscription, got %d", len(subscriptions))
+	}
+	if s := subscriptions[0]; s.ID != sub.ID || s.Type != subscription.TypeWebhook || s.Target != sub.Target || !s.Confirmed || s.UnsubscribeToken != sub.UnsubscribeToken || len(s.Groups) != 2 || s.Groups[0] != "core" || s.Groups[1] != "frontend" {
+		t.Errorf("expected subscription to be persisted as is, got %+v", s)
+	}
+	store.Clear()
+	if subscriptions, _ = store.GetConfirmedSubscriptions(); len(subscriptions) != 0 {
+		t.Errorf("expected subscriptions to be cleared, got %d", len(subscriptions))
+	}
+}
+
+func TestStore_ConfirmSubscription(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_ConfirmSubscription.db", false)
+	defer store.Close()
+	previous, _ := subscription.New(subscription.TypeEmail, "john.doe@example.org", []string{"core"})
+	other, _ := subscription.New(subscription.TypeEmail, "jane.doe@example.org", nil)
+	replacement, _ := subscription.New(subscription.TypeEmail, "john.doe@example.org", []string{"frontend"})
+	for _, sub := range []*subscription.Subscription{previous, other, replacement} {
+		if err := store.InsertSubscription(sub); err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+	}
+	for _, sub := range []*subscription.Subscription{previous, other} {
+		if err := store.ConfirmSubscription(sub.ConfirmationToken, time.Now()); err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+	}
+	if subscriptions, _ := store.GetConfirmedSubscriptions(); len(subscriptions) != 2 {
+		t.Errorf("expected 2 confirmed subscriptions, got %d", len(subscriptions))
+	}
+	// Confirming the new subscription of the same address replaces the one that was confirmed before
+	if err := store.ConfirmSubscription(replacement.ConfirmationToken, time.Now()); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	subscriptions, _ := store.GetConfirmedSubscriptions()
+	if len(subscriptions) != 2 || subscriptions[0].ID != other.ID || subscriptions[1].ID != replacement.ID || subscriptions[1].Groups[0] != "frontend" {
+		t.Errorf("expected the previous subscription to be replaced, got %+v", subscriptions)
+	}
+	// Confirming a subscription that was already confirmed does nothing
+	if err := store.ConfirmSubscription(replacement.ConfirmationToken, time.Now().Add(2*subscription.ConfirmationTimeout)); err != nil {
+		t.Error("expected no error, got", err.Error())
+	}
+	if err := store.ConfirmSubscription("nope", time.Now()); !errors.Is(err, common.ErrSubscriptionNotFound) {
+		t.Errorf("expected %v, got %v", common.ErrSubscriptionNotFound, err)
+	}
+}
+
+func TestStore_DeleteSubscription(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_DeleteSubscription.db", false)
+	defer store.Close()
+	sub, _ := subscription.New(subscription.TypeWebhook, "https://example.org/webhook", []string{"core"})
+	if err := store.InsertSubscription(sub); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if err := store.ConfirmSubscription(sub.ConfirmationToken, time.Now()); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if err := store.DeleteSubscription(sub.ConfirmationToken); !errors.Is(err, common.ErrSubscriptionNotFound) {
+		t.Errorf("expected the confirmation token not to unsubscribe, got %v", err)
+	}
+	if err := store.DeleteSubscription(sub.UnsubscribeToken); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if subscriptions, _ := store.GetConfirmedSubscriptions(); len(subscriptions) != 0 {
+		t.Errorf("expected the subscription to be deleted, got %d", len(subscriptions))
+	}
+	var numberOfGroups int
+	_ = store.db.QueryRow("SELECT COUNT(1) FROM subscription_groups").Scan(&numberOfGroups)
+	if numberOfGroups != 0 {
+		t.Errorf("expected the groups of the subscription to be deleted in cascade, got %d", numberOfGroups)
+	}
+	if err := store.DeleteSubscription(sub.UnsubscribeToken); !errors.Is(err, common.ErrSubscriptionNotFound) {
+		t.Errorf("expected %v, got %v", common.ErrSubscriptionNotFound, err)
+	}
+}
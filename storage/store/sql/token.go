@@ -0,0 +1,164 @@
+package sql
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/gatus/v5/token"
+)
+
+// InsertToken adds an API token to the store and sets its ID.
+//
+// Tokens are never deleted, even after they expired or were revoked, so that the audit log can still be related to
+// them.
+func (s *Store) InsertToken(t *token.Token) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	err = tx.QueryRow(
+		"INSERT INTO tokens (name, hash, timestamp, expires_at) VALUES ($1, $2, $3, $4) RETURNING token_id",
+		t.Name,
+		t.Hash,
+		t.Timestamp.UTC(),
+		toNullTime(t.ExpiresAt),
+	).Scan(&t.ID)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	for _, scope := range t.Scopes {
+		if _, err = tx.Exec("INSERT INTO token_scopes (token_id, scope) VALUES ($1, $2)", t.ID, scope); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+	}
+	return err
+}
+
+// GetTokenByHash returns the API token whose hash is passed as parameter, whether it's active or not
+func (s *Store) GetTokenByHash(hash string) (*token.Token, error) {
+	tx, err := s.readDB().Begin()
+	if err != nil {
+		return nil, err
+	}
+	t := &token.Token{Hash: hash}
+	var expiresAt, revokedAt sql.NullTime
+	err = tx.QueryRow(
+		"SELECT token_id, name, timestamp, expires_at, revoked_at FROM tokens WHERE hash = $1",
+		hash,
+	).Scan(&t.ID, &t.Name, &t.Timestamp, &expiresAt, &revokedAt)
+	if err != nil {
+		_ = tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, common.ErrTokenNotFound
+		}
+		return nil, err
+	}
+	t.ExpiresAt, t.RevokedAt = fromNullTime(expiresAt), fromNullTime(revokedAt)
+	if t.Scopes, err = s.getTokenScopes(tx, t.ID); err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+	}
+	return t, nil
+}
+
+// GetTokens returns every API token, whether it's active or not, from newest to oldest
+func (s *Store) GetTokens() ([]*token.Token, error) {
+	tx, err := s.readDB().Begin()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query("SELECT token_id, name, hash, timestamp, expires_at, revoked_at FROM tokens ORDER BY token_id DESC")
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	tokens := make([]*token.Token, 0)
+	for rows.Next() {
+		t := &token.Token{}
+		var expiresAt, revokedAt sql.NullTime
+		if err = rows.Scan(&t.ID, &t.Name, &t.Hash, &t.Timestamp, &expiresAt, &revokedAt); err != nil {
+			_ = rows.Close()
+			_ = tx.Rollback()
+			return nil, err
+		}
+		t.ExpiresAt, t.RevokedAt = fromNullTime(expiresAt), fromNullTime(revokedAt)
+		tokens = append(tokens, t)
+	}
+	_ = rows.Close()
+	if err = rows.Err(); err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	for _, t := range tokens {
+		if t.Scopes, err = s.getTokenScopes(tx, t.ID); err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+	}
+	return tokens, nil
+}
+
+// RevokeToken revokes the API token with the ID passed as parameter at the time passed as parameter, unless it was
+// already revoked
+func (s *Store) RevokeToken(id int64, at time.Time) error {
+	result, err := s.db.Exec("UPDATE tokens SET revoked_at = $1 WHERE token_id = $2 AND revoked_at IS NULL", at.UTC(), id)
+	if err != nil {
+		return err
+	}
+	if numberOfRowsUpdated, _ := result.RowsAffected(); numberOfRowsUpdated > 0 {
+		return nil
+	}
+	// Nothing was updated, either because the token was already revoked, or because it doesn't exist
+	var exists bool
+	if err = s.db.QueryRow("SELECT EXISTS (SELECT 1 FROM tokens WHERE token_id = $1)", id).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return common.ErrTokenNotFound
+	}
+	return nil
+}
+
+// getTokenScopes returns the scopes of an API token
+func (s *Store) getTokenScopes(tx *sql.Tx, tokenID int64) (scopes []token.Scope, err error) {
+	rows, err := tx.Query("SELECT scope FROM token_scopes WHERE token_id = $1 ORDER BY scope", tokenID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var scope token.Scope
+		if err = rows.Scan(&scope); err != nil {
+			return nil, err
+		}
+		scopes = append(scopes, scope)
+	}
+	return scopes, rows.Err()
+}
+
+func toNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t.UTC(), Valid: true}
+}
+
+func fromNullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
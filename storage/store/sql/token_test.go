@@ -0,0 +1,84 @@
+package sql
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/gatus/v5/token"
+)
+
+func TestStore_InsertToken(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_InsertToken.db", false)
+	defer store.Close()
+	now := time.Now().Truncate(time.Second)
+	later := now.Add(time.Hour)
+	first := &token.Token{Name: "ci", Scopes: []token.Scope{token.ScopeReadStatuses}, Hash: token.Hash("gatus_first"), Timestamp: now}
+	second := &token.Token{Name: "agent", Scopes: []token.Scope{token.ScopeReadStatuses, token.ScopePushExternal}, Hash: token.Hash("gatus_second"), Timestamp: now, ExpiresAt: &later}
+	for _, tk := range []*token.Token{first, second} {
+		if err := store.InsertToken(tk); err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+	}
+	if first.ID == 0 || second.ID <= first.ID {
+		t.Errorf("expected the IDs to be set in increasing order, got %d and %d", first.ID, second.ID)
+	}
+	tokens, err := store.GetTokens()
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(tokens) != 2 || tokens[0].ID != second.ID || tokens[1].ID != first.ID {
+		t.Fatalf("expected both tokens from newest to oldest, got %+v", tokens)
+	}
+	if tk := tokens[0]; tk.Name != "agent" || tk.Hash != second.Hash || len(tk.Scopes) != 2 || tk.Scopes[0] != token.ScopePushExternal || !tk.Timestamp.Equal(now) || tk.ExpiresAt == nil || !tk.ExpiresAt.Equal(later) || tk.RevokedAt != nil {
+		t.Errorf("expected token to be persisted as is, got %+v", tk)
+	}
+	if tokens[1].ExpiresAt != nil {
+		t.Errorf("expected token without expiration to have none, got %s", tokens[1].ExpiresAt)
+	}
+	found, err := store.GetTokenByHash(first.Hash)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if found.ID != first.ID || found.Name != "ci" || len(found.Scopes) != 1 || found.Scopes[0] != token.ScopeReadStatuses {
+		t.Errorf("expected the first token to be found by its hash, got %+v", found)
+	}
+	if _, err = store.GetTokenByHash(token.Hash("gatus_third")); !errors.Is(err, common.ErrTokenNotFound) {
+		t.Errorf("expected %v, got %v", common.ErrTokenNotFound, err)
+	}
+	if err = store.InsertToken(&token.Token{Name: "duplicate", Hash: first.Hash, Timestamp: now}); err == nil {
+		t.Error("expected an error when inserting a token with the hash of another token")
+	}
+	store.Clear()
+	if tokens, _ = store.GetTokens(); len(tokens) != 0 {
+		t.Errorf("expected tokens to be cleared, got %d", len(tokens))
+	}
+}
+
+func TestStore_RevokeToken(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_RevokeToken.db", false)
+	defer store.Close()
+	now := time.Now().Truncate(time.Second)
+	tk := &token.Token{Name: "ci", Scopes: []token.Scope{token.ScopeAdmin}, Hash: token.Hash("gatus_secret"), Timestamp: now}
+	if err := store.InsertToken(tk); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if err := store.RevokeToken(tk.ID, now); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	revoked, _ := store.GetTokenByHash(tk.Hash)
+	if revoked.RevokedAt == nil || revoked.IsActive(now) {
+		t.Errorf("expected the token to have been revoked, got %+v", revoked)
+	}
+	// Revoking a token that was already revoked must not change when it was revoked
+	if err := store.RevokeToken(tk.ID, now.Add(time.Hour)); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if revoked, _ = store.GetTokenByHash(tk.Hash); !revoked.RevokedAt.Equal(now) {
+		t.Errorf("expected the token to remain revoked at %s, got %s", now, revoked.RevokedAt)
+	}
+	if err := store.RevokeToken(tk.ID+1, now); !errors.Is(err, common.ErrTokenNotFound) {
+		t.Errorf("expected %v, got %v", common.ErrTokenNotFound, err)
+	}
+}
@@ -6,11 +6,19 @@ import (
 	"time"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/announcement"
+	"github.com/TwiN/gatus/v5/audit"
 	"github.com/TwiN/gatus/v5/config/endpoint"
 	"github.com/TwiN/gatus/v5/storage"
+	"github.com/TwiN/gatus/v5/storage/archive"
+	"github.com/TwiN/gatus/v5/storage/store/clickhouse"
+	"github.com/TwiN/gatus/v5/storage/store/common"
 	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+	"github.com/TwiN/gatus/v5/storage/store/external"
 	"github.com/TwiN/gatus/v5/storage/store/memory"
 	"github.com/TwiN/gatus/v5/storage/store/sql"
+	"github.com/TwiN/gatus/v5/subscription"
+	"github.com/TwiN/gatus/v5/token"
 )
 
 // Store is the interface that each store should implement
@@ -68,12 +76,149 @@ type Store interface {
 	Close()
 }
 
+// Downsampler is the interface implemented by the stores that support downsampling old results into aggregates
+type Downsampler interface {
+	// Downsample collapses the results older than hourlyAfter into hourly aggregates, and the hourly aggregates older
+	// than dailyAfter into daily aggregates. Daily aggregates older than retention are deleted.
+	Downsample(now time.Time, hourlyAfter, dailyAfter, retention time.Duration) error
+
+	// GetAggregatesByKey returns the aggregates of an endpoint during a time range at the resolution specified,
+	// which must be either time.Hour or 24*time.Hour
+	GetAggregatesByKey(key string, resolution time.Duration, from, to time.Time) ([]*endpoint.Aggregate, error)
+}
+
+// Pinger is the interface implemented by the stores whose data is kept by a server that can be unreachable
+type Pinger interface {
+	// Ping checks that the server keeping the data of the store can be reached
+	Ping(ctx context.Context) error
+}
+
+// BackupStore is the interface implemented by the stores whose data can be exported and imported
+type BackupStore interface {
+	// GetHourlyUptimeStatisticsByKey returns the hourly uptime statistics (value) of an endpoint for every hourly unix
+	// timestamp (key)
+	GetHourlyUptimeStatisticsByKey(key string) (map[int64]*endpoint.HourlyUptimeStatistics, error)
+
+	// ImportEndpointStatus replaces the results, events and uptime of an endpoint by those of the status passed as
+	// parameter, creating the endpoint if it doesn't exist
+	ImportEndpointStatus(status *endpoint.Status) error
+}
+
+// FailureCaptureStore is the interface implemented by the stores that keep the captures of the checks that failed
+type FailureCaptureStore interface {
+	// GetFailureCapturesByKey returns the captures of the checks that failed of an endpoint, from newest to oldest
+	GetFailureCapturesByKey(key string) ([]*endpoint.FailureCapture, error)
+}
+
+// AnnotationStore is the interface implemented by the stores that keep annotations
+type AnnotationStore interface {
+	// InsertAnnotation adds an annotation to the store
+	InsertAnnotation(annotation *endpoint.Annotation) error
+
+	// GetAnnotationsByKey returns the annotations affecting an endpoint during a time range, from oldest to newest
+	GetAnnotationsByKey(key string, from, to time.Time) ([]*endpoint.Annotation, error)
+}
+
+// AuditStore is the interface implemented by the stores that keep an audit log of the administrative actions
+type AuditStore interface {
+	// InsertAuditEntry records an administrative action in the audit log
+	InsertAuditEntry(entry *audit.Entry) error
+
+	// GetAuditEntries returns a page of the audit log, from newest to oldest
+	GetAuditEntries(page, pageSize int) ([]*audit.Entry, error)
+}
+
+// AnnouncementStore is the interface implemented by the stores that keep the announcements displayed on the dashboard
+type AnnouncementStore interface {
+	// InsertAnnouncement adds an announcement to the store and sets its ID
+	InsertAnnouncement(a *announcement.Announcement) error
+
+	// ExpireAnnouncement makes the announcement with the ID passed as parameter expire at the time passed as parameter,
+	// unless it already expired before then
+	ExpireAnnouncement(id int64, at time.Time) error
+
+	// GetActiveAnnouncements returns the announcements that haven't expired at the time passed as parameter, from
+	// newest to oldest
+	GetActiveAnnouncements(now time.Time) ([]*announcement.Announcement, error)
+}
+
+// SubscriptionStore is the interface implemented by the stores that keep the subscriptions of the visitors
+type SubscriptionStore interface {
+	// InsertSubscription adds a subscription that has yet to be confirmed to the store and sets its ID. Subscriptions
+	// that weren't confirmed within subscription.ConfirmationTimeout are deleted.
+	InsertSubscription(s *subscription.Subscription) error
+
+	// ConfirmSubscription confirms the subscription whose confirmation token is passed as parameter, which replaces
+	// the subscriptions of the same type and target that were confirmed before. Returns common.ErrSubscriptionNotFound
+	// if there's no such subscription, or if it wasn't confirmed within subscription.ConfirmationTimeout.
+	ConfirmSubscription(confirmationToken string, now time.Time) error
+
+	// DeleteSubscription deletes the subscription whose unsubscribe token is passed as parameter
+	DeleteSubscription(unsubscribeToken string) error
+
+	// GetConfirmedSubscriptions returns every subscription that was confirmed, from oldest to newest
+	GetConfirmedSubscriptions() ([]*subscription.Subscription, error)
+}
+
+// TokenStore is the interface implemented by the stores that keep the API tokens
+type TokenStore interface {
+	// InsertToken adds an API token to the store and sets its ID
+	InsertToken(t *token.Token) error
+
+	// GetTokenByHash returns the API token whose hash is passed as parameter, whether it's active or not. Returns
+	// common.ErrTokenNotFound if there's no such token.
+	GetTokenByHash(hash string) (*token.Token, error)
+
+	// GetTokens returns every API token, whether it's active or not, from newest to oldest
+	GetTokens() ([]*token.Token, error)
+
+	// RevokeToken revokes the API token with the ID passed as parameter at the time passed as parameter, unless it was
+	// already revoked. Returns common.ErrTokenNotFound if there's no such token.
+	RevokeToken(id int64, at time.Time) error
+}
+
+// ResponseTimeStore is the interface implemented by the stores that can retrieve the response times of an endpoint
+// without retrieving its entire results
+type ResponseTimeStore interface {
+	// GetResponseTimesByKey returns the response time of every result of an endpoint during a time range, from oldest
+	// to newest
+	GetResponseTimesByKey(key string, from, to time.Time) ([]*endpoint.ResponseTime, error)
+}
+
 // TODO: add method to check state of store (by keeping track of silent errors)
 
 var (
 	// Validate interface implementation on compile
 	_ Store = (*memory.Store)(nil)
 	_ Store = (*sql.Store)(nil)
+	_ Store = (*clickhouse.Store)(nil)
+	_ Store = (*external.Store)(nil)
+
+	_ Downsampler = (*sql.Store)(nil)
+
+	_ BackupStore = (*memory.Store)(nil)
+	_ BackupStore = (*sql.Store)(nil)
+
+	_ FailureCaptureStore = (*memory.Store)(nil)
+	_ FailureCaptureStore = (*sql.Store)(nil)
+
+	_ AnnotationStore = (*memory.Store)(nil)
+	_ AnnotationStore = (*sql.Store)(nil)
+
+	_ AuditStore = (*memory.Store)(nil)
+	_ AuditStore = (*sql.Store)(nil)
+
+	_ AnnouncementStore = (*memory.Store)(nil)
+	_ AnnouncementStore = (*sql.Store)(nil)
+
+	_ SubscriptionStore = (*memory.Store)(nil)
+	_ SubscriptionStore = (*sql.Store)(nil)
+
+	_ TokenStore = (*memory.Store)(nil)
+	_ TokenStore = (*sql.Store)(nil)
+
+	_ ResponseTimeStore = (*memory.Store)(nil)
+	_ ResponseTimeStore = (*sql.Store)(nil)
 )
 
 var (
@@ -100,6 +245,17 @@ func Get() Store {
 	return store
 }
 
+// Audit records an administrative action in the audit log of the storage provider, if it keeps one
+func Audit(entry *audit.Entry) {
+	auditStore, ok := Get().(AuditStore)
+	if !ok {
+		return
+	}
+	if err := auditStore.InsertAuditEntry(entry); err != nil {
+		log.Printf("[store.Audit] Failed to record action=%s in the audit log: %s", entry.Action, err.Error())
+	}
+}
+
 // Initialize instantiates the storage provider based on the Config provider
 func Initialize(cfg *storage.Config) error {
 	initialized = true
@@ -113,22 +269,138 @@ func Initialize(cfg *storage.Config) error {
 		log.Println("[store.Initialize] nil storage config passed as parameter. This should only happen in tests. Defaulting to an empty config.")
 		cfg = &storage.Config{}
 	}
-	if len(cfg.Path) == 0 && cfg.Type != storage.TypePostgres {
+	if len(cfg.Path) == 0 && cfg.Type != storage.TypePostgres && cfg.Type != storage.TypeClickHouse {
 		log.Printf("[store.Initialize] Creating storage provider of type=%s", cfg.Type)
 	}
 	ctx, cancelFunc = context.WithCancel(context.Background())
-	switch cfg.Type {
-	case storage.TypeSQLite, storage.TypePostgres:
-		store, err = sql.NewStore(string(cfg.Type), cfg.Path, cfg.Caching)
+	if store, err = New(cfg); err != nil {
+		return err
+	}
+	if cfg.MaxOpenConns > 0 || cfg.MaxIdleConns > 0 || cfg.ConnMaxLifetime > 0 {
+		pooledStore, ok := store.(interface {
+			SetConnectionPool(maxOpenConns, maxIdleConns int, connMaxLifetime time.Duration)
+		})
+		if !ok {
+			return storage.ErrConnectionPoolNotSupported
+		}
+		pooledStore.SetConnectionPool(cfg.MaxOpenConns, cfg.MaxIdleConns, cfg.ConnMaxLifetime)
+	}
+	if cfg.Archive != nil {
+		archivableStore, ok := store.(interface{ SetArchiver(*archive.Archiver) })
+		if !ok {
+			return storage.ErrArchiveNotSupported
+		}
+		archiver, err := archive.NewArchiver(cfg.Archive)
 		if err != nil {
 			return err
 		}
+		archivableStore.SetArchiver(archiver)
+	}
+	if len(cfg.Retention) > 0 {
+		retentionStore, ok := store.(interface {
+			SetRetentionPolicy(common.RetentionPolicyFunc)
+		})
+		if !ok {
+			return storage.ErrRetentionNotSupported
+		}
+		retentionStore.SetRetentionPolicy(cfg.RetentionPolicy)
+	}
+	if cfg.WriteBuffer != nil {
+		bufferedStore, ok := store.(interface {
+			SetWriteBuffer(batchSize int, flushInterval time.Duration)
+		})
+		if !ok {
+			return storage.ErrWriteBufferNotSupported
+		}
+		bufferedStore.SetWriteBuffer(cfg.WriteBuffer.BatchSize, cfg.WriteBuffer.FlushInterval)
+	}
+	if cfg.Type == storage.TypeMemory && len(cfg.Path) > 0 {
+		snapshotInterval := memory.DefaultSnapshotInterval
+		if cfg.Memory != nil && cfg.Memory.SnapshotInterval > 0 {
+			snapshotInterval = cfg.Memory.SnapshotInterval
+		}
+		go autoSave(ctx, store, snapshotInterval)
+	}
+	if cfg.Downsampling != nil {
+		downsampler, ok := store.(Downsampler)
+		if !ok {
+			return storage.ErrDownsamplingNotSupported
+		}
+		go downsample(ctx, downsampler, cfg.Downsampling)
+	}
+	return nil
+}
+
+// downsample automatically calls the Downsample function of the provider at every interval
+func downsample(ctx context.Context, downsampler Downsampler, cfg *storage.DownsamplingConfig) {
+	for {
+		select {
+		case <-ctx.Done():
+			log.Printf("[store.downsample] Stopping active job")
+			return
+		case <-time.After(cfg.Interval):
+			if err := downsampler.Downsample(time.Now(), cfg.After, cfg.DailyAfter, cfg.Retention); err != nil {
+				log.Println("[store.downsample] Downsampling failed:", err.Error())
+			}
+		}
+	}
+}
+
+// New creates the storage provider described by the configuration passed as parameter, without initializing it as
+// the storage provider returned by Get
+func New(cfg *storage.Config) (Store, error) {
+	switch cfg.Type {
+	case storage.TypePostgres:
+		if cfg.Postgres == nil {
+			return nilIfError(sql.NewStore(string(cfg.Type), cfg.Path, cfg.Caching))
+		}
+		var postgresStore *sql.Store
+		var err error
+		if len(cfg.Postgres.Partitioning) > 0 {
+			postgresStore, err = sql.NewPartitionedStore(cfg.Path, cfg.Caching, cfg.Postgres.Partitioning, cfg.Postgres.Retention, cfg.Postgres.PartitionInterval)
+		} else {
+			postgresStore, err = sql.NewStore(string(cfg.Type), cfg.Path, cfg.Caching)
+		}
+		if err == nil && len(cfg.Postgres.Replicas) > 0 {
+			if err = postgresStore.SetReplicas(cfg.Postgres.Replicas); err != nil {
+				postgresStore.Close()
+			}
+		}
+		return nilIfError(postgresStore, err)
+	case storage.TypeSQLite:
+		if len(cfg.EncryptionKey) > 0 {
+			return nilIfError(sql.NewEncryptedStore(cfg.Path, cfg.EncryptionKey, cfg.Caching))
+		}
+		return nilIfError(sql.NewStore(string(cfg.Type), cfg.Path, cfg.Caching))
+	case storage.TypeClickHouse:
+		clickHouseConfig := cfg.ClickHouse
+		if clickHouseConfig == nil {
+			clickHouseConfig = &storage.ClickHouseConfig{}
+		}
+		return nilIfError(clickhouse.NewStore(cfg.Path, clickHouseConfig.BatchSize, clickHouseConfig.FlushInterval, clickHouseConfig.Retention))
+	case storage.TypeExternal:
+		externalConfig := cfg.External
+		if externalConfig == nil {
+			externalConfig = &storage.ExternalConfig{}
+		}
+		return nilIfError(external.NewStore(cfg.Path, externalConfig.Command, externalConfig.Timeout))
 	case storage.TypeMemory:
+		if len(cfg.Path) > 0 {
+			return nilIfError(memory.NewStoreWithSnapshot(cfg.Path))
+		}
 		fallthrough
 	default:
-		store, _ = memory.NewStore()
+		return memory.NewStore()
 	}
-	return nil
+}
+
+// nilIfError converts the store passed as parameter to a Store, unless there's an error, in which case a nil Store is
+// returned instead of a Store holding a nil pointer
+func nilIfError[T Store](s T, err error) (Store, error) {
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
 }
 
 // autoSave automatically calls the Save function of the provider at every interval
@@ -363,6 +363,38 @@ func TestStore_GetUptimeByKey(t *testing.T) {
 	}
 }
 
+func TestStore_GetUptimeByKeyOverLongDurations(t *testing.T) {
+	scenarios := initStoresAndBaseScenarios(t, "TestStore_GetUptimeByKeyOverLongDurations")
+	defer cleanUp(scenarios)
+	firstResult := testUnsuccessfulResult
+	firstResult.Timestamp = now.Add(-60 * 24 * time.Hour)
+	firstResult.Duration = 300 * time.Millisecond
+	secondResult := testSuccessfulResult
+	secondResult.Timestamp = now
+	secondResult.Duration = 100 * time.Millisecond
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			scenario.Store.Insert(&testEndpoint, &firstResult)
+			scenario.Store.Insert(&testEndpoint, &secondResult)
+			if uptime, _ := scenario.Store.GetUptimeByKey(testEndpoint.Key(), now.Add(-time.Hour*24*7), time.Now()); uptime != 1 {
+				t.Errorf("the uptime over the past 7d should've been 1, got %f", uptime)
+			}
+			if uptime, _ := scenario.Store.GetUptimeByKey(testEndpoint.Key(), now.Add(-time.Hour*24*30), time.Now()); uptime != 1 {
+				t.Errorf("the uptime over the past 30d should've been 1, got %f", uptime)
+			}
+			if uptime, _ := scenario.Store.GetUptimeByKey(testEndpoint.Key(), now.Add(-time.Hour*24*90), time.Now()); uptime != 0.5 {
+				t.Errorf("the uptime over the past 90d should've been 0.5, got %f", uptime)
+			}
+			if uptime, _ := scenario.Store.GetUptimeByKey(testEndpoint.Key(), now.Add(-time.Hour*24*365), time.Now()); uptime != 0.5 {
+				t.Errorf("the uptime over the past 365d should've been 0.5, got %f", uptime)
+			}
+			if averageResponseTime, _ := scenario.Store.GetAverageResponseTimeByKey(testEndpoint.Key(), now.Add(-time.Hour*24*90), time.Now()); averageResponseTime != 200 {
+				t.Errorf("the average response time over the past 90d should've been 200ms, got %dms", averageResponseTime)
+			}
+		})
+	}
+}
+
 func TestStore_GetAverageResponseTimeByKey(t *testing.T) {
 	scenarios := initStoresAndBaseScenarios(t, "TestStore_GetAverageResponseTimeByKey")
 	defer cleanUp(scenarios)
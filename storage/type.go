@@ -4,7 +4,9 @@ package storage
 type Type string
 
 const (
-	TypeMemory   Type = "memory"   // In-memory store
-	TypeSQLite   Type = "sqlite"   // SQLite store
-	TypePostgres Type = "postgres" // Postgres store
+	TypeMemory     Type = "memory"     // In-memory store
+	TypeSQLite     Type = "sqlite"     // SQLite store
+	TypePostgres   Type = "postgres"   // Postgres store
+	TypeClickHouse Type = "clickhouse" // ClickHouse store
+	TypeExternal   Type = "external"   // Store proxying to a storage plugin over gRPC
 )
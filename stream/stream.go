@@ -0,0 +1,107 @@
+// Package stream broadcasts the results of the endpoints, and the changes of their state, to the subscribers of the
+// live status updates of the API as they happen.
+package stream
+
+import (
+	"log"
+	"sync"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+)
+
+// SubscriberBufferSize is the number of events that can be queued for a subscriber before the events sent to said
+// subscriber are dropped
+const SubscriberBufferSize = 64
+
+// EventType is the type of event sent to the subscribers
+type EventType string
+
+var (
+	// EventTypeResult is a type of event that represents a new result of an endpoint
+	EventTypeResult EventType = "result"
+
+	// EventTypeStateChange is a type of event that represents an endpoint going from healthy to unhealthy, or vice versa
+	EventTypeStateChange EventType = "state-change"
+)
+
+// Event is what is sent to the subscribers
+type Event struct {
+	// Type is the kind of event
+	Type EventType `json:"type"`
+
+	// Key of the endpoint
+	Key string `json:"key"`
+
+	// Name of the endpoint
+	Name string `json:"name"`
+
+	// Group of the endpoint
+	Group string `json:"group,omitempty"`
+
+	// Result is the new result of the endpoint. Only set if Type is EventTypeResult.
+	Result *endpoint.Result `json:"result,omitempty"`
+
+	// Event is the HEALTHY or UNHEALTHY event of the endpoint. Only set if Type is EventTypeStateChange.
+	Event *endpoint.Event `json:"event,omitempty"`
+}
+
+var (
+	subscribers      = make(map[chan *Event]struct{})
+	lastSuccessByKey = make(map[string]bool)
+	mutex            sync.Mutex
+)
+
+// Subscribe returns a channel to which every event will be sent until the unsubscribe function is called, or until
+// UnsubscribeAll is called, both of which close the channel
+func Subscribe() (events <-chan *Event, unsubscribe func()) {
+	channel := make(chan *Event, SubscriberBufferSize)
+	mutex.Lock()
+	subscribers[channel] = struct{}{}
+	mutex.Unlock()
+	return channel, func() {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if _, exists := subscribers[channel]; exists {
+			delete(subscribers, channel)
+			close(channel)
+		}
+	}
+}
+
+// UnsubscribeAll closes the channels of all subscribers, which is necessary for the server to shut down
+func UnsubscribeAll() {
+	mutex.Lock()
+	defer mutex.Unlock()
+	for channel := range subscribers {
+		delete(subscribers, channel)
+		close(channel)
+	}
+}
+
+// Publish sends the result of the endpoint passed as parameter to the subscribers, preceded by a state change event
+// if the success of the result differs from the success of the previous result of the endpoint
+func Publish(ep *endpoint.Endpoint, result *endpoint.Result) {
+	key := ep.Key()
+	mutex.Lock()
+	defer mutex.Unlock()
+	lastSuccess, exists := lastSuccessByKey[key]
+	lastSuccessByKey[key] = result.Success
+	if len(subscribers) == 0 {
+		return
+	}
+	if exists && lastSuccess != result.Success {
+		send(&Event{Type: EventTypeStateChange, Key: key, Name: ep.Name, Group: ep.Group, Event: endpoint.NewEventFromResult(result)})
+	}
+	send(&Event{Type: EventTypeResult, Key: key, Name: ep.Name, Group: ep.Group, Result: result})
+}
+
+// send sends the event passed as parameter to every subscriber without blocking. The mutex must be locked.
+func send(event *Event) {
+	for channel := range subscribers {
+		select {
+		case channel <- event:
+		default:
+			log.Printf("[stream.send] Dropped %s event of endpoint with key=%s because the subscriber is too slow", event.Type, event.Key)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package stream
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+)
+
+func TestPublish(t *testing.T) {
+	ep := &endpoint.Endpoint{Name: "name", Group: "group"}
+	Publish(ep, &endpoint.Result{Success: true, Timestamp: time.Now()}) // Published before subscribing, so not received
+	events, unsubscribe := Subscribe()
+	defer unsubscribe()
+	Publish(ep, &endpoint.Result{Success: true, Timestamp: time.Now()})
+	Publish(ep, &endpoint.Result{Success: false, Timestamp: time.Now()})
+	expectedTypes := []EventType{EventTypeResult, EventTypeStateChange, EventTypeResult}
+	for i, expectedType := range expectedTypes {
+		select {
+		case event := <-events:
+			if event.Type != expectedType {
+				t.Errorf("expected event #%d to be of type %s, got %s", i, expectedType, event.Type)
+			}
+			if event.Key != "group_name" || event.Name != "name" || event.Group != "group" {
+				t.Errorf("expected event #%d to be of endpoint group_name, got %s", i, event.Key)
+			}
+			if expectedType == EventTypeStateChange && (event.Event == nil || event.Event.Type != endpoint.EventUnhealthy) {
+				t.Errorf("expected event #%d to be an UNHEALTHY event, got %v", i, event.Event)
+			}
+			if expectedType == EventTypeResult && event.Result == nil {
+				t.Errorf("expected event #%d to have a result", i)
+			}
+		default:
+			t.Fatalf("expected event #%d to have been sent", i)
+		}
+	}
+}
+
+func TestPublish_WithSlowSubscriber(t *testing.T) {
+	events, unsubscribe := Subscribe()
+	defer unsubscribe()
+	ep := &endpoint.Endpoint{Name: "slow"}
+	for i := 0; i < SubscriberBufferSize*2; i++ {
+		Publish(ep, &endpoint.Result{Success: true, Timestamp: time.Now()})
+	}
+	if len(events) != SubscriberBufferSize {
+		t.Errorf("expected %d events to have been queued, got %d", SubscriberBufferSize, len(events))
+	}
+}
+
+func TestUnsubscribeAll(t *testing.T) {
+	events, unsubscribe := Subscribe()
+	UnsubscribeAll()
+	if _, ok := <-events; ok {
+		t.Error("expected channel to have been closed")
+	}
+	// Unsubscribing after the channel was closed by UnsubscribeAll should not panic
+	unsubscribe()
+	if len(subscribers) != 0 {
+		t.Errorf("expected no subscribers, got %d", len(subscribers))
+	}
+}
@@ -0,0 +1,80 @@
+package subscription
+
+import (
+	"errors"
+	"math"
+	"net/url"
+	"strings"
+
+	"github.com/TwiN/gatus/v5/client"
+)
+
+var (
+	ErrMissingURL         = errors.New("subscriptions.url must be set to the URL at which Gatus is reached by the subscribers")
+	ErrInvalidURL         = errors.New("subscriptions.url must be an absolute URL with the http or https scheme")
+	ErrNoTypeEnabled      = errors.New("subscriptions.email must be configured or subscriptions.webhooks must be enabled")
+	ErrInvalidEmailConfig = errors.New("subscriptions.email must have a from address, a host and a valid port")
+)
+
+// Config is the configuration of the subscriptions, through which visitors are notified of the changes of state of the
+// endpoints and of the announcements
+type Config struct {
+	// URL is the URL at which Gatus is reached by the subscribers, which is used to build the links they confirm their
+	// subscription and unsubscribe with, e.g. https://status.example.org
+	URL string `yaml:"url"`
+
+	// Email is the configuration of the SMTP server the subscribers are notified by email through. If nil, visitors
+	// cannot subscribe by email.
+	Email *EmailConfig `yaml:"email,omitempty"`
+
+	// Webhooks is whether visitors can subscribe with a webhook
+	Webhooks bool `yaml:"webhooks,omitempty"`
+
+	// ClientConfig is the configuration of the client the webhooks are called with
+	ClientConfig *client.Config `yaml:"client,omitempty"`
+}
+
+// EmailConfig is the configuration of the SMTP server the subscribers are notified by email through
+type EmailConfig struct {
+	From     string `yaml:"from"`
+	Username string `yaml:"username,omitempty"`
+	Password string `yaml:"password,omitempty"`
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+
+	// ClientConfig is the configuration of the client used to communicate with the SMTP server
+	ClientConfig *client.Config `yaml:"client,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the subscriptions configuration and sets the default values if necessary
+func (c *Config) ValidateAndSetDefaults() error {
+	if len(c.URL) == 0 {
+		return ErrMissingURL
+	}
+	parsedURL, err := url.Parse(c.URL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || len(parsedURL.Host) == 0 {
+		return ErrInvalidURL
+	}
+	c.URL = strings.TrimSuffix(c.URL, "/")
+	if c.Email == nil && !c.Webhooks {
+		return ErrNoTypeEnabled
+	}
+	if c.Email != nil && (len(c.Email.From) == 0 || len(c.Email.Host) == 0 || c.Email.Port <= 0 || c.Email.Port >= math.MaxUint16) {
+		return ErrInvalidEmailConfig
+	}
+	if c.ClientConfig == nil {
+		c.ClientConfig = client.GetDefaultConfig()
+	}
+	return nil
+}
+
+// IsTypeEnabled returns whether visitors can subscribe with the type of subscription passed as parameter
+func (c *Config) IsTypeEnabled(subscriptionType Type) bool {
+	switch subscriptionType {
+	case TypeEmail:
+		return c.Email != nil
+	case TypeWebhook:
+		return c.Webhooks
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package subscription
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		Name          string
+		Config        *Config
+		ExpectedURL   string
+		ExpectedError error
+	}{
+		{
+			Name:          "missing-url",
+			Config:        &Config{Webhooks: true},
+			ExpectedError: ErrMissingURL,
+		},
+		{
+			Name:          "relative-url",
+			Config:        &Config{URL: "/status", Webhooks: true},
+			ExpectedError: ErrInvalidURL,
+		},
+		{
+			Name:          "url-with-invalid-scheme",
+			Config:        &Config{URL: "ftp://status.example.org", Webhooks: true},
+			ExpectedError: ErrInvalidURL,
+		},
+		{
+			Name:          "no-type-enabled",
+			Config:        &Config{URL: "https://status.example.org"},
+			ExpectedError: ErrNoTypeEnabled,
+		},
+		{
+			Name:          "email-without-host",
+			Config:        &Config{URL: "https://status.example.org", Email: &EmailConfig{From: "status@example.org", Port: 587}},
+			ExpectedError: ErrInvalidEmailConfig,
+		},
+		{
+			Name:          "email-with-invalid-port",
+			Config:        &Config{URL: "https://status.example.org", Email: &EmailConfig{From: "status@example.org", Host: "smtp.example.org", Port: 70000}},
+			ExpectedError: ErrInvalidEmailConfig,
+		},
+		{
+			Name:        "email",
+			Config:      &Config{URL: "https://status.example.org", Email: &EmailConfig{From: "status@example.org", Host: "smtp.example.org", Port: 587}},
+			ExpectedURL: "https://status.example.org",
+		},
+		{
+			Name:        "webhooks-with-trailing-slash",
+			Config:      &Config{URL: "https://example.org/status/", Webhooks: true},
+			ExpectedURL: "https://example.org/status",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			err := scenario.Config.ValidateAndSetDefaults()
+			if !errors.Is(err, scenario.ExpectedError) {
+				t.Fatalf("expected error %v, got %v", scenario.ExpectedError, err)
+			}
+			if err != nil {
+				return
+			}
+			if scenario.Config.URL != scenario.ExpectedURL {
+				t.Errorf("expected URL %s, got %s", scenario.ExpectedURL, scenario.Config.URL)
+			}
+			if scenario.Config.ClientConfig == nil {
+				t.Error("expected the client configuration to default to the default client configuration")
+			}
+		})
+	}
+}
+
+func TestConfig_IsTypeEnabled(t *testing.T) {
+	cfg := &Config{URL: "https://status.example.org", Email: &EmailConfig{From: "status@example.org", Host: "smtp.example.org", Port: 587}}
+	if !cfg.IsTypeEnabled(TypeEmail) {
+		t.Error("expected subscribing by email to be enabled")
+	}
+	if cfg.IsTypeEnabled(TypeWebhook) {
+		t.Error("expected subscribing with a webhook to be disabled")
+	}
+	if cfg.IsTypeEnabled("sms") {
+		t.Error("expected unknown types to be disabled")
+	}
+}
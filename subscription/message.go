@@ -0,0 +1,133 @@
+package subscription
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/TwiN/gatus/v5/client"
+	gomail "gopkg.in/mail.v2"
+)
+
+// MessageType is the type of message sent to a subscriber
+type MessageType string
+
+var (
+	// MessageTypeConfirmation is the type of message asking the subscriber to confirm the subscription
+	MessageTypeConfirmation MessageType = "confirmation"
+
+	// MessageTypeStateChange is the type of message notifying the subscriber of an endpoint going from healthy to
+	// unhealthy, or vice versa
+	MessageTypeStateChange MessageType = "state-change"
+
+	// MessageTypeAnnouncement is the type of message notifying the subscriber of an announcement
+	MessageTypeAnnouncement MessageType = "announcement"
+)
+
+// Message is what is sent to a subscriber. Webhooks receive it as the JSON body of the request.
+type Message struct {
+	// Type is the type of message
+	Type MessageType `json:"type"`
+
+	// Title is a short summary of the message, which is the subject of emails
+	Title string `json:"title"`
+
+	// Text is what the message says
+	Text string `json:"text"`
+
+	// Group of the endpoint whose state changed. Only set if Type is MessageTypeStateChange.
+	Group string `json:"group,omitempty"`
+
+	// Key of the endpoint whose state changed. Only set if Type is MessageTypeStateChange.
+	Key string `json:"key,omitempty"`
+
+	// Timestamp is when what the message is about happened
+	Timestamp time.Time `json:"timestamp"`
+
+	// URL is the link the subscriber confirms the subscription with if Type is MessageTypeConfirmation, or
+	// unsubscribes with otherwise. It is set when the message is sent.
+	URL string `json:"url"`
+}
+
+// Send sends a message to a subscriber
+func (c *Config) Send(s *Subscription, message Message) error {
+	if message.Type == MessageTypeConfirmation {
+		message.URL = c.URL + "/api/v1/subscriptions/confirm?token=" + s.ConfirmationToken
+	} else {
+		message.URL = c.URL + "/api/v1/subscriptions/unsubscribe?token=" + s.UnsubscribeToken
+	}
+	switch s.Type {
+	case TypeEmail:
+		if c.Email == nil {
+			return fmt.Errorf("cannot send message to %s: subscribing by email is not enabled", s.Target)
+		}
+		return c.Email.send(s.Target, &message)
+	case TypeWebhook:
+		return c.callWebhook(s.Target, &message)
+	}
+	return ErrInvalidType
+}
+
+// send sends a message by email
+func (c *EmailConfig) send(to string, message *Message) error {
+	body := message.Text + "\n\n"
+	m := gomail.NewMessage()
+	if message.Type == MessageTypeConfirmation {
+		body += "To confirm your subscription, open the following link:\n" + message.URL
+	} else {
+		body += "To unsubscribe, open the following link:\n" + message.URL
+		m.SetHeader("List-Unsubscribe", "<"+message.URL+">")
+		m.SetHeader("List-Unsubscribe-Post", "List-Unsubscribe=One-Click")
+	}
+	m.SetHeader("From", c.From)
+	m.SetHeader("To", to)
+	m.SetHeader("Subject", message.Title)
+	m.SetBody("text/plain", body)
+	var d *gomail.Dialer
+	if len(c.Password) == 0 {
+		// Get the domain in the From address
+		localName := "localhost"
+		if fromParts := strings.Split(c.From, "@"); len(fromParts) == 2 {
+			localName = fromParts[1]
+		}
+		d = &gomail.Dialer{Host: c.Host, Port: c.Port, LocalName: localName}
+	} else {
+		username := c.Username
+		if len(username) == 0 {
+			username = c.From
+		}
+		d = gomail.NewDialer(c.Host, c.Port, username, c.Password)
+	}
+	if c.ClientConfig != nil && c.ClientConfig.Insecure {
+		d.TLSConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+	return d.DialAndSend(m)
+}
+
+// callWebhook sends a message as the JSON body of a POST request to the URL of a webhook
+func (c *Config) callWebhook(url string, message *Message) error {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	response, err := client.GetHTTPClient(c.ClientConfig).Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode > 399 {
+		body, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("call to webhook returned status code %d: %s", response.StatusCode, string(body))
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package subscription
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestConfig_SendWithWebhook(t *testing.T) {
+	var received []*Message
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		message := &Message{}
+		if err := json.NewDecoder(r.Body).Decode(message); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received = append(received, message)
+		if r.URL.Path == "/broken" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+	cfg := &Config{URL: "https://status.example.org", Webhooks: true}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	subscription, _ := New(TypeWebhook, server.URL, nil)
+	if err := cfg.Send(subscription, Message{Type: MessageTypeConfirmation, Title: "Confirm your subscription", Timestamp: time.Now()}); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if err := cfg.Send(subscription, Message{Type: MessageTypeStateChange, Title: "core/frontend is unhealthy", Group: "core", Key: "core_frontend", Timestamp: time.Now()}); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(received) != 2 {
+		t.Fatalf("expected 2 messages to be received, got %d", len(received))
+	}
+	if expectedURL := "https://status.example.org/api/v1/subscriptions/confirm?token=" + subscription.ConfirmationToken; received[0].URL != expectedURL {
+		t.Errorf("expected the confirmation message to link to %s, got %s", expectedURL, received[0].URL)
+	}
+	if expectedURL := "https://status.example.org/api/v1/subscriptions/unsubscribe?token=" + subscription.UnsubscribeToken; received[1].URL != expectedURL {
+		t.Errorf("expected the notification to link to %s, got %s", expectedURL, received[1].URL)
+	}
+	if received[1].Type != MessageTypeStateChange || received[1].Key != "core_frontend" || received[1].Group != "core" {
+		t.Errorf("expected the notification to be sent as is, got %+v", received[1])
+	}
+	subscription.Target = server.URL + "/broken"
+	if err := cfg.Send(subscription, Message{Type: MessageTypeAnnouncement, Title: "Maintenance"}); err == nil {
+		t.Error("expected an error, because the webhook returned an error status code")
+	}
+	if err := cfg.Send(&Subscription{Type: "sms"}, Message{Type: MessageTypeAnnouncement}); err != ErrInvalidType {
+		t.Errorf("expected %v, got %v", ErrInvalidType, err)
+	}
+}
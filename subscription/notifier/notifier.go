@@ -0,0 +1,154 @@
+// Package notifier notifies the subscribers whose subscription was confirmed of the changes of state of the endpoints
+// and of the announcements.
+package notifier
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/TwiN/gatus/v5/announcement"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/group"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/stream"
+	"github.com/TwiN/gatus/v5/subscription"
+)
+
+// QueueSize is the number of messages that can be queued before the messages are dropped
+const QueueSize = 256
+
+var (
+	queue       chan subscription.Message
+	unsubscribe func()
+	mutex       sync.Mutex
+)
+
+// Start notifies the subscribers of the changes of state of the endpoints until Shutdown is called. Does nothing if
+// the configuration of the subscriptions passed as parameter is nil.
+//
+// Messages are queued and sent one after the other, so that slow SMTP servers and webhooks never cause the changes of
+// state to be dropped by the stream. The changes of state of the endpoints of the groups passed whose visibility is
+// restricted are only sent to the subscribers who subscribed to the group by name, since only the visitors allowed to
+// see the group may do so, whereas anyone may subscribe to every group.
+func Start(cfg *subscription.Config, groups []*group.Group) {
+	if cfg == nil {
+		return
+	}
+	restrictedGroups := make(map[string]bool)
+	for _, g := range groups {
+		if g.VisibleTo != nil {
+			restrictedGroups[g.Name] = true
+		}
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	events, unsubscribeFromStream := stream.Subscribe()
+	messages := make(chan subscription.Message, QueueSize)
+	queue, unsubscribe = messages, unsubscribeFromStream
+	go func() {
+		for event := range events {
+			if event.Type == stream.EventTypeStateChange {
+				enqueue(newStateChangeMessage(event))
+			}
+		}
+		// The stream was closed, either by Shutdown or because the server shut down
+		mutex.Lock()
+		if queue == messages {
+			queue, unsubscribe = nil, nil
+		}
+		mutex.Unlock()
+		close(messages)
+	}()
+	go func() {
+		for message := range messages {
+			notify(cfg, restrictedGroups, message)
+		}
+	}()
+}
+
+// Shutdown stops notifying the subscribers. The messages that were already queued are still sent.
+func Shutdown() {
+	mutex.Lock()
+	unsubscribeFromStream := unsubscribe
+	queue, unsubscribe = nil, nil
+	mutex.Unlock()
+	if unsubscribeFromStream != nil {
+		unsubscribeFromStream()
+	}
+}
+
+// Announce notifies every subscriber of an announcement, regardless of the groups they subscribed to
+func Announce(a *announcement.Announcement) {
+	text := a.Message
+	if a.ExpiresAt != nil {
+		text += "\n\nThis announcement is valid until " + a.ExpiresAt.UTC().Format("2006-01-02 15:04 MST") + "."
+	}
+	enqueue(subscription.Message{
+		Type:      subscription.MessageTypeAnnouncement,
+		Title:     strings.ToUpper(string(a.Type[:1])) + string(a.Type[1:]) + " announcement",
+		Text:      text,
+		Timestamp: a.Timestamp,
+	})
+}
+
+// enqueue queues a message without blocking. Does nothing if the notifier isn't started.
+func enqueue(message subscription.Message) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	if queue == nil {
+		return
+	}
+	select {
+	case queue <- message:
+	default:
+		log.Printf("[notifier.enqueue] Dropped %s message with title='%s' because too many messages are queued", message.Type, message.Title)
+	}
+}
+
+// notify sends a message to every subscriber it is relevant to and, for the changes of state of the endpoints of the
+// restricted groups passed, allowed to see
+func notify(cfg *subscription.Config, restrictedGroups map[string]bool, message subscription.Message) {
+	subscriptionStore, ok := store.Get().(store.SubscriptionStore)
+	if !ok {
+		return
+	}
+	subscriptions, err := subscriptionStore.GetConfirmedSubscriptions()
+	if err != nil {
+		log.Printf("[notifier.notify] Failed to retrieve subscriptions: %s", err.Error())
+		return
+	}
+	for _, sub := range subscriptions {
+		if message.Type == subscription.MessageTypeStateChange {
+			if !sub.IsInterestedIn(message.Group) || (restrictedGroups[message.Group] && !slices.Contains(sub.Groups, message.Group)) {
+				continue
+			}
+		}
+		if !cfg.IsTypeEnabled(sub.Type) {
+			// The type of subscription was disabled after the visitor subscribed
+			continue
+		}
+		if err := cfg.Send(sub, message); err != nil {
+			log.Printf("[notifier.notify] Failed to send %s message to subscription with id=%d: %s", message.Type, sub.ID, err.Error())
+		}
+	}
+}
+
+// newStateChangeMessage creates the message notifying the subscribers of the change of state of an endpoint
+func newStateChangeMessage(event *stream.Event) subscription.Message {
+	displayName := (&endpoint.Endpoint{Name: event.Name, Group: event.Group}).DisplayName()
+	state := "healthy"
+	if event.Event.Type == endpoint.EventUnhealthy {
+		state = "unhealthy"
+	}
+	return subscription.Message{
+		Type:      subscription.MessageTypeStateChange,
+		Title:     fmt.Sprintf("%s is %s", displayName, state),
+		Text:      fmt.Sprintf("%s became %s at %s.", displayName, state, event.Event.Timestamp.UTC().Format("2006-01-02 15:04:05 MST")),
+		Group:     event.Group,
+		Key:       event.Key,
+		Timestamp: event.Event.Timestamp,
+	}
+}
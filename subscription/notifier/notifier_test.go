@@ -0,0 +1,105 @@
+package notifier
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/announcement"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/group"
+	"github.com/TwiN/gatus/v5/security"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/stream"
+	"github.com/TwiN/gatus/v5/subscription"
+)
+
+func TestStart(t *testing.T) {
+	defer store.Get().Clear()
+	var (
+		received      = make(map[string][]*subscription.Message)
+		receivedMutex sync.Mutex
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		message := &subscription.Message{}
+		_ = json.NewDecoder(r.Body).Decode(message)
+		receivedMutex.Lock()
+		received[r.URL.Path] = append(received[r.URL.Path], message)
+		receivedMutex.Unlock()
+	}))
+	defer server.Close()
+	cfg := &subscription.Config{URL: "https://status.example.org", Webhooks: true}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	subscriptionStore := store.Get().(store.SubscriptionStore)
+	for _, sub := range []*subscription.Subscription{
+		{Type: subscription.TypeWebhook, Target: server.URL + "/everything", ConfirmationToken: "1", UnsubscribeToken: "1", Timestamp: time.Now()},
+		{Type: subscription.TypeWebhook, Target: server.URL + "/core", Groups: []string{"core"}, ConfirmationToken: "2", UnsubscribeToken: "2", Timestamp: time.Now()},
+		{Type: subscription.TypeWebhook, Target: server.URL + "/pending", ConfirmationToken: "3", UnsubscribeToken: "3", Timestamp: time.Now()},
+		{Type: subscription.TypeEmail, Target: "john.doe@example.org", ConfirmationToken: "4", UnsubscribeToken: "4", Timestamp: time.Now()},
+		{Type: subscription.TypeWebhook, Target: server.URL + "/billing", Groups: []string{"billing"}, ConfirmationToken: "5", UnsubscribeToken: "5", Timestamp: time.Now()},
+	} {
+		_ = subscriptionStore.InsertSubscription(sub)
+		if sub.ConfirmationToken != "3" {
+			_ = subscriptionStore.ConfirmSubscription(sub.ConfirmationToken, time.Now())
+		}
+	}
+	endpoints := []*endpoint.Endpoint{{Name: "frontend", Group: "web"}, {Name: "backend", Group: "core"}, {Name: "ledger", Group: "billing"}}
+	for _, ep := range endpoints {
+		stream.Publish(ep, &endpoint.Result{Success: true, Timestamp: time.Now()})
+	}
+	Start(cfg, []*group.Group{{Name: "core"}, {Name: "billing", VisibleTo: &group.Visibility{Roles: []security.Role{security.RoleAdmin}}}})
+	for _, ep := range endpoints {
+		stream.Publish(ep, &endpoint.Result{Success: true, Timestamp: time.Now()})
+		stream.Publish(ep, &endpoint.Result{Success: false, Timestamp: time.Now()})
+	}
+	Announce(&announcement.Announcement{Type: announcement.TypeMaintenance, Message: "Maintenance tonight", Timestamp: time.Now()})
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		receivedMutex.Lock()
+		numberOfMessagesReceived := len(received["/everything"]) + len(received["/core"]) + len(received["/billing"])
+		receivedMutex.Unlock()
+		if numberOfMessagesReceived >= 7 {
+			break
+		}
+	}
+	Shutdown()
+	// Nothing is sent once the notifier is shut down
+	Announce(&announcement.Announcement{Type: announcement.TypeInformation, Message: "Hello", Timestamp: time.Now()})
+	time.Sleep(50 * time.Millisecond)
+	receivedMutex.Lock()
+	defer receivedMutex.Unlock()
+	// The announcement may be sent before the changes of state, since they're queued by different goroutines, and the
+	// changes of state of the restricted group are only sent to the subscriber who subscribed to it by name
+	for path, expectedTitles := range map[string][]string{
+		"/everything": {"web/frontend is unhealthy", "core/backend is unhealthy", "Maintenance announcement"},
+		"/core":       {"core/backend is unhealthy", "Maintenance announcement"},
+		"/billing":    {"billing/ledger is unhealthy", "Maintenance announcement"},
+	} {
+		titles := make(map[string]bool)
+		for _, message := range received[path] {
+			titles[message.Title] = true
+		}
+		if len(received[path]) != len(expectedTitles) {
+			t.Errorf("expected %s to receive %d messages, got %d", path, len(expectedTitles), len(received[path]))
+		}
+		for _, expectedTitle := range expectedTitles {
+			if !titles[expectedTitle] {
+				t.Errorf("expected %s to receive a message with title '%s'", path, expectedTitle)
+			}
+		}
+	}
+	if messages := received["/pending"]; len(messages) != 0 {
+		t.Errorf("expected the subscriber who didn't confirm not to be notified, got %d messages", len(messages))
+	}
+}
+
+func TestStart_WithoutConfig(t *testing.T) {
+	Start(nil, nil)
+	// Announcing while the notifier isn't started must do nothing
+	Announce(&announcement.Announcement{Type: announcement.TypeInformation, Message: "Hello", Timestamp: time.Now()})
+	Shutdown()
+}
@@ -0,0 +1,97 @@
+package subscription
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+// ConfirmationTimeout is the duration during which a subscription can be confirmed, after which it is discarded
+const ConfirmationTimeout = 24 * time.Hour
+
+// ErrInvalidType is the error returned when the type of a subscription is neither TypeEmail nor TypeWebhook
+var ErrInvalidType = errors.New("subscription type must be either email or webhook")
+
+// Type is the type of subscription, which determines how the subscriber is notified
+type Type string
+
+var (
+	// TypeEmail is the type of subscription whose subscriber is notified by email
+	TypeEmail Type = "email"
+
+	// TypeWebhook is the type of subscription whose subscriber is notified through a POST request to a URL
+	TypeWebhook Type = "webhook"
+)
+
+// Subscription is the registration of a visitor who wants to be notified of the changes of state of the endpoints of
+// some or all groups, as well as of the announcements
+type Subscription struct {
+	// ID is the identifier of the subscription, which is set by the store when the subscription is inserted
+	ID int64
+
+	// Type is the type of subscription
+	Type Type
+
+	// Target is where the subscriber is notified, i.e. an email address or the URL of a webhook
+	Target string
+
+	// Groups are the groups the subscriber is notified of the changes of state of the endpoints of. If empty, the
+	// subscriber is notified of the changes of state of every endpoint.
+	Groups []string
+
+	// Confirmed is whether the subscriber confirmed the subscription. Only confirmed subscriptions are notified.
+	Confirmed bool
+
+	// ConfirmationToken is the secret token the subscriber confirms the subscription with
+	ConfirmationToken string
+
+	// UnsubscribeToken is the secret token the subscriber cancels the subscription with
+	UnsubscribeToken string
+
+	// Timestamp is when the subscription was created
+	Timestamp time.Time
+}
+
+// New creates a new subscription that has yet to be confirmed
+func New(subscriptionType Type, target string, groups []string) (*Subscription, error) {
+	confirmationToken, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+	unsubscribeToken, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+	return &Subscription{
+		Type:              subscriptionType,
+		Target:            target,
+		Groups:            groups,
+		ConfirmationToken: confirmationToken,
+		UnsubscribeToken:  unsubscribeToken,
+		Timestamp:         time.Now(),
+	}, nil
+}
+
+// IsInterestedIn returns whether the subscriber wants to be notified of the changes of state of the endpoints of the
+// group passed as parameter
+func (s *Subscription) IsInterestedIn(group string) bool {
+	if len(s.Groups) == 0 {
+		return true
+	}
+	for _, subscribedGroup := range s.Groups {
+		if subscribedGroup == group {
+			return true
+		}
+	}
+	return false
+}
+
+// generateToken generates a random token that is long enough not to be guessed
+func generateToken() (string, error) {
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
@@ -0,0 +1,43 @@
+package subscription
+
+import (
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	subscription, err := New(TypeEmail, "john.doe@example.org", []string{"core"})
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if subscription.Confirmed {
+		t.Error("expected a new subscription to have yet to be confirmed")
+	}
+	if len(subscription.ConfirmationToken) != 64 || len(subscription.UnsubscribeToken) != 64 || subscription.ConfirmationToken == subscription.UnsubscribeToken {
+		t.Errorf("expected two distinct tokens of 64 characters, got %s and %s", subscription.ConfirmationToken, subscription.UnsubscribeToken)
+	}
+	if subscription.Timestamp.IsZero() {
+		t.Error("expected the timestamp to be set")
+	}
+}
+
+func TestSubscription_IsInterestedIn(t *testing.T) {
+	scenarios := []struct {
+		Name           string
+		Groups         []string
+		Group          string
+		ExpectedResult bool
+	}{
+		{Name: "every-group", Groups: nil, Group: "core", ExpectedResult: true},
+		{Name: "subscribed-group", Groups: []string{"core", "frontend"}, Group: "frontend", ExpectedResult: true},
+		{Name: "other-group", Groups: []string{"core"}, Group: "frontend", ExpectedResult: false},
+		{Name: "no-group", Groups: []string{"core"}, Group: "", ExpectedResult: false},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			subscription := &Subscription{Type: TypeWebhook, Groups: scenario.Groups}
+			if result := subscription.IsInterestedIn(scenario.Group); result != scenario.ExpectedResult {
+				t.Errorf("expected %v, got %v", scenario.ExpectedResult, result)
+			}
+		})
+	}
+}
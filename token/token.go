@@ -0,0 +1,107 @@
+package token
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// Prefix is the prefix of every API token, which makes them easy to recognize, e.g. by secret scanners
+const Prefix = "gatus_"
+
+// Scope is what an API token grants access to
+type Scope string
+
+const (
+	// ScopeReadStatuses is the scope granting access to the statuses, uptimes, response times and other data that the
+	// dashboard reads
+	ScopeReadStatuses Scope = "read-statuses"
+
+	// ScopeOperate is the scope granting access to acting on the endpoints, such as checking them on demand, pausing
+	// them, resuming them, annotating changes and managing announcements, as well as to what ScopeReadStatuses does
+	ScopeOperate Scope = "operate"
+
+	// ScopePushExternal is the scope granting access to pushing the results of any external endpoint
+	ScopePushExternal Scope = "push-external"
+
+	// ScopeAdmin is the scope granting access to everything, including the administrative actions and the management
+	// of the API tokens
+	ScopeAdmin Scope = "admin"
+)
+
+// IsValid returns whether the scope is one of the supported scopes
+func (s Scope) IsValid() bool {
+	switch s {
+	case ScopeReadStatuses, ScopeOperate, ScopePushExternal, ScopeAdmin:
+		return true
+	}
+	return false
+}
+
+// Token is an API token granting automation access to the parts of the API covered by its scopes, without the
+// credentials of a user. Only the hash of the secret is kept, so the secret can't be retrieved after the token is
+// created.
+type Token struct {
+	// ID is the identifier of the token, which is set by the store when the token is inserted
+	ID int64 `json:"id"`
+
+	// Name describes what the token is used for, e.g. the name of the CI pipeline using it
+	Name string `json:"name"`
+
+	// Scopes are what the token grants access to
+	Scopes []Scope `json:"scopes"`
+
+	// Hash is the hash of the secret of the token, as returned by Hash
+	Hash string `json:"-"`
+
+	// Timestamp is when the token was created
+	Timestamp time.Time `json:"timestamp"`
+
+	// ExpiresAt is when the token stops granting access. If nil, the token never expires.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+	// RevokedAt is when the token was revoked through the API, if it was
+	RevokedAt *time.Time `json:"revokedAt,omitempty"`
+}
+
+// Generate returns a new secret, which is what the clients authenticate with, as well as its hash
+func Generate() (secret, hash string, err error) {
+	randomBytes := make([]byte, 32)
+	if _, err = rand.Read(randomBytes); err != nil {
+		return "", "", err
+	}
+	secret = Prefix + base64.RawURLEncoding.EncodeToString(randomBytes)
+	return secret, Hash(secret), nil
+}
+
+// Hash returns the hash of the secret passed as parameter.
+//
+// The secrets are long and random enough that, unlike passwords, they don't need a slow hash function to resist
+// brute-force attacks.
+func Hash(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(hash[:])
+}
+
+// IsToken returns whether the secret passed as parameter looks like the secret of an API token
+func IsToken(secret string) bool {
+	return strings.HasPrefix(secret, Prefix)
+}
+
+// IsActive returns whether the token still grants access at the time passed as parameter
+func (t *Token) IsActive(now time.Time) bool {
+	return (t.ExpiresAt == nil || now.Before(*t.ExpiresAt)) && (t.RevokedAt == nil || now.Before(*t.RevokedAt))
+}
+
+// HasScope returns whether the token grants access to the scope passed as parameter, which the admin scope always does
+func (t *Token) HasScope(scope Scope) bool {
+	for _, s := range t.Scopes {
+		if s == scope || s == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package watchdog
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/stream"
+)
+
+var (
+	// externalEndpointMutex is used to prevent a result pushed to an external endpoint and the failure recorded because
+	// no result was pushed within its expected interval from being handled at the same time
+	externalEndpointMutex sync.Mutex
+
+	lastExternalEndpointResultByKey = make(map[string]time.Time)
+)
+
+// HandleExternalEndpointResult stores the result of an external endpoint and takes care of the alerts to resolve and
+// alerts to trigger, unless currently in the maintenance window
+func HandleExternalEndpointResult(ee *endpoint.ExternalEndpoint, result *endpoint.Result, cfg *config.Config) error {
+	externalEndpointMutex.Lock()
+	defer externalEndpointMutex.Unlock()
+	convertedEndpoint := ee.ToEndpoint()
+	if err := store.Get().Insert(convertedEndpoint, result); err != nil {
+		return err
+	}
+	lastExternalEndpointResultByKey[ee.Key()] = result.Timestamp
+	stream.Publish(convertedEndpoint, result)
+	if !cfg.Maintenance.IsUnderMaintenance() {
+		HandleAlerting(convertedEndpoint, result, cfg.Alerting, cfg.Debug)
+		ee.NumberOfSuccessesInARow = convertedEndpoint.NumberOfSuccessesInARow
+		ee.NumberOfFailuresInARow = convertedEndpoint.NumberOfFailuresInARow
+	} else if cfg.Debug {
+		log.Println("[watchdog.HandleExternalEndpointResult] Not handling alerting because currently in the maintenance window")
+	}
+	return nil
+}
+
+// monitorExternalEndpoint records a failure for an external endpoint every time its expected interval elapses without
+// a result being pushed to it. The first interval starts when the monitoring starts.
+func monitorExternalEndpoint(ee *endpoint.ExternalEndpoint, cfg *config.Config, ctx context.Context) {
+	lastResultTimestamp := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Printf("[watchdog.monitorExternalEndpoint] Canceling current execution of group=%s; endpoint=%s", ee.Group, ee.Name)
+			return
+		case <-time.After(time.Until(lastResultTimestamp.Add(ee.ExpectedInterval))):
+			externalEndpointMutex.Lock()
+			if timestamp, exists := lastExternalEndpointResultByKey[ee.Key()]; exists && timestamp.After(lastResultTimestamp) {
+				lastResultTimestamp = timestamp
+			}
+			externalEndpointMutex.Unlock()
+			if time.Since(lastResultTimestamp) < ee.ExpectedInterval {
+				// A result was pushed in the meantime, so the window starts again from that result
+				continue
+			}
+			log.Printf("[watchdog.monitorExternalEndpoint] No result was pushed to group=%s; endpoint=%s within the expected interval of %s", ee.Group, ee.Name, ee.ExpectedInterval)
+			result := &endpoint.Result{
+				Timestamp: time.Now(),
+				Success:   false,
+				Errors:    []string{"no result was pushed within the expected interval of " + ee.ExpectedInterval.String()},
+			}
+			if err := HandleExternalEndpointResult(ee, result, cfg); err != nil {
+				log.Printf("[watchdog.monitorExternalEndpoint] Failed to insert result in storage: %s", err.Error())
+			}
+			lastResultTimestamp = result.Timestamp
+		}
+	}
+}
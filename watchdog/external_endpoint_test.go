@@ -0,0 +1,48 @@
+package watchdog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/maintenance"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+)
+
+func TestMonitorExternalEndpoint(t *testing.T) {
+	defer store.Get().Clear()
+	externalEndpoint := &endpoint.ExternalEndpoint{Name: "job", Group: "cron", Token: "token", ExpectedInterval: 100 * time.Millisecond}
+	cfg := &config.Config{
+		ExternalEndpoints: []*endpoint.ExternalEndpoint{externalEndpoint},
+		Maintenance:       &maintenance.Config{},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go monitorExternalEndpoint(externalEndpoint, cfg, ctx)
+	getResults := func() []*endpoint.Result {
+		status, err := store.Get().GetEndpointStatusByKey(externalEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, 10))
+		if err != nil {
+			return nil
+		}
+		return status.Results
+	}
+	time.Sleep(50 * time.Millisecond)
+	if err := HandleExternalEndpointResult(externalEndpoint, &endpoint.Result{Timestamp: time.Now(), Success: true}, cfg); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(70 * time.Millisecond)
+	if results := getResults(); len(results) != 1 || !results[0].Success {
+		t.Fatalf("expected only the pushed result, because it was pushed within the expected interval, got %d results", len(results))
+	}
+	time.Sleep(80 * time.Millisecond)
+	results := getResults()
+	if len(results) != 2 {
+		t.Fatalf("expected a failure to be recorded once the expected interval elapsed without a result, got %d results", len(results))
+	}
+	if results[1].Success || len(results[1].Errors) != 1 {
+		t.Errorf("expected the recorded result to be a failure with one error, got success=%v and errors=%v", results[1].Success, results[1].Errors)
+	}
+}
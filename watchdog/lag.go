@@ -0,0 +1,51 @@
+package watchdog
+
+import (
+	"sync"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+)
+
+var (
+	lastIterationByKey      = make(map[string]time.Time)
+	lastIterationByKeyMutex sync.RWMutex
+)
+
+// recordIteration records that an iteration of the loop monitoring the endpoint whose key is passed ended at the time
+// passed, whether the endpoint was evaluated or skipped
+func recordIteration(key string, now time.Time) {
+	lastIterationByKeyMutex.Lock()
+	defer lastIterationByKeyMutex.Unlock()
+	lastIterationByKey[key] = now
+}
+
+// clearIterations forgets every iteration recorded, which is done when the monitoring stops, since the loops whose
+// iterations were recorded are stopped along with it
+func clearIterations() {
+	lastIterationByKeyMutex.Lock()
+	defer lastIterationByKeyMutex.Unlock()
+	lastIterationByKey = make(map[string]time.Time)
+}
+
+// GetLag returns how late the loop monitoring the most behind of the endpoints passed as parameter is, which is how
+// long it has been since its last iteration, minus the interval of the endpoint.
+//
+// The loops are late when the endpoints wait for each other because of the monitoring lock, or when the evaluation of
+// the endpoints is stuck. The endpoints that are paused are skipped right away, so they're never late, whereas those
+// that are disabled, or whose monitoring hasn't started yet, are ignored.
+func GetLag(endpoints []*endpoint.Endpoint, now time.Time) time.Duration {
+	lastIterationByKeyMutex.RLock()
+	defer lastIterationByKeyMutex.RUnlock()
+	var lag time.Duration
+	for _, ep := range endpoints {
+		lastIteration, exists := lastIterationByKey[ep.Key()]
+		if !exists || !ep.IsEnabled() {
+			continue
+		}
+		if endpointLag := now.Sub(lastIteration) - ep.Interval; endpointLag > lag {
+			lag = endpointLag
+		}
+	}
+	return lag
+}
@@ -0,0 +1,33 @@
+package watchdog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+)
+
+func TestGetLag(t *testing.T) {
+	defer clearIterations()
+	disabled := false
+	endpoints := []*endpoint.Endpoint{
+		{Name: "on-time", Interval: time.Minute},
+		{Name: "late", Interval: time.Minute},
+		{Name: "not-started", Interval: time.Minute},
+		{Name: "disabled", Interval: time.Minute, Enabled: &disabled},
+	}
+	now := time.Now()
+	recordIteration(endpoints[0].Key(), now.Add(-30*time.Second))
+	recordIteration(endpoints[1].Key(), now.Add(-3*time.Minute))
+	recordIteration(endpoints[3].Key(), now.Add(-time.Hour))
+	if lag := GetLag(endpoints, now); lag != 2*time.Minute {
+		t.Errorf("expected lag of 2m, got %s", lag)
+	}
+	if lag := GetLag(endpoints[:1], now); lag != 0 {
+		t.Errorf("expected no lag, got %s", lag)
+	}
+	clearIterations()
+	if lag := GetLag(endpoints, now); lag != 0 {
+		t.Errorf("expected no lag once the iterations are cleared, got %s", lag)
+	}
+}
@@ -0,0 +1,39 @@
+package watchdog
+
+import (
+	"context"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/maintenance"
+	"github.com/TwiN/gatus/v5/lifecycle"
+)
+
+// maintenanceCheckInterval is the interval at which whether Gatus is under maintenance is checked, in order to
+// publish the lifecycle events of the maintenance window starting and ending
+const maintenanceCheckInterval = 30 * time.Second
+
+// monitorMaintenance publishes the lifecycle events of the maintenance window starting and ending until the context
+// is canceled
+func monitorMaintenance(maintenanceConfig *maintenance.Config, ctx context.Context) {
+	underMaintenance := maintenanceConfig.IsUnderMaintenance()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(maintenanceCheckInterval):
+			underMaintenance = publishMaintenanceTransition(maintenanceConfig, underMaintenance)
+		}
+	}
+}
+
+// publishMaintenanceTransition publishes the start or the end of the maintenance window if whether Gatus is under
+// maintenance differs from wasUnderMaintenance, and returns whether Gatus is under maintenance
+func publishMaintenanceTransition(maintenanceConfig *maintenance.Config, wasUnderMaintenance bool) bool {
+	underMaintenance := maintenanceConfig.IsUnderMaintenance()
+	if underMaintenance && !wasUnderMaintenance {
+		lifecycle.Publish(lifecycle.NewEvent(lifecycle.EventTypeMaintenanceStarted, "Maintenance window started"))
+	} else if !underMaintenance && wasUnderMaintenance {
+		lifecycle.Publish(lifecycle.NewEvent(lifecycle.EventTypeMaintenanceEnded, "Maintenance window ended"))
+	}
+	return underMaintenance
+}
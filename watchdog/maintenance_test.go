@@ -0,0 +1,68 @@
+package watchdog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/maintenance"
+	"github.com/TwiN/gatus/v5/lifecycle"
+)
+
+func TestPublishMaintenanceTransition(t *testing.T) {
+	underMaintenanceConfig := &maintenance.Config{Start: time.Now().UTC().Add(-time.Hour).Format("15:04"), Duration: 2 * time.Hour}
+	notUnderMaintenanceConfig := &maintenance.Config{Start: time.Now().UTC().Add(2 * time.Hour).Format("15:04"), Duration: time.Hour}
+	for _, maintenanceConfig := range []*maintenance.Config{underMaintenanceConfig, notUnderMaintenanceConfig} {
+		if err := maintenanceConfig.ValidateAndSetDefaults(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	events, unsubscribe := lifecycle.Subscribe()
+	defer unsubscribe()
+	scenarios := []struct {
+		name                string
+		maintenanceConfig   *maintenance.Config
+		wasUnderMaintenance bool
+		expectedEventType   lifecycle.EventType
+	}{
+		{
+			name:                "started",
+			maintenanceConfig:   underMaintenanceConfig,
+			wasUnderMaintenance: false,
+			expectedEventType:   lifecycle.EventTypeMaintenanceStarted,
+		},
+		{
+			name:                "still-under-maintenance",
+			maintenanceConfig:   underMaintenanceConfig,
+			wasUnderMaintenance: true,
+		},
+		{
+			name:                "ended",
+			maintenanceConfig:   notUnderMaintenanceConfig,
+			wasUnderMaintenance: true,
+			expectedEventType:   lifecycle.EventTypeMaintenanceEnded,
+		},
+		{
+			name:                "still-not-under-maintenance",
+			maintenanceConfig:   notUnderMaintenanceConfig,
+			wasUnderMaintenance: false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			underMaintenance := publishMaintenanceTransition(scenario.maintenanceConfig, scenario.wasUnderMaintenance)
+			if underMaintenance != (scenario.maintenanceConfig == underMaintenanceConfig) {
+				t.Errorf("expected underMaintenance to be %v", !underMaintenance)
+			}
+			select {
+			case event := <-events:
+				if event.Type != scenario.expectedEventType {
+					t.Errorf("expected event of type %q, got %s", scenario.expectedEventType, event.Type)
+				}
+			default:
+				if len(scenario.expectedEventType) > 0 {
+					t.Errorf("expected event of type %s to have been published", scenario.expectedEventType)
+				}
+			}
+		})
+	}
+}
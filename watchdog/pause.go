@@ -0,0 +1,32 @@
+package watchdog
+
+import (
+	"sync"
+)
+
+var (
+	pausedKeys      = make(map[string]bool)
+	pausedKeysMutex sync.RWMutex
+)
+
+// Pause stops the monitoring of the endpoint whose key is passed until Resume is called with the same key.
+// The results of the endpoint are kept, and the endpoint stays paused if the configuration is reloaded.
+func Pause(key string) {
+	pausedKeysMutex.Lock()
+	defer pausedKeysMutex.Unlock()
+	pausedKeys[key] = true
+}
+
+// Resume resumes the monitoring of the endpoint whose key is passed
+func Resume(key string) {
+	pausedKeysMutex.Lock()
+	defer pausedKeysMutex.Unlock()
+	delete(pausedKeys, key)
+}
+
+// IsPaused returns whether the monitoring of the endpoint whose key is passed is paused
+func IsPaused(key string) bool {
+	pausedKeysMutex.RLock()
+	defer pausedKeysMutex.RUnlock()
+	return pausedKeys[key]
+}
@@ -0,0 +1,21 @@
+package watchdog
+
+import "testing"
+
+func TestPauseAndResume(t *testing.T) {
+	defer Resume("core_frontend")
+	if IsPaused("core_frontend") {
+		t.Error("endpoints should not be paused by default")
+	}
+	Pause("core_frontend")
+	if !IsPaused("core_frontend") {
+		t.Error("the endpoint should have been paused")
+	}
+	if IsPaused("core_backend") {
+		t.Error("only the endpoint that was paused should be paused")
+	}
+	Resume("core_frontend")
+	if IsPaused("core_frontend") {
+		t.Error("the endpoint should have been resumed")
+	}
+}
@@ -2,6 +2,7 @@ package watchdog
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"sync"
 	"time"
@@ -11,8 +12,10 @@ import (
 	"github.com/TwiN/gatus/v5/config/connectivity"
 	"github.com/TwiN/gatus/v5/config/endpoint"
 	"github.com/TwiN/gatus/v5/config/maintenance"
+	"github.com/TwiN/gatus/v5/lifecycle"
 	"github.com/TwiN/gatus/v5/metrics"
 	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/stream"
 )
 
 var (
@@ -34,12 +37,23 @@ func Monitor(cfg *config.Config) {
 			go monitor(endpoint, cfg.Alerting, cfg.Maintenance, cfg.Connectivity, cfg.DisableMonitoringLock, cfg.Metrics, cfg.Debug, ctx)
 		}
 	}
+	for _, externalEndpoint := range cfg.ExternalEndpoints {
+		if externalEndpoint.IsEnabled() && externalEndpoint.ExpectedInterval > 0 {
+			go monitorExternalEndpoint(externalEndpoint, cfg, ctx)
+		}
+	}
+	if cfg.Maintenance != nil && cfg.Maintenance.IsEnabled() {
+		go monitorMaintenance(cfg.Maintenance, ctx)
+	}
 }
 
 // monitor a single endpoint in a loop
 func monitor(ep *endpoint.Endpoint, alertingConfig *alerting.Config, maintenanceConfig *maintenance.Config, connectivityConfig *connectivity.Config, disableMonitoringLock, enabledMetrics, debug bool, ctx context.Context) {
 	// Run it immediately on start
-	execute(ep, alertingConfig, maintenanceConfig, connectivityConfig, disableMonitoringLock, enabledMetrics, debug)
+	if !IsPaused(ep.Key()) {
+		execute(ep, alertingConfig, maintenanceConfig, connectivityConfig, disableMonitoringLock, enabledMetrics, debug)
+	}
+	recordIteration(ep.Key(), time.Now())
 	// Loop for the next executions
 	for {
 		select {
@@ -47,15 +61,32 @@ func monitor(ep *endpoint.Endpoint, alertingConfig *alerting.Config, maintenance
 			log.Printf("[watchdog.monitor] Canceling current execution of group=%s; endpoint=%s", ep.Group, ep.Name)
 			return
 		case <-time.After(ep.Interval):
+			if IsPaused(ep.Key()) {
+				if debug {
+					log.Printf("[watchdog.monitor] Skipping execution of group=%s; endpoint=%s because it is paused", ep.Group, ep.Name)
+				}
+				publishCheckSkipped(ep, lifecycle.SkipReasonPaused, "it is paused")
+				recordIteration(ep.Key(), time.Now())
+				continue
+			}
 			execute(ep, alertingConfig, maintenanceConfig, connectivityConfig, disableMonitoringLock, enabledMetrics, debug)
+			recordIteration(ep.Key(), time.Now())
 		}
 	}
 	// Just in case somebody wandered all the way to here and wonders, "what about ExternalEndpoints?"
 	// Alerting is checked every time an external endpoint is pushed to Gatus, so they're not monitored
-	// periodically like they are for normal endpoints.
+	// periodically like they are for normal endpoints, unless they have an expected interval, in which
+	// case monitorExternalEndpoint only makes sure that results keep being pushed.
+}
+
+// Execute evaluates the health of the endpoint passed right away, outside of its interval, and returns the result.
+// Like the periodic executions, the result is stored and alerting is handled. If Gatus has no connectivity, the
+// endpoint is not evaluated and nil is returned.
+func Execute(ep *endpoint.Endpoint, cfg *config.Config) *endpoint.Result {
+	return execute(ep, cfg.Alerting, cfg.Maintenance, cfg.Connectivity, cfg.DisableMonitoringLock, cfg.Metrics, cfg.Debug)
 }
 
-func execute(ep *endpoint.Endpoint, alertingConfig *alerting.Config, maintenanceConfig *maintenance.Config, connectivityConfig *connectivity.Config, disableMonitoringLock, enabledMetrics, debug bool) {
+func execute(ep *endpoint.Endpoint, alertingConfig *alerting.Config, maintenanceConfig *maintenance.Config, connectivityConfig *connectivity.Config, disableMonitoringLock, enabledMetrics, debug bool) *endpoint.Result {
 	if !disableMonitoringLock {
 		// By placing the lock here, we prevent multiple endpoints from being monitored at the exact same time, which
 		// could cause performance issues and return inaccurate results
@@ -65,7 +96,8 @@ func execute(ep *endpoint.Endpoint, alertingConfig *alerting.Config, maintenance
 	// If there's a connectivity checker configured, check if Gatus has internet connectivity
 	if connectivityConfig != nil && connectivityConfig.Checker != nil && !connectivityConfig.Checker.IsConnected() {
 		log.Println("[watchdog.execute] No connectivity; skipping execution")
-		return
+		publishCheckSkipped(ep, lifecycle.SkipReasonNoConnectivity, "Gatus has no connectivity")
+		return nil
 	}
 	if debug {
 		log.Printf("[watchdog.execute] Monitoring group=%s; endpoint=%s", ep.Group, ep.Name)
@@ -89,13 +121,23 @@ func execute(ep *endpoint.Endpoint, alertingConfig *alerting.Config, maintenance
 	if debug {
 		log.Printf("[watchdog.execute] Waiting for interval=%s before monitoring group=%s endpoint=%s again", ep.Interval, ep.Group, ep.Name)
 	}
+	return result
+}
+
+// publishCheckSkipped publishes the lifecycle event of the check of an endpoint being skipped for the reason passed
+func publishCheckSkipped(ep *endpoint.Endpoint, reason, explanation string) {
+	event := lifecycle.NewEvent(lifecycle.EventTypeCheckSkipped, fmt.Sprintf("Check of endpoint %s was skipped because %s", ep.DisplayName(), explanation))
+	event.WithEndpoint(ep.Key(), ep.Name, ep.Group).Reason = reason
+	lifecycle.Publish(event)
 }
 
 // UpdateEndpointStatuses updates the slice of endpoint statuses
 func UpdateEndpointStatuses(ep *endpoint.Endpoint, result *endpoint.Result) {
 	if err := store.Get().Insert(ep, result); err != nil {
 		log.Println("[watchdog.UpdateEndpointStatuses] Failed to insert result in storage:", err.Error())
+		return
 	}
+	stream.Publish(ep, result)
 }
 
 // Shutdown stops monitoring all endpoints
@@ -105,4 +147,5 @@ func Shutdown(cfg *config.Config) {
 		ep.Close()
 	}
 	cancelFunc()
+	clearIterations()
 }
@@ -13,6 +13,21 @@ const routes = [
         name: 'Details',
         component: Details,
     },
+    {
+        path: '/groups/:group',
+        name: 'Group',
+        component: Home,
+    },
+    {
+        path: '/tenants/:tenant',
+        name: 'Tenant',
+        component: Home,
+    },
+    {
+        path: '/tenants/:tenant/endpoints/:key',
+        name: 'TenantDetails',
+        component: Details,
+    },
 ];
 
 const router = createRouter({
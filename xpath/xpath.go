@@ -0,0 +1,337 @@
+package xpath
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html/charset"
+)
+
+var (
+	// ErrInvalidExpression is the error returned when an expression cannot be parsed
+	ErrInvalidExpression = errors.New("invalid xpath expression: expected an absolute location path such as /Envelope/Body/Price or //Price")
+
+	// ErrNodeNotFound is the error returned when no node matches the expression
+	ErrNodeNotFound = errors.New("no node matching the xpath expression was found")
+
+	nameRegex = regexp.MustCompile(`^([A-Za-z_][\w.-]*:)?[A-Za-z_][\w.-]*$`)
+)
+
+// Eval evaluates a subset of XPath 1.0 against an XML document and returns the value of the first node that matches
+// the expression, with leading and trailing whitespaces removed.
+//
+// Supported are absolute location paths made up of child (/) and descendant (//) steps, name tests, which match the
+// local name of elements regardless of their namespace prefix, the * wildcard, text() and @attribute as last step,
+// as well as predicates on the position ([1], [last()]), on an attribute ([@id], [@id='1']), on a child element
+// ([name], [name='john']) or on the text of the element ([text()='john']). The expression may also be wrapped in the
+// count() function, in which case the number of nodes matching the expression is returned.
+func Eval(expression string, body []byte) (string, error) {
+	steps, counting, err := parseExpression(expression)
+	if err != nil {
+		return "", err
+	}
+	document, err := parseDocument(body)
+	if err != nil {
+		return "", err
+	}
+	contexts := []*node{document}
+	for _, s := range steps[:len(steps)-1] {
+		contexts = s.selectNodes(contexts)
+	}
+	values := steps[len(steps)-1].selectValues(contexts)
+	if counting {
+		return strconv.Itoa(len(values)), nil
+	}
+	if len(values) == 0 {
+		return "", ErrNodeNotFound
+	}
+	return values[0], nil
+}
+
+// node is an element or a text node of an XML document. The document itself is a node without a name.
+type node struct {
+	name     string
+	text     string
+	isText   bool
+	attrs    []xml.Attr
+	parent   *node
+	children []*node
+}
+
+// stringValue returns the concatenation of the text of the node and of all of its descendants
+func (n *node) stringValue() string {
+	if n.isText {
+		return n.text
+	}
+	var builder strings.Builder
+	for _, child := range n.children {
+		builder.WriteString(child.stringValue())
+	}
+	return builder.String()
+}
+
+// attribute returns the value of the attribute of the node with the local name passed as parameter
+func (n *node) attribute(name string) (string, bool) {
+	for _, attr := range n.attrs {
+		if attr.Name.Local == name {
+			return attr.Value, true
+		}
+	}
+	return "", false
+}
+
+// descendants returns every element under the node, in document order
+func (n *node) descendants() []*node {
+	var descendants []*node
+	for _, child := range n.children {
+		if !child.isText {
+			descendants = append(descendants, child)
+			descendants = append(descendants, child.descendants()...)
+		}
+	}
+	return descendants
+}
+
+func parseDocument(body []byte) (*node, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	decoder.CharsetReader = charset.NewReaderLabel
+	document := &node{}
+	current := document
+	hasRoot := false
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error parsing XML: %w", err)
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			child := &node{name: t.Name.Local, attrs: t.Copy().Attr, parent: current}
+			current.children = append(current.children, child)
+			current = child
+			hasRoot = true
+		case xml.EndElement:
+			current = current.parent
+		case xml.CharData:
+			current.children = append(current.children, &node{text: string(t), isText: true, parent: current})
+		}
+	}
+	if !hasRoot {
+		return nil, errors.New("error parsing XML: document has no root element")
+	}
+	return document, nil
+}
+
+// predicate returns whether a node, at the given position among the size nodes selected by a step, should be kept
+type predicate func(n *node, position, size int) bool
+
+type step struct {
+	descendant bool
+	test       string
+	predicates []predicate
+}
+
+func (s step) matches(n *node) bool {
+	if s.test == "text()" {
+		// Text nodes made up only of whitespaces are formatting rather than content, so they're ignored
+		return n.isText && len(strings.TrimSpace(n.text)) > 0
+	}
+	return !n.isText && (s.test == "*" || n.name == localName(s.test))
+}
+
+// selectNodes returns the nodes selected by the step from each context node, in document order
+func (s step) selectNodes(contexts []*node) []*node {
+	var selected []*node
+	seen := make(map[*node]bool)
+	for _, context := range contexts {
+		parents := []*node{context}
+		if s.descendant {
+			parents = append(parents, context.descendants()...)
+		}
+		for _, parent := range parents {
+			var candidates []*node
+			for _, child := range parent.children {
+				if s.matches(child) {
+					candidates = append(candidates, child)
+				}
+			}
+			// Positions are relative to the nodes that passed the previous predicates, as per the XPath specification
+			for _, p := range s.predicates {
+				var filtered []*node
+				for i, candidate := range candidates {
+					if p(candidate, i+1, len(candidates)) {
+						filtered = append(filtered, candidate)
+					}
+				}
+				candidates = filtered
+			}
+			for _, candidate := range candidates {
+				if !seen[candidate] {
+					seen[candidate] = true
+					selected = append(selected, candidate)
+				}
+			}
+		}
+	}
+	return selected
+}
+
+// selectValues returns the values of the nodes selected by the step from each context node.
+// Unlike the other steps, the last step may select attributes.
+func (s step) selectValues(contexts []*node) []string {
+	var values []string
+	if strings.HasPrefix(s.test, "@") {
+		elements := contexts
+		if s.descendant {
+			elements = (step{descendant: true, test: "*"}).selectNodes(contexts)
+		}
+		for _, element := range elements {
+			if value, exists := element.attribute(localName(s.test[1:])); exists {
+				values = append(values, strings.TrimSpace(value))
+			}
+		}
+		return values
+	}
+	for _, n := range s.selectNodes(contexts) {
+		values = append(values, strings.TrimSpace(n.stringValue()))
+	}
+	return values
+}
+
+// parseExpression parses an expression into a list of steps, and returns whether the expression was wrapped in the
+// count function
+func parseExpression(expression string) ([]step, bool, error) {
+	expression = strings.TrimSpace(expression)
+	counting := false
+	if strings.HasPrefix(expression, "count(") && strings.HasSuffix(expression, ")") {
+		counting = true
+		expression = strings.TrimSpace(expression[len("count(") : len(expression)-1])
+	}
+	var steps []step
+	for i := 0; i < len(expression); {
+		if expression[i] != '/' {
+			return nil, false, ErrInvalidExpression
+		}
+		s := step{}
+		i++
+		if i < len(expression) && expression[i] == '/' {
+			s.descendant = true
+			i++
+		}
+		start := i
+		for i < len(expression) && expression[i] != '/' && expression[i] != '[' {
+			i++
+		}
+		s.test = strings.TrimSpace(expression[start:i])
+		if !isValidTest(s.test, true) {
+			return nil, false, ErrInvalidExpression
+		}
+		for i < len(expression) && expression[i] == '[' {
+			end := closingBracketIndex(expression, i)
+			if end == -1 {
+				return nil, false, ErrInvalidExpression
+			}
+			p, err := parsePredicate(strings.TrimSpace(expression[i+1 : end]))
+			if err != nil {
+				return nil, false, err
+			}
+			s.predicates = append(s.predicates, p)
+			i = end + 1
+		}
+		steps = append(steps, s)
+	}
+	if len(steps) == 0 {
+		return nil, false, ErrInvalidExpression
+	}
+	// Attributes and text nodes have no children, so they may only be selected by the last step
+	for _, s := range steps[:len(steps)-1] {
+		if s.test == "text()" || strings.HasPrefix(s.test, "@") {
+			return nil, false, ErrInvalidExpression
+		}
+	}
+	if strings.HasPrefix(steps[len(steps)-1].test, "@") && len(steps[len(steps)-1].predicates) > 0 {
+		return nil, false, ErrInvalidExpression
+	}
+	return steps, counting, nil
+}
+
+// parsePredicate parses the content of a predicate, e.g. 1, last(), @id='1' or name='john'
+func parsePredicate(content string) (predicate, error) {
+	if content == "last()" {
+		return func(_ *node, position, size int) bool {
+			return position == size
+		}, nil
+	}
+	if expectedPosition, err := strconv.Atoi(content); err == nil {
+		if expectedPosition < 1 {
+			return nil, ErrInvalidExpression
+		}
+		return func(_ *node, position, _ int) bool {
+			return position == expectedPosition
+		}, nil
+	}
+	test, expectedValue, hasExpectedValue := content, "", false
+	if equalSignIndex := strings.Index(content, "="); equalSignIndex != -1 {
+		test, expectedValue = strings.TrimSpace(content[:equalSignIndex]), strings.TrimSpace(content[equalSignIndex+1:])
+		if len(expectedValue) < 2 || (expectedValue[0] != '\'' && expectedValue[0] != '"') || expectedValue[len(expectedValue)-1] != expectedValue[0] {
+			return nil, ErrInvalidExpression
+		}
+		expectedValue = expectedValue[1 : len(expectedValue)-1]
+		hasExpectedValue = true
+	}
+	if !isValidTest(test, false) {
+		return nil, ErrInvalidExpression
+	}
+	s := step{test: test}
+	return func(n *node, _, _ int) bool {
+		for _, value := range s.selectValues([]*node{n}) {
+			if !hasExpectedValue || value == expectedValue {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// isValidTest checks whether the test of a step is valid. The wildcard is only allowed outside of predicates.
+func isValidTest(test string, allowWildcard bool) bool {
+	if test == "text()" || (allowWildcard && test == "*") {
+		return true
+	}
+	return nameRegex.MatchString(strings.TrimPrefix(test, "@"))
+}
+
+// closingBracketIndex returns the index of the bracket closing the one at the index passed as parameter, ignoring
+// brackets that are inside quotes, or -1 if there's none
+func closingBracketIndex(expression string, openingBracketIndex int) int {
+	var quote byte
+	for i := openingBracketIndex + 1; i < len(expression); i++ {
+		switch {
+		case quote != 0:
+			if expression[i] == quote {
+				quote = 0
+			}
+		case expression[i] == '\'' || expression[i] == '"':
+			quote = expression[i]
+		case expression[i] == ']':
+			return i
+		}
+	}
+	return -1
+}
+
+// localName removes the namespace prefix of a name, if any
+func localName(name string) string {
+	if index := strings.LastIndex(name, ":"); index != -1 {
+		return name[index+1:]
+	}
+	return name
+}
@@ -0,0 +1,195 @@
+package xpath
+
+import (
+	"testing"
+)
+
+const data = `<?xml version="1.0" encoding="UTF-8"?>
+<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+  <soap:Body>
+    <m:GetUsersResponse xmlns:m="https://example.org/users">
+      <m:User id="1" active="true">
+        <m:Name>john</m:Name>
+        <m:Gender>male</m:Gender>
+      </m:User>
+      <m:User id="2" active="false">
+        <m:Name>jane</m:Name>
+        <m:Gender>female</m:Gender>
+      </m:User>
+      <m:Total>2</m:Total>
+    </m:GetUsersResponse>
+  </soap:Body>
+</soap:Envelope>`
+
+func TestEval(t *testing.T) {
+	type Scenario struct {
+		Name           string
+		Expression     string
+		Data           string
+		ExpectedOutput string
+		ExpectedError  bool
+	}
+	scenarios := []Scenario{
+		{
+			Name:           "absolute-path",
+			Expression:     "/Envelope/Body/GetUsersResponse/Total",
+			Data:           data,
+			ExpectedOutput: "2",
+		},
+		{
+			Name:           "absolute-path-with-namespace-prefix",
+			Expression:     "/soap:Envelope/soap:Body/m:GetUsersResponse/m:Total",
+			Data:           data,
+			ExpectedOutput: "2",
+		},
+		{
+			Name:           "descendant",
+			Expression:     "//Name",
+			Data:           data,
+			ExpectedOutput: "john",
+		},
+		{
+			Name:           "position",
+			Expression:     "//User[2]/Name",
+			Data:           data,
+			ExpectedOutput: "jane",
+		},
+		{
+			Name:           "last",
+			Expression:     "//User[last()]/Gender",
+			Data:           data,
+			ExpectedOutput: "female",
+		},
+		{
+			Name:           "attribute",
+			Expression:     "//User[2]/@id",
+			Data:           data,
+			ExpectedOutput: "2",
+		},
+		{
+			Name:           "descendant-attribute",
+			Expression:     "//@active",
+			Data:           data,
+			ExpectedOutput: "true",
+		},
+		{
+			Name:           "attribute-predicate",
+			Expression:     "//User[@id='2']/Name",
+			Data:           data,
+			ExpectedOutput: "jane",
+		},
+		{
+			Name:           "child-predicate",
+			Expression:     `//User[Name="jane"]/@active`,
+			Data:           data,
+			ExpectedOutput: "false",
+		},
+		{
+			Name:           "multiple-predicates",
+			Expression:     "//User[@active][2]/Name",
+			Data:           data,
+			ExpectedOutput: "jane",
+		},
+		{
+			Name:           "text",
+			Expression:     "//User[1]/Name/text()",
+			Data:           data,
+			ExpectedOutput: "john",
+		},
+		{
+			Name:           "text-predicate",
+			Expression:     "//Gender[text()='female']",
+			Data:           data,
+			ExpectedOutput: "female",
+		},
+		{
+			Name:           "wildcard",
+			Expression:     "//User[1]/*",
+			Data:           data,
+			ExpectedOutput: "john",
+		},
+		{
+			Name:           "count",
+			Expression:     "count(//User)",
+			Data:           data,
+			ExpectedOutput: "2",
+		},
+		{
+			Name:           "count-without-match",
+			Expression:     "count(//Fault)",
+			Data:           data,
+			ExpectedOutput: "0",
+		},
+		{
+			Name:           "count-with-predicate",
+			Expression:     "count(//User[@active='true'])",
+			Data:           data,
+			ExpectedOutput: "1",
+		},
+		{
+			Name:          "no-match",
+			Expression:    "//Fault",
+			Data:          data,
+			ExpectedError: true,
+		},
+		{
+			Name:          "relative-path",
+			Expression:    "Envelope/Body",
+			Data:          data,
+			ExpectedError: true,
+		},
+		{
+			Name:          "empty-expression",
+			Expression:    "",
+			Data:          data,
+			ExpectedError: true,
+		},
+		{
+			Name:          "unclosed-predicate",
+			Expression:    "//User[1",
+			Data:          data,
+			ExpectedError: true,
+		},
+		{
+			Name:          "invalid-predicate",
+			Expression:    "//User[@id=2]",
+			Data:          data,
+			ExpectedError: true,
+		},
+		{
+			Name:          "attribute-before-last-step",
+			Expression:    "//@id/Name",
+			Data:          data,
+			ExpectedError: true,
+		},
+		{
+			Name:          "invalid-data",
+			Expression:    "//Name",
+			Data:          `{"name": "john"}`,
+			ExpectedError: true,
+		},
+		{
+			Name:          "malformed-data",
+			Expression:    "//Name",
+			Data:          `<User><Name>john</User>`,
+			ExpectedError: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			output, err := Eval(scenario.Expression, []byte(scenario.Data))
+			if scenario.ExpectedError {
+				if err == nil {
+					t.Errorf("Expected error, got '%v'", output)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got '%v'", err)
+			}
+			if output != scenario.ExpectedOutput {
+				t.Errorf("Expected output to be %v, but was %v", scenario.ExpectedOutput, output)
+			}
+		})
+	}
+}